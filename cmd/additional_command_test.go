@@ -1,12 +1,17 @@
 package main
 
 import (
+	"archive/zip"
 	"bytes"
+	encodingcsv "encoding/csv"
 	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
+
+	"github.com/rhino11/pivot/internal"
 )
 
 // TestInitCommandWithImport tests init command with --import flag
@@ -333,6 +338,240 @@ func TestCSVExportCommandWithOptions(t *testing.T) {
 	}
 }
 
+// TestCSVExportCommandFromDatabase tests that the agile fields of an issue created
+// from a file survive the round trip through the database into an exported CSV
+func TestCSVExportCommandFromDatabase(t *testing.T) {
+	setupConfiguredDBTest(t, "org", "alpha")
+
+	issueContent := `---
+title: Local story
+story_points: 5
+epic: Reporting
+---
+Build the weekly report.
+
+Estimated Hours: 12
+Acceptance Criteria:
+- Report lists open issues
+- Report is emailed
+`
+	if err := os.WriteFile("story.md", []byte(issueContent), 0600); err != nil {
+		t.Fatalf("Failed to write issue file: %v", err)
+	}
+
+	output := &bytes.Buffer{}
+	cmd := NewRootCommand()
+	cmd.SetOut(output)
+	cmd.SetErr(output)
+	cmd.SetArgs([]string{"create", "--from-file", "story.md"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Create command failed: %v\nOutput: %s", err, output.String())
+	}
+
+	output.Reset()
+	cmd = NewRootCommand()
+	cmd.SetOut(output)
+	cmd.SetErr(output)
+	cmd.SetArgs([]string{"export", "csv", "--fields", "title,story_points,estimated_hours,epic,acceptance_criteria"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("CSV export command failed: %v", err)
+	}
+	if !strings.Contains(output.String(), "Exported 1 issues") {
+		t.Errorf("Expected output to contain 'Exported 1 issues', got: %s", output.String())
+	}
+
+	content, err := os.ReadFile("issues.csv")
+	if err != nil {
+		t.Fatalf("Failed to read exported CSV: %v", err)
+	}
+	expected := "Local story,5,12,Reporting,\"- Report lists open issues\n- Report is emailed\""
+	if !strings.Contains(string(content), expected) {
+		t.Errorf("Expected exported row %q, got: %s", expected, content)
+	}
+}
+
+// TestCSVImportPreviewLatin1 tests previewing a Latin-1 encoded CSV with --encoding
+func TestCSVImportPreviewLatin1(t *testing.T) {
+	csvFile := filepath.Join(t.TempDir(), "legacy.csv")
+	// "Café" in Latin-1: é is the single byte 0xE9
+	if err := os.WriteFile(csvFile, []byte("title,state\nCaf\xe9,open\n"), 0644); err != nil {
+		t.Fatalf("Failed to create test CSV: %v", err)
+	}
+
+	output := &bytes.Buffer{}
+	cmd := NewRootCommand()
+	cmd.SetOut(output)
+	cmd.SetErr(output)
+	cmd.SetArgs([]string{"import", "csv", "--preview", "--encoding", "latin1", csvFile})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("CSV import preview failed: %v", err)
+	}
+	if !strings.Contains(output.String(), "Café") {
+		t.Errorf("Expected decoded title 'Café', got: %s", output.String())
+	}
+}
+
+// TestCSVImportPreviewMultipleFiles tests merging several CSV files with a duplicate title
+func TestCSVImportPreviewMultipleFiles(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.csv")
+	second := filepath.Join(dir, "second.csv")
+	if err := os.WriteFile(first, []byte("title\nLogin page\nSignup page\n"), 0644); err != nil {
+		t.Fatalf("Failed to create test CSV: %v", err)
+	}
+	if err := os.WriteFile(second, []byte("title\nLogin page\nPassword reset\n"), 0644); err != nil {
+		t.Fatalf("Failed to create test CSV: %v", err)
+	}
+
+	output := &bytes.Buffer{}
+	cmd := NewRootCommand()
+	cmd.SetOut(output)
+	cmd.SetErr(output)
+	cmd.SetArgs([]string{"import", "csv", "--preview", first, second})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("CSV import preview failed: %v", err)
+	}
+	out := output.String()
+	if !strings.Contains(out, "Parsed 4 issues from 2 CSV files") {
+		t.Errorf("Expected combined parse count, got: %s", out)
+	}
+	if !strings.Contains(out, "Skipping duplicate issue 'Login page'") {
+		t.Errorf("Expected duplicate notice, got: %s", out)
+	}
+	if !strings.Contains(out, "3. Password reset") {
+		t.Errorf("Expected merged preview of 3 issues, got: %s", out)
+	}
+}
+
+// TestCSVImportPreviewStateMap tests state synonyms and the unknown state warning
+func TestCSVImportPreviewStateMap(t *testing.T) {
+	csvFile := filepath.Join(t.TempDir(), "states.csv")
+	if err := os.WriteFile(csvFile, []byte("title,state\nShipped,done\nParked,blocked\n"), 0644); err != nil {
+		t.Fatalf("Failed to create test CSV: %v", err)
+	}
+
+	output := &bytes.Buffer{}
+	cmd := NewRootCommand()
+	cmd.SetOut(output)
+	cmd.SetErr(output)
+	cmd.SetArgs([]string{"import", "csv", "--preview", "--state-map", "done=closed", csvFile})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("CSV import preview failed: %v", err)
+	}
+	out := output.String()
+	if !strings.Contains(out, "Shipped [closed]") || !strings.Contains(out, "Parked [open]") {
+		t.Errorf("Expected normalized states in preview, got: %s", out)
+	}
+	if !strings.Contains(out, "⚠ line 3: unknown state 'blocked', using 'open'") {
+		t.Errorf("Expected unknown state warning, got: %s", out)
+	}
+}
+
+// TestCSVImportMappingPreview tests that --mapping-preview shows the resolved mapping and exits
+func TestCSVImportMappingPreview(t *testing.T) {
+	dir := t.TempDir()
+	csvFile := filepath.Join(dir, "jira.csv")
+	if err := os.WriteFile(csvFile, []byte("Summary,Status,Sprint\nFix login,done,12\n"), 0644); err != nil {
+		t.Fatalf("Failed to create test CSV: %v", err)
+	}
+	mapFile := filepath.Join(dir, "mapping.yml")
+	if err := os.WriteFile(mapFile, []byte("columns:\n  Status: state\ndefaults:\n  labels: imported\n"), 0644); err != nil {
+		t.Fatalf("Failed to create mapping file: %v", err)
+	}
+
+	output := &bytes.Buffer{}
+	cmd := NewRootCommand()
+	cmd.SetOut(output)
+	cmd.SetErr(output)
+	cmd.SetArgs([]string{"import", "csv", "--map", "Summary=title", "--map-file", mapFile, "--mapping-preview", csvFile})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Mapping preview failed: %v", err)
+	}
+	out := output.String()
+	for _, want := range []string{
+		"Summary → title (mapped)",
+		"Status → state (mapped)",
+		"Sprint → (unmapped)",
+		"labels = imported",
+		"⚠ 1 unmapped columns will not be imported: Sprint",
+		"Run without --mapping-preview to import.",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q, got: %s", want, out)
+		}
+	}
+	if strings.Contains(out, "Parsing CSV data") {
+		t.Errorf("Expected mapping preview to exit before parsing, got: %s", out)
+	}
+}
+
+// TestCSVImportMappingPreviewRepositoryColumn tests that the repository column is not reported as unmapped
+func TestCSVImportMappingPreviewRepositoryColumn(t *testing.T) {
+	csvFile := filepath.Join(t.TempDir(), "issues.csv")
+	if err := os.WriteFile(csvFile, []byte("title,repo\nFix login,acme/api\n"), 0644); err != nil {
+		t.Fatalf("Failed to create test CSV: %v", err)
+	}
+
+	output := &bytes.Buffer{}
+	cmd := NewRootCommand()
+	cmd.SetOut(output)
+	cmd.SetErr(output)
+	cmd.SetArgs([]string{"import", "csv", "--repository-from-column", "repo", "--mapping-preview", csvFile})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Mapping preview failed: %v", err)
+	}
+	out := output.String()
+	for _, want := range []string{"repo → repository"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q, got: %s", want, out)
+		}
+	}
+	if strings.Contains(out, "unmapped") {
+		t.Errorf("Expected no unmapped columns, got: %s", out)
+	}
+}
+
+// TestCSVImportNegativeDelay tests rejection of a negative --delay
+func TestCSVImportNegativeDelay(t *testing.T) {
+	csvFile := filepath.Join(t.TempDir(), "issues.csv")
+	if err := os.WriteFile(csvFile, []byte("title\nIssue\n"), 0644); err != nil {
+		t.Fatalf("Failed to create test CSV: %v", err)
+	}
+
+	cmd := NewRootCommand()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"import", "csv", "--delay", "-1s", csvFile})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "--delay must not be negative") {
+		t.Errorf("Expected negative delay error, got: %v", err)
+	}
+}
+
+// TestCSVImportInvalidEncoding tests rejection of unsupported encodings
+func TestCSVImportInvalidEncoding(t *testing.T) {
+	csvFile := filepath.Join(t.TempDir(), "issues.csv")
+	if err := os.WriteFile(csvFile, []byte("title\nIssue\n"), 0644); err != nil {
+		t.Fatalf("Failed to create test CSV: %v", err)
+	}
+
+	cmd := NewRootCommand()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"import", "csv", "--encoding", "ebcdic", csvFile})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "invalid encoding") {
+		t.Errorf("Expected invalid encoding error, got: %v", err)
+	}
+}
+
 // TestCSVImportPreview tests CSV import with preview flag
 func TestCSVImportPreview(t *testing.T) {
 	tempDir := t.TempDir()
@@ -421,6 +660,129 @@ Test Issue 2,closed,Another test issue,feature`
 	}
 }
 
+// TestCSVImportDryRunRepositoryFromColumn tests that a dry run names each row's repository
+func TestCSVImportDryRunRepositoryFromColumn(t *testing.T) {
+	csvFile := filepath.Join(t.TempDir(), "test.csv")
+	csvContent := "title,repo\nAPI bug,acme/api\nWeb bug,acme/web\nShared task,\n"
+	if err := os.WriteFile(csvFile, []byte(csvContent), 0644); err != nil {
+		t.Fatalf("Failed to create test CSV: %v", err)
+	}
+
+	output := &bytes.Buffer{}
+	cmd := NewRootCommand()
+	cmd.SetOut(output)
+	cmd.SetErr(output)
+	cmd.SetArgs([]string{"import", "csv", "--dry-run", "--repository-from-column", "repo", "--repository", "acme/web", csvFile})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("CSV import dry-run failed: %v", err)
+	}
+
+	for _, want := range []string{"Would create in acme/api: API bug", "Would create in acme/web: Web bug", "Would create in acme/web: Shared task"} {
+		if !strings.Contains(output.String(), want) {
+			t.Errorf("Expected output to contain %q, got: %s", want, output.String())
+		}
+	}
+
+	// An invalid repository value fails before anything is imported
+	if err := os.WriteFile(csvFile, []byte("title,repo\nAPI bug,acme\n"), 0644); err != nil {
+		t.Fatalf("Failed to create test CSV: %v", err)
+	}
+	cmd = NewRootCommand()
+	cmd.SetOut(output)
+	cmd.SetErr(output)
+	cmd.SetArgs([]string{"import", "csv", "--dry-run", "--repository-from-column", "repo", csvFile})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "invalid repository 'acme'") {
+		t.Errorf("Expected an invalid repository error, got: %v", err)
+	}
+}
+
+// TestCSVImportDryRunBodyFromFileColumn tests that body files are resolved next to the CSV
+// and that a missing one fails the import with its line
+func TestCSVImportDryRunBodyFromFileColumn(t *testing.T) {
+	dir := t.TempDir()
+	csvFile := filepath.Join(dir, "test.csv")
+	if err := os.WriteFile(filepath.Join(dir, "login.md"), []byte("Steps to reproduce"), 0644); err != nil {
+		t.Fatalf("Failed to create body file: %v", err)
+	}
+	if err := os.WriteFile(csvFile, []byte("title,body_file\nLogin fails,login.md\nNo file,\n"), 0644); err != nil {
+		t.Fatalf("Failed to create test CSV: %v", err)
+	}
+
+	run := func() (string, error) {
+		output := &bytes.Buffer{}
+		cmd := NewRootCommand()
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetArgs([]string{"import", "csv", "--dry-run", "--repository", "acme/api", "--body-from-file-column", "body_file", csvFile})
+		err := cmd.Execute()
+		return output.String(), err
+	}
+
+	output, err := run()
+	if err != nil {
+		t.Fatalf("CSV import dry-run failed: %v\n%s", err, output)
+	}
+	if !strings.Contains(output, "Would create: Login fails") || !strings.Contains(output, "Would create: No file") {
+		t.Errorf("Expected both rows to be imported, got: %s", output)
+	}
+
+	if err := os.WriteFile(csvFile, []byte("title,body_file\nLogin fails,login.md\nCrash,crash.md\n"), 0644); err != nil {
+		t.Fatalf("Failed to create test CSV: %v", err)
+	}
+	if output, err := run(); err == nil || !strings.Contains(err.Error(), "line 3, column 2 (body_file): body file 'crash.md' not found") {
+		t.Errorf("Expected a missing body file error naming the row, got: %v\n%s", err, output)
+	}
+}
+
+// TestCSVImportPreviewCount tests that --preview-count limits the previewed issues in row order
+func TestCSVImportPreviewCount(t *testing.T) {
+	csvFile := filepath.Join(t.TempDir(), "test.csv")
+	csvContent := "title,state\nZulu,open\nAlpha,open\nMike,closed\nBravo,open\n"
+	if err := os.WriteFile(csvFile, []byte(csvContent), 0644); err != nil {
+		t.Fatalf("Failed to create test CSV: %v", err)
+	}
+
+	run := func(args ...string) (string, error) {
+		output := &bytes.Buffer{}
+		cmd := NewRootCommand()
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetArgs(append(append([]string{"import", "csv"}, args...), csvFile))
+		err := cmd.Execute()
+		return output.String(), err
+	}
+
+	// --preview-count implies --preview
+	output, err := run("--preview-count", "2")
+	if err != nil {
+		t.Fatalf("CSV import preview failed: %v\n%s", err, output)
+	}
+	if !strings.Contains(output, "1. Zulu [open]") || !strings.Contains(output, "2. Alpha [open]") || strings.Contains(output, "Mike") {
+		t.Errorf("Expected the first 2 rows in row order, got: %s", output)
+	}
+	if !strings.Contains(output, "... and 2 more issues") {
+		t.Errorf("Expected the remaining 2 issues to be reported, got: %s", output)
+	}
+
+	// Repeated previews are identical
+	again, _ := run("--preview-count", "2")
+	if again != output {
+		t.Errorf("Expected a stable preview, got:\n%s\nthen:\n%s", output, again)
+	}
+
+	output, err = run("--preview", "--preview-count", "0")
+	if err != nil {
+		t.Fatalf("CSV import preview failed: %v\n%s", err, output)
+	}
+	if !strings.Contains(output, "4. Bravo [open]") || strings.Contains(output, "more issues") {
+		t.Errorf("Expected all 4 issues without a remainder line, got: %s", output)
+	}
+
+	if _, err := run("--preview-count", "-1"); err == nil || !strings.Contains(err.Error(), "--preview-count must not be negative") {
+		t.Errorf("Expected error for a negative count, got %v", err)
+	}
+}
+
 // TestCSVImportFileNotFound tests CSV import with non-existent file
 func TestCSVImportFileNotFound(t *testing.T) {
 	output := &bytes.Buffer{}
@@ -475,3 +837,726 @@ database: ./test.db`
 		t.Logf("Sync failed as expected with legacy config: %v", err)
 	}
 }
+
+// TestExportCustomCommandInvalidTemplate tests that the template is validated before exporting
+func TestExportCustomCommandInvalidTemplate(t *testing.T) {
+	tempDir := t.TempDir()
+	templateFile := filepath.Join(tempDir, "bad.tmpl")
+	if err := os.WriteFile(templateFile, []byte("{{.Issues"), 0600); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	output := &bytes.Buffer{}
+	cmd := NewRootCommand()
+	cmd.SetOut(output)
+	cmd.SetErr(output)
+	cmd.SetArgs([]string{"export", "custom", "--template", templateFile})
+
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal("Expected error for invalid template")
+	}
+	if !strings.Contains(err.Error(), "invalid template") {
+		t.Errorf("Expected invalid template error, got: %v", err)
+	}
+}
+
+// TestExportCustomCommandZip tests exporting two projects into a zip archive
+func TestExportCustomCommandZip(t *testing.T) {
+	tempDir := t.TempDir()
+	oldDir, _ := os.Getwd()
+	defer func() {
+		if err := os.Chdir(oldDir); err != nil {
+			t.Logf("Warning: Failed to change back to original directory: %v", err)
+		}
+	}()
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+
+	configContent := `global:
+  database: ./pivot.db
+  token: test_token
+projects:
+  - owner: org
+    repo: alpha
+  - owner: org
+    repo: beta`
+	if err := os.WriteFile("config.yml", []byte(configContent), 0600); err != nil {
+		t.Fatalf("Failed to create test config: %v", err)
+	}
+
+	db, err := internal.InitMultiProjectDBFromPath("./pivot.db")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	for i, repo := range []string{"alpha", "beta"} {
+		projectID, err := internal.CreateProject(db, &internal.ProjectConfig{Owner: "org", Repo: repo})
+		if err != nil {
+			t.Fatalf("Failed to create project: %v", err)
+		}
+		issue := &internal.DBIssue{ID: i + 1, Number: 1, Title: "Issue in " + repo, State: "open"}
+		if err := internal.SaveIssue(db, projectID, issue); err != nil {
+			t.Fatalf("Failed to save issue: %v", err)
+		}
+	}
+	db.Close()
+
+	if err := os.WriteFile("report.md.tmpl", []byte("{{range .Issues}}- {{.Title}}\n{{end}}"), 0600); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	output := &bytes.Buffer{}
+	cmd := NewRootCommand()
+	cmd.SetOut(output)
+	cmd.SetErr(output)
+	cmd.SetArgs([]string{"export", "custom", "--template", "report.md.tmpl", "--split-by", "project", "--zip", "reports.zip"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	reader, err := zip.OpenReader("reports.zip")
+	if err != nil {
+		t.Fatalf("Failed to open archive: %v", err)
+	}
+	defer reader.Close()
+
+	var names []string
+	for _, file := range reader.File {
+		names = append(names, file.Name)
+	}
+	expected := "report-org-alpha.md,report-org-beta.md,manifest.json"
+	if strings.Join(names, ",") != expected {
+		t.Errorf("Expected entries %s, got %s", expected, strings.Join(names, ","))
+	}
+}
+
+// TestExportCustomCommandInvalidSplitBy tests rejection of unsupported split-by values
+func TestExportCustomCommandInvalidSplitBy(t *testing.T) {
+	output := &bytes.Buffer{}
+	cmd := NewRootCommand()
+	cmd.SetOut(output)
+	cmd.SetErr(output)
+	cmd.SetArgs([]string{"export", "custom", "--template", "report.tmpl", "--split-by", "label"})
+
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal("Expected error for unsupported split-by value")
+	}
+	if !strings.Contains(err.Error(), "invalid split-by value") {
+		t.Errorf("Expected split-by error, got: %v", err)
+	}
+}
+
+// TestSyncCommandAdHocValidation tests flag validation for ad-hoc sync without a config file
+func TestSyncCommandAdHocValidation(t *testing.T) {
+	tempDir := t.TempDir()
+	oldDir, _ := os.Getwd()
+	defer func() {
+		if err := os.Chdir(oldDir); err != nil {
+			t.Logf("Warning: Failed to change back to original directory: %v", err)
+		}
+	}()
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"sync", "--repo", "invalid_format", "--token", "test_token"}, "must be in format 'owner/repo'"},
+		{[]string{"sync", "--repo", "owner/repo"}, "token is required"},
+	}
+
+	for _, tt := range tests {
+		output := &bytes.Buffer{}
+		cmd := NewRootCommand()
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetArgs(tt.args)
+
+		err := cmd.Execute()
+		if err == nil {
+			t.Errorf("Expected error for args %v", tt.args)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.expected) {
+			t.Errorf("Expected error containing %q, got: %v", tt.expected, err)
+		}
+	}
+}
+
+// TestCommandErrorsScrubToken tests that tokens never appear in command errors
+func TestCommandErrorsScrubToken(t *testing.T) {
+	tempDir := t.TempDir()
+	oldDir, _ := os.Getwd()
+	defer func() {
+		if err := os.Chdir(oldDir); err != nil {
+			t.Logf("Warning: Failed to change back to original directory: %v", err)
+		}
+	}()
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+
+	token := "ghp_" + strings.Repeat("Zz09", 9)
+	output := &bytes.Buffer{}
+	cmd := NewRootCommand()
+	cmd.SetOut(output)
+	cmd.SetErr(output)
+	// The token is echoed back in the repository format error
+	cmd.SetArgs([]string{"sync", "--repo", token, "--token", token})
+
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal("Expected error for invalid repository")
+	}
+	if strings.Contains(err.Error(), token) || strings.Contains(output.String(), token) {
+		t.Errorf("Expected token to be scrubbed, got error %q and output %q", err.Error(), output.String())
+	}
+}
+
+// TestListCommandDerivedStates tests that mapped labels are shown as derived states
+func TestListCommandDerivedStates(t *testing.T) {
+	tempDir := t.TempDir()
+	oldDir, _ := os.Getwd()
+	defer func() {
+		if err := os.Chdir(oldDir); err != nil {
+			t.Logf("Warning: Failed to change back to original directory: %v", err)
+		}
+	}()
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+
+	configContent := `global:
+  database: ./pivot.db
+state_mapping:
+  - labels: [in-progress]
+    state: in_progress
+projects:
+  - owner: org
+    repo: alpha`
+	if err := os.WriteFile("config.yml", []byte(configContent), 0600); err != nil {
+		t.Fatalf("Failed to create test config: %v", err)
+	}
+
+	db, err := internal.InitMultiProjectDBFromPath("./pivot.db")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	projectID, err := internal.CreateProject(db, &internal.ProjectConfig{Owner: "org", Repo: "alpha"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	for _, issue := range []internal.DBIssue{
+		{ID: 1, Number: 1, Title: "Working on it", State: "open", Labels: "in-progress"},
+		{ID: 2, Number: 2, Title: "Awaiting review", State: "open", Labels: "in-review"},
+		{ID: 3, Number: 3, Title: "Finished", State: "closed", Labels: "in-progress"},
+	} {
+		issue := issue
+		if err := internal.SaveIssue(db, projectID, &issue); err != nil {
+			t.Fatalf("Failed to save issue: %v", err)
+		}
+	}
+	db.Close()
+
+	output := &bytes.Buffer{}
+	cmd := NewRootCommand()
+	cmd.SetOut(output)
+	cmd.SetErr(output)
+	cmd.SetArgs([]string{"list", "--map-state", "in-review=in_review"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+
+	lines := strings.Split(output.String(), "\n")
+	expected := map[int]string{0: "in_progress", 1: "in_review", 2: "closed"}
+	for i, state := range expected {
+		fields := strings.Fields(lines[i])
+		if len(fields) < 2 || fields[1] != state {
+			t.Errorf("Expected line %d to show state %s, got %q", i, state, lines[i])
+		}
+	}
+}
+
+// TestListCommandColumns tests column selection and truncation in list output
+func TestListCommandColumns(t *testing.T) {
+	tempDir := t.TempDir()
+	oldDir, _ := os.Getwd()
+	defer func() {
+		if err := os.Chdir(oldDir); err != nil {
+			t.Logf("Warning: Failed to change back to original directory: %v", err)
+		}
+	}()
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+
+	configContent := `global:
+  database: ./pivot.db
+projects:
+  - owner: org
+    repo: alpha`
+	if err := os.WriteFile("config.yml", []byte(configContent), 0600); err != nil {
+		t.Fatalf("Failed to create test config: %v", err)
+	}
+
+	db, err := internal.InitMultiProjectDBFromPath("./pivot.db")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	projectID, err := internal.CreateProject(db, &internal.ProjectConfig{Owner: "org", Repo: "alpha"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	issue := internal.DBIssue{ID: 1, Number: 1, Title: "Investigate the intermittent failure in nightly builds", State: "open", Labels: "bug"}
+	if err := internal.SaveIssue(db, projectID, &issue); err != nil {
+		t.Fatalf("Failed to save issue: %v", err)
+	}
+	db.Close()
+
+	output := &bytes.Buffer{}
+	cmd := NewRootCommand()
+	cmd.SetOut(output)
+	cmd.SetErr(output)
+	cmd.SetArgs([]string{"list", "--columns", "labels,number,title", "--max-width", "15"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+
+	lines := strings.Split(output.String(), "\n")
+	if fields := strings.Fields(lines[0]); strings.Join(fields, " ") != "LABELS NUMBER TITLE" {
+		t.Errorf("Expected selected columns in order, got %q", lines[0])
+	}
+	if fields := strings.Fields(lines[1]); len(fields) < 3 || fields[0] != "bug" || fields[1] != "#1" {
+		t.Errorf("Expected row values in column order, got %q", lines[1])
+	}
+	if !strings.Contains(lines[1], "Investigate th…") || strings.Contains(lines[1], "nightly") {
+		t.Errorf("Expected title truncated to 15 characters, got %q", lines[1])
+	}
+
+	cmd = NewRootCommand()
+	cmd.SetOut(output)
+	cmd.SetErr(output)
+	cmd.SetArgs([]string{"list", "--columns", "number,priority"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "invalid column") {
+		t.Errorf("Expected invalid column error, got %v", err)
+	}
+}
+
+// TestResolveCommandSingleIssue tests resolving one conflicted issue by number
+func TestResolveCommandSingleIssue(t *testing.T) {
+	setupConfiguredDBTest(t, "org", "alpha")
+
+	db, err := internal.OpenConfiguredDB()
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	projectID, err := internal.CreateProject(db, &internal.ProjectConfig{Owner: "org", Repo: "alpha"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	if err := internal.InitSyncStateSchema(db); err != nil {
+		t.Fatalf("Failed to create sync state schema: %v", err)
+	}
+	for number := 1; number <= 3; number++ {
+		githubID := int64(100 + number)
+		if err := internal.SaveIssue(db, projectID, &internal.DBIssue{ID: int(githubID), Number: number, Title: "Issue " + strconv.Itoa(number), State: "open"}); err != nil {
+			t.Fatalf("Failed to save issue: %v", err)
+		}
+		localID, err := internal.LocalIDByNumber(db, projectID, number)
+		if err != nil {
+			t.Fatalf("Failed to look up issue: %v", err)
+		}
+		state := internal.SyncStateConflicted
+		if number == 3 {
+			state = internal.SyncStateSynced
+		}
+		if err := internal.CreateSyncState(db, localID, state, &githubID); err != nil {
+			t.Fatalf("Failed to create sync state: %v", err)
+		}
+	}
+	db.Close()
+
+	run := func(input string, args ...string) (string, error) {
+		output := &bytes.Buffer{}
+		cmd := NewRootCommand()
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetIn(strings.NewReader(input))
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return output.String(), err
+	}
+
+	out, err := run("", "resolve", "--issue", "2", "--take-local")
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if !strings.Contains(out, "Resolved #2") {
+		t.Errorf("Expected issue #2 to be resolved, got %q", out)
+	}
+
+	db, err = internal.OpenConfiguredDB()
+	if err != nil {
+		t.Fatalf("Failed to reopen database: %v", err)
+	}
+	defer db.Close()
+	conflicts, err := internal.GetConflictedIssues(db, 0)
+	if err != nil {
+		t.Fatalf("Failed to list conflicts: %v", err)
+	}
+	if len(conflicts) != 1 || conflicts[0].Number != 1 {
+		t.Errorf("Expected only issue #1 to stay CONFLICTED, got %+v", conflicts)
+	}
+
+	if _, err := run("", "resolve", "--issue", "3", "--take-remote"); err == nil || !strings.Contains(err.Error(), "not conflicted") {
+		t.Errorf("Expected error for issue that is not conflicted, got %v", err)
+	}
+
+	if _, err := run("", "resolve", "--issue", "1", "--project", "alpha", "--take-local"); err == nil || !strings.Contains(err.Error(), "format 'owner/repo'") {
+		t.Errorf("Expected project format error, got %v", err)
+	}
+
+	// Interactive resolution without an answer must not resolve anything
+	if _, err := run("", "resolve", "--issue", "1"); err == nil || !strings.Contains(err.Error(), "no resolution chosen") {
+		t.Errorf("Expected error without interactive answer, got %v", err)
+	}
+
+	out, err = run("x\nr\n", "resolve", "--issue", "1")
+	if err != nil {
+		t.Fatalf("Interactive resolve failed: %v", err)
+	}
+	if !strings.Contains(out, "Please answer") || !strings.Contains(out, "kept remote version") {
+		t.Errorf("Expected reprompt then remote resolution, got %q", out)
+	}
+}
+
+// TestResolveCommandAfterSync tests resolving a conflict that sync recorded in the configured database
+func TestResolveCommandAfterSync(t *testing.T) {
+	setupConfiguredDBTest(t, "octo", "demo")
+	title := "First"
+	newMockGitHubServer(t, "octo", "demo", func() string {
+		return `[{"id": 101, "number": 1, "title": "` + title + `", "state": "open", "updated_at": "2024-05-01T10:00:00Z"}]`
+	})
+
+	run := func(args ...string) (string, error) {
+		output := &bytes.Buffer{}
+		cmd := NewRootCommand()
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetIn(strings.NewReader(""))
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return output.String(), err
+	}
+
+	if _, err := run("sync"); err != nil {
+		t.Fatalf("First sync failed: %v", err)
+	}
+
+	// Edit the issue locally, then let GitHub change it too
+	db, err := internal.OpenConfiguredDB()
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if _, err := db.Exec("UPDATE issues SET title = 'Local title', local_modified_at = '2024-05-02T10:00:00Z' WHERE number = 1"); err != nil {
+		t.Fatalf("Failed to modify issue: %v", err)
+	}
+	db.Close()
+	title = "Remote title"
+
+	if _, err := run("sync"); exitCode(err) != ExitConflicts {
+		t.Fatalf("Expected the second sync to report a conflict, got %v", err)
+	}
+
+	out, err := run("resolve", "--project", "octo/demo", "--issue", "1", "--take-remote")
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if !strings.Contains(out, "Resolved #1") {
+		t.Errorf("Expected issue #1 to be resolved, got %q", out)
+	}
+	if out, err := run("resolve"); err != nil || !strings.Contains(out, "No conflicted issues") {
+		t.Errorf("Expected no conflicts left, got %v: %s", err, out)
+	}
+	if _, err := os.Stat("pivot.db"); !os.IsNotExist(err) {
+		t.Errorf("Expected resolve not to create ./pivot.db, got %v", err)
+	}
+}
+
+// TestSyncCommandAssigneeFlagsConflict tests that --assignee and --assigned-to-me are exclusive
+func TestSyncCommandAssigneeFlagsConflict(t *testing.T) {
+	output := &bytes.Buffer{}
+	cmd := NewRootCommand()
+	cmd.SetOut(output)
+	cmd.SetErr(output)
+	cmd.SetArgs([]string{"sync", "--assignee", "octocat", "--assigned-to-me"})
+
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal("Expected error when combining --assignee and --assigned-to-me")
+	}
+	if !strings.Contains(err.Error(), "cannot be used together") {
+		t.Errorf("Expected conflict error, got: %v", err)
+	}
+}
+
+// TestSyncCommandOnlyNewConflicts tests that --only-new rejects flags that would update stored issues
+func TestSyncCommandOnlyNewConflicts(t *testing.T) {
+	for _, flag := range []string{"--compare-only", "--force-overwrite"} {
+		output := &bytes.Buffer{}
+		cmd := NewRootCommand()
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetArgs([]string{"sync", "--only-new", flag})
+
+		err := cmd.Execute()
+		if err == nil || !strings.Contains(err.Error(), "--only-new cannot be used with") {
+			t.Errorf("Expected --only-new with %s to be rejected, got: %v", flag, err)
+		}
+	}
+}
+
+func TestListCommandBlocked(t *testing.T) {
+	setupDBCommandTest(t)
+
+	db, err := internal.InitMultiProjectDBFromPath("./pivot.db")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	for _, issue := range []internal.DBIssue{
+		{ID: 10, Number: 10, Title: "Checkout", State: "open", Dependencies: "11,12"},
+		{ID: 11, Number: 11, Title: "Payments API", State: "open"},
+		{ID: 12, Number: 12, Title: "Cart", State: "closed"},
+	} {
+		issue := issue
+		if err := internal.SaveIssue(db, 1, &issue); err != nil {
+			t.Fatalf("Failed to save issue: %v", err)
+		}
+	}
+	db.Close()
+
+	run := func(args ...string) (string, error) {
+		output := &bytes.Buffer{}
+		cmd := NewRootCommand()
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetArgs(append([]string{"list"}, args...))
+		err := cmd.Execute()
+		return output.String(), err
+	}
+
+	output, err := run("--blocked")
+	if err != nil {
+		t.Fatalf("list --blocked failed: %v\n%s", err, output)
+	}
+	if !strings.Contains(output, "Checkout (depends on #11, #12)") || !strings.Contains(output, "Showing 1 issues") {
+		t.Errorf("Expected only #10 with its dependencies, got: %s", output)
+	}
+
+	output, err = run("--blocking")
+	if err != nil {
+		t.Fatalf("list --blocking failed: %v\n%s", err, output)
+	}
+	if !strings.Contains(output, "Payments API") || !strings.Contains(output, "Showing 1 issues") {
+		t.Errorf("Expected only #11, got: %s", output)
+	}
+
+	if _, err := run("--blocked", "--state", "closed"); err == nil || !strings.Contains(err.Error(), "only list open issues") {
+		t.Errorf("Expected --state closed to be rejected, got %v", err)
+	}
+}
+
+// TestCSVExportCommandDedupe tests that --dedupe writes one row per key and repository,
+// keeping the newest copy
+func TestCSVExportCommandDedupe(t *testing.T) {
+	setupDBCommandTest(t)
+
+	// org/alpha holds #1 and #2, both titled "Issue"; org/beta reuses the number #1
+	// and the title "Issue" for unrelated issues
+	db, err := internal.InitMultiProjectDBFromPath("./pivot.db")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	projectID, err := internal.CreateProject(db, &internal.ProjectConfig{Owner: "org", Repo: "beta"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	if _, err := db.Exec("UPDATE issues SET updated_at = '2024-01-01T00:00:00Z'"); err != nil {
+		t.Fatalf("Failed to set updated_at: %v", err)
+	}
+	for _, issue := range []*internal.DBIssue{
+		{ID: 9001, Number: 1, Title: "Beta issue", State: "closed", UpdatedAt: "2024-06-01T00:00:00Z"},
+		{ID: 9002, Number: 2, Title: "Issue", State: "open", UpdatedAt: "2024-06-01T00:00:00Z"},
+	} {
+		if err := internal.SaveIssue(db, projectID, issue); err != nil {
+			t.Fatalf("Failed to save issue: %v", err)
+		}
+	}
+	if _, err := db.Exec("UPDATE issues SET updated_at = '2024-02-01T00:00:00Z' WHERE number = 2 AND project_id != ?", projectID); err != nil {
+		t.Fatalf("Failed to set updated_at: %v", err)
+	}
+	db.Close()
+
+	exportRows := func(args ...string) (string, [][]string) {
+		t.Helper()
+		output := &bytes.Buffer{}
+		cmd := NewRootCommand()
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetArgs(append([]string{"export", "csv", "--output", "dedupe.csv", "--fields", "id,title"}, args...))
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("CSV export failed: %v\n%s", err, output.String())
+		}
+		file, err := os.Open("dedupe.csv")
+		if err != nil {
+			t.Fatalf("Failed to open export: %v", err)
+		}
+		defer file.Close()
+		rows, err := encodingcsv.NewReader(file).ReadAll()
+		if err != nil {
+			t.Fatalf("Failed to read export: %v", err)
+		}
+		return output.String(), rows[1:]
+	}
+
+	if _, rows := exportRows(); len(rows) != 4 {
+		t.Fatalf("Expected 4 rows without --dedupe, got %v", rows)
+	}
+
+	// Issue numbers are only unique within a repository
+	output, rows := exportRows("--dedupe")
+	if len(rows) != 4 {
+		t.Errorf("Expected #1 and #2 of both repositories to be kept, got %v", rows)
+	}
+	if !strings.Contains(output, "Removed 0 duplicate issues (by number)") {
+		t.Errorf("Expected the removed count, got: %s", output)
+	}
+
+	// --dedupe-key implies --dedupe; only the two alpha issues share a title
+	output, rows = exportRows("--dedupe-key", "title")
+	if len(rows) != 3 {
+		t.Fatalf("Expected one row per title and repository, got %v", rows)
+	}
+	if rows[0][0] != "2" || rows[0][1] != "Issue" {
+		t.Errorf("Expected the most recently updated alpha issue to survive, got %v", rows[0])
+	}
+	if !strings.Contains(output, "Removed 1 duplicate issues (by title)") {
+		t.Errorf("Expected the removed count, got: %s", output)
+	}
+
+	for key, expected := range map[string]string{"assignee": "invalid dedupe key", "external_id": "issues in the database have no external_id"} {
+		cmd := NewRootCommand()
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs([]string{"export", "csv", "--dedupe-key", key})
+		if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected %q for --dedupe-key %s, got %v", expected, key, err)
+		}
+	}
+}
+
+func TestExportOrderBy(t *testing.T) {
+	setupDBCommandTest(t)
+
+	// Replace the two seeded issues with issues whose fields order them differently
+	db, err := internal.InitMultiProjectDBFromPath("./pivot.db")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	for _, issue := range []*internal.DBIssue{
+		{ID: 1, Number: 1, Title: "charlie", State: "open", CreatedAt: "2024-01-03T00:00:00Z", UpdatedAt: "2024-02-01T00:00:00Z"},
+		{ID: 2, Number: 2, Title: "Alpha", State: "open", CreatedAt: "2024-01-01T00:00:00Z", UpdatedAt: "2024-02-03T00:00:00Z"},
+		{ID: 3, Number: 3, Title: "bravo", State: "open", CreatedAt: "2024-01-02T00:00:00Z", UpdatedAt: "2024-02-02T00:00:00Z"},
+	} {
+		if err := internal.SaveIssue(db, 1, issue); err != nil {
+			t.Fatalf("Failed to save issue: %v", err)
+		}
+	}
+	for number, plusOne := range map[int]int{1: 2, 3: 7} {
+		if err := internal.SaveReactions(db, 1, number, &internal.Reactions{PlusOne: plusOne}); err != nil {
+			t.Fatalf("Failed to save reactions: %v", err)
+		}
+	}
+	db.Close()
+
+	export := func(file string, args ...string) string {
+		t.Helper()
+		output := &bytes.Buffer{}
+		cmd := NewRootCommand()
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetArgs(append([]string{"export", "csv", "--output", file, "--fields", "id,title"}, args...))
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("CSV export failed: %v\n%s", err, output.String())
+		}
+		content, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("Failed to read export: %v", err)
+		}
+		return string(content)
+	}
+	numbers := func(content string) string {
+		t.Helper()
+		rows, err := encodingcsv.NewReader(strings.NewReader(content)).ReadAll()
+		if err != nil {
+			t.Fatalf("Failed to parse export: %v", err)
+		}
+		var ids []string
+		for _, row := range rows[1:] {
+			ids = append(ids, row[0])
+		}
+		return strings.Join(ids, ",")
+	}
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{nil, "1,2,3"},
+		{[]string{"--desc"}, "3,2,1"},
+		{[]string{"--order-by", "created"}, "2,3,1"},
+		{[]string{"--order-by", "updated", "--desc"}, "2,3,1"},
+		{[]string{"--order-by", "title"}, "2,3,1"},
+		{[]string{"--order-by", "reactions", "--desc"}, "3,1,2"},
+	}
+	for _, tt := range tests {
+		first, second := export("first.csv", tt.args...), export("second.csv", tt.args...)
+		if first != second {
+			t.Errorf("%v: expected identical consecutive exports, got:\n%s\nand:\n%s", tt.args, first, second)
+		}
+		if got := numbers(first); got != tt.want {
+			t.Errorf("%v: expected issues %s, got %s", tt.args, tt.want, got)
+		}
+	}
+
+	// The custom template export honors the order too
+	if err := os.WriteFile("numbers.tmpl", []byte("{{range .Issues}}{{.Number}} {{end}}"), 0600); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+	output := &bytes.Buffer{}
+	cmd := NewRootCommand()
+	cmd.SetOut(output)
+	cmd.SetErr(output)
+	cmd.SetArgs([]string{"export", "custom", "--template", "numbers.tmpl", "--order-by", "updated"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Custom export failed: %v\n%s", err, output.String())
+	}
+	if output.String() != "1 3 2 " {
+		t.Errorf("Expected issues in updated order, got %q", output.String())
+	}
+
+	cmd = NewRootCommand()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"export", "csv", "--order-by", "priority"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "invalid --order-by") {
+		t.Errorf("Expected an invalid order error, got %v", err)
+	}
+}
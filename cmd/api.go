@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/rhino11/pivot/internal"
+	"github.com/spf13/cobra"
+)
+
+// createAPICommand creates the api command that serves local issues as read-only JSON
+func createAPICommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "api",
+		Short: "Serve local issues over a read-only JSON API",
+		Long: `Serve issues from the local database over a read-only JSON API for local integrations.
+
+Endpoints:
+  GET /issues           List issues (query: project, state, label, sort, desc, limit, offset)
+  GET /issues/{number}  Show one issue (query: project)
+  GET /summary          Issue counts by state and project
+
+Examples:
+  pivot api
+  pivot api --addr 127.0.0.1:9000
+  curl 'http://127.0.0.1:8080/issues?state=open&sort=updated&desc=true'`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addr, _ := cmd.Flags().GetString("addr")
+			if err := internal.ServeAPI(addr); err != nil {
+				return fmt.Errorf("api server failed: %w", err)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("addr", "127.0.0.1:8080", "Address to listen on")
+
+	return cmd
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/rhino11/pivot/internal"
+	"github.com/spf13/cobra"
+)
+
+// verifyAuth checks a token against GitHub. Tests replace it to avoid network access.
+var verifyAuth = internal.VerifyAuth
+
+// printAuthVerification verifies token and the given repositories (owner/repo) and
+// prints the result as JSON. A failed check is returned as an error after printing.
+func printAuthVerification(cmd *cobra.Command, token string, repositories []string) error {
+	verification := verifyAuth(token, repositories)
+
+	data, err := json.MarshalIndent(verification, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode verification result: %w", err)
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), string(data))
+
+	if !verification.Passed() {
+		cmd.SilenceUsage = true
+		if !verification.TokenValid {
+			return fmt.Errorf("GitHub token is not valid")
+		}
+		return fmt.Errorf("repository access check failed")
+	}
+	return nil
+}
@@ -2,8 +2,11 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
 	"strings"
 	"testing"
+
+	"github.com/rhino11/pivot/internal"
 )
 
 func TestAuthCommand(t *testing.T) {
@@ -76,3 +79,100 @@ func TestAuthCommand(t *testing.T) {
 		}
 	})
 }
+
+// stubVerifyAuth replaces the GitHub check of auth verify --json for one test
+func stubVerifyAuth(t *testing.T, verification *internal.AuthVerification) *[]string {
+	t.Helper()
+	var checked []string
+	oldVerifyAuth := verifyAuth
+	verifyAuth = func(token string, repositories []string) *internal.AuthVerification {
+		checked = append(checked, repositories...)
+		return verification
+	}
+	t.Cleanup(func() { verifyAuth = oldVerifyAuth })
+	return &checked
+}
+
+func TestAuthVerifyJSON(t *testing.T) {
+	t.Run("valid token", func(t *testing.T) {
+		checked := stubVerifyAuth(t, &internal.AuthVerification{
+			TokenValid: true,
+			Login:      "octocat",
+			Scopes:     []string{"repo"},
+			RepoAccess: map[string]bool{"myorg/myrepo": true},
+		})
+
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		cmd := NewRootCommand()
+		cmd.SetOut(stdout)
+		cmd.SetErr(stderr)
+		cmd.SetArgs([]string{"auth", "verify", "--token", "ghp_test", "--owner", "myorg", "--repo", "myrepo", "--json"})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("Expected verification to pass, got: %v", err)
+		}
+
+		var result map[string]interface{}
+		if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+			t.Fatalf("Expected only JSON on stdout, got %q: %v", stdout.String(), err)
+		}
+		for _, key := range []string{"token_valid", "login", "scopes", "repo_access"} {
+			if _, ok := result[key]; !ok {
+				t.Errorf("Expected key %q in %s", key, stdout.String())
+			}
+		}
+		if result["token_valid"] != true || result["login"] != "octocat" {
+			t.Errorf("Expected a valid token for octocat, got %v", result)
+		}
+		if access, ok := result["repo_access"].(map[string]interface{}); !ok || access["myorg/myrepo"] != true {
+			t.Errorf("Expected repo_access {myorg/myrepo: true}, got %v", result["repo_access"])
+		}
+		if len(*checked) != 1 || (*checked)[0] != "myorg/myrepo" {
+			t.Errorf("Expected myorg/myrepo to be checked, got %v", *checked)
+		}
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		stubVerifyAuth(t, &internal.AuthVerification{
+			Scopes:     []string{},
+			RepoAccess: map[string]bool{},
+			Errors:     []string{"Invalid GitHub token"},
+		})
+
+		stdout := &bytes.Buffer{}
+		cmd := NewRootCommand()
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs([]string{"auth", "verify", "--token", "invalid_token", "--json"})
+		if err := cmd.Execute(); err == nil || exitCode(err) == ExitClean {
+			t.Fatalf("Expected a non-zero exit for an invalid token, got %v", err)
+		}
+
+		var result internal.AuthVerification
+		if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+			t.Fatalf("Expected only JSON on stdout, got %q: %v", stdout.String(), err)
+		}
+		if result.TokenValid || result.Login != "" {
+			t.Errorf("Expected an invalid token without login, got %+v", result)
+		}
+		if !strings.Contains(stdout.String(), `"scopes": []`) || !strings.Contains(stdout.String(), `"repo_access": {}`) {
+			t.Errorf("Expected empty scopes and repo_access, got %s", stdout.String())
+		}
+	})
+
+	t.Run("inaccessible repository", func(t *testing.T) {
+		stubVerifyAuth(t, &internal.AuthVerification{
+			TokenValid: true,
+			Login:      "octocat",
+			Scopes:     []string{},
+			RepoAccess: map[string]bool{"myorg/private": false},
+		})
+
+		cmd := NewRootCommand()
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs([]string{"auth", "verify", "--token", "ghp_test", "--owner", "myorg", "--repo", "private", "--json"})
+		if err := cmd.Execute(); err == nil {
+			t.Error("Expected a non-zero exit when a repository is not accessible")
+		}
+	})
+}
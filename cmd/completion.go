@@ -0,0 +1,31 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/rhino11/pivot/internal"
+	"github.com/spf13/cobra"
+)
+
+// completeProjects suggests the owner/repo identifiers of the projects in config.yml
+func completeProjects(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	config, err := internal.LoadMultiProjectConfig()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var projects []string
+	for _, project := range config.Projects {
+		id := project.Owner + "/" + project.Repo
+		if strings.HasPrefix(strings.ToLower(id), strings.ToLower(toComplete)) {
+			projects = append(projects, id)
+		}
+	}
+	return projects, cobra.ShellCompDirectiveNoFileComp
+}
+
+// registerProjectCompletion completes the named owner/repo flag from the configured projects
+func registerProjectCompletion(cmd *cobra.Command, flag string) {
+	// The flag is registered by the caller, so this cannot fail
+	_ = cmd.RegisterFlagCompletionFunc(flag, completeProjects)
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// setupCompletionTest writes a config with three projects to a temp directory
+func setupCompletionTest(t *testing.T) {
+	t.Helper()
+	tempDir := t.TempDir()
+	oldDir, _ := os.Getwd()
+	t.Cleanup(func() {
+		if err := os.Chdir(oldDir); err != nil {
+			t.Logf("Warning: Failed to change back to original directory: %v", err)
+		}
+	})
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+
+	configContent := `global:
+  database: ./pivot.db
+projects:
+  - owner: org
+    repo: alpha
+  - owner: org
+    repo: beta
+  - owner: other
+    repo: gamma`
+	if err := os.WriteFile("config.yml", []byte(configContent), 0600); err != nil {
+		t.Fatalf("Failed to create test config: %v", err)
+	}
+}
+
+func TestCompleteProjects(t *testing.T) {
+	setupCompletionTest(t)
+
+	projects, directive := completeProjects(&cobra.Command{}, nil, "")
+	expected := []string{"org/alpha", "org/beta", "other/gamma"}
+	if !reflect.DeepEqual(projects, expected) {
+		t.Errorf("Expected %v, got %v", expected, projects)
+	}
+	if directive != cobra.ShellCompDirectiveNoFileComp {
+		t.Errorf("Expected NoFileComp directive, got %v", directive)
+	}
+
+	projects, _ = completeProjects(&cobra.Command{}, nil, "Org/")
+	if !reflect.DeepEqual(projects, []string{"org/alpha", "org/beta"}) {
+		t.Errorf("Expected org projects for prefix, got %v", projects)
+	}
+}
+
+func TestCompleteProjects_NoConfig(t *testing.T) {
+	tempDir := t.TempDir()
+	oldDir, _ := os.Getwd()
+	defer func() { _ = os.Chdir(oldDir) }()
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+
+	projects, directive := completeProjects(&cobra.Command{}, nil, "")
+	if len(projects) != 0 || directive != cobra.ShellCompDirectiveNoFileComp {
+		t.Errorf("Expected no suggestions without config, got %v (%v)", projects, directive)
+	}
+}
+
+func TestProjectFlagCompletion(t *testing.T) {
+	setupCompletionTest(t)
+
+	for _, args := range [][]string{
+		{"sync", "--project", "o"},
+		{"list", "--project", "o"},
+		{"import", "csv", "--repository", "o"},
+	} {
+		output := &bytes.Buffer{}
+		cmd := NewRootCommand()
+		cmd.SetOut(output)
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(append([]string{cobra.ShellCompRequestCmd}, args...))
+
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("Completion of %v failed: %v", args, err)
+		}
+		for _, project := range []string{"org/alpha", "org/beta", "other/gamma"} {
+			if !strings.Contains(output.String(), project) {
+				t.Errorf("Expected %s in completion of %v, got: %s", project, args, output.String())
+			}
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rhino11/pivot/internal"
+	"github.com/spf13/cobra"
+)
+
+// createCreateCommand creates the create command for opening a new GitHub issue
+func createCreateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a GitHub issue",
+		Long: `Create an issue on GitHub in one of the configured projects. The issue is
+stored locally by the next sync.
+
+--from-file authors the issue from a markdown file instead: YAML front matter
+(title, labels, assignees, type, milestone, story_points, estimated_hours, epic,
+acceptance_criteria) supplies the fields and the markdown after it becomes the
+body; agile fields not in the front matter are read from "Field: value" lines
+in the body, as sync does. The issue is only stored locally, as LOCAL_ONLY, and is not
+sent to GitHub. depends_on lists issue numbers; a value that is not the number
+of a stored issue is taken as the local ID of an issue that is not pushed yet.
+
+--project may be omitted when exactly one project is configured. --type sets the
+GitHub issue type (e.g. Bug, Feature or Task); it requires issue types to be
+enabled for the repository's organization.
+
+Examples:
+  pivot create --title "Login fails on Safari"
+  pivot create --project myorg/myrepo --title "Login fails" --type Bug --label frontend
+  pivot create --from-file issue.md`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectSpec, _ := cmd.Flags().GetString("project")
+			title, _ := cmd.Flags().GetString("title")
+			body, _ := cmd.Flags().GetString("body")
+			labels, _ := cmd.Flags().GetStringArray("label")
+			assignees, _ := cmd.Flags().GetStringArray("assignee")
+			issueType, _ := cmd.Flags().GetString("type")
+			fromFile, _ := cmd.Flags().GetString("from-file")
+
+			if fromFile != "" {
+				for _, flag := range []string{"title", "body", "label", "assignee", "type"} {
+					if cmd.Flags().Changed(flag) {
+						return fmt.Errorf("--%s cannot be combined with --from-file; set it in the file's front matter", flag)
+					}
+				}
+			} else if title == "" {
+				return fmt.Errorf("--title is required (or use --from-file)")
+			}
+
+			config, err := internal.LoadMultiProjectConfig()
+			if err != nil {
+				return fmt.Errorf("failed to load configuration: %w (run 'pivot init' to set up config)", err)
+			}
+			project, err := selectProject(config, projectSpec)
+			if err != nil {
+				return err
+			}
+			if fromFile != "" {
+				return createLocalIssueFromFile(cmd, config, project, fromFile)
+			}
+			token := project.GetEffectiveToken(&config.Global)
+			if token == "" {
+				return fmt.Errorf("no GitHub token configured for project %s/%s", project.Owner, project.Repo)
+			}
+
+			request := internal.CreateIssueRequest{
+				Title:     title,
+				Body:      body,
+				Labels:    internal.DedupeList(labels),
+				Assignees: internal.DedupeList(assignees),
+				Type:      issueType,
+			}
+			response, err := internal.CreateIssue(project.Owner, project.Repo, token, request)
+			if err != nil {
+				return fmt.Errorf("failed to create issue: %w", err)
+			}
+
+			cmd.Printf("✓ Created issue #%d in %s/%s\n", response.Number, project.Owner, project.Repo)
+			if response.HTMLURL != "" {
+				cmd.Printf("  %s\n", response.HTMLURL)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("project", "", "Project to create the issue in (format: owner/repo)")
+	registerProjectCompletion(cmd, "project")
+	cmd.Flags().String("title", "", "Issue title (required unless --from-file is used)")
+	cmd.Flags().String("body", "", "Issue description")
+	cmd.Flags().StringArray("label", []string{}, "Label to add (repeatable)")
+	cmd.Flags().StringArray("assignee", []string{}, "Login to assign (repeatable)")
+	cmd.Flags().String("type", "", "GitHub issue type, e.g. Bug, Feature or Task")
+	cmd.Flags().String("from-file", "", "Create a local-only issue from a markdown file with YAML front matter")
+
+	return cmd
+}
+
+// createLocalIssueFromFile stores the issue described by a markdown file as LOCAL_ONLY
+func createLocalIssueFromFile(cmd *cobra.Command, config *internal.MultiProjectConfig, project *internal.ProjectConfig, path string) error {
+	file, err := internal.ParseIssueFile(path)
+	if err != nil {
+		return err
+	}
+
+	db, err := internal.InitMultiProjectDBWithSettings(config.Global.Database, config.Database)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	projectID, err := internal.CreateProject(db, project)
+	if err != nil {
+		return err
+	}
+	numbers, dependsOn, err := internal.ResolveDependencies(db, projectID, file.DependsOn)
+	if err != nil {
+		return err
+	}
+	localID, err := internal.CreateLocalIssue(db, projectID, &internal.DBIssue{
+		Title:     file.Title,
+		Body:      file.Body,
+		Labels:    strings.Join(file.Labels, ","),
+		Assignees: strings.Join(file.Assignees, ","),
+		Type:      file.Type,
+		Milestone: file.Milestone,
+
+		StoryPoints:        file.StoryPoints,
+		EstimatedHours:     file.EstimatedHours,
+		Epic:               file.Epic,
+		AcceptanceCriteria: file.AcceptanceCriteria,
+		Dependencies:       internal.FormatDependencies(numbers),
+	})
+	if err != nil {
+		return err
+	}
+	if err := internal.SetDependencies(db, localID, dependsOn); err != nil {
+		return err
+	}
+
+	cmd.Printf("📝 Created local issue '%s' in %s/%s (local ID %d)\n", file.Title, project.Owner, project.Repo, localID)
+	cmd.Printf("  State: %s, not on GitHub yet\n", internal.SyncStateLocalOnly)
+	return nil
+}
+
+// selectProject returns the configured project named by spec (owner/repo), or the
+// only configured project when spec is empty
+func selectProject(config *internal.MultiProjectConfig, spec string) (*internal.ProjectConfig, error) {
+	if spec == "" {
+		if len(config.Projects) != 1 {
+			return nil, fmt.Errorf("--project is required when %d projects are configured", len(config.Projects))
+		}
+		return &config.Projects[0], nil
+	}
+
+	for i, project := range config.Projects {
+		if project.Owner+"/"+project.Repo == spec {
+			return &config.Projects[i], nil
+		}
+	}
+	return nil, fmt.Errorf("project %s not found in configuration", spec)
+}
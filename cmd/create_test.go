@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/rhino11/pivot/internal"
+)
+
+func TestSelectProject(t *testing.T) {
+	single := &internal.MultiProjectConfig{Projects: []internal.ProjectConfig{{Owner: "org", Repo: "alpha"}}}
+	project, err := selectProject(single, "")
+	if err != nil || project.Repo != "alpha" {
+		t.Errorf("Expected the only project, got %v (%v)", project, err)
+	}
+
+	multi := &internal.MultiProjectConfig{Projects: []internal.ProjectConfig{{Owner: "org", Repo: "alpha"}, {Owner: "org", Repo: "beta"}}}
+	if _, err := selectProject(multi, ""); err == nil || !strings.Contains(err.Error(), "--project is required") {
+		t.Errorf("Expected --project to be required, got: %v", err)
+	}
+	if project, err := selectProject(multi, "org/beta"); err != nil || project.Repo != "beta" {
+		t.Errorf("Expected org/beta, got %v (%v)", project, err)
+	}
+	if _, err := selectProject(multi, "org/gamma"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected unknown project error, got: %v", err)
+	}
+}
+
+func TestCreateCommandRequiresTitle(t *testing.T) {
+	cmd := NewRootCommand()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"create", "--type", "Bug"})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "title") {
+		t.Errorf("Expected missing title error, got: %v", err)
+	}
+}
+
+func TestCreateCommandFromFile(t *testing.T) {
+	tempDir := t.TempDir()
+	oldDir, _ := os.Getwd()
+	t.Cleanup(func() {
+		if err := os.Chdir(oldDir); err != nil {
+			t.Logf("Warning: Failed to change back to original directory: %v", err)
+		}
+	})
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+
+	configContent := `global:
+  database: ./pivot.db
+projects:
+  - owner: org
+    repo: alpha`
+	if err := os.WriteFile("config.yml", []byte(configContent), 0600); err != nil {
+		t.Fatalf("Failed to create test config: %v", err)
+	}
+	issueFile := "---\ntitle: Offline draft\nlabels: [bug, ui]\nassignees: [alice, bob]\n---\nDescribed in **markdown**.\n"
+	if err := os.WriteFile("issue.md", []byte(issueFile), 0600); err != nil {
+		t.Fatalf("Failed to create issue file: %v", err)
+	}
+
+	var out bytes.Buffer
+	cmd := NewRootCommand()
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs([]string{"create", "--from-file", "issue.md"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Expected create --from-file to succeed without a token, got: %v", err)
+	}
+	if !strings.Contains(out.String(), "Created local issue 'Offline draft' in org/alpha") || !strings.Contains(out.String(), "LOCAL_ONLY") {
+		t.Errorf("Expected local issue confirmation, got: %s", out.String())
+	}
+
+	db, err := internal.InitMultiProjectDBFromPath("./pivot.db")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	states, err := internal.GetSyncStatesByState(db, internal.SyncStateLocalOnly)
+	if err != nil || len(states) != 1 {
+		t.Fatalf("Expected 1 LOCAL_ONLY issue, got %d (%v)", len(states), err)
+	}
+
+	var title, body, labels, assignees string
+	err = db.QueryRow("SELECT title, body, labels, assignees FROM issues WHERE rowid = ?", states[0].IssueLocalID).
+		Scan(&title, &body, &labels, &assignees)
+	if err != nil {
+		t.Fatalf("Failed to read local issue: %v", err)
+	}
+	if title != "Offline draft" || body != "Described in **markdown**." {
+		t.Errorf("Expected title and body from the file, got '%s' / '%s'", title, body)
+	}
+	if labels != "bug,ui" || assignees != "alice,bob" {
+		t.Errorf("Expected labels bug,ui and assignees alice,bob, got '%s' / '%s'", labels, assignees)
+	}
+
+	// A second draft depends on the first by local ID; unknown references are rejected
+	draftID := states[0].IssueLocalID
+	for _, test := range []struct {
+		dependsOn string
+		wantErr   string
+	}{
+		{strconv.FormatInt(draftID, 10), ""},
+		{"999", "neither an issue number nor the local ID of an unpushed issue"},
+	} {
+		content := "---\ntitle: Follow-up\ndepends_on: [" + test.dependsOn + "]\n---\n"
+		if err := os.WriteFile("follow-up.md", []byte(content), 0600); err != nil {
+			t.Fatalf("Failed to create issue file: %v", err)
+		}
+		cmd := NewRootCommand()
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs([]string{"create", "--from-file", "follow-up.md"})
+		err := cmd.Execute()
+		if test.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("Expected %q, got %v", test.wantErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Expected the follow-up to be created, got: %v", err)
+		}
+	}
+
+	blocked, err := internal.ListIssues(db, internal.ListOptions{Blocked: true})
+	if err != nil || len(blocked) != 1 || blocked[0].Title != "Follow-up" {
+		t.Errorf("Expected the follow-up to be blocked by the draft, got %+v (%v)", blocked, err)
+	}
+}
+
+func TestCreateCommandFromFileRejectsFieldFlags(t *testing.T) {
+	cmd := NewRootCommand()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"create", "--from-file", "issue.md", "--title", "Other"})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "--title cannot be combined with --from-file") {
+		t.Errorf("Expected --title/--from-file conflict error, got: %v", err)
+	}
+}
@@ -111,6 +111,17 @@ func TestCSVExportCommand(t *testing.T) {
 			expectedOut: "Exported 2 issues",
 			checkFile:   filepath.Join(tmpDir, "filtered.csv"),
 		},
+		{
+			name:        "export all fields",
+			args:        []string{"export", "csv", "--fields", "all", "--output", filepath.Join(tmpDir, "all.csv")},
+			expectedOut: "Exported 2 issues",
+			checkFile:   filepath.Join(tmpDir, "all.csv"),
+		},
+		{
+			name:        "unknown field",
+			args:        []string{"export", "csv", "--fields", "title,severity", "--output", filepath.Join(tmpDir, "unknown.csv")},
+			expectError: true,
+		},
 		{
 			name:        "help command",
 			args:        []string{"export", "csv", "--help"},
@@ -167,6 +178,24 @@ func TestCSVExportCommand(t *testing.T) {
 	}
 }
 
+func TestExportListFields(t *testing.T) {
+	var buf bytes.Buffer
+	rootCmd := NewRootCommand()
+	rootCmd.SetOut(&buf)
+	rootCmd.SetErr(&buf)
+	rootCmd.SetArgs([]string{"export", "--list-fields"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("export --list-fields failed: %v", err)
+	}
+
+	expected := "id\ntitle\nstate\npriority\nlabels\nassignees\nmilestone\ncreated_at\nupdated_at\nbody\n" +
+		"estimated_hours\nstory_points\nepic\ndependencies\nacceptance_criteria\nexternal_id\nstate_reason\nclosed_at\ntype\n"
+	if buf.String() != expected {
+		t.Errorf("Expected field list:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
 func TestCSVRoundTrip(t *testing.T) {
 	tmpDir := t.TempDir()
 	originalCSV := filepath.Join(tmpDir, "original.csv")
@@ -50,7 +50,8 @@ func showCSVFormatGuide() {
 		{"state", "Issue state", "open, closed"},
 		{"priority", "Issue priority", "high, medium, low"},
 		{"labels", "Comma-separated labels", "bug,urgent,security"},
-		{"assignee", "Assigned user", "john.doe"},
+		{"assignees", "Comma-separated assigned users", "john.doe,jane.roe"},
+		{"assignee", "Assigned user (older single-user column, may also hold a list)", "john.doe"},
 		{"milestone", "Milestone name", "v1.0.0"},
 		{"body", "Issue description", "Detailed description..."},
 		{"estimated_hours", "Estimated work hours", "8"},
@@ -58,8 +59,12 @@ func showCSVFormatGuide() {
 		{"epic", "Epic name", "User Authentication"},
 		{"dependencies", "Comma-separated issue IDs", "45,67,89"},
 		{"acceptance_criteria", "Acceptance criteria", "User can login successfully"},
+		{"external_id", "ID from the source system, used by --dedup-by external_id", "JIRA-1234"},
 		{"created_at", "Creation timestamp", "2024-01-15T10:00:00Z"},
 		{"updated_at", "Last update timestamp", "2024-01-15T10:30:00Z"},
+		{"state_reason", "Why a closed issue was closed", "completed, not_planned, duplicate"},
+		{"closed_at", "Close timestamp", "2024-01-20T09:00:00Z"},
+		{"type", "GitHub issue type", "Bug, Feature, Task"},
 	}
 
 	for _, field := range fields {
@@ -127,7 +132,7 @@ func showCSVFormatGuide() {
 	fmt.Println("  2. Test with --dry-run before actual import")
 	fmt.Println("  3. Backup existing issues before importing")
 	fmt.Println("  4. Start with small test files")
-	fmt.Println("  5. Ensure UTF-8 encoding without BOM")
+	fmt.Println("  5. Save as UTF-8, or pass --encoding for Latin-1, Windows-1252 or UTF-16 files")
 	fmt.Println()
 
 	fmt.Println("📖 For detailed documentation, see: docs/CSV_FORMAT_GUIDE.md")
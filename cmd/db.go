@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/rhino11/pivot/internal"
+	"github.com/spf13/cobra"
+)
+
+// createDBCommand creates the db command for maintaining the local database
+func createDBCommand() *cobra.Command {
+	dbCmd := &cobra.Command{
+		Use:   "db",
+		Short: "Maintain the local database",
+		Long: `Inspect and maintain the local SQLite database.
+
+Examples:
+  pivot db info
+  pivot db vacuum
+  pivot db export backup.json
+  pivot db import backup.json`,
+	}
+
+	infoCmd := &cobra.Command{
+		Use:   "info",
+		Short: "Show database size and row counts per table",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := internal.ConfiguredDBPath()
+			if err != nil {
+				return err
+			}
+
+			db, err := internal.OpenConfiguredDB()
+			if err != nil {
+				return fmt.Errorf("failed to connect to database: %w", err)
+			}
+			defer db.Close()
+
+			info, err := internal.GetDatabaseInfo(db, path)
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("Database: %s\n", info.Path)
+			cmd.Printf("Size: %s\n\n", internal.FormatByteSize(info.SizeBytes))
+			cmd.Printf("%-24s %10s\n", "TABLE", "ROWS")
+			for _, table := range info.Tables {
+				cmd.Printf("%-24s %10d\n", table.Table, table.Rows)
+			}
+			return nil
+		},
+	}
+
+	vacuumCmd := &cobra.Command{
+		Use:   "vacuum",
+		Short: "Rebuild the database file to reclaim unused space",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := internal.ConfiguredDBPath()
+			if err != nil {
+				return err
+			}
+
+			db, err := internal.OpenConfiguredDB()
+			if err != nil {
+				return fmt.Errorf("failed to connect to database: %w", err)
+			}
+			defer db.Close()
+
+			cmd.Printf("🧹 Vacuuming %s...\n", path)
+			result, err := internal.VacuumDatabase(db, path)
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("✓ Vacuum complete: %s → %s (reclaimed %s)\n",
+				internal.FormatByteSize(result.BeforeBytes),
+				internal.FormatByteSize(result.AfterBytes),
+				internal.FormatByteSize(result.Reclaimed()))
+			return nil
+		},
+	}
+
+	exportCmd := &cobra.Command{
+		Use:   "export <file.json>",
+		Short: "Export the whole database as portable JSON",
+		Long: `Write every table of the database (projects, issues, sync state, reactions,
+checkpoints and watermarks) to a JSON file that does not depend on the SQLite
+version, for moving pivot to another machine. Project tokens are not exported.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := internal.OpenConfiguredDB()
+			if err != nil {
+				return fmt.Errorf("failed to connect to database: %w", err)
+			}
+			defer db.Close()
+
+			dump, err := internal.DumpDatabase(db)
+			if err != nil {
+				return err
+			}
+			if err := internal.WriteDatabaseDump(args[0], dump); err != nil {
+				return err
+			}
+
+			rows := 0
+			for _, table := range dump.Tables {
+				rows += len(table.Rows)
+			}
+			cmd.Printf("✓ Exported %d rows from %d tables to %s\n", rows, len(dump.Tables), args[0])
+			return nil
+		},
+	}
+
+	importCmd := &cobra.Command{
+		Use:   "import <file.json>",
+		Short: "Restore a database exported with 'pivot db export'",
+		Long: `Rebuild the database from a JSON file written by 'pivot db export'. The
+database configured in config.yml must be fresh (no issues or projects yet);
+point global.database at a new file before importing.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dump, err := internal.ReadDatabaseDump(args[0])
+			if err != nil {
+				return err
+			}
+
+			db, err := internal.OpenConfiguredDB()
+			if err != nil {
+				return fmt.Errorf("failed to connect to database: %w", err)
+			}
+			defer db.Close()
+			if err := internal.InitSyncStateSchema(db); err != nil {
+				return fmt.Errorf("failed to initialize sync state schema: %w", err)
+			}
+
+			result, err := internal.RestoreDatabase(db, dump)
+			if err != nil {
+				return fmt.Errorf("import failed: %w", err)
+			}
+
+			cmd.Printf("✓ Imported %s\n", args[0])
+			cmd.Printf("%-24s %10s\n", "TABLE", "ROWS")
+			for _, table := range result.Tables {
+				cmd.Printf("%-24s %10d\n", table.Table, table.Rows)
+			}
+			for _, table := range result.SkippedTables {
+				cmd.Printf("⚠ Skipped table %s (not part of this version's schema)\n", table)
+			}
+			return nil
+		},
+	}
+
+	dbCmd.AddCommand(infoCmd)
+	dbCmd.AddCommand(vacuumCmd)
+	dbCmd.AddCommand(exportCmd)
+	dbCmd.AddCommand(importCmd)
+	return dbCmd
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/rhino11/pivot/internal"
+)
+
+// setupDBCommandTest creates a config and database with a few issues in a temp directory
+func setupDBCommandTest(t *testing.T) {
+	t.Helper()
+	tempDir := t.TempDir()
+	oldDir, _ := os.Getwd()
+	t.Cleanup(func() {
+		if err := os.Chdir(oldDir); err != nil {
+			t.Logf("Warning: Failed to change back to original directory: %v", err)
+		}
+	})
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+
+	configContent := `global:
+  database: ./pivot.db
+  token: test_token
+projects:
+  - owner: org
+    repo: alpha`
+	if err := os.WriteFile("config.yml", []byte(configContent), 0600); err != nil {
+		t.Fatalf("Failed to create test config: %v", err)
+	}
+
+	db, err := internal.InitMultiProjectDBFromPath("./pivot.db")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.Close()
+	projectID, err := internal.CreateProject(db, &internal.ProjectConfig{Owner: "org", Repo: "alpha"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	body := strings.Repeat("x", 4096)
+	for i := 1; i <= 200; i++ {
+		issue := &internal.DBIssue{ID: i, Number: i, Title: "Issue", Body: body, State: "open"}
+		if err := internal.SaveIssue(db, projectID, issue); err != nil {
+			t.Fatalf("Failed to save issue: %v", err)
+		}
+	}
+	if _, err := db.Exec("DELETE FROM issues WHERE number > 2"); err != nil {
+		t.Fatalf("Failed to delete issues: %v", err)
+	}
+}
+
+func runDBCommand(t *testing.T, args ...string) string {
+	t.Helper()
+	output := &bytes.Buffer{}
+	cmd := NewRootCommand()
+	cmd.SetOut(output)
+	cmd.SetErr(output)
+	cmd.SetArgs(append([]string{"db"}, args...))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("db %s failed: %v\n%s", strings.Join(args, " "), err, output.String())
+	}
+	return output.String()
+}
+
+func TestDBInfoCommand(t *testing.T) {
+	setupDBCommandTest(t)
+
+	output := runDBCommand(t, "info")
+
+	if !strings.Contains(output, "Size: ") {
+		t.Errorf("Expected size in output, got: %s", output)
+	}
+	found := false
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[0] == "issues" && fields[1] == "2" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected issues row count of 2, got: %s", output)
+	}
+}
+
+func TestDBVacuumCommand(t *testing.T) {
+	setupDBCommandTest(t)
+
+	output := runDBCommand(t, "vacuum")
+
+	if !strings.Contains(output, "Vacuum complete") {
+		t.Errorf("Expected vacuum completion message, got: %s", output)
+	}
+	if !strings.Contains(output, "reclaimed") || strings.Contains(output, "reclaimed 0 B") {
+		t.Errorf("Expected reclaimed space to be reported, got: %s", output)
+	}
+}
+
+func TestDBCommandWithoutConfig(t *testing.T) {
+	tempDir := t.TempDir()
+	oldDir, _ := os.Getwd()
+	defer func() {
+		if err := os.Chdir(oldDir); err != nil {
+			t.Logf("Warning: Failed to change back to original directory: %v", err)
+		}
+	}()
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+
+	cmd := NewRootCommand()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"db", "info"})
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected error without config")
+	}
+}
+
+func TestDBExportImportCommands(t *testing.T) {
+	setupDBCommandTest(t)
+
+	output := runDBCommand(t, "export", "backup.json")
+	if !strings.Contains(output, "✓ Exported") || !strings.Contains(output, "backup.json") {
+		t.Errorf("Expected export confirmation, got: %s", output)
+	}
+
+	// Importing into the populated database is refused
+	cmd := NewRootCommand()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"db", "import", "backup.json"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "not empty") {
+		t.Errorf("Expected import into a populated database to fail, got: %v", err)
+	}
+
+	// Point the config at a fresh database and restore into it
+	configContent := `global:
+  database: ./restored.db
+  token: test_token
+projects:
+  - owner: org
+    repo: alpha`
+	if err := os.WriteFile("config.yml", []byte(configContent), 0600); err != nil {
+		t.Fatalf("Failed to rewrite test config: %v", err)
+	}
+
+	output = runDBCommand(t, "import", "backup.json")
+	if !strings.Contains(output, "✓ Imported backup.json") {
+		t.Errorf("Expected import confirmation, got: %s", output)
+	}
+
+	db, err := internal.InitMultiProjectDBFromPath("./restored.db")
+	if err != nil {
+		t.Fatalf("Failed to open restored database: %v", err)
+	}
+	defer db.Close()
+	issues, err := internal.ListIssues(db, internal.ListOptions{})
+	if err != nil {
+		t.Fatalf("Failed to list restored issues: %v", err)
+	}
+	if len(issues) != 2 || issues[0].Number != 1 || issues[1].Number != 2 {
+		t.Errorf("Expected issues #1 and #2 to be restored, got %+v", issues)
+	}
+}
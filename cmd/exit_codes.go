@@ -0,0 +1,181 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/rhino11/pivot/internal"
+)
+
+// Exit codes of sync, push and status, so CI can tell the outcomes apart
+const (
+	ExitClean     = 0 // Completed without conflicts or failures
+	ExitError     = 1 // Did not run to completion, e.g. invalid flags, config or network errors
+	ExitConflicts = 2 // Completed, but issues are conflicted and need 'pivot resolve'
+	ExitFailures  = 3 // Completed, but some projects or issues failed
+)
+
+// Exit codes of 'status --exit-code', one per problem state. Conflicts keep ExitConflicts.
+const (
+	ExitPushFailed = 4 // Issues are PUSH_FAILED
+	ExitSyncFailed = 5 // Issues are SYNC_FAILED
+	ExitStateError = 6 // Issues are in the ERROR state
+)
+
+// exitCodeError is a command error that maps to a specific exit code
+type exitCodeError struct {
+	code int
+	err  error
+}
+
+func (e *exitCodeError) Error() string { return e.err.Error() }
+func (e *exitCodeError) Unwrap() error { return e.err }
+
+// withExitCode attaches an exit code to err
+func withExitCode(code int, err error) error {
+	return &exitCodeError{code: code, err: err}
+}
+
+// exitCode returns the process exit code for a command error
+func exitCode(err error) int {
+	if err == nil {
+		return ExitClean
+	}
+	var coded *exitCodeError
+	if errors.As(err, &coded) {
+		return coded.code
+	}
+	return ExitError
+}
+
+// syncOutcome returns the error matching the exit code of a completed sync. Failures
+// take precedence over conflicts.
+func syncOutcome(result *internal.SyncResult) error {
+	if result == nil {
+		return nil
+	}
+	totals := result.Totals()
+	if len(totals.Errors) > 0 {
+		return withExitCode(ExitFailures, fmt.Errorf("sync failed for %d projects", countFailedProjects(result)))
+	}
+	if len(totals.ChecksumMismatches) > 0 {
+		return withExitCode(ExitFailures, fmt.Errorf("checksum verification failed for %d issues", len(totals.ChecksumMismatches)))
+	}
+	if totals.Conflicted > 0 {
+		return withExitCode(ExitConflicts, fmt.Errorf("sync completed with %d conflicted issues; run 'pivot resolve'", totals.Conflicted))
+	}
+	return nil
+}
+
+// countFailedProjects counts the projects of a sync result that reported errors
+func countFailedProjects(result *internal.SyncResult) int {
+	failed := 0
+	for _, project := range result.Projects {
+		if len(project.Errors) > 0 {
+			failed++
+		}
+	}
+	return failed
+}
+
+// pushOutcome returns the error matching the exit code of a completed push
+func pushOutcome(result *internal.PushResult) error {
+	if result == nil || len(result.Failed) == 0 {
+		return nil
+	}
+	return withExitCode(ExitFailures, fmt.Errorf("%d issues failed to push; they are marked PUSH_FAILED and retried by the next push", len(result.Failed)))
+}
+
+// statusOutcome returns the error matching the exit code for a sync state summary.
+// Failed issues take precedence over conflicted ones.
+func statusOutcome(summary map[internal.SyncState]int) error {
+	failed := summary[internal.SyncStatePushFailed] + summary[internal.SyncStateSyncFailed] + summary[internal.SyncStateError]
+	if failed > 0 {
+		return withExitCode(ExitFailures, fmt.Errorf("%d issues are in a failed state", failed))
+	}
+	if conflicted := summary[internal.SyncStateConflicted]; conflicted > 0 {
+		return withExitCode(ExitConflicts, fmt.Errorf("%d issues are conflicted; run 'pivot resolve'", conflicted))
+	}
+	return nil
+}
+
+// statusStateExitCodes maps the problem states to their 'status --exit-code' exit
+// codes, most severe first
+var statusStateExitCodes = []struct {
+	state internal.SyncState
+	code  int
+}{
+	{internal.SyncStateError, ExitStateError},
+	{internal.SyncStateSyncFailed, ExitSyncFailed},
+	{internal.SyncStatePushFailed, ExitPushFailed},
+	{internal.SyncStateConflicted, ExitConflicts},
+}
+
+// statusStateOutcome returns the error of 'status --exit-code' for a sync state summary.
+// Its exit code is that of the most severe problem state with issues; the message
+// counts every problem state.
+func statusStateOutcome(summary map[internal.SyncState]int) error {
+	code := ExitClean
+	var problems []string
+	for _, entry := range statusStateExitCodes {
+		if count := summary[entry.state]; count > 0 {
+			if code == ExitClean {
+				code = entry.code
+			}
+			problems = append(problems, fmt.Sprintf("%d %s", count, entry.state))
+		}
+	}
+	if code == ExitClean {
+		return nil
+	}
+	return withExitCode(code, fmt.Errorf("issues need attention: %s", strings.Join(problems, ", ")))
+}
+
+// printSyncCounts writes the --summary-only line of a sync
+func printSyncCounts(w io.Writer, result *internal.SyncResult) {
+	var totals internal.ProjectSyncResult
+	if result != nil {
+		totals = result.Totals()
+	}
+	fmt.Fprintf(w, "created=%d updated=%d conflicted=%d skipped=%d errors=%d checksum_mismatches=%d\n",
+		totals.Created, totals.Updated, totals.Conflicted, totals.Skipped, len(totals.Errors), len(totals.ChecksumMismatches))
+}
+
+// printPushCounts writes the --summary-only line of a push
+func printPushCounts(w io.Writer, result *internal.PushResult) {
+	var pushed, failed, skipped int
+	if result != nil {
+		pushed, failed, skipped = len(result.Pushed), len(result.Failed), len(result.Skipped)
+	}
+	fmt.Fprintf(w, "pushed=%d failed=%d skipped=%d\n", pushed, failed, skipped)
+}
+
+// printStatusCounts writes the --summary-only line of status, states in canonical order
+func printStatusCounts(w io.Writer, summary map[internal.SyncState]int) {
+	fields := make([]string, 0, len(summary)+1)
+	total := 0
+	for _, entry := range internal.OrderSyncStateSummary(summary) {
+		fields = append(fields, fmt.Sprintf("%s=%d", entry.State, entry.Count))
+		total += entry.Count
+	}
+	fields = append(fields, fmt.Sprintf("total=%d", total))
+	fmt.Fprintln(w, strings.Join(fields, " "))
+}
+
+// silenceStdout redirects os.Stdout to the null device until the returned function is
+// called, hiding the progress output of a --summary-only run
+func silenceStdout() (func(), error) {
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", os.DevNull, err)
+	}
+	stdout := os.Stdout
+	os.Stdout = devNull
+	return func() {
+		os.Stdout = stdout
+		devNull.Close()
+	}, nil
+}
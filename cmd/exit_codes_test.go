@@ -0,0 +1,441 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/rhino11/pivot/internal"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"success", nil, ExitClean},
+		{"plain error", errors.New("boom"), ExitError},
+		{"conflicts", withExitCode(ExitConflicts, errors.New("conflicted")), ExitConflicts},
+		{"failures", withExitCode(ExitFailures, errors.New("failed")), ExitFailures},
+		{"wrapped", fmt.Errorf("outer: %w", withExitCode(ExitFailures, errors.New("failed"))), ExitFailures},
+		{"scrubbed", internal.ScrubError(withExitCode(ExitConflicts, errors.New("conflicted"))), ExitConflicts},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCode(tt.err); got != tt.want {
+				t.Errorf("Expected exit code %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSyncOutcome(t *testing.T) {
+	tests := []struct {
+		name   string
+		result *internal.SyncResult
+		want   int
+	}{
+		{"clean", &internal.SyncResult{Projects: []internal.ProjectSyncResult{{Created: 2, Updated: 1}}}, ExitClean},
+		{"no result", nil, ExitClean},
+		{"conflicts", &internal.SyncResult{Projects: []internal.ProjectSyncResult{{Updated: 1, Conflicted: 2}}}, ExitConflicts},
+		{"project failed", &internal.SyncResult{Projects: []internal.ProjectSyncResult{{Created: 1}, {Errors: []string{"network down"}}}}, ExitFailures},
+		{"checksum mismatch", &internal.SyncResult{Projects: []internal.ProjectSyncResult{{ChecksumMismatches: []int{7}}}}, ExitFailures},
+		{"failures win over conflicts", &internal.SyncResult{Projects: []internal.ProjectSyncResult{{Conflicted: 1, Errors: []string{"boom"}}}}, ExitFailures},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCode(syncOutcome(tt.result)); got != tt.want {
+				t.Errorf("Expected exit code %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPushOutcome(t *testing.T) {
+	if got := exitCode(pushOutcome(&internal.PushResult{Pushed: []internal.PushedIssue{{Number: 1}}})); got != ExitClean {
+		t.Errorf("Expected exit code %d for a clean push, got %d", ExitClean, got)
+	}
+	failed := &internal.PushResult{Failed: []internal.PushFailure{{LocalID: 1, Error: "boom"}}}
+	if got := exitCode(pushOutcome(failed)); got != ExitFailures {
+		t.Errorf("Expected exit code %d for failed pushes, got %d", ExitFailures, got)
+	}
+}
+
+func TestStatusOutcome(t *testing.T) {
+	tests := []struct {
+		name    string
+		summary map[internal.SyncState]int
+		want    int
+	}{
+		{"clean", map[internal.SyncState]int{internal.SyncStateSynced: 3, internal.SyncStateLocalOnly: 1}, ExitClean},
+		{"empty", map[internal.SyncState]int{}, ExitClean},
+		{"conflicts", map[internal.SyncState]int{internal.SyncStateSynced: 3, internal.SyncStateConflicted: 1}, ExitConflicts},
+		{"push failed", map[internal.SyncState]int{internal.SyncStatePushFailed: 1}, ExitFailures},
+		{"sync failed", map[internal.SyncState]int{internal.SyncStateSyncFailed: 1}, ExitFailures},
+		{"error state", map[internal.SyncState]int{internal.SyncStateError: 1}, ExitFailures},
+		{"failures win over conflicts", map[internal.SyncState]int{internal.SyncStateConflicted: 2, internal.SyncStatePushFailed: 1}, ExitFailures},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCode(statusOutcome(tt.summary)); got != tt.want {
+				t.Errorf("Expected exit code %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestReportSyncResultSummaryOnly(t *testing.T) {
+	result := &internal.SyncResult{Projects: []internal.ProjectSyncResult{
+		{Owner: "org", Repo: "alpha", Created: 2, Updated: 1, Conflicted: 1},
+		{Owner: "org", Repo: "beta", Skipped: 4},
+	}}
+
+	counts := &bytes.Buffer{}
+	err := reportSyncResult(result, "", counts)
+	if got := exitCode(err); got != ExitConflicts {
+		t.Errorf("Expected exit code %d, got %d (%v)", ExitConflicts, got, err)
+	}
+
+	want := "created=2 updated=1 conflicted=1 skipped=4 errors=0 checksum_mismatches=0\n"
+	if counts.String() != want {
+		t.Errorf("Expected counts %q, got %q", want, counts.String())
+	}
+}
+
+func TestReportSyncOutputJSON(t *testing.T) {
+	result := &internal.SyncResult{Projects: []internal.ProjectSyncResult{
+		{Owner: "org", Repo: "alpha", DryRun: &internal.ProjectSyncDiff{Project: "org/alpha",
+			Counts: internal.SyncDiffCounts{New: 1}, New: []internal.SyncDiffIssue{{Number: 7, Title: "Fresh"}}}},
+	}}
+
+	// A dry run prints the diff document
+	output := &bytes.Buffer{}
+	if err := reportSyncOutput(result, true, output, "", nil); err != nil {
+		t.Fatalf("reportSyncOutput failed: %v", err)
+	}
+	var doc internal.SyncDiffDocument
+	if err := json.Unmarshal(output.Bytes(), &doc); err != nil {
+		t.Fatalf("Expected JSON output, got %q: %v", output.String(), err)
+	}
+	if doc.SchemaVersion != internal.SyncDiffSchemaVersion || doc.Counts.New != 1 || doc.Projects[0].New[0].Number != 7 {
+		t.Errorf("Unexpected dry-run document: %+v", doc)
+	}
+
+	// A regular sync prints the result itself, with the usual exit code
+	result.Projects[0].DryRun = nil
+	result.Projects[0].Conflicted = 1
+	output.Reset()
+	err := reportSyncOutput(result, false, output, "", nil)
+	if got := exitCode(err); got != ExitConflicts {
+		t.Errorf("Expected exit code %d, got %d (%v)", ExitConflicts, got, err)
+	}
+	if !strings.Contains(output.String(), `"conflicted": 1`) {
+		t.Errorf("Expected the sync result as JSON, got %s", output.String())
+	}
+}
+
+func TestSyncCommandDryRunFlagConflicts(t *testing.T) {
+	for _, args := range [][]string{
+		{"sync", "--output", "yaml"},
+		{"sync", "--dry-run", "--compare-only"},
+		{"sync", "--output", "json", "--summary-only"},
+	} {
+		output := &bytes.Buffer{}
+		cmd := NewRootCommand()
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err == nil {
+			t.Errorf("Expected %v to be rejected", args)
+		}
+	}
+}
+
+func TestPrintStatusCounts(t *testing.T) {
+	output := &bytes.Buffer{}
+	printStatusCounts(output, map[internal.SyncState]int{internal.SyncStateSynced: 3, internal.SyncStateConflicted: 1})
+
+	want := "SYNCED=3 CONFLICTED=1 total=4\n"
+	if output.String() != want {
+		t.Errorf("Expected %q, got %q", want, output.String())
+	}
+}
+
+func TestSilenceStdout(t *testing.T) {
+	stdout := os.Stdout
+	restore, err := silenceStdout()
+	if err != nil {
+		t.Fatalf("Failed to silence stdout: %v", err)
+	}
+	if os.Stdout == stdout {
+		t.Error("Expected os.Stdout to be redirected")
+	}
+	restore()
+	if os.Stdout != stdout {
+		t.Error("Expected os.Stdout to be restored")
+	}
+}
+
+func TestStatusCommandSummaryOnlyExitCodes(t *testing.T) {
+	tests := []struct {
+		name   string
+		states []internal.SyncState
+		want   int
+		counts string
+	}{
+		{"clean", []internal.SyncState{internal.SyncStateSynced, internal.SyncStateLocalOnly}, ExitClean, "LOCAL_ONLY=1 SYNCED=1 total=2"},
+		{"conflicts", []internal.SyncState{internal.SyncStateSynced, internal.SyncStateConflicted}, ExitConflicts, "SYNCED=1 CONFLICTED=1 total=2"},
+		{"failures", []internal.SyncState{internal.SyncStateConflicted, internal.SyncStatePushFailed}, ExitFailures, "PUSH_FAILED=1 CONFLICTED=1 total=2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seedSyncStates(t, tt.states...)
+
+			output := &bytes.Buffer{}
+			cmd := NewRootCommand()
+			cmd.SetOut(output)
+			cmd.SetErr(&bytes.Buffer{})
+			cmd.SetArgs([]string{"status", "--summary-only"})
+			err := cmd.Execute()
+
+			if got := exitCode(err); got != tt.want {
+				t.Errorf("Expected exit code %d, got %d (%v)", tt.want, got, err)
+			}
+			if strings.TrimSpace(output.String()) != tt.counts {
+				t.Errorf("Expected only the counts %q, got %q", tt.counts, output.String())
+			}
+		})
+	}
+}
+
+func TestStatusCommandExitsCleanWithoutSummaryOnly(t *testing.T) {
+	seedSyncStates(t, internal.SyncStateConflicted, internal.SyncStatePushFailed)
+
+	output := &bytes.Buffer{}
+	cmd := NewRootCommand()
+	cmd.SetOut(output)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"status"})
+	if err := cmd.Execute(); err != nil {
+		t.Errorf("Expected plain status to exit %d, got %d (%v)", ExitClean, exitCode(err), err)
+	}
+	if !strings.Contains(output.String(), "Sync State Summary") {
+		t.Errorf("Expected the summary to be shown, got: %s", output.String())
+	}
+}
+
+// seedSyncStates creates ./pivot.db in a temporary working directory with one issue
+// in each of the given sync states
+func seedSyncStates(t *testing.T, states ...internal.SyncState) {
+	t.Helper()
+	oldDir, _ := os.Getwd()
+	t.Cleanup(func() {
+		if err := os.Chdir(oldDir); err != nil {
+			t.Logf("Warning: Failed to change back to original directory: %v", err)
+		}
+	})
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+
+	db, err := internal.InitDB()
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.Close()
+	if err := internal.InitSyncStateSchema(db); err != nil {
+		t.Fatalf("Failed to create sync state schema: %v", err)
+	}
+	for i, state := range states {
+		githubID := int64(i + 1)
+		if _, err := db.Exec("INSERT INTO issues (github_id, number, title, state) VALUES (?, ?, 'Issue', 'open')", githubID, githubID); err != nil {
+			t.Fatalf("Failed to insert issue: %v", err)
+		}
+		if err := internal.CreateSyncState(db, githubID, state, &githubID); err != nil {
+			t.Fatalf("Failed to create sync state: %v", err)
+		}
+	}
+}
+
+func TestStatusCommandExitCodePerState(t *testing.T) {
+	tests := []struct {
+		name    string
+		states  []internal.SyncState
+		want    int
+		message string
+	}{
+		{"clean", []internal.SyncState{internal.SyncStateSynced, internal.SyncStateLocalOnly}, ExitClean, ""},
+		{"conflicted", []internal.SyncState{internal.SyncStateSynced, internal.SyncStateConflicted}, ExitConflicts, "1 CONFLICTED"},
+		{"push failed", []internal.SyncState{internal.SyncStatePushFailed, internal.SyncStatePushFailed}, ExitPushFailed, "2 PUSH_FAILED"},
+		{"sync failed", []internal.SyncState{internal.SyncStateSynced, internal.SyncStateSyncFailed}, ExitSyncFailed, "1 SYNC_FAILED"},
+		{"error", []internal.SyncState{internal.SyncStateError}, ExitStateError, "1 ERROR"},
+		{"most severe wins", []internal.SyncState{internal.SyncStateConflicted, internal.SyncStatePushFailed, internal.SyncStateSyncFailed},
+			ExitSyncFailed, "1 SYNC_FAILED, 1 PUSH_FAILED, 1 CONFLICTED"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seedSyncStates(t, tt.states...)
+
+			for _, args := range [][]string{{"status", "--exit-code"}, {"status", "--summary-only", "--exit-code"}} {
+				cmd := NewRootCommand()
+				cmd.SetOut(&bytes.Buffer{})
+				cmd.SetErr(&bytes.Buffer{})
+				cmd.SetArgs(args)
+				err := cmd.Execute()
+
+				if got := exitCode(err); got != tt.want {
+					t.Errorf("%v: expected exit code %d, got %d (%v)", args, tt.want, got, err)
+				}
+				if tt.message != "" && (err == nil || !strings.Contains(err.Error(), tt.message)) {
+					t.Errorf("%v: expected the error to count %q, got %v", args, tt.message, err)
+				}
+			}
+		})
+	}
+}
+
+func TestStatusCommandExitCodeRejectsWatch(t *testing.T) {
+	cmd := NewRootCommand()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"status", "--exit-code", "--watch"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--exit-code cannot be used with --watch") {
+		t.Errorf("Expected --watch to be rejected, got %v", err)
+	}
+}
+
+func TestPushCommandSummaryOnlyFailures(t *testing.T) {
+	setupDBCommandTest(t)
+	t.Cleanup(func() { _ = internal.SetHTTPProxy("") })
+
+	// The unreachable proxy makes creating the valid issue fail; the other one breaks
+	// the validation rules and is skipped
+	configContent := `global:
+  database: ./pivot.db
+  token: test_token
+sync:
+  proxy: http://127.0.0.1:1
+push:
+  validation:
+    title_pattern: "^\\[[A-Z]+-[0-9]+\\]"
+projects:
+  - owner: org
+    repo: alpha`
+	if err := os.WriteFile("config.yml", []byte(configContent), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	db, err := internal.InitMultiProjectDBFromPath("./pivot.db")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	for _, title := range []string{"[OPS-1] Rotate keys", "No ticket prefix"} {
+		if _, err := internal.CreateLocalIssue(db, 1, &internal.DBIssue{Title: title}); err != nil {
+			t.Fatalf("Failed to create local issue: %v", err)
+		}
+	}
+	db.Close()
+
+	run := func(args ...string) (string, error) {
+		output := &bytes.Buffer{}
+		cmd := NewRootCommand()
+		cmd.SetOut(output)
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return output.String(), err
+	}
+
+	// By default a violation aborts the push before anything is sent
+	if _, err := run("push", "--summary-only"); err == nil || !strings.Contains(err.Error(), "nothing was pushed") {
+		t.Errorf("Expected the push to be aborted, got %v", err)
+	}
+
+	output, err := run("push", "--summary-only", "--on-violation", "skip")
+	if got := exitCode(err); got != ExitFailures {
+		t.Errorf("Expected exit code %d, got %d (%v)", ExitFailures, got, err)
+	}
+	if output != "pushed=0 failed=1 skipped=1\n" {
+		t.Errorf("Expected only the push counts, got %q", output)
+	}
+}
+
+func TestStatusCommandExitCodeAfterFailedPush(t *testing.T) {
+	setupConfiguredDBTest(t, "org", "alpha")
+	t.Cleanup(func() { _ = internal.SetHTTPProxy("") })
+
+	// The unreachable proxy makes the push fail, marking the issue PUSH_FAILED
+	configContent := `global:
+  database: ./data/issues.db
+  token: test_token
+sync:
+  proxy: http://127.0.0.1:1
+projects:
+  - owner: org
+    repo: alpha`
+	if err := os.WriteFile("config.yml", []byte(configContent), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	db, err := internal.OpenConfiguredDB()
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	projectID, err := internal.CreateProject(db, &internal.ProjectConfig{Owner: "org", Repo: "alpha"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	if _, err := internal.CreateLocalIssue(db, projectID, &internal.DBIssue{Title: "Rotate keys"}); err != nil {
+		t.Fatalf("Failed to create local issue: %v", err)
+	}
+	db.Close()
+
+	run := func(args ...string) error {
+		cmd := NewRootCommand()
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(args)
+		return cmd.Execute()
+	}
+
+	if err := run("push", "--summary-only"); exitCode(err) != ExitFailures {
+		t.Fatalf("Expected the push to fail with exit code %d, got %d (%v)", ExitFailures, exitCode(err), err)
+	}
+	err = run("status", "--exit-code")
+	if got := exitCode(err); got != ExitPushFailed {
+		t.Errorf("Expected exit code %d after the failed push, got %d (%v)", ExitPushFailed, got, err)
+	}
+	if err == nil || !strings.Contains(err.Error(), "1 PUSH_FAILED") {
+		t.Errorf("Expected the error to count the failed push, got %v", err)
+	}
+}
+
+func TestSummaryOnlyRejectsConflictingFlags(t *testing.T) {
+	for _, args := range [][]string{
+		{"status", "--summary-only", "--verbose"},
+		{"push", "--summary-only", "--dry-run"},
+		{"sync", "--summary-only", "--compare-only"},
+	} {
+		cmd := NewRootCommand()
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		if err == nil || !strings.Contains(err.Error(), "--summary-only cannot be used") {
+			t.Errorf("Expected %v to be rejected, got: %v", args, err)
+		}
+		if got := exitCode(err); got != ExitError {
+			t.Errorf("Expected exit code %d for %v, got %d", ExitError, args, got)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/rhino11/pivot/internal"
+	"github.com/spf13/cobra"
+)
+
+// exportBaseName picks the file name for bundled exports: the output file when given,
+// otherwise the template name without its .tmpl extension
+func exportBaseName(templatePath string, args []string) string {
+	if len(args) > 0 {
+		return args[0]
+	}
+	return strings.TrimSuffix(filepath.Base(templatePath), ".tmpl")
+}
+
+// writeBundledExport renders a split and/or zipped export and writes it to disk
+func writeBundledExport(cmd *cobra.Command, tmpl *template.Template, groups []internal.ProjectIssues, baseName, splitBy, zipPath string) error {
+	var files []internal.ExportFile
+	switch splitBy {
+	case "project":
+		rendered, err := internal.RenderSplitExport(tmpl, groups, baseName)
+		if err != nil {
+			return fmt.Errorf("template export failed: %w", err)
+		}
+		files = rendered
+	case "":
+		var issues []internal.DBIssue
+		for _, group := range groups {
+			issues = append(issues, group.Issues...)
+		}
+		var buf bytes.Buffer
+		if err := internal.RenderIssueTemplate(&buf, tmpl, issues); err != nil {
+			return fmt.Errorf("template export failed: %w", err)
+		}
+		files = []internal.ExportFile{{Name: filepath.Base(baseName), Issues: len(issues), Content: buf.Bytes()}}
+	default:
+		return fmt.Errorf("invalid split-by value '%s' (supported: project)", splitBy)
+	}
+
+	if zipPath != "" {
+		if err := internal.WriteExportZip(zipPath, files); err != nil {
+			return fmt.Errorf("zip export failed: %w", err)
+		}
+		cmd.Printf("✓ Exported %d files to %s\n", len(files), zipPath)
+		return nil
+	}
+
+	outputDir := filepath.Dir(baseName)
+	if err := internal.WriteExportFiles(outputDir, files); err != nil {
+		return fmt.Errorf("template export failed: %w", err)
+	}
+	for _, file := range files {
+		cmd.Printf("✓ Exported %d issues to %s\n", file.Issues, filepath.Join(outputDir, file.Name))
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/rhino11/pivot/internal"
+	"github.com/rhino11/pivot/internal/csv"
+	"github.com/spf13/cobra"
+)
+
+// sampleExportIssues are exported when no project configuration is available
+func sampleExportIssues() []*csv.Issue {
+	return []*csv.Issue{
+		{
+			ID:       1,
+			Title:    "Sample Issue 1",
+			State:    "open",
+			Priority: "high",
+			Labels:   []string{"bug", "urgent"},
+			Body:     "This is a sample issue for testing CSV export",
+		},
+		{
+			ID:       2,
+			Title:    "Sample Issue 2",
+			State:    "closed",
+			Priority: "medium",
+			Labels:   []string{"feature"},
+			Body:     "Another sample issue",
+		},
+	}
+}
+
+// exportIssueOrder returns the issue order selected by the --order-by and --desc
+// flags of the export commands
+func exportIssueOrder(cmd *cobra.Command) (internal.IssueOrder, error) {
+	orderBy, _ := cmd.Flags().GetString("order-by")
+	desc, _ := cmd.Flags().GetBool("desc")
+	order, err := internal.ParseIssueOrder(orderBy, desc)
+	if err != nil {
+		return internal.IssueOrder{}, fmt.Errorf("invalid --order-by: %w", err)
+	}
+	return order, nil
+}
+
+// loadCSVExportIssues returns the issues in the configured local database in the
+// given order, each with the owner/repo of its project, falling back to sample data
+// when pivot has not been initialized
+func loadCSVExportIssues(order internal.IssueOrder) ([]*csv.Issue, error) {
+	if _, err := internal.LoadMultiProjectConfig(); err != nil {
+		return sampleExportIssues(), nil
+	}
+
+	db, err := internal.OpenConfiguredDB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	groups, err := internal.GetIssuesByProjectOrdered(db, order)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load issues: %w", err)
+	}
+
+	var issues []*csv.Issue
+	for _, group := range groups {
+		for _, dbIssue := range group.Issues {
+			issue := csv.FromDBIssue(dbIssue)
+			issue.Repository = group.Project.Owner + "/" + group.Project.Repo
+			issues = append(issues, issue)
+		}
+	}
+	return issues, nil
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/rhino11/pivot/internal"
+	"github.com/spf13/cobra"
+)
+
+// fetchIssueTimeline fetches the timeline events of an issue; replaced in tests
+var fetchIssueTimeline = internal.FetchIssueTimeline
+
+// createHistoryCommand creates the history command that prints the timeline events of an issue
+func createHistoryCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history <number>",
+		Short: "Show when an issue was closed, reopened and labeled",
+		Long: `Show the closed, reopened and labeled events of an issue, oldest first.
+
+By default the events stored by 'pivot sync --include-timeline' are shown. Use
+--remote to fetch the current timeline from GitHub instead; the local database
+is not changed.
+
+--project is required when the database holds more than one project.
+
+Examples:
+  pivot history 42
+  pivot history 42 --remote
+  pivot history 42 --project myorg/myrepo`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectSpec, _ := cmd.Flags().GetString("project")
+			remote, _ := cmd.Flags().GetBool("remote")
+
+			number, err := strconv.Atoi(args[0])
+			if err != nil || number <= 0 {
+				return fmt.Errorf("issue number must be a positive integer, got: %s", args[0])
+			}
+
+			db, err := internal.OpenConfiguredDB()
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			project, err := resolveShowProject(db, projectSpec)
+			if err != nil {
+				return err
+			}
+
+			var events []internal.IssueEvent
+			source := "stored by sync --include-timeline"
+			if remote {
+				token, err := storedProjectToken(project)
+				if err != nil {
+					return err
+				}
+				if events, err = fetchIssueTimeline(project.Owner, project.Repo, token, number); err != nil {
+					return err
+				}
+				source = "from GitHub"
+			} else if events, err = internal.GetIssueEvents(db, int64(project.ID), number); err != nil {
+				return err
+			}
+
+			if len(events) == 0 {
+				if remote {
+					cmd.Printf("No closed, reopened or labeled events for %s/%s#%d on GitHub\n", project.Owner, project.Repo, number)
+				} else {
+					cmd.Printf("No timeline events stored for %s/%s#%d; run 'pivot sync --include-timeline' or use --remote\n",
+						project.Owner, project.Repo, number)
+				}
+				return nil
+			}
+
+			cmd.Printf("📜 History of %s/%s#%d (%s)\n", project.Owner, project.Repo, number, source)
+			internal.PrintIssueEvents(cmd.OutOrStdout(), events)
+			return nil
+		},
+	}
+
+	cmd.Flags().String("project", "", "Project as owner/repo (required when the database holds several projects)")
+	cmd.Flags().Bool("remote", false, "Fetch the timeline from GitHub instead of the local database")
+	return cmd
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rhino11/pivot/internal"
+)
+
+func runHistoryCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	output := &bytes.Buffer{}
+	cmd := NewRootCommand()
+	cmd.SetOut(output)
+	cmd.SetErr(output)
+	cmd.SetArgs(append([]string{"history"}, args...))
+	err := cmd.Execute()
+	return output.String(), err
+}
+
+func TestHistoryCommandStoredEvents(t *testing.T) {
+	setupDBCommandTest(t)
+
+	output, err := runHistoryCommand(t, "1")
+	if err != nil {
+		t.Fatalf("history failed: %v\n%s", err, output)
+	}
+	if !strings.Contains(output, "No timeline events stored for org/alpha#1") {
+		t.Errorf("Expected a hint to sync the timeline, got: %s", output)
+	}
+
+	db, err := internal.InitMultiProjectDBFromPath("./pivot.db")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if err := internal.SaveIssueEvents(db, 1, 1, []internal.IssueEvent{
+		{EventID: 2, Event: "closed", Actor: "alice", CreatedAt: "2024-03-02T09:00:00Z"},
+		{EventID: 1, Event: "reopened", Actor: "bob", CreatedAt: "2024-03-03T09:00:00Z"},
+	}); err != nil {
+		t.Fatalf("Failed to save events: %v", err)
+	}
+	db.Close()
+
+	output, err = runHistoryCommand(t, "1", "--project", "org/alpha")
+	if err != nil {
+		t.Fatalf("history failed: %v\n%s", err, output)
+	}
+	closed, reopened := strings.Index(output, "closed    by alice"), strings.Index(output, "reopened  by bob")
+	if closed < 0 || reopened < closed {
+		t.Errorf("Expected closed before reopened, got: %s", output)
+	}
+
+	if _, err := runHistoryCommand(t, "abc"); err == nil {
+		t.Error("Expected error for an invalid issue number")
+	}
+}
+
+func TestHistoryCommandRemote(t *testing.T) {
+	setupDBCommandTest(t)
+
+	var calls []string
+	old := fetchIssueTimeline
+	fetchIssueTimeline = func(owner, repo, token string, number int) ([]internal.IssueEvent, error) {
+		calls = append(calls, fmt.Sprintf("%s/%s %s #%d", owner, repo, token, number))
+		if number == 9 {
+			return nil, fmt.Errorf("GitHub API error (404) fetching timeline of issue #9")
+		}
+		return []internal.IssueEvent{{Number: number, EventID: 5, Event: "labeled", Label: "bug", Actor: "carol", CreatedAt: "2024-05-01T00:00:00Z"}}, nil
+	}
+	t.Cleanup(func() { fetchIssueTimeline = old })
+
+	output, err := runHistoryCommand(t, "2", "--remote")
+	if err != nil {
+		t.Fatalf("history --remote failed: %v\n%s", err, output)
+	}
+	if len(calls) != 1 || calls[0] != "org/alpha test_token #2" {
+		t.Errorf("Expected one fetch with the configured token, got %v", calls)
+	}
+	if !strings.Contains(output, "History of org/alpha#2 (from GitHub)") || !strings.Contains(output, "labeled   bug  by carol") {
+		t.Errorf("Expected the remote events, got: %s", output)
+	}
+
+	// The remote view does not store anything
+	db, err := internal.InitMultiProjectDBFromPath("./pivot.db")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	if events, _ := internal.GetIssueEvents(db, 1, 2); len(events) != 0 {
+		t.Errorf("Expected --remote not to store events, got %+v", events)
+	}
+
+	if _, err := runHistoryCommand(t, "9", "--remote"); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("Expected the GitHub error, got %v", err)
+	}
+}
+
+func TestSyncCommandIncludeTimelineConflicts(t *testing.T) {
+	for _, args := range [][]string{
+		{"sync", "--include-timeline", "--dry-run"},
+		{"sync", "--include-timeline", "--compare-only"},
+	} {
+		cmd := NewRootCommand()
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--include-timeline cannot be used") {
+			t.Errorf("Expected conflict error for %v, got %v", args, err)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/rhino11/pivot/internal"
+	"github.com/rhino11/pivot/internal/csv"
+	"github.com/spf13/cobra"
+)
+
+// createImportJiraCommand creates the import jira command that imports a Jira CSV export
+func createImportJiraCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "jira <export.csv>",
+		Short: "Import issues from a Jira CSV export",
+		Long: `Import issues from a CSV file exported from Jira ("Export > CSV").
+
+The Jira columns are mapped to issue fields:
+  Issue key     -> external_id (kept to trace issues back to Jira)
+  Summary       -> title
+  Description   -> body
+  Status        -> state
+  Labels        -> labels (all Labels columns are merged)
+  Assignee      -> assignee
+  Priority      -> priority
+  Story Points  -> story_points (decimals are rounded)
+  Epic Link     -> epic
+The "Custom field (Story Points)" and "Custom field (Epic Link)" variants are
+recognized too. Other columns are ignored.
+
+Common Jira statuses such as To Do, In Progress, Done, Resolved and Won't Do map
+to open or closed. Map other workflow statuses with --state-map; unknown ones
+import as open with a warning.
+
+Examples:
+  pivot import jira --dry-run jira-export.csv
+  pivot import jira --repository myorg/myrepo jira-export.csv
+  pivot import jira --state-map blocked=open --repository myorg/myrepo jira-export.csv`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			filePath := args[0]
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			repository, _ := cmd.Flags().GetString("repository")
+			encodingName, _ := cmd.Flags().GetString("encoding")
+			stateMaps, _ := cmd.Flags().GetStringArray("state-map")
+			delay, _ := cmd.Flags().GetDuration("delay")
+
+			if _, err := os.Stat(filePath); os.IsNotExist(err) {
+				return fmt.Errorf("Jira export not found: %s", filePath)
+			}
+			encoding, err := csv.ParseEncoding(encodingName)
+			if err != nil {
+				return err
+			}
+			stateMap, err := csv.ParseStateMap(stateMaps)
+			if err != nil {
+				return err
+			}
+			if delay < 0 {
+				return fmt.Errorf("--delay must not be negative, got %s", delay)
+			}
+
+			config := &csv.ImportConfig{
+				FilePath:   filePath,
+				Repository: repository,
+				DryRun:     dryRun,
+				Encoding:   encoding,
+				StateMap:   stateMap,
+				Delay:      delay,
+			}
+
+			issues, err := csv.ParseJiraCSV(filePath, config)
+			if err != nil {
+				return fmt.Errorf("Jira export parsing failed: %w", err)
+			}
+			cmd.Printf("✓ Parsed %d issues from Jira export\n", len(issues))
+			for _, issue := range issues {
+				for _, warning := range issue.Warnings {
+					cmd.Printf("⚠ %s\n", warning)
+				}
+			}
+
+			if dryRun {
+				cmd.Println("\n🧪 Dry Run Mode - No issues will be created")
+				cmd.Println("==========================================")
+				for _, issue := range issues {
+					cmd.Printf("Would create: %s %s [%s]\n", issue.ExternalID, issue.Title, issue.State)
+				}
+				cmd.Printf("\nTotal: %d issues would be created\n", len(issues))
+				return nil
+			}
+
+			if repository == "" {
+				return fmt.Errorf("repository flag is required for import (use --repository owner/repo)")
+			}
+			repoParts := strings.Split(repository, "/")
+			if len(repoParts) != 2 {
+				return fmt.Errorf("repository must be in format 'owner/repo', got: %s", repository)
+			}
+
+			cfg, err := internal.LoadMultiProjectConfig()
+			if err != nil {
+				return fmt.Errorf("failed to load configuration: %w (run 'pivot init' to set up config)", err)
+			}
+			config.Validation = cfg.Push.Validation
+			config.AddFooter = cfg.Import.AddFooter
+			config.Version = version
+			if db := openImportDB(cmd); db != nil {
+				defer db.Close()
+				config.DB = db
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+			config.Context = ctx
+
+			cmd.Println("\n🚀 Starting import to GitHub...")
+			result, err := csv.ImportJiraToGitHub(filePath, repoParts[0], repoParts[1],
+				internal.RepositoryToken(cfg, repoParts[0], repoParts[1]), config)
+			if err != nil && result == nil {
+				return fmt.Errorf("GitHub import failed: %w", err)
+			}
+
+			printImportSummary(cmd, result)
+			if err != nil {
+				return fmt.Errorf("GitHub import failed: %w", err)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("dry-run", false, "Show what would be imported without making changes")
+	cmd.Flags().String("repository", "", "Target GitHub repository (e.g., owner/repo)")
+	registerProjectCompletion(cmd, "repository")
+	cmd.Flags().String("encoding", csv.EncodingUTF8, "CSV file encoding: utf-8, latin1, windows-1252, utf-16le or utf-16be")
+	cmd.Flags().StringArray("state-map", []string{}, "Map a Jira status to open or closed (format: status=state, repeatable)")
+	cmd.Flags().Duration("delay", csv.DefaultImportDelay, "Pause between issue creations, plus up to 50% random jitter")
+
+	return cmd
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestImportJiraDryRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jira.csv")
+	content := "Summary,Issue key,Status,Labels,Labels,Custom field (Story Points)\nLogin fails,PROJ-1,Done,auth,web,2.0\nShip it,PROJ-2,Blocked,,,\n"
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write Jira export: %v", err)
+	}
+
+	output := &bytes.Buffer{}
+	cmd := NewRootCommand()
+	cmd.SetOut(output)
+	cmd.SetErr(output)
+	cmd.SetArgs([]string{"import", "jira", "--dry-run", path})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("import jira --dry-run failed: %v\n%s", err, output.String())
+	}
+
+	for _, want := range []string{
+		"Parsed 2 issues from Jira export",
+		"unknown state 'Blocked'",
+		"Would create: PROJ-1 Login fails [closed]",
+		"Would create: PROJ-2 Ship it [open]",
+		"Total: 2 issues would be created",
+	} {
+		if !strings.Contains(output.String(), want) {
+			t.Errorf("Expected output to contain %q, got: %s", want, output.String())
+		}
+	}
+}
+
+func TestImportJiraRequiresRepository(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jira.csv")
+	if err := os.WriteFile(path, []byte("Summary,Issue key\nLogin fails,PROJ-1\n"), 0600); err != nil {
+		t.Fatalf("Failed to write Jira export: %v", err)
+	}
+
+	cmd := NewRootCommand()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"import", "jira", path})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "repository flag is required") {
+		t.Errorf("Expected missing repository error, got %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/rhino11/pivot/internal"
+	"github.com/rhino11/pivot/internal/csv"
+	"github.com/spf13/cobra"
+)
+
+// openImportDB opens the local database an import records the created issues in. The
+// import still runs without it.
+func openImportDB(cmd *cobra.Command) *sql.DB {
+	db, err := internal.OpenConfiguredDB()
+	if err != nil {
+		cmd.Printf("⚠ Created issues will not be recorded locally: %v\n", err)
+		return nil
+	}
+	return db
+}
+
+// printImportPreview lists the issues of an import in the order they would be created,
+// the first count of them or all when count is 0, followed by how many were left out
+func printImportPreview(cmd *cobra.Command, issues []*csv.Issue, count int) {
+	cmd.Println("\n📋 Import Preview:")
+	cmd.Println("=================")
+
+	shown := issues
+	if count > 0 && count < len(issues) {
+		shown = issues[:count]
+	}
+	for i, issue := range shown {
+		cmd.Printf("%d. %s [%s] - %s\n", i+1, issue.Title, issue.State, strings.Join(issue.Labels, ", "))
+	}
+	if remaining := len(issues) - len(shown); remaining > 0 {
+		cmd.Printf("... and %d more issues (use --preview-count 0 to show all)\n", remaining)
+	}
+}
+
+// printImportSummary prints the outcome of a CSV import, including partial aborted imports
+func printImportSummary(cmd *cobra.Command, result *csv.ImportResult) {
+	if result.Aborted {
+		cmd.Printf("❌ Import aborted!\n")
+	} else {
+		cmd.Printf("✅ Import complete!\n")
+	}
+	cmd.Printf("   Total issues: %d\n", result.Total)
+	cmd.Printf("   Created: %d\n", result.Created)
+	if result.Updated > 0 {
+		cmd.Printf("   Updated: %d\n", result.Updated)
+	}
+	cmd.Printf("   Skipped: %d\n", result.Skipped)
+	if len(result.Duplicates) > 0 {
+		cmd.Printf("   Duplicates: %d (included in skipped)\n", len(result.Duplicates))
+	}
+	for _, reason := range result.SkipReasons {
+		cmd.Printf("     - %s\n", reason)
+	}
+	cmd.Printf("   On error: %s\n", result.OnError)
+	if len(result.Errors) > 0 {
+		cmd.Printf("   Errors: %d\n", len(result.Errors))
+		for _, err := range result.Errors {
+			cmd.Printf("     - %s\n", err)
+		}
+	}
+	for _, warning := range result.Warnings {
+		cmd.Printf("⚠ %s\n", warning)
+	}
+}
+
+// printRepositoryImportSummary prints the outcome of an import spread over several
+// repositories, one summary per repository followed by the totals
+func printRepositoryImportSummary(cmd *cobra.Command, results []csv.RepositoryImport) {
+	created, failed := 0, 0
+	for _, repository := range results {
+		cmd.Printf("\n📦 %s\n", repository.Repository)
+		printImportSummary(cmd, repository.Result)
+		created += repository.Result.Created
+		failed += len(repository.Result.Errors)
+	}
+	cmd.Printf("\n📊 Created %d issues in %d repositories (%d errors)\n", created, len(results), failed)
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/rhino11/pivot/internal/csv"
+	"github.com/spf13/cobra"
+)
+
+// TestPrintImportSummary tests the summary for completed and aborted imports
+func TestPrintImportSummary(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   *csv.ImportResult
+		expected []string
+	}{
+		{
+			name:     "Completed",
+			result:   &csv.ImportResult{Total: 3, Created: 2, OnError: csv.OnErrorContinue, Errors: []string{"Failed to create issue 'Bad'"}},
+			expected: []string{"✅ Import complete!", "Created: 2", "On error: continue", "Errors: 1", "Failed to create issue 'Bad'"},
+		},
+		{
+			name:     "Aborted",
+			result:   &csv.ImportResult{Total: 3, Created: 1, OnError: csv.OnErrorAbort, Aborted: true, Errors: []string{"Failed to create issue 'Bad'"}},
+			expected: []string{"❌ Import aborted!", "Created: 1", "On error: abort"},
+		},
+		{
+			name: "Mixed",
+			result: &csv.ImportResult{Total: 5, Created: 1, Updated: 1, Skipped: 2, OnError: csv.OnErrorContinue,
+				SkipReasons: []string{"Skipped duplicate issue 'Dup'"}, Duplicates: []*csv.Issue{{Title: "Dup"}},
+				Errors: []string{"Failed to create issue 'Bad'"}},
+			expected: []string{"Updated: 1", "Skipped: 2", "Duplicates: 1 (included in skipped)", "- Skipped duplicate issue 'Dup'", "Errors: 1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			cmd := &cobra.Command{}
+			cmd.SetOut(output)
+
+			printImportSummary(cmd, tt.result)
+			for _, want := range tt.expected {
+				if !strings.Contains(output.String(), want) {
+					t.Errorf("Expected output to contain %q, got:\n%s", want, output.String())
+				}
+			}
+		})
+	}
+}
+
+// TestImportCSVCommandInvalidOnError tests that unknown --on-error modes are rejected
+func TestImportCSVCommandInvalidOnError(t *testing.T) {
+	output := &bytes.Buffer{}
+	cmd := NewRootCommand()
+	cmd.SetOut(output)
+	cmd.SetErr(output)
+	cmd.SetArgs([]string{"import", "csv", "--on-error", "retry", "main_test.go"})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "invalid error mode 'retry'") {
+		t.Errorf("Expected invalid error mode error, got %v", err)
+	}
+}
+
+// TestPrintRepositoryImportSummary tests the per-repository summary of a multi-repository import
+func TestPrintRepositoryImportSummary(t *testing.T) {
+	output := &bytes.Buffer{}
+	cmd := &cobra.Command{}
+	cmd.SetOut(output)
+
+	printRepositoryImportSummary(cmd, []csv.RepositoryImport{
+		{Repository: "acme/api", Result: &csv.ImportResult{Total: 2, Created: 2, OnError: csv.OnErrorContinue}},
+		{Repository: "acme/web", Result: &csv.ImportResult{Total: 2, Created: 1, OnError: csv.OnErrorContinue, Errors: []string{"Failed to create issue 'Bad'"}}},
+	})
+
+	text := output.String()
+	api, web := strings.Index(text, "📦 acme/api"), strings.Index(text, "📦 acme/web")
+	if api < 0 || web < api {
+		t.Fatalf("Expected a section per repository in order, got:\n%s", text)
+	}
+	if !strings.Contains(text[web:], "Failed to create issue 'Bad'") || strings.Contains(text[api:web], "Bad") {
+		t.Errorf("Expected the error under acme/web only, got:\n%s", text)
+	}
+	if !strings.Contains(text, "Created 3 issues in 2 repositories (1 errors)") {
+		t.Errorf("Expected the totals, got:\n%s", text)
+	}
+}
+
+// TestPrintImportPreview tests that the preview honors the count and reports the rest
+func TestPrintImportPreview(t *testing.T) {
+	var issues []*csv.Issue
+	for _, title := range []string{"First", "Second", "Third", "Fourth"} {
+		issues = append(issues, &csv.Issue{Title: title, State: "open", Labels: []string{"bug"}})
+	}
+
+	tests := []struct {
+		name      string
+		count     int
+		shown     []string
+		hidden    []string
+		remaining string
+	}{
+		{name: "Subset", count: 2, shown: []string{"1. First [open] - bug", "2. Second"}, hidden: []string{"Third", "Fourth"},
+			remaining: "... and 2 more issues (use --preview-count 0 to show all)"},
+		{name: "All", count: 0, shown: []string{"1. First", "4. Fourth"}},
+		{name: "CountAboveTotal", count: 10, shown: []string{"4. Fourth"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			cmd := &cobra.Command{}
+			cmd.SetOut(output)
+			printImportPreview(cmd, issues, tt.count)
+
+			for _, want := range tt.shown {
+				if !strings.Contains(output.String(), want) {
+					t.Errorf("Expected preview to contain %q, got: %s", want, output.String())
+				}
+			}
+			for _, unwanted := range tt.hidden {
+				if strings.Contains(output.String(), unwanted) {
+					t.Errorf("Expected preview not to contain %q, got: %s", unwanted, output.String())
+				}
+			}
+			if tt.remaining == "" && strings.Contains(output.String(), "more issues") {
+				t.Errorf("Expected no remainder line, got: %s", output.String())
+			}
+			if tt.remaining != "" && !strings.Contains(output.String(), tt.remaining) {
+				t.Errorf("Expected remainder %q, got: %s", tt.remaining, output.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/rhino11/pivot/internal"
+	"github.com/spf13/cobra"
+)
+
+// deleteRemoteLabel deletes a label on GitHub; replaced in tests
+var deleteRemoteLabel = internal.DeleteRemoteLabel
+
+// createLabelsCommand creates the labels command for managing issue labels
+func createLabelsCommand() *cobra.Command {
+	labelsCmd := &cobra.Command{
+		Use:   "labels",
+		Short: "Manage issue labels",
+		Long:  `Manage the labels of the issues stored in the local database and on GitHub.`,
+	}
+	labelsCmd.AddCommand(createLabelsDeleteCommand())
+	return labelsCmd
+}
+
+// createLabelsDeleteCommand creates the labels delete command
+func createLabelsDeleteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete a label from the stored issues and optionally from GitHub",
+		Long: `Delete a label from every issue of a project in the local database. Labels
+are matched case-insensitively, like GitHub does.
+
+Use --remote to also delete the label from the GitHub repository, which removes
+it from every issue and pull request there. The remote label is deleted first, so
+the local database is left unchanged when GitHub rejects the request.
+
+The command reports how many open and closed issues carry the label and refuses
+to delete a label that is still applied to open issues unless --force is given.
+
+--project is required when the database holds more than one project.
+
+Examples:
+  pivot labels delete wontfix
+  pivot labels delete "needs triage" --remote
+  pivot labels delete bug --project myorg/myrepo --remote --force`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			projectSpec, _ := cmd.Flags().GetString("project")
+			remote, _ := cmd.Flags().GetBool("remote")
+			force, _ := cmd.Flags().GetBool("force")
+
+			db, err := internal.OpenConfiguredDB()
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			project, err := resolveShowProject(db, projectSpec)
+			if err != nil {
+				return err
+			}
+			projectID := int64(project.ID)
+			fullName := project.Owner + "/" + project.Repo
+
+			usage, err := internal.CountLabelUsage(db, projectID, name)
+			if err != nil {
+				return err
+			}
+			cmd.Printf("🔍 Label '%s' in %s is applied to %d open and %d closed issues\n", name, fullName, usage.Open, usage.Closed)
+			if usage.Open > 0 && !force {
+				return fmt.Errorf("label '%s' is still applied to %d open issues; use --force to delete it anyway", name, usage.Open)
+			}
+
+			if remote {
+				token, err := storedProjectToken(project)
+				if err != nil {
+					return err
+				}
+				if err := deleteRemoteLabel(project.Owner, project.Repo, token, name); err != nil {
+					return err
+				}
+				cmd.Printf("✓ Deleted label '%s' from %s on GitHub\n", name, fullName)
+			}
+
+			changed, err := internal.RemoveLabelLocally(db, projectID, name)
+			if err != nil {
+				return err
+			}
+			cmd.Printf("✓ Removed label '%s' from %d stored issues\n", name, changed)
+			return nil
+		},
+	}
+
+	cmd.Flags().String("project", "", "Project as owner/repo (required when the database holds several projects)")
+	cmd.Flags().Bool("remote", false, "Also delete the label from the GitHub repository")
+	cmd.Flags().Bool("force", false, "Delete the label even when it is applied to open issues")
+	return cmd
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rhino11/pivot/internal"
+)
+
+// seedLabeledIssues replaces the issues of setupDBCommandTest with labeled ones:
+// #1 open with bug, #2 closed with bug and ui
+func seedLabeledIssues(t *testing.T) {
+	t.Helper()
+	db, err := internal.InitMultiProjectDBFromPath("./pivot.db")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	for _, issue := range []*internal.DBIssue{
+		{ID: 1, Number: 1, Title: "Open", State: "open", Labels: "bug"},
+		{ID: 2, Number: 2, Title: "Closed", State: "closed", Labels: "bug,ui"},
+	} {
+		if err := internal.SaveIssue(db, 1, issue); err != nil {
+			t.Fatalf("Failed to save issue: %v", err)
+		}
+	}
+}
+
+func runLabelsCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	output := &bytes.Buffer{}
+	cmd := NewRootCommand()
+	cmd.SetOut(output)
+	cmd.SetErr(output)
+	cmd.SetArgs(append([]string{"labels"}, args...))
+	err := cmd.Execute()
+	return output.String(), err
+}
+
+// storedLabels returns the labels of the stored issues by number
+func storedLabels(t *testing.T) map[int]string {
+	t.Helper()
+	db, err := internal.InitMultiProjectDBFromPath("./pivot.db")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	issues, err := internal.GetIssuesForProject(db, 1)
+	if err != nil {
+		t.Fatalf("Failed to get issues: %v", err)
+	}
+	labels := make(map[int]string)
+	for _, issue := range issues {
+		labels[issue.Number] = issue.Labels
+	}
+	return labels
+}
+
+// stubDeleteRemoteLabel records the remote deletes and fails them with err
+func stubDeleteRemoteLabel(t *testing.T, err error) *[]string {
+	t.Helper()
+	calls := &[]string{}
+	old := deleteRemoteLabel
+	deleteRemoteLabel = func(owner, repo, token, name string) error {
+		*calls = append(*calls, fmt.Sprintf("%s/%s %s %s", owner, repo, token, name))
+		return err
+	}
+	t.Cleanup(func() { deleteRemoteLabel = old })
+	return calls
+}
+
+func TestLabelsDeleteLocal(t *testing.T) {
+	setupDBCommandTest(t)
+	seedLabeledIssues(t)
+	calls := stubDeleteRemoteLabel(t, nil)
+
+	output, err := runLabelsCommand(t, "delete", "UI")
+	if err != nil {
+		t.Fatalf("labels delete failed: %v\n%s", err, output)
+	}
+	for _, want := range []string{"applied to 0 open and 1 closed issues", "Removed label 'UI' from 1 stored issues"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got: %s", want, output)
+		}
+	}
+	if labels := storedLabels(t); labels[1] != "bug" || labels[2] != "bug" {
+		t.Errorf("Expected only ui to be removed, got %v", labels)
+	}
+	if len(*calls) != 0 {
+		t.Errorf("Expected no remote delete without --remote, got %v", *calls)
+	}
+}
+
+func TestLabelsDeleteRefusesLabelOnOpenIssues(t *testing.T) {
+	setupDBCommandTest(t)
+	seedLabeledIssues(t)
+	calls := stubDeleteRemoteLabel(t, nil)
+
+	output, err := runLabelsCommand(t, "delete", "bug", "--remote")
+	if err == nil || !strings.Contains(err.Error(), "still applied to 1 open issues; use --force") {
+		t.Fatalf("Expected refusal for a label on open issues, got %v\n%s", err, output)
+	}
+	if !strings.Contains(output, "applied to 1 open and 1 closed issues") {
+		t.Errorf("Expected the usage counts, got: %s", output)
+	}
+	if labels := storedLabels(t); labels[1] != "bug" || labels[2] != "bug,ui" {
+		t.Errorf("Expected labels to be left unchanged, got %v", labels)
+	}
+	if len(*calls) != 0 {
+		t.Errorf("Expected no remote delete after the refusal, got %v", *calls)
+	}
+}
+
+func TestLabelsDeleteForcedRemote(t *testing.T) {
+	setupDBCommandTest(t)
+	seedLabeledIssues(t)
+	calls := stubDeleteRemoteLabel(t, nil)
+
+	output, err := runLabelsCommand(t, "delete", "bug", "--remote", "--force", "--project", "org/alpha")
+	if err != nil {
+		t.Fatalf("labels delete --remote --force failed: %v\n%s", err, output)
+	}
+	if len(*calls) != 1 || (*calls)[0] != "org/alpha test_token bug" {
+		t.Errorf("Expected one remote delete with the configured token, got %v", *calls)
+	}
+	for _, want := range []string{"Deleted label 'bug' from org/alpha on GitHub", "Removed label 'bug' from 2 stored issues"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got: %s", want, output)
+		}
+	}
+	if labels := storedLabels(t); labels[1] != "" || labels[2] != "ui" {
+		t.Errorf("Expected bug to be removed from both issues, got %v", labels)
+	}
+}
+
+func TestLabelsDeleteRemoteFailureKeepsLocalLabels(t *testing.T) {
+	setupDBCommandTest(t)
+	seedLabeledIssues(t)
+	stubDeleteRemoteLabel(t, fmt.Errorf("label 'ui' not found in org/alpha"))
+
+	if _, err := runLabelsCommand(t, "delete", "ui", "--remote"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("Expected the remote error, got %v", err)
+	}
+	if labels := storedLabels(t); labels[2] != "bug,ui" {
+		t.Errorf("Expected local labels to be kept when the remote delete fails, got %v", labels)
+	}
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rhino11/pivot/internal"
+	"github.com/spf13/cobra"
+)
+
+// createListCommand creates the list command that shows issues from the local database
+func createListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List issues from the local database",
+		Long: `List issues stored in the local database with filtering, sorting and pagination.
+
+Sorting and pagination are performed by the database, so large local
+databases stay fast.
+
+Examples:
+  pivot list
+  pivot list --state open --sort updated --desc
+  pivot list --limit 20 --offset 40
+  pivot list --project myorg/myrepo
+  pivot list --map-state in-progress=in_progress
+  pivot list --columns number,title,state,labels --max-width 30
+  pivot list --stale 30d
+  pivot list --blocked
+
+Open issues can be shown with a derived workflow state based on their labels,
+configured under state_mapping in config.yml or with --map-state. GitHub's
+open/closed state is never changed.
+
+--stale lists only open issues that have not been updated for the given age,
+e.g. 30d, 2w or a Go duration such as 36h.
+
+--blocked lists open issues that depend on an issue that is still open, and
+--blocking lists open issues that an open issue depends on. Dependencies come
+from "Depends on: #12, #15" lines in issue bodies and depends_on in issue files;
+they refer to issue numbers in the same project, or to local IDs of issues that
+have not been pushed yet.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			project, _ := cmd.Flags().GetString("project")
+			state, _ := cmd.Flags().GetString("state")
+			sortKey, _ := cmd.Flags().GetString("sort")
+			desc, _ := cmd.Flags().GetBool("desc")
+			limit, _ := cmd.Flags().GetInt("limit")
+			offset, _ := cmd.Flags().GetInt("offset")
+			mapState, _ := cmd.Flags().GetStringArray("map-state")
+			columnSpec, _ := cmd.Flags().GetString("columns")
+			maxWidth, _ := cmd.Flags().GetInt("max-width")
+			staleSpec, _ := cmd.Flags().GetString("stale")
+			blocked, _ := cmd.Flags().GetBool("blocked")
+			blocking, _ := cmd.Flags().GetBool("blocking")
+
+			var staleAge time.Duration
+			if staleSpec != "" {
+				age, err := internal.ParseAge(staleSpec)
+				if err != nil {
+					return fmt.Errorf("invalid --stale: %w", err)
+				}
+				if state != "" && state != "open" {
+					return fmt.Errorf("--stale only lists open issues and cannot be used with --state %s", state)
+				}
+				staleAge = age
+			}
+
+			if (blocked || blocking) && state != "" && state != "open" {
+				return fmt.Errorf("--blocked and --blocking only list open issues and cannot be used with --state %s", state)
+			}
+
+			var columns []string
+			if columnSpec != "" {
+				parsed, err := internal.ParseListColumns(columnSpec)
+				if err != nil {
+					return err
+				}
+				columns = parsed
+			}
+
+			// Inline mappings take precedence over configured ones
+			mappings, err := internal.ParseStateMappings(mapState)
+			if err != nil {
+				return err
+			}
+			mappings = append(mappings, internal.ConfiguredStateMappings()...)
+
+			db, err := internal.OpenConfiguredDB()
+			if err != nil {
+				return fmt.Errorf("failed to connect to database: %w", err)
+			}
+			defer db.Close()
+
+			opts := internal.ListOptions{
+				State:  state,
+				Sort:   sortKey,
+				Desc:   desc,
+				Limit:  limit,
+				Offset: offset,
+
+				Blocked:  blocked,
+				Blocking: blocking,
+			}
+			if staleAge > 0 {
+				opts.State = "open"
+				opts.UpdatedBefore = time.Now().Add(-staleAge)
+			}
+
+			if project != "" {
+				parts := strings.Split(project, "/")
+				if len(parts) != 2 {
+					return fmt.Errorf("project must be in format 'owner/repo', got: %s", project)
+				}
+				found, err := internal.FindProjectByOwnerRepo(db, parts[0], parts[1])
+				if err != nil {
+					return err
+				}
+				opts.ProjectID = int64(found.ID)
+			}
+
+			issues, err := internal.ListIssues(db, opts)
+			if err != nil {
+				return fmt.Errorf("failed to list issues: %w", err)
+			}
+
+			if len(issues) == 0 {
+				cmd.Println("No issues found.")
+				return nil
+			}
+
+			if columns != nil {
+				cmd.Print(internal.RenderIssueTable(issues, columns, mappings, maxWidth))
+				cmd.Printf("\nShowing %d issues\n", len(issues))
+				return nil
+			}
+
+			for _, issue := range issues {
+				cmd.Printf("#%-6d %-11s %s", issue.Number, internal.DisplayState(issue, mappings), issue.Title)
+				if issue.Labels != "" {
+					cmd.Printf(" [%s]", issue.Labels)
+				}
+				if blocked && issue.Dependencies != "" {
+					cmd.Printf(" (depends on #%s)", strings.ReplaceAll(issue.Dependencies, ",", ", #"))
+				}
+				cmd.Println()
+			}
+			cmd.Printf("\nShowing %d issues\n", len(issues))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("project", "", "List issues for a specific project (format: owner/repo)")
+	registerProjectCompletion(cmd, "project")
+	cmd.Flags().String("state", "", "Filter by issue state (open, closed)")
+	cmd.Flags().String("sort", "number", "Sort by: "+strings.Join(internal.ListSortKeys(), ", "))
+	cmd.Flags().Bool("desc", false, "Sort in descending order")
+	cmd.Flags().Int("limit", 0, "Maximum number of issues to show (0 = no limit)")
+	cmd.Flags().Int("offset", 0, "Number of issues to skip")
+	cmd.Flags().String("columns", "", "Comma-separated columns to show: "+strings.Join(internal.ListColumnNames(), ", "))
+	cmd.Flags().Int("max-width", internal.DefaultColumnWidth, "Truncate cells longer than this with --columns (0 = no limit)")
+	cmd.Flags().String("stale", "", "Only list open issues not updated for this age, e.g. 30d, 2w or 36h")
+	cmd.Flags().Bool("blocked", false, "Only list open issues with a dependency that is still open")
+	cmd.Flags().Bool("blocking", false, "Only list open issues that an open issue depends on")
+	cmd.Flags().StringArray("map-state", []string{}, "Show open issues with these labels in a derived state (format: label=state, repeatable)")
+
+	return cmd
+}
+
+// printWorkflowStates prints issue counts by derived display state from the configured database
+func printWorkflowStates(cmd *cobra.Command, mappings []internal.StateMapping) {
+	db, err := internal.OpenConfiguredDB()
+	if err != nil {
+		return
+	}
+	defer db.Close()
+
+	issues, err := internal.GetAllIssues(db)
+	if err != nil {
+		return
+	}
+
+	counts := internal.CountDisplayStates(issues, mappings)
+	states := make([]string, 0, len(counts))
+	for state := range counts {
+		states = append(states, state)
+	}
+	sort.Strings(states)
+
+	cmd.Println("\n🏷  Workflow States")
+	for _, state := range states {
+		cmd.Printf("  %s: %d issues\n", state, counts[state])
+	}
+}
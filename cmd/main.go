@@ -2,8 +2,11 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
 	"strings"
+	"time"
 
 	"github.com/rhino11/pivot/internal"
 	"github.com/rhino11/pivot/internal/csv"
@@ -21,6 +24,12 @@ func NewRootCommand() *cobra.Command {
 		Use:   "pivot",
 		Short: "GitHub Issues Management CLI",
 		Long:  `Pivot is a CLI tool for managing GitHub issues locally with offline sync capabilities.`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// One request budget shared by sync, import, push and every other GitHub call
+			rate, _ := cmd.Flags().GetFloat64("rate")
+			concurrency, _ := cmd.Flags().GetInt("concurrency")
+			return internal.SetRequestLimits(rate, concurrency)
+		},
 	}
 
 	var initCmd = &cobra.Command{
@@ -63,20 +72,32 @@ func NewRootCommand() *cobra.Command {
 			fmt.Println("Initializing local issues database...")
 
 			// Try to load as multi-project config first
-			if _, err := internal.LoadMultiProjectConfig(); err == nil {
+			var dbPath string
+			if multiConfig, err := internal.LoadMultiProjectConfig(); err == nil {
 				// Multi-project database initialization
 				if err := internal.InitMultiProjectDatabase(); err != nil {
 					return fmt.Errorf("multi-project database init failed: %w", err)
 				}
+				dbPath = multiConfig.Global.Database
 			} else {
 				// Legacy single-project database initialization
 				if err := internal.Init(); err != nil {
 					return fmt.Errorf("database init failed: %w", err)
 				}
+				dbPath = "./pivot.db"
+				if cfg, err := internal.LoadConfig(); err == nil {
+					dbPath = cfg.Database
+				}
 			}
 
 			fmt.Println("✓ Initialized local issues database.")
 
+			if noGitignore, _ := cmd.Flags().GetBool("no-gitignore"); !noGitignore {
+				if err := internal.OfferGitignoreEntry(dbPath); err != nil {
+					fmt.Printf("⚠ Could not update .gitignore: %v\n", err)
+				}
+			}
+
 			fmt.Println()
 			fmt.Println("🎉 Pivot is ready to use!")
 			fmt.Println("Run 'pivot sync' to fetch your GitHub issues.")
@@ -151,29 +172,383 @@ func NewRootCommand() *cobra.Command {
 		},
 	}
 
+	var configAddOrgCmd = &cobra.Command{
+		Use:   "add-org <org>",
+		Short: "Add every repository of an organization as a project",
+		Long: `Add every repository of a GitHub organization that the global token can see
+as a project in config.yml. Repositories that are already configured are left
+alone.
+
+Use --skip-archived and --skip-forks to leave out archived repositories and
+forks. Their defaults come from the discovery section of config.yml:
+
+  discovery:
+    skip_archived: true
+    skip_forks: true
+
+Use --dry-run to list what would be added without writing config.yml.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var skipArchived, skipForks *bool
+			if cmd.Flags().Changed("skip-archived") {
+				value, _ := cmd.Flags().GetBool("skip-archived")
+				skipArchived = &value
+			}
+			if cmd.Flags().Changed("skip-forks") {
+				value, _ := cmd.Flags().GetBool("skip-forks")
+				skipForks = &value
+			}
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+			if err := internal.AddOrgProjects(args[0], skipArchived, skipForks, dryRun); err != nil {
+				return fmt.Errorf("failed to add organization: %w", err)
+			}
+			return nil
+		},
+	}
+
 	var configImportCmd = &cobra.Command{
 		Use:   "import <file>",
 		Short: "Import configuration from file",
-		Long:  `Import Pivot configuration from a YAML file.`,
-		Args:  cobra.ExactArgs(1),
+		Long: `Import Pivot configuration from a YAML file.
+
+When config.yml already exists, the two configurations are merged in one of
+two modes:
+
+  default           Imported values win. The imported token and database replace
+                    the current ones, and an imported project replaces the
+                    configured project with the same owner/repo. You are asked
+                    before merging; answering no replaces config.yml.
+  --preserve-local  Current values win. The current token, database and project
+                    settings are kept, and imported values only fill settings
+                    that are empty. Projects not configured yet are still added.
+                    The merge happens without asking.
+
+Use --dry-run to preview the resulting configuration (merged with the current
+config.yml when it exists) as a diff without writing anything. Tokens and the
+notify webhook URL are masked; a changed one is shown as '*** (changed)'.
+
+Examples:
+  pivot config import team.yml
+  pivot config import team.yml --preserve-local --dry-run`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			filePath := args[0]
-			if err := internal.ImportConfigFile(filePath); err != nil {
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			preserveLocal, _ := cmd.Flags().GetBool("preserve-local")
+			opts := internal.ConfigImportOptions{PreserveLocal: preserveLocal}
+			if dryRun {
+				if err := internal.ImportConfigFileDryRun(filePath, opts); err != nil {
+					return fmt.Errorf("config import preview failed: %w", err)
+				}
+				return nil
+			}
+			if err := internal.ImportConfigFileWithOptions(filePath, opts); err != nil {
 				return fmt.Errorf("config import failed: %w", err)
 			}
 			return nil
 		},
 	}
 
+	var configDoctorTokenCmd = &cobra.Command{
+		Use:   "doctor-token",
+		Short: "Explain the scopes of configured GitHub tokens",
+		Long: `Inspect the OAuth scopes granted to each configured GitHub token and check
+them against the visibility of the configured projects.
+
+Private repositories require the 'repo' scope; public repositories require
+'public_repo' (or 'repo').`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := internal.DoctorToken(); err != nil {
+				return fmt.Errorf("token check failed: %w", err)
+			}
+			return nil
+		},
+	}
+
 	var syncCmd = &cobra.Command{
 		Use:   "sync",
 		Short: "Sync issues between upstream and local database",
+		Long: `Sync issues between upstream and local database.
+
+Use --repo and --token to sync a single repository into the default database
+without writing a config file. Without --repo, --token replaces the project and
+global tokens from config.yml for this run only; it is never saved and is
+scrubbed from error output.
+
+By default only issues updated since the last successful sync of each project
+are fetched. Use --reset-watermark to fetch everything again, or
+--since-last-success=false for a one-off full sync.
+
+Use --assigned-to-me to fetch only the issues assigned to the user the token
+belongs to, or --assignee to fetch the issues of a specific login.
+
+Use --compare-only to write a report of every issue that differs between the
+local database and GitHub without changing the database. The report is JSON,
+or markdown when --report ends in .md.
+
+Use --select to keep only a slice of a large repository locally, e.g.
+--select "label:team-a". This is a local projection, not a server-side filter:
+every issue is still fetched from GitHub, but only matching issues are stored.
+Issues already stored are kept even when they no longer match. Such a sync does
+not advance the watermark, so widening or dropping the selection later still
+fetches the issues it skipped. Filter keys: state, label, assignee, milestone,
+epic and title.
+
+Use --explain to write one JSON line per fetched issue to stderr with the sync
+decision (created, updated, conflict or skipped), the reason and its inputs:
+the stored and remote sync hashes, the remote updated_at, the local
+modification time and the --select filter.
+
+An issue whose updated_at from GitHub is older than the stored copy points at a
+stale cache or clock skew. It is reported and left unchanged; use
+--force-overwrite to store the fetched copy anyway.
+
+Before syncing, the remaining GitHub API budget of each token is compared with
+an estimate of the requests the sync needs (from the issues already stored). A
+budget that is too low is reported; use --wait-for-rate-limit to pause until it
+resets instead. --dump-rate-limit prints the current budget and exits.
+
+Use --store-raw to keep the GitHub JSON of each stored issue, shown by
+'pivot show <number> --raw'. It is gzip-compressed unless sync.raw_compression
+in config.yml or --raw-compression is set to none.
+
+Use --resume-from <number> for manual recovery when one issue keeps failing:
+fetched issues are processed in number order starting at the given number, and
+issues numbered below it are left untouched. Such a sync does not advance the
+watermark, so the next regular sync fetches the skipped issues again.
+
+Use --only-new to treat the local database as an append-only capture: fetched
+issues that are not stored yet are inserted, issues already stored (by GitHub
+ID) are left untouched, even when they changed on GitHub. Such a sync does not
+advance the watermark, so the next regular sync fetches the skipped changes.
+
+Use --include-timeline to also store the closed, reopened and labeled events
+of each stored issue, shown by 'pivot history <number>'. Every stored issue
+costs at least one extra API request, so combine it with incremental syncs.
+
+Use --force-conflict-as local|remote to resolve every conflict of this run one
+way, as 'pivot resolve' would: local keeps the local edits queued as local
+modifications, remote replaces them with the GitHub copy. Only this run is
+affected; later syncs mark conflicts CONFLICTED again.
+
+Use --summary-only to print just the counts on one line, e.g. for CI logs.
+
+Use --dry-run to see what a sync would change without changing the database:
+which fetched issues would be new, which would be updated (with the local and
+remote value of each changed field) and which would conflict with local edits.
+With --output json the result is a stable JSON document with a schema_version,
+counts and the per-issue changes, e.g. for a CI bot to post as a PR comment.
+Without --dry-run, --output json prints the sync result as JSON.
+
+Exit codes: 0 when the sync completed without conflicts, 2 when it completed
+with conflicted issues, 3 when projects failed or checksum verification found
+mismatches, and 1 when the sync could not run at all.
+
+Use --notify to fire the completion hooks configured under sync.notify in
+config.yml once the sync finishes: a shell command that receives the sync
+result as JSON on stdin, and/or a webhook the JSON is POSTed to. A failing hook
+is reported as a warning and does not fail the sync.
+
+A renamed or transferred repository is synced from its new location. When stdin
+is a terminal, sync asks whether to record the new owner/repo in config.yml;
+otherwise, and with --repo, --output json or --summary-only, it only warns.
+
+Examples:
+  pivot sync
+  pivot sync --project myorg/myrepo
+  pivot sync --checkpoint
+  pivot sync --reset-watermark
+  pivot sync --assigned-to-me
+  pivot sync --assignee octocat
+  pivot sync --checksum-verify
+  pivot sync --compare-only --report differences.md
+  pivot sync --notify
+  pivot sync --summary-only
+  pivot sync --explain 2> decisions.jsonl
+  pivot sync --select "label:team-a -state:closed"
+  pivot sync --top-reactions 10
+  pivot sync --dump-rate-limit
+  pivot sync --wait-for-rate-limit
+  pivot sync --store-raw
+  pivot sync --project myorg/myrepo --resume-from 1234
+  pivot sync --only-new
+  pivot sync --include-timeline
+  pivot sync --force-conflict-as remote
+  pivot sync --dry-run
+  pivot sync --dry-run --output json > sync-diff.json
+  pivot sync --repo myorg/myrepo --token ghp_xxx
+  pivot sync --project myorg/myrepo --token ghp_xxx`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			project, _ := cmd.Flags().GetString("project")
+			withReactions, _ := cmd.Flags().GetBool("with-reactions")
+			checkpoint, _ := cmd.Flags().GetBool("checkpoint")
+			sinceLastSuccess, _ := cmd.Flags().GetBool("since-last-success")
+			resetWatermark, _ := cmd.Flags().GetBool("reset-watermark")
+			assignee, _ := cmd.Flags().GetString("assignee")
+			assignedToMe, _ := cmd.Flags().GetBool("assigned-to-me")
+			checksumVerify, _ := cmd.Flags().GetBool("checksum-verify")
+			compareOnly, _ := cmd.Flags().GetBool("compare-only")
+			reportPath, _ := cmd.Flags().GetString("report")
+			notify, _ := cmd.Flags().GetBool("notify")
+			selectExpr, _ := cmd.Flags().GetString("select")
+			explain, _ := cmd.Flags().GetBool("explain")
+			topReactions, _ := cmd.Flags().GetInt("top-reactions")
+			forceOverwrite, _ := cmd.Flags().GetBool("force-overwrite")
+			repo, _ := cmd.Flags().GetString("repo")
+			token, _ := cmd.Flags().GetString("token")
+			dumpRateLimit, _ := cmd.Flags().GetBool("dump-rate-limit")
+			waitForRateLimit, _ := cmd.Flags().GetBool("wait-for-rate-limit")
+			storeRaw, _ := cmd.Flags().GetBool("store-raw")
+			rawCompression, _ := cmd.Flags().GetString("raw-compression")
+			resumeFrom, _ := cmd.Flags().GetInt("resume-from")
+			summaryOnly, _ := cmd.Flags().GetBool("summary-only")
+			onlyNew, _ := cmd.Flags().GetBool("only-new")
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			output, _ := cmd.Flags().GetString("output")
+			includeTimeline, _ := cmd.Flags().GetBool("include-timeline")
+			forceConflictAs, _ := cmd.Flags().GetString("force-conflict-as")
+
+			if dumpRateLimit {
+				return dumpRateLimits(cmd, repo, project, token)
+			}
+
+			if assignee != "" && assignedToMe {
+				return fmt.Errorf("--assignee and --assigned-to-me cannot be used together")
+			}
+			if topReactions < 0 {
+				return fmt.Errorf("--top-reactions must not be negative, got %d", topReactions)
+			}
+			if resumeFrom < 0 {
+				return fmt.Errorf("--resume-from must not be negative, got %d", resumeFrom)
+			}
+			if output != "text" && output != "json" {
+				return fmt.Errorf("invalid output format '%s' (valid: text, json)", output)
+			}
+			if dryRun && (compareOnly || checksumVerify || explain || notify || topReactions > 0) {
+				return fmt.Errorf("--dry-run cannot be used with --compare-only, --checksum-verify, --explain, --notify or --top-reactions")
+			}
+			if output == "json" && (summaryOnly || compareOnly || topReactions > 0) {
+				return fmt.Errorf("--output json cannot be used with --summary-only, --compare-only or --top-reactions")
+			}
+			if includeTimeline && (compareOnly || dryRun) {
+				return fmt.Errorf("--include-timeline cannot be used with --compare-only or --dry-run")
+			}
+			if forceConflictAs != "" {
+				if forceConflictAs != internal.ResolutionLocal && forceConflictAs != internal.ResolutionRemote {
+					return fmt.Errorf("invalid --force-conflict-as '%s' (valid: %s, %s)", forceConflictAs, internal.ResolutionLocal, internal.ResolutionRemote)
+				}
+				if compareOnly || dryRun {
+					return fmt.Errorf("--force-conflict-as cannot be used with --compare-only or --dry-run")
+				}
+			}
+			if onlyNew && (compareOnly || forceOverwrite) {
+				return fmt.Errorf("--only-new cannot be used with --compare-only or --force-overwrite")
+			}
+			if summaryOnly && (compareOnly || explain || topReactions > 0) {
+				return fmt.Errorf("--summary-only cannot be used with --compare-only, --explain or --top-reactions")
+			}
+
+			opts := internal.SyncOptions{
+				Checkpoint:       checkpoint,
+				FullSync:         !sinceLastSuccess,
+				ResetWatermark:   resetWatermark,
+				WithReactions:    withReactions,
+				Assignee:         assignee,
+				AssignedToMe:     assignedToMe,
+				ChecksumVerify:   checksumVerify,
+				CompareOnly:      compareOnly,
+				ForceOverwrite:   forceOverwrite,
+				WaitForRateLimit: waitForRateLimit,
+				StoreRaw:         storeRaw,
+				RawCompression:   rawCompression,
+				ResumeFrom:       resumeFrom,
+				OnlyNew:          onlyNew,
+				DryRun:           dryRun,
+				IncludeTimeline:  includeTimeline,
+				ForceConflictAs:  forceConflictAs,
+			}
+			if topReactions > 0 {
+				// The ranking needs fresh reaction counts
+				opts.WithReactions = true
+			}
+			if repo == "" {
+				// Without --repo, --token overrides the configured tokens for this run
+				opts.Token = token
+				opts.ConfirmRepositoryMove = confirmRepositoryMove(cmd, summaryOnly || output == "json")
+			}
+			if !compareOnly {
+				reportPath = ""
+			}
+			if explain {
+				opts.Explain = cmd.ErrOrStderr()
+			}
+			if selectExpr != "" {
+				filter, err := internal.ParseIssueFilter(selectExpr)
+				if err != nil {
+					return err
+				}
+				opts.Select = filter
+			}
+
+			// Flags are valid; a failure from here on should not print the usage
+			cmd.SilenceUsage = true
+
+			// With --summary-only the progress output is hidden and only the counts are printed
+			var counts io.Writer
+			if summaryOnly {
+				counts = cmd.OutOrStdout()
+				restore, err := silenceStdout()
+				if err != nil {
+					return err
+				}
+				defer restore()
+			}
+
+			// With --output json the progress output is hidden and only the JSON is printed
+			var jsonOut io.Writer
+			if output == "json" {
+				jsonOut = cmd.OutOrStdout()
+				restore, err := silenceStdout()
+				if err != nil {
+					return err
+				}
+				defer restore()
+			}
+
+			// Ad-hoc sync of a single repository without a config file
+			if repo != "" {
+				config, err := internal.NewAdHocConfig(repo, token)
+				if err != nil {
+					return err
+				}
+				result, err := internal.SyncAdHoc(config, opts)
+				if err != nil {
+					return fmt.Errorf("sync failed: %w", err)
+				}
+				if notify {
+					notifySyncResult(result)
+				}
+				if err := reportSyncOutput(result, dryRun, jsonOut, reportPath, counts); err != nil {
+					return err
+				}
+				if topReactions > 0 {
+					db, err := internal.InitMultiProjectDBWithSettings(config.Global.Database, config.Database)
+					if err != nil {
+						return fmt.Errorf("failed to open database: %w", err)
+					}
+					defer db.Close()
+					adHoc := config.Projects[0]
+					return printTopReactions(cmd.OutOrStdout(), db, adHoc.Owner+"/"+adHoc.Repo, topReactions)
+				}
+				return nil
+			}
+
+			var result *internal.SyncResult
 
 			// Try to load multi-project config first
 			if _, err := internal.LoadMultiProjectConfig(); err == nil {
-				if err := internal.SyncMultiProject(project); err != nil {
+				if result, err = internal.SyncMultiProjectWithOptions(project, opts); err != nil {
 					return fmt.Errorf("multi-project sync failed: %w", err)
 				}
 			} else {
@@ -185,13 +560,30 @@ func NewRootCommand() *cobra.Command {
 					return fmt.Errorf("sync failed: %w", err)
 				}
 
+				if assignee != "" || assignedToMe || checksumVerify || compareOnly || selectExpr != "" || explain || topReactions > 0 || token != "" || storeRaw || resumeFrom > 0 || onlyNew || dryRun || includeTimeline || forceConflictAs != "" {
+					return fmt.Errorf("assignee filters, --select, --explain, --top-reactions, --token, --store-raw, --resume-from, --only-new, --dry-run, --include-timeline, --force-conflict-as, --checksum-verify and --compare-only require a multi-project config or --repo")
+				}
+
 				// Fall back to legacy single-project sync
-				if err := internal.Sync(); err != nil {
+				if result, err = internal.Sync(); err != nil {
 					return fmt.Errorf("sync failed: %w", err)
 				}
 			}
 
-			fmt.Println("✓ Sync complete.")
+			if notify {
+				notifySyncResult(result)
+			}
+			if err := reportSyncOutput(result, dryRun, jsonOut, reportPath, counts); err != nil {
+				return err
+			}
+			if topReactions > 0 {
+				db, err := internal.OpenConfiguredDB()
+				if err != nil {
+					return fmt.Errorf("failed to connect to database: %w", err)
+				}
+				defer db.Close()
+				return printTopReactions(cmd.OutOrStdout(), db, project, topReactions)
+			}
 			return nil
 		},
 	}
@@ -207,82 +599,113 @@ func NewRootCommand() *cobra.Command {
 - LOCAL_MODIFIED: Modified locally since last sync
 - CONFLICTED: Both local and remote changes detected
 
+Use --watch to redraw the summary every few seconds until interrupted, e.g.
+while a sync runs in another terminal. Watching requires a terminal; when the
+output is redirected the summary is shown once.
+
 Examples:
   pivot status
-  pivot status --verbose`,
+  pivot status --verbose
+  pivot status --watch --interval 5s
+  pivot status --top-reactions 5
+  pivot status --stale 30d --verbose
+  pivot status --summary-only --exit-code
+
+Use --stale to count the open issues that have not been updated for the given
+age (e.g. 30d, 2w or 36h); with --verbose they are listed as well.
+
+Without --summary-only or --exit-code, status exits 0 whatever the states.
+
+Use --summary-only to print just the count of each state on one line. The exit
+code is 0 when no issue is conflicted or failed, 2 when issues are CONFLICTED
+and 3 when issues are PUSH_FAILED, SYNC_FAILED or ERROR.
+
+Use --exit-code to tell the failed states apart: the exit code is 2 for
+CONFLICTED, 4 for PUSH_FAILED, 5 for SYNC_FAILED and 6 for ERROR. When issues
+are in several of these states the most severe wins, in the order ERROR,
+SYNC_FAILED, PUSH_FAILED, CONFLICTED.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			verbose, _ := cmd.Flags().GetBool("verbose")
+			watch, _ := cmd.Flags().GetBool("watch")
+			interval, _ := cmd.Flags().GetDuration("interval")
+			topReactions, _ := cmd.Flags().GetInt("top-reactions")
+			staleSpec, _ := cmd.Flags().GetString("stale")
+			summaryOnly, _ := cmd.Flags().GetBool("summary-only")
+			stateExitCodes, _ := cmd.Flags().GetBool("exit-code")
+
+			if topReactions < 0 {
+				return fmt.Errorf("--top-reactions must not be negative, got %d", topReactions)
+			}
+			if watch && topReactions > 0 {
+				return fmt.Errorf("--top-reactions cannot be used with --watch")
+			}
+			if summaryOnly && (watch || verbose || topReactions > 0 || staleSpec != "") {
+				return fmt.Errorf("--summary-only cannot be used with --watch, --verbose, --top-reactions or --stale")
+			}
+			if stateExitCodes && watch {
+				return fmt.Errorf("--exit-code cannot be used with --watch")
+			}
+			// Problem states only set the exit code with --summary-only or --exit-code
+			outcome := func(map[internal.SyncState]int) error { return nil }
+			switch {
+			case stateExitCodes:
+				outcome = statusStateOutcome
+			case summaryOnly:
+				outcome = statusOutcome
+			}
+			var staleAge time.Duration
+			if staleSpec != "" {
+				if watch {
+					return fmt.Errorf("--stale cannot be used with --watch")
+				}
+				age, err := internal.ParseAge(staleSpec)
+				if err != nil {
+					return fmt.Errorf("invalid --stale: %w", err)
+				}
+				staleAge = age
+			}
+			cmd.SilenceUsage = true
 
-			// Open database connection
-			db, err := internal.InitDB()
+			// Open the database sync and push record the sync states in
+			db, err := openStateDB()
 			if err != nil {
 				return fmt.Errorf("failed to connect to database: %w", err)
 			}
 			defer db.Close()
 
-			// Get sync state summary
+			if watch {
+				return watchStatus(cmd, db, verbose, interval)
+			}
 			summary, err := internal.GetSyncStateSummary(db)
 			if err != nil {
 				return fmt.Errorf("failed to get sync state summary: %w", err)
 			}
-
-			cmd.Println("📊 Sync State Summary")
-			cmd.Println("====================")
-
-			total := 0
-			for state, count := range summary {
-				total += count
-				var icon, description string
-				switch state {
-				case internal.SyncStateLocalOnly:
-					icon, description = "📝", "Created locally, not on GitHub"
-				case internal.SyncStatePendingPush:
-					icon, description = "⏳", "Queued for GitHub creation"
-				case internal.SyncStatePushFailed:
-					icon, description = "❌", "Failed to push to GitHub"
-				case internal.SyncStateSynced:
-					icon, description = "✅", "Synchronized with GitHub"
-				case internal.SyncStateLocalModified:
-					icon, description = "📝", "Modified locally since sync"
-				case internal.SyncStatePendingSync:
-					icon, description = "⏳", "Queued for GitHub update"
-				case internal.SyncStateSyncFailed:
-					icon, description = "❌", "Failed to sync to GitHub"
-				case internal.SyncStateConflicted:
-					icon, description = "⚠️", "Conflicting local/remote changes"
-				case internal.SyncStateError:
-					icon, description = "💥", "Unrecoverable error state"
-				default:
-					icon, description = "❓", "Unknown state"
-				}
-
-				cmd.Printf("  %s %s: %d issues", icon, state, count)
-				if verbose {
-					cmd.Printf(" - %s", description)
-				}
-				cmd.Println()
+			if summaryOnly {
+				printStatusCounts(cmd.OutOrStdout(), summary)
+				return outcome(summary)
+			}
+			if err := renderStatus(cmd, db, verbose); err != nil {
+				return err
+			}
+			if topReactions == 0 && staleAge == 0 {
+				return outcome(summary)
 			}
 
-			cmd.Printf("\nTotal: %d issues\n", total)
-
-			// Show actionable items
-			if verbose {
-				cmd.Println("\n💡 Next Actions:")
-				if localOnlyCount := summary[internal.SyncStateLocalOnly]; localOnlyCount > 0 {
-					cmd.Printf("  • Run 'pivot push' to push %d local-only issues to GitHub\n", localOnlyCount)
-				}
-				if modifiedCount := summary[internal.SyncStateLocalModified]; modifiedCount > 0 {
-					cmd.Printf("  • Run 'pivot sync' to sync %d locally modified issues\n", modifiedCount)
-				}
-				if conflictedCount := summary[internal.SyncStateConflicted]; conflictedCount > 0 {
-					cmd.Printf("  • Run 'pivot resolve' to handle %d conflicted issues\n", conflictedCount)
+			if topReactions > 0 {
+				if err := printTopReactions(cmd.OutOrStdout(), db, "", topReactions); err != nil {
+					return err
 				}
-				if failedCount := summary[internal.SyncStatePushFailed] + summary[internal.SyncStateSyncFailed]; failedCount > 0 {
-					cmd.Printf("  • Check and retry %d failed sync operations\n", failedCount)
+			}
+			if staleAge > 0 {
+				now := time.Now()
+				stale, err := internal.StaleIssues(db, 0, staleAge, now)
+				if err != nil {
+					return fmt.Errorf("failed to find stale issues: %w", err)
 				}
+				cmd.Println()
+				internal.PrintStaleIssues(cmd.OutOrStdout(), stale, staleSpec, verbose, now)
 			}
-
-			return nil
+			return outcome(summary)
 		},
 	}
 
@@ -291,63 +714,47 @@ Examples:
 		Short: "Push local-only issues to GitHub",
 		Long: `Push issues that were created locally (LOCAL_ONLY state) to GitHub.
 This creates new GitHub issues for locally created issues and updates their sync state.
+Issues whose previous push failed are retried.
+
+A milestone given by title is resolved to the repository's milestone of that
+title. Pushing an issue whose milestone does not exist fails unless
+--create-milestone-if-missing is given, which creates the milestone first.
+
+Issues are checked against the push.validation rules in config.yml before any
+is pushed. Use --on-violation skip to push only the issues that pass, or
+--on-violation abort (default) to push nothing when any issue fails. Skipped
+issues keep their state and are checked again by the next push.
+
+Use --summary-only to print just the pushed, failed and skipped counts. The exit code is
+0 when every issue was pushed and 3 when some issues failed to push.
 
 Examples:
   pivot push                    # Push all local-only issues
   pivot push --dry-run         # Preview what would be pushed
-  pivot push --limit 10        # Push up to 10 issues`,
+  pivot push --limit 10        # Push up to 10 issues
+  pivot push --create-milestone-if-missing
+  pivot push --on-violation skip
+  pivot push --summary-only`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			dryRun, _ := cmd.Flags().GetBool("dry-run")
 			limit, _ := cmd.Flags().GetInt("limit")
+			createMilestones, _ := cmd.Flags().GetBool("create-milestone-if-missing")
+			summaryOnly, _ := cmd.Flags().GetBool("summary-only")
+			onViolation, _ := cmd.Flags().GetString("on-violation")
 
-			// Open database connection
-			db, err := internal.InitDB()
-			if err != nil {
-				return fmt.Errorf("failed to connect to database: %w", err)
+			if summaryOnly && dryRun {
+				return fmt.Errorf("--summary-only cannot be used with --dry-run")
 			}
-			defer db.Close()
-
-			// Get local-only issues
-			localOnlyIssues, err := internal.GetSyncStatesByState(db, internal.SyncStateLocalOnly)
+			violationMode, err := internal.ParseViolationMode(onViolation)
 			if err != nil {
-				return fmt.Errorf("failed to get local-only issues: %w", err)
-			}
-
-			if len(localOnlyIssues) == 0 {
-				cmd.Println("🎉 No local-only issues to push!")
-				return nil
-			}
-
-			// Apply limit if specified
-			issuesToPush := localOnlyIssues
-			if limit > 0 && len(issuesToPush) > limit {
-				issuesToPush = localOnlyIssues[:limit]
-				cmd.Printf("📌 Limiting push to first %d of %d local-only issues\n", limit, len(localOnlyIssues))
+				return err
 			}
-
-			cmd.Printf("🚀 Found %d local-only issues to push\n", len(issuesToPush))
-
-			if dryRun {
-				cmd.Println("\n🧪 Dry Run Mode - No issues will be pushed")
-				cmd.Println("=========================================")
-				for i, syncState := range issuesToPush {
-					// TODO: Get issue details from database using syncState.IssueLocalID
-					cmd.Printf("%d. Issue ID %d (Local ID: %d)\n", i+1, syncState.GitHubID, syncState.IssueLocalID)
-				}
-				cmd.Printf("\nTotal: %d issues would be pushed to GitHub\n", len(issuesToPush))
-				return nil
+			opts := internal.PushOptions{Limit: limit, CreateMissingMilestones: createMilestones, OnViolation: violationMode}
+			cmd.SilenceUsage = true
+			if summaryOnly {
+				return runPushSummary(cmd, opts)
 			}
-
-			// TODO: Implement actual push logic
-			// This would involve:
-			// 1. Loading GitHub configuration
-			// 2. For each local-only issue, create it on GitHub
-			// 3. Update sync state to SYNCED with the new GitHub ID
-
-			cmd.Println("⚠️  Push functionality is not yet implemented")
-			cmd.Println("This will be implemented in the next phase of development")
-
-			return nil
+			return runPush(cmd, dryRun, opts)
 		},
 	}
 
@@ -358,26 +765,64 @@ Examples:
 Provides interactive resolution for conflicted issues.
 
 Examples:
-  pivot resolve                 # Resolve all conflicts interactively
-  pivot resolve --take-local   # Take local version for all conflicts
-  pivot resolve --take-remote  # Take remote version for all conflicts`,
+  pivot resolve                           # Resolve all conflicts interactively
+  pivot resolve --take-local              # Take local version for all conflicts
+  pivot resolve --take-remote             # Take remote version for all conflicts
+  pivot resolve --issue 42 --take-remote  # Resolve only issue #42
+  pivot resolve --project myorg/myrepo    # Resolve only the conflicts of one project
+
+An issue number that is conflicted in several projects needs --project.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			takeLocal, _ := cmd.Flags().GetBool("take-local")
 			takeRemote, _ := cmd.Flags().GetBool("take-remote")
+			issueNumber, _ := cmd.Flags().GetInt("issue")
+			projectSpec, _ := cmd.Flags().GetString("project")
 
 			if takeLocal && takeRemote {
 				return fmt.Errorf("cannot specify both --take-local and --take-remote")
 			}
+			if cmd.Flags().Changed("issue") && issueNumber <= 0 {
+				return fmt.Errorf("--issue must be a positive issue number")
+			}
 
-			// Open database connection
-			db, err := internal.InitDB()
+			resolution := ""
+			if takeLocal {
+				resolution = internal.ResolutionLocal
+			} else if takeRemote {
+				resolution = internal.ResolutionRemote
+			}
+
+			// Open the database sync records the conflicts in
+			db, err := openStateDB()
 			if err != nil {
 				return fmt.Errorf("failed to connect to database: %w", err)
 			}
 			defer db.Close()
 
+			if err := internal.InitSyncStateSchema(db); err != nil {
+				return fmt.Errorf("failed to prepare sync state: %w", err)
+			}
+
+			var projectID int64
+			if projectSpec != "" {
+				project, err := resolveShowProject(db, projectSpec)
+				if err != nil {
+					return err
+				}
+				projectID = int64(project.ID)
+			}
+
+			// Resolve a single issue
+			if issueNumber > 0 {
+				conflict, err := internal.FindConflictedIssue(db, projectID, issueNumber)
+				if err != nil {
+					return err
+				}
+				return resolveConflicts(cmd, db, []internal.ConflictedIssue{*conflict}, resolution)
+			}
+
 			// Get conflicted issues
-			conflictedIssues, err := internal.GetSyncStatesByState(db, internal.SyncStateConflicted)
+			conflictedIssues, err := internal.GetConflictedIssues(db, projectID)
 			if err != nil {
 				return fmt.Errorf("failed to get conflicted issues: %w", err)
 			}
@@ -388,17 +833,7 @@ Examples:
 			}
 
 			cmd.Printf("⚠️  Found %d conflicted issues\n", len(conflictedIssues))
-
-			// TODO: Implement conflict resolution logic
-			// This would involve:
-			// 1. For each conflicted issue, show local vs remote differences
-			// 2. Allow user to choose resolution strategy
-			// 3. Update the issue and sync state accordingly
-
-			cmd.Println("⚠️  Conflict resolution functionality is not yet implemented")
-			cmd.Println("This will be implemented in the next phase of development")
-
-			return nil
+			return resolveConflicts(cmd, db, conflictedIssues, resolution)
 		},
 	}
 
@@ -406,69 +841,233 @@ Examples:
 	var importCmd = &cobra.Command{
 		Use:   "import",
 		Short: "Import data from external sources",
-		Long:  `Import issues and other data from CSV files or other external sources.`,
+		Long:  `Import issues and other data from CSV files or other external sources, such as Jira CSV exports.`,
 	}
 
 	var csvImportCmd = &cobra.Command{
-		Use:   "csv <file>",
-		Short: "Import issues from CSV file",
-		Long: `Import GitHub issues from a CSV file. The CSV should contain columns like:
+		Use:   "csv <file>...",
+		Short: "Import issues from one or more CSV files",
+		Long: `Import GitHub issues from one or more CSV files. The CSV should contain columns like:
 title, state, priority, labels, assignee, milestone, body, etc.
 
 Examples:
   pivot import csv backlog.csv
   pivot import csv --preview backlog.csv
-  pivot import csv --dry-run --repository myorg/myrepo backlog.csv`,
-		Args: cobra.ExactArgs(1),
+  pivot import csv --preview-count 0 backlog.csv
+  pivot import csv --dry-run --repository myorg/myrepo backlog.csv
+  pivot import csv --encoding windows-1252 legacy-export.csv
+  pivot import csv --dedup-by external_id backlog-q1.csv backlog-q2.csv
+  pivot import csv --state-map done=closed --state-map todo=open backlog.csv
+  pivot import csv --map Summary=title --map-file jira.yml --mapping-preview backlog.csv
+  pivot import csv --delay 1s --repository myorg/myrepo backlog.csv
+  pivot import csv --assignee-validate --repository myorg/myrepo backlog.csv
+  pivot import csv --repository-from-column repo backlog.csv
+  pivot import csv --body-from-file-column body_file backlog.csv
+
+States are matched case-insensitively, so Open and OPEN both import as open.
+Other values can be mapped to open or closed with --state-map; blank and
+unknown values fall back to --default-state (open unless set), unknown ones
+with a warning.
+
+Several files are validated and merged into one import batch. An issue whose
+title (or external_id with --dedup-by external_id) matches an issue from an
+earlier file is skipped as a duplicate. Titles are compared after the
+match.normalize rules in config.yml.
+
+Use --preview to list the issues that would be created, in the order they would
+be created (file by file, row by row), without creating them. It shows the first
+5 issues; use --preview-count N to show N, or 0 to show all. --preview-count
+implies --preview.
+
+Use --mapping-preview to print how each column resolves with --map and
+--map-file, the defaults, and the columns that will not be imported, without
+validating or importing anything.
+
+Issues are checked against the push.validation rules in config.yml before any
+are created. Use --on-violation skip to create only the issues that pass, or
+--on-violation abort (default) to create nothing when any issue fails.
+
+Use --assignee-validate to fetch the repository's collaborators once and check
+every assignee before any issue is created. All unknown assignees are reported
+together, instead of GitHub rejecting the affected issues one by one.
+
+Created issues are recorded in the local database as synced issues, with their
+dependencies: the dependencies column refers to the id column of the imported
+rows, or else to issues already synced from the repository.
+
+When GitHub rejects an issue, --on-error continue (default) reports the failure
+and imports the remaining issues; --on-error abort stops at the first failure
+and exits with an error. Issues created before the failure are kept.
+
+Use --repository-from-column <column> when one CSV targets several repositories:
+each row is created in the owner/repo of that column, or in --repository when the
+cell is blank. Every value is validated and the access to every repository is
+checked before anything is created. Rows are imported one repository at a time
+and the results are reported per repository.
+
+Use --body-from-file-column <column> to read long bodies from files: each row's
+body is the content of the file named in that column, relative to the CSV file's
+directory, and decoded with --encoding like the CSV itself. Absolute paths and
+paths leading out of that directory are refused. Rows with a blank cell keep the
+body column. A missing or refused file fails the import before anything is
+created, naming the row.
+
+Issue creations are spaced by --delay (250ms by default) plus up to half of it
+again in random jitter, to stay clear of GitHub's secondary rate limits. Use
+--no-delay to create issues back to back. Ctrl+C stops the import during a delay.`,
+		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			filePath := args[0]
+			filePaths := args
 
 			// Get flags
 			dryRun, _ := cmd.Flags().GetBool("dry-run")
 			preview, _ := cmd.Flags().GetBool("preview")
 			repository, _ := cmd.Flags().GetString("repository")
 			skipDuplicates, _ := cmd.Flags().GetBool("skip-duplicates")
+			inlineMaps, _ := cmd.Flags().GetStringArray("map")
+			mapFile, _ := cmd.Flags().GetString("map-file")
+			onViolation, _ := cmd.Flags().GetString("on-violation")
+			onError, _ := cmd.Flags().GetString("on-error")
+			encodingName, _ := cmd.Flags().GetString("encoding")
+			dedupBy, _ := cmd.Flags().GetString("dedup-by")
+			stateMaps, _ := cmd.Flags().GetStringArray("state-map")
+			defaultStateName, _ := cmd.Flags().GetString("default-state")
+			mappingPreview, _ := cmd.Flags().GetBool("mapping-preview")
+			delay, _ := cmd.Flags().GetDuration("delay")
+			noDelay, _ := cmd.Flags().GetBool("no-delay")
+			assigneeValidate, _ := cmd.Flags().GetBool("assignee-validate")
+			repositoryColumn, _ := cmd.Flags().GetString("repository-from-column")
+			bodyFileColumn, _ := cmd.Flags().GetString("body-from-file-column")
+			previewCount, _ := cmd.Flags().GetInt("preview-count")
+
+			// Validate CSV files exist
+			for _, filePath := range filePaths {
+				if _, err := os.Stat(filePath); os.IsNotExist(err) {
+					return fmt.Errorf("CSV file not found: %s", filePath)
+				}
+			}
 
-			// Validate CSV file exists
-			if _, err := os.Stat(filePath); os.IsNotExist(err) {
-				return fmt.Errorf("CSV file not found: %s", filePath)
+			violationMode, err := internal.ParseViolationMode(onViolation)
+			if err != nil {
+				return err
+			}
+			errorMode, err := csv.ParseOnErrorMode(onError)
+			if err != nil {
+				return err
+			}
+			encoding, err := csv.ParseEncoding(encodingName)
+			if err != nil {
+				return err
+			}
+			dedupPolicy, err := csv.ParseDedupPolicy(dedupBy)
+			if err != nil {
+				return err
+			}
+			stateMap, err := csv.ParseStateMap(stateMaps)
+			if err != nil {
+				return err
+			}
+			defaultState, err := csv.ParseDefaultState(defaultStateName)
+			if err != nil {
+				return err
+			}
+			if delay < 0 {
+				return fmt.Errorf("--delay must not be negative, got %s", delay)
+			}
+			if previewCount < 0 {
+				return fmt.Errorf("--preview-count must not be negative, got %d", previewCount)
+			}
+			if cmd.Flags().Changed("preview-count") {
+				// Asking for a number of previewed issues implies the preview
+				preview = true
+			}
+			if noDelay {
+				delay = 0
+			}
+
+			config := &csv.ImportConfig{
+				FilePath:       filePaths[0],
+				Repository:     repository,
+				DryRun:         dryRun || preview,
+				SkipDuplicates: skipDuplicates,
+				OnViolation:    violationMode,
+				OnError:        errorMode,
+				Encoding:       encoding,
+				DedupBy:        dedupPolicy,
+				StateMap:       stateMap,
+				DefaultState:   defaultState,
+				Delay:          delay,
+
+				ValidateAssignees: assigneeValidate,
+				RepositoryColumn:  strings.TrimSpace(repositoryColumn),
+				BodyFileColumn:    strings.TrimSpace(bodyFileColumn),
+			}
+
+			// Resolve column mappings (inline --map entries take precedence over --map-file)
+			inline, err := csv.ParseInlineMappings(inlineMaps)
+			if err != nil {
+				return err
+			}
+			var mapping *csv.MappingFile
+			if mapFile != "" {
+				if mapping, err = csv.LoadMappingFile(mapFile); err != nil {
+					return err
+				}
+			}
+			config.ApplyMappings(mapping, inline)
+
+			if mappingPreview {
+				for _, filePath := range filePaths {
+					preview, err := csv.PreviewMapping(filePath, config)
+					if err != nil {
+						return fmt.Errorf("%s: %w", filePath, err)
+					}
+					printMappingPreview(cmd, filePath, preview)
+				}
+				cmd.Println("\nRun without --mapping-preview to import.")
+				return nil
 			}
 
 			// Validate CSV format
 			fmt.Println("📋 Validating CSV format...")
-			if err := csv.ValidateCSV(filePath); err != nil {
-				return fmt.Errorf("CSV validation failed: %w", err)
+			for _, filePath := range filePaths {
+				if err := csv.ValidateCSVWithConfig(filePath, config); err != nil {
+					return fmt.Errorf("CSV validation failed: %s: %w", filePath, err)
+				}
 			}
 			fmt.Println("✓ CSV format is valid")
 
 			// Parse CSV
 			fmt.Println("📊 Parsing CSV data...")
-			config := &csv.ImportConfig{
-				FilePath:       filePath,
-				Repository:     repository,
-				DryRun:         dryRun || preview,
-				SkipDuplicates: skipDuplicates,
-			}
 
-			issues, err := csv.ParseCSV(filePath, config)
+			// Title normalization for deduplication is optional; previews work without a config
+			if cfg, err := internal.LoadConfig(); err == nil {
+				config.TitleMatch = cfg.Match.Normalize
+			}
+			issues, duplicates, err := csv.ParseCSVFiles(filePaths, config)
 			if err != nil {
 				return fmt.Errorf("CSV parsing failed: %w", err)
 			}
 
-			cmd.Printf("✓ Parsed %d issues from CSV\n", len(issues))
+			if len(filePaths) > 1 {
+				cmd.Printf("✓ Parsed %d issues from %d CSV files\n", len(issues)+len(duplicates), len(filePaths))
+				for _, dup := range duplicates {
+					cmd.Printf("⚠ Skipping duplicate issue '%s' (dedup by %s)\n", dup.Title, dedupPolicy)
+				}
+			} else {
+				cmd.Printf("✓ Parsed %d issues from CSV\n", len(issues))
+			}
+			for _, group := range [][]*csv.Issue{issues, duplicates} {
+				for _, issue := range group {
+					for _, warning := range issue.Warnings {
+						cmd.Printf("⚠ %s\n", warning)
+					}
+				}
+			}
 
 			// Preview mode - just show the data
 			if preview {
-				cmd.Println("\n📋 Import Preview:")
-				cmd.Println("=================")
-				for i, issue := range issues {
-					if i >= 5 { // Show only first 5 issues in preview
-						cmd.Printf("... and %d more issues\n", len(issues)-5)
-						break
-					}
-					cmd.Printf("%d. %s [%s] - %s\n",
-						i+1, issue.Title, issue.State, strings.Join(issue.Labels, ", "))
-				}
+				printImportPreview(cmd, issues, previewCount)
 				cmd.Println("\nRun without --preview to perform the actual import.")
 				return nil
 			}
@@ -478,46 +1077,72 @@ Examples:
 				cmd.Println("\n🧪 Dry Run Mode - No issues will be created")
 				cmd.Println("==========================================")
 				for _, issue := range issues {
+					if issue.Repository != "" {
+						cmd.Printf("Would create in %s: %s [%s]\n", issue.Repository, issue.Title, issue.State)
+						continue
+					}
 					cmd.Printf("Would create: %s [%s]\n", issue.Title, issue.State)
 				}
 				cmd.Printf("\nTotal: %d issues would be created\n", len(issues))
 				return nil
 			}
 
-			// Actual import to GitHub
-			if repository == "" {
-				return fmt.Errorf("repository flag is required for import (use --repository owner/repo)")
-			}
+			// Actual import to GitHub, to --repository or to the repository of each row
+			var owner, repoName string
+			if config.RepositoryColumn == "" {
+				if repository == "" {
+					return fmt.Errorf("repository flag is required for import (use --repository owner/repo or --repository-from-column)")
+				}
 
-			// Parse repository owner/repo
-			repoParts := strings.Split(repository, "/")
-			if len(repoParts) != 2 {
-				return fmt.Errorf("repository must be in format 'owner/repo', got: %s", repository)
+				// Parse repository owner/repo
+				repoParts := strings.Split(repository, "/")
+				if len(repoParts) != 2 {
+					return fmt.Errorf("repository must be in format 'owner/repo', got: %s", repository)
+				}
+				owner, repoName = repoParts[0], repoParts[1]
 			}
-			owner, repoName := repoParts[0], repoParts[1]
 
 			cmd.Println("\n🚀 Starting import to GitHub...")
 
-			// Load configuration to get GitHub token
-			cfg, err := internal.LoadConfig()
+			// Load configuration to get the GitHub token of each repository
+			cfg, err := internal.LoadMultiProjectConfig()
 			if err != nil {
 				return fmt.Errorf("failed to load configuration: %w (run 'pivot init' to set up config)", err)
 			}
+			token := func(owner, repo string) string { return internal.RepositoryToken(cfg, owner, repo) }
+			config.Validation = cfg.Push.Validation
+			config.AddFooter = cfg.Import.AddFooter
+			config.Version = version
+			if db := openImportDB(cmd); db != nil {
+				defer db.Close()
+				config.DB = db
+			}
 
-			result, err := csv.ImportCSVToGitHub(filePath, owner, repoName, cfg.Token, config)
-			if err != nil {
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+			config.Context = ctx
+
+			if config.RepositoryColumn != "" {
+				results, err := csv.ImportCSVFilesByRepository(filePaths, token, config)
+				if err != nil && results == nil {
+					return fmt.Errorf("GitHub import failed: %w", err)
+				}
+
+				printRepositoryImportSummary(cmd, results)
+				if err != nil {
+					return fmt.Errorf("GitHub import failed: %w", err)
+				}
+				return nil
+			}
+
+			result, err := csv.ImportCSVFilesToGitHub(filePaths, owner, repoName, token(owner, repoName), config)
+			if err != nil && result == nil {
 				return fmt.Errorf("GitHub import failed: %w", err)
 			}
 
-			cmd.Printf("✅ Import complete!\n")
-			cmd.Printf("   Total issues: %d\n", result.Total)
-			cmd.Printf("   Created: %d\n", result.Created)
-			cmd.Printf("   Skipped: %d\n", result.Skipped)
-			if len(result.Errors) > 0 {
-				cmd.Printf("   Errors: %d\n", len(result.Errors))
-				for _, err := range result.Errors {
-					cmd.Printf("     - %s\n", err)
-				}
+			printImportSummary(cmd, result)
+			if err != nil {
+				return fmt.Errorf("GitHub import failed: %w", err)
 			}
 
 			return nil
@@ -527,7 +1152,24 @@ Examples:
 	var exportCmd = &cobra.Command{
 		Use:   "export",
 		Short: "Export data to external formats",
-		Long:  `Export issues and other data to CSV files or other external formats.`,
+		Long: `Export issues and other data to CSV files or other external formats.
+
+Use --list-fields to print the field names accepted by 'export csv --fields'.
+
+Issues are exported grouped by project and, within each project, by --order-by
+(number, created, updated, title or reactions; number by default), ascending
+unless --desc is given. Ties are broken by issue number, so exporting an
+unchanged database twice produces identical output.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			listFields, _ := cmd.Flags().GetBool("list-fields")
+			if !listFields {
+				return cmd.Help()
+			}
+			for _, field := range csv.ExportFields() {
+				cmd.Println(field)
+			}
+			return nil
+		},
 	}
 
 	var csvExportCmd = &cobra.Command{
@@ -535,16 +1177,41 @@ Examples:
 		Short: "Export issues to CSV file",
 		Long: `Export GitHub issues to a CSV file.
 
+With --dedupe, issues of the same repository sharing a key are written once,
+keeping the most recently updated copy. The key is the issue number unless
+--dedupe-key selects title (compared using the match.normalize rules of config.yml).
+
 Examples:
   pivot export csv
   pivot export csv --output issues.csv
-  pivot export csv --fields title,state,labels --filter "state:open"`,
+  pivot export csv --fields title,state,labels --filter "state:open"
+  pivot export csv --fields all
+  pivot export csv --dedupe --dedupe-key title
+  pivot export csv --order-by updated --desc
+  pivot export csv --anonymize --redact "ACME-[0-9]+"`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Get flags
 			outputFile, _ := cmd.Flags().GetString("output")
 			fields, _ := cmd.Flags().GetStringSlice("fields")
 			filter, _ := cmd.Flags().GetString("filter")
 			repository, _ := cmd.Flags().GetString("repository")
+			anonymize, _ := cmd.Flags().GetBool("anonymize")
+			redact, _ := cmd.Flags().GetStringArray("redact")
+			dedupe, _ := cmd.Flags().GetBool("dedupe")
+			dedupeKey, _ := cmd.Flags().GetString("dedupe-key")
+
+			order, err := exportIssueOrder(cmd)
+			if err != nil {
+				return err
+			}
+			if cmd.Flags().Changed("dedupe-key") {
+				dedupe = true
+			}
+			if dedupe {
+				if _, err := csv.ParseExportDedupeKey(dedupeKey); err != nil {
+					return err
+				}
+			}
 
 			// Default output file
 			if outputFile == "" {
@@ -562,25 +1229,29 @@ Examples:
 
 			cmd.Printf("📤 Exporting issues to: %s\n", outputFile)
 
-			// TODO: Get issues from local database or GitHub API
-			// For now, create some sample data
-			sampleIssues := []*csv.Issue{
-				{
-					ID:       1,
-					Title:    "Sample Issue 1",
-					State:    "open",
-					Priority: "high",
-					Labels:   []string{"bug", "urgent"},
-					Body:     "This is a sample issue for testing CSV export",
-				},
-				{
-					ID:       2,
-					Title:    "Sample Issue 2",
-					State:    "closed",
-					Priority: "medium",
-					Labels:   []string{"feature"},
-					Body:     "Another sample issue",
-				},
+			issues, err := loadCSVExportIssues(order)
+			if err != nil {
+				return err
+			}
+
+			if dedupe {
+				var normalization internal.TitleNormalization
+				if config, err := internal.LoadMultiProjectConfig(); err == nil {
+					normalization = config.Match.Normalize
+				}
+				var removed int
+				if issues, removed, err = csv.DedupeIssues(issues, dedupeKey, normalization); err != nil {
+					return err
+				}
+				cmd.Printf("🔍 Removed %d duplicate issues (by %s)\n", removed, dedupeKey)
+			}
+
+			if anonymize {
+				anonymizer, err := internal.ConfiguredAnonymizer(redact)
+				if err != nil {
+					return err
+				}
+				issues = csv.AnonymizeIssues(issues, anonymizer)
 			}
 
 			config := &csv.ExportConfig{
@@ -590,11 +1261,109 @@ Examples:
 				Filter:     filter,
 			}
 
-			if err := csv.WriteCSV(sampleIssues, outputFile, config); err != nil {
+			if err := csv.WriteCSV(issues, outputFile, config); err != nil {
 				return fmt.Errorf("CSV export failed: %w", err)
 			}
 
-			cmd.Printf("✓ Exported %d issues to %s\n", len(sampleIssues), outputFile)
+			cmd.Printf("✓ Exported %d issues to %s\n", len(issues), outputFile)
+			return nil
+		},
+	}
+
+	var customExportCmd = &cobra.Command{
+		Use:   "custom [output-file]",
+		Short: "Export issues using a custom Go template",
+		Long: `Render issues from the local database through a user-provided Go template.
+
+The template receives .Issues, .Count, .ByState, .ByLabel, .ByAssignee and
+.GeneratedAt, plus the helpers split, join, lower, upper, count and sortedKeys.
+Output is written to stdout unless an output file is given.
+
+With --anonymize, assignee logins and @mentions are replaced with stable
+pseudonyms, links are removed and text matching export.redact_patterns (or
+--redact) is redacted.
+
+With --split-by project, one file is rendered per project. With --zip, the
+rendered files are bundled into a zip archive together with a manifest.json.
+
+Examples:
+  pivot export custom --template report.tmpl
+  pivot export custom --template report.tmpl report.md
+  pivot export custom --template report.tmpl --order-by title
+  pivot export custom --template report.tmpl --anonymize
+  pivot export custom --template report.md.tmpl --split-by project
+  pivot export custom --template report.md.tmpl --split-by project --zip reports.zip`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			templatePath, _ := cmd.Flags().GetString("template")
+			if templatePath == "" {
+				return fmt.Errorf("template flag is required (use --template <file>)")
+			}
+
+			splitBy, _ := cmd.Flags().GetString("split-by")
+			if splitBy != "" && splitBy != "project" {
+				return fmt.Errorf("invalid split-by value '%s' (supported: project)", splitBy)
+			}
+			order, err := exportIssueOrder(cmd)
+			if err != nil {
+				return err
+			}
+
+			// Validate the template before touching the database
+			tmpl, err := internal.ParseExportTemplate(templatePath)
+			if err != nil {
+				return fmt.Errorf("invalid template: %w", err)
+			}
+
+			db, err := internal.OpenConfiguredDB()
+			if err != nil {
+				return fmt.Errorf("failed to connect to database: %w", err)
+			}
+			defer db.Close()
+
+			groups, err := internal.GetIssuesByProjectOrdered(db, order)
+			if err != nil {
+				return fmt.Errorf("failed to load issues: %w", err)
+			}
+
+			if anonymize, _ := cmd.Flags().GetBool("anonymize"); anonymize {
+				redact, _ := cmd.Flags().GetStringArray("redact")
+				anonymizer, err := internal.ConfiguredAnonymizer(redact)
+				if err != nil {
+					return err
+				}
+				for i := range groups {
+					groups[i].Issues = anonymizer.AnonymizeIssues(groups[i].Issues)
+				}
+			}
+
+			zipPath, _ := cmd.Flags().GetString("zip")
+			if splitBy != "" || zipPath != "" {
+				return writeBundledExport(cmd, tmpl, groups, exportBaseName(templatePath, args), splitBy, zipPath)
+			}
+
+			var issues []internal.DBIssue
+			for _, group := range groups {
+				issues = append(issues, group.Issues...)
+			}
+
+			out := cmd.OutOrStdout()
+			if len(args) > 0 {
+				file, err := os.Create(args[0]) // #nosec G304 - User controls output path
+				if err != nil {
+					return fmt.Errorf("failed to create output file: %w", err)
+				}
+				defer file.Close()
+				out = file
+			}
+
+			if err := internal.RenderIssueTemplate(out, tmpl, issues); err != nil {
+				return fmt.Errorf("template export failed: %w", err)
+			}
+
+			if len(args) > 0 {
+				cmd.Printf("✓ Exported %d issues to %s\n", len(issues), args[0])
+			}
 			return nil
 		},
 	}
@@ -602,41 +1371,123 @@ Examples:
 	// Add flags to commands
 	initCmd.Flags().String("import", "", "Import configuration from file")
 	initCmd.Flags().Bool("multi-project", false, "Use multi-project configuration setup")
+	initCmd.Flags().Bool("no-gitignore", false, "Do not offer to add the database file to .gitignore")
 
 	configSetupCmd.Flags().Bool("multi-project", false, "Use multi-project configuration setup")
+	configImportCmd.Flags().Bool("dry-run", false, "Preview the merged configuration without writing it")
+	configImportCmd.Flags().Bool("preserve-local", false, "Keep current global and project values when merging; imported values only fill empty settings")
+
+	// Add flags to config add-org command
+	configAddOrgCmd.Flags().Bool("skip-archived", false, "Leave out archived repositories (default from discovery.skip_archived)")
+	configAddOrgCmd.Flags().Bool("skip-forks", false, "Leave out forked repositories (default from discovery.skip_forks)")
+	configAddOrgCmd.Flags().Bool("dry-run", false, "List the repositories that would be added without writing config.yml")
 
 	syncCmd.Flags().String("project", "", "Sync specific project (format: owner/repo)")
+	registerProjectCompletion(syncCmd, "project")
+	syncCmd.Flags().Bool("with-reactions", false, "Store reaction counts for each issue")
+	syncCmd.Flags().Bool("store-raw", false, "Store the GitHub JSON of each issue for 'pivot show --raw'")
+	syncCmd.Flags().Int("resume-from", 0, "Process fetched issues in number order starting at this issue number (does not advance the watermark)")
+	syncCmd.Flags().Bool("dry-run", false, "Show what the sync would create, update and conflict on without changing the database")
+	syncCmd.Flags().String("output", "text", "Output format: text or json (with --dry-run, a stable diff document)")
+	syncCmd.Flags().Bool("only-new", false, "Insert only issues not stored yet and leave stored issues untouched")
+	syncCmd.Flags().Bool("include-timeline", false, "Also store the closed, reopened and labeled timeline events of each stored issue")
+	syncCmd.Flags().String("force-conflict-as", "", "Resolve every conflict of this run toward local or remote instead of marking it CONFLICTED")
+	syncCmd.Flags().String("raw-compression", "", "Compression of stored raw JSON: gzip or none (default from sync.raw_compression, else gzip)")
+	syncCmd.Flags().Bool("force-overwrite", false, "Store fetched issues even when their updated_at is older than the stored copy")
+	syncCmd.Flags().Int("top-reactions", 0, "After syncing, list the N most-reacted open issues (implies --with-reactions)")
+	syncCmd.Flags().Bool("checkpoint", false, "Resume an interrupted sync from the last completed page")
+	syncCmd.Flags().Bool("since-last-success", true, "Only fetch issues updated since the last successful sync")
+	syncCmd.Flags().Bool("reset-watermark", false, "Forget the last successful sync and fetch all issues")
+	syncCmd.Flags().String("assignee", "", "Only sync issues assigned to this GitHub login")
+	syncCmd.Flags().Bool("assigned-to-me", false, "Only sync issues assigned to the authenticated user")
+	syncCmd.Flags().Bool("checksum-verify", false, "Verify the stored content of every issue against its sync hash after syncing")
+	syncCmd.Flags().Bool("compare-only", false, "Report local-vs-remote differences without changing the database")
+	syncCmd.Flags().String("report", "sync-compare.json", "Report file written by --compare-only (.md for markdown, otherwise JSON)")
+	syncCmd.Flags().String("select", "", "Store only fetched issues matching this filter, e.g. \"label:team-a\" (local projection, all issues are still fetched)")
+	syncCmd.Flags().Bool("explain", false, "Write the decision and its inputs for every fetched issue to stderr as JSON lines")
+	syncCmd.Flags().Bool("summary-only", false, "Print only the sync counts; the exit code reports conflicts (2) and failures (3)")
+	syncCmd.Flags().Bool("notify", false, "Fire the sync.notify command and webhook hooks from config.yml after the sync")
+	syncCmd.Flags().String("repo", "", "Sync a repository without a config file (format: owner/repo)")
+	syncCmd.Flags().Bool("dump-rate-limit", false, "Print the remaining GitHub API budget of the configured tokens and exit")
+	syncCmd.Flags().Bool("wait-for-rate-limit", false, "Pause until the rate limit resets when the budget is too low for the sync")
+	syncCmd.Flags().String("token", "", "GitHub token for --repo, or to use instead of the configured tokens for this run (not saved)")
 
 	// Add flags to CSV import command
 	csvImportCmd.Flags().Bool("preview", false, "Preview the import without creating issues")
+	csvImportCmd.Flags().Int("preview-count", 5, "Number of issues to show in the preview (0 = all); implies --preview")
 	csvImportCmd.Flags().Bool("dry-run", false, "Show what would be imported without making changes")
 	csvImportCmd.Flags().String("repository", "", "Target GitHub repository (e.g., owner/repo)")
+	registerProjectCompletion(csvImportCmd, "repository")
 	csvImportCmd.Flags().Bool("skip-duplicates", false, "Skip issues that appear to be duplicates")
+	csvImportCmd.Flags().StringArray("map", []string{}, "Map a CSV column to an issue field (format: column=field, repeatable)")
+	csvImportCmd.Flags().String("map-file", "", "YAML file with column mappings, defaults and assignee maps")
+	csvImportCmd.Flags().Duration("delay", csv.DefaultImportDelay, "Pause between issue creations, plus up to 50% random jitter")
+	csvImportCmd.Flags().Bool("no-delay", false, "Create issues back to back without pausing")
+	csvImportCmd.Flags().Bool("mapping-preview", false, "Show how CSV columns resolve to issue fields, then exit without importing")
+	csvImportCmd.Flags().String("on-violation", internal.ViolationAbort, "How to handle issues failing push validation: skip or abort")
+	csvImportCmd.Flags().String("on-error", csv.OnErrorContinue, "How to handle issues GitHub fails to create: continue or abort")
+	csvImportCmd.Flags().String("encoding", csv.EncodingUTF8, "CSV file encoding: utf-8, latin1, windows-1252, utf-16le or utf-16be")
+	csvImportCmd.Flags().StringArray("state-map", []string{}, "Map a CSV state value to open or closed (format: value=state, repeatable)")
+	csvImportCmd.Flags().String("default-state", csv.StateOpen, "State for blank or unknown CSV state values: open or closed")
+	csvImportCmd.Flags().String("repository-from-column", "", "Create each row in the owner/repo named in this CSV column (--repository is the fallback for blank cells)")
+	csvImportCmd.Flags().String("body-from-file-column", "", "Read each row's body from the file named in this CSV column, relative to the CSV file")
+	csvImportCmd.Flags().Bool("assignee-validate", false, "Check all assignees against the repository collaborators before creating any issue")
+	csvImportCmd.Flags().String("dedup-by", csv.DedupByTitle, "How to detect duplicates across several CSV files: title or external_id")
 
 	// Add flags to CSV export command
 	csvExportCmd.Flags().StringP("output", "o", "", "Output CSV file path")
-	csvExportCmd.Flags().StringSlice("fields", []string{}, "Specific fields to export (comma-separated)")
+	exportCmd.Flags().Bool("list-fields", false, "List the field names accepted by --fields")
+	exportCmd.PersistentFlags().String("order-by", internal.DefaultIssueOrder.Field, "Order of the exported issues within each project: number, created, updated, title or reactions")
+	exportCmd.PersistentFlags().Bool("desc", false, "Export issues in descending --order-by order")
+	csvExportCmd.Flags().StringSlice("fields", []string{}, "Specific fields to export (comma-separated, or 'all'; see 'pivot export --list-fields')")
 	csvExportCmd.Flags().String("filter", "", "Filter expression for issues to export")
 	csvExportCmd.Flags().String("repository", "", "Source GitHub repository (e.g., owner/repo)")
+	registerProjectCompletion(csvExportCmd, "repository")
+	csvExportCmd.Flags().Bool("anonymize", false, "Pseudonymize assignees, remove links and redact configured patterns")
+	csvExportCmd.Flags().StringArray("redact", []string{}, "Additional regular expression to redact with --anonymize (repeatable)")
+	csvExportCmd.Flags().Bool("dedupe", false, "Write issues sharing a dedupe key once, keeping the most recently updated")
+	csvExportCmd.Flags().String("dedupe-key", csv.DedupByNumber, "Key identifying duplicates for --dedupe: number or title")
+
+	// Add flags to custom template export command
+	customExportCmd.Flags().String("template", "", "Go template file to render")
+	customExportCmd.Flags().String("split-by", "", "Render one file per group (supported: project)")
+	customExportCmd.Flags().String("zip", "", "Bundle the exported files into a zip archive with a manifest")
+	customExportCmd.Flags().Bool("anonymize", false, "Pseudonymize assignees, remove links and redact configured patterns")
+	customExportCmd.Flags().StringArray("redact", []string{}, "Additional regular expression to redact with --anonymize (repeatable)")
 
 	// Build command hierarchy
 	configCmd.AddCommand(configSetupCmd)
 	configCmd.AddCommand(configShowCmd)
 	configCmd.AddCommand(configAddProjectCmd)
+	configCmd.AddCommand(configAddOrgCmd)
 	configCmd.AddCommand(configImportCmd)
+	configCmd.AddCommand(configDoctorTokenCmd)
+	configCmd.AddCommand(createConfigSetTokenCommand())
 
 	importCmd.AddCommand(csvImportCmd)
+	importCmd.AddCommand(createImportJiraCommand())
 	exportCmd.AddCommand(csvExportCmd)
+	exportCmd.AddCommand(customExportCmd)
 
 	var versionCmd = &cobra.Command{
 		Use:   "version",
 		Short: "Print version information",
+		Long: `Print version information.
+
+Use --check to ask GitHub whether a newer release is available. Nothing is
+downloaded or installed.`,
 		Run: func(cmd *cobra.Command, args []string) {
 			cmd.Printf("pivot version %s\n", version)
 			cmd.Printf("commit: %s\n", commit)
 			cmd.Printf("built: %s\n", date)
+
+			if check, _ := cmd.Flags().GetBool("check"); check {
+				info, err := internal.CheckForUpdate(version)
+				printUpdateCheck(cmd, info, err)
+			}
 		},
 	}
+	versionCmd.Flags().Bool("check", false, "Check GitHub for a newer release")
 
 	// Auth command for credential verification
 	var authCmd = &cobra.Command{
@@ -650,16 +1501,28 @@ Examples:
 		Short: "Verify GitHub credentials and repository access",
 		Long: `Verify that your GitHub token is valid and has proper access to repositories.
 
+With --json a single JSON object is printed instead, for use in scripts:
+  {"token_valid": true, "login": "octocat", "scopes": ["repo"], "repo_access": {"myorg/myrepo": true}}
+The exit code is non-zero when the token is invalid or a repository is not accessible.
+
 Examples:
   pivot auth verify                    # Verify with config token
-  pivot auth verify --owner myorg --repo myrepo  # Verify access to specific repo`,
+  pivot auth verify --owner myorg --repo myrepo  # Verify access to specific repo
+  pivot auth verify --owner myorg --repo myrepo --json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			owner, _ := cmd.Flags().GetString("owner")
 			repo, _ := cmd.Flags().GetString("repo")
 			tokenFlag, _ := cmd.Flags().GetString("token")
+			jsonOutput, _ := cmd.Flags().GetBool("json")
 
-			cmd.Println("🔐 Verifying GitHub Credentials")
-			cmd.Println("==============================")
+			// Progress messages would corrupt the JSON document
+			info := cmd.OutOrStdout()
+			if jsonOutput {
+				info = io.Discard
+			}
+
+			fmt.Fprintln(info, "🔐 Verifying GitHub Credentials")
+			fmt.Fprintln(info, "==============================")
 
 			var token string
 			var configOwner, configRepo string
@@ -677,9 +1540,9 @@ Examples:
 						return fmt.Errorf("failed to load configuration: %w (run 'pivot init' to set up config)", err)
 					}
 
-					if multiConfig.Global.Token != "" {
-						token = multiConfig.Global.Token
-						cmd.Println("📋 Using global token from multi-project config")
+					if multiConfig.Global.EffectiveToken() != "" {
+						token = multiConfig.Global.EffectiveToken()
+						fmt.Fprintln(info, "📋 Using global token from multi-project config")
 					} else {
 						return fmt.Errorf("no GitHub token found in configuration. Run 'pivot init' to set up")
 					}
@@ -687,7 +1550,7 @@ Examples:
 					token = cfg.Token
 					configOwner = cfg.Owner
 					configRepo = cfg.Repo
-					cmd.Println("📋 Using token from single-project config")
+					fmt.Fprintln(info, "📋 Using token from single-project config")
 				}
 			}
 
@@ -699,13 +1562,26 @@ Examples:
 				repo = configRepo
 			}
 
-			// Validate basic credentials
-			cmd.Println("\n🧪 Testing GitHub token validity...")
-			if err := internal.ValidateGitHubCredentials(token); err != nil {
-				cmd.Printf("❌ Token validation failed: %v\n", err)
-				return err
+			if jsonOutput {
+				var repositories []string
+				if owner != "" && repo != "" {
+					repositories = append(repositories, owner+"/"+repo)
+				}
+				return printAuthVerification(cmd, token, repositories)
+			}
+
+			// Validate basic credentials; a GitHub App installation cannot read /user,
+			// so it is only checked against the repository below
+			if token == internal.GitHubAppToken {
+				cmd.Println("\n🤖 Authenticating as the configured GitHub App installation")
+			} else {
+				cmd.Println("\n🧪 Testing GitHub token validity...")
+				if err := internal.ValidateGitHubCredentials(token); err != nil {
+					cmd.Printf("❌ Token validation failed: %v\n", err)
+					return err
+				}
+				cmd.Println("✅ GitHub token is valid")
 			}
-			cmd.Println("✅ GitHub token is valid")
 
 			// Test repository access if specified
 			if owner != "" && repo != "" {
@@ -728,20 +1604,43 @@ Examples:
 	authVerifyCmd.Flags().String("owner", "", "GitHub repository owner/organization")
 	authVerifyCmd.Flags().String("repo", "", "GitHub repository name")
 	authVerifyCmd.Flags().String("token", "", "GitHub token to verify (instead of using config)")
+	authVerifyCmd.Flags().Bool("json", false, "Print the result as JSON (token_valid, login, scopes, repo_access)")
 
 	// Add flags to sync state management commands
 	statusCmd.Flags().Bool("verbose", false, "Show detailed status information and next actions")
+	statusCmd.Flags().Bool("watch", false, "Redraw the summary every --interval until interrupted")
+	statusCmd.Flags().Duration("interval", 2*time.Second, "Refresh interval for --watch")
+	statusCmd.Flags().Int("top-reactions", 0, "Also list the N most-reacted open issues")
+	statusCmd.Flags().Bool("summary-only", false, "Print only the count of each sync state on one line")
+	statusCmd.Flags().Bool("exit-code", false, "Exit with a distinct code for CONFLICTED (2), PUSH_FAILED (4), SYNC_FAILED (5) and ERROR (6) issues")
+	statusCmd.Flags().String("stale", "", "Count open issues not updated for this age, e.g. 30d, 2w or 36h (listed with --verbose)")
 	pushCmd.Flags().Bool("dry-run", false, "Preview what would be pushed without making changes")
 	pushCmd.Flags().Int("limit", 0, "Limit number of issues to push (0 = no limit)")
+	pushCmd.Flags().Bool("summary-only", false, "Print only the pushed, failed and skipped counts; the exit code is 3 when pushes failed")
+	pushCmd.Flags().Bool("create-milestone-if-missing", false, "Create milestones referenced by title that do not exist on GitHub yet")
+	pushCmd.Flags().String("on-violation", internal.ViolationAbort, "How to handle issues failing push validation: skip or abort")
 	resolveCmd.Flags().Bool("take-local", false, "Automatically take local version for all conflicts")
 	resolveCmd.Flags().Bool("take-remote", false, "Automatically take remote version for all conflicts")
+	resolveCmd.Flags().Int("issue", 0, "Resolve only the conflicted issue with this number")
+	resolveCmd.Flags().String("project", "", "Resolve only conflicts of this project (owner/repo)")
 
 	// Build auth command hierarchy
 	authCmd.AddCommand(authVerifyCmd)
 
+	rootCmd.PersistentFlags().Float64("rate", 0, "Maximum GitHub requests per second (0 = sync.requests_per_second of the config, unpaced by default)")
+	rootCmd.PersistentFlags().Int("concurrency", 0, "Maximum GitHub requests in flight at once (0 = sync.max_concurrency of the config, unbounded by default)")
+
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(configCmd)
 	rootCmd.AddCommand(syncCmd)
+	rootCmd.AddCommand(createListCommand())
+	rootCmd.AddCommand(createAPICommand())
+	rootCmd.AddCommand(createDBCommand())
+	rootCmd.AddCommand(createCreateCommand())
+	rootCmd.AddCommand(createShowCommand())
+	rootCmd.AddCommand(createLabelsCommand())
+	rootCmd.AddCommand(createHistoryCommand())
+	rootCmd.AddCommand(createReconcileCommand())
 	rootCmd.AddCommand(statusCmd)
 	rootCmd.AddCommand(pushCmd)
 	rootCmd.AddCommand(resolveCmd)
@@ -750,6 +1649,9 @@ Examples:
 	rootCmd.AddCommand(exportCmd)
 	rootCmd.AddCommand(versionCmd)
 
+	// Never let a token surface in command errors
+	scrubCommandErrors(rootCmd)
+
 	return rootCmd
 }
 
@@ -758,10 +1660,10 @@ func Run() int {
 	rootCmd := NewRootCommand()
 
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		return 1
+		fmt.Fprintf(os.Stderr, "Error: %v\n", internal.ScrubError(err))
+		return exitCode(err)
 	}
-	return 0
+	return ExitClean
 }
 
 func main() {
@@ -358,6 +358,30 @@ func TestSyncCommand(t *testing.T) {
 		}
 	})
 
+	t.Run("dump rate limit without config should fail", func(t *testing.T) {
+		tempDir := t.TempDir()
+		oldDir, _ := os.Getwd()
+		defer func() {
+			if err := os.Chdir(oldDir); err != nil {
+				t.Logf("Warning: Failed to change back to original directory: %v", err)
+			}
+		}()
+		if err := os.Chdir(tempDir); err != nil {
+			t.Fatalf("Failed to change to temp directory: %v", err)
+		}
+
+		output := &bytes.Buffer{}
+		cmd := NewRootCommand()
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetArgs([]string{"sync", "--dump-rate-limit"})
+
+		err := cmd.Execute()
+		if err == nil || !strings.Contains(err.Error(), "--dump-rate-limit requires a multi-project config or --repo") {
+			t.Errorf("Expected missing config error, got: %v", err)
+		}
+	})
+
 	t.Run("sync help", func(t *testing.T) {
 		output := &bytes.Buffer{}
 		cmd := NewRootCommand()
@@ -528,3 +552,96 @@ func TestConcurrentCommandExecution(t *testing.T) {
 		}
 	}
 }
+
+func TestGlobalRequestLimitFlags(t *testing.T) {
+	cmd := NewRootCommand()
+	for _, name := range []string{"rate", "concurrency"} {
+		if cmd.PersistentFlags().Lookup(name) == nil {
+			t.Errorf("Expected global --%s flag", name)
+		}
+	}
+
+	output := &bytes.Buffer{}
+	cmd.SetOut(output)
+	cmd.SetErr(output)
+	cmd.SetArgs([]string{"version", "--rate", "-1"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "must not be negative") {
+		t.Errorf("Expected a negative --rate to be rejected, got %v", err)
+	}
+
+	cmd = NewRootCommand()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"version", "--rate", "0", "--concurrency", "0"})
+	if err := cmd.Execute(); err != nil {
+		t.Errorf("Expected zero limits to be accepted, got %v", err)
+	}
+}
+
+func TestSyncCommandForceConflictAsValidation(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"sync", "--force-conflict-as", "theirs"}, "invalid --force-conflict-as 'theirs' (valid: local, remote)"},
+		{[]string{"sync", "--force-conflict-as", "local", "--dry-run"}, "--force-conflict-as cannot be used"},
+		{[]string{"sync", "--force-conflict-as", "remote", "--compare-only"}, "--force-conflict-as cannot be used"},
+	}
+	for _, tt := range tests {
+		cmd := NewRootCommand()
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(tt.args)
+		if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%v: expected error containing %q, got %v", tt.args, tt.want, err)
+		}
+	}
+}
+
+func TestSyncCommandWithoutToken(t *testing.T) {
+	tempDir := t.TempDir()
+	oldDir, _ := os.Getwd()
+	t.Cleanup(func() { _ = os.Chdir(oldDir) })
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+	configContent := `global:
+  database: ./pivot.db
+projects:
+  - owner: org
+    repo: alpha`
+	if err := os.WriteFile("config.yml", []byte(configContent), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cmd := NewRootCommand()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"sync"})
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "no GitHub token configured for org/alpha") || !strings.Contains(err.Error(), "pivot config set-token") {
+		t.Errorf("Expected an actionable missing token error, got %v", err)
+	}
+	if got := exitCode(err); got != ExitError {
+		t.Errorf("Expected exit code %d, got %d", ExitError, got)
+	}
+}
+
+// TestConfirmRepositoryMove tests that sync only asks about a moved repository when
+// the question can be seen and answered
+func TestConfirmRepositoryMove(t *testing.T) {
+	cmd := NewRootCommand()
+	output := &bytes.Buffer{}
+	cmd.SetOut(output)
+
+	// A pipe or buffer is not a terminal, so there is no prompt
+	cmd.SetIn(strings.NewReader("y\n"))
+	if confirmRepositoryMove(cmd, false) != nil {
+		t.Error("Expected no prompt when stdin is not a terminal")
+	}
+	if confirmRepositoryMove(cmd, true) != nil {
+		t.Error("Expected no prompt when the output is hidden")
+	}
+	if output.Len() != 0 {
+		t.Errorf("Expected nothing to be printed, got: %s", output.String())
+	}
+}
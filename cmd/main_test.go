@@ -142,7 +142,7 @@ database: ./test_init_existing.db
 	}
 
 	rootCmd := NewRootCommand()
-	rootCmd.SetArgs([]string{"init"})
+	rootCmd.SetArgs([]string{"init", "--no-gitignore"})
 
 	// Capture stdout
 	oldStdout := os.Stdout
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/rhino11/pivot/internal/csv"
+	"github.com/spf13/cobra"
+)
+
+// printMappingPreview prints how the columns of a CSV file resolve to issue fields
+func printMappingPreview(cmd *cobra.Command, filePath string, preview *csv.MappingPreview) {
+	cmd.Printf("\n🗺  Column mapping for %s:\n", filePath)
+	for _, column := range preview.Columns {
+		switch {
+		case column.Mapped:
+			cmd.Printf("  %s → %s (mapped)\n", column.Column, column.Field)
+		case column.Field != "":
+			cmd.Printf("  %s → %s\n", column.Column, column.Field)
+		case column.OverriddenBy != "":
+			cmd.Printf("  %s → (ignored, '%s' is mapped onto it)\n", column.Column, column.OverriddenBy)
+		default:
+			cmd.Printf("  %s → (unmapped)\n", column.Column)
+		}
+	}
+
+	if len(preview.Defaults) > 0 {
+		cmd.Println("\nDefaults (used when blank or missing):")
+		fields := make([]string, 0, len(preview.Defaults))
+		for field := range preview.Defaults {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			cmd.Printf("  %s = %s\n", field, preview.Defaults[field])
+		}
+	}
+
+	if unmapped := preview.Unmapped(); len(unmapped) > 0 {
+		cmd.Printf("\n⚠ %d unmapped columns will not be imported: %s\n", len(unmapped), strings.Join(unmapped, ", "))
+	}
+	for _, column := range preview.MissingColumns {
+		cmd.Printf("⚠ Mapped column '%s' is not in the CSV header\n", column)
+	}
+	for _, field := range preview.UnknownFields {
+		cmd.Printf("⚠ '%s' is not an issue field (valid: %s)\n", field, strings.Join(csv.ImportFields, ", "))
+	}
+	if preview.MissingTitle {
+		cmd.Println("❌ No column or default provides the required title field")
+	}
+}
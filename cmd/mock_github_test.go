@@ -0,0 +1,70 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/rhino11/pivot/internal"
+)
+
+// newMockGitHubServer starts a mock GitHub API that accepts any token for /user and
+// the owner/repo repository endpoint, serving the result of issuesJSON from the issues
+// endpoint. GitHub requests go to the server for the duration of the test.
+func newMockGitHubServer(t *testing.T, owner, repo string, issuesJSON func() string) *httptest.Server {
+	t.Helper()
+
+	routes := map[string]http.HandlerFunc{
+		"/user": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"login": "octocat"}`))
+		},
+		"/repos/" + owner + "/" + repo: func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"private": false}`))
+		},
+		"/repos/" + owner + "/" + repo + "/issues": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(issuesJSON()))
+		},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if handler, ok := routes[r.URL.Path]; ok {
+			handler(w, r)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+
+	restore := internal.SetGitHubAPIURL(server.URL)
+	t.Cleanup(func() {
+		restore()
+		server.Close()
+	})
+	return server
+}
+
+// setupConfiguredDBTest changes to a temporary directory holding a multi-project
+// config for owner/repo whose database is ./data/issues.db, not the legacy ./pivot.db
+func setupConfiguredDBTest(t *testing.T, owner, repo string) {
+	t.Helper()
+	oldDir, _ := os.Getwd()
+	t.Cleanup(func() {
+		if err := os.Chdir(oldDir); err != nil {
+			t.Logf("Warning: Failed to change back to original directory: %v", err)
+		}
+	})
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+
+	configContent := `global:
+  database: ./data/issues.db
+  token: test_token
+projects:
+  - owner: ` + owner + `
+    repo: ` + repo + `
+`
+	if err := os.WriteFile("config.yml", []byte(configContent), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+}
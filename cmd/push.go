@@ -0,0 +1,111 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/rhino11/pivot/internal"
+	"github.com/spf13/cobra"
+)
+
+// openPushDB loads the configuration and opens the database a push works on
+func openPushDB() (*internal.MultiProjectConfig, *sql.DB, error) {
+	config, err := internal.LoadMultiProjectConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	db, err := internal.InitMultiProjectDBWithSettings(config.Global.Database, config.Database)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	return config, db, nil
+}
+
+// runPush creates the local-only issues on GitHub, or lists them with dryRun
+func runPush(cmd *cobra.Command, dryRun bool, opts internal.PushOptions) error {
+	config, db, err := openPushDB()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	issues, err := internal.ListPushableIssues(db)
+	if err != nil {
+		return fmt.Errorf("failed to get local-only issues: %w", err)
+	}
+
+	if len(issues) == 0 {
+		cmd.Println("🎉 No local-only issues to push!")
+		return nil
+	}
+
+	if opts.Limit > 0 && len(issues) > opts.Limit {
+		cmd.Printf("📌 Limiting push to first %d of %d local-only issues\n", opts.Limit, len(issues))
+		issues = issues[:opts.Limit]
+	}
+
+	cmd.Printf("🚀 Found %d local-only issues to push\n", len(issues))
+
+	if dryRun {
+		cmd.Println("\n🧪 Dry Run Mode - No issues will be pushed")
+		cmd.Println("=========================================")
+		for i, local := range issues {
+			cmd.Printf("%d. %s/%s: %s (Local ID: %d)", i+1, local.Owner, local.Repo, local.Issue.Title, local.LocalID)
+			if local.Issue.Milestone != "" {
+				cmd.Printf(" [milestone: %s]", local.Issue.Milestone)
+			}
+			cmd.Println()
+		}
+		cmd.Printf("\nTotal: %d issues would be pushed to GitHub\n", len(issues))
+		return nil
+	}
+
+	result, err := internal.PushLocalIssues(db, config, issues, opts)
+	if err != nil {
+		return fmt.Errorf("push failed: %w", err)
+	}
+
+	for _, milestone := range result.CreatedMilestones {
+		cmd.Printf("📌 Created milestone %s\n", milestone)
+	}
+	for _, pushed := range result.Pushed {
+		cmd.Printf("✓ Pushed '%s' as %s/%s#%d\n", pushed.Title, pushed.Owner, pushed.Repo, pushed.Number)
+	}
+	for _, failure := range result.Failed {
+		cmd.Printf("❌ Failed to push '%s' (Local ID: %d): %s\n", failure.Title, failure.LocalID, failure.Error)
+	}
+	for _, skipped := range result.Skipped {
+		cmd.Printf("⏭️  Skipped '%s' (Local ID: %d): %s\n", skipped.Title, skipped.LocalID, skipped.Error)
+	}
+
+	cmd.Printf("\n📊 %d pushed, %d failed, %d skipped\n", len(result.Pushed), len(result.Failed), len(result.Skipped))
+	return pushOutcome(result)
+}
+
+// runPushSummary pushes the local-only issues like runPush but prints only the counts
+func runPushSummary(cmd *cobra.Command, opts internal.PushOptions) error {
+	config, db, err := openPushDB()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	issues, err := internal.ListPushableIssues(db)
+	if err != nil {
+		return fmt.Errorf("failed to get local-only issues: %w", err)
+	}
+	if opts.Limit > 0 && len(issues) > opts.Limit {
+		issues = issues[:opts.Limit]
+	}
+
+	result := &internal.PushResult{}
+	if len(issues) > 0 {
+		if result, err = internal.PushLocalIssues(db, config, issues, opts); err != nil {
+			return fmt.Errorf("push failed: %w", err)
+		}
+	}
+
+	printPushCounts(cmd.OutOrStdout(), result)
+	return pushOutcome(result)
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/rhino11/pivot/internal"
+)
+
+func TestPushCommandDryRun(t *testing.T) {
+	setupDBCommandTest(t)
+
+	db, err := internal.InitMultiProjectDBFromPath("./pivot.db")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if _, err := internal.CreateLocalIssue(db, 1, &internal.DBIssue{Title: "Local work", Milestone: "v2.0"}); err != nil {
+		t.Fatalf("Failed to create local issue: %v", err)
+	}
+	db.Close()
+
+	output := &bytes.Buffer{}
+	cmd := NewRootCommand()
+	cmd.SetOut(output)
+	cmd.SetErr(output)
+	cmd.SetArgs([]string{"push", "--dry-run", "--create-milestone-if-missing"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("push --dry-run failed: %v\n%s", err, output.String())
+	}
+
+	for _, want := range []string{"Found 1 local-only issues", "1. org/alpha: Local work", "[milestone: v2.0]", "Total: 1 issues would be pushed"} {
+		if !strings.Contains(output.String(), want) {
+			t.Errorf("Expected output to contain %q, got: %s", want, output.String())
+		}
+	}
+}
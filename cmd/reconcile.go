@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bufio"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rhino11/pivot/internal"
+	"github.com/spf13/cobra"
+)
+
+// createReconcileCommand creates the reconcile command that links local-only issues
+// to their synced duplicates
+func createReconcileCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reconcile",
+		Short: "Link local-only issues to matching issues that already exist on GitHub",
+		Long: `Find issues waiting to be pushed (LOCAL_ONLY or PUSH_FAILED) whose title matches
+an issue that was synced from GitHub, and link them instead of pushing a duplicate.
+
+Titles are compared after the match.normalize rules in config.yml; by default
+case, repeated whitespace and trailing punctuation are ignored. Linking gives the local issue
+the GitHub number and fields of the synced issue, removes the synced copy and marks
+the local issue SYNCED. Run 'pivot sync' first so recently created GitHub issues
+are known.
+
+Each match is confirmed interactively unless --yes is given; with --yes, local
+issues that match several synced issues are skipped.
+
+Examples:
+  pivot reconcile --dry-run   # List the matches
+  pivot reconcile             # Confirm each link
+  pivot reconcile --yes       # Link every unambiguous match`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			yes, _ := cmd.Flags().GetBool("yes")
+			if dryRun && yes {
+				return fmt.Errorf("--dry-run and --yes cannot be used together")
+			}
+
+			config, db, err := openPushDB()
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			matches, err := internal.FindReconcileMatches(db, config.Match.Normalize)
+			if err != nil {
+				return fmt.Errorf("failed to find matching issues: %w", err)
+			}
+			if len(matches) == 0 {
+				cmd.Println("🎉 No local-only issues match an issue on GitHub")
+				return nil
+			}
+
+			cmd.Printf("🔍 Found %d local-only issues matching issues on GitHub\n", len(matches))
+			if dryRun {
+				cmd.Println("\n🧪 Dry Run Mode - No issues will be linked")
+				cmd.Println("=========================================")
+				for _, match := range matches {
+					cmd.Printf("%s/%s: %s (Local ID: %d) -> %s\n", match.Local.Owner, match.Local.Repo,
+						match.Local.Issue.Title, match.Local.LocalID, formatCandidates(match.Remotes))
+				}
+				return nil
+			}
+
+			return reconcileMatches(cmd, db, matches, yes)
+		},
+	}
+
+	cmd.Flags().Bool("dry-run", false, "List the matches without linking them")
+	cmd.Flags().BoolP("yes", "y", false, "Link every unambiguous match without asking")
+
+	return cmd
+}
+
+// reconcileMatches links each match, asking first unless yes is set. A synced issue
+// is linked to at most one local issue.
+func reconcileMatches(cmd *cobra.Command, db *sql.DB, matches []internal.ReconcileMatch, yes bool) error {
+	reader := bufio.NewReader(cmd.InOrStdin())
+	linked := make(map[int64]bool)
+	count := 0
+
+	for _, match := range matches {
+		var remotes []internal.RemoteCandidate
+		for _, remote := range match.Remotes {
+			if !linked[remote.LocalID] {
+				remotes = append(remotes, remote)
+			}
+		}
+		if len(remotes) == 0 {
+			continue
+		}
+
+		var chosen *internal.RemoteCandidate
+		if yes {
+			if len(remotes) > 1 {
+				cmd.Printf("⚠ Skipped %s (Local ID: %d): matches %s\n", match.Local.Issue.Title, match.Local.LocalID, formatCandidates(remotes))
+				continue
+			}
+			chosen = &remotes[0]
+		} else {
+			var err error
+			if chosen, err = promptReconcile(cmd, reader, match.Local, remotes); err != nil {
+				return err
+			}
+			if chosen == nil {
+				cmd.Printf("⏭  Skipped %s (Local ID: %d)\n", match.Local.Issue.Title, match.Local.LocalID)
+				continue
+			}
+		}
+
+		if err := internal.LinkLocalIssue(db, match.Local.LocalID, chosen.LocalID); err != nil {
+			return fmt.Errorf("failed to link %s (Local ID: %d): %w", match.Local.Issue.Title, match.Local.LocalID, err)
+		}
+		linked[chosen.LocalID] = true
+		count++
+		cmd.Printf("✓ Linked %s (Local ID: %d) to %s/%s#%d\n", match.Local.Issue.Title, match.Local.LocalID,
+			match.Local.Owner, match.Local.Repo, chosen.Number)
+	}
+
+	cmd.Printf("\nLinked %d of %d local-only issues\n", count, len(matches))
+	return nil
+}
+
+// promptReconcile asks whether to link a local issue and, with several candidates, to
+// which one. A nil candidate means the issue is skipped.
+func promptReconcile(cmd *cobra.Command, reader *bufio.Reader, local internal.LocalIssue, remotes []internal.RemoteCandidate) (*internal.RemoteCandidate, error) {
+	for {
+		if len(remotes) == 1 {
+			cmd.Printf("Link %s (Local ID: %d) to #%d %s? [y]es or [n]o: ", local.Issue.Title, local.LocalID, remotes[0].Number, remotes[0].Title)
+		} else {
+			cmd.Printf("Link %s (Local ID: %d) to which issue, %s, or [s]kip? ", local.Issue.Title, local.LocalID, formatCandidates(remotes))
+		}
+		answer, err := reader.ReadString('\n')
+		if err != nil && answer == "" {
+			return nil, fmt.Errorf("no answer for local issue %d (use --yes to link without asking)", local.LocalID)
+		}
+
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		switch answer {
+		case "y", "yes":
+			if len(remotes) == 1 {
+				return &remotes[0], nil
+			}
+		case "n", "no", "s", "skip":
+			return nil, nil
+		}
+		if number, convErr := strconv.Atoi(strings.TrimPrefix(answer, "#")); convErr == nil {
+			for i := range remotes {
+				if remotes[i].Number == number {
+					return &remotes[i], nil
+				}
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("no answer for local issue %d (use --yes to link without asking)", local.LocalID)
+		}
+		if len(remotes) == 1 {
+			cmd.Println("Please answer y or n.")
+		} else {
+			cmd.Println("Please answer with one of the issue numbers or s.")
+		}
+	}
+}
+
+// formatCandidates lists synced issues as "#1 Title, #2 Title"
+func formatCandidates(remotes []internal.RemoteCandidate) string {
+	parts := make([]string, len(remotes))
+	for i, remote := range remotes {
+		parts[i] = fmt.Sprintf("#%d %s", remote.Number, remote.Title)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/rhino11/pivot/internal"
+)
+
+// seedReconcileTest adds local-only issues to the setupDBCommandTest database, which
+// already holds the synced issues #1 and #2 titled "Issue"
+func seedReconcileTest(t *testing.T, titles ...string) []int64 {
+	t.Helper()
+	db, err := internal.InitMultiProjectDBFromPath("./pivot.db")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	if err := internal.SaveIssue(db, 1, &internal.DBIssue{ID: 50, Number: 50, Title: "Login bug", State: "open"}); err != nil {
+		t.Fatalf("Failed to save synced issue: %v", err)
+	}
+	var ids []int64
+	for _, title := range titles {
+		id, err := internal.CreateLocalIssue(db, 1, &internal.DBIssue{Title: title})
+		if err != nil {
+			t.Fatalf("Failed to create local issue: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// runReconcile runs reconcile with the given args and stdin
+func runReconcile(t *testing.T, stdin string, args ...string) (string, error) {
+	t.Helper()
+	output := &bytes.Buffer{}
+	cmd := NewRootCommand()
+	cmd.SetOut(output)
+	cmd.SetErr(output)
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetArgs(append([]string{"reconcile"}, args...))
+	err := cmd.Execute()
+	return output.String(), err
+}
+
+// linkedNumber returns the GitHub number and sync state of a local issue
+func linkedNumber(t *testing.T, localID int64) (int, internal.SyncState) {
+	t.Helper()
+	db, err := internal.InitMultiProjectDBFromPath("./pivot.db")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	var number int
+	if err := db.QueryRow("SELECT number FROM issues WHERE rowid = ?", localID).Scan(&number); err != nil {
+		t.Fatalf("Failed to read issue: %v", err)
+	}
+	state, err := internal.GetSyncState(db, localID)
+	if err != nil {
+		t.Fatalf("Failed to read sync state: %v", err)
+	}
+	return number, state.SyncState
+}
+
+func TestReconcileCommandYes(t *testing.T) {
+	setupDBCommandTest(t)
+	ids := seedReconcileTest(t, "login BUG", "Issue", "Brand new")
+
+	output, err := runReconcile(t, "", "--yes")
+	if err != nil {
+		t.Fatalf("reconcile --yes failed: %v\n%s", err, output)
+	}
+
+	for _, want := range []string{
+		"Found 2 local-only issues matching",
+		"✓ Linked login BUG (Local ID: ",
+		"to org/alpha#50",
+		"⚠ Skipped Issue",
+		"matches #1 Issue, #2 Issue",
+		"Linked 1 of 2 local-only issues",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got: %s", want, output)
+		}
+	}
+	if number, state := linkedNumber(t, ids[0]); number != 50 || state != internal.SyncStateSynced {
+		t.Errorf("Expected issue linked to #50 and SYNCED, got #%d %s", number, state)
+	}
+	if _, state := linkedNumber(t, ids[1]); state != internal.SyncStateLocalOnly {
+		t.Errorf("Expected the ambiguous issue to stay LOCAL_ONLY, got %s", state)
+	}
+}
+
+func TestReconcileCommandInteractive(t *testing.T) {
+	setupDBCommandTest(t)
+	ids := seedReconcileTest(t, "Issue", "Login bug")
+
+	output, err := runReconcile(t, "#3\n2\nn\n")
+	if err != nil {
+		t.Fatalf("reconcile failed: %v\n%s", err, output)
+	}
+
+	if !strings.Contains(output, "Please answer with one of the issue numbers or s.") {
+		t.Errorf("Expected a retry for an unknown number, got: %s", output)
+	}
+	if !strings.Contains(output, "⏭  Skipped Login bug") {
+		t.Errorf("Expected the second issue to be skipped, got: %s", output)
+	}
+	if number, state := linkedNumber(t, ids[0]); number != 2 || state != internal.SyncStateSynced {
+		t.Errorf("Expected issue linked to #2 and SYNCED, got #%d %s", number, state)
+	}
+	if _, state := linkedNumber(t, ids[1]); state != internal.SyncStateLocalOnly {
+		t.Errorf("Expected the skipped issue to stay LOCAL_ONLY, got %s", state)
+	}
+}
+
+func TestReconcileCommandDryRun(t *testing.T) {
+	setupDBCommandTest(t)
+	ids := seedReconcileTest(t, "Login bug")
+
+	output, err := runReconcile(t, "", "--dry-run")
+	if err != nil {
+		t.Fatalf("reconcile --dry-run failed: %v\n%s", err, output)
+	}
+	if !strings.Contains(output, "org/alpha: Login bug (Local ID: ") || !strings.Contains(output, "-> #50 Login bug") {
+		t.Errorf("Expected the match to be listed, got: %s", output)
+	}
+	if _, state := linkedNumber(t, ids[0]); state != internal.SyncStateLocalOnly {
+		t.Errorf("Expected dry run to leave the issue LOCAL_ONLY, got %s", state)
+	}
+}
+
+func TestReconcileCommandNoMatches(t *testing.T) {
+	setupDBCommandTest(t)
+	seedReconcileTest(t, "Brand new")
+
+	output, err := runReconcile(t, "", "--yes")
+	if err != nil {
+		t.Fatalf("reconcile failed: %v\n%s", err, output)
+	}
+	if !strings.Contains(output, "No local-only issues match") {
+		t.Errorf("Expected no matches message, got: %s", output)
+	}
+}
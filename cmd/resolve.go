@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bufio"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/rhino11/pivot/internal"
+	"github.com/spf13/cobra"
+)
+
+// resolveConflicts applies resolution to each conflict, prompting for a choice
+// per issue when resolution is empty
+func resolveConflicts(cmd *cobra.Command, db *sql.DB, conflicts []internal.ConflictedIssue, resolution string) error {
+	reader := bufio.NewReader(cmd.InOrStdin())
+	resolved := 0
+
+	for _, conflict := range conflicts {
+		choice := resolution
+		if choice == "" {
+			var err error
+			if choice, err = promptResolution(cmd, reader, conflict); err != nil {
+				return err
+			}
+			if choice == "" {
+				cmd.Printf("⏭  Skipped #%d\n", conflict.Number)
+				continue
+			}
+		}
+
+		if err := internal.ResolveConflict(db, conflict.IssueLocalID, choice); err != nil {
+			return fmt.Errorf("failed to resolve issue #%d: %w", conflict.Number, err)
+		}
+		cmd.Printf("✓ Resolved #%d %s (kept %s version)\n", conflict.Number, conflict.Title, choice)
+		resolved++
+	}
+
+	cmd.Printf("\nResolved %d of %d conflicted issues\n", resolved, len(conflicts))
+	return nil
+}
+
+// promptResolution asks which version of a conflicted issue to keep. An empty
+// choice means the issue is skipped.
+func promptResolution(cmd *cobra.Command, reader *bufio.Reader, conflict internal.ConflictedIssue) (string, error) {
+	for {
+		cmd.Printf("#%d %s: keep [l]ocal, [r]emote or [s]kip? ", conflict.Number, conflict.Title)
+		answer, err := reader.ReadString('\n')
+		if err != nil && answer == "" {
+			return "", fmt.Errorf("no resolution chosen for issue #%d (use --take-local or --take-remote)", conflict.Number)
+		}
+
+		switch strings.ToLower(strings.TrimSpace(answer)) {
+		case "l", "local":
+			return internal.ResolutionLocal, nil
+		case "r", "remote":
+			return internal.ResolutionRemote, nil
+		case "s", "skip":
+			return "", nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("no resolution chosen for issue #%d (use --take-local or --take-remote)", conflict.Number)
+		}
+		cmd.Println("Please answer l, r or s.")
+	}
+}
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"github.com/rhino11/pivot/internal"
+	"github.com/spf13/cobra"
+)
+
+// scrubCommandErrors wraps the RunE of a command and all of its subcommands so that
+// returned errors have known secrets removed before they are printed
+func scrubCommandErrors(cmd *cobra.Command) {
+	if run := cmd.RunE; run != nil {
+		cmd.RunE = func(c *cobra.Command, args []string) error {
+			return internal.ScrubError(run(c, args))
+		}
+	}
+	for _, child := range cmd.Commands() {
+		scrubCommandErrors(child)
+	}
+}
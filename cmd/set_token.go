@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+
+	"github.com/rhino11/pivot/internal"
+	"github.com/spf13/cobra"
+)
+
+// promptToken reads a token from the terminal without echoing it. Tests replace it.
+var promptToken = promptTokenNoEcho
+
+// createConfigSetTokenCommand creates the config set-token command
+func createConfigSetTokenCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-token",
+		Short: "Store a GitHub token in config.yml without passing it on the command line",
+		Long: `Store a GitHub token in config.yml as the global token, or with --project as
+the token of one configured project.
+
+The token is never accepted as an argument or flag value, so it does not end up
+in shell history or process lists. It is read from a prompt that does not echo
+the input, from standard input with --stdin, or from a file with --file.
+config.yml is written with owner-only permissions.
+
+Examples:
+  pivot config set-token
+  pivot config set-token --project myorg/myrepo
+  gh auth token | pivot config set-token --stdin
+  pivot config set-token --file ~/.secrets/github-token`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			// Never echo the arguments, they may be the token itself
+			if len(args) > 0 {
+				return fmt.Errorf("set-token does not accept arguments; enter the token at the prompt or use --stdin or --file")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fromStdin, _ := cmd.Flags().GetBool("stdin")
+			file, _ := cmd.Flags().GetString("file")
+			project, _ := cmd.Flags().GetString("project")
+
+			if fromStdin && file != "" {
+				return fmt.Errorf("--stdin and --file cannot be used together")
+			}
+
+			token, err := readToken(cmd, fromStdin, file)
+			if err != nil {
+				return err
+			}
+			if err := internal.SetToken(token, project); err != nil {
+				return err
+			}
+
+			if project != "" {
+				cmd.Printf("✓ Stored the GitHub token for %s in config.yml\n", project)
+			} else {
+				cmd.Println("✓ Stored the global GitHub token in config.yml")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("stdin", false, "Read the token from standard input")
+	cmd.Flags().String("file", "", "Read the token from this file")
+	cmd.Flags().String("project", "", "Store the token for this configured project (format: owner/repo)")
+	registerProjectCompletion(cmd, "project")
+
+	return cmd
+}
+
+// readToken reads a token from stdin, a file or the no-echo prompt
+func readToken(cmd *cobra.Command, fromStdin bool, file string) (string, error) {
+	var input string
+	switch {
+	case fromStdin:
+		line, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+		if err != nil && err != io.EOF {
+			return "", fmt.Errorf("failed to read token from stdin: %w", err)
+		}
+		input = line
+	case file != "":
+		data, err := os.ReadFile(file) // #nosec G304 - User controls the token file path
+		if err != nil {
+			return "", fmt.Errorf("failed to read token file: %w", err)
+		}
+		input = string(data)
+	default:
+		line, err := promptToken(cmd)
+		if err != nil {
+			return "", err
+		}
+		input = line
+	}
+
+	token, err := internal.NormalizeToken(input)
+	if err != nil {
+		return "", fmt.Errorf("invalid token: %w", err)
+	}
+	return token, nil
+}
+
+// promptTokenNoEcho asks for the token on the terminal with echo turned off
+func promptTokenNoEcho(cmd *cobra.Command) (string, error) {
+	if !isTerminal(cmd.InOrStdin()) {
+		return "", fmt.Errorf("no terminal to prompt for the token; use --stdin or --file")
+	}
+
+	if err := setTerminalEcho(false); err != nil {
+		return "", fmt.Errorf("cannot hide the token while typing (%v); use --stdin or --file", err)
+	}
+	defer func() {
+		_ = setTerminalEcho(true)
+		fmt.Fprintln(cmd.ErrOrStderr())
+	}()
+
+	fmt.Fprint(cmd.ErrOrStderr(), "GitHub token: ")
+	line, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("failed to read token: %w", err)
+	}
+	return line, nil
+}
+
+// setTerminalEcho turns terminal echo on or off with stty
+func setTerminalEcho(on bool) error {
+	mode := "-echo"
+	if on {
+		mode = "echo"
+	}
+	stty := exec.Command("stty", mode) // #nosec G204 - Fixed arguments
+	stty.Stdin = os.Stdin
+	return stty.Run()
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// runSetToken runs config set-token with the given args and stdin
+func runSetToken(t *testing.T, stdin string, args ...string) (string, error) {
+	t.Helper()
+	output := &bytes.Buffer{}
+	cmd := NewRootCommand()
+	cmd.SetOut(output)
+	cmd.SetErr(output)
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetArgs(append([]string{"config", "set-token"}, args...))
+	err := cmd.Execute()
+	return output.String(), err
+}
+
+// readConfigFile returns the contents of config.yml in the current directory
+func readConfigFile(t *testing.T) string {
+	t.Helper()
+	data, err := os.ReadFile("config.yml")
+	if err != nil {
+		t.Fatalf("Failed to read config.yml: %v", err)
+	}
+	return string(data)
+}
+
+func TestConfigSetTokenFromStdin(t *testing.T) {
+	setupDBCommandTest(t)
+
+	output, err := runSetToken(t, "ghp_fromstdin123\n", "--stdin")
+	if err != nil {
+		t.Fatalf("set-token --stdin failed: %v\n%s", err, output)
+	}
+	if !strings.Contains(output, "Stored the global GitHub token") {
+		t.Errorf("Expected confirmation, got: %s", output)
+	}
+	if strings.Contains(output, "ghp_fromstdin123") {
+		t.Errorf("Expected the token not to be printed, got: %s", output)
+	}
+	if config := readConfigFile(t); !strings.Contains(config, "token: ghp_fromstdin123") {
+		t.Errorf("Expected config.yml to contain the new token, got:\n%s", config)
+	}
+}
+
+func TestConfigSetTokenFromFileForProject(t *testing.T) {
+	setupDBCommandTest(t)
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("ghp_fromfile456\n"), 0600); err != nil {
+		t.Fatalf("Failed to write token file: %v", err)
+	}
+
+	output, err := runSetToken(t, "", "--file", tokenFile, "--project", "org/alpha")
+	if err != nil {
+		t.Fatalf("set-token --file failed: %v\n%s", err, output)
+	}
+	if !strings.Contains(output, "Stored the GitHub token for org/alpha") {
+		t.Errorf("Expected project confirmation, got: %s", output)
+	}
+	config := readConfigFile(t)
+	if !strings.Contains(config, "token: ghp_fromfile456") {
+		t.Errorf("Expected config.yml to contain the project token, got:\n%s", config)
+	}
+	if !strings.Contains(config, "token: test_token") {
+		t.Errorf("Expected the global token to be kept, got:\n%s", config)
+	}
+}
+
+func TestConfigSetTokenPrompt(t *testing.T) {
+	setupDBCommandTest(t)
+	original := promptToken
+	t.Cleanup(func() { promptToken = original })
+	promptToken = func(cmd *cobra.Command) (string, error) {
+		return "ghp_prompted789\n", nil
+	}
+
+	if output, err := runSetToken(t, ""); err != nil {
+		t.Fatalf("set-token failed: %v\n%s", err, output)
+	}
+	if config := readConfigFile(t); !strings.Contains(config, "token: ghp_prompted789") {
+		t.Errorf("Expected config.yml to contain the prompted token, got:\n%s", config)
+	}
+}
+
+func TestConfigSetTokenPromptRequiresTerminal(t *testing.T) {
+	setupDBCommandTest(t)
+
+	_, err := runSetToken(t, "ghp_piped\n")
+	if err == nil || !strings.Contains(err.Error(), "use --stdin or --file") {
+		t.Errorf("Expected no terminal error, got %v", err)
+	}
+	if config := readConfigFile(t); strings.Contains(config, "ghp_piped") {
+		t.Errorf("Expected config.yml unchanged, got:\n%s", config)
+	}
+}
+
+func TestConfigSetTokenRejectsArguments(t *testing.T) {
+	setupDBCommandTest(t)
+
+	output, err := runSetToken(t, "", "ghp_onthecommandline")
+	if err == nil {
+		t.Fatal("Expected an error for a positional token")
+	}
+	if strings.Contains(err.Error(), "ghp_onthecommandline") || strings.Contains(output, "ghp_onthecommandline") {
+		t.Errorf("Expected the argument not to be echoed, got error %q and output: %s", err, output)
+	}
+	if config := readConfigFile(t); strings.Contains(config, "ghp_onthecommandline") {
+		t.Errorf("Expected config.yml unchanged, got:\n%s", config)
+	}
+}
+
+func TestConfigSetTokenInvalidInput(t *testing.T) {
+	setupDBCommandTest(t)
+
+	if _, err := runSetToken(t, "\n", "--stdin"); err == nil || !strings.Contains(err.Error(), "token is empty") {
+		t.Errorf("Expected empty token error, got %v", err)
+	}
+	if _, err := runSetToken(t, "", "--stdin", "--file", "token"); err == nil || !strings.Contains(err.Error(), "cannot be used together") {
+		t.Errorf("Expected mutually exclusive flags error, got %v", err)
+	}
+}
+
+func TestConfigSetTokenHasNoTokenFlag(t *testing.T) {
+	cmd := createConfigSetTokenCommand()
+	for _, name := range []string{"token", "github-token", "set-token"} {
+		if cmd.Flags().Lookup(name) != nil {
+			t.Errorf("Expected no flag taking the token, found --%s", name)
+		}
+	}
+	if usage := cmd.Flags().FlagUsages(); strings.Contains(usage, "--token") {
+		t.Errorf("Expected no --token flag, got usage:\n%s", usage)
+	}
+}
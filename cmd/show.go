@@ -0,0 +1,226 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/rhino11/pivot/internal"
+	"github.com/spf13/cobra"
+)
+
+// createShowCommand creates the show command that prints a single stored issue
+func createShowCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show <number>",
+		Short: "Show an issue from the local database",
+		Long: `Show a single issue stored in the local database: its title, state, labels,
+assignees, milestone, timestamps, sync state, GitHub URL and body.
+
+Use --json to print the same fields as a JSON object, e.g. for scripts.
+
+Use --raw to print the GitHub JSON stored for the issue by 'pivot sync
+--store-raw', exactly as it was received. Compressed copies are decompressed
+transparently.
+
+--project is required when the database holds more than one project.
+
+Examples:
+  pivot show 42
+  pivot show 42 --json
+  pivot show 42 --project myorg/myrepo --raw`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			project, _ := cmd.Flags().GetString("project")
+			raw, _ := cmd.Flags().GetBool("raw")
+			jsonOutput, _ := cmd.Flags().GetBool("json")
+			if raw && jsonOutput {
+				return fmt.Errorf("--raw and --json cannot be used together")
+			}
+
+			number, err := strconv.Atoi(args[0])
+			if err != nil || number <= 0 {
+				return fmt.Errorf("issue number must be a positive integer, got: %s", args[0])
+			}
+
+			db, err := internal.OpenConfiguredDB()
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			found, err := resolveShowProject(db, project)
+			if err != nil {
+				return err
+			}
+			projectID := int64(found.ID)
+
+			if raw {
+				data, err := internal.GetRawIssue(db, projectID, number)
+				if err != nil {
+					return err
+				}
+				if data == nil {
+					return fmt.Errorf("no raw JSON stored for issue #%d; run 'pivot sync --store-raw' first", number)
+				}
+				_, err = cmd.OutOrStdout().Write(append(data, '\n'))
+				return err
+			}
+
+			detail, err := loadIssueDetail(db, found, number)
+			if err != nil {
+				return err
+			}
+			if jsonOutput {
+				data, err := json.MarshalIndent(detail, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to encode issue #%d: %w", number, err)
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), string(data))
+				return nil
+			}
+			printIssue(cmd.OutOrStdout(), detail)
+			return nil
+		},
+	}
+
+	cmd.Flags().String("project", "", "Project of the issue (format: owner/repo)")
+	registerProjectCompletion(cmd, "project")
+	cmd.Flags().Bool("raw", false, "Print the stored GitHub JSON of the issue")
+	cmd.Flags().Bool("json", false, "Print the issue as JSON")
+
+	return cmd
+}
+
+// resolveShowProject returns the project named owner/repo, or the only project in the
+// database when no project is given
+func resolveShowProject(db *sql.DB, project string) (*internal.ProjectConfig, error) {
+	if project != "" {
+		parts := strings.Split(project, "/")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("project must be in format 'owner/repo', got: %s", project)
+		}
+		return internal.FindProjectByOwnerRepo(db, parts[0], parts[1])
+	}
+
+	projects, err := internal.ListProjects(db)
+	if err != nil {
+		return nil, err
+	}
+	switch len(projects) {
+	case 0:
+		return nil, fmt.Errorf("no projects in the local database; run 'pivot sync' first")
+	case 1:
+		return &projects[0], nil
+	default:
+		return nil, fmt.Errorf("the database holds %d projects; use --project owner/repo", len(projects))
+	}
+}
+
+// storedProjectToken returns the GitHub token for a project found in the database:
+// the token of its entry in config.yml, or the global token when it has none
+func storedProjectToken(project *internal.ProjectConfig) (string, error) {
+	config, err := internal.LoadMultiProjectConfig()
+	if err != nil {
+		return "", fmt.Errorf("failed to load configuration: %w", err)
+	}
+	token := config.Global.EffectiveToken()
+	if configured, err := selectProject(config, project.Owner+"/"+project.Repo); err == nil {
+		token = configured.GetEffectiveToken(&config.Global)
+	}
+	if token == "" {
+		return "", fmt.Errorf("no GitHub token configured for project %s/%s", project.Owner, project.Repo)
+	}
+	return token, nil
+}
+
+// issueDetail is a stored issue with the local information 'pivot show' adds to it
+type issueDetail struct {
+	internal.DBIssue
+	LocalID   int64  `json:"local_id"`
+	Project   string `json:"project"` // owner/repo
+	HTMLURL   string `json:"html_url"`
+	SyncState string `json:"sync_state,omitempty"` // Empty for issues synced before sync states were recorded
+	SyncError string `json:"sync_error,omitempty"`
+}
+
+// loadIssueDetail returns the issue with the given number in a project
+func loadIssueDetail(db *sql.DB, project *internal.ProjectConfig, number int) (*issueDetail, error) {
+	localID, err := internal.LocalIDByNumber(db, int64(project.ID), number)
+	if err != nil {
+		return nil, err
+	}
+	issues, err := internal.GetIssuesForProject(db, int64(project.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, issue := range issues {
+		if issue.Number != number || localID == 0 {
+			continue
+		}
+		detail := &issueDetail{
+			DBIssue: issue,
+			LocalID: localID,
+			Project: project.Owner + "/" + project.Repo,
+			HTMLURL: fmt.Sprintf("https://github.com/%s/%s/issues/%d", project.Owner, project.Repo, number),
+		}
+		if err := internal.InitSyncStateSchema(db); err != nil {
+			return nil, err
+		}
+		state, err := internal.GetSyncState(db, localID)
+		if err != nil {
+			return nil, err
+		}
+		if state != nil {
+			detail.SyncState = string(state.SyncState)
+			if state.SyncError != nil {
+				detail.SyncError = *state.SyncError
+			}
+		}
+		return detail, nil
+	}
+	return nil, fmt.Errorf("issue #%d not found in the local database", number)
+}
+
+// printIssue prints the stored fields of an issue
+func printIssue(w io.Writer, issue *issueDetail) {
+	fmt.Fprintf(w, "#%d %s\n", issue.Number, issue.Title)
+	fmt.Fprintf(w, "Project:   %s\n", issue.Project)
+	fmt.Fprintf(w, "State:     %s\n", issue.State)
+	if issue.Type != "" {
+		fmt.Fprintf(w, "Type:      %s\n", issue.Type)
+	}
+	if issue.Labels != "" {
+		fmt.Fprintf(w, "Labels:    %s\n", issue.Labels)
+	}
+	if issue.Assignees != "" {
+		fmt.Fprintf(w, "Assignees: %s\n", issue.Assignees)
+	}
+	if issue.Milestone != "" {
+		fmt.Fprintf(w, "Milestone: %s\n", issue.Milestone)
+	}
+	fmt.Fprintf(w, "Created:   %s\n", issue.CreatedAt)
+	fmt.Fprintf(w, "Updated:   %s\n", issue.UpdatedAt)
+	if issue.ClosedAt != "" {
+		fmt.Fprintf(w, "Closed:    %s\n", issue.ClosedAt)
+	}
+	if issue.Dependencies != "" {
+		fmt.Fprintf(w, "Depends:   %s\n", "#"+strings.ReplaceAll(issue.Dependencies, ",", ", #"))
+	}
+	if issue.SyncState != "" {
+		fmt.Fprintf(w, "Sync:      %s (Local ID: %d)\n", issue.SyncState, issue.LocalID)
+	} else {
+		fmt.Fprintf(w, "Sync:      unknown (Local ID: %d)\n", issue.LocalID)
+	}
+	if issue.SyncError != "" {
+		fmt.Fprintf(w, "Error:     %s\n", issue.SyncError)
+	}
+	fmt.Fprintf(w, "URL:       %s\n", issue.HTMLURL)
+	if issue.Body != "" {
+		fmt.Fprintf(w, "\n%s\n", issue.Body)
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/rhino11/pivot/internal"
+)
+
+func runShowCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	output := &bytes.Buffer{}
+	cmd := NewRootCommand()
+	cmd.SetOut(output)
+	cmd.SetErr(output)
+	cmd.SetArgs(append([]string{"show"}, args...))
+	err := cmd.Execute()
+	return output.String(), err
+}
+
+func TestShowCommand(t *testing.T) {
+	setupDBCommandTest(t)
+
+	output, err := runShowCommand(t, "2")
+	if err != nil {
+		t.Fatalf("show failed: %v\n%s", err, output)
+	}
+	if !strings.Contains(output, "#2 Issue") || !strings.Contains(output, "State:     open") {
+		t.Errorf("Expected issue #2 details, got: %s", output)
+	}
+
+	if _, err := runShowCommand(t, "99"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+	if _, err := runShowCommand(t, "abc"); err == nil {
+		t.Error("Expected error for an invalid issue number")
+	}
+}
+
+func TestShowCommandRaw(t *testing.T) {
+	setupDBCommandTest(t)
+
+	if _, err := runShowCommand(t, "1", "--raw"); err == nil || !strings.Contains(err.Error(), "--store-raw") {
+		t.Errorf("Expected hint to sync with --store-raw, got %v", err)
+	}
+
+	db, err := internal.InitMultiProjectDBFromPath("./pivot.db")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	raw := []byte(`{"number": 1, "title": "Issue",  "body": "exact spacing"}`)
+	if err := internal.SaveRawIssue(db, 1, 1, raw, true); err != nil {
+		t.Fatalf("Failed to save raw JSON: %v", err)
+	}
+	db.Close()
+
+	output, err := runShowCommand(t, "1", "--raw", "--project", "org/alpha")
+	if err != nil {
+		t.Fatalf("show --raw failed: %v\n%s", err, output)
+	}
+	if output != string(raw)+"\n" {
+		t.Errorf("Expected the stored JSON, got: %s", output)
+	}
+}
+
+// seedShowIssue stores a synced issue with every detail field set
+func seedShowIssue(t *testing.T) {
+	t.Helper()
+	db, err := internal.InitMultiProjectDBFromPath("./pivot.db")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	_, err = internal.SaveSyncedIssue(db, 1, &internal.DBIssue{
+		ID: 7000, Number: 7, Title: "Detailed issue", Body: "Steps to reproduce", State: "closed",
+		Labels: "bug,ui", Assignees: "alice,bob", Milestone: "v1.0",
+		CreatedAt: "2024-01-02T03:04:05Z", UpdatedAt: "2024-02-03T04:05:06Z", ClosedAt: "2024-02-03T04:05:06Z",
+		Dependencies: "1,2",
+	})
+	if err != nil {
+		t.Fatalf("Failed to seed issue: %v", err)
+	}
+}
+
+func TestShowCommandDetail(t *testing.T) {
+	setupDBCommandTest(t)
+	seedShowIssue(t)
+
+	output, err := runShowCommand(t, "7")
+	if err != nil {
+		t.Fatalf("show failed: %v\n%s", err, output)
+	}
+	for _, expected := range []string{
+		"#7 Detailed issue",
+		"Project:   org/alpha",
+		"State:     closed",
+		"Labels:    bug,ui",
+		"Assignees: alice,bob",
+		"Milestone: v1.0",
+		"Created:   2024-01-02T03:04:05Z",
+		"Updated:   2024-02-03T04:05:06Z",
+		"Closed:    2024-02-03T04:05:06Z",
+		"Depends:   #1, #2",
+		"Sync:      SYNCED (Local ID: 3)",
+		"URL:       https://github.com/org/alpha/issues/7",
+		"Steps to reproduce",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected %q in output, got:\n%s", expected, output)
+		}
+	}
+
+	// Issues stored without a sync state still show
+	output, err = runShowCommand(t, "1")
+	if err != nil {
+		t.Fatalf("show failed: %v\n%s", err, output)
+	}
+	if !strings.Contains(output, "Sync:      unknown (Local ID: 1)") {
+		t.Errorf("Expected unknown sync state, got:\n%s", output)
+	}
+}
+
+func TestShowCommandJSON(t *testing.T) {
+	setupDBCommandTest(t)
+	seedShowIssue(t)
+
+	output, err := runShowCommand(t, "7", "--json")
+	if err != nil {
+		t.Fatalf("show --json failed: %v\n%s", err, output)
+	}
+	var detail map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &detail); err != nil {
+		t.Fatalf("Expected JSON output, got %q: %v", output, err)
+	}
+	expected := map[string]interface{}{
+		"number":     float64(7),
+		"title":      "Detailed issue",
+		"body":       "Steps to reproduce",
+		"state":      "closed",
+		"labels":     "bug,ui",
+		"assignees":  "alice,bob",
+		"milestone":  "v1.0",
+		"created_at": "2024-01-02T03:04:05Z",
+		"updated_at": "2024-02-03T04:05:06Z",
+		"closed_at":  "2024-02-03T04:05:06Z",
+		"project":    "org/alpha",
+		"sync_state": "SYNCED",
+		"html_url":   "https://github.com/org/alpha/issues/7",
+	}
+	for key, value := range expected {
+		if detail[key] != value {
+			t.Errorf("Expected %s = %v, got %v", key, value, detail[key])
+		}
+	}
+
+	if _, err := runShowCommand(t, "99", "--json"); err == nil || !strings.Contains(err.Error(), "issue #99 not found") {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+	if _, err := runShowCommand(t, "7", "--json", "--raw"); err == nil {
+		t.Error("Expected --json and --raw to be rejected together")
+	}
+}
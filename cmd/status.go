@@ -0,0 +1,146 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/rhino11/pivot/internal"
+	"github.com/spf13/cobra"
+)
+
+// clearScreen moves the cursor home and clears the terminal
+const clearScreen = "\033[H\033[2J"
+
+// openStateDB opens the database sync and push record sync states in: the configured
+// multi-project database, or the legacy ./pivot.db when no configuration loads
+func openStateDB() (*sql.DB, error) {
+	if _, err := internal.LoadMultiProjectConfig(); err != nil {
+		return internal.InitDB()
+	}
+	return internal.OpenConfiguredDB()
+}
+
+// renderStatus prints the sync state summary of the database
+func renderStatus(cmd *cobra.Command, db *sql.DB, verbose bool) error {
+	// Get sync state summary
+	summary, err := internal.GetOrderedSyncStateSummary(db)
+	if err != nil {
+		return fmt.Errorf("failed to get sync state summary: %w", err)
+	}
+
+	cmd.Println("📊 Sync State Summary")
+	cmd.Println("====================")
+
+	total := 0
+	counts := make(map[internal.SyncState]int, len(summary))
+	for _, entry := range summary {
+		state, count := entry.State, entry.Count
+		total += count
+		counts[state] = count
+		var icon, description string
+		switch state {
+		case internal.SyncStateLocalOnly:
+			icon, description = "📝", "Created locally, not on GitHub"
+		case internal.SyncStatePendingPush:
+			icon, description = "⏳", "Queued for GitHub creation"
+		case internal.SyncStatePushFailed:
+			icon, description = "❌", "Failed to push to GitHub"
+		case internal.SyncStateSynced:
+			icon, description = "✅", "Synchronized with GitHub"
+		case internal.SyncStateLocalModified:
+			icon, description = "📝", "Modified locally since sync"
+		case internal.SyncStatePendingSync:
+			icon, description = "⏳", "Queued for GitHub update"
+		case internal.SyncStateSyncFailed:
+			icon, description = "❌", "Failed to sync to GitHub"
+		case internal.SyncStateConflicted:
+			icon, description = "⚠️", "Conflicting local/remote changes"
+		case internal.SyncStateError:
+			icon, description = "💥", "Unrecoverable error state"
+		default:
+			icon, description = "❓", "Unknown state"
+		}
+
+		cmd.Printf("  %s %s: %d issues", icon, state, count)
+		if verbose {
+			cmd.Printf(" - %s", description)
+		}
+		cmd.Println()
+	}
+
+	cmd.Printf("\nTotal: %d issues\n", total)
+
+	// Show derived workflow states when state mappings are configured
+	if mappings := internal.ConfiguredStateMappings(); len(mappings) > 0 {
+		printWorkflowStates(cmd, mappings)
+	}
+
+	// Show actionable items
+	if verbose {
+		cmd.Println("\n💡 Next Actions:")
+		if localOnlyCount := counts[internal.SyncStateLocalOnly]; localOnlyCount > 0 {
+			cmd.Printf("  • Run 'pivot push' to push %d local-only issues to GitHub\n", localOnlyCount)
+		}
+		if modifiedCount := counts[internal.SyncStateLocalModified]; modifiedCount > 0 {
+			cmd.Printf("  • Run 'pivot sync' to sync %d locally modified issues\n", modifiedCount)
+		}
+		if conflictedCount := counts[internal.SyncStateConflicted]; conflictedCount > 0 {
+			cmd.Printf("  • Run 'pivot resolve' to handle %d conflicted issues\n", conflictedCount)
+		}
+		if failedCount := counts[internal.SyncStatePushFailed] + counts[internal.SyncStateSyncFailed]; failedCount > 0 {
+			cmd.Printf("  • Check and retry %d failed sync operations\n", failedCount)
+		}
+	}
+
+	return nil
+}
+
+// watchStatus redraws the status summary every interval until interrupted. When the
+// output is not a terminal the summary is rendered once.
+func watchStatus(cmd *cobra.Command, db *sql.DB, verbose bool, interval time.Duration) error {
+	if !isTerminal(cmd.OutOrStdout()) {
+		cmd.Println("⚠ --watch needs a terminal; showing the summary once")
+		return renderStatus(cmd, db, verbose)
+	}
+	if interval <= 0 {
+		return fmt.Errorf("--interval must be positive, got %s", interval)
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		cmd.Print(clearScreen)
+		if err := renderStatus(cmd, db, verbose); err != nil {
+			return err
+		}
+		cmd.Printf("\nRefreshing every %s (updated %s), press Ctrl+C to stop\n", interval, time.Now().Format("15:04:05"))
+
+		select {
+		case <-interrupt:
+			cmd.Println()
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// isTerminal reports whether w is an interactive terminal
+func isTerminal(w interface{}) bool {
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,365 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rhino11/pivot/internal"
+	"github.com/spf13/cobra"
+)
+
+func TestRenderStatusReflectsChangingCounts(t *testing.T) {
+	tempDir := t.TempDir()
+	oldDir, _ := os.Getwd()
+	defer func() {
+		if err := os.Chdir(oldDir); err != nil {
+			t.Logf("Warning: Failed to change back to original directory: %v", err)
+		}
+	}()
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+
+	db, err := internal.InitDB()
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.Close()
+	if err := internal.InitSyncStateSchema(db); err != nil {
+		t.Fatalf("Failed to create sync state schema: %v", err)
+	}
+
+	addIssue := func(githubID int64, state internal.SyncState) {
+		t.Helper()
+		if _, err := db.Exec("INSERT INTO issues (github_id, number, title, state) VALUES (?, ?, 'Issue', 'open')", githubID, githubID); err != nil {
+			t.Fatalf("Failed to insert issue: %v", err)
+		}
+		if err := internal.CreateSyncState(db, githubID, state, &githubID); err != nil {
+			t.Fatalf("Failed to create sync state: %v", err)
+		}
+	}
+
+	output := &bytes.Buffer{}
+	cmd := &cobra.Command{}
+	cmd.SetOut(output)
+
+	addIssue(1, internal.SyncStateLocalOnly)
+	addIssue(2, internal.SyncStateLocalOnly)
+	if err := renderStatus(cmd, db, false); err != nil {
+		t.Fatalf("First render failed: %v", err)
+	}
+	first := output.String()
+	if !strings.Contains(first, "LOCAL_ONLY: 2 issues") || !strings.Contains(first, "Total: 2 issues") {
+		t.Errorf("Expected 2 local-only issues in first render, got:\n%s", first)
+	}
+
+	// A sync in the background pushes one issue and adds another
+	if err := internal.UpdateSyncState(db, 1, internal.SyncStateSynced, nil, nil); err != nil {
+		t.Fatalf("Failed to update sync state: %v", err)
+	}
+	addIssue(3, internal.SyncStateSynced)
+
+	output.Reset()
+	if err := renderStatus(cmd, db, false); err != nil {
+		t.Fatalf("Second render failed: %v", err)
+	}
+	second := output.String()
+	for _, want := range []string{"LOCAL_ONLY: 1 issues", "SYNCED: 2 issues", "Total: 3 issues"} {
+		if !strings.Contains(second, want) {
+			t.Errorf("Expected second render to contain %q, got:\n%s", want, second)
+		}
+	}
+}
+
+func TestRenderStatusUsesCanonicalStateOrder(t *testing.T) {
+	tempDir := t.TempDir()
+	oldDir, _ := os.Getwd()
+	defer func() {
+		if err := os.Chdir(oldDir); err != nil {
+			t.Logf("Warning: Failed to change back to original directory: %v", err)
+		}
+	}()
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+
+	db, err := internal.InitDB()
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.Close()
+	if err := internal.InitSyncStateSchema(db); err != nil {
+		t.Fatalf("Failed to create sync state schema: %v", err)
+	}
+
+	// Alphabetical order would print CONFLICTED before LOCAL_ONLY and SYNCED
+	for i, state := range []internal.SyncState{internal.SyncStateConflicted, internal.SyncStateSynced, internal.SyncStateLocalOnly} {
+		githubID := int64(i + 1)
+		if _, err := db.Exec("INSERT INTO issues (github_id, number, title, state) VALUES (?, ?, 'Issue', 'open')", githubID, githubID); err != nil {
+			t.Fatalf("Failed to insert issue: %v", err)
+		}
+		if err := internal.CreateSyncState(db, githubID, state, &githubID); err != nil {
+			t.Fatalf("Failed to create sync state: %v", err)
+		}
+	}
+
+	output := &bytes.Buffer{}
+	cmd := &cobra.Command{}
+	cmd.SetOut(output)
+	if err := renderStatus(cmd, db, false); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	rendered := output.String()
+	localOnly := strings.Index(rendered, "LOCAL_ONLY")
+	synced := strings.Index(rendered, "SYNCED")
+	conflicted := strings.Index(rendered, "CONFLICTED")
+	if localOnly < 0 || !(localOnly < synced && synced < conflicted) {
+		t.Errorf("Expected LOCAL_ONLY, SYNCED, CONFLICTED in that order, got:\n%s", rendered)
+	}
+}
+
+func TestWatchStatusWithoutTerminalRendersOnce(t *testing.T) {
+	tempDir := t.TempDir()
+	oldDir, _ := os.Getwd()
+	defer func() {
+		if err := os.Chdir(oldDir); err != nil {
+			t.Logf("Warning: Failed to change back to original directory: %v", err)
+		}
+	}()
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+
+	db, err := internal.InitDB()
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	if err := internal.InitSyncStateSchema(db); err != nil {
+		t.Fatalf("Failed to create sync state schema: %v", err)
+	}
+	db.Close()
+
+	output := &bytes.Buffer{}
+	cmd := NewRootCommand()
+	cmd.SetOut(output)
+	cmd.SetErr(output)
+	cmd.SetArgs([]string{"status", "--watch"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("status --watch failed: %v", err)
+	}
+	if !strings.Contains(output.String(), "--watch needs a terminal") {
+		t.Errorf("Expected non-terminal notice, got: %s", output.String())
+	}
+	if strings.Count(output.String(), "Sync State Summary") != 1 {
+		t.Errorf("Expected a single render, got: %s", output.String())
+	}
+	if strings.Contains(output.String(), clearScreen) {
+		t.Error("Expected no screen clearing without a terminal")
+	}
+}
+
+func TestStatusAfterSyncReadsConfiguredDB(t *testing.T) {
+	setupConfiguredDBTest(t, "octo", "demo")
+	newMockGitHubServer(t, "octo", "demo", func() string {
+		return `[
+			{"id": 101, "number": 1, "title": "First", "state": "open", "updated_at": "2024-05-01T10:00:00Z"},
+			{"id": 102, "number": 2, "title": "Second", "state": "closed", "updated_at": "2024-05-02T10:00:00Z"}
+		]`
+	})
+
+	run := func(args ...string) (string, error) {
+		output := &bytes.Buffer{}
+		cmd := NewRootCommand()
+		cmd.SetOut(output)
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return output.String(), err
+	}
+
+	if _, err := run("sync"); err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	output, err := run("status", "--summary-only")
+	if err != nil {
+		t.Fatalf("status failed: %v", err)
+	}
+	if strings.TrimSpace(output) != "SYNCED=2 total=2" {
+		t.Errorf("Expected the synced issues to be counted, got %q", output)
+	}
+	if output, err := run("status"); err != nil || !strings.Contains(output, "Total: 2 issues") {
+		t.Errorf("Expected status to show the synced issues, got %v: %s", err, output)
+	}
+	if _, err := os.Stat("pivot.db"); !os.IsNotExist(err) {
+		t.Errorf("Expected status not to create ./pivot.db, got %v", err)
+	}
+}
+
+func TestIsTerminal(t *testing.T) {
+	if isTerminal(&bytes.Buffer{}) {
+		t.Error("Expected a buffer not to be a terminal")
+	}
+
+	file, err := os.CreateTemp(t.TempDir(), "out")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer file.Close()
+	if isTerminal(file) {
+		t.Error("Expected a regular file not to be a terminal")
+	}
+}
+
+func TestPrintTopReactionsScopesToProject(t *testing.T) {
+	db, err := internal.InitMultiProjectDBFromPath(t.TempDir() + "/pivot.db")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.Close()
+
+	for i, repo := range []string{"alpha", "beta"} {
+		projectID, err := internal.CreateProject(db, &internal.ProjectConfig{Owner: "org", Repo: repo})
+		if err != nil {
+			t.Fatalf("Failed to create project: %v", err)
+		}
+		issue := &internal.DBIssue{ID: i + 1, Number: 1, Title: repo + " request", State: "open"}
+		if err := internal.SaveIssue(db, projectID, issue); err != nil {
+			t.Fatalf("Failed to save issue: %v", err)
+		}
+		if err := internal.SaveReactions(db, projectID, 1, &internal.Reactions{TotalCount: 5 * (i + 1)}); err != nil {
+			t.Fatalf("Failed to save reactions: %v", err)
+		}
+	}
+
+	var all bytes.Buffer
+	if err := printTopReactions(&all, db, "", 5); err != nil {
+		t.Fatalf("printTopReactions failed: %v", err)
+	}
+	if strings.Index(all.String(), "org/beta#1") > strings.Index(all.String(), "org/alpha#1") {
+		t.Errorf("Expected beta (10 reactions) before alpha (5 reactions), got:\n%s", all.String())
+	}
+
+	var scoped bytes.Buffer
+	if err := printTopReactions(&scoped, db, "org/alpha", 5); err != nil {
+		t.Fatalf("printTopReactions failed: %v", err)
+	}
+	if strings.Contains(scoped.String(), "org/beta") || !strings.Contains(scoped.String(), "org/alpha#1") {
+		t.Errorf("Expected only org/alpha, got:\n%s", scoped.String())
+	}
+
+	if err := printTopReactions(&scoped, db, "invalid", 5); err == nil {
+		t.Error("Expected error for an invalid project")
+	}
+}
+
+func TestTopReactionsFlagValidation(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"status", "--top-reactions", "-1"}, "must not be negative"},
+		{[]string{"status", "--top-reactions", "3", "--watch"}, "cannot be used with --watch"},
+		{[]string{"sync", "--top-reactions", "-1"}, "must not be negative"},
+	}
+
+	for _, tt := range tests {
+		cmd := NewRootCommand()
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(tt.args)
+
+		err := cmd.Execute()
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%v: expected error containing %q, got: %v", tt.args, tt.want, err)
+		}
+	}
+}
+
+func TestStaleFlag(t *testing.T) {
+	setupDBCommandTest(t)
+
+	db, err := internal.InitMultiProjectDBFromPath("./pivot.db")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	projectID, err := internal.CreateProject(db, &internal.ProjectConfig{Owner: "org", Repo: "alpha"})
+	if err != nil {
+		t.Fatalf("Failed to get project: %v", err)
+	}
+	now := time.Now().UTC()
+	seeds := []internal.DBIssue{
+		{ID: 501, Number: 51, Title: "Neglected issue", State: "open", UpdatedAt: now.AddDate(0, 0, -45).Format(time.RFC3339)},
+		{ID: 502, Number: 52, Title: "Fresh issue", State: "open", UpdatedAt: now.AddDate(0, 0, -2).Format(time.RFC3339)},
+		{ID: 503, Number: 53, Title: "Old closed issue", State: "closed", UpdatedAt: now.AddDate(0, 0, -90).Format(time.RFC3339)},
+	}
+	for i := range seeds {
+		if err := internal.SaveIssue(db, projectID, &seeds[i]); err != nil {
+			t.Fatalf("Failed to save issue: %v", err)
+		}
+	}
+	if err := internal.InitSyncStateSchema(db); err != nil {
+		t.Fatalf("Failed to create sync state schema: %v", err)
+	}
+	db.Close()
+
+	run := func(args ...string) string {
+		t.Helper()
+		output := &bytes.Buffer{}
+		cmd := NewRootCommand()
+		cmd.SetOut(output)
+		cmd.SetErr(output)
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("%v failed: %v\n%s", args, err, output.String())
+		}
+		return output.String()
+	}
+
+	// The two issues from setupDBCommandTest have no updated_at and are never stale
+	listed := run("list", "--stale", "30d")
+	if !strings.Contains(listed, "Neglected issue") || !strings.Contains(listed, "Showing 1 issues") {
+		t.Errorf("Expected only open issues older than 30d, got:\n%s", listed)
+	}
+
+	status := run("status", "--stale", "4w", "--verbose")
+	if !strings.Contains(status, "1 open issues not updated in 4w") || !strings.Contains(status, "#51     Neglected issue") {
+		t.Errorf("Expected the stale count and list, got:\n%s", status)
+	}
+	if strings.Contains(status, "Fresh issue") {
+		t.Errorf("Expected fresh issue not to be listed, got:\n%s", status)
+	}
+
+	counted := run("status", "--stale", "1w")
+	if strings.Contains(counted, "Neglected issue") {
+		t.Errorf("Expected only the count without --verbose, got:\n%s", counted)
+	}
+}
+
+func TestStaleFlagValidation(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"status", "--stale", "soon"}, "invalid --stale"},
+		{[]string{"status", "--stale", "30d", "--watch"}, "cannot be used with --watch"},
+		{[]string{"list", "--stale", "0d"}, "age must be positive"},
+		{[]string{"list", "--stale", "30d", "--state", "closed"}, "cannot be used with --state closed"},
+	}
+
+	for _, tt := range tests {
+		cmd := NewRootCommand()
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(tt.args)
+
+		err := cmd.Execute()
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%v: expected error containing %q, got: %v", tt.args, tt.want, err)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bufio"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/rhino11/pivot/internal"
+	"github.com/spf13/cobra"
+)
+
+// reportSyncResult prints the sync summary, or only the counts to counts when it is set
+// (--summary-only). The returned error carries the exit code for conflicts and failures,
+// such as projects that failed or checksum mismatches. For a compare-only sync, the
+// differences are written to reportPath instead.
+func reportSyncResult(result *internal.SyncResult, reportPath string, counts io.Writer) error {
+	if reportPath != "" {
+		if err := internal.WriteCompareReport(reportPath, result); err != nil {
+			return err
+		}
+		fmt.Printf("📝 Wrote %d differing issues to %s\n", len(result.Totals().Differences), reportPath)
+		return nil
+	}
+
+	if counts != nil {
+		printSyncCounts(counts, result)
+		return syncOutcome(result)
+	}
+
+	internal.PrintSyncResult(os.Stdout, result)
+
+	if err := syncOutcome(result); err != nil {
+		return err
+	}
+
+	fmt.Println("✓ Sync complete.")
+	return nil
+}
+
+// reportSyncOutput reports a sync result as --dry-run and --output ask for: a dry-run
+// diff as text or JSON, or the result as JSON to jsonOut when it is set. Otherwise the
+// result is reported by reportSyncResult. The exit code is the same in every format.
+func reportSyncOutput(result *internal.SyncResult, dryRun bool, jsonOut io.Writer, reportPath string, counts io.Writer) error {
+	if !dryRun && jsonOut == nil {
+		return reportSyncResult(result, reportPath, counts)
+	}
+
+	var document interface{} = result
+	if dryRun {
+		diff := internal.NewSyncDiffDocument(result)
+		if jsonOut == nil {
+			internal.PrintSyncDiff(os.Stdout, diff)
+			return syncOutcome(result)
+		}
+		document = diff
+	}
+
+	data, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode sync result: %w", err)
+	}
+	fmt.Fprintln(jsonOut, string(data))
+	return syncOutcome(result)
+}
+
+// notifySyncResult fires the completion hooks configured under sync.notify. Missing
+// configuration only produces a warning, the sync itself has already succeeded.
+func notifySyncResult(result *internal.SyncResult) {
+	config, err := internal.LoadMultiProjectConfig()
+	if err != nil || config.Sync.Notify.IsEmpty() {
+		fmt.Println("⚠ --notify given but no sync.notify command or webhook is configured in config.yml")
+		return
+	}
+	internal.NotifySyncResult(os.Stdout, config.Sync.Notify, result)
+}
+
+// printTopReactions lists the most-reacted open issues in db, restricted to project
+// (owner/repo) when it is set
+func printTopReactions(w io.Writer, db *sql.DB, project string, limit int) error {
+	var projectID int64
+	if project != "" {
+		parts := strings.Split(project, "/")
+		if len(parts) != 2 {
+			return fmt.Errorf("project must be in format 'owner/repo', got: %s", project)
+		}
+		found, err := internal.FindProjectByOwnerRepo(db, parts[0], parts[1])
+		if err != nil {
+			return err
+		}
+		projectID = int64(found.ID)
+	}
+
+	issues, err := internal.TopReactedIssues(db, projectID, limit)
+	if err != nil {
+		return err
+	}
+	internal.PrintTopReactedIssues(w, issues)
+	return nil
+}
+
+// dumpRateLimits prints the rate limit budget for an ad-hoc --repo sync or the
+// configured projects, without syncing
+func dumpRateLimits(cmd *cobra.Command, repo, project, token string) error {
+	if repo != "" {
+		config, err := internal.NewAdHocConfig(repo, token)
+		if err != nil {
+			return err
+		}
+		return internal.PrintRateLimits(cmd.OutOrStdout(), config, "", "")
+	}
+
+	config, err := internal.LoadMultiProjectConfig()
+	if err != nil {
+		return fmt.Errorf("--dump-rate-limit requires a multi-project config or --repo: %w", err)
+	}
+	internal.RegisterSecret(token)
+	return internal.PrintRateLimits(cmd.OutOrStdout(), config, project, token)
+}
+
+// confirmRepositoryMove returns the prompt asking whether to record a moved repository
+// in config.yml, or nil when no one can answer it: stdin is not a terminal or the
+// progress output, and so the question, is hidden
+func confirmRepositoryMove(cmd *cobra.Command, outputHidden bool) func(*internal.ProjectConfig, string, string) bool {
+	if outputHidden || !isTerminal(cmd.InOrStdin()) {
+		return nil
+	}
+	return func(project *internal.ProjectConfig, newOwner, newRepo string) bool {
+		fmt.Fprintf(cmd.OutOrStdout(), "Update project configuration to %s/%s? (y/N): ", newOwner, newRepo)
+		response, _ := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
+		return response == "y" || response == "yes"
+	}
+}
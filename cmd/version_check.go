@@ -0,0 +1,23 @@
+package main
+
+import (
+	"github.com/rhino11/pivot/internal"
+	"github.com/spf13/cobra"
+)
+
+// printUpdateCheck reports the outcome of an update check. Failures only produce a
+// warning so the version command keeps working offline.
+func printUpdateCheck(cmd *cobra.Command, info *internal.UpdateInfo, err error) {
+	cmd.Println()
+	switch {
+	case err != nil:
+		cmd.Printf("⚠ Could not check for updates: %v\n", err)
+	case info.DevBuild:
+		cmd.Printf("ℹ Development build; latest release is %s\n", info.Latest)
+	case info.Available:
+		cmd.Printf("🎉 A new version is available: %s (current: %s)\n", info.Latest, info.Current)
+		cmd.Println("   Download it from https://github.com/rhino11/pivot/releases/latest")
+	default:
+		cmd.Printf("✓ pivot is up to date (%s)\n", info.Current)
+	}
+}
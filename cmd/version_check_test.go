@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rhino11/pivot/internal"
+	"github.com/spf13/cobra"
+)
+
+// TestPrintUpdateCheck tests the update notice for each check outcome
+func TestPrintUpdateCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		info     *internal.UpdateInfo
+		err      error
+		expected string
+	}{
+		{"UpToDate", &internal.UpdateInfo{Current: "v1.4.0", Latest: "v1.4.0"}, nil, "up to date (v1.4.0)"},
+		{"Outdated", &internal.UpdateInfo{Current: "v1.4.0", Latest: "v1.5.0", Available: true}, nil, "new version is available: v1.5.0"},
+		{"DevBuild", &internal.UpdateInfo{Current: "dev", Latest: "v1.5.0", DevBuild: true}, nil, "Development build"},
+		{"Offline", nil, errors.New("failed to reach GitHub: no route to host"), "Could not check for updates"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := &bytes.Buffer{}
+			cmd := &cobra.Command{}
+			cmd.SetOut(output)
+
+			printUpdateCheck(cmd, tt.info, tt.err)
+			if !strings.Contains(output.String(), tt.expected) {
+				t.Errorf("Expected output to contain %q, got %q", tt.expected, output.String())
+			}
+		})
+	}
+}
+
+// TestVersionCommandCheckFlag tests that version accepts --check
+func TestVersionCommandCheckFlag(t *testing.T) {
+	cmd := NewRootCommand()
+	versionCmd, _, err := cmd.Find([]string{"version"})
+	if err != nil {
+		t.Fatalf("Failed to find version command: %v", err)
+	}
+	if versionCmd.Flags().Lookup("check") == nil {
+		t.Error("Expected version command to have --check flag")
+	}
+}
@@ -0,0 +1,65 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NewAdHocConfig builds an in-memory single-project configuration for syncing a
+// repository without a config file. The default database is used.
+func NewAdHocConfig(repository, token string) (*MultiProjectConfig, error) {
+	parts := strings.Split(repository, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("repository must be in format 'owner/repo', got: %s", repository)
+	}
+	if token == "" {
+		return nil, fmt.Errorf("a GitHub token is required for ad-hoc sync (use --token)")
+	}
+
+	RegisterSecret(token)
+
+	config := &MultiProjectConfig{
+		Global: GlobalConfig{Token: token},
+		Projects: []ProjectConfig{
+			{Owner: parts[0], Repo: parts[1]},
+		},
+	}
+	setDefaults(config)
+
+	return config, nil
+}
+
+// SyncAdHoc syncs the single project of an ephemeral configuration, creating the
+// database schema if needed. Nothing is written to config.yml.
+func SyncAdHoc(config *MultiProjectConfig, opts SyncOptions) (*SyncResult, error) {
+	if len(config.Projects) != 1 {
+		return nil, fmt.Errorf("ad-hoc sync requires exactly one project, got %d", len(config.Projects))
+	}
+
+	db, err := InitMultiProjectDBWithSettings(config.Global.Database, config.Database)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	if opts.Audit == nil {
+		if opts.Audit, err = NewAuditLog(config.Audit); err != nil {
+			return nil, err
+		}
+	}
+
+	checkRateLimitBudget(db, &config.Global, config.Projects, opts)
+
+	// The project is not in config.yml, so a moved repository is only reported
+	opts.ConfirmRepositoryMove = nil
+
+	project := config.Projects[0]
+	fmt.Printf("🔄 Syncing %s/%s...\n", project.Owner, project.Repo)
+	projectResult, err := syncProjectWithOptions(db, &config.Global, &project, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sync %s/%s: %w", project.Owner, project.Repo, err)
+	}
+	fmt.Printf("✓ Synced %s/%s\n", project.Owner, project.Repo)
+
+	return &SyncResult{Projects: []ProjectSyncResult{*projectResult}}, nil
+}
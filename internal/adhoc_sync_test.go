@@ -0,0 +1,99 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewAdHocConfig(t *testing.T) {
+	config, err := NewAdHocConfig("octo/widgets", "test-token")
+	if err != nil {
+		t.Fatalf("NewAdHocConfig failed: %v", err)
+	}
+	if len(config.Projects) != 1 || config.Projects[0].Owner != "octo" || config.Projects[0].Repo != "widgets" {
+		t.Errorf("Expected single project octo/widgets, got %+v", config.Projects)
+	}
+	if config.Global.Token != "test-token" {
+		t.Errorf("Expected token test-token, got %s", config.Global.Token)
+	}
+	if config.Global.Database != "~/.pivot/pivot.db" {
+		t.Errorf("Expected default database, got %s", config.Global.Database)
+	}
+
+	if _, err := NewAdHocConfig("octo", "test-token"); err == nil {
+		t.Error("Expected error for repository without owner")
+	}
+	if _, err := NewAdHocConfig("octo/widgets", ""); err == nil {
+		t.Error("Expected error for missing token")
+	}
+}
+
+func TestSyncAdHoc_WithoutConfigFile(t *testing.T) {
+	tempDir := t.TempDir()
+	oldDir, _ := os.Getwd()
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.Chdir(oldDir); err != nil {
+			t.Logf("Warning: Failed to change back to original directory: %v", err)
+		}
+	}()
+
+	newMockGitHubServer(t, "octo", "widgets", `[
+		{"id": 201, "number": 1, "title": "First", "state": "open"},
+		{"id": 202, "number": 2, "title": "Second", "state": "closed"}
+	]`, nil)
+
+	config, err := NewAdHocConfig("octo/widgets", "test-token")
+	if err != nil {
+		t.Fatalf("NewAdHocConfig failed: %v", err)
+	}
+	// Keep the test away from the real default database
+	config.Global.Database = filepath.Join(tempDir, "data", "pivot.db")
+
+	result, err := SyncAdHoc(config, SyncOptions{})
+	if err != nil {
+		t.Fatalf("SyncAdHoc failed: %v", err)
+	}
+	if len(result.Projects) != 1 || result.Projects[0].Created != 2 {
+		t.Errorf("Expected result with 2 created issues, got %+v", result.Projects)
+	}
+
+	if _, err := os.Stat("config.yml"); !os.IsNotExist(err) {
+		t.Error("Expected no config.yml to be written by ad-hoc sync")
+	}
+
+	db, err := InitMultiProjectDBFromPath(config.Global.Database)
+	if err != nil {
+		t.Fatalf("Failed to reopen database: %v", err)
+	}
+	defer db.Close()
+
+	project, err := FindProjectByOwnerRepo(db, "octo", "widgets")
+	if err != nil {
+		t.Fatalf("Expected project to be recorded: %v", err)
+	}
+	issues, err := GetIssuesForProject(db, int64(project.ID))
+	if err != nil {
+		t.Fatalf("Failed to load issues: %v", err)
+	}
+	if len(issues) != 2 {
+		t.Errorf("Expected 2 persisted issues, got %d", len(issues))
+	}
+}
+
+func TestSyncAdHoc_FailurePropagates(t *testing.T) {
+	newMockGitHubServer(t, "octo", "widgets", `[]`, nil)
+
+	config, err := NewAdHocConfig("octo/missing", "test-token")
+	if err != nil {
+		t.Fatalf("NewAdHocConfig failed: %v", err)
+	}
+	config.Global.Database = filepath.Join(t.TempDir(), "pivot.db")
+
+	if _, err := SyncAdHoc(config, SyncOptions{}); err == nil {
+		t.Error("Expected error when the repository cannot be synced")
+	}
+}
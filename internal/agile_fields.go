@@ -0,0 +1,104 @@
+package internal
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// agileColumns are the issue columns holding agile planning fields
+var agileColumns = []struct{ name, sqlType string }{
+	{"milestone", "TEXT"},
+	{"story_points", "INTEGER"},
+	{"estimated_hours", "INTEGER"},
+	{"epic", "TEXT"},
+	{"acceptance_criteria", "TEXT"},
+	{"dependencies", "TEXT"},
+}
+
+// AddAgileColumnsToIssues adds the agile planning columns to the issues table
+func AddAgileColumnsToIssues(db *sql.DB) error {
+	for _, column := range agileColumns {
+		exists, err := hasColumn(db, "issues", column.name)
+		if err != nil {
+			return fmt.Errorf("failed to check for %s column: %w", column.name, err)
+		}
+		if exists {
+			continue
+		}
+		if _, err := db.Exec(fmt.Sprintf("ALTER TABLE issues ADD COLUMN %s %s", column.name, column.sqlType)); err != nil {
+			return fmt.Errorf("failed to add %s column: %w", column.name, err)
+		}
+	}
+	return nil
+}
+
+// agileMetadataLine matches body lines such as "Story Points: 5", "**Epic:** Checkout"
+// or "Depends on: #12, #15". The field name must be a whole word followed by a colon,
+// so prose such as "Epic fail on login" is not metadata.
+var agileMetadataLine = regexp.MustCompile(`(?i)^\s*[-*]?\s*\**\s*(milestone|story points|estimated hours|epic|acceptance criteria|dependencies|depends on|blocked by)\b\s*\**\s*:\s*\**\s*(.*?)\s*$`)
+
+// criteriaContinuationLine matches the indented or bullet lines that continue an
+// "Acceptance Criteria:" line, e.g. "- Card payments succeed" or "  1. Refunds work"
+var criteriaContinuationLine = regexp.MustCompile(`^(\s+\S|\s*([-*+]|\d+[.)])\s)`)
+
+// ApplyAgileMetadata fills the agile fields of an issue from "Field: value"
+// metadata lines in its body. Acceptance criteria also take the indented or bullet
+// lines that follow, up to the next blank line or heading. Fields without a
+// metadata line are left unchanged.
+func ApplyAgileMetadata(issue *DBIssue) {
+	lines := strings.Split(issue.Body, "\n")
+	for i := 0; i < len(lines); i++ {
+		match := agileMetadataLine.FindStringSubmatch(lines[i])
+		if match == nil {
+			continue
+		}
+		value := strings.TrimSpace(match[2])
+		field := strings.ToLower(match[1])
+		if field == "acceptance criteria" {
+			criteria := []string{}
+			if value != "" {
+				criteria = append(criteria, value)
+			}
+			for i+1 < len(lines) && isCriteriaContinuation(lines[i+1]) {
+				i++
+				criteria = append(criteria, strings.TrimSpace(lines[i]))
+			}
+			value = strings.Join(criteria, "\n")
+		}
+		if value == "" {
+			continue
+		}
+
+		switch field {
+		case "milestone":
+			issue.Milestone = value
+		case "story points":
+			if points, err := strconv.Atoi(value); err == nil {
+				issue.StoryPoints = points
+			}
+		case "estimated hours":
+			if hours, err := strconv.Atoi(value); err == nil {
+				issue.EstimatedHours = hours
+			}
+		case "epic":
+			issue.Epic = value
+		case "acceptance criteria":
+			issue.AcceptanceCriteria = value
+		case "dependencies", "depends on", "blocked by":
+			issue.Dependencies = FormatDependencies(ParseDependencies(value))
+		}
+	}
+}
+
+// isCriteriaContinuation reports whether a line continues the acceptance criteria:
+// an indented or bullet line that is not blank, a heading or another metadata line
+func isCriteriaContinuation(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" || strings.HasPrefix(trimmed, "#") || agileMetadataLine.MatchString(line) {
+		return false
+	}
+	return criteriaContinuationLine.MatchString(line)
+}
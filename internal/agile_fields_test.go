@@ -0,0 +1,176 @@
+package internal
+
+import (
+	"testing"
+)
+
+func TestSaveIssue_PersistsAgileFields(t *testing.T) {
+	db := newTestMultiProjectDB(t)
+
+	projectID, err := CreateProject(db, &ProjectConfig{Owner: "org", Repo: "repo"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	issue := &DBIssue{
+		ID:                 1,
+		Number:             1,
+		Title:              "Checkout flow",
+		State:              "open",
+		CreatedAt:          "2026-01-01T00:00:00Z",
+		UpdatedAt:          "2026-01-02T00:00:00Z",
+		Milestone:          "v1.0",
+		StoryPoints:        8,
+		EstimatedHours:     16,
+		Epic:               "Payments",
+		AcceptanceCriteria: "User can pay with a card",
+	}
+	if err := SaveIssue(db, projectID, issue); err != nil {
+		t.Fatalf("Failed to save issue: %v", err)
+	}
+
+	issues, err := GetIssuesForProject(db, projectID)
+	if err != nil {
+		t.Fatalf("Failed to get issues: %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d", len(issues))
+	}
+
+	got := issues[0]
+	if got.Milestone != "v1.0" {
+		t.Errorf("Expected milestone v1.0, got %q", got.Milestone)
+	}
+	if got.StoryPoints != 8 {
+		t.Errorf("Expected 8 story points, got %d", got.StoryPoints)
+	}
+	if got.EstimatedHours != 16 {
+		t.Errorf("Expected 16 estimated hours, got %d", got.EstimatedHours)
+	}
+	if got.Epic != "Payments" {
+		t.Errorf("Expected epic Payments, got %q", got.Epic)
+	}
+	if got.AcceptanceCriteria != "User can pay with a card" {
+		t.Errorf("Expected acceptance criteria to persist, got %q", got.AcceptanceCriteria)
+	}
+}
+
+func TestGetIssuesForProject_AgileFieldsDefaultToZero(t *testing.T) {
+	db := newTestMultiProjectDB(t)
+
+	projectID, err := CreateProject(db, &ProjectConfig{Owner: "org", Repo: "repo"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO issues (github_id, project_id, number, title, body, state, created_at, updated_at)
+		VALUES (1, ?, 1, 'Legacy', '', 'open', '2026-01-01T00:00:00Z', '2026-01-01T00:00:00Z')`, projectID); err != nil {
+		t.Fatalf("Failed to insert issue: %v", err)
+	}
+
+	issues, err := GetIssuesForProject(db, projectID)
+	if err != nil {
+		t.Fatalf("Failed to get issues: %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d", len(issues))
+	}
+	if issues[0].StoryPoints != 0 || issues[0].Epic != "" || issues[0].Milestone != "" {
+		t.Errorf("Expected empty agile fields, got %+v", issues[0])
+	}
+}
+
+func TestAddAgileColumnsToIssues_Idempotent(t *testing.T) {
+	db := newTestMultiProjectDB(t)
+
+	if err := AddAgileColumnsToIssues(db); err != nil {
+		t.Fatalf("Expected second migration to succeed, got %v", err)
+	}
+
+	for _, column := range []string{"milestone", "story_points", "estimated_hours", "epic", "acceptance_criteria"} {
+		exists, err := hasColumn(db, "issues", column)
+		if err != nil {
+			t.Fatalf("Failed to check column %s: %v", column, err)
+		}
+		if !exists {
+			t.Errorf("Expected column %s to exist", column)
+		}
+	}
+}
+
+func TestApplyAgileMetadata(t *testing.T) {
+	issue := &DBIssue{Body: "Implement checkout.\n\n**Story Points:** 5\nEstimated Hours: 12\n- Epic: Payments\nAcceptance Criteria: Card payments succeed\nMilestone: v2.0\nNotes: unrelated"}
+
+	ApplyAgileMetadata(issue)
+
+	if issue.StoryPoints != 5 {
+		t.Errorf("Expected 5 story points, got %d", issue.StoryPoints)
+	}
+	if issue.EstimatedHours != 12 {
+		t.Errorf("Expected 12 estimated hours, got %d", issue.EstimatedHours)
+	}
+	if issue.Epic != "Payments" {
+		t.Errorf("Expected epic Payments, got %q", issue.Epic)
+	}
+	if issue.AcceptanceCriteria != "Card payments succeed" {
+		t.Errorf("Expected acceptance criteria, got %q", issue.AcceptanceCriteria)
+	}
+	if issue.Milestone != "v2.0" {
+		t.Errorf("Expected milestone v2.0, got %q", issue.Milestone)
+	}
+}
+
+func TestApplyAgileMetadata_IgnoresProse(t *testing.T) {
+	issue := &DBIssue{Body: "Epic fail on login\nMilestones slipped again: see the retro\nEpics: Payments\n**Epic**: Checkout", Milestone: "v1.0"}
+
+	ApplyAgileMetadata(issue)
+
+	if issue.Milestone != "v1.0" {
+		t.Errorf("Expected the milestone to stay v1.0, got %q", issue.Milestone)
+	}
+	if issue.Epic != "Checkout" {
+		t.Errorf("Expected only the metadata line to set the epic, got %q", issue.Epic)
+	}
+}
+
+func TestApplyAgileMetadata_IgnoresInvalidNumbers(t *testing.T) {
+	issue := &DBIssue{Body: "Story Points: lots", StoryPoints: 3}
+
+	ApplyAgileMetadata(issue)
+
+	if issue.StoryPoints != 3 {
+		t.Errorf("Expected story points to stay 3, got %d", issue.StoryPoints)
+	}
+}
+
+func TestConvertIssueToDBIssue_ParsesAgileMetadata(t *testing.T) {
+	issue := &Issue{ID: 1, Number: 1, Title: "Story", Body: "Story Points: 3\nEpic: Onboarding"}
+
+	dbIssue := ConvertIssueToDBIssue(issue)
+
+	if dbIssue.StoryPoints != 3 {
+		t.Errorf("Expected 3 story points, got %d", dbIssue.StoryPoints)
+	}
+	if dbIssue.Epic != "Onboarding" {
+		t.Errorf("Expected epic Onboarding, got %q", dbIssue.Epic)
+	}
+}
+
+func TestApplyAgileMetadata_MultiLineCriteria(t *testing.T) {
+	issue := &DBIssue{Body: "Acceptance Criteria:\n- Card payments succeed\n- Refunds are\n  issued within a day\n1. Receipts are emailed\n- Epic: Payments\n\n- Unrelated bullet"}
+
+	ApplyAgileMetadata(issue)
+
+	expected := "- Card payments succeed\n- Refunds are\nissued within a day\n1. Receipts are emailed"
+	if issue.AcceptanceCriteria != expected {
+		t.Errorf("Expected criteria %q, got %q", expected, issue.AcceptanceCriteria)
+	}
+	if issue.Epic != "Payments" {
+		t.Errorf("Expected the metadata line after the criteria to set the epic, got %q", issue.Epic)
+	}
+
+	issue = &DBIssue{Body: "Acceptance Criteria: Checkout works\n  - on mobile\n## Notes\n  - not criteria"}
+	ApplyAgileMetadata(issue)
+	if issue.AcceptanceCriteria != "Checkout works\n- on mobile" {
+		t.Errorf("Expected criteria to stop at the heading, got %q", issue.AcceptanceCriteria)
+	}
+}
@@ -0,0 +1,94 @@
+package internal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ExportSettings contains configuration shared by the export commands
+type ExportSettings struct {
+	RedactPatterns []string `yaml:"redact_patterns,omitempty"` // Regular expressions redacted by --anonymize
+}
+
+var (
+	urlPattern     = regexp.MustCompile(`https?://[^\s)>\]"']+`)
+	mentionPattern = regexp.MustCompile(`@([A-Za-z0-9](?:[A-Za-z0-9-]*[A-Za-z0-9])?)`)
+)
+
+// Anonymizer strips personally identifying details from issues before they are shared
+type Anonymizer struct {
+	redactions []*regexp.Regexp
+}
+
+// NewAnonymizer creates an anonymizer that additionally redacts text matching the given patterns
+func NewAnonymizer(patterns []string) (*Anonymizer, error) {
+	anonymizer := &Anonymizer{}
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redact pattern '%s': %w", pattern, err)
+		}
+		anonymizer.redactions = append(anonymizer.redactions, re)
+	}
+	return anonymizer, nil
+}
+
+// Pseudonym returns a stable pseudonym for a GitHub login. The same login always
+// maps to the same pseudonym, regardless of case.
+func (a *Anonymizer) Pseudonym(login string) string {
+	login = strings.TrimSpace(login)
+	if login == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(strings.ToLower(login)))
+	return "user-" + hex.EncodeToString(sum[:4])
+}
+
+// AnonymizeText removes URLs, pseudonymizes @mentions and redacts configured patterns
+func (a *Anonymizer) AnonymizeText(text string) string {
+	text = urlPattern.ReplaceAllString(text, "[link removed]")
+	text = mentionPattern.ReplaceAllStringFunc(text, func(mention string) string {
+		return "@" + a.Pseudonym(strings.TrimPrefix(mention, "@"))
+	})
+	for _, re := range a.redactions {
+		text = re.ReplaceAllString(text, "[redacted]")
+	}
+	return text
+}
+
+// AnonymizeIssue returns a copy of an issue with assignees pseudonymized and its title,
+// body, epic and acceptance criteria anonymized
+func (a *Anonymizer) AnonymizeIssue(issue DBIssue) DBIssue {
+	var assignees []string
+	for _, login := range splitCommaList(issue.Assignees) {
+		assignees = append(assignees, a.Pseudonym(login))
+	}
+	issue.Assignees = strings.Join(assignees, ",")
+	issue.Title = a.AnonymizeText(issue.Title)
+	issue.Body = a.AnonymizeText(issue.Body)
+	issue.Epic = a.AnonymizeText(issue.Epic)
+	issue.AcceptanceCriteria = a.AnonymizeText(issue.AcceptanceCriteria)
+	return issue
+}
+
+// AnonymizeIssues anonymizes every issue in a slice, leaving the originals untouched
+func (a *Anonymizer) AnonymizeIssues(issues []DBIssue) []DBIssue {
+	anonymized := make([]DBIssue, len(issues))
+	for i, issue := range issues {
+		anonymized[i] = a.AnonymizeIssue(issue)
+	}
+	return anonymized
+}
+
+// ConfiguredAnonymizer builds an anonymizer from the export redact patterns in the
+// configuration (when one can be loaded) plus any extra patterns
+func ConfiguredAnonymizer(extra []string) (*Anonymizer, error) {
+	var patterns []string
+	if config, err := LoadMultiProjectConfig(); err == nil {
+		patterns = append(patterns, config.Export.RedactPatterns...)
+	}
+	return NewAnonymizer(append(patterns, extra...))
+}
@@ -0,0 +1,99 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAnonymizer_PseudonymIsStable(t *testing.T) {
+	first, err := NewAnonymizer(nil)
+	if err != nil {
+		t.Fatalf("NewAnonymizer failed: %v", err)
+	}
+	second, _ := NewAnonymizer(nil)
+
+	alice := first.Pseudonym("alice")
+	if alice == "" || alice == "alice" {
+		t.Fatalf("Expected a pseudonym for alice, got %q", alice)
+	}
+	if got := first.Pseudonym("alice"); got != alice {
+		t.Errorf("Expected same pseudonym on repeat, got %s and %s", alice, got)
+	}
+	if got := second.Pseudonym("Alice"); got != alice {
+		t.Errorf("Expected same pseudonym across anonymizers and case, got %s and %s", alice, got)
+	}
+	if bob := first.Pseudonym("bob"); bob == alice {
+		t.Errorf("Expected different logins to get different pseudonyms, both got %s", bob)
+	}
+	if got := first.Pseudonym(""); got != "" {
+		t.Errorf("Expected empty pseudonym for empty login, got %q", got)
+	}
+}
+
+func TestAnonymizer_AnonymizeIssues(t *testing.T) {
+	anonymizer, err := NewAnonymizer([]string{`ACME-[0-9]+`})
+	if err != nil {
+		t.Fatalf("NewAnonymizer failed: %v", err)
+	}
+
+	issues := []DBIssue{
+		{
+			Number:             1,
+			Title:              "Fix ACME-42 login",
+			Body:               "Reported by @alice, see https://github.com/org/private/issues/1 and (http://wiki.internal/page).",
+			Assignees:          "alice, bob",
+			Epic:               "ACME-7 rollout",
+			AcceptanceCriteria: "Signed off by @alice, steps in https://wiki.internal/login",
+		},
+		{
+			Number:    2,
+			Title:     "Follow-up",
+			Body:      "Ping @bob",
+			Assignees: "alice",
+		},
+	}
+
+	anonymized := anonymizer.AnonymizeIssues(issues)
+
+	alice := anonymizer.Pseudonym("alice")
+	bob := anonymizer.Pseudonym("bob")
+
+	if anonymized[0].Assignees != alice+","+bob {
+		t.Errorf("Expected assignees %s,%s, got %s", alice, bob, anonymized[0].Assignees)
+	}
+	if anonymized[1].Assignees != alice {
+		t.Errorf("Expected alice to get the same pseudonym in every issue, got %s", anonymized[1].Assignees)
+	}
+
+	body := anonymized[0].Body
+	for _, leaked := range []string{"alice", "https://", "http://", "github.com", "wiki.internal"} {
+		if strings.Contains(body, leaked) {
+			t.Errorf("Expected %q to be removed from body, got: %s", leaked, body)
+		}
+	}
+	if !strings.Contains(body, "@"+alice) || !strings.Contains(body, "[link removed]") {
+		t.Errorf("Expected pseudonymized mention and removed links, got: %s", body)
+	}
+	if anonymized[1].Body != "Ping @"+bob {
+		t.Errorf("Expected 'Ping @%s', got %s", bob, anonymized[1].Body)
+	}
+	if anonymized[0].Title != "Fix [redacted] login" {
+		t.Errorf("Expected redacted title, got %s", anonymized[0].Title)
+	}
+	if anonymized[0].Epic != "[redacted] rollout" {
+		t.Errorf("Expected redacted epic, got %s", anonymized[0].Epic)
+	}
+	if expected := "Signed off by @" + alice + ", steps in [link removed]"; anonymized[0].AcceptanceCriteria != expected {
+		t.Errorf("Expected acceptance criteria %q, got %q", expected, anonymized[0].AcceptanceCriteria)
+	}
+
+	if issues[0].Assignees != "alice, bob" {
+		t.Errorf("Expected original issues to be untouched, got %s", issues[0].Assignees)
+	}
+}
+
+func TestNewAnonymizer_InvalidPattern(t *testing.T) {
+	if _, err := NewAnonymizer([]string{"("}); err == nil {
+		t.Error("Expected error for invalid redact pattern")
+	}
+}
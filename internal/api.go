@@ -0,0 +1,179 @@
+package internal
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// IssueSummary aggregates local issue counts
+type IssueSummary struct {
+	Total     int            `json:"total"`
+	ByState   map[string]int `json:"by_state"`
+	ByProject map[string]int `json:"by_project"`
+}
+
+// GetIssueSummary counts local issues by state and by project
+func GetIssueSummary(db *sql.DB) (*IssueSummary, error) {
+	summary := &IssueSummary{
+		ByState:   make(map[string]int),
+		ByProject: make(map[string]int),
+	}
+
+	rows, err := db.Query(`
+		SELECT p.owner || '/' || p.repo, COALESCE(i.state, ''), COUNT(*)
+		FROM issues i
+		JOIN projects p ON p.id = i.project_id
+		GROUP BY p.owner, p.repo, i.state`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize issues: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var project, state string
+		var count int
+		if err := rows.Scan(&project, &state, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan summary: %w", err)
+		}
+		summary.Total += count
+		summary.ByState[state] += count
+		summary.ByProject[project] += count
+	}
+
+	return summary, rows.Err()
+}
+
+// NewAPIHandler returns the read-only JSON API over the local database:
+//
+//	GET /issues           list issues (query: project, state, label, sort, desc, limit, offset)
+//	GET /issues/{number}  a single issue (query: project, required when numbers collide)
+//	GET /summary          issue counts by state and project
+func NewAPIHandler(db *sql.DB) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /issues", func(w http.ResponseWriter, r *http.Request) {
+		opts, err := listOptionsFromQuery(db, r)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, err)
+			return
+		}
+		issues, err := ListIssues(db, opts)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, err)
+			return
+		}
+		if issues == nil {
+			issues = []DBIssue{}
+		}
+		writeJSON(w, http.StatusOK, issues)
+	})
+
+	mux.HandleFunc("GET /issues/{number}", func(w http.ResponseWriter, r *http.Request) {
+		number, err := strconv.Atoi(r.PathValue("number"))
+		if err != nil || number <= 0 {
+			writeAPIError(w, http.StatusBadRequest, fmt.Errorf("invalid issue number '%s'", r.PathValue("number")))
+			return
+		}
+		opts, err := listOptionsFromQuery(db, r)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, err)
+			return
+		}
+		opts.Number = number
+
+		issues, err := ListIssues(db, opts)
+		if err != nil {
+			writeAPIError(w, http.StatusInternalServerError, err)
+			return
+		}
+		switch len(issues) {
+		case 0:
+			writeAPIError(w, http.StatusNotFound, fmt.Errorf("issue #%d not found", number))
+		case 1:
+			writeJSON(w, http.StatusOK, issues[0])
+		default:
+			writeAPIError(w, http.StatusConflict, fmt.Errorf("issue #%d exists in several projects; add ?project=owner/repo", number))
+		}
+	})
+
+	mux.HandleFunc("GET /summary", func(w http.ResponseWriter, r *http.Request) {
+		summary, err := GetIssueSummary(db)
+		if err != nil {
+			writeAPIError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, summary)
+	})
+
+	return mux
+}
+
+// listOptionsFromQuery translates query parameters into list options
+func listOptionsFromQuery(db *sql.DB, r *http.Request) (ListOptions, error) {
+	query := r.URL.Query()
+	opts := ListOptions{
+		State: query.Get("state"),
+		Label: query.Get("label"),
+		Sort:  query.Get("sort"),
+		Desc:  query.Get("desc") == "true",
+	}
+
+	for name, target := range map[string]*int{"limit": &opts.Limit, "offset": &opts.Offset} {
+		if value := query.Get(name); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				return opts, fmt.Errorf("invalid %s '%s'", name, value)
+			}
+			*target = parsed
+		}
+	}
+
+	if project := query.Get("project"); project != "" {
+		parts := strings.Split(project, "/")
+		if len(parts) != 2 {
+			return opts, fmt.Errorf("project must be in format 'owner/repo', got: %s", project)
+		}
+		found, err := FindProjectByOwnerRepo(db, parts[0], parts[1])
+		if err != nil {
+			return opts, err
+		}
+		opts.ProjectID = int64(found.ID)
+	}
+
+	return opts, nil
+}
+
+// writeJSON writes a JSON response body
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value) // #nosec G104 - Client disconnects are not actionable
+}
+
+// writeAPIError writes a JSON error response
+func writeAPIError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// ServeAPI serves the read-only JSON API for the configured database until the server stops
+func ServeAPI(addr string) error {
+	db, err := OpenConfiguredDB()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           NewAPIHandler(db),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	fmt.Printf("🌐 Serving read-only API on http://%s\n", addr)
+	return server.ListenAndServe()
+}
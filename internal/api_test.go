@@ -0,0 +1,173 @@
+package internal
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newTestAPIServer seeds a database with two projects and serves the API over it
+func newTestAPIServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	db := newTestMultiProjectDB(t)
+
+	seed := map[string][]DBIssue{
+		"alpha": {
+			{ID: 1, Number: 1, Title: "Login bug", State: "open", Labels: "bug,ui"},
+			{ID: 2, Number: 2, Title: "Docs", State: "closed", Labels: "docs"},
+			{ID: 3, Number: 3, Title: "Crash", State: "open", Labels: "bug"},
+		},
+		"beta": {
+			{ID: 4, Number: 1, Title: "Beta setup", State: "open"},
+		},
+	}
+	for _, repo := range []string{"alpha", "beta"} {
+		projectID, err := CreateProject(db, &ProjectConfig{Owner: "org", Repo: repo})
+		if err != nil {
+			t.Fatalf("Failed to create project: %v", err)
+		}
+		for i := range seed[repo] {
+			if err := SaveIssue(db, projectID, &seed[repo][i]); err != nil {
+				t.Fatalf("Failed to save issue: %v", err)
+			}
+		}
+	}
+
+	server := httptest.NewServer(NewAPIHandler(db))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// getJSON fetches a URL and decodes the JSON response into target
+func getJSON(t *testing.T, url string, target interface{}) int {
+	t.Helper()
+	resp, err := http.Get(url) // #nosec G107 - test server URL
+	if err != nil {
+		t.Fatalf("GET %s failed: %v", url, err)
+	}
+	defer resp.Body.Close()
+
+	if contentType := resp.Header.Get("Content-Type"); contentType != "application/json" {
+		t.Errorf("Expected application/json, got %s", contentType)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
+		t.Fatalf("Failed to decode response from %s: %v", url, err)
+	}
+	return resp.StatusCode
+}
+
+func TestAPI_ListIssues(t *testing.T) {
+	server := newTestAPIServer(t)
+
+	var all []DBIssue
+	if status := getJSON(t, server.URL+"/issues", &all); status != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", status)
+	}
+	if len(all) != 4 {
+		t.Errorf("Expected 4 issues, got %d", len(all))
+	}
+
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{"?state=open&project=org/alpha", []string{"Login bug", "Crash"}},
+		{"?label=bug", []string{"Login bug", "Crash"}},
+		{"?project=org/beta", []string{"Beta setup"}},
+		{"?project=org/alpha&sort=title&limit=2", []string{"Crash", "Docs"}},
+		{"?project=org/alpha&desc=true&offset=2", []string{"Login bug"}},
+	}
+	for _, tt := range tests {
+		var issues []DBIssue
+		if status := getJSON(t, server.URL+"/issues"+tt.query, &issues); status != http.StatusOK {
+			t.Errorf("%s: expected 200, got %d", tt.query, status)
+			continue
+		}
+		var titles []string
+		for _, issue := range issues {
+			titles = append(titles, issue.Title)
+		}
+		if len(titles) != len(tt.expected) {
+			t.Errorf("%s: expected %v, got %v", tt.query, tt.expected, titles)
+			continue
+		}
+		for i := range titles {
+			if titles[i] != tt.expected[i] {
+				t.Errorf("%s: expected %v, got %v", tt.query, tt.expected, titles)
+				break
+			}
+		}
+	}
+
+	var empty []DBIssue
+	getJSON(t, server.URL+"/issues?state=merged", &empty)
+	if empty == nil || len(empty) != 0 {
+		t.Errorf("Expected an empty JSON array, got %v", empty)
+	}
+
+	var apiErr map[string]string
+	if status := getJSON(t, server.URL+"/issues?sort=bogus", &apiErr); status != http.StatusBadRequest {
+		t.Errorf("Expected 400 for invalid sort, got %d", status)
+	}
+	if apiErr["error"] == "" {
+		t.Error("Expected error message in response")
+	}
+}
+
+func TestAPI_GetIssue(t *testing.T) {
+	server := newTestAPIServer(t)
+
+	var issue DBIssue
+	if status := getJSON(t, server.URL+"/issues/3", &issue); status != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", status)
+	}
+	if issue.Title != "Crash" || issue.Labels != "bug" {
+		t.Errorf("Unexpected issue: %+v", issue)
+	}
+
+	var apiErr map[string]string
+	if status := getJSON(t, server.URL+"/issues/1", &apiErr); status != http.StatusConflict {
+		t.Errorf("Expected 409 for ambiguous number, got %d", status)
+	}
+	if status := getJSON(t, server.URL+"/issues/1?project=org/beta", &issue); status != http.StatusOK || issue.Title != "Beta setup" {
+		t.Errorf("Expected Beta setup with project filter, got %d %+v", status, issue)
+	}
+	if status := getJSON(t, server.URL+"/issues/99", &apiErr); status != http.StatusNotFound {
+		t.Errorf("Expected 404 for missing issue, got %d", status)
+	}
+	if status := getJSON(t, server.URL+"/issues/abc", &apiErr); status != http.StatusBadRequest {
+		t.Errorf("Expected 400 for invalid number, got %d", status)
+	}
+}
+
+func TestAPI_Summary(t *testing.T) {
+	server := newTestAPIServer(t)
+
+	var summary IssueSummary
+	if status := getJSON(t, server.URL+"/summary", &summary); status != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", status)
+	}
+	if summary.Total != 4 {
+		t.Errorf("Expected total 4, got %d", summary.Total)
+	}
+	if summary.ByState["open"] != 3 || summary.ByState["closed"] != 1 {
+		t.Errorf("Unexpected state counts: %v", summary.ByState)
+	}
+	if summary.ByProject["org/alpha"] != 3 || summary.ByProject["org/beta"] != 1 {
+		t.Errorf("Unexpected project counts: %v", summary.ByProject)
+	}
+}
+
+func TestAPI_ReadOnly(t *testing.T) {
+	server := newTestAPIServer(t)
+
+	resp, err := http.Post(server.URL+"/issues", "application/json", nil)
+	if err != nil {
+		t.Fatalf("POST failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405 for POST, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,129 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Audit log defaults used when the config leaves them unset
+const (
+	DefaultAuditMaxSizeBytes = 10 * 1024 * 1024
+	DefaultAuditMaxBackups   = 5
+)
+
+// Sync actions recorded in the audit log
+const (
+	AuditActionCreated    = "created"
+	AuditActionUpdated    = "updated"
+	AuditActionConflict   = "conflict"
+	AuditActionRegression = "regression" // GitHub returned an older updated_at than stored; not applied
+)
+
+// AuditSettings configures the append-only sync audit log
+type AuditSettings struct {
+	File         string `yaml:"file,omitempty"`           // JSON lines file; auditing is off when empty
+	MaxSizeBytes int64  `yaml:"max_size_bytes,omitempty"` // Rotate once the file would grow past this size
+	MaxBackups   int    `yaml:"max_backups,omitempty"`    // Rotated files to keep (file.1 is the newest)
+}
+
+// AuditEntry is one line of the audit log
+type AuditEntry struct {
+	Timestamp string `json:"timestamp"`
+	Project   string `json:"project"`
+	Issue     int    `json:"issue"`
+	Action    string `json:"action"`
+	OldState  string `json:"old_state,omitempty"`
+	NewState  string `json:"new_state,omitempty"`
+}
+
+// AuditLog appends sync actions to a JSON lines file with size-based rotation.
+// A nil *AuditLog records nothing.
+type AuditLog struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+}
+
+// NewAuditLog returns the audit log described by settings, or nil when no file is configured
+func NewAuditLog(settings AuditSettings) (*AuditLog, error) {
+	if settings.File == "" {
+		return nil, nil
+	}
+
+	path, err := ResolveDatabasePath(settings.File)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve audit file: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return nil, fmt.Errorf("failed to create audit directory: %w", err)
+	}
+
+	log := &AuditLog{path: path, maxSize: settings.MaxSizeBytes, maxBackups: settings.MaxBackups}
+	if log.maxSize <= 0 {
+		log.maxSize = DefaultAuditMaxSizeBytes
+	}
+	if log.maxBackups <= 0 {
+		log.maxBackups = DefaultAuditMaxBackups
+	}
+	return log, nil
+}
+
+// Record appends an entry, stamping it with the current time when unset
+func (a *AuditLog) Record(entry AuditEntry) error {
+	if a == nil {
+		return nil
+	}
+	if entry.Timestamp == "" {
+		entry.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+	line = append(line, '\n')
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if info, err := os.Stat(a.path); err == nil && info.Size() > 0 && info.Size()+int64(len(line)) > a.maxSize {
+		if err := a.rotate(); err != nil {
+			return err
+		}
+	}
+
+	file, err := os.OpenFile(a.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600) // #nosec G304 - User controls audit file path
+	if err != nil {
+		return fmt.Errorf("failed to open audit file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(line); err != nil {
+		return fmt.Errorf("failed to write audit entry: %w", err)
+	}
+	return nil
+}
+
+// rotate shifts file.N to file.N+1, dropping the oldest backup, and moves the
+// current file to file.1
+func (a *AuditLog) rotate() error {
+	oldest := fmt.Sprintf("%s.%d", a.path, a.maxBackups)
+	if err := os.Remove(oldest); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove old audit file: %w", err)
+	}
+	for i := a.maxBackups - 1; i >= 1; i-- {
+		from := fmt.Sprintf("%s.%d", a.path, i)
+		if err := os.Rename(from, fmt.Sprintf("%s.%d", a.path, i+1)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to rotate audit file: %w", err)
+		}
+	}
+	if err := os.Rename(a.path, a.path+".1"); err != nil {
+		return fmt.Errorf("failed to rotate audit file: %w", err)
+	}
+	return nil
+}
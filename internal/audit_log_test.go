@@ -0,0 +1,150 @@
+package internal
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func readAuditEntries(t *testing.T, path string) []AuditEntry {
+	t.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open audit file: %v", err)
+	}
+	defer file.Close()
+
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("Invalid JSON line %q: %v", scanner.Text(), err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestNewAuditLog_Disabled(t *testing.T) {
+	log, err := NewAuditLog(AuditSettings{})
+	if err != nil {
+		t.Fatalf("NewAuditLog failed: %v", err)
+	}
+	if log != nil {
+		t.Error("Expected no audit log without a file")
+	}
+	// A nil log ignores entries
+	if err := log.Record(AuditEntry{Issue: 1, Action: AuditActionCreated}); err != nil {
+		t.Errorf("Expected nil audit log to ignore entries, got %v", err)
+	}
+}
+
+func TestAuditLog_RotatesPastSizeThreshold(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "audit.jsonl")
+	log, err := NewAuditLog(AuditSettings{File: path, MaxSizeBytes: 200, MaxBackups: 2})
+	if err != nil {
+		t.Fatalf("NewAuditLog failed: %v", err)
+	}
+
+	// Each entry is roughly 120 bytes, so every write past the first rotates
+	for i := 1; i <= 4; i++ {
+		entry := AuditEntry{Project: "octo/widgets", Issue: i, Action: AuditActionUpdated, OldState: "open", NewState: "closed"}
+		if err := log.Record(entry); err != nil {
+			t.Fatalf("Record failed: %v", err)
+		}
+	}
+
+	current := readAuditEntries(t, path)
+	if len(current) != 1 || current[0].Issue != 4 {
+		t.Errorf("Expected current file to hold only issue 4, got %+v", current)
+	}
+	if backup := readAuditEntries(t, path+".1"); len(backup) != 1 || backup[0].Issue != 3 {
+		t.Errorf("Expected newest backup to hold issue 3, got %+v", backup)
+	}
+	if backup := readAuditEntries(t, path+".2"); len(backup) != 1 || backup[0].Issue != 2 {
+		t.Errorf("Expected oldest backup to hold issue 2, got %+v", backup)
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Error("Expected backups beyond max_backups to be dropped")
+	}
+}
+
+func TestAuditLog_AppendsBelowThreshold(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	log, err := NewAuditLog(AuditSettings{File: path})
+	if err != nil {
+		t.Fatalf("NewAuditLog failed: %v", err)
+	}
+
+	for i := 1; i <= 3; i++ {
+		if err := log.Record(AuditEntry{Project: "octo/widgets", Issue: i, Action: AuditActionCreated}); err != nil {
+			t.Fatalf("Record failed: %v", err)
+		}
+	}
+
+	entries := readAuditEntries(t, path)
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 appended entries, got %d", len(entries))
+	}
+	if entries[0].Timestamp == "" {
+		t.Error("Expected entries to be timestamped")
+	}
+	if _, err := os.Stat(path + ".1"); !os.IsNotExist(err) {
+		t.Error("Expected no rotation below the default size threshold")
+	}
+}
+
+func TestSyncAdHoc_WritesAuditLog(t *testing.T) {
+	tempDir := t.TempDir()
+	issuesJSON := `[
+		{"id": 701, "number": 1, "title": "First", "state": "open"},
+		{"id": 702, "number": 2, "title": "Second", "state": "open"}
+	]`
+	newMockGitHubServer(t, "octo", "widgets", "", map[string]http.HandlerFunc{
+		"/repos/octo/widgets/issues": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(issuesJSON))
+		},
+	})
+
+	config, err := NewAdHocConfig("octo/widgets", "test-token")
+	if err != nil {
+		t.Fatalf("NewAdHocConfig failed: %v", err)
+	}
+	config.Global.Database = filepath.Join(tempDir, "pivot.db")
+	config.Audit.File = filepath.Join(tempDir, "audit.jsonl")
+
+	if _, err := SyncAdHoc(config, SyncOptions{FullSync: true}); err != nil {
+		t.Fatalf("First sync failed: %v", err)
+	}
+
+	issuesJSON = `[{"id": 702, "number": 2, "title": "Second", "state": "closed"}]`
+	if _, err := SyncAdHoc(config, SyncOptions{FullSync: true}); err != nil {
+		t.Fatalf("Second sync failed: %v", err)
+	}
+
+	entries := readAuditEntries(t, config.Audit.File)
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 audit entries, got %+v", entries)
+	}
+
+	var actions []string
+	for _, entry := range entries {
+		if entry.Project != "octo/widgets" {
+			t.Errorf("Expected project octo/widgets, got %s", entry.Project)
+		}
+		actions = append(actions, entry.Action)
+	}
+	if strings.Join(actions, ",") != "created,created,updated" {
+		t.Errorf("Expected created,created,updated, got %v", actions)
+	}
+
+	last := entries[2]
+	if last.Issue != 2 || last.OldState != "open" || last.NewState != "closed" {
+		t.Errorf("Expected issue 2 to move from open to closed, got %+v", last)
+	}
+}
@@ -0,0 +1,63 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AuthVerification is the machine-readable result of 'pivot auth verify --json'
+type AuthVerification struct {
+	TokenValid bool            `json:"token_valid"`
+	Login      string          `json:"login"`
+	Scopes     []string        `json:"scopes"`           // Empty for fine-grained tokens, which report no scopes
+	RepoAccess map[string]bool `json:"repo_access"`      // Keyed by owner/repo
+	Errors     []string        `json:"errors,omitempty"` // Why a check failed
+}
+
+// Passed reports whether the token is valid and every requested repository is accessible
+func (v *AuthVerification) Passed() bool {
+	if !v.TokenValid {
+		return false
+	}
+	for _, accessible := range v.RepoAccess {
+		if !accessible {
+			return false
+		}
+	}
+	return true
+}
+
+// VerifyAuth checks that token is valid, reads its login and scopes from the /user
+// endpoint and checks access to each repository (owner/repo). Repositories are not
+// checked when the token is invalid.
+func VerifyAuth(token string, repositories []string) *AuthVerification {
+	verification := &AuthVerification{Scopes: []string{}, RepoAccess: make(map[string]bool)}
+
+	report, err := FetchTokenScopes(token)
+	if err != nil {
+		verification.Errors = append(verification.Errors, ScrubError(err).Error())
+		for _, repository := range repositories {
+			verification.RepoAccess[repository] = false
+		}
+		return verification
+	}
+	verification.TokenValid = true
+	verification.Login = report.Login
+	verification.Scopes = append(verification.Scopes, report.Scopes...)
+
+	for _, repository := range repositories {
+		owner, repo, ok := strings.Cut(repository, "/")
+		if !ok || owner == "" || repo == "" {
+			verification.RepoAccess[repository] = false
+			verification.Errors = append(verification.Errors, fmt.Sprintf("%s: repository must be in format 'owner/repo'", repository))
+			continue
+		}
+		if err := ValidateRepositoryAccess(owner, repo, token); err != nil {
+			verification.RepoAccess[repository] = false
+			verification.Errors = append(verification.Errors, fmt.Sprintf("%s: %v", repository, ScrubError(err)))
+			continue
+		}
+		verification.RepoAccess[repository] = true
+	}
+	return verification
+}
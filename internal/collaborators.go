@@ -0,0 +1,51 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ListCollaborators returns the logins of the users that can be assigned issues in a
+// repository
+func ListCollaborators(owner, repo, token string) ([]string, error) {
+	var logins []string
+	for page := 1; ; page++ {
+		url := fmt.Sprintf("%s/repos/%s/%s/collaborators?per_page=100&page=%d", githubAPIURL, owner, repo, page)
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Authorization", "token "+token)
+		req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+		resp, err := newHTTPClient().Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list collaborators of %s/%s: %w", owner, repo, err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read collaborators of %s/%s: %w", owner, repo, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("GitHub API error (%d) listing collaborators: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+		}
+
+		var pageUsers []struct {
+			Login string `json:"login"`
+		}
+		if err := json.Unmarshal(body, &pageUsers); err != nil {
+			return nil, fmt.Errorf("failed to parse collaborators of %s/%s: %w", owner, repo, err)
+		}
+		for _, user := range pageUsers {
+			logins = append(logins, user.Login)
+		}
+
+		if !strings.Contains(resp.Header.Get("Link"), `rel="next"`) {
+			return logins, nil
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package internal
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestListCollaborators_Pagination(t *testing.T) {
+	newMockGitHubServer(t, "acme", "api", "[]", map[string]http.HandlerFunc{
+		"/repos/acme/api/collaborators": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("page") == "1" {
+				w.Header().Set("Link", `<https://api.github.com/repositories/1/collaborators?page=2>; rel="next"`)
+				_, _ = w.Write([]byte(`[{"login": "alice"}, {"login": "bob"}]`))
+				return
+			}
+			_, _ = w.Write([]byte(`[{"login": "carol"}]`))
+		},
+	})
+
+	logins, err := ListCollaborators("acme", "api", "test-token")
+	if err != nil {
+		t.Fatalf("ListCollaborators failed: %v", err)
+	}
+	if got := strings.Join(logins, ","); got != "alice,bob,carol" {
+		t.Errorf("Expected collaborators from both pages, got %s", got)
+	}
+}
+
+func TestListCollaborators_Forbidden(t *testing.T) {
+	newMockGitHubServer(t, "acme", "api", "[]", map[string]http.HandlerFunc{
+		"/repos/acme/api/collaborators": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message": "Must have push access to view repository collaborators."}`))
+		},
+	})
+
+	_, err := ListCollaborators("acme", "api", "test-token")
+	if err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("Expected a 403 error, got %v", err)
+	}
+}
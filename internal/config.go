@@ -122,7 +122,7 @@ sync:
 		config.Sync.BatchSize,
 	)
 
-	if err := os.WriteFile("config.yml", []byte(configContent), 0600); err != nil {
+	if err := writeConfigFile("config.yml", []byte(configContent)); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
 
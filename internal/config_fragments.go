@@ -0,0 +1,120 @@
+package internal
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// configFragmentDir holds project fragments merged into the main config
+const configFragmentDir = "config.d"
+
+// configFragment is a file under config.d/ listing extra projects
+type configFragment struct {
+	Projects []ProjectConfig `yaml:"projects"`
+}
+
+// loadConfigFragments appends the projects of every config.d/*.yml fragment, in file
+// name order, to config. A project defined twice, in the main config or any fragment,
+// is an error naming both files.
+func loadConfigFragments(config *MultiProjectConfig, mainFile string) error {
+	paths, err := filepath.Glob(filepath.Join(configFragmentDir, "*.yml"))
+	if err != nil {
+		return fmt.Errorf("failed to list config fragments: %w", err)
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+
+	definedIn := make(map[string]string)
+	for _, project := range config.Projects {
+		if _, ok := definedIn[fragmentProjectKey(project)]; !ok {
+			definedIn[fragmentProjectKey(project)] = mainFile
+		}
+	}
+
+	for _, path := range paths {
+		data, err := readConfigFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read config fragment %s: %w", path, err)
+		}
+		var fragment configFragment
+		if err := yaml.Unmarshal(data, &fragment); err != nil {
+			return fmt.Errorf("failed to parse config fragment %s: %w", path, err)
+		}
+
+		for _, project := range fragment.Projects {
+			if project.Owner == "" || project.Repo == "" {
+				return fmt.Errorf("invalid project in config fragment %s: owner and repo are required", path)
+			}
+			key := fragmentProjectKey(project)
+			if other, ok := definedIn[key]; ok {
+				return fmt.Errorf("duplicate project %s/%s in %s (already defined in %s)", project.Owner, project.Repo, path, other)
+			}
+			definedIn[key] = path
+
+			project.Source = path
+			config.Projects = append(config.Projects, project)
+		}
+	}
+	return nil
+}
+
+// fragmentProjectKey identifies a project across config files; GitHub names are case-insensitive
+func fragmentProjectKey(project ProjectConfig) string {
+	return strings.ToLower(project.Owner + "/" + project.Repo)
+}
+
+// mainConfigProjects returns the projects defined in the main config file, leaving out
+// those loaded from fragments, so saving the config never copies them into it
+func mainConfigProjects(projects []ProjectConfig) []ProjectConfig {
+	var main []ProjectConfig
+	for _, project := range projects {
+		if project.Source == "" {
+			main = append(main, project)
+		}
+	}
+	return main
+}
+
+// saveConfigFragments writes the projects loaded from config.d/ fragments back to their
+// files, so changes such as a project token are kept. Only fragments whose projects
+// changed are rewritten.
+func saveConfigFragments(projects []ProjectConfig) error {
+	var paths []string
+	bySource := make(map[string][]ProjectConfig)
+	for _, project := range projects {
+		if project.Source == "" {
+			continue
+		}
+		if _, ok := bySource[project.Source]; !ok {
+			paths = append(paths, project.Source)
+		}
+		bySource[project.Source] = append(bySource[project.Source], project)
+	}
+
+	for _, path := range paths {
+		data, err := yaml.Marshal(&configFragment{Projects: bySource[path]})
+		if err != nil {
+			return fmt.Errorf("failed to marshal config fragment %s: %w", path, err)
+		}
+
+		// Compare with the file as loaded, defaults included, ignoring its layout
+		if current, err := readConfigFile(path); err == nil {
+			var fragment configFragment
+			if yaml.Unmarshal(current, &fragment) == nil {
+				setDefaults(&MultiProjectConfig{Projects: fragment.Projects})
+				if normalized, err := yaml.Marshal(&fragment); err == nil && string(normalized) == string(data) {
+					continue
+				}
+			}
+		}
+
+		if err := writeConfigFile(path, data); err != nil {
+			return fmt.Errorf("failed to write config fragment %s: %w", path, err)
+		}
+	}
+	return nil
+}
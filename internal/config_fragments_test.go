@@ -0,0 +1,206 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeConfigFixture writes a config file, creating its directory
+func writeConfigFixture(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create %s: %v", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
+
+func TestLoadMultiProjectConfig_Fragments(t *testing.T) {
+	chdirTemp(t)
+	writeConfigFixture(t, "config.yml", `global:
+  database: ./pivot.db
+projects:
+  - owner: org
+    repo: core
+`)
+	writeConfigFixture(t, "config.d/team-b.yml", `projects:
+  - owner: org
+    repo: billing
+    token: team-b-token
+`)
+	writeConfigFixture(t, "config.d/team-a.yml", `projects:
+  - owner: org
+    repo: api
+  - owner: org
+    repo: web
+`)
+	writeConfigFixture(t, "config.d/notes.txt", "projects: [not a fragment]\n")
+
+	config, err := LoadMultiProjectConfig()
+	if err != nil {
+		t.Fatalf("LoadMultiProjectConfig failed: %v", err)
+	}
+
+	expected := "org/core, org/api, org/web, org/billing"
+	if got := projectNames(config.Projects); got != expected {
+		t.Fatalf("Expected projects %v, got %v", expected, got)
+	}
+	if config.Projects[0].Source != "" {
+		t.Errorf("Expected no source for the main config project, got %q", config.Projects[0].Source)
+	}
+	if source := config.Projects[3].Source; source != filepath.Join("config.d", "team-b.yml") {
+		t.Errorf("Expected org/billing to come from config.d/team-b.yml, got %q", source)
+	}
+	if config.Projects[3].Token != "team-b-token" {
+		t.Errorf("Expected the fragment's project token, got %q", config.Projects[3].Token)
+	}
+	if config.Projects[1].Path == "" {
+		t.Error("Expected fragment projects to get a default path")
+	}
+}
+
+func TestLoadMultiProjectConfig_DuplicateFragmentProjects(t *testing.T) {
+	chdirTemp(t)
+	writeConfigFixture(t, "config.yml", `global:
+  database: ./pivot.db
+projects:
+  - owner: org
+    repo: core
+`)
+	writeConfigFixture(t, "config.d/team-a.yml", `projects:
+  - owner: org
+    repo: api
+`)
+	writeConfigFixture(t, "config.d/team-b.yml", `projects:
+  - owner: Org
+    repo: API
+`)
+
+	_, err := LoadMultiProjectConfig()
+	if err == nil {
+		t.Fatal("Expected a project defined in two fragments to be rejected")
+	}
+	for _, want := range []string{"duplicate project Org/API", filepath.Join("config.d", "team-a.yml"), filepath.Join("config.d", "team-b.yml")} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to mention %q, got: %v", want, err)
+		}
+	}
+
+	// A fragment may not redefine a project of the main config either
+	writeConfigFixture(t, "config.d/team-b.yml", `projects:
+  - owner: org
+    repo: core
+`)
+	_, err = LoadMultiProjectConfig()
+	if err == nil || !strings.Contains(err.Error(), "already defined in config.yml") {
+		t.Errorf("Expected a duplicate of a main config project to be rejected, got: %v", err)
+	}
+}
+
+func TestLoadMultiProjectConfig_InvalidFragment(t *testing.T) {
+	chdirTemp(t)
+	writeConfigFixture(t, "config.yml", "global:\n  database: ./pivot.db\nprojects: []\n")
+
+	writeConfigFixture(t, "config.d/broken.yml", "projects: [owner: {\n")
+	if _, err := LoadMultiProjectConfig(); err == nil || !strings.Contains(err.Error(), "broken.yml") {
+		t.Errorf("Expected a parse error naming the fragment, got: %v", err)
+	}
+
+	writeConfigFixture(t, "config.d/broken.yml", "projects:\n  - owner: org\n")
+	if _, err := LoadMultiProjectConfig(); err == nil || !strings.Contains(err.Error(), "owner and repo are required") {
+		t.Errorf("Expected a project without a repo to be rejected, got: %v", err)
+	}
+}
+
+func TestSaveMultiProjectConfig_KeepsFragmentsSeparate(t *testing.T) {
+	chdirTemp(t)
+	writeConfigFixture(t, "config.yml", `global:
+  database: ./pivot.db
+projects:
+  - owner: org
+    repo: core
+`)
+	writeConfigFixture(t, "config.d/team-a.yml", `projects:
+  - owner: org
+    repo: api
+`)
+
+	config, err := LoadMultiProjectConfig()
+	if err != nil {
+		t.Fatalf("LoadMultiProjectConfig failed: %v", err)
+	}
+	config.Projects = append(config.Projects, ProjectConfig{Owner: "org", Repo: "new"})
+	if err := SaveMultiProjectConfig(config); err != nil {
+		t.Fatalf("SaveMultiProjectConfig failed: %v", err)
+	}
+
+	data, err := os.ReadFile("config.yml")
+	if err != nil {
+		t.Fatalf("Failed to read config.yml: %v", err)
+	}
+	if strings.Contains(string(data), "repo: api") {
+		t.Errorf("Expected the fragment project not to be copied into config.yml:\n%s", data)
+	}
+	if !strings.Contains(string(data), "repo: new") {
+		t.Errorf("Expected the added project in config.yml:\n%s", data)
+	}
+	if len(config.Projects) != 3 {
+		t.Errorf("Expected saving to leave the in-memory projects alone, got %d", len(config.Projects))
+	}
+
+	reloaded, err := LoadMultiProjectConfig()
+	if err != nil {
+		t.Fatalf("Reloading failed: %v", err)
+	}
+	expected := "org/core, org/new, org/api"
+	if got := projectNames(reloaded.Projects); got != expected {
+		t.Errorf("Expected projects %v after reload, got %v", expected, got)
+	}
+}
+
+func TestSaveMultiProjectConfig_WritesChangedFragments(t *testing.T) {
+	chdirTemp(t)
+	writeConfigFixture(t, "config.yml", `global:
+  database: ./pivot.db
+projects:
+  - owner: org
+    repo: core
+`)
+	untouched := "# Team B keeps its comments\nprojects:\n  - owner: b\n    repo: docs\n"
+	writeConfigFixture(t, "config.d/a.yml", "projects:\n  - owner: b\n    repo: frag\n  - owner: b\n    repo: old\n")
+	writeConfigFixture(t, "config.d/b.yml", untouched)
+
+	if err := SetToken("ghp_fragment", "b/frag"); err != nil {
+		t.Fatalf("SetToken failed: %v", err)
+	}
+	if err := UpdateProjectCoordinates("b", "old", "b", "renamed"); err != nil {
+		t.Fatalf("UpdateProjectCoordinates failed: %v", err)
+	}
+
+	reloaded, err := LoadMultiProjectConfig()
+	if err != nil {
+		t.Fatalf("Reloading failed: %v", err)
+	}
+	if got := projectNames(reloaded.Projects); got != "org/core, b/frag, b/renamed, b/docs" {
+		t.Errorf("Expected the renamed fragment project after reload, got %v", got)
+	}
+	for _, project := range reloaded.Projects {
+		if project.Repo == "frag" && (project.Token != "ghp_fragment" || project.Source != filepath.Join("config.d", "a.yml")) {
+			t.Errorf("Expected the token to be kept in its fragment, got %+v", project)
+		}
+	}
+
+	data, err := os.ReadFile("config.yml")
+	if err != nil {
+		t.Fatalf("Failed to read config.yml: %v", err)
+	}
+	if strings.Contains(string(data), "frag") || strings.Contains(string(data), "ghp_fragment") {
+		t.Errorf("Expected fragment projects to stay out of config.yml:\n%s", data)
+	}
+	if data, _ := os.ReadFile(filepath.Join("config.d", "b.yml")); string(data) != untouched {
+		t.Errorf("Expected an unchanged fragment not to be rewritten, got:\n%s", data)
+	}
+}
@@ -0,0 +1,310 @@
+package internal
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestMergeConfigs(t *testing.T) {
+	current := &MultiProjectConfig{
+		Global: GlobalConfig{Database: "~/.pivot/pivot.db", Token: "current-token"},
+		Projects: []ProjectConfig{
+			{Owner: "org", Repo: "alpha", Path: "/old/alpha"},
+			{Owner: "org", Repo: "beta"},
+		},
+	}
+	imported := &MultiProjectConfig{
+		Global: GlobalConfig{Token: "imported-token"},
+		Projects: []ProjectConfig{
+			{Owner: "org", Repo: "alpha", Path: "/new/alpha"},
+			{Owner: "org", Repo: "gamma"},
+		},
+	}
+
+	merged := MergeConfigs(current, imported)
+
+	if merged.Global.Token != "imported-token" {
+		t.Errorf("Expected imported token to take precedence, got %s", merged.Global.Token)
+	}
+	if merged.Global.Database != "~/.pivot/pivot.db" {
+		t.Errorf("Expected current database to be kept, got %s", merged.Global.Database)
+	}
+	if len(merged.Projects) != 3 {
+		t.Fatalf("Expected 3 projects, got %d", len(merged.Projects))
+	}
+	if merged.Projects[0].Path != "/new/alpha" {
+		t.Errorf("Expected existing project to be updated, got path %s", merged.Projects[0].Path)
+	}
+	if merged.Projects[2].Repo != "gamma" {
+		t.Errorf("Expected new project to be appended, got %s", merged.Projects[2].Repo)
+	}
+
+	// The current configuration must not be modified
+	if current.Global.Token != "current-token" || current.Projects[0].Path != "/old/alpha" || len(current.Projects) != 2 {
+		t.Errorf("Expected current config to be left untouched, got %+v", current)
+	}
+}
+
+func TestPreviewConfigImport_DryRunDoesNotWrite(t *testing.T) {
+	tempDir := t.TempDir()
+	oldDir, _ := os.Getwd()
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.Chdir(oldDir); err != nil {
+			t.Logf("Warning: Failed to change back to original directory: %v", err)
+		}
+	}()
+
+	currentContent := `global:
+  database: ./pivot.db
+  token: current-token
+projects:
+- owner: org
+  repo: alpha
+`
+	if err := os.WriteFile("config.yml", []byte(currentContent), 0600); err != nil {
+		t.Fatalf("Failed to write config.yml: %v", err)
+	}
+	importContent := `global:
+  token: imported-token
+projects:
+- owner: org
+  repo: gamma
+`
+	if err := os.WriteFile("import.yml", []byte(importContent), 0600); err != nil {
+		t.Fatalf("Failed to write import.yml: %v", err)
+	}
+
+	merged, diff, err := PreviewConfigImport("import.yml", ConfigImportOptions{})
+	if err != nil {
+		t.Fatalf("PreviewConfigImport failed: %v", err)
+	}
+
+	// Imported configs receive the default database, which replaces the current one
+	if merged.Global.Token != "imported-token" || merged.Global.Database != "~/.pivot/pivot.db" {
+		t.Errorf("Unexpected merged globals: %+v", merged.Global)
+	}
+	if len(merged.Projects) != 2 || merged.Projects[0].Repo != "alpha" || merged.Projects[1].Repo != "gamma" {
+		t.Errorf("Expected projects alpha and gamma, got %+v", merged.Projects)
+	}
+
+	for _, expected := range []string{"-   token: '***'", "+   token: '*** (changed)'", "+   repo: gamma", "    repo: alpha"} {
+		if !strings.Contains(diff, expected) {
+			t.Errorf("Expected diff to contain %q, got:\n%s", expected, diff)
+		}
+	}
+
+	if err := ImportConfigFileDryRun("import.yml", ConfigImportOptions{}); err != nil {
+		t.Fatalf("ImportConfigFileDryRun failed: %v", err)
+	}
+
+	content, err := os.ReadFile("config.yml")
+	if err != nil {
+		t.Fatalf("Failed to read config.yml: %v", err)
+	}
+	if string(content) != currentContent {
+		t.Errorf("Expected config.yml to be unchanged in dry run, got:\n%s", content)
+	}
+}
+
+func TestPreviewConfigImport_MasksSecrets(t *testing.T) {
+	chdirTemp(t)
+
+	currentContent := `global:
+  database: ./pivot.db
+  token: ghp_SECRETcurrent123
+sync:
+  notify:
+    webhook: https://hooks.example.com/services/SECRETcurrent
+projects:
+- owner: org
+  repo: alpha
+  token: ghp_SECRETalpha789
+`
+	if err := os.WriteFile("config.yml", []byte(currentContent), 0600); err != nil {
+		t.Fatalf("Failed to write config.yml: %v", err)
+	}
+	importContent := `global:
+  token: ghp_SECRETimported456
+sync:
+  notify:
+    webhook: https://hooks.example.com/services/SECRETimported
+projects:
+- owner: org
+  repo: gamma
+  token: ghp_SECRETgamma000
+`
+	if err := os.WriteFile("import.yml", []byte(importContent), 0600); err != nil {
+		t.Fatalf("Failed to write import.yml: %v", err)
+	}
+
+	merged, diff, err := PreviewConfigImport("import.yml", ConfigImportOptions{})
+	if err != nil {
+		t.Fatalf("PreviewConfigImport failed: %v", err)
+	}
+	if strings.Contains(diff, "SECRET") {
+		t.Errorf("Expected no token or webhook in the preview, got:\n%s", diff)
+	}
+	for _, expected := range []string{"-   token: '***'", "+   token: '*** (changed)'", "webhook: '***'", "  token: '***'"} {
+		if !strings.Contains(diff, expected) {
+			t.Errorf("Expected diff to contain %q, got:\n%s", expected, diff)
+		}
+	}
+
+	// Only the preview is masked, not the merged configuration
+	if merged.Global.Token != "ghp_SECRETimported456" || merged.Projects[0].Token != "ghp_SECRETalpha789" {
+		t.Errorf("Expected the merged config to keep its tokens, got %+v", merged)
+	}
+}
+
+func TestPreviewConfigImport_NoCurrentConfig(t *testing.T) {
+	tempDir := t.TempDir()
+	oldDir, _ := os.Getwd()
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.Chdir(oldDir); err != nil {
+			t.Logf("Warning: Failed to change back to original directory: %v", err)
+		}
+	}()
+
+	if err := os.WriteFile("import.yml", []byte("projects:\n- owner: org\n  repo: alpha\n"), 0600); err != nil {
+		t.Fatalf("Failed to write import.yml: %v", err)
+	}
+
+	merged, diff, err := PreviewConfigImport("import.yml", ConfigImportOptions{})
+	if err != nil {
+		t.Fatalf("PreviewConfigImport failed: %v", err)
+	}
+	if len(merged.Projects) != 1 {
+		t.Errorf("Expected 1 project, got %d", len(merged.Projects))
+	}
+	for _, line := range strings.Split(diff, "\n") {
+		if !strings.HasPrefix(line, "+ ") {
+			t.Errorf("Expected only added lines without a current config, got %q", line)
+		}
+	}
+	if _, err := os.Stat("config.yml"); !os.IsNotExist(err) {
+		t.Error("Expected config.yml not to be created in dry run")
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	diff := diffLines("a\nb\nc\n", "a\nc\nd\n")
+	expected := "  a\n- b\n  c\n+ d"
+	if diff != expected {
+		t.Errorf("Expected diff:\n%s\ngot:\n%s", expected, diff)
+	}
+}
+
+func TestMergeConfigsPreservingLocal(t *testing.T) {
+	current := &MultiProjectConfig{
+		Global: GlobalConfig{Database: "./local.db", Token: "local-token"},
+		Projects: []ProjectConfig{
+			{Owner: "org", Repo: "alpha", Path: "/local/alpha"},
+			{Owner: "org", Repo: "beta"},
+		},
+	}
+	imported := &MultiProjectConfig{
+		Global: GlobalConfig{Database: "./team.db", Token: "team-token"},
+		Projects: []ProjectConfig{
+			{Owner: "org", Repo: "alpha", Path: "/team/alpha", Token: "alpha-token"},
+			{Owner: "org", Repo: "gamma", Path: "/team/gamma"},
+		},
+	}
+
+	merged := MergeConfigsPreservingLocal(current, imported)
+
+	if merged.Global.Token != "local-token" || merged.Global.Database != "./local.db" {
+		t.Errorf("Expected local globals to be kept, got %+v", merged.Global)
+	}
+	if len(merged.Projects) != 3 {
+		t.Fatalf("Expected 3 projects, got %d", len(merged.Projects))
+	}
+	alpha := merged.Projects[0]
+	if alpha.Path != "/local/alpha" {
+		t.Errorf("Expected the local path of alpha to be kept, got %s", alpha.Path)
+	}
+	if alpha.Token != "alpha-token" {
+		t.Errorf("Expected the empty token of alpha to be filled from the import, got %q", alpha.Token)
+	}
+	if merged.Projects[2].Repo != "gamma" || merged.Projects[2].Path != "/team/gamma" {
+		t.Errorf("Expected the new project to be appended as imported, got %+v", merged.Projects[2])
+	}
+
+	// Empty local globals are filled from the import
+	filled := MergeConfigsPreservingLocal(&MultiProjectConfig{}, imported)
+	if filled.Global.Token != "team-token" || filled.Global.Database != "./team.db" {
+		t.Errorf("Expected empty globals to be filled from the import, got %+v", filled.Global)
+	}
+
+	if current.Projects[0].Token != "" || len(current.Projects) != 2 {
+		t.Errorf("Expected current config to be left untouched, got %+v", current)
+	}
+}
+
+func TestImportConfigFileWithOptions_PreserveLocal(t *testing.T) {
+	chdirTemp(t)
+
+	currentContent := `global:
+  database: ./local.db
+  token: local-token
+projects:
+- owner: org
+  repo: alpha
+  path: /local/alpha
+`
+	if err := os.WriteFile("config.yml", []byte(currentContent), 0600); err != nil {
+		t.Fatalf("Failed to write config.yml: %v", err)
+	}
+	importContent := `global:
+  database: ./team.db
+  token: team-token
+projects:
+- owner: org
+  repo: alpha
+  path: /team/alpha
+- owner: org
+  repo: gamma
+`
+	if err := os.WriteFile("import.yml", []byte(importContent), 0600); err != nil {
+		t.Fatalf("Failed to write import.yml: %v", err)
+	}
+
+	preview, _, err := PreviewConfigImport("import.yml", ConfigImportOptions{PreserveLocal: true})
+	if err != nil {
+		t.Fatalf("PreviewConfigImport failed: %v", err)
+	}
+	if preview.Global.Token != "local-token" {
+		t.Errorf("Expected the preview to keep the local token, got %s", preview.Global.Token)
+	}
+
+	// No merge prompt is shown, so nothing is read from stdin
+	if err := ImportConfigFileWithOptions("import.yml", ConfigImportOptions{PreserveLocal: true}); err != nil {
+		t.Fatalf("ImportConfigFileWithOptions failed: %v", err)
+	}
+
+	config, err := LoadMultiProjectConfig()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if config.Global.Token != "local-token" {
+		t.Errorf("Expected token 'local-token', got '%s'", config.Global.Token)
+	}
+	if config.Global.Database != "./local.db" {
+		t.Errorf("Expected database './local.db', got '%s'", config.Global.Database)
+	}
+	if len(config.Projects) != 2 {
+		t.Fatalf("Expected 2 projects, got %d", len(config.Projects))
+	}
+	if config.Projects[0].Path != "/local/alpha" {
+		t.Errorf("Expected the local path of alpha to be kept, got %s", config.Projects[0].Path)
+	}
+	if config.Projects[1].Repo != "gamma" {
+		t.Errorf("Expected the new project gamma to be added, got %s", config.Projects[1].Repo)
+	}
+}
@@ -0,0 +1,111 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Timing of the advisory lock guarding config file reads and writes
+var (
+	configLockTimeout = 5 * time.Second       // Give up waiting for the lock after this long
+	configLockStale   = 30 * time.Second      // A lock file older than this was left by a crashed process
+	configLockRetry   = 10 * time.Millisecond // Delay between attempts to take the lock
+)
+
+// lockConfigFile takes the advisory lock for path, a path+".lock" file created
+// exclusively, and returns the function releasing it. Every pivot process reading or
+// writing the config file goes through this lock.
+func lockConfigFile(path string) (func(), error) {
+	lockPath := path + ".lock"
+	deadline := time.Now().Add(configLockTimeout)
+	for {
+		file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600) // #nosec G304 - Lock file next to the config file
+		if err == nil {
+			_, _ = fmt.Fprintf(file, "%d\n", os.Getpid())
+			_ = file.Close()
+			return func() { _ = os.Remove(lockPath) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+		}
+
+		if info, statErr := os.Stat(lockPath); statErr == nil && time.Since(info.ModTime()) > configLockStale {
+			_ = os.Remove(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for %s (remove it if no other pivot command is running)", lockPath)
+		}
+		time.Sleep(configLockRetry)
+	}
+}
+
+// readConfigFile reads a config file while holding its lock. When the lock cannot be
+// created at all, e.g. in a read-only directory, the file is read without it: writes
+// replace the file atomically, so a reader never sees a partial file either way.
+func readConfigFile(path string) ([]byte, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+
+	unlock, err := lockConfigFile(path)
+	if err == nil {
+		defer unlock()
+	} else if !errors.Is(err, os.ErrPermission) {
+		return nil, err
+	}
+
+	return os.ReadFile(path) // #nosec G304 - Config file path is fixed by the caller
+}
+
+// writeConfigFile replaces a config file atomically while holding its lock
+func writeConfigFile(path string, data []byte) error {
+	unlock, err := lockConfigFile(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return replaceConfigFile(path, data)
+}
+
+// replaceConfigFile replaces a config file atomically; the caller holds its lock. The
+// data is written to a temporary file in the same directory and renamed over the
+// original. A symlinked config file stays a symlink: its target is replaced.
+func replaceConfigFile(path string, data []byte) error {
+	if target, err := filepath.EvalSymlinks(path); err == nil {
+		path = target
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to resolve %s: %w", path, err)
+	}
+
+	temp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary config file: %w", err)
+	}
+	tempPath := temp.Name()
+	defer os.Remove(tempPath) // No-op once renamed
+
+	if _, err := temp.Write(data); err != nil {
+		_ = temp.Close()
+		return fmt.Errorf("failed to write temporary config file: %w", err)
+	}
+	if err := temp.Sync(); err != nil {
+		_ = temp.Close()
+		return fmt.Errorf("failed to flush temporary config file: %w", err)
+	}
+	if err := temp.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary config file: %w", err)
+	}
+	if err := os.Chmod(tempPath, 0600); err != nil {
+		return fmt.Errorf("failed to set config file permissions: %w", err)
+	}
+
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+	return nil
+}
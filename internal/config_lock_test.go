@@ -0,0 +1,222 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// chdirTemp switches to a fresh temp directory for the duration of the test
+func chdirTemp(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	oldDir, _ := os.Getwd()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("Failed to chdir: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(oldDir) })
+	return dir
+}
+
+// configWithProjects builds a config with n projects and a long description per
+// project, so partially written files would be easy to spot
+func configWithProjects(n int) *MultiProjectConfig {
+	config := &MultiProjectConfig{Global: GlobalConfig{Database: "./pivot.db"}}
+	for i := 0; i < n; i++ {
+		config.Projects = append(config.Projects, ProjectConfig{
+			Owner: "org",
+			Repo:  fmt.Sprintf("repo-%d", i),
+			Path:  strings.Repeat("p", 4096),
+		})
+	}
+	return config
+}
+
+func TestConfigConcurrentSaveAndLoad(t *testing.T) {
+	dir := chdirTemp(t)
+	if err := SaveMultiProjectConfig(configWithProjects(1)); err != nil {
+		t.Fatalf("Initial save failed: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 200)
+	for writer := 1; writer <= 8; writer++ {
+		wg.Add(1)
+		go func(projects int) {
+			defer wg.Done()
+			for i := 0; i < 10; i++ {
+				if err := SaveMultiProjectConfig(configWithProjects(projects)); err != nil {
+					errs <- fmt.Errorf("save: %w", err)
+				}
+			}
+		}(writer * 5)
+	}
+	for reader := 0; reader < 8; reader++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 10; i++ {
+				// Read the way LoadMultiProjectConfig does, without its process-wide side effects
+				data, err := readConfigFile("config.yml")
+				if err != nil {
+					errs <- fmt.Errorf("read: %w", err)
+					continue
+				}
+				var config MultiProjectConfig
+				if err := yaml.Unmarshal(data, &config); err != nil {
+					errs <- fmt.Errorf("read a partial config: %w", err)
+					continue
+				}
+				if n := len(config.Projects); n != 1 && n%5 != 0 {
+					errs <- fmt.Errorf("load returned a partial config with %d projects", n)
+				}
+				for _, project := range config.Projects {
+					if len(project.Path) != 4096 {
+						errs <- fmt.Errorf("load returned a truncated project %s", project.Repo)
+						break
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+
+	if _, err := LoadMultiProjectConfig(); err != nil {
+		t.Errorf("Expected a valid config after concurrent writes, got: %v", err)
+	}
+	leftovers, _ := filepath.Glob(filepath.Join(dir, "config.yml.*"))
+	if len(leftovers) != 0 {
+		t.Errorf("Expected no lock or temporary files left behind, got %v", leftovers)
+	}
+}
+
+func TestWriteConfigFile_Permissions(t *testing.T) {
+	chdirTemp(t)
+	if err := writeConfigFile("config.yml", []byte("global: {}\n")); err != nil {
+		t.Fatalf("writeConfigFile failed: %v", err)
+	}
+	info, err := os.Stat("config.yml")
+	if err != nil {
+		t.Fatalf("Config file missing: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 && os.PathSeparator == '/' {
+		t.Errorf("Expected permissions 0600, got %o", perm)
+	}
+}
+
+func TestLockConfigFile_WaitsAndRecoversStaleLock(t *testing.T) {
+	chdirTemp(t)
+	oldTimeout, oldStale := configLockTimeout, configLockStale
+	configLockTimeout, configLockStale = 100*time.Millisecond, time.Hour
+	t.Cleanup(func() { configLockTimeout, configLockStale = oldTimeout, oldStale })
+
+	unlock, err := lockConfigFile("config.yml")
+	if err != nil {
+		t.Fatalf("Failed to take lock: %v", err)
+	}
+	if _, err := lockConfigFile("config.yml"); err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("Expected a held lock to time out, got: %v", err)
+	}
+	unlock()
+
+	// A lock left behind by a crashed process is taken over once stale
+	if err := os.WriteFile("config.yml.lock", []byte("12345\n"), 0600); err != nil {
+		t.Fatalf("Failed to create stale lock: %v", err)
+	}
+	old := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes("config.yml.lock", old, old); err != nil {
+		t.Fatalf("Failed to age lock: %v", err)
+	}
+	unlock, err = lockConfigFile("config.yml")
+	if err != nil {
+		t.Fatalf("Expected stale lock to be replaced, got: %v", err)
+	}
+	unlock()
+	if _, err := os.Stat("config.yml.lock"); !os.IsNotExist(err) {
+		t.Errorf("Expected lock file to be removed on unlock, got: %v", err)
+	}
+}
+
+func TestUpdateMultiProjectConfig_ConcurrentUpdatesKeepEveryChange(t *testing.T) {
+	chdirTemp(t)
+	config := configWithProjects(8)
+	if err := SaveMultiProjectConfig(config); err != nil {
+		t.Fatalf("Initial save failed: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := SetToken(fmt.Sprintf("ghp_token%d", i), fmt.Sprintf("org/repo-%d", i)); err != nil {
+				errs <- err
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	loaded, err := LoadMultiProjectConfig()
+	if err != nil {
+		t.Fatalf("LoadMultiProjectConfig failed: %v", err)
+	}
+	for i, project := range loaded.Projects {
+		if want := fmt.Sprintf("ghp_token%d", i); project.Token != want {
+			t.Errorf("Expected %s to keep token %s, got %q", project.Repo, want, project.Token)
+		}
+	}
+}
+
+func TestUpdateMultiProjectConfig_KeepsSymlink(t *testing.T) {
+	dir := chdirTemp(t)
+	target := filepath.Join(dir, "dotfiles", "pivot.yml")
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		t.Fatalf("Failed to create target dir: %v", err)
+	}
+	if err := os.WriteFile(target, []byte("global:\n  database: ./pivot.db\nprojects:\n  - owner: org\n    repo: app\n"), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if err := os.Symlink(target, "config.yml"); err != nil {
+		t.Skipf("Symlinks not supported: %v", err)
+	}
+
+	if err := SetToken("ghp_linked", ""); err != nil {
+		t.Fatalf("SetToken failed: %v", err)
+	}
+
+	if info, err := os.Lstat("config.yml"); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("Expected config.yml to stay a symlink, got %v (%v)", info, err)
+	}
+	data, err := os.ReadFile(target)
+	if err != nil || !strings.Contains(string(data), "ghp_linked") {
+		t.Errorf("Expected the link target to be updated, got %q (%v)", data, err)
+	}
+
+	// A failed update saves nothing
+	failed := fmt.Errorf("stop")
+	if err := UpdateMultiProjectConfig(func(config *MultiProjectConfig) error {
+		config.Global.Token = "ghp_discarded"
+		return failed
+	}); err != failed {
+		t.Errorf("Expected the update error, got %v", err)
+	}
+	if data, _ := os.ReadFile(target); strings.Contains(string(data), "ghp_discarded") {
+		t.Error("Expected a failed update not to be saved")
+	}
+}
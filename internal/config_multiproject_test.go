@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 )
@@ -219,6 +220,87 @@ func TestCentralDatabasePath(t *testing.T) {
 	}
 }
 
+// setTestHome points the home directory at a temp dir on every platform
+func setTestHome(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	return home
+}
+
+// TestResolveDatabasePath_CrossPlatform tests home and environment expansion without assuming a separator
+func TestResolveDatabasePath_CrossPlatform(t *testing.T) {
+	home := setTestHome(t)
+	dataDir := t.TempDir()
+	t.Setenv("PIVOT_DATA", dataDir)
+
+	tests := []struct {
+		name     string
+		dbPath   string
+		expected string
+	}{
+		{"bare tilde", "~", home},
+		{"tilde with slash", "~/.pivot/pivot.db", filepath.Join(home, ".pivot", "pivot.db")},
+		{"tilde with platform separator", "~" + string(filepath.Separator) + "pivot.db", filepath.Join(home, "pivot.db")},
+		{"dollar variable", "$PIVOT_DATA/pivot.db", filepath.Join(dataDir, "pivot.db")},
+		{"braced variable", "${PIVOT_DATA}/pivot.db", filepath.Join(dataDir, "pivot.db")},
+		{"percent variable", "%PIVOT_DATA%/pivot.db", filepath.Join(dataDir, "pivot.db")},
+		{"unset percent variable", "%PIVOT_UNSET_VAR%/pivot.db", filepath.FromSlash("%PIVOT_UNSET_VAR%/pivot.db")},
+		{"other user tilde", "~someone/pivot.db", filepath.FromSlash("~someone/pivot.db")},
+		{"slash separated relative", "data/pivot.db", filepath.Join("data", "pivot.db")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved, err := ResolveDatabasePath(tt.dbPath)
+			if err != nil {
+				t.Fatalf("ResolveDatabasePath failed: %v", err)
+			}
+			if resolved != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, resolved)
+			}
+		})
+	}
+}
+
+// TestResolveDatabasePath_UserProfileVariable tests the Windows %USERPROFILE% form
+func TestResolveDatabasePath_UserProfileVariable(t *testing.T) {
+	home := setTestHome(t)
+
+	resolved, err := ResolveDatabasePath(`%USERPROFILE%/.pivot/pivot.db`)
+	if err != nil {
+		t.Fatalf("ResolveDatabasePath failed: %v", err)
+	}
+	expected := filepath.Join(home, ".pivot", "pivot.db")
+	if resolved != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, resolved)
+	}
+}
+
+// TestResolveDatabasePath_WindowsDrivePaths tests drive-absolute and drive-relative paths
+func TestResolveDatabasePath_WindowsDrivePaths(t *testing.T) {
+	if runtime.GOOS != "windows" {
+		t.Skip("drive letters only apply on Windows")
+	}
+
+	resolved, err := ResolveDatabasePath(`C:\Users\me\pivot.db`)
+	if err != nil {
+		t.Fatalf("ResolveDatabasePath failed: %v", err)
+	}
+	if resolved != `C:\Users\me\pivot.db` {
+		t.Errorf("Expected drive-absolute path unchanged, got '%s'", resolved)
+	}
+
+	resolved, err = ResolveDatabasePath("C:pivot.db")
+	if err != nil {
+		t.Fatalf("ResolveDatabasePath failed: %v", err)
+	}
+	if !filepath.IsAbs(resolved) {
+		t.Errorf("Expected drive-relative path to become absolute, got '%s'", resolved)
+	}
+}
+
 // TestConfigImportFromFile tests importing configuration from a file
 func TestConfigImportFromFile(t *testing.T) {
 	tempDir := t.TempDir()
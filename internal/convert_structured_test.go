@@ -0,0 +1,88 @@
+package internal
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// structuredIssueJSON is an issue as GitHub returns it, with full label and assignee objects
+const structuredIssueJSON = `{
+	"id": 900, "number": 9, "title": "Structured", "state": "open",
+	"labels": [
+		{"id": 208045946, "name": "ui", "color": "a2eeef", "description": "User interface"},
+		{"id": 208045947, "name": "bug", "color": "d73a4a", "description": "Something isn't working"}
+	],
+	"assignees": [
+		{"id": 583231, "login": "octocat"},
+		{"id": 1024, "login": "hubot"}
+	]
+}`
+
+func decodeStructuredIssue(t *testing.T) *Issue {
+	t.Helper()
+	var issue Issue
+	if err := json.Unmarshal([]byte(structuredIssueJSON), &issue); err != nil {
+		t.Fatalf("Failed to decode issue: %v", err)
+	}
+	return &issue
+}
+
+func TestConvertIssueToDBIssue_FlatOutput(t *testing.T) {
+	issue := decodeStructuredIssue(t)
+
+	dbIssue := ConvertIssueToDBIssue(issue)
+
+	if dbIssue.Labels != "bug,ui" {
+		t.Errorf("Expected sorted flat labels 'bug,ui', got %q", dbIssue.Labels)
+	}
+	if dbIssue.Assignees != "hubot,octocat" {
+		t.Errorf("Expected sorted flat assignees 'hubot,octocat', got %q", dbIssue.Assignees)
+	}
+	if dbIssue.LabelObjects != nil || dbIssue.AssigneeObjects != nil {
+		t.Errorf("Expected no structured output by default, got %+v and %+v", dbIssue.LabelObjects, dbIssue.AssigneeObjects)
+	}
+}
+
+func TestConvertIssueToDBIssueWithOptions_StructuredOutput(t *testing.T) {
+	issue := decodeStructuredIssue(t)
+
+	dbIssue := ConvertIssueToDBIssueWithOptions(issue, ConvertOptions{Structured: true})
+
+	// The flat output is unchanged
+	if flat := ConvertIssueToDBIssue(issue); dbIssue.Labels != flat.Labels || dbIssue.Assignees != flat.Assignees {
+		t.Errorf("Expected the flat fields to match the default conversion, got %q and %q", dbIssue.Labels, dbIssue.Assignees)
+	}
+
+	if len(dbIssue.LabelObjects) != len(issue.Labels) {
+		t.Fatalf("Expected %d labels, got %d", len(issue.Labels), len(dbIssue.LabelObjects))
+	}
+	for i, label := range issue.Labels {
+		if dbIssue.LabelObjects[i] != label {
+			t.Errorf("Expected label %d to be %+v, got %+v", i, label, dbIssue.LabelObjects[i])
+		}
+	}
+	if got := dbIssue.LabelObjects[1]; got.ID != 208045947 || got.Color != "d73a4a" || got.Description != "Something isn't working" {
+		t.Errorf("Expected the id, color and description of the bug label, got %+v", got)
+	}
+
+	if len(dbIssue.AssigneeObjects) != len(issue.Assignees) {
+		t.Fatalf("Expected %d assignees, got %d", len(issue.Assignees), len(dbIssue.AssigneeObjects))
+	}
+	for i, user := range issue.Assignees {
+		if dbIssue.AssigneeObjects[i] != user {
+			t.Errorf("Expected assignee %d to be %+v, got %+v", i, user, dbIssue.AssigneeObjects[i])
+		}
+	}
+
+	// The structured output is a copy, not a view of the input issue
+	issue.Labels[0].Name = "changed"
+	if dbIssue.LabelObjects[0].Name != "ui" {
+		t.Errorf("Expected structured labels to be copied, got %q", dbIssue.LabelObjects[0].Name)
+	}
+
+	// An issue without labels or assignees gets empty, non-nil lists
+	empty := ConvertIssueToDBIssueWithOptions(&Issue{ID: 1, Number: 1}, ConvertOptions{Structured: true})
+	if empty.LabelObjects == nil || len(empty.LabelObjects) != 0 || empty.AssigneeObjects == nil {
+		t.Errorf("Expected empty structured lists, got %+v and %+v", empty.LabelObjects, empty.AssigneeObjects)
+	}
+}
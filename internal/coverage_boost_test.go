@@ -52,7 +52,7 @@ func TestSyncProject_AdditionalCoverage(t *testing.T) {
 		}
 
 		// This will fail at FetchIssues due to invalid token, but should pass token validation
-		err := syncProject(db, globalConfig, projectConfig)
+		_, err := syncProject(db, globalConfig, projectConfig)
 		if err != nil && strings.Contains(err.Error(), "no GitHub token configured") {
 			t.Errorf("Should not be a token error when project has specific token, got: %v", err)
 		}
@@ -68,7 +68,7 @@ func TestSyncProject_AdditionalCoverage(t *testing.T) {
 		}
 
 		// This will fail at FetchIssues due to invalid token, but should pass token validation
-		err := syncProject(db, globalConfig, projectConfig)
+		_, err := syncProject(db, globalConfig, projectConfig)
 		if err != nil && strings.Contains(err.Error(), "no GitHub token configured") {
 			t.Errorf("Should not be a token error when global has token, got: %v", err)
 		}
@@ -86,7 +86,7 @@ func TestSyncProject_AdditionalCoverage(t *testing.T) {
 			Token: "project_token",
 		}
 
-		err := syncProject(db, globalConfig, projectConfig)
+		_, err := syncProject(db, globalConfig, projectConfig)
 		if err == nil {
 			t.Error("Expected error when using invalid credentials")
 		}
@@ -129,7 +129,7 @@ projects:
 		defer os.Remove("sync_multi.db")
 
 		// Test SyncMultiProject with empty filter (should sync all projects)
-		err = SyncMultiProject("")
+		_, err = SyncMultiProject("")
 		if err == nil {
 			t.Skip("Sync succeeded unexpectedly - likely means network access")
 		}
@@ -156,7 +156,7 @@ projects:
 		defer os.Remove("sync_filter.db")
 
 		// Test with invalid filter format (missing slash)
-		err = SyncMultiProject("invalidfilter")
+		_, err = SyncMultiProject("invalidfilter")
 		if err == nil {
 			t.Error("Expected error for invalid filter format")
 		}
@@ -181,7 +181,7 @@ projects:
 		defer os.Remove("sync_notfound.db")
 
 		// Test with project filter that doesn't exist in config
-		err = SyncMultiProject("nonexistent/repo")
+		_, err = SyncMultiProject("nonexistent/repo")
 		if err == nil {
 			t.Error("Expected error when project not found")
 		}
@@ -207,7 +207,7 @@ projects:
 		// This should fail at database initialization but the function will
 		// attempt to continue and sync projects, printing errors to stdout
 		// The function only returns errors for config/database setup issues
-		err = SyncMultiProject("")
+		_, err = SyncMultiProject("")
 		// The function should return nil since it continues even when individual
 		// project syncs fail - it only returns errors for setup issues
 		if err != nil {
@@ -17,7 +17,7 @@ func TestSync_EdgeCases(t *testing.T) {
 		os.Remove("config.yml")
 		os.Remove("config.yaml")
 
-		err := Sync()
+		_, err := Sync()
 		if err == nil {
 			t.Error("Expected error when no config file exists")
 		}
@@ -36,7 +36,7 @@ func TestSync_EdgeCases(t *testing.T) {
 		}
 		defer os.Remove("config.yml")
 
-		err = Sync()
+		_, err = Sync()
 		if err == nil {
 			t.Error("Expected error with invalid config file")
 		}
@@ -0,0 +1,58 @@
+package internal
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCreateIssueRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     CreateIssueRequest
+		expectedErr string
+	}{
+		{"valid request", CreateIssueRequest{Title: "Fix login", Body: "Details", Labels: []string{"bug"}, Assignees: []string{"octocat"}}, ""},
+		{"title at limit", CreateIssueRequest{Title: strings.Repeat("é", MaxIssueTitleLength)}, ""},
+		{"empty title", CreateIssueRequest{Title: ""}, "title is required"},
+		{"blank title", CreateIssueRequest{Title: "   "}, "title is required"},
+		{"overlong title", CreateIssueRequest{Title: strings.Repeat("a", MaxIssueTitleLength+1)}, "issue title is 257 characters long (maximum 256)"},
+		{"overlong body", CreateIssueRequest{Title: "ok", Body: strings.Repeat("b", MaxIssueBodyLength+1)}, "issue body is"},
+		{"empty label", CreateIssueRequest{Title: "ok", Labels: []string{"bug", ""}}, "label names must not be empty"},
+		{"overlong label", CreateIssueRequest{Title: "ok", Labels: []string{strings.Repeat("l", MaxLabelNameLength+1)}}, "maximum 50"},
+		{"too many assignees", CreateIssueRequest{Title: "ok", Assignees: make([]string, MaxIssueAssignees+1)}, "11 assignees"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.Validate()
+			if tt.expectedErr == "" {
+				if err != nil {
+					t.Errorf("Expected valid request, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.expectedErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestCreateIssue_InvalidRequestFailsBeforeAPI(t *testing.T) {
+	requests := 0
+	countRequest := func(w http.ResponseWriter, r *http.Request) { requests++ }
+	newMockGitHubServer(t, "octo", "widgets", "[]", map[string]http.HandlerFunc{
+		"/user":                      countRequest,
+		"/repos/octo/widgets":        countRequest,
+		"/repos/octo/widgets/issues": countRequest,
+	})
+
+	_, err := CreateIssue("octo", "widgets", "test-token", CreateIssueRequest{Title: ""})
+	if err == nil || !strings.Contains(err.Error(), "invalid issue: issue title is required") {
+		t.Errorf("Expected local validation error, got %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("Expected no API requests, got %d", requests)
+	}
+}
@@ -0,0 +1,132 @@
+package csv
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/rhino11/pivot/internal"
+)
+
+func TestFromDBIssue_AgileFields(t *testing.T) {
+	dbIssue := internal.DBIssue{
+		ID:                 1001,
+		Number:             7,
+		Title:              "Checkout flow",
+		State:              "open",
+		Labels:             "feature,payments",
+		Assignees:          "alice",
+		CreatedAt:          "2026-01-01T00:00:00Z",
+		Milestone:          "v1.0",
+		StoryPoints:        8,
+		EstimatedHours:     16,
+		Epic:               "Payments",
+		AcceptanceCriteria: "User can pay with a card",
+	}
+
+	issue := FromDBIssue(dbIssue)
+
+	if issue.ID != 7 {
+		t.Errorf("Expected ID to be the issue number 7, got %d", issue.ID)
+	}
+	if len(issue.Labels) != 2 || issue.Labels[1] != "payments" {
+		t.Errorf("Expected labels [feature payments], got %v", issue.Labels)
+	}
+	if issue.CreatedAt.IsZero() {
+		t.Error("Expected created_at to be parsed")
+	}
+	if issue.StoryPoints != 8 || issue.EstimatedHours != 16 {
+		t.Errorf("Expected 8 points and 16 hours, got %d and %d", issue.StoryPoints, issue.EstimatedHours)
+	}
+	if issue.Epic != "Payments" || issue.Milestone != "v1.0" {
+		t.Errorf("Expected epic Payments and milestone v1.0, got %q and %q", issue.Epic, issue.Milestone)
+	}
+	if issue.AcceptanceCriteria != "User can pay with a card" {
+		t.Errorf("Expected acceptance criteria, got %q", issue.AcceptanceCriteria)
+	}
+}
+
+func TestToDBIssue_AgileFields(t *testing.T) {
+	issue := &Issue{Title: "Story", StoryPoints: 3, EstimatedHours: 5, Epic: "Onboarding", AcceptanceCriteria: "Done"}
+
+	dbIssue := toDBIssue(issue)
+
+	if dbIssue.StoryPoints != 3 || dbIssue.EstimatedHours != 5 {
+		t.Errorf("Expected 3 points and 5 hours, got %d and %d", dbIssue.StoryPoints, dbIssue.EstimatedHours)
+	}
+	if dbIssue.Epic != "Onboarding" || dbIssue.AcceptanceCriteria != "Done" {
+		t.Errorf("Expected epic and acceptance criteria to carry over, got %+v", dbIssue)
+	}
+}
+
+// TestAgileFields_LocalIssueExportRoundTrip exports a locally authored issue
+// from the database and reads it back from CSV
+func TestAgileFields_LocalIssueExportRoundTrip(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	db, err := internal.InitMultiProjectDBFromPath(filepath.Join(tmpDir, "pivot.db"))
+	if err != nil {
+		t.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer db.Close()
+
+	projectID, err := internal.CreateProject(db, &internal.ProjectConfig{Owner: "org", Repo: "repo"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	local := &internal.DBIssue{
+		ID:                 -1,
+		Number:             1,
+		Title:              "Local story",
+		State:              "open",
+		CreatedAt:          "2026-01-01T00:00:00Z",
+		UpdatedAt:          "2026-01-01T00:00:00Z",
+		Milestone:          "Sprint 3",
+		StoryPoints:        5,
+		EstimatedHours:     10,
+		Epic:               "Reporting",
+		AcceptanceCriteria: "Report renders",
+	}
+	if err := internal.SaveIssue(db, projectID, local); err != nil {
+		t.Fatalf("Failed to save issue: %v", err)
+	}
+
+	dbIssues, err := internal.GetAllIssues(db)
+	if err != nil {
+		t.Fatalf("Failed to load issues: %v", err)
+	}
+	var issues []*Issue
+	for _, dbIssue := range dbIssues {
+		issues = append(issues, FromDBIssue(dbIssue))
+	}
+
+	exportFile := filepath.Join(tmpDir, "export.csv")
+	if err := WriteCSV(issues, exportFile, &ExportConfig{FilePath: exportFile}); err != nil {
+		t.Fatalf("Failed to export CSV: %v", err)
+	}
+
+	imported, err := ParseCSV(exportFile, &ImportConfig{FilePath: exportFile})
+	if err != nil {
+		t.Fatalf("Failed to import CSV: %v", err)
+	}
+	if len(imported) != 1 {
+		t.Fatalf("Expected 1 issue, got %d", len(imported))
+	}
+
+	got := imported[0]
+	if got.StoryPoints != 5 {
+		t.Errorf("Expected 5 story points, got %d", got.StoryPoints)
+	}
+	if got.EstimatedHours != 10 {
+		t.Errorf("Expected 10 estimated hours, got %d", got.EstimatedHours)
+	}
+	if got.Epic != "Reporting" {
+		t.Errorf("Expected epic Reporting, got %q", got.Epic)
+	}
+	if got.AcceptanceCriteria != "Report renders" {
+		t.Errorf("Expected acceptance criteria Report renders, got %q", got.AcceptanceCriteria)
+	}
+	if got.Milestone != "Sprint 3" {
+		t.Errorf("Expected milestone Sprint 3, got %q", got.Milestone)
+	}
+}
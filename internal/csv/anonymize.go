@@ -0,0 +1,24 @@
+package csv
+
+import (
+	"github.com/rhino11/pivot/internal"
+)
+
+// AnonymizeIssues returns copies of the issues with the assignees pseudonymized and
+// the title, body, epic and acceptance criteria anonymized for external sharing
+func AnonymizeIssues(issues []*Issue, anonymizer *internal.Anonymizer) []*Issue {
+	anonymized := make([]*Issue, len(issues))
+	for i, issue := range issues {
+		copied := *issue
+		copied.Assignees = make([]string, len(issue.Assignees))
+		for j, assignee := range issue.Assignees {
+			copied.Assignees[j] = anonymizer.Pseudonym(assignee)
+		}
+		copied.Title = anonymizer.AnonymizeText(issue.Title)
+		copied.Body = anonymizer.AnonymizeText(issue.Body)
+		copied.Epic = anonymizer.AnonymizeText(issue.Epic)
+		copied.AcceptanceCriteria = anonymizer.AnonymizeText(issue.AcceptanceCriteria)
+		anonymized[i] = &copied
+	}
+	return anonymized
+}
@@ -0,0 +1,39 @@
+package csv
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rhino11/pivot/internal"
+)
+
+func TestAnonymizeIssues(t *testing.T) {
+	anonymizer, err := internal.NewAnonymizer(nil)
+	if err != nil {
+		t.Fatalf("NewAnonymizer failed: %v", err)
+	}
+
+	issues := []*Issue{
+		{ID: 1, Title: "First", Assignees: []string{"alice"}, Body: "Details at https://github.com/org/repo/issues/1",
+			Epic: "Ask @alice", AcceptanceCriteria: "See https://wiki.internal/spec"},
+		{ID: 2, Title: "Second", Assignees: []string{"alice"}},
+	}
+
+	anonymized := AnonymizeIssues(issues, anonymizer)
+
+	if strings.Join(anonymized[0].Assignees, ",") != strings.Join(anonymized[1].Assignees, ",") {
+		t.Errorf("Expected consistent pseudonyms, got %v and %v", anonymized[0].Assignees, anonymized[1].Assignees)
+	}
+	if strings.Join(anonymized[0].Assignees, ",") == "alice" {
+		t.Error("Expected assignee to be pseudonymized")
+	}
+	if strings.Contains(anonymized[0].Body, "https://") {
+		t.Errorf("Expected URL to be removed, got %s", anonymized[0].Body)
+	}
+	if strings.Contains(anonymized[0].Epic, "alice") || strings.Contains(anonymized[0].AcceptanceCriteria, "https://") {
+		t.Errorf("Expected epic and acceptance criteria to be anonymized, got %q and %q", anonymized[0].Epic, anonymized[0].AcceptanceCriteria)
+	}
+	if strings.Join(issues[0].Assignees, ",") != "alice" {
+		t.Errorf("Expected original issue to be untouched, got %v", issues[0].Assignees)
+	}
+}
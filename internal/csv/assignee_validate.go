@@ -0,0 +1,49 @@
+package csv
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// validateAssignees checks every assignee of the issues about to be created against the
+// repository's collaborators, fetched once, and reports all unknown assignees at once.
+// GitHub logins are case-insensitive.
+func validateAssignees(issues []*Issue, skip map[*Issue][]string, owner, repo, token string) error {
+	collaborators, err := listCollaborators(owner, repo, token)
+	if err != nil {
+		return fmt.Errorf("failed to validate assignees: %w", err)
+	}
+	known := make(map[string]bool, len(collaborators))
+	for _, login := range collaborators {
+		known[strings.ToLower(login)] = true
+	}
+
+	unknown := make(map[string][]string) // assignee -> titles of the issues naming it
+	for _, issue := range issues {
+		if _, skipped := skip[issue]; skipped {
+			continue
+		}
+		for _, assignee := range issue.Assignees {
+			if !known[strings.ToLower(assignee)] {
+				unknown[assignee] = append(unknown[assignee], fmt.Sprintf("'%s'", issue.Title))
+			}
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	assignees := make([]string, 0, len(unknown))
+	for assignee := range unknown {
+		assignees = append(assignees, assignee)
+	}
+	sort.Strings(assignees)
+
+	report := make([]string, len(assignees))
+	for i, assignee := range assignees {
+		report[i] = fmt.Sprintf("%s (issues: %s)", assignee, strings.Join(unknown[assignee], ", "))
+	}
+	return fmt.Errorf("%d assignees are not collaborators of %s/%s, nothing was created:\n  %s",
+		len(assignees), owner, repo, strings.Join(report, "\n  "))
+}
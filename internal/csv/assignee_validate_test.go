@@ -0,0 +1,101 @@
+package csv
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rhino11/pivot/internal"
+)
+
+// mockAssigneeImport replaces the GitHub calls of an import and counts the issues created
+func mockAssigneeImport(t *testing.T, collaborators []string) *int {
+	t.Helper()
+	created := 0
+	oldCreate, oldEnsure, oldList := createGitHubIssue, ensureGitHubCredentials, listCollaborators
+	createGitHubIssue = func(owner, repo, token string, req internal.CreateIssueRequest) (*internal.CreateIssueResponse, error) {
+		created++
+		return &internal.CreateIssueResponse{ID: created, Number: created, Title: req.Title}, nil
+	}
+	ensureGitHubCredentials = func(owner, repo, token string) error { return nil }
+	listCollaborators = func(owner, repo, token string) ([]string, error) { return collaborators, nil }
+	t.Cleanup(func() { createGitHubIssue, ensureGitHubCredentials, listCollaborators = oldCreate, oldEnsure, oldList })
+	return &created
+}
+
+func TestImportCSVToGitHub_AssigneeValidateReportsUnknownBeforeCreating(t *testing.T) {
+	created := mockAssigneeImport(t, []string{"alice", "Bob"})
+
+	path := writeTestCSV(t, `title,assignees
+First,"alice,bob"
+Second,"mallory,alice"
+Third,"mallory,trudy"
+`)
+	_, err := ImportCSVToGitHub(path, "owner", "repo", "token", &ImportConfig{ValidateAssignees: true})
+	if err == nil {
+		t.Fatal("Expected unknown assignees to fail the import")
+	}
+	if *created != 0 {
+		t.Errorf("Expected no issues to be created, got %d", *created)
+	}
+
+	message := err.Error()
+	for _, want := range []string{"2 assignees are not collaborators of owner/repo", "mallory (issues: 'Second', 'Third')", "trudy (issues: 'Third')"} {
+		if !strings.Contains(message, want) {
+			t.Errorf("Expected error to contain %q, got: %s", want, message)
+		}
+	}
+	if strings.Contains(message, "bob") {
+		t.Errorf("Expected collaborators to match case-insensitively, got: %s", message)
+	}
+}
+
+func TestImportCSVToGitHub_AssigneeValidatePasses(t *testing.T) {
+	created := mockAssigneeImport(t, []string{"alice", "bob"})
+
+	path := writeTestCSV(t, "title,assignees\nFirst,\"alice,bob\"\nUnassigned,\n")
+	result, err := ImportCSVToGitHub(path, "owner", "repo", "token", &ImportConfig{ValidateAssignees: true})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if *created != 2 || result.Created != 2 {
+		t.Errorf("Expected 2 issues to be created, got %d", *created)
+	}
+}
+
+func TestImportCSVToGitHub_AssigneeValidateOffByDefault(t *testing.T) {
+	created := mockAssigneeImport(t, nil)
+	listCollaborators = func(owner, repo, token string) ([]string, error) {
+		t.Error("Expected collaborators not to be fetched without ValidateAssignees")
+		return nil, nil
+	}
+
+	path := writeTestCSV(t, "title,assignees\nFirst,mallory\n")
+	if _, err := ImportCSVToGitHub(path, "owner", "repo", "token", &ImportConfig{}); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if *created != 1 {
+		t.Errorf("Expected 1 issue to be created, got %d", *created)
+	}
+}
+
+func TestImportCSVFilesByRepository_AssigneeValidateChecksEveryRepository(t *testing.T) {
+	created := mockAssigneeImport(t, nil)
+	listCollaborators = func(owner, repo, token string) ([]string, error) {
+		if repo == "api" {
+			return []string{"alice"}, nil
+		}
+		return []string{"bob"}, nil
+	}
+
+	path := writeTestCSV(t, `title,repo,assignees
+API bug,acme/api,alice
+Web bug,acme/web,alice
+`)
+	_, err := ImportCSVFilesByRepository([]string{path}, staticToken, &ImportConfig{RepositoryColumn: "repo", ValidateAssignees: true})
+	if err == nil || !strings.Contains(err.Error(), "1 assignees are not collaborators of acme/web") {
+		t.Fatalf("Expected the unknown assignee in acme/web to be reported, got: %v", err)
+	}
+	if *created != 0 {
+		t.Errorf("Expected nothing to be created in any repository, got %d", *created)
+	}
+}
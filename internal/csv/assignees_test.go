@@ -0,0 +1,87 @@
+package csv
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rhino11/pivot/internal"
+)
+
+func TestParseCSV_AssigneesColumn(t *testing.T) {
+	path := writeTestCSV(t, `title,assignees,assignee
+Pair work,"alice, bob,carol",
+Legacy column,,dave
+Both columns,"erin,frank",erin
+Unassigned,,
+`)
+
+	issues, err := ParseCSV(path, &ImportConfig{})
+	if err != nil {
+		t.Fatalf("ParseCSV failed: %v", err)
+	}
+
+	expected := [][]string{{"alice", "bob", "carol"}, {"dave"}, {"erin", "frank"}, nil}
+	for i, want := range expected {
+		if !reflect.DeepEqual(issues[i].Assignees, want) {
+			t.Errorf("Issue '%s': expected assignees %v, got %v", issues[i].Title, want, issues[i].Assignees)
+		}
+	}
+}
+
+func TestParseCSV_AssigneeMapAppliesToEachAssignee(t *testing.T) {
+	path := writeTestCSV(t, "title,assignees\nMapped,\"John Doe,asmith,Jane Roe\"\n")
+
+	config := &ImportConfig{AssigneeMap: map[string]string{"John Doe": "jdoe", "Jane Roe": "asmith"}}
+	issues, err := ParseCSV(path, config)
+	if err != nil {
+		t.Fatalf("ParseCSV failed: %v", err)
+	}
+	if expected := []string{"jdoe", "asmith"}; !reflect.DeepEqual(issues[0].Assignees, expected) {
+		t.Errorf("Expected mapped and deduplicated assignees %v, got %v", expected, issues[0].Assignees)
+	}
+}
+
+func TestImportCSVToGitHub_SendsAllAssignees(t *testing.T) {
+	var sent [][]string
+	oldCreate, oldEnsure := createGitHubIssue, ensureGitHubCredentials
+	createGitHubIssue = func(owner, repo, token string, req internal.CreateIssueRequest) (*internal.CreateIssueResponse, error) {
+		sent = append(sent, req.Assignees)
+		return &internal.CreateIssueResponse{ID: len(sent), Number: len(sent), Title: req.Title}, nil
+	}
+	ensureGitHubCredentials = func(owner, repo, token string) error { return nil }
+	t.Cleanup(func() { createGitHubIssue, ensureGitHubCredentials = oldCreate, oldEnsure })
+
+	path := writeTestCSV(t, "title,assignees\nTeam issue,\"alice,bob,carol\"\n")
+	if _, err := ImportCSVToGitHub(path, "owner", "repo", "token", &ImportConfig{}); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if len(sent) != 1 || !reflect.DeepEqual(sent[0], []string{"alice", "bob", "carol"}) {
+		t.Errorf("Expected all three assignees to be sent, got %v", sent)
+	}
+}
+
+func TestAssigneesRoundTrip(t *testing.T) {
+	issue := FromDBIssue(internal.DBIssue{Title: "Stored", Assignees: "alice,bob"})
+	if !reflect.DeepEqual(issue.Assignees, []string{"alice", "bob"}) {
+		t.Errorf("Expected assignees from the database, got %v", issue.Assignees)
+	}
+	if value := getIssueFieldValue(issue, "assignees"); value != "alice,bob" {
+		t.Errorf("Expected exported assignees 'alice,bob', got '%s'", value)
+	}
+	if value := getIssueFieldValue(issue, "assignee"); value != "alice,bob" {
+		t.Errorf("Expected the assignee column to list all assignees, got '%s'", value)
+	}
+
+	anonymizer, err := internal.NewAnonymizer(nil)
+	if err != nil {
+		t.Fatalf("NewAnonymizer failed: %v", err)
+	}
+	anonymized := AnonymizeIssues([]*Issue{issue}, anonymizer)
+	if len(anonymized[0].Assignees) != 2 || strings.Contains(strings.Join(anonymized[0].Assignees, ","), "alice") {
+		t.Errorf("Expected each assignee to be pseudonymized, got %v", anonymized[0].Assignees)
+	}
+	if anonymized[0].Assignees[0] == anonymized[0].Assignees[1] {
+		t.Errorf("Expected distinct pseudonyms, got %v", anonymized[0].Assignees)
+	}
+}
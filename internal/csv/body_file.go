@@ -0,0 +1,60 @@
+package csv
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// rowBodyFile reads the body of a row from the file named in config.BodyFileColumn.
+// Paths are relative to baseDir, the directory of the CSV file, and may not leave it,
+// so a CSV cannot publish arbitrary local files. The file is decoded with the
+// encoding of the CSV. A blank value keeps the body read from the body column.
+func rowBodyFile(record []string, headerIndex map[string]int, lineNum int, baseDir string, config *ImportConfig) (string, bool, *CSVRowError) {
+	field := strings.ToLower(strings.TrimSpace(config.BodyFileColumn))
+	idx := headerIndex[field]
+
+	var value string
+	if idx < len(record) {
+		value = strings.TrimSpace(record[idx])
+	}
+	if value == "" {
+		return "", false, nil
+	}
+
+	body, err := readBodyFile(baseDir, value, config.Encoding)
+	if err != nil {
+		return "", false, &CSVRowError{Line: lineNum, Column: idx + 1, Field: field, Cause: err}
+	}
+	return body, true, nil
+}
+
+// readBodyFile reads and decodes the body file named value inside baseDir
+func readBodyFile(baseDir, value, encodingName string) (string, error) {
+	if filepath.IsAbs(value) || strings.HasPrefix(value, "/") {
+		return "", fmt.Errorf("body file '%s' must be a path relative to the CSV file", value)
+	}
+	if !filepath.IsLocal(value) {
+		return "", fmt.Errorf("body file '%s' is outside the directory of the CSV file", value)
+	}
+
+	encoding, err := ParseEncoding(encodingName)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(filepath.Join(baseDir, value)) // #nosec G304 - Confined to the CSV file's directory
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", fmt.Errorf("body file '%s' not found", value)
+		}
+		return "", fmt.Errorf("failed to read body file '%s': %w", value, err)
+	}
+	decoded, err := decodeCSVData(data, encoding)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode body file '%s': %w", value, err)
+	}
+	return strings.TrimSpace(string(decoded)), nil
+}
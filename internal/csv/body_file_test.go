@@ -0,0 +1,105 @@
+package csv
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseCSV_BodyFileColumn(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "bodies"), 0755); err != nil {
+		t.Fatalf("Failed to create bodies dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "bodies", "login.md"), []byte("## Steps\n\n1. Open the login page\n"), 0644); err != nil {
+		t.Fatalf("Failed to write body file: %v", err)
+	}
+	path := writeTestCSVIn(t, dir, "issues.csv", `title,body,body_file
+Login fails,ignored,bodies/login.md
+Inline body,Kept as is,
+`)
+
+	issues, err := ParseCSV(path, &ImportConfig{BodyFileColumn: "Body_File"})
+	if err != nil {
+		t.Fatalf("ParseCSV failed: %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d", len(issues))
+	}
+	if issues[0].Body != "## Steps\n\n1. Open the login page" {
+		t.Errorf("Expected the body to be read from the file, got %q", issues[0].Body)
+	}
+	if issues[1].Body != "Kept as is" {
+		t.Errorf("Expected a blank body file to keep the body column, got %q", issues[1].Body)
+	}
+}
+
+func TestParseCSV_BodyFileColumnErrors(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTestCSVIn(t, dir, "issues.csv", `title,body_file
+First,
+Second,missing.md
+`)
+
+	_, err := ParseCSV(path, &ImportConfig{BodyFileColumn: "body_file"})
+	if err == nil || !strings.Contains(err.Error(), "line 3, column 2 (body_file): body file 'missing.md' not found") {
+		t.Errorf("Expected a missing body file error naming the row, got %v", err)
+	}
+
+	_, err = ParseCSV(path, &ImportConfig{BodyFileColumn: "description_file"})
+	if err == nil || !strings.Contains(err.Error(), "body file column 'description_file' not found in CSV headers") {
+		t.Errorf("Expected a missing column error, got %v", err)
+	}
+}
+
+func TestParseCSV_BodyFileOutsideCSVDirectory(t *testing.T) {
+	root := t.TempDir()
+	secret := filepath.Join(root, "secret.txt")
+	if err := os.WriteFile(secret, []byte("private key"), 0600); err != nil {
+		t.Fatalf("Failed to write secret file: %v", err)
+	}
+	dir := filepath.Join(root, "backlog")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("Failed to create CSV dir: %v", err)
+	}
+
+	for value, message := range map[string]string{
+		secret:                    "must be a path relative to the CSV file",
+		"../secret.txt":           "is outside the directory of the CSV file",
+		"bodies/../../secret.txt": "is outside the directory of the CSV file",
+	} {
+		path := writeTestCSVIn(t, dir, "issues.csv", "title,body_file\nLeak,"+value+"\n")
+		issues, err := ParseCSV(path, &ImportConfig{BodyFileColumn: "body_file"})
+		if err == nil || !strings.Contains(err.Error(), "line 2, column 2 (body_file): body file '"+value+"' "+message) {
+			t.Errorf("Expected %q to be rejected with %q, got %v", value, message, err)
+		}
+		for _, issue := range issues {
+			if strings.Contains(issue.Body, "private key") {
+				t.Errorf("Expected %q not to be read, got body %q", value, issue.Body)
+			}
+		}
+	}
+}
+
+func TestParseCSV_BodyFileUsesCSVEncoding(t *testing.T) {
+	dir := t.TempDir()
+	// "Café – résumé" in Windows-1252
+	if err := os.WriteFile(filepath.Join(dir, "body.txt"), []byte("Caf\xe9 \x96 r\xe9sum\xe9"), 0644); err != nil {
+		t.Fatalf("Failed to write body file: %v", err)
+	}
+	path := writeTestCSVIn(t, dir, "issues.csv", "title,body_file\nMenu,body.txt\n")
+
+	issues, err := ParseCSV(path, &ImportConfig{BodyFileColumn: "body_file", Encoding: "windows-1252"})
+	if err != nil {
+		t.Fatalf("ParseCSV failed: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Body != "Café – résumé" {
+		t.Errorf("Expected the body file to be decoded as windows-1252, got %+v", issues)
+	}
+
+	_, err = ParseCSV(path, &ImportConfig{BodyFileColumn: "body_file"})
+	if err == nil || !strings.Contains(err.Error(), "failed to decode body file 'body.txt'") {
+		t.Errorf("Expected invalid UTF-8 in the body file to be reported, got %v", err)
+	}
+}
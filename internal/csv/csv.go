@@ -1,10 +1,14 @@
 package csv
 
 import (
+	"bytes"
+	"context"
+	"database/sql"
 	"encoding/csv"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -19,7 +23,7 @@ type Issue struct {
 	State              string    `csv:"state"`
 	Priority           string    `csv:"priority"`
 	Labels             []string  `csv:"labels"`
-	Assignee           string    `csv:"assignee"`
+	Assignees          []string  `csv:"assignees"` // Also read from the singular assignee column
 	Milestone          string    `csv:"milestone"`
 	CreatedAt          time.Time `csv:"created_at"`
 	UpdatedAt          time.Time `csv:"updated_at"`
@@ -29,15 +33,45 @@ type Issue struct {
 	Epic               string    `csv:"epic"`
 	Dependencies       []int     `csv:"dependencies"`
 	AcceptanceCriteria string    `csv:"acceptance_criteria"`
+	ExternalID         string    `csv:"external_id"`
+	StateReason        string    `csv:"state_reason"`
+	ClosedAt           time.Time `csv:"closed_at"`
+	Type               string    `csv:"type"` // GitHub issue type, e.g. Bug
+
+	Warnings   []string `csv:"-"` // Problems fixed up while parsing, such as an unknown state
+	SourceFile string   `csv:"-"` // Base name of the CSV file the issue was parsed from
+	Repository string   `csv:"-"` // owner/repo the row targets, read from ImportConfig.RepositoryColumn; set to the issue's project on export
 }
 
+// StateReasons lists the values GitHub accepts for an issue's state_reason
+var StateReasons = []string{"completed", "not_planned", "duplicate", "reopened"}
+
 // ImportConfig holds configuration for CSV import
 type ImportConfig struct {
 	FilePath       string
 	Repository     string
 	DryRun         bool
 	SkipDuplicates bool
-	Mapping        map[string]string
+	Mapping        map[string]string // CSV column -> issue field
+	Defaults       map[string]string // Issue field -> value used when blank or missing
+	AssigneeMap    map[string]string // CSV assignee -> GitHub login, applied to each assignee
+	Validation     internal.PushValidationRules
+	OnViolation    string            // internal.ViolationSkip or internal.ViolationAbort (default)
+	OnError        string            // OnErrorContinue (default) or OnErrorAbort
+	Encoding       string            // File encoding, see ParseEncoding (default UTF-8)
+	DedupBy        string            // DedupByTitle (default) or DedupByExternalID, for multi-file imports
+	StateMap       map[string]string // Lowercase state synonym -> open or closed
+	DefaultState   string            // State for blank or unknown values (default open)
+	Delay          time.Duration     // Pause between issue creations, plus up to 50% jitter (0 = none)
+	Context        context.Context   // Cancels the import, including a pending delay (nil = never)
+	AddFooter      bool              // Append an "imported by" footer to the bodies sent to GitHub
+	Version        string            // pivot version named in the footer
+
+	ValidateAssignees bool                        // Check all assignees against the repository collaborators before creating anything
+	TitleMatch        internal.TitleNormalization // How titles are compared when deduplicating by title
+	DB                *sql.DB                     // Local database the created issues and dependencies are recorded in (nil = none)
+	RepositoryColumn  string                      // Column holding each row's owner/repo; blank rows fall back to Repository (empty = off)
+	BodyFileColumn    string                      // Column holding a file, relative to the CSV, each row's body is read from (empty = off)
 }
 
 // ExportConfig holds configuration for CSV export
@@ -48,14 +82,20 @@ type ExportConfig struct {
 	Filter     string
 }
 
-// ImportResult contains the results of a CSV import operation
+// ImportResult contains the results of a CSV import operation. Every row is counted
+// in exactly one of Created, Updated, Skipped or Errors, so Total == Processed().
 type ImportResult struct {
-	Total      int
-	Created    int
-	Skipped    int
-	Errors     []string
-	Issues     []*Issue
-	Duplicates []*Issue
+	Total       int
+	Created     int
+	Updated     int
+	Skipped     int
+	OnError     string   // Error mode the import ran with
+	Aborted     bool     // Import stopped at the first create failure or was cancelled
+	Errors      []string // One entry per row GitHub rejected
+	SkipReasons []string // Why rows were skipped; dry-run skips have no reason
+	Issues      []*Issue
+	Duplicates  []*Issue // Cross-file duplicates, counted as skipped
+	Warnings    []string // Problems recording the created issues locally, such as unresolved dependencies
 }
 
 // ExportResult contains the results of a CSV export operation
@@ -67,43 +107,22 @@ type ExportResult struct {
 
 // ValidateCSV validates a CSV file and returns parsing errors
 func ValidateCSV(filePath string) error {
-	file, err := os.Open(filePath) // #nosec G304 - File path is validated and user-controlled
+	return ValidateCSVWithConfig(filePath, nil)
+}
+
+// ValidateCSVWithConfig validates a CSV file, taking column mappings and defaults into account
+func ValidateCSVWithConfig(filePath string, config *ImportConfig) error {
+	data, err := readCSVFile(filePath, config)
 	if err != nil {
-		return fmt.Errorf("failed to open CSV file: %w", err)
+		return err
 	}
-	defer file.Close()
 
 	// Check for empty file
-	fileInfo, err := file.Stat()
-	if err != nil {
-		return fmt.Errorf("failed to get file info: %w", err)
-	}
-	if fileInfo.Size() == 0 {
+	if len(data) == 0 {
 		return fmt.Errorf("CSV file is empty")
 	}
 
-	// Read a few bytes to check for UTF-8 BOM
-	buf := make([]byte, 3)
-	n, err := file.Read(buf)
-	if err != nil && err != io.EOF {
-		return fmt.Errorf("failed to read file beginning: %w", err)
-	}
-
-	// Reset file position
-	_, err = file.Seek(0, 0)
-	if err != nil {
-		return fmt.Errorf("failed to reset file position: %w", err)
-	}
-
-	// Skip UTF-8 BOM if present
-	if n >= 3 && buf[0] == 0xEF && buf[1] == 0xBB && buf[2] == 0xBF {
-		_, err = file.Seek(3, 0)
-		if err != nil {
-			return fmt.Errorf("failed to skip BOM: %w", err)
-		}
-	}
-
-	reader := csv.NewReader(file)
+	reader := csv.NewReader(bytes.NewReader(data))
 	reader.FieldsPerRecord = -1 // Allow variable number of fields initially
 
 	// Read header
@@ -127,16 +146,10 @@ func ValidateCSV(filePath string) error {
 
 	// Validate required columns
 	requiredColumns := []string{"title"}
-	headerMap := make(map[string]bool)
-	for _, header := range headers {
-		cleanHeader := strings.ToLower(strings.TrimSpace(header))
-		if cleanHeader != "" {
-			headerMap[cleanHeader] = true
-		}
-	}
+	headerMap := resolveHeaderIndex(headers, config)
 
 	for _, required := range requiredColumns {
-		if !headerMap[required] {
+		if _, exists := headerMap[required]; !exists {
 			return fmt.Errorf("required column '%s' not found in CSV headers: %v", required, headers)
 		}
 	}
@@ -144,7 +157,8 @@ func ValidateCSV(filePath string) error {
 	// Set expected field count for remaining validation
 	reader.FieldsPerRecord = len(headers)
 
-	// Validate each row
+	// Validate each row, collecting every bad row up to MaxRowErrors
+	var rowErrs CSVRowErrors
 	lineNum := 2 // Start from line 2 (after header)
 	rowCount := 0
 	for {
@@ -153,12 +167,7 @@ func ValidateCSV(filePath string) error {
 			break
 		}
 		if err != nil {
-			return fmt.Errorf("error reading CSV line %d: %w", lineNum, err)
-		}
-
-		if len(record) != len(headers) {
-			return fmt.Errorf("line %d: column count mismatch (expected %d, got %d)",
-				lineNum, len(headers), len(record))
+			rowErrs.add(readRowError(err, lineNum, record, len(headers)))
 		}
 
 		rowCount++
@@ -169,39 +178,17 @@ func ValidateCSV(filePath string) error {
 		return fmt.Errorf("CSV file contains no data rows (header-only)")
 	}
 
-	return nil
+	return rowErrs.err()
 }
 
 // ParseCSV reads and parses a CSV file into Issue structs
 func ParseCSV(filePath string, config *ImportConfig) ([]*Issue, error) {
-	file, err := os.Open(filePath) // #nosec G304 - File path is validated and user-controlled
+	data, err := readCSVFile(filePath, config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open CSV file: %w", err)
-	}
-	defer file.Close()
-
-	// Check for UTF-8 BOM and skip it
-	buf := make([]byte, 3)
-	n, err := file.Read(buf)
-	if err != nil && err != io.EOF {
-		return nil, fmt.Errorf("failed to read file beginning: %w", err)
-	}
-
-	// Reset file position
-	_, err = file.Seek(0, 0)
-	if err != nil {
-		return nil, fmt.Errorf("failed to reset file position: %w", err)
-	}
-
-	// Skip UTF-8 BOM if present
-	if n >= 3 && buf[0] == 0xEF && buf[1] == 0xBB && buf[2] == 0xBF {
-		_, err = file.Seek(3, 0)
-		if err != nil {
-			return nil, fmt.Errorf("failed to skip BOM: %w", err)
-		}
+		return nil, err
 	}
 
-	reader := csv.NewReader(file)
+	reader := csv.NewReader(bytes.NewReader(data))
 
 	// Read header
 	headers, err := reader.Read()
@@ -218,22 +205,27 @@ func ParseCSV(filePath string, config *ImportConfig) ([]*Issue, error) {
 		headers[0] = strings.TrimSpace(headers[0])
 	}
 
-	// Create header index map
-	headerIndex := make(map[string]int)
-	for i, header := range headers {
-		cleanHeader := strings.ToLower(strings.TrimSpace(header))
-		if cleanHeader != "" {
-			headerIndex[cleanHeader] = i
-		}
-	}
+	// Create header index map, applying any column mappings
+	headerIndex := resolveHeaderIndex(headers, config)
 
 	// Validate required columns
 	if _, exists := headerIndex["title"]; !exists {
 		return nil, fmt.Errorf("required column 'title' not found in CSV headers: %v", headers)
 	}
+	if config != nil && config.RepositoryColumn != "" {
+		if _, exists := headerIndex[strings.ToLower(strings.TrimSpace(config.RepositoryColumn))]; !exists {
+			return nil, fmt.Errorf("repository column '%s' not found in CSV headers: %v", config.RepositoryColumn, headers)
+		}
+	}
+	if config != nil && config.BodyFileColumn != "" {
+		if _, exists := headerIndex[strings.ToLower(strings.TrimSpace(config.BodyFileColumn))]; !exists {
+			return nil, fmt.Errorf("body file column '%s' not found in CSV headers: %v", config.BodyFileColumn, headers)
+		}
+	}
 
 	var issues []*Issue
-	lineNum := 2 // Start from line 2 (after header)
+	var rowErrs CSVRowErrors
+	lineNum := 1
 
 	for {
 		record, err := reader.Read()
@@ -241,25 +233,85 @@ func ParseCSV(filePath string, config *ImportConfig) ([]*Issue, error) {
 			break
 		}
 		if err != nil {
-			return nil, fmt.Errorf("error reading CSV line %d: %w", lineNum, err)
+			rowErrs.add(readRowError(err, lineNum+1, record, len(headers)))
+			lineNum++
+			continue
 		}
+		// Quoted fields can span lines, so ask the reader where the row starts
+		lineNum, _ = reader.FieldPos(0)
 
-		issue, err := parseIssueFromRecord(record, headerIndex, lineNum)
-		if err != nil {
-			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		if config != nil {
+			record = applyDefaults(record, headerIndex, config.Defaults)
+		}
+
+		issue, rowErr := parseIssueFromRecord(record, headerIndex, lineNum)
+		if rowErr != nil {
+			rowErrs.add(rowErr)
+			continue
+		}
+
+		var rawState string
+		if idx, exists := headerIndex["state"]; exists && idx < len(record) {
+			rawState = record[idx]
+		}
+		state, warning := normalizeState(rawState, config)
+		issue.State = state
+		issue.SourceFile = filepath.Base(filePath)
+		if warning != "" {
+			issue.Warnings = append(issue.Warnings, fmt.Sprintf("line %d: %s", lineNum, warning))
+		}
+
+		if config != nil && config.RepositoryColumn != "" {
+			repository, rowErr := rowRepository(record, headerIndex, lineNum, config)
+			if rowErr != nil {
+				rowErrs.add(rowErr)
+				continue
+			}
+			issue.Repository = repository
+		}
+
+		if config != nil && config.BodyFileColumn != "" {
+			body, ok, rowErr := rowBodyFile(record, headerIndex, lineNum, filepath.Dir(filePath), config)
+			if rowErr != nil {
+				rowErrs.add(rowErr)
+				continue
+			}
+			if ok {
+				issue.Body = body
+			}
+		}
+
+		if config != nil && len(config.AssigneeMap) > 0 {
+			for i, assignee := range issue.Assignees {
+				if login, ok := config.AssigneeMap[assignee]; ok {
+					issue.Assignees[i] = login
+				}
+			}
+			issue.Assignees = internal.DedupeList(issue.Assignees)
 		}
 
 		issues = append(issues, issue)
-		lineNum++
 	}
 
+	if err := rowErrs.err(); err != nil {
+		return nil, err
+	}
 	return issues, nil
 }
 
 // parseIssueFromRecord converts a CSV record to an Issue struct
-func parseIssueFromRecord(record []string, headerIndex map[string]int, lineNum int) (*Issue, error) {
+func parseIssueFromRecord(record []string, headerIndex map[string]int, lineNum int) (*Issue, *CSVRowError) {
 	issue := &Issue{}
 
+	// Helper function to report a problem with a field's column
+	fieldError := func(fieldName string, cause error) *CSVRowError {
+		column := 0
+		if idx, exists := headerIndex[fieldName]; exists {
+			column = idx + 1
+		}
+		return &CSVRowError{Line: lineNum, Column: column, Field: fieldName, Cause: cause}
+	}
+
 	// Helper function to safely get field value
 	getField := func(fieldName string) string {
 		if idx, exists := headerIndex[fieldName]; exists && idx < len(record) {
@@ -271,7 +323,7 @@ func parseIssueFromRecord(record []string, headerIndex map[string]int, lineNum i
 	// Parse required fields
 	issue.Title = getField("title")
 	if issue.Title == "" {
-		return nil, fmt.Errorf("title is required")
+		return nil, fieldError("title", fmt.Errorf("title is required"))
 	}
 
 	// Parse optional fields
@@ -281,19 +333,28 @@ func parseIssueFromRecord(record []string, headerIndex map[string]int, lineNum i
 	}
 
 	issue.Priority = getField("priority")
-	issue.Assignee = getField("assignee")
+	// The assignees column holds a comma-separated list; the older singular assignee
+	// column is still read and may hold a list too
+	assignees := strings.Split(getField("assignee"), ",")
+	assignees = append(assignees, strings.Split(getField("assignees"), ",")...)
+	issue.Assignees = internal.DedupeList(assignees)
 	issue.Milestone = getField("milestone")
 	issue.Body = getField("body")
 	issue.Epic = getField("epic")
 	issue.AcceptanceCriteria = getField("acceptance_criteria")
+	issue.ExternalID = getField("external_id")
+	issue.Type = getField("type")
 
-	// Parse labels (comma-separated)
-	if labelsStr := getField("labels"); labelsStr != "" {
-		labels := strings.Split(labelsStr, ",")
-		for i, label := range labels {
-			labels[i] = strings.TrimSpace(label)
+	if reason := strings.ToLower(getField("state_reason")); reason != "" {
+		if !isValidStateReason(reason) {
+			return nil, fieldError("state_reason", fmt.Errorf("invalid state_reason '%s' (valid: %s)", reason, strings.Join(StateReasons, ", ")))
 		}
-		issue.Labels = labels
+		issue.StateReason = reason
+	}
+
+	// Parse labels (comma-separated, duplicates dropped case-insensitively)
+	if labelsStr := getField("labels"); labelsStr != "" {
+		issue.Labels = internal.DedupeList(strings.Split(labelsStr, ","))
 	}
 
 	// Parse numeric fields
@@ -340,11 +401,33 @@ func parseIssueFromRecord(record []string, headerIndex map[string]int, lineNum i
 		}
 	}
 
+	if closedStr := getField("closed_at"); closedStr != "" {
+		if closed, err := time.Parse(time.RFC3339, closedStr); err == nil {
+			issue.ClosedAt = closed
+		}
+	}
+
 	return issue, nil
 }
 
+// isValidStateReason reports whether reason is one of StateReasons
+func isValidStateReason(reason string) bool {
+	for _, valid := range StateReasons {
+		if reason == valid {
+			return true
+		}
+	}
+	return false
+}
+
 // WriteCSV exports issues to a CSV file
 func WriteCSV(issues []*Issue, filePath string, config *ExportConfig) error {
+	// Reject unknown fields before creating the file
+	fields, err := ResolveExportFields(config.Fields)
+	if err != nil {
+		return err
+	}
+
 	file, err := os.Create(filePath) // #nosec G304 - File path is validated and user-controlled
 	if err != nil {
 		return fmt.Errorf("failed to create CSV file: %w", err)
@@ -358,12 +441,12 @@ func WriteCSV(issues []*Issue, filePath string, config *ExportConfig) error {
 	columns := []string{
 		"id", "title", "state", "priority", "labels", "assignee", "milestone",
 		"created_at", "updated_at", "body", "estimated_hours", "story_points",
-		"epic", "dependencies", "acceptance_criteria",
+		"epic", "dependencies", "acceptance_criteria", "state_reason", "closed_at", "type",
 	}
 
 	// Use custom fields if specified
-	if len(config.Fields) > 0 {
-		columns = config.Fields
+	if len(fields) > 0 {
+		columns = fields
 	}
 
 	// Write header
@@ -401,8 +484,8 @@ func getIssueFieldValue(issue *Issue, fieldName string) string {
 		return issue.Priority
 	case "labels":
 		return strings.Join(issue.Labels, ",")
-	case "assignee":
-		return issue.Assignee
+	case "assignee", "assignees":
+		return strings.Join(issue.Assignees, ",")
 	case "milestone":
 		return issue.Milestone
 	case "created_at":
@@ -440,12 +523,24 @@ func getIssueFieldValue(issue *Issue, fieldName string) string {
 		return strings.Join(deps, ",")
 	case "acceptance_criteria":
 		return issue.AcceptanceCriteria
+	case "external_id":
+		return issue.ExternalID
+	case "type":
+		return issue.Type
+	case "state_reason":
+		return issue.StateReason
+	case "closed_at":
+		if issue.ClosedAt.IsZero() {
+			return ""
+		}
+		return issue.ClosedAt.Format(time.RFC3339)
 	default:
 		return ""
 	}
 }
 
-// ImportCSVToGitHub imports issues from CSV to GitHub repository
+// ImportCSVToGitHub imports issues from CSV to GitHub repository. When config.OnError
+// is OnErrorAbort, the partial result is returned together with the first create error.
 func ImportCSVToGitHub(filePath, owner, repo, token string, config *ImportConfig) (*ImportResult, error) {
 	// Parse CSV first
 	issues, err := ParseCSV(filePath, config)
@@ -453,71 +548,252 @@ func ImportCSVToGitHub(filePath, owner, repo, token string, config *ImportConfig
 		return nil, fmt.Errorf("failed to parse CSV: %w", err)
 	}
 
+	return importIssues(issues, nil, owner, repo, token, config)
+}
+
+// ImportCSVFilesToGitHub validates and merges several CSV files (see ParseCSVFiles) and
+// imports them as one batch. Cross-file duplicates are skipped and reported in the result.
+func ImportCSVFilesToGitHub(filePaths []string, owner, repo, token string, config *ImportConfig) (*ImportResult, error) {
+	issues, duplicates, err := ParseCSVFiles(filePaths, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV: %w", err)
+	}
+
+	return importIssues(issues, duplicates, owner, repo, token, config)
+}
+
+// importIssues creates the parsed issues on GitHub; duplicates are counted as skipped
+func importIssues(issues, duplicates []*Issue, owner, repo, token string, config *ImportConfig) (*ImportResult, error) {
+	// Enforce push validation rules before anything is created
+	violations, err := validateForPush(issues, config)
+	if err != nil {
+		return nil, err
+	}
+
+	onError, err := ParseOnErrorMode(config.OnError)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reject requests GitHub would refuse before anything is created
+	if err := validateRequests(issues); err != nil {
+		return nil, err
+	}
+
 	// Validate GitHub credentials before attempting import (unless in dry-run mode)
 	if !config.DryRun {
-		if err := internal.EnsureGitHubCredentials(owner, repo, token); err != nil {
+		if err := ensureGitHubCredentials(owner, repo, token); err != nil {
 			return nil, fmt.Errorf("GitHub credential validation failed: %w", err)
 		}
+		if config.ValidateAssignees {
+			if err := validateAssignees(issues, violations, owner, repo, token); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	result := &ImportResult{
-		Total:  len(issues),
-		Issues: issues,
-		Errors: []string{},
+		Total:      len(issues) + len(duplicates),
+		OnError:    onError,
+		Issues:     issues,
+		Errors:     []string{},
+		Duplicates: duplicates,
+	}
+
+	for _, dup := range duplicates {
+		result.record(outcomeSkipped, fmt.Sprintf("Skipped duplicate issue '%s'", dup.Title))
+	}
+
+	ctx := config.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	importedAt := importNow()
+
+	// Record whatever was created, also when the import stops early
+	var imported []importedIssue
+	if config.DB != nil {
+		defer func() {
+			warnings, err := recordImportedIssues(config.DB, owner, repo, imported)
+			result.Warnings = append(result.Warnings, warnings...)
+			if err != nil {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("failed to record the created issues in the local database: %v", err))
+			}
+		}()
 	}
 
 	// Import each issue to GitHub
+	attempted := 0
 	for _, issue := range issues {
-		if config.DryRun {
-			result.Skipped++
+		if problems, failed := violations[issue]; failed {
+			result.record(outcomeSkipped, fmt.Sprintf("Skipped issue '%s': %s", issue.Title, strings.Join(problems, "; ")))
 			continue
 		}
 
-		// Convert CSV issue to GitHub issue request
-		githubRequest := internal.CreateIssueRequest{
-			Title: issue.Title,
-			Body:  issue.Body,
+		if config.DryRun {
+			result.record(outcomeSkipped, "")
+			continue
 		}
 
-		// Add labels if present
-		if len(issue.Labels) > 0 {
-			githubRequest.Labels = issue.Labels
+		// Pace create calls to stay clear of secondary rate limits; a cancelled
+		// context stops the import before the next create
+		delay := config.Delay
+		if attempted == 0 {
+			delay = 0
 		}
+		if err := pace(ctx, delay); err != nil {
+			result.Aborted = true
+			result.skipRemaining()
+			return result, fmt.Errorf("import cancelled after creating %d of %d issues: %w", result.Created, result.Total, err)
+		}
+		attempted++
 
-		// Add assignee if present
-		if issue.Assignee != "" {
-			githubRequest.Assignees = []string{issue.Assignee}
+		// Convert CSV issue to GitHub issue request
+		githubRequest := convertToGitHubIssue(issue)
+		if config.AddFooter {
+			// Only the remote body gets the footer; issue.Body stays canonical
+			githubRequest.Body = appendImportFooter(githubRequest.Body, importFooter(issue.SourceFile, config.Version, importedAt))
 		}
 
 		// Create the issue on GitHub
-		response, err := internal.CreateIssue(owner, repo, token, githubRequest)
+		response, err := createGitHubIssue(owner, repo, token, githubRequest)
 		if err != nil {
-			result.Errors = append(result.Errors, fmt.Sprintf("Failed to create issue '%s': %v", issue.Title, err))
+			result.record(outcomeFailed, fmt.Sprintf("Failed to create issue '%s': %v", issue.Title, err))
+			if onError == OnErrorAbort {
+				result.Aborted = true
+				result.skipRemaining()
+				return result, fmt.Errorf("import aborted after creating %d of %d issues: failed to create issue '%s': %w",
+					result.Created, result.Total, issue.Title, err)
+			}
 			continue
 		}
 
+		imported = append(imported, importedIssue{csvID: issue.ID, issue: issue, response: response})
+
 		// Update the issue with GitHub data
 		issue.ID = response.ID
-		result.Created++
+		result.record(outcomeCreated, "")
 	}
 
 	return result, nil
 }
 
-// convertToGitHubIssue converts a CSV Issue to a GitHub CreateIssueRequest format
-func convertToGitHubIssue(issue *Issue) map[string]interface{} {
-	request := map[string]interface{}{
-		"title": issue.Title,
-		"body":  issue.Body,
+// validateForPush checks issues against the configured push validation rules. In abort
+// mode any violation is returned as an error; in skip mode the violations are returned per issue.
+func validateForPush(issues []*Issue, config *ImportConfig) (map[*Issue][]string, error) {
+	violations := make(map[*Issue][]string)
+	if config.Validation.IsEmpty() {
+		return violations, nil
+	}
+
+	mode, err := internal.ParseViolationMode(config.OnViolation)
+	if err != nil {
+		return nil, err
+	}
+
+	var report []string
+	for _, issue := range issues {
+		problems, err := config.Validation.Validate(convertToGitHubIssue(issue))
+		if err != nil {
+			return nil, err
+		}
+		if len(problems) > 0 {
+			violations[issue] = problems
+			report = append(report, fmt.Sprintf("'%s': %s", issue.Title, strings.Join(problems, "; ")))
+		}
+	}
+
+	if mode == internal.ViolationAbort && len(report) > 0 {
+		return nil, fmt.Errorf("%d issues failed push validation, nothing was created:\n  %s",
+			len(report), strings.Join(report, "\n  "))
+	}
+
+	return violations, nil
+}
+
+// validateRequests checks every issue's GitHub request and reports all invalid issues at once
+func validateRequests(issues []*Issue) error {
+	var report []string
+	for i, issue := range issues {
+		if err := convertToGitHubIssue(issue).Validate(); err != nil {
+			report = append(report, fmt.Sprintf("issue %d ('%s'): %v", i+1, issue.Title, err))
+		}
+	}
+	if len(report) > 0 {
+		return fmt.Errorf("%d issues are invalid, nothing was created:\n  %s", len(report), strings.Join(report, "\n  "))
+	}
+	return nil
+}
+
+// convertToGitHubIssue converts a CSV Issue to a GitHub CreateIssueRequest
+func convertToGitHubIssue(issue *Issue) internal.CreateIssueRequest {
+	return internal.ToCreateRequest(toDBIssue(issue))
+}
+
+// toDBIssue converts a CSV Issue to the database issue format
+func toDBIssue(issue *Issue) *internal.DBIssue {
+	dbIssue := &internal.DBIssue{
+		ID:        issue.ID,
+		Title:     issue.Title,
+		Body:      issue.Body,
+		State:     issue.State,
+		Labels:    strings.Join(issue.Labels, ","),
+		Assignees: strings.Join(issue.Assignees, ","),
+		Milestone: issue.Milestone,
+
+		StoryPoints:        issue.StoryPoints,
+		EstimatedHours:     issue.EstimatedHours,
+		Epic:               issue.Epic,
+		AcceptanceCriteria: issue.AcceptanceCriteria,
+		Type:               issue.Type,
+		Dependencies:       internal.FormatDependencies(issue.Dependencies),
+	}
+
+	if !issue.CreatedAt.IsZero() {
+		dbIssue.CreatedAt = issue.CreatedAt.Format(time.RFC3339)
+	}
+	if !issue.UpdatedAt.IsZero() {
+		dbIssue.UpdatedAt = issue.UpdatedAt.Format(time.RFC3339)
+	}
+	if !issue.ClosedAt.IsZero() {
+		dbIssue.ClosedAt = issue.ClosedAt.Format(time.RFC3339)
 	}
 
-	if len(issue.Labels) > 0 {
-		request["labels"] = issue.Labels
+	return dbIssue
+}
+
+// FromDBIssue converts a database issue to the CSV issue format
+func FromDBIssue(dbIssue internal.DBIssue) *Issue {
+	issue := &Issue{
+		ID:                 dbIssue.Number,
+		Title:              dbIssue.Title,
+		State:              dbIssue.State,
+		Milestone:          dbIssue.Milestone,
+		Body:               dbIssue.Body,
+		StoryPoints:        dbIssue.StoryPoints,
+		EstimatedHours:     dbIssue.EstimatedHours,
+		Epic:               dbIssue.Epic,
+		AcceptanceCriteria: dbIssue.AcceptanceCriteria,
+		Type:               dbIssue.Type,
+		Dependencies:       internal.ParseDependencies(dbIssue.Dependencies),
 	}
 
-	if issue.Assignee != "" {
-		request["assignees"] = []string{issue.Assignee}
+	if dbIssue.Labels != "" {
+		issue.Labels = strings.Split(dbIssue.Labels, ",")
+	}
+	if dbIssue.Assignees != "" {
+		issue.Assignees = strings.Split(dbIssue.Assignees, ",")
+	}
+	if createdAt, err := time.Parse(time.RFC3339, dbIssue.CreatedAt); err == nil {
+		issue.CreatedAt = createdAt
+	}
+	if updatedAt, err := time.Parse(time.RFC3339, dbIssue.UpdatedAt); err == nil {
+		issue.UpdatedAt = updatedAt
+	}
+	if closedAt, err := time.Parse(time.RFC3339, dbIssue.ClosedAt); err == nil {
+		issue.ClosedAt = closedAt
 	}
 
-	return request
+	return issue
 }
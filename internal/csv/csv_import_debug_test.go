@@ -239,8 +239,8 @@ func TestCSVImportFieldParsing(t *testing.T) {
 	if len(issue1.Labels) != 2 || issue1.Labels[0] != "bug" || issue1.Labels[1] != "urgent" {
 		t.Errorf("Expected labels [bug, urgent], got %v", issue1.Labels)
 	}
-	if issue1.Assignee != "john" {
-		t.Errorf("Expected assignee 'john', got '%s'", issue1.Assignee)
+	if strings.Join(issue1.Assignees, ",") != "john" {
+		t.Errorf("Expected assignee 'john', got %v", issue1.Assignees)
 	}
 	if issue1.EstimatedHours != 8 {
 		t.Errorf("Expected estimated hours 8, got %d", issue1.EstimatedHours)
@@ -321,7 +321,7 @@ func TestCSVExportImportRoundTrip(t *testing.T) {
 			State:          "open",
 			Priority:       "high",
 			Labels:         []string{"bug", "urgent"},
-			Assignee:       "testuser",
+			Assignees:      []string{"testuser"},
 			Milestone:      "v1.0",
 			EstimatedHours: 8,
 			StoryPoints:    5,
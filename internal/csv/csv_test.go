@@ -9,6 +9,23 @@ import (
 	"time"
 )
 
+// writeTestCSV writes content to issues.csv in a new temp directory and returns its path
+func writeTestCSV(t *testing.T, content string) string {
+	t.Helper()
+	return writeTestCSVIn(t, t.TempDir(), "issues.csv", content)
+}
+
+// writeTestCSVIn writes content to the named file in dir and returns its path, for
+// tests that need several files side by side
+func writeTestCSVIn(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to create test CSV file: %v", err)
+	}
+	return path
+}
+
 func TestValidateCSV(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -130,8 +147,8 @@ func TestParseCSV(t *testing.T) {
 	if !reflect.DeepEqual(issue1.Labels, []string{"bug", "critical"}) {
 		t.Errorf("Expected labels [bug, critical], got %v", issue1.Labels)
 	}
-	if issue1.Assignee != "john" {
-		t.Errorf("Expected assignee 'john', got '%s'", issue1.Assignee)
+	if strings.Join(issue1.Assignees, ",") != "john" {
+		t.Errorf("Expected assignee 'john', got %v", issue1.Assignees)
 	}
 	if issue1.EstimatedHours != 4 {
 		t.Errorf("Expected estimated hours 4, got %d", issue1.EstimatedHours)
@@ -168,7 +185,7 @@ func TestWriteCSV(t *testing.T) {
 			State:              "open",
 			Priority:           "high",
 			Labels:             []string{"bug", "urgent"},
-			Assignee:           "john",
+			Assignees:          []string{"john"},
 			Milestone:          "v1.0.0",
 			CreatedAt:          time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
 			UpdatedAt:          time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC),
@@ -254,12 +271,12 @@ func TestParseIssueFromRecord(t *testing.T) {
 			name:   "valid record",
 			record: []string{"1", "Test Issue", "open", "high", "bug,urgent", "john"},
 			expected: &Issue{
-				ID:       1,
-				Title:    "Test Issue",
-				State:    "open",
-				Priority: "high",
-				Labels:   []string{"bug", "urgent"},
-				Assignee: "john",
+				ID:        1,
+				Title:     "Test Issue",
+				State:     "open",
+				Priority:  "high",
+				Labels:    []string{"bug", "urgent"},
+				Assignees: []string{"john"},
 			},
 		},
 		{
@@ -271,12 +288,12 @@ func TestParseIssueFromRecord(t *testing.T) {
 			name:   "default state",
 			record: []string{"1", "Test Issue", "", "high", "bug", "john"},
 			expected: &Issue{
-				ID:       1,
-				Title:    "Test Issue",
-				State:    "open", // Should default to "open"
-				Priority: "high",
-				Labels:   []string{"bug"},
-				Assignee: "john",
+				ID:        1,
+				Title:     "Test Issue",
+				State:     "open", // Should default to "open"
+				Priority:  "high",
+				Labels:    []string{"bug"},
+				Assignees: []string{"john"},
 			},
 		},
 	}
@@ -386,37 +403,31 @@ Test Dry Run Issue,open,high,bug,This is a dry run test`
 
 func TestConvertToGitHubIssue(t *testing.T) {
 	issue := &Issue{
-		Title:    "Test Issue",
-		Body:     "Test body content",
-		Labels:   []string{"bug", "urgent"},
-		Assignee: "testuser",
-		State:    "open",
-		Priority: "high",
+		Title:     "Test Issue",
+		Body:      "Test body content",
+		Labels:    []string{"bug", "urgent"},
+		Assignees: []string{"testuser"},
+		Milestone: "3",
+		State:     "open",
+		Priority:  "high",
 	}
 
 	result := convertToGitHubIssue(issue)
 
-	if result["title"] != "Test Issue" {
-		t.Errorf("Expected title 'Test Issue', got %v", result["title"])
+	if result.Title != "Test Issue" {
+		t.Errorf("Expected title 'Test Issue', got %v", result.Title)
 	}
-	if result["body"] != "Test body content" {
-		t.Errorf("Expected body 'Test body content', got %v", result["body"])
+	if result.Body != "Test body content" {
+		t.Errorf("Expected body 'Test body content', got %v", result.Body)
 	}
-
-	labels, ok := result["labels"].([]string)
-	if !ok {
-		t.Errorf("Expected labels to be []string, got %T", result["labels"])
+	if len(result.Labels) != 2 || result.Labels[0] != "bug" || result.Labels[1] != "urgent" {
+		t.Errorf("Expected labels [bug, urgent], got %v", result.Labels)
 	}
-	if len(labels) != 2 || labels[0] != "bug" || labels[1] != "urgent" {
-		t.Errorf("Expected labels [bug, urgent], got %v", labels)
+	if len(result.Assignees) != 1 || result.Assignees[0] != "testuser" {
+		t.Errorf("Expected assignees [testuser], got %v", result.Assignees)
 	}
-
-	assignees, ok := result["assignees"].([]string)
-	if !ok {
-		t.Errorf("Expected assignees to be []string, got %T", result["assignees"])
-	}
-	if len(assignees) != 1 || assignees[0] != "testuser" {
-		t.Errorf("Expected assignees [testuser], got %v", assignees)
+	if result.Milestone != 3 {
+		t.Errorf("Expected milestone 3, got %d", result.Milestone)
 	}
 }
 
@@ -428,19 +439,22 @@ func TestConvertToGitHubIssue_MinimalFields(t *testing.T) {
 
 	result := convertToGitHubIssue(issue)
 
-	if result["title"] != "Minimal Issue" {
-		t.Errorf("Expected title 'Minimal Issue', got %v", result["title"])
+	if result.Title != "Minimal Issue" {
+		t.Errorf("Expected title 'Minimal Issue', got %v", result.Title)
 	}
-	if result["body"] != "Just title and body" {
-		t.Errorf("Expected body 'Just title and body', got %v", result["body"])
+	if result.Body != "Just title and body" {
+		t.Errorf("Expected body 'Just title and body', got %v", result.Body)
 	}
 
-	// Should not have labels or assignees for minimal issue
-	if _, exists := result["labels"]; exists {
-		t.Error("Expected no labels field for minimal issue")
+	// Should not have labels, assignees or milestone for minimal issue
+	if result.Labels != nil {
+		t.Errorf("Expected no labels for minimal issue, got %v", result.Labels)
+	}
+	if result.Assignees != nil {
+		t.Errorf("Expected no assignees for minimal issue, got %v", result.Assignees)
 	}
-	if _, exists := result["assignees"]; exists {
-		t.Error("Expected no assignees field for minimal issue")
+	if result.Milestone != 0 {
+		t.Errorf("Expected no milestone for minimal issue, got %d", result.Milestone)
 	}
 }
 
@@ -527,8 +541,8 @@ Enhancement C,closed,low,enhancement,testuser,"Description for enhancement C"`
 	if len(issue1.Labels) != 2 {
 		t.Errorf("Expected 2 labels, got %d", len(issue1.Labels))
 	}
-	if issue1.Assignee != "testuser" {
-		t.Errorf("Expected assignee 'testuser', got '%s'", issue1.Assignee)
+	if strings.Join(issue1.Assignees, ",") != "testuser" {
+		t.Errorf("Expected assignee 'testuser', got %v", issue1.Assignees)
 	}
 
 	// Test import simulation
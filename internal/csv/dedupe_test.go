@@ -0,0 +1,33 @@
+package csv
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseIssueFromRecord_DedupesLabelsAndAssignees(t *testing.T) {
+	headerIndex := map[string]int{"title": 0, "labels": 1, "assignee": 2}
+
+	issue, err := parseIssueFromRecord([]string{"Duplicates", "bug, urgent,Bug,,URGENT,ui", "alice,Alice, bob"}, headerIndex, 2)
+	if err != nil {
+		t.Fatalf("parseIssueFromRecord failed: %v", err)
+	}
+	if expected := []string{"bug", "urgent", "ui"}; !reflect.DeepEqual(issue.Labels, expected) {
+		t.Errorf("Expected labels %v, got %v", expected, issue.Labels)
+	}
+	if strings.Join(issue.Assignees, ",") != "alice,bob" {
+		t.Errorf("Expected assignee 'alice,bob', got %v", issue.Assignees)
+	}
+}
+
+func TestConvertToGitHubIssue_DedupesLabels(t *testing.T) {
+	request := convertToGitHubIssue(&Issue{Title: "Merged", Labels: []string{"bug", "Bug", "api"}, Assignees: []string{"carol", "Carol"}})
+
+	if expected := []string{"bug", "api"}; !reflect.DeepEqual(request.Labels, expected) {
+		t.Errorf("Expected labels %v, got %v", expected, request.Labels)
+	}
+	if expected := []string{"carol"}; !reflect.DeepEqual(request.Assignees, expected) {
+		t.Errorf("Expected assignees %v, got %v", expected, request.Assignees)
+	}
+}
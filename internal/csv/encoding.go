@@ -0,0 +1,110 @@
+package csv
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Supported CSV file encodings
+const (
+	EncodingUTF8        = "utf-8"
+	EncodingLatin1      = "latin1"
+	EncodingWindows1252 = "windows-1252"
+	EncodingUTF16LE     = "utf-16le"
+	EncodingUTF16BE     = "utf-16be"
+)
+
+// encodingAliases maps accepted spellings to the canonical encoding names
+var encodingAliases = map[string]string{
+	"":             EncodingUTF8,
+	"utf-8":        EncodingUTF8,
+	"utf8":         EncodingUTF8,
+	"latin1":       EncodingLatin1,
+	"latin-1":      EncodingLatin1,
+	"iso-8859-1":   EncodingLatin1,
+	"windows-1252": EncodingWindows1252,
+	"cp1252":       EncodingWindows1252,
+	"utf-16le":     EncodingUTF16LE,
+	"utf16le":      EncodingUTF16LE,
+	"utf-16be":     EncodingUTF16BE,
+	"utf16be":      EncodingUTF16BE,
+}
+
+// ParseEncoding validates an --encoding value and returns its canonical name.
+// An empty value selects UTF-8.
+func ParseEncoding(name string) (string, error) {
+	encoding, ok := encodingAliases[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return "", fmt.Errorf("invalid encoding '%s' (supported: utf-8, latin1, windows-1252, utf-16le, utf-16be)", name)
+	}
+	return encoding, nil
+}
+
+// windows1252 holds the characters Windows-1252 places in 0x80-0x9F, where
+// Latin-1 has control codes. Unassigned bytes keep their Latin-1 meaning.
+var windows1252 = [32]rune{
+	'€', 0x81, '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', 0x8D, 'Ž', 0x8F,
+	0x90, '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', 0x9D, 'ž', 'Ÿ',
+}
+
+// readCSVFile reads a CSV file and returns its content as UTF-8 without a byte order mark
+func readCSVFile(filePath string, config *ImportConfig) ([]byte, error) {
+	data, err := os.ReadFile(filePath) // #nosec G304 - File path is validated and user-controlled
+	if err != nil {
+		return nil, fmt.Errorf("failed to open CSV file: %w", err)
+	}
+
+	encoding := EncodingUTF8
+	if config != nil {
+		if encoding, err = ParseEncoding(config.Encoding); err != nil {
+			return nil, err
+		}
+	}
+
+	return decodeCSVData(data, encoding)
+}
+
+// decodeCSVData converts data in the given encoding to UTF-8, stripping any byte order mark
+func decodeCSVData(data []byte, encoding string) ([]byte, error) {
+	switch encoding {
+	case EncodingUTF8:
+		data = bytes.TrimPrefix(data, []byte{0xEF, 0xBB, 0xBF})
+		if !utf8.Valid(data) {
+			return nil, fmt.Errorf("CSV file is not valid UTF-8 (use --encoding to select latin1, windows-1252 or utf-16)")
+		}
+		return data, nil
+	case EncodingLatin1, EncodingWindows1252:
+		var buf bytes.Buffer
+		buf.Grow(len(data))
+		for _, b := range data {
+			r := rune(b)
+			if encoding == EncodingWindows1252 && b >= 0x80 && b <= 0x9F {
+				r = windows1252[b-0x80]
+			}
+			buf.WriteRune(r)
+		}
+		return buf.Bytes(), nil
+	case EncodingUTF16LE, EncodingUTF16BE:
+		if len(data)%2 != 0 {
+			return nil, fmt.Errorf("CSV file has an odd number of bytes for %s", encoding)
+		}
+		units := make([]uint16, 0, len(data)/2)
+		for i := 0; i < len(data); i += 2 {
+			if encoding == EncodingUTF16LE {
+				units = append(units, uint16(data[i])|uint16(data[i+1])<<8)
+			} else {
+				units = append(units, uint16(data[i])<<8|uint16(data[i+1]))
+			}
+		}
+		if len(units) > 0 && units[0] == 0xFEFF {
+			units = units[1:]
+		}
+		return []byte(string(utf16.Decode(units))), nil
+	default:
+		return nil, fmt.Errorf("unsupported encoding: %s", encoding)
+	}
+}
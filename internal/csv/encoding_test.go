@@ -0,0 +1,150 @@
+package csv
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf16"
+)
+
+const accentedCSV = "title,body\nCafé crème,Résumé für Jürgen\n"
+
+// latin1Bytes encodes a string whose runes are all below U+0100 as Latin-1
+func latin1Bytes(s string) []byte {
+	var out []byte
+	for _, r := range s {
+		out = append(out, byte(r))
+	}
+	return out
+}
+
+// utf16Bytes encodes a string as UTF-16 with a byte order mark
+func utf16Bytes(s string, bigEndian bool) []byte {
+	var out []byte
+	for _, unit := range append([]uint16{0xFEFF}, utf16.Encode([]rune(s))...) {
+		if bigEndian {
+			out = append(out, byte(unit>>8), byte(unit))
+		} else {
+			out = append(out, byte(unit), byte(unit>>8))
+		}
+	}
+	return out
+}
+
+// assertAccentedIssue checks the single issue parsed from accentedCSV
+func assertAccentedIssue(t *testing.T, issues []*Issue) {
+	t.Helper()
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d", len(issues))
+	}
+	if issues[0].Title != "Café crème" {
+		t.Errorf("Expected title 'Café crème', got %q", issues[0].Title)
+	}
+	if issues[0].Body != "Résumé für Jürgen" {
+		t.Errorf("Expected body 'Résumé für Jürgen', got %q", issues[0].Body)
+	}
+}
+
+func TestParseCSV_Latin1(t *testing.T) {
+	path := writeTestCSV(t, string(latin1Bytes(accentedCSV)))
+	config := &ImportConfig{FilePath: path, Encoding: "latin1"}
+
+	if err := ValidateCSVWithConfig(path, config); err != nil {
+		t.Fatalf("Expected Latin-1 file to validate, got %v", err)
+	}
+	issues, err := ParseCSV(path, config)
+	if err != nil {
+		t.Fatalf("ParseCSV failed: %v", err)
+	}
+	assertAccentedIssue(t, issues)
+}
+
+func TestParseCSV_UTF16(t *testing.T) {
+	tests := []struct {
+		encoding  string
+		bigEndian bool
+	}{
+		{"utf-16le", false},
+		{"utf-16be", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.encoding, func(t *testing.T) {
+			path := writeTestCSV(t, string(utf16Bytes(accentedCSV, tt.bigEndian)))
+			config := &ImportConfig{FilePath: path, Encoding: tt.encoding}
+
+			if err := ValidateCSVWithConfig(path, config); err != nil {
+				t.Fatalf("Expected UTF-16 file to validate, got %v", err)
+			}
+			issues, err := ParseCSV(path, config)
+			if err != nil {
+				t.Fatalf("ParseCSV failed: %v", err)
+			}
+			assertAccentedIssue(t, issues)
+		})
+	}
+}
+
+func TestParseCSV_UTF8WithBOM(t *testing.T) {
+	path := writeTestCSV(t, string(append([]byte{0xEF, 0xBB, 0xBF}, accentedCSV...)))
+
+	issues, err := ParseCSV(path, &ImportConfig{FilePath: path})
+	if err != nil {
+		t.Fatalf("ParseCSV failed: %v", err)
+	}
+	assertAccentedIssue(t, issues)
+}
+
+func TestParseCSV_InvalidUTF8SuggestsEncoding(t *testing.T) {
+	path := writeTestCSV(t, string(latin1Bytes(accentedCSV)))
+
+	_, err := ParseCSV(path, &ImportConfig{FilePath: path})
+	if err == nil || !strings.Contains(err.Error(), "--encoding") {
+		t.Errorf("Expected an error suggesting --encoding, got %v", err)
+	}
+}
+
+func TestDecodeCSVData_Windows1252(t *testing.T) {
+	// 0x93/0x94 are curly quotes and 0x80 is the euro sign in Windows-1252
+	data, err := decodeCSVData([]byte{0x93, 'h', 'i', 0x94, ' ', 0x80}, EncodingWindows1252)
+	if err != nil {
+		t.Fatalf("decodeCSVData failed: %v", err)
+	}
+	if string(data) != "“hi” €" {
+		t.Errorf("Expected “hi” €, got %q", data)
+	}
+}
+
+func TestDecodeCSVData_OddUTF16Length(t *testing.T) {
+	if _, err := decodeCSVData([]byte{0x41, 0x00, 0x42}, EncodingUTF16LE); err == nil {
+		t.Error("Expected error for odd-length UTF-16 data")
+	}
+}
+
+func TestParseEncoding(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{"", EncodingUTF8, false},
+		{"UTF8", EncodingUTF8, false},
+		{"ISO-8859-1", EncodingLatin1, false},
+		{"cp1252", EncodingWindows1252, false},
+		{"utf-16LE", EncodingUTF16LE, false},
+		{"utf16be", EncodingUTF16BE, false},
+		{"ebcdic", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseEncoding(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Expected error for %q", tt.input)
+			}
+			continue
+		}
+		if err != nil || got != tt.expected {
+			t.Errorf("ParseEncoding(%q): expected %s, got %s (%v)", tt.input, tt.expected, got, err)
+		}
+	}
+}
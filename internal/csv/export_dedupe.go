@@ -0,0 +1,65 @@
+package csv
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rhino11/pivot/internal"
+)
+
+// DedupByNumber treats exported issues with the same issue number as duplicates
+const DedupByNumber = "number"
+
+// ParseExportDedupeKey validates an 'export csv --dedupe-key' value: number (default)
+// or title. external_id is rejected, as sync does not store one for exported issues.
+func ParseExportDedupeKey(key string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(key)) {
+	case "", DedupByNumber:
+		return DedupByNumber, nil
+	case DedupByTitle:
+		return DedupByTitle, nil
+	case DedupByExternalID, "external-id":
+		return "", fmt.Errorf("dedupe key '%s' is not supported for export: issues in the database have no external_id (valid: %s, %s)", key, DedupByNumber, DedupByTitle)
+	default:
+		return "", fmt.Errorf("invalid dedupe key '%s' (valid: %s, %s)", key, DedupByNumber, DedupByTitle)
+	}
+}
+
+// DedupeIssues collapses issues of the same repository sharing a dedupe key into the
+// most recently updated copy, kept at the position of the first one; on equal
+// updated_at the first copy wins. Issues of different repositories are never
+// duplicates, and issues without a number are never duplicates by number. It returns
+// the remaining issues and how many were removed.
+func DedupeIssues(issues []*Issue, key string, normalization internal.TitleNormalization) ([]*Issue, int, error) {
+	policy, err := ParseExportDedupeKey(key)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var kept []*Issue
+	position := make(map[string]int)
+	for _, issue := range issues {
+		var issueKey string
+		if policy == DedupByNumber {
+			if issue.ID <= 0 {
+				kept = append(kept, issue)
+				continue
+			}
+			issueKey = "number:" + strconv.Itoa(issue.ID)
+		} else {
+			issueKey = dedupKey(issue, policy, normalization)
+		}
+		issueKey = issue.Repository + "\x00" + issueKey
+
+		if i, ok := position[issueKey]; ok {
+			if issue.UpdatedAt.After(kept[i].UpdatedAt) {
+				kept[i] = issue
+			}
+			continue
+		}
+		position[issueKey] = len(kept)
+		kept = append(kept, issue)
+	}
+	return kept, len(issues) - len(kept), nil
+}
@@ -0,0 +1,97 @@
+package csv
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rhino11/pivot/internal"
+)
+
+func TestDedupeIssues(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 0, 0, 0, 0, time.UTC) }
+	issues := []*Issue{
+		{ID: 1, Title: "Login fails", ExternalID: "JIRA-1", UpdatedAt: day(1)},
+		{ID: 2, Title: "Search is slow", ExternalID: "JIRA-2", UpdatedAt: day(2)},
+		{ID: 1, Title: "Login fails (newer copy)", ExternalID: "JIRA-1", UpdatedAt: day(5)},
+		{ID: 0, Title: "Local draft", UpdatedAt: day(3)},
+		{ID: 0, Title: "Local draft", UpdatedAt: day(4)},
+		{ID: 1, Title: "Login fails (older copy)", ExternalID: "JIRA-1", UpdatedAt: day(3)},
+		{ID: 3, Title: "login fails.", ExternalID: "JIRA-3", UpdatedAt: day(6)},
+	}
+
+	tests := []struct {
+		key     string
+		titles  []string
+		removed int
+	}{
+		{DedupByNumber, []string{"Login fails (newer copy)", "Search is slow", "Local draft", "Local draft", "login fails."}, 2},
+		{DedupByTitle, []string{"login fails.", "Search is slow", "Login fails (newer copy)", "Local draft", "Login fails (older copy)"}, 2},
+	}
+	for _, test := range tests {
+		t.Run(test.key, func(t *testing.T) {
+			kept, removed, err := DedupeIssues(issues, test.key, internal.TitleNormalization{})
+			if err != nil {
+				t.Fatalf("DedupeIssues failed: %v", err)
+			}
+			if removed != test.removed {
+				t.Errorf("Expected %d duplicates removed, got %d", test.removed, removed)
+			}
+			var titles []string
+			for _, issue := range kept {
+				titles = append(titles, issue.Title)
+			}
+			if len(titles) != len(test.titles) {
+				t.Fatalf("Expected %v, got %v", test.titles, titles)
+			}
+			for i := range titles {
+				if titles[i] != test.titles[i] {
+					t.Errorf("Expected %v, got %v", test.titles, titles)
+					break
+				}
+			}
+		})
+	}
+
+	if _, _, err := DedupeIssues(issues, "assignee", internal.TitleNormalization{}); err == nil {
+		t.Error("Expected an invalid dedupe key to be rejected")
+	}
+	if _, _, err := DedupeIssues(issues, DedupByExternalID, internal.TitleNormalization{}); err == nil {
+		t.Error("Expected external_id to be rejected for exported issues")
+	}
+}
+
+func TestDedupeIssues_PerRepository(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 0, 0, 0, 0, time.UTC) }
+	issues := []*Issue{
+		{ID: 1, Title: "Login fails", Repository: "org/alpha", UpdatedAt: day(1)},
+		{ID: 1, Title: "Login fails", Repository: "org/beta", UpdatedAt: day(2)},
+		{ID: 1, Title: "Login fails (newer copy)", Repository: "org/alpha", UpdatedAt: day(3)},
+	}
+
+	for _, key := range []string{DedupByNumber, DedupByTitle} {
+		kept, removed, err := DedupeIssues(issues, key, internal.TitleNormalization{})
+		if err != nil {
+			t.Fatalf("DedupeIssues failed: %v", err)
+		}
+		if len(kept) < 2 || kept[0].Repository != "org/alpha" || kept[1].Repository != "org/beta" {
+			t.Errorf("%s: expected the issue of each repository to be kept, got %+v", key, kept)
+		}
+		if key == DedupByNumber && (removed != 1 || kept[0].Title != "Login fails (newer copy)") {
+			t.Errorf("Expected the newer alpha #1 to replace the older one, got %d removed and %+v", removed, kept)
+		}
+	}
+}
+
+func TestDedupeIssues_KeepsFirstOnEqualUpdatedAt(t *testing.T) {
+	same := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	kept, removed, err := DedupeIssues([]*Issue{
+		{ID: 4, Title: "First", UpdatedAt: same},
+		{ID: 4, Title: "Second", UpdatedAt: same},
+	}, "", internal.TitleNormalization{})
+	if err != nil {
+		t.Fatalf("DedupeIssues failed: %v", err)
+	}
+	if removed != 1 || len(kept) != 1 || kept[0].Title != "First" {
+		t.Errorf("Expected only the first copy to be kept, got %d removed and %+v", removed, kept)
+	}
+}
@@ -0,0 +1,53 @@
+package csv
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// AllFields selects every exportable field in --fields
+const AllFields = "all"
+
+// fieldAliases are accepted in --fields in addition to the canonical names
+var fieldAliases = map[string]string{"assignee": "assignees"}
+
+// ExportFields returns the canonical exportable field names, in Issue struct order,
+// taken from the csv tags of Issue
+func ExportFields() []string {
+	issueType := reflect.TypeOf(Issue{})
+	var fields []string
+	for i := 0; i < issueType.NumField(); i++ {
+		tag := issueType.Field(i).Tag.Get("csv")
+		if tag == "" || tag == "-" {
+			continue
+		}
+		fields = append(fields, tag)
+	}
+	return fields
+}
+
+// ResolveExportFields validates a --fields selection and expands "all" into every
+// exportable field. Names are case-insensitive; the result keeps the order given.
+func ResolveExportFields(selection []string) ([]string, error) {
+	known := make(map[string]bool)
+	for _, field := range ExportFields() {
+		known[field] = true
+	}
+
+	var fields []string
+	for _, field := range selection {
+		name := strings.ToLower(strings.TrimSpace(field))
+		switch {
+		case name == "":
+			continue
+		case name == AllFields:
+			fields = append(fields, ExportFields()...)
+		case known[name] || fieldAliases[name] != "":
+			fields = append(fields, name)
+		default:
+			return nil, fmt.Errorf("unknown field '%s' (run 'pivot export --list-fields' to see the available fields)", field)
+		}
+	}
+	return fields, nil
+}
@@ -0,0 +1,61 @@
+package csv
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExportFields(t *testing.T) {
+	fields := ExportFields()
+	if len(fields) != 19 {
+		t.Errorf("Expected 19 exportable fields, got %d: %v", len(fields), fields)
+	}
+	for _, field := range fields {
+		if field == "-" || field == "" {
+			t.Errorf("Expected only tagged fields, got %q", field)
+		}
+		// Every exportable field must have a value in getIssueFieldValue
+		issue := &Issue{ID: 1, Title: "t", State: "open", Priority: "p", Labels: []string{"l"}, Assignees: []string{"a"},
+			Milestone: "m", Body: "b", EstimatedHours: 1, StoryPoints: 1, Epic: "e", Dependencies: []int{1},
+			AcceptanceCriteria: "ac", ExternalID: "x", StateReason: "completed", Type: "Bug"}
+		if field != "created_at" && field != "updated_at" && field != "closed_at" && getIssueFieldValue(issue, field) == "" {
+			t.Errorf("Expected a value for field %s", field)
+		}
+	}
+}
+
+func TestResolveExportFields(t *testing.T) {
+	fields, err := ResolveExportFields([]string{"all"})
+	if err != nil {
+		t.Fatalf("ResolveExportFields failed: %v", err)
+	}
+	if strings.Join(fields, ",") != strings.Join(ExportFields(), ",") {
+		t.Errorf("Expected all to expand to every field, got %v", fields)
+	}
+
+	fields, err = ResolveExportFields([]string{" Title ", "assignee", "state"})
+	if err != nil {
+		t.Fatalf("ResolveExportFields failed: %v", err)
+	}
+	if got := strings.Join(fields, ","); got != "title,assignee,state" {
+		t.Errorf("Expected title,assignee,state, got %s", got)
+	}
+
+	_, err = ResolveExportFields([]string{"title", "severity"})
+	if err == nil || !strings.Contains(err.Error(), "unknown field 'severity'") {
+		t.Errorf("Expected unknown field error, got %v", err)
+	}
+}
+
+func TestWriteCSV_UnknownField(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.csv")
+	err := WriteCSV([]*Issue{{Title: "One"}}, path, &ExportConfig{Fields: []string{"severity"}})
+	if err == nil {
+		t.Fatal("Expected error for unknown field")
+	}
+	if _, statErr := os.Stat(path); !os.IsNotExist(statErr) {
+		t.Errorf("Expected no file to be created for an invalid field selection")
+	}
+}
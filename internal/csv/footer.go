@@ -0,0 +1,30 @@
+package csv
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// importNow returns the time stamped into import footers. Tests replace it.
+var importNow = time.Now
+
+// importFooter returns the traceability note added to imported issue bodies
+func importFooter(source, version string, at time.Time) string {
+	if source == "" {
+		source = "CSV"
+	}
+	if version == "" {
+		version = "dev"
+	}
+	return fmt.Sprintf("---\n_Imported by pivot %s from %s on %s_", version, source, at.UTC().Format(time.RFC3339))
+}
+
+// appendImportFooter appends footer to body, separated by a blank line
+func appendImportFooter(body, footer string) string {
+	body = strings.TrimRight(body, " \t\r\n")
+	if body == "" {
+		return footer
+	}
+	return body + "\n\n" + footer
+}
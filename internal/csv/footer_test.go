@@ -0,0 +1,82 @@
+package csv
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rhino11/pivot/internal"
+)
+
+// captureBodies replaces the GitHub calls of the import and records each body sent
+func captureBodies(t *testing.T) map[string]string {
+	t.Helper()
+	bodies := make(map[string]string)
+
+	oldCreate, oldEnsure, oldNow := createGitHubIssue, ensureGitHubCredentials, importNow
+	createGitHubIssue = func(owner, repo, token string, req internal.CreateIssueRequest) (*internal.CreateIssueResponse, error) {
+		bodies[req.Title] = req.Body
+		return &internal.CreateIssueResponse{ID: len(bodies), Number: len(bodies), Title: req.Title}, nil
+	}
+	ensureGitHubCredentials = func(owner, repo, token string) error { return nil }
+	importNow = func() time.Time { return time.Date(2026, 3, 4, 5, 6, 7, 0, time.FixedZone("CET", 3600)) }
+	t.Cleanup(func() {
+		createGitHubIssue, ensureGitHubCredentials, importNow = oldCreate, oldEnsure, oldNow
+	})
+
+	return bodies
+}
+
+func TestImportFooter_AddedToRemoteBodyOnly(t *testing.T) {
+	bodies := captureBodies(t)
+	path := writeTestCSVIn(t, t.TempDir(), "backlog.csv", "title,body\nWith body,\"Steps to reproduce\n\"\nNo body,\n")
+
+	config := &ImportConfig{AddFooter: true, Version: "1.4.2"}
+	result, err := ImportCSVToGitHub(path, "owner", "repo", "token", config)
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	footer := "---\n_Imported by pivot 1.4.2 from backlog.csv on 2026-03-04T04:06:07Z_"
+	if bodies["With body"] != "Steps to reproduce\n\n"+footer {
+		t.Errorf("Expected footer after the body, got %q", bodies["With body"])
+	}
+	if bodies["No body"] != footer {
+		t.Errorf("Expected only the footer for an empty body, got %q", bodies["No body"])
+	}
+
+	for _, issue := range result.Issues {
+		if strings.Contains(issue.Body, "Imported by pivot") {
+			t.Errorf("Expected local body of '%s' to stay without footer, got %q", issue.Title, issue.Body)
+		}
+	}
+	if result.Issues[0].Body != "Steps to reproduce" {
+		t.Errorf("Expected canonical body to be unchanged, got %q", result.Issues[0].Body)
+	}
+}
+
+func TestImportFooter_OffByDefault(t *testing.T) {
+	bodies := captureBodies(t)
+	path := writeTestCSVIn(t, t.TempDir(), "backlog.csv", "title,body\nPlain,Just the body\n")
+
+	if _, err := ImportCSVToGitHub(path, "owner", "repo", "token", &ImportConfig{Version: "1.4.2"}); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if bodies["Plain"] != "Just the body" {
+		t.Errorf("Expected body without footer, got %q", bodies["Plain"])
+	}
+}
+
+func TestImportFooter_NamesEachSourceFile(t *testing.T) {
+	bodies := captureBodies(t)
+	dir := t.TempDir()
+	first := writeTestCSVIn(t, dir, "first.csv", "title\nAlpha\n")
+	second := writeTestCSVIn(t, dir, "second.csv", "title\nBeta\n")
+
+	if _, err := ImportCSVFilesToGitHub([]string{first, second}, "owner", "repo", "token", &ImportConfig{AddFooter: true}); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if !strings.Contains(bodies["Alpha"], "pivot dev from first.csv") || !strings.Contains(bodies["Beta"], "from second.csv") {
+		t.Errorf("Expected footers to name each source file, got %q and %q", bodies["Alpha"], bodies["Beta"])
+	}
+}
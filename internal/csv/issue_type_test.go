@@ -0,0 +1,40 @@
+package csv
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rhino11/pivot/internal"
+)
+
+func TestWriteCSV_ExportsIssueType(t *testing.T) {
+	issues := []*Issue{
+		FromDBIssue(internal.DBIssue{Number: 1, Title: "Crash", State: "open", Type: "Bug"}),
+		FromDBIssue(internal.DBIssue{Number: 2, Title: "Untyped", State: "open"}),
+	}
+	path := filepath.Join(t.TempDir(), "typed.csv")
+	if err := WriteCSV(issues, path, &ExportConfig{FilePath: path}); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read export: %v", err)
+	}
+	if header := strings.SplitN(string(data), "\n", 2)[0]; !strings.HasSuffix(header, ",type") {
+		t.Errorf("Expected type column in header, got: %s", header)
+	}
+
+	imported, err := ParseCSV(path, &ImportConfig{FilePath: path})
+	if err != nil {
+		t.Fatalf("ParseCSV failed: %v", err)
+	}
+	if imported[0].Type != "Bug" || imported[1].Type != "" {
+		t.Errorf("Expected types Bug and empty after round trip, got %q and %q", imported[0].Type, imported[1].Type)
+	}
+	if request := convertToGitHubIssue(imported[0]); request.Type != "Bug" {
+		t.Errorf("Expected type Bug in create request, got %q", request.Type)
+	}
+}
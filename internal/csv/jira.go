@@ -0,0 +1,217 @@
+package csv
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/rhino11/pivot/internal"
+)
+
+// JiraColumns maps the column names of a Jira CSV export, lowercased, to issue fields.
+// Jira names custom fields "Custom field (Name)" in some export modes.
+var JiraColumns = map[string]string{
+	"issue key":                   "external_id",
+	"summary":                     "title",
+	"description":                 "body",
+	"status":                      "state",
+	"labels":                      "labels",
+	"assignee":                    "assignee",
+	"priority":                    "priority",
+	"story points":                "story_points",
+	"custom field (story points)": "story_points",
+	"epic link":                   "epic",
+	"custom field (epic link)":    "epic",
+}
+
+// JiraStates maps common Jira workflow statuses, lowercased, to open or closed.
+// --state-map entries take precedence.
+var JiraStates = map[string]string{
+	"backlog":                  StateOpen,
+	"to do":                    StateOpen,
+	"open":                     StateOpen,
+	"selected for development": StateOpen,
+	"in progress":              StateOpen,
+	"in review":                StateOpen,
+	"reopened":                 StateOpen,
+	"done":                     StateClosed,
+	"closed":                   StateClosed,
+	"resolved":                 StateClosed,
+	"won't do":                 StateClosed,
+	"cancelled":                StateClosed,
+}
+
+// jiraRequiredColumns are the Jira columns an export must contain
+var jiraRequiredColumns = []string{"Issue key", "Summary"}
+
+// ParseJiraCSV parses a Jira CSV export into issues. The Jira issue key is kept as the
+// external_id. Jira writes one Labels column per label; all of them are merged.
+func ParseJiraCSV(filePath string, config *ImportConfig) ([]*Issue, error) {
+	if config == nil {
+		config = &ImportConfig{}
+	}
+	data, err := readCSVFile(filePath, config)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	headers, err := reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("CSV file is empty or contains no headers")
+		}
+		return nil, fmt.Errorf("failed to read CSV headers: %w", err)
+	}
+
+	// A field can be filled from several columns, e.g. the repeated Labels columns
+	columns := make(map[string][]int)
+	for i, header := range headers {
+		if field, ok := JiraColumns[strings.ToLower(strings.TrimSpace(header))]; ok {
+			columns[field] = append(columns[field], i)
+		}
+	}
+	for _, required := range jiraRequiredColumns {
+		if _, ok := columns[JiraColumns[strings.ToLower(required)]]; !ok {
+			return nil, fmt.Errorf("required Jira column '%s' not found in CSV headers: %v", required, headers)
+		}
+	}
+
+	stateConfig := &ImportConfig{DefaultState: config.DefaultState, StateMap: make(map[string]string)}
+	for status, state := range JiraStates {
+		stateConfig.StateMap[status] = state
+	}
+	for status, state := range config.StateMap {
+		stateConfig.StateMap[status] = state
+	}
+
+	var issues []*Issue
+	var rowErrs CSVRowErrors
+	lineNum := 1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			rowErrs.add(readRowError(err, lineNum+1, record, len(headers)))
+			lineNum++
+			continue
+		}
+		// Jira descriptions often span lines, so ask the reader where the row starts
+		lineNum, _ = reader.FieldPos(0)
+
+		issue, rowErr := parseJiraRecord(record, columns, lineNum)
+		if rowErr != nil {
+			rowErrs.add(rowErr)
+			continue
+		}
+
+		var status string
+		if values := jiraValues(record, columns["state"]); len(values) > 0 {
+			status = values[0]
+		}
+		state, warning := normalizeState(status, stateConfig)
+		issue.State = state
+		if warning != "" {
+			issue.Warnings = append(issue.Warnings, fmt.Sprintf("line %d: %s", lineNum, warning))
+		}
+		issue.SourceFile = filepath.Base(filePath)
+
+		for i, assignee := range issue.Assignees {
+			if login, ok := config.AssigneeMap[assignee]; ok {
+				issue.Assignees[i] = login
+			}
+		}
+		issue.Assignees = internal.DedupeList(issue.Assignees)
+
+		issues = append(issues, issue)
+	}
+
+	if err := rowErrs.err(); err != nil {
+		return nil, err
+	}
+	return issues, nil
+}
+
+// parseJiraRecord converts a row of a Jira export to an issue, leaving the state to the caller
+func parseJiraRecord(record []string, columns map[string][]int, lineNum int) (*Issue, *CSVRowError) {
+	first := func(field string) string {
+		if values := jiraValues(record, columns[field]); len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+	fieldError := func(field string, cause error) *CSVRowError {
+		column := 0
+		if indexes := columns[field]; len(indexes) > 0 {
+			column = indexes[0] + 1
+		}
+		return &CSVRowError{Line: lineNum, Column: column, Field: field, Cause: cause}
+	}
+
+	issue := &Issue{
+		ExternalID: first("external_id"),
+		Title:      first("title"),
+		Body:       first("body"),
+		Priority:   first("priority"),
+		Epic:       first("epic"),
+	}
+	if issue.Title == "" {
+		return nil, fieldError("title", fmt.Errorf("summary is required"))
+	}
+	if issue.ExternalID == "" {
+		return nil, fieldError("external_id", fmt.Errorf("issue key is required"))
+	}
+
+	// Jira labels cannot contain spaces, so a single column may hold several
+	var labels []string
+	for _, value := range jiraValues(record, columns["labels"]) {
+		labels = append(labels, strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' })...)
+	}
+	issue.Labels = internal.DedupeList(labels)
+
+	if assignee := first("assignee"); assignee != "" {
+		issue.Assignees = []string{assignee}
+	}
+
+	// Jira stores story points as decimals, e.g. "3.0"
+	if points := first("story_points"); points != "" {
+		value, err := strconv.ParseFloat(points, 64)
+		if err != nil || value < 0 {
+			return nil, fieldError("story_points", fmt.Errorf("invalid story points '%s'", points))
+		}
+		issue.StoryPoints = int(math.Round(value))
+	}
+
+	return issue, nil
+}
+
+// jiraValues returns the non-blank values of the given columns of a record
+func jiraValues(record []string, indexes []int) []string {
+	var values []string
+	for _, idx := range indexes {
+		if idx < len(record) {
+			if value := strings.TrimSpace(record[idx]); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// ImportJiraToGitHub parses a Jira CSV export and creates its issues on GitHub like
+// ImportCSVToGitHub
+func ImportJiraToGitHub(filePath, owner, repo, token string, config *ImportConfig) (*ImportResult, error) {
+	issues, err := ParseJiraCSV(filePath, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Jira export: %w", err)
+	}
+
+	return importIssues(issues, nil, owner, repo, token, config)
+}
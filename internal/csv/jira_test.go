@@ -0,0 +1,143 @@
+package csv
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rhino11/pivot/internal"
+)
+
+// jiraExport is a trimmed Jira "Export CSV (all fields)" file: Labels repeats once per
+// label, story points are decimals and descriptions span lines
+const jiraExport = `Summary,Issue key,Issue id,Issue Type,Status,Priority,Assignee,Reporter,Description,Labels,Labels,Custom field (Story Points),Custom field (Epic Link)
+Login fails on Safari,PROJ-101,10101,Bug,In Progress,High,jdoe,asmith,"Steps:
+1. Open Safari
+2. Log in",frontend,safari,3.0,PROJ-1
+Add CSV export,PROJ-102,10102,Story,Done,Medium,,asmith,Export issues as CSV,backend,,5,PROJ-2
+Clean up flags,PROJ-103,10103,Task,Won't Do,Low,asmith,jdoe,,,,,
+Draft roadmap,PROJ-104,10104,Task,Blocked,Low,jdoe,jdoe,,planning,,0.5,
+`
+
+func TestParseJiraCSV_MapsFields(t *testing.T) {
+	path := writeTestCSV(t, jiraExport)
+
+	issues, err := ParseJiraCSV(path, &ImportConfig{AssigneeMap: map[string]string{"jdoe": "john-doe"}})
+	if err != nil {
+		t.Fatalf("ParseJiraCSV failed: %v", err)
+	}
+	if len(issues) != 4 {
+		t.Fatalf("Expected 4 issues, got %d", len(issues))
+	}
+
+	first := issues[0]
+	if first.ExternalID != "PROJ-101" || first.Title != "Login fails on Safari" {
+		t.Errorf("Expected key and summary to be kept, got %q %q", first.ExternalID, first.Title)
+	}
+	if first.Body != "Steps:\n1. Open Safari\n2. Log in" {
+		t.Errorf("Expected the multi-line description as body, got %q", first.Body)
+	}
+	if first.State != StateOpen || first.Priority != "High" || first.Epic != "PROJ-1" || first.StoryPoints != 3 {
+		t.Errorf("Expected open High issue in epic PROJ-1 with 3 points, got %+v", first)
+	}
+	if !reflect.DeepEqual(first.Labels, []string{"frontend", "safari"}) {
+		t.Errorf("Expected labels from both Labels columns, got %v", first.Labels)
+	}
+	if !reflect.DeepEqual(first.Assignees, []string{"john-doe"}) {
+		t.Errorf("Expected the assignee map to apply, got %v", first.Assignees)
+	}
+
+	if issues[1].State != StateClosed || issues[1].Assignees != nil || issues[1].StoryPoints != 5 {
+		t.Errorf("Expected a closed, unassigned issue with 5 points, got %+v", issues[1])
+	}
+	if issues[2].State != StateClosed || issues[2].Labels != nil {
+		t.Errorf("Expected Won't Do to close the issue without labels, got %+v", issues[2])
+	}
+
+	// Unknown statuses fall back to open with a warning; 0.5 points round up
+	if issues[3].State != StateOpen || len(issues[3].Warnings) != 1 || !strings.Contains(issues[3].Warnings[0], "unknown state 'Blocked'") {
+		t.Errorf("Expected an open issue with an unknown state warning, got %+v", issues[3])
+	}
+	if issues[3].StoryPoints != 1 {
+		t.Errorf("Expected 0.5 story points to round to 1, got %d", issues[3].StoryPoints)
+	}
+}
+
+func TestParseJiraCSV_StateMapOverridesJiraStatuses(t *testing.T) {
+	path := writeTestCSV(t, jiraExport)
+
+	issues, err := ParseJiraCSV(path, &ImportConfig{StateMap: map[string]string{"blocked": StateClosed, "done": StateOpen}})
+	if err != nil {
+		t.Fatalf("ParseJiraCSV failed: %v", err)
+	}
+	if issues[1].State != StateOpen || issues[3].State != StateClosed || len(issues[3].Warnings) != 0 {
+		t.Errorf("Expected --state-map entries to win over the Jira defaults, got %s and %s", issues[1].State, issues[3].State)
+	}
+}
+
+func TestParseJiraCSV_PlainColumnNames(t *testing.T) {
+	path := writeTestCSV(t, "Issue key,Summary,Status,Labels,Story Points,Epic Link\nOPS-7,Rotate keys,To Do,security ops,2,OPS-1\n")
+
+	issues, err := ParseJiraCSV(path, nil)
+	if err != nil {
+		t.Fatalf("ParseJiraCSV failed: %v", err)
+	}
+	issue := issues[0]
+	if issue.ExternalID != "OPS-7" || issue.StoryPoints != 2 || issue.Epic != "OPS-1" || issue.State != StateOpen {
+		t.Errorf("Expected plain Jira column names to map, got %+v", issue)
+	}
+	if !reflect.DeepEqual(issue.Labels, []string{"security", "ops"}) {
+		t.Errorf("Expected space-separated labels to be split, got %v", issue.Labels)
+	}
+}
+
+func TestParseJiraCSV_MissingRequiredColumn(t *testing.T) {
+	path := writeTestCSV(t, "Summary,Status\nNo key,Done\n")
+
+	if _, err := ParseJiraCSV(path, nil); err == nil || !strings.Contains(err.Error(), "required Jira column 'Issue key'") {
+		t.Errorf("Expected missing Issue key column error, got %v", err)
+	}
+}
+
+func TestParseJiraCSV_ReportsBadRows(t *testing.T) {
+	path := writeTestCSV(t, "Issue key,Summary,Story Points\nA-1,,1\nA-2,Valid,lots\nA-3,Fine,2\n")
+
+	_, err := ParseJiraCSV(path, nil)
+	var rowErrs *CSVRowErrors
+	if !errors.As(err, &rowErrs) || len(rowErrs.Errors) != 2 {
+		t.Fatalf("Expected 2 row errors, got %v", err)
+	}
+	if rowErrs.Errors[0].Line != 2 || rowErrs.Errors[0].Field != "title" {
+		t.Errorf("Expected missing summary on line 2, got %v", rowErrs.Errors[0])
+	}
+	if rowErrs.Errors[1].Line != 3 || rowErrs.Errors[1].Column != 3 || !strings.Contains(rowErrs.Errors[1].Error(), "invalid story points 'lots'") {
+		t.Errorf("Expected invalid story points on line 3, column 3, got %v", rowErrs.Errors[1])
+	}
+}
+
+func TestImportJiraToGitHub(t *testing.T) {
+	var sent []internal.CreateIssueRequest
+	oldCreate, oldEnsure := createGitHubIssue, ensureGitHubCredentials
+	createGitHubIssue = func(owner, repo, token string, req internal.CreateIssueRequest) (*internal.CreateIssueResponse, error) {
+		sent = append(sent, req)
+		return &internal.CreateIssueResponse{ID: len(sent), Number: len(sent), Title: req.Title}, nil
+	}
+	ensureGitHubCredentials = func(owner, repo, token string) error { return nil }
+	t.Cleanup(func() { createGitHubIssue, ensureGitHubCredentials = oldCreate, oldEnsure })
+
+	path := writeTestCSV(t, jiraExport)
+	result, err := ImportJiraToGitHub(path, "owner", "repo", "token", &ImportConfig{})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if result.Created != 4 || len(sent) != 4 {
+		t.Fatalf("Expected 4 issues to be created, got %d", result.Created)
+	}
+	if sent[0].Title != "Login fails on Safari" || !reflect.DeepEqual(sent[0].Labels, []string{"frontend", "safari"}) {
+		t.Errorf("Expected the Jira summary and labels to be sent, got %+v", sent[0])
+	}
+	if result.Issues[0].ExternalID != "PROJ-101" {
+		t.Errorf("Expected the Jira key to be preserved, got %q", result.Issues[0].ExternalID)
+	}
+}
@@ -0,0 +1,277 @@
+package csv
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// MappingFile describes a reusable CSV column mapping loaded with --map-file
+type MappingFile struct {
+	Columns   map[string]string `yaml:"columns"`   // CSV column -> issue field
+	Defaults  map[string]string `yaml:"defaults"`  // issue field -> value used when blank or missing
+	Assignees map[string]string `yaml:"assignees"` // CSV assignee -> GitHub login
+}
+
+// ImportFields lists the issue fields a CSV column can be imported into
+var ImportFields = []string{
+	"id", "title", "state", "priority", "labels", "assignee", "assignees", "milestone",
+	"created_at", "updated_at", "body", "estimated_hours", "story_points",
+	"epic", "dependencies", "acceptance_criteria", "external_id", "state_reason", "closed_at", "type",
+}
+
+// ColumnResolution describes what a single CSV column is imported as
+type ColumnResolution struct {
+	Column       string
+	Field        string // Issue field the column fills, empty when the column is not imported
+	Mapped       bool   // Resolved through --map or --map-file rather than by its name
+	OverriddenBy string // Column mapped onto the same field, which takes precedence over this one
+}
+
+// MappingPreview is the effective column -> field mapping for a CSV file
+type MappingPreview struct {
+	Columns        []ColumnResolution // In header order
+	Defaults       map[string]string  // Issue field -> value used when blank or missing
+	MissingColumns []string           // Mapped columns that are not in the header
+	UnknownFields  []string           // Mapping targets that are not issue fields
+	MissingTitle   bool               // No column or default provides the required title
+}
+
+// Unmapped returns the columns that are not imported into any field
+func (p *MappingPreview) Unmapped() []string {
+	var columns []string
+	for _, column := range p.Columns {
+		if column.Field == "" {
+			columns = append(columns, column.Column)
+		}
+	}
+	return columns
+}
+
+// PreviewMapping reads the header of a CSV file and resolves each column the same
+// way ParseCSV does, without parsing any rows
+func PreviewMapping(filePath string, config *ImportConfig) (*MappingPreview, error) {
+	data, err := readCSVFile(filePath, config)
+	if err != nil {
+		return nil, err
+	}
+
+	headers, err := csv.NewReader(bytes.NewReader(data)).Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("CSV file is empty or contains no headers")
+		}
+		return nil, fmt.Errorf("failed to read CSV headers: %w", err)
+	}
+	if len(headers) > 0 {
+		headers[0] = strings.TrimPrefix(headers[0], "\uFEFF") // Remove UTF-8 BOM
+		headers[0] = strings.TrimSpace(headers[0])
+	}
+
+	if config == nil {
+		config = &ImportConfig{}
+	}
+
+	known := make(map[string]bool)
+	for _, field := range ImportFields {
+		known[field] = true
+	}
+	present := make(map[string]string) // Lowercase header -> header
+	for _, header := range headers {
+		present[strings.ToLower(strings.TrimSpace(header))] = header
+	}
+
+	// Mapped fields are filled from the mapped column, whatever other columns are named
+	mappedFrom := make(map[string]string) // Lowercase field -> mapped column
+	preview := &MappingPreview{Defaults: config.Defaults}
+	for _, column := range sortedKeys(config.Mapping) {
+		field := strings.ToLower(config.Mapping[column])
+		header, ok := present[strings.ToLower(strings.TrimSpace(column))]
+		if !ok {
+			preview.MissingColumns = append(preview.MissingColumns, column)
+			continue
+		}
+		if !known[field] {
+			preview.UnknownFields = append(preview.UnknownFields, field)
+		}
+		mappedFrom[field] = header
+	}
+
+	// The repository column is read by its own option
+	repositoryColumn := strings.ToLower(strings.TrimSpace(config.RepositoryColumn))
+	if repositoryColumn != "" {
+		if _, ok := present[repositoryColumn]; !ok {
+			preview.MissingColumns = append(preview.MissingColumns, config.RepositoryColumn)
+		}
+	}
+
+	for _, header := range headers {
+		resolution := ColumnResolution{Column: header}
+		name := strings.ToLower(strings.TrimSpace(header))
+		if repositoryColumn != "" && name == repositoryColumn {
+			resolution.Field = "repository"
+		} else if field, ok := lookupMapping(config.Mapping, name); ok && known[strings.ToLower(field)] {
+			resolution.Field = strings.ToLower(field)
+			resolution.Mapped = true
+		} else if known[name] {
+			if from, ok := mappedFrom[name]; ok && from != header {
+				resolution.OverriddenBy = from
+			} else {
+				resolution.Field = name
+			}
+		}
+		preview.Columns = append(preview.Columns, resolution)
+	}
+
+	_, titleDefault := lookupMapping(config.Defaults, "title")
+	preview.MissingTitle = !titleDefault
+	for _, column := range preview.Columns {
+		if column.Field == "title" {
+			preview.MissingTitle = false
+		}
+	}
+
+	return preview, nil
+}
+
+// lookupMapping finds key in m case-insensitively
+func lookupMapping(m map[string]string, key string) (string, bool) {
+	for k, v := range m {
+		if strings.EqualFold(strings.TrimSpace(k), key) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// LoadMappingFile reads a YAML column mapping file
+func LoadMappingFile(path string) (*MappingFile, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - User controls mapping file path
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mapping file: %w", err)
+	}
+
+	var mapping MappingFile
+	if err := yaml.Unmarshal(data, &mapping); err != nil {
+		return nil, fmt.Errorf("failed to parse mapping file: %w", err)
+	}
+
+	return &mapping, nil
+}
+
+// ParseInlineMappings parses --map values of the form "column=field"
+func ParseInlineMappings(values []string) (map[string]string, error) {
+	mapping := make(map[string]string)
+	for _, value := range values {
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			return nil, fmt.Errorf("invalid mapping '%s' (expected column=field)", value)
+		}
+		mapping[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	return mapping, nil
+}
+
+// ApplyMappings layers a mapping file under inline column mappings on the import
+// configuration. An inline mapping replaces any file mapping targeting the same field.
+func (c *ImportConfig) ApplyMappings(file *MappingFile, inline map[string]string) {
+	columns := make(map[string]string)
+	inlineFields := make(map[string]bool)
+	for _, field := range inline {
+		inlineFields[strings.ToLower(field)] = true
+	}
+
+	if file != nil {
+		for column, field := range file.Columns {
+			if !inlineFields[strings.ToLower(field)] {
+				columns[column] = field
+			}
+		}
+		if len(file.Defaults) > 0 {
+			c.Defaults = file.Defaults
+		}
+		if len(file.Assignees) > 0 {
+			c.AssigneeMap = file.Assignees
+		}
+	}
+
+	for column, field := range inline {
+		columns[column] = field
+	}
+
+	c.Mapping = columns
+}
+
+// resolveHeaderIndex builds the field -> column index map for a header row,
+// applying the column mappings and reserving indexes for default-only fields
+func resolveHeaderIndex(headers []string, config *ImportConfig) map[string]int {
+	headerIndex := make(map[string]int)
+	for i, header := range headers {
+		cleanHeader := strings.ToLower(strings.TrimSpace(header))
+		if cleanHeader != "" {
+			headerIndex[cleanHeader] = i
+		}
+	}
+
+	if config == nil {
+		return headerIndex
+	}
+
+	for column, field := range config.Mapping {
+		if idx, exists := headerIndex[strings.ToLower(strings.TrimSpace(column))]; exists {
+			headerIndex[strings.ToLower(field)] = idx
+		}
+	}
+
+	// Fields only provided by defaults are appended after the real columns
+	next := len(headers)
+	for _, field := range sortedKeys(config.Defaults) {
+		field = strings.ToLower(field)
+		if _, exists := headerIndex[field]; !exists {
+			headerIndex[field] = next
+			next++
+		}
+	}
+
+	return headerIndex
+}
+
+// applyDefaults returns a copy of record with default values filled in for blank or missing fields
+func applyDefaults(record []string, headerIndex map[string]int, defaults map[string]string) []string {
+	if len(defaults) == 0 {
+		return record
+	}
+
+	size := len(record)
+	for _, idx := range headerIndex {
+		if idx >= size {
+			size = idx + 1
+		}
+	}
+	filled := make([]string, size)
+	copy(filled, record)
+
+	for field, value := range defaults {
+		if idx, exists := headerIndex[strings.ToLower(field)]; exists && strings.TrimSpace(filled[idx]) == "" {
+			filled[idx] = value
+		}
+	}
+
+	return filled
+}
+
+// sortedKeys returns the keys of a string map in a stable order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,295 @@
+package csv
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeMappingTestFiles(t *testing.T) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+
+	csvContent := `Summary,Name,Status,Owner,Tags
+"Fix login","Login fix (alt)",,jdoe,"bug,auth"
+"Add dashboard","Dashboard (alt)",closed,asmith,
+`
+	csvFile := filepath.Join(dir, "issues.csv")
+	if err := os.WriteFile(csvFile, []byte(csvContent), 0600); err != nil {
+		t.Fatalf("Failed to write CSV: %v", err)
+	}
+
+	mappingContent := `columns:
+  Summary: title
+  Owner: assignee
+  Tags: labels
+  Status: state
+defaults:
+  state: open
+  milestone: v1.0
+assignees:
+  jdoe: john-doe-gh
+`
+	mapFile := filepath.Join(dir, "mapping.yml")
+	if err := os.WriteFile(mapFile, []byte(mappingContent), 0600); err != nil {
+		t.Fatalf("Failed to write mapping file: %v", err)
+	}
+
+	return csvFile, mapFile
+}
+
+func TestMappingFile_UsedByImport(t *testing.T) {
+	csvFile, mapFile := writeMappingTestFiles(t)
+
+	mapping, err := LoadMappingFile(mapFile)
+	if err != nil {
+		t.Fatalf("Failed to load mapping file: %v", err)
+	}
+
+	config := &ImportConfig{}
+	config.ApplyMappings(mapping, nil)
+
+	if err := ValidateCSVWithConfig(csvFile, config); err != nil {
+		t.Fatalf("Expected mapped CSV to validate, got: %v", err)
+	}
+
+	issues, err := ParseCSV(csvFile, config)
+	if err != nil {
+		t.Fatalf("ParseCSV failed: %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d", len(issues))
+	}
+
+	first := issues[0]
+	if first.Title != "Fix login" {
+		t.Errorf("Expected title from Summary column, got '%s'", first.Title)
+	}
+	if first.State != "open" {
+		t.Errorf("Expected default state 'open', got '%s'", first.State)
+	}
+	if first.Milestone != "v1.0" {
+		t.Errorf("Expected default milestone 'v1.0', got '%s'", first.Milestone)
+	}
+	if strings.Join(first.Assignees, ",") != "john-doe-gh" {
+		t.Errorf("Expected mapped assignee 'john-doe-gh', got %v", first.Assignees)
+	}
+	if len(first.Labels) != 2 || first.Labels[0] != "bug" {
+		t.Errorf("Expected labels from Tags column, got %v", first.Labels)
+	}
+
+	second := issues[1]
+	if second.State != "closed" {
+		t.Errorf("Expected explicit state 'closed', got '%s'", second.State)
+	}
+	if strings.Join(second.Assignees, ",") != "asmith" {
+		t.Errorf("Expected unmapped assignee to pass through, got %v", second.Assignees)
+	}
+}
+
+func TestMappingFile_InlineTakesPrecedence(t *testing.T) {
+	csvFile, mapFile := writeMappingTestFiles(t)
+
+	mapping, err := LoadMappingFile(mapFile)
+	if err != nil {
+		t.Fatalf("Failed to load mapping file: %v", err)
+	}
+	inline, err := ParseInlineMappings([]string{"Name=title"})
+	if err != nil {
+		t.Fatalf("Failed to parse inline mappings: %v", err)
+	}
+
+	config := &ImportConfig{}
+	config.ApplyMappings(mapping, inline)
+
+	if _, exists := config.Mapping["Summary"]; exists {
+		t.Error("Expected file mapping for title to be replaced by inline mapping")
+	}
+
+	issues, err := ParseCSV(csvFile, config)
+	if err != nil {
+		t.Fatalf("ParseCSV failed: %v", err)
+	}
+	if issues[0].Title != "Login fix (alt)" {
+		t.Errorf("Expected title from inline-mapped Name column, got '%s'", issues[0].Title)
+	}
+	// Other file mappings still apply
+	if strings.Join(issues[0].Assignees, ",") != "john-doe-gh" {
+		t.Errorf("Expected file assignee mapping to still apply, got %v", issues[0].Assignees)
+	}
+}
+
+func TestMappingFile_MissingTitleFailsValidation(t *testing.T) {
+	csvFile, _ := writeMappingTestFiles(t)
+
+	config := &ImportConfig{}
+	config.ApplyMappings(&MappingFile{Columns: map[string]string{"Owner": "assignee"}}, nil)
+
+	if err := ValidateCSVWithConfig(csvFile, config); err == nil {
+		t.Error("Expected validation error when no column resolves to title")
+	}
+}
+
+func TestParseInlineMappings_Invalid(t *testing.T) {
+	for _, value := range []string{"Summary", "=title", "Summary="} {
+		if _, err := ParseInlineMappings([]string{value}); err == nil {
+			t.Errorf("Expected error for invalid mapping %q", value)
+		}
+	}
+}
+
+// describeColumns renders column resolutions as "column=field" for easy comparison
+func describeColumns(preview *MappingPreview) string {
+	var parts []string
+	for _, column := range preview.Columns {
+		field := column.Field
+		if field == "" {
+			field = "-"
+		}
+		if column.Mapped {
+			field += "*"
+		}
+		parts = append(parts, fmt.Sprintf("%s=%s", column.Column, field))
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestPreviewMapping_InlineMappings(t *testing.T) {
+	path := writeTestCSV(t, "Summary,Status,Labels,Reporter,Sprint\nFix login,done,bug,ann,12\n")
+
+	config := &ImportConfig{}
+	inline, err := ParseInlineMappings([]string{"Summary=title", "Status=state"})
+	if err != nil {
+		t.Fatalf("Failed to parse mappings: %v", err)
+	}
+	config.ApplyMappings(nil, inline)
+
+	preview, err := PreviewMapping(path, config)
+	if err != nil {
+		t.Fatalf("PreviewMapping failed: %v", err)
+	}
+
+	expected := "Summary=title* Status=state* Labels=labels Reporter=- Sprint=-"
+	if got := describeColumns(preview); got != expected {
+		t.Errorf("Expected columns '%s', got '%s'", expected, got)
+	}
+	if got := strings.Join(preview.Unmapped(), ","); got != "Reporter,Sprint" {
+		t.Errorf("Expected unmapped columns Reporter,Sprint, got '%s'", got)
+	}
+	if preview.MissingTitle {
+		t.Error("Expected title to be provided by the Summary mapping")
+	}
+}
+
+func TestPreviewMapping_MappingFile(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTestCSVIn(t, dir, "export.csv", "Name,Owner,Notes,Extra\nTask,ann,n,x\n")
+	mapFile := writeTestCSVIn(t, dir, "mapping.yml", `columns:
+  Name: title
+  Owner: assignee
+  Notes: body
+  Missing: milestone
+defaults:
+  labels: imported
+  state: open
+`)
+
+	mapping, err := LoadMappingFile(mapFile)
+	if err != nil {
+		t.Fatalf("Failed to load mapping file: %v", err)
+	}
+	// Inline mappings take precedence over the file for the same field
+	inline, _ := ParseInlineMappings([]string{"Extra=body"})
+	config := &ImportConfig{}
+	config.ApplyMappings(mapping, inline)
+
+	preview, err := PreviewMapping(path, config)
+	if err != nil {
+		t.Fatalf("PreviewMapping failed: %v", err)
+	}
+
+	expected := "Name=title* Owner=assignee* Notes=- Extra=body*"
+	if got := describeColumns(preview); got != expected {
+		t.Errorf("Expected columns '%s', got '%s'", expected, got)
+	}
+	if preview.Defaults["labels"] != "imported" || preview.Defaults["state"] != "open" {
+		t.Errorf("Expected defaults from the mapping file, got %v", preview.Defaults)
+	}
+	if strings.Join(preview.MissingColumns, ",") != "Missing" {
+		t.Errorf("Expected mapped column 'Missing' to be reported as missing, got %v", preview.MissingColumns)
+	}
+	if strings.Join(preview.Unmapped(), ",") != "Notes" {
+		t.Errorf("Expected Notes to be unmapped, got %v", preview.Unmapped())
+	}
+}
+
+func TestPreviewMapping_OverriddenAndUnknown(t *testing.T) {
+	path := writeTestCSV(t, "title,Headline,Points\nOld,New,3\n")
+
+	config := &ImportConfig{Mapping: map[string]string{"Headline": "title", "Points": "points"}}
+	preview, err := PreviewMapping(path, config)
+	if err != nil {
+		t.Fatalf("PreviewMapping failed: %v", err)
+	}
+
+	if preview.Columns[0].Field != "" || preview.Columns[0].OverriddenBy != "Headline" {
+		t.Errorf("Expected title column to be overridden by Headline, got %+v", preview.Columns[0])
+	}
+	if strings.Join(preview.UnknownFields, ",") != "points" {
+		t.Errorf("Expected 'points' to be reported as an unknown field, got %v", preview.UnknownFields)
+	}
+	if preview.Columns[2].Field != "" {
+		t.Errorf("Expected a column mapped to an unknown field not to be imported, got %+v", preview.Columns[2])
+	}
+}
+
+func TestPreviewMapping_MissingTitle(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTestCSVIn(t, dir, "issues.csv", "Summary,state\nTask,open\n")
+
+	preview, err := PreviewMapping(path, nil)
+	if err != nil {
+		t.Fatalf("PreviewMapping failed: %v", err)
+	}
+	if !preview.MissingTitle {
+		t.Error("Expected missing title to be flagged")
+	}
+
+	preview, err = PreviewMapping(path, &ImportConfig{Defaults: map[string]string{"title": "Untitled"}})
+	if err != nil {
+		t.Fatalf("PreviewMapping failed: %v", err)
+	}
+	if preview.MissingTitle {
+		t.Error("Expected a title default to satisfy the required field")
+	}
+
+	if _, err := PreviewMapping(writeTestCSVIn(t, dir, "empty.csv", ""), nil); err == nil {
+		t.Error("Expected error for an empty file")
+	}
+}
+
+func TestPreviewMapping_RepositoryColumn(t *testing.T) {
+	path := writeTestCSV(t, "title,Repo\nTask,acme/api\n")
+
+	preview, err := PreviewMapping(path, &ImportConfig{RepositoryColumn: "repo"})
+	if err != nil {
+		t.Fatalf("PreviewMapping failed: %v", err)
+	}
+	expected := "title=title Repo=repository"
+	if got := describeColumns(preview); got != expected {
+		t.Errorf("Expected columns '%s', got '%s'", expected, got)
+	}
+	if len(preview.Unmapped()) != 0 {
+		t.Errorf("Expected no unmapped columns, got %v", preview.Unmapped())
+	}
+
+	preview, err = PreviewMapping(path, &ImportConfig{RepositoryColumn: "project"})
+	if err != nil {
+		t.Fatalf("PreviewMapping failed: %v", err)
+	}
+	if got := strings.Join(preview.MissingColumns, ","); got != "project" {
+		t.Errorf("Expected the missing repository column to be reported, got %q", got)
+	}
+}
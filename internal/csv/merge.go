@@ -0,0 +1,82 @@
+package csv
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rhino11/pivot/internal"
+)
+
+// Dedup policies for merging several CSV files into one import batch
+const (
+	DedupByTitle      = "title"       // Issues with the same title are duplicates
+	DedupByExternalID = "external_id" // Issues with the same external_id are duplicates, falling back to title
+)
+
+// ParseDedupPolicy validates a --dedup-by value
+func ParseDedupPolicy(policy string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(policy)) {
+	case "", DedupByTitle:
+		return DedupByTitle, nil
+	case DedupByExternalID, "external-id":
+		return DedupByExternalID, nil
+	default:
+		return "", fmt.Errorf("invalid dedup policy '%s' (valid: %s, %s)", policy, DedupByTitle, DedupByExternalID)
+	}
+}
+
+// dedupKey returns the key two issues must share to be considered duplicates. Titles
+// are normalized with the configured title match rules.
+func dedupKey(issue *Issue, policy string, normalization internal.TitleNormalization) string {
+	if policy == DedupByExternalID && issue.ExternalID != "" {
+		return "external_id:" + strings.TrimSpace(issue.ExternalID)
+	}
+	return "title:" + normalization.Normalize(issue.Title)
+}
+
+// ParseCSVFiles validates and parses each CSV file and merges the issues into one batch,
+// in file order. An issue whose dedup key matches an issue from an earlier file is
+// returned as a duplicate instead; duplicates within a single file are kept as-is.
+func ParseCSVFiles(filePaths []string, config *ImportConfig) ([]*Issue, []*Issue, error) {
+	if len(filePaths) == 0 {
+		return nil, nil, fmt.Errorf("no CSV files given")
+	}
+
+	policy, err := ParseDedupPolicy(config.DedupBy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var merged, duplicates []*Issue
+	seen := make(map[string]bool)
+	for _, filePath := range filePaths {
+		if err := ValidateCSVWithConfig(filePath, config); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", filePath, err)
+		}
+
+		issues, err := ParseCSV(filePath, config)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", filePath, err)
+		}
+
+		fileKeys := make(map[string]bool)
+		for _, issue := range issues {
+			key := dedupKey(issue, policy, config.TitleMatch)
+			if issue.Repository != "" {
+				// Rows targeting different repositories never duplicate each other
+				key = strings.ToLower(issue.Repository) + " " + key
+			}
+			if seen[key] {
+				duplicates = append(duplicates, issue)
+				continue
+			}
+			fileKeys[key] = true
+			merged = append(merged, issue)
+		}
+		for key := range fileKeys {
+			seen[key] = true
+		}
+	}
+
+	return merged, duplicates, nil
+}
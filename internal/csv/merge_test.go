@@ -0,0 +1,127 @@
+package csv
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rhino11/pivot/internal"
+)
+
+func TestParseDedupPolicy(t *testing.T) {
+	tests := map[string]string{"": DedupByTitle, "Title": DedupByTitle, "external_id": DedupByExternalID, "external-id": DedupByExternalID}
+	for input, expected := range tests {
+		policy, err := ParseDedupPolicy(input)
+		if err != nil || policy != expected {
+			t.Errorf("ParseDedupPolicy(%q): expected %s, got %s (%v)", input, expected, policy, err)
+		}
+	}
+	if _, err := ParseDedupPolicy("body"); err == nil {
+		t.Error("Expected error for unknown policy")
+	}
+}
+
+func TestParseCSVFiles_DedupByTitle(t *testing.T) {
+	dir := t.TempDir()
+	first := writeTestCSVIn(t, dir, "first.csv", "title,state\nLogin page,open\nSignup page,open\n")
+	second := writeTestCSVIn(t, dir, "second.csv", "title,state\n  login PAGE ,closed\nPassword reset,open\n")
+
+	issues, duplicates, err := ParseCSVFiles([]string{first, second}, &ImportConfig{})
+	if err != nil {
+		t.Fatalf("ParseCSVFiles failed: %v", err)
+	}
+	if len(issues) != 3 {
+		t.Fatalf("Expected 3 merged issues, got %d", len(issues))
+	}
+	if issues[2].Title != "Password reset" {
+		t.Errorf("Expected file order to be kept, got %s last", issues[2].Title)
+	}
+	if len(duplicates) != 1 || duplicates[0].State != "closed" {
+		t.Errorf("Expected the second file's 'login PAGE' to be the duplicate, got %+v", duplicates)
+	}
+}
+
+func TestParseCSVFiles_DedupByNormalizedTitle(t *testing.T) {
+	dir := t.TempDir()
+	first := writeTestCSVIn(t, dir, "first.csv", "title,state\nLogin page,open\nSignup page,open\n")
+	second := writeTestCSVIn(t, dir, "second.csv", "title,state\n\"[BUG]  Login   page.\",closed\n[UI] Signup page,open\n")
+
+	config := &ImportConfig{TitleMatch: internal.TitleNormalization{StripPrefixes: []string{"[bug]"}}}
+	issues, duplicates, err := ParseCSVFiles([]string{first, second}, config)
+	if err != nil {
+		t.Fatalf("ParseCSVFiles failed: %v", err)
+	}
+	if len(duplicates) != 1 || duplicates[0].Title != "[BUG]  Login   page." {
+		t.Errorf("Expected '[BUG]  Login   page.' to be the duplicate, got %+v", duplicates)
+	}
+	if len(issues) != 3 {
+		t.Errorf("Expected '[UI] Signup page' to be kept with an unlisted prefix, got %d issues", len(issues))
+	}
+}
+
+func TestParseCSVFiles_DedupByExternalID(t *testing.T) {
+	dir := t.TempDir()
+	first := writeTestCSVIn(t, dir, "first.csv", "title,external_id\nLogin page,JIRA-1\nSignup page,\n")
+	second := writeTestCSVIn(t, dir, "second.csv", "title,external_id\nLogin page,JIRA-2\nRenamed login,JIRA-1\nSignup page,\n")
+
+	issues, duplicates, err := ParseCSVFiles([]string{first, second}, &ImportConfig{DedupBy: DedupByExternalID})
+	if err != nil {
+		t.Fatalf("ParseCSVFiles failed: %v", err)
+	}
+	// "Login page" with a new external_id is kept; JIRA-1 and the id-less "Signup page" are duplicates
+	if len(issues) != 3 {
+		t.Errorf("Expected 3 merged issues, got %d", len(issues))
+	}
+	if len(duplicates) != 2 || duplicates[0].Title != "Renamed login" || duplicates[1].Title != "Signup page" {
+		t.Errorf("Expected 'Renamed login' and 'Signup page' as duplicates, got %+v", duplicates)
+	}
+}
+
+func TestParseCSVFiles_KeepsDuplicatesWithinOneFile(t *testing.T) {
+	path := writeTestCSV(t, "title\nSame title\nSame title\n")
+
+	issues, duplicates, err := ParseCSVFiles([]string{path}, &ImportConfig{})
+	if err != nil {
+		t.Fatalf("ParseCSVFiles failed: %v", err)
+	}
+	if len(issues) != 2 || len(duplicates) != 0 {
+		t.Errorf("Expected 2 issues and no duplicates, got %d and %d", len(issues), len(duplicates))
+	}
+}
+
+func TestParseCSVFiles_InvalidFile(t *testing.T) {
+	dir := t.TempDir()
+	good := writeTestCSVIn(t, dir, "good.csv", "title\nIssue\n")
+	bad := writeTestCSVIn(t, dir, "bad.csv", "state\nopen\n")
+
+	_, _, err := ParseCSVFiles([]string{good, bad}, &ImportConfig{})
+	if err == nil || !strings.Contains(err.Error(), "bad.csv") {
+		t.Errorf("Expected error naming bad.csv, got: %v", err)
+	}
+}
+
+func TestImportCSVFilesToGitHub_OverlappingTitle(t *testing.T) {
+	attempted := stubGitHub(t, "")
+	dir := t.TempDir()
+	first := writeTestCSVIn(t, dir, "first.csv", "title\nLogin page\nSignup page\n")
+	second := writeTestCSVIn(t, dir, "second.csv", "title\nLogin page\nPassword reset\n")
+
+	result, err := ImportCSVFilesToGitHub([]string{first, second}, "owner", "repo", "token", &ImportConfig{})
+	if err != nil {
+		t.Fatalf("ImportCSVFilesToGitHub failed: %v", err)
+	}
+	if result.Total != 4 || result.Created != 3 || result.Skipped != 1 {
+		t.Errorf("Expected total 4, created 3, skipped 1, got %d, %d, %d", result.Total, result.Created, result.Skipped)
+	}
+	if len(result.Duplicates) != 1 || result.Duplicates[0].Title != "Login page" {
+		t.Errorf("Expected 'Login page' as the duplicate, got %+v", result.Duplicates)
+	}
+	if strings.Join(*attempted, "|") != "Login page|Signup page|Password reset" {
+		t.Errorf("Expected each title created once, got %v", *attempted)
+	}
+	if len(result.SkipReasons) != 1 || !strings.Contains(result.SkipReasons[0], "Skipped duplicate issue 'Login page'") {
+		t.Errorf("Expected duplicate to be reported, got %v", result.SkipReasons)
+	}
+	if len(result.Errors) != 0 {
+		t.Errorf("Expected no errors for a skipped duplicate, got %v", result.Errors)
+	}
+}
@@ -0,0 +1,33 @@
+package csv
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rhino11/pivot/internal"
+)
+
+// Error modes for issue creation failures during a CSV import
+const (
+	OnErrorContinue = "continue" // Record the failure and import the remaining issues
+	OnErrorAbort    = "abort"    // Stop at the first failure
+)
+
+// GitHub calls made by ImportCSVToGitHub. Tests replace them to simulate failures.
+var (
+	createGitHubIssue       = internal.CreateIssue
+	ensureGitHubCredentials = internal.EnsureGitHubCredentials
+	listCollaborators       = internal.ListCollaborators
+)
+
+// ParseOnErrorMode validates an --on-error value
+func ParseOnErrorMode(mode string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "", OnErrorContinue:
+		return OnErrorContinue, nil
+	case OnErrorAbort:
+		return OnErrorAbort, nil
+	default:
+		return "", fmt.Errorf("invalid error mode '%s' (valid: %s, %s)", mode, OnErrorContinue, OnErrorAbort)
+	}
+}
@@ -0,0 +1,122 @@
+package csv
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rhino11/pivot/internal"
+)
+
+// stubGitHub replaces the GitHub calls of the import with a fake that fails for one title
+func stubGitHub(t *testing.T, failTitle string) *[]string {
+	t.Helper()
+	var attempted []string
+
+	oldCreate, oldEnsure := createGitHubIssue, ensureGitHubCredentials
+	createGitHubIssue = func(owner, repo, token string, req internal.CreateIssueRequest) (*internal.CreateIssueResponse, error) {
+		attempted = append(attempted, req.Title)
+		if req.Title == failTitle {
+			return nil, fmt.Errorf("GitHub API error (status 422): Validation Failed")
+		}
+		return &internal.CreateIssueResponse{ID: len(attempted), Number: len(attempted), Title: req.Title}, nil
+	}
+	ensureGitHubCredentials = func(owner, repo, token string) error { return nil }
+	t.Cleanup(func() {
+		createGitHubIssue, ensureGitHubCredentials = oldCreate, oldEnsure
+	})
+
+	return &attempted
+}
+
+func writeOnErrorCSV(t *testing.T) string {
+	t.Helper()
+	return writeTestCSV(t, `title,state
+First issue,open
+Broken issue,open
+Last issue,open`)
+}
+
+func TestParseOnErrorMode(t *testing.T) {
+	tests := map[string]string{"": OnErrorContinue, "continue": OnErrorContinue, "ABORT": OnErrorAbort}
+	for input, expected := range tests {
+		mode, err := ParseOnErrorMode(input)
+		if err != nil || mode != expected {
+			t.Errorf("ParseOnErrorMode(%q): expected %s, got %s (%v)", input, expected, mode, err)
+		}
+	}
+	if _, err := ParseOnErrorMode("retry"); err == nil {
+		t.Error("Expected error for unknown mode")
+	}
+}
+
+func TestImportCSVToGitHub_OnErrorContinue(t *testing.T) {
+	attempted := stubGitHub(t, "Broken issue")
+	csvFile := writeOnErrorCSV(t)
+
+	result, err := ImportCSVToGitHub(csvFile, "testowner", "testrepo", "testtoken", &ImportConfig{FilePath: csvFile})
+	if err != nil {
+		t.Fatalf("Expected continue mode to succeed, got error: %v", err)
+	}
+	if len(*attempted) != 3 {
+		t.Errorf("Expected all 3 issues to be attempted, got %v", *attempted)
+	}
+	if result.Created != 2 || result.Aborted {
+		t.Errorf("Expected 2 created and no abort, got created=%d aborted=%v", result.Created, result.Aborted)
+	}
+	if result.OnError != OnErrorContinue {
+		t.Errorf("Expected result to report mode continue, got %s", result.OnError)
+	}
+	if len(result.Errors) != 1 || !strings.Contains(result.Errors[0], "Broken issue") {
+		t.Errorf("Expected one error for 'Broken issue', got %v", result.Errors)
+	}
+}
+
+func TestImportCSVToGitHub_OnErrorAbort(t *testing.T) {
+	attempted := stubGitHub(t, "Broken issue")
+	csvFile := writeOnErrorCSV(t)
+
+	result, err := ImportCSVToGitHub(csvFile, "testowner", "testrepo", "testtoken",
+		&ImportConfig{FilePath: csvFile, OnError: OnErrorAbort})
+	if err == nil {
+		t.Fatal("Expected abort mode to return an error")
+	}
+	if !strings.Contains(err.Error(), "import aborted after creating 1 of 3 issues") {
+		t.Errorf("Expected abort error, got %v", err)
+	}
+	if result == nil {
+		t.Fatal("Expected partial result alongside the abort error")
+	}
+	if len(*attempted) != 2 {
+		t.Errorf("Expected import to stop after the failing issue, attempted %v", *attempted)
+	}
+	if result.Created != 1 || !result.Aborted || result.OnError != OnErrorAbort {
+		t.Errorf("Expected 1 created, aborted in abort mode, got %+v", result)
+	}
+}
+
+func TestImportCSVToGitHub_InvalidOnError(t *testing.T) {
+	stubGitHub(t, "")
+	csvFile := writeOnErrorCSV(t)
+
+	if _, err := ImportCSVToGitHub(csvFile, "testowner", "testrepo", "testtoken",
+		&ImportConfig{FilePath: csvFile, OnError: "retry"}); err == nil {
+		t.Error("Expected error for invalid error mode")
+	}
+}
+
+func TestImportCSVToGitHub_InvalidRequestsFailFast(t *testing.T) {
+	attempted := stubGitHub(t, "")
+	csvFile := writeTestCSV(t, "title,state\nValid issue,open\n"+strings.Repeat("x", internal.MaxIssueTitleLength+1)+",open\n")
+
+	_, err := ImportCSVToGitHub(csvFile, "owner", "repo", "token", &ImportConfig{})
+	if err == nil || !strings.Contains(err.Error(), "1 issues are invalid, nothing was created") {
+		t.Fatalf("Expected validation error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "issue 2") || !strings.Contains(err.Error(), "maximum 256") {
+		t.Errorf("Expected the overlong title to be reported, got %v", err)
+	}
+	if len(*attempted) != 0 {
+		t.Errorf("Expected no issues to be created, got %v", *attempted)
+	}
+}
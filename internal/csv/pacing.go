@@ -0,0 +1,38 @@
+package csv
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// DefaultImportDelay is the pause between issue creations unless --delay or --no-delay
+// is given; it keeps large imports clear of GitHub's secondary rate limits
+const DefaultImportDelay = 250 * time.Millisecond
+
+// importJitter returns a random extra pause of up to max. Tests replace it to make the
+// delay deterministic.
+var importJitter = func(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(max) + 1)) // #nosec G404 - Jitter does not need a secure source
+}
+
+// pace waits for delay plus up to half of it again in jitter, returning early with the
+// context's error when it is cancelled
+func pace(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay + importJitter(delay/2))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,121 @@
+package csv
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rhino11/pivot/internal"
+)
+
+// stubTimedGitHub replaces the GitHub calls, recording when each issue is created.
+// onCreate, when set, runs after each create.
+func stubTimedGitHub(t *testing.T, onCreate func()) *[]time.Time {
+	t.Helper()
+	var created []time.Time
+
+	oldCreate, oldEnsure := createGitHubIssue, ensureGitHubCredentials
+	createGitHubIssue = func(owner, repo, token string, req internal.CreateIssueRequest) (*internal.CreateIssueResponse, error) {
+		created = append(created, time.Now())
+		if onCreate != nil {
+			onCreate()
+		}
+		return &internal.CreateIssueResponse{ID: len(created), Number: len(created), Title: req.Title}, nil
+	}
+	ensureGitHubCredentials = func(owner, repo, token string) error { return nil }
+	t.Cleanup(func() {
+		createGitHubIssue, ensureGitHubCredentials = oldCreate, oldEnsure
+	})
+
+	return &created
+}
+
+// stubJitter makes the jitter a fixed fraction of its maximum
+func stubJitter(t *testing.T, fraction float64) {
+	t.Helper()
+	old := importJitter
+	importJitter = func(max time.Duration) time.Duration { return time.Duration(float64(max) * fraction) }
+	t.Cleanup(func() { importJitter = old })
+}
+
+func TestImport_DelayBetweenCreations(t *testing.T) {
+	stubJitter(t, 0)
+	created := stubTimedGitHub(t, nil)
+
+	delay := 30 * time.Millisecond
+	start := time.Now()
+	result, err := ImportCSVToGitHub(writeOnErrorCSV(t), "owner", "repo", "token", &ImportConfig{Delay: delay})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if result.Created != 3 || len(*created) != 3 {
+		t.Fatalf("Expected 3 issues created, got %d", result.Created)
+	}
+
+	if first := (*created)[0].Sub(start); first >= delay {
+		t.Errorf("Expected no delay before the first creation, waited %s", first)
+	}
+	for i := 1; i < len(*created); i++ {
+		if gap := (*created)[i].Sub((*created)[i-1]); gap < delay {
+			t.Errorf("Expected at least %s between creations %d and %d, got %s", delay, i, i+1, gap)
+		}
+	}
+}
+
+func TestImport_DelayIncludesJitter(t *testing.T) {
+	stubJitter(t, 1) // Maximum jitter: half the delay again
+	created := stubTimedGitHub(t, nil)
+
+	delay := 20 * time.Millisecond
+	if _, err := ImportCSVToGitHub(writeOnErrorCSV(t), "owner", "repo", "token", &ImportConfig{Delay: delay}); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if gap := (*created)[1].Sub((*created)[0]); gap < delay+delay/2 {
+		t.Errorf("Expected at least %s with full jitter, got %s", delay+delay/2, gap)
+	}
+}
+
+func TestImportJitter_Bounds(t *testing.T) {
+	if importJitter(0) != 0 {
+		t.Error("Expected no jitter for a zero maximum")
+	}
+	for i := 0; i < 100; i++ {
+		if jitter := importJitter(10 * time.Millisecond); jitter < 0 || jitter > 10*time.Millisecond {
+			t.Fatalf("Expected jitter within [0, 10ms], got %s", jitter)
+		}
+	}
+}
+
+func TestImport_CancelDuringDelay(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// Cancel right after the first issue is created, while the import waits
+	created := stubTimedGitHub(t, cancel)
+
+	start := time.Now()
+	result, err := ImportCSVToGitHub(writeOnErrorCSV(t), "owner", "repo", "token", &ImportConfig{Delay: time.Hour, Context: ctx})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected cancellation to interrupt the delay, took %s", elapsed)
+	}
+	if result == nil || !result.Aborted || result.Created != 1 || len(*created) != 1 {
+		t.Errorf("Expected an aborted result with 1 created issue, got %+v", result)
+	}
+}
+
+func TestImport_CancelledBeforeStart(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	created := stubTimedGitHub(t, nil)
+
+	result, err := ImportCSVToGitHub(writeOnErrorCSV(t), "owner", "repo", "token", &ImportConfig{Context: ctx})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got: %v", err)
+	}
+	if len(*created) != 0 || result.Created != 0 {
+		t.Errorf("Expected no issues created, got %d", len(*created))
+	}
+}
@@ -0,0 +1,96 @@
+package csv
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rhino11/pivot/internal"
+)
+
+func writeValidationCSV(t *testing.T) string {
+	t.Helper()
+	return writeTestCSV(t, `title,state,labels,body
+Good issue,open,triage,A body long enough to pass
+Empty body,open,triage,
+Unlabeled issue,open,,Another body long enough to pass`)
+}
+
+var testValidationRules = internal.PushValidationRules{
+	RequiredLabels: []string{"triage"},
+	MinBodyLength:  10,
+}
+
+func TestImportCSVToGitHub_ValidationSkip(t *testing.T) {
+	csvFile := writeValidationCSV(t)
+	config := &ImportConfig{
+		FilePath:    csvFile,
+		DryRun:      true,
+		Validation:  testValidationRules,
+		OnViolation: internal.ViolationSkip,
+	}
+
+	result, err := ImportCSVToGitHub(csvFile, "testowner", "testrepo", "testtoken", config)
+	if err != nil {
+		t.Fatalf("Expected violations to be skipped, got error: %v", err)
+	}
+	if result.Skipped != 3 {
+		t.Errorf("Expected 3 skipped issues (2 violations + 1 dry run), got %d", result.Skipped)
+	}
+	if len(result.SkipReasons) != 2 {
+		t.Fatalf("Expected 2 violation reports, got %d: %v", len(result.SkipReasons), result.SkipReasons)
+	}
+	if !strings.Contains(result.SkipReasons[0], "Empty body") || !strings.Contains(result.SkipReasons[0], "body is 0 characters") {
+		t.Errorf("Expected body violation for 'Empty body', got %s", result.SkipReasons[0])
+	}
+	if !strings.Contains(result.SkipReasons[1], "Unlabeled issue") || !strings.Contains(result.SkipReasons[1], "missing required label 'triage'") {
+		t.Errorf("Expected label violation for 'Unlabeled issue', got %s", result.SkipReasons[1])
+	}
+	if len(result.Errors) != 0 {
+		t.Errorf("Expected no errors for skipped violations, got %v", result.Errors)
+	}
+}
+
+func TestImportCSVToGitHub_ValidationAbort(t *testing.T) {
+	csvFile := writeValidationCSV(t)
+	config := &ImportConfig{
+		FilePath:    csvFile,
+		DryRun:      true,
+		Validation:  testValidationRules,
+		OnViolation: internal.ViolationAbort,
+	}
+
+	result, err := ImportCSVToGitHub(csvFile, "testowner", "testrepo", "testtoken", config)
+	if err == nil {
+		t.Fatal("Expected abort when issues fail validation")
+	}
+	if result != nil {
+		t.Errorf("Expected no result on abort, got %+v", result)
+	}
+	for _, expected := range []string{"2 issues failed push validation", "Empty body", "Unlabeled issue"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected error to contain %q, got: %v", expected, err)
+		}
+	}
+}
+
+func TestImportCSVToGitHub_ValidationAllPass(t *testing.T) {
+	csvFile := writeTestCSV(t, `title,state,labels,body
+Good issue,open,triage,A body long enough to pass`)
+
+	for _, mode := range []string{internal.ViolationSkip, internal.ViolationAbort} {
+		config := &ImportConfig{
+			FilePath:    csvFile,
+			DryRun:      true,
+			Validation:  testValidationRules,
+			OnViolation: mode,
+		}
+
+		result, err := ImportCSVToGitHub(csvFile, "testowner", "testrepo", "testtoken", config)
+		if err != nil {
+			t.Fatalf("Mode %s: expected passing issues to import, got error: %v", mode, err)
+		}
+		if len(result.SkipReasons) != 0 || len(result.Errors) != 0 {
+			t.Errorf("Mode %s: expected no violations, got %v %v", mode, result.SkipReasons, result.Errors)
+		}
+	}
+}
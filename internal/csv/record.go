@@ -0,0 +1,99 @@
+package csv
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"github.com/rhino11/pivot/internal"
+)
+
+// importedIssue is an issue created on GitHub by an import, with the id it had in the CSV
+type importedIssue struct {
+	csvID    int
+	issue    *Issue
+	response *internal.CreateIssueResponse
+}
+
+// recordImportedIssues stores the issues created by an import in the local database as
+// SYNCED issues, so dependency-aware commands work without a sync. The stored body is
+// the imported one, without the footer only sent to GitHub. CSV dependencies
+// refer to the id column of the imported rows, or else to the number of an issue
+// already stored for the repository; they are resolved to local IDs. References that
+// cannot be resolved are returned as warnings.
+func recordImportedIssues(db *sql.DB, owner, repo string, imported []importedIssue) ([]string, error) {
+	if len(imported) == 0 {
+		return nil, nil
+	}
+
+	projectID, err := importProjectID(db, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	// Save every issue first, dependencies may point forward in the CSV
+	localIDs := make([]int64, len(imported))
+	byCSVID := make(map[int]int)
+	for i, entry := range imported {
+		dbIssue := toDBIssue(entry.issue)
+		dbIssue.ID = entry.response.ID
+		dbIssue.Number = entry.response.Number
+		if entry.response.State != "" {
+			dbIssue.State = entry.response.State
+		}
+		dbIssue.Dependencies = ""
+
+		localID, err := internal.SaveSyncedIssue(db, projectID, dbIssue)
+		if err != nil {
+			return nil, fmt.Errorf("failed to record issue #%d: %w", entry.response.Number, err)
+		}
+		localIDs[i] = localID
+		if entry.csvID > 0 {
+			byCSVID[entry.csvID] = i
+		}
+	}
+
+	var warnings []string
+	for i, entry := range imported {
+		if len(entry.issue.Dependencies) == 0 {
+			continue
+		}
+
+		var dependsOn []int64
+		var numbers []int
+		for _, reference := range entry.issue.Dependencies {
+			var localID int64
+			number := reference
+			if j, ok := byCSVID[reference]; ok {
+				localID, number = localIDs[j], imported[j].response.Number
+			} else if localID, err = internal.LocalIDByNumber(db, projectID, reference); err != nil {
+				return warnings, err
+			}
+			if localID == 0 {
+				warnings = append(warnings, fmt.Sprintf("issue '%s' depends on %d, which is neither an imported id nor a known issue number", entry.issue.Title, reference))
+				continue
+			}
+			dependsOn = append(dependsOn, localID)
+			numbers = append(numbers, number)
+		}
+		sort.Ints(numbers)
+
+		if err := internal.SetDependencies(db, localIDs[i], dependsOn); err != nil {
+			return warnings, err
+		}
+		// Keep the dependencies column used by list --blocked in step
+		if _, err := db.Exec("UPDATE issues SET dependencies = ? WHERE rowid = ?", internal.FormatDependencies(numbers), localIDs[i]); err != nil {
+			return warnings, fmt.Errorf("failed to save dependencies of issue #%d: %w", entry.response.Number, err)
+		}
+	}
+	return warnings, nil
+}
+
+// importProjectID returns the database ID of the import's repository, adding the
+// project when it is not known yet
+func importProjectID(db *sql.DB, owner, repo string) (int64, error) {
+	if project, err := internal.FindProjectByOwnerRepo(db, owner, repo); err == nil {
+		return int64(project.ID), nil
+	}
+	return internal.CreateProject(db, &internal.ProjectConfig{Owner: owner, Repo: repo})
+}
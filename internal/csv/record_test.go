@@ -0,0 +1,140 @@
+package csv
+
+import (
+	"database/sql"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rhino11/pivot/internal"
+)
+
+// newRecordTestDB creates a database holding the already synced issue #7 of owner/repo
+func newRecordTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := internal.InitMultiProjectDBFromPath(t.TempDir() + "/pivot.db")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	projectID, err := internal.CreateProject(db, &internal.ProjectConfig{Owner: "owner", Repo: "repo"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	if err := internal.SaveIssue(db, projectID, &internal.DBIssue{ID: 7000, Number: 7, Title: "Existing", State: "open"}); err != nil {
+		t.Fatalf("Failed to save issue: %v", err)
+	}
+	return db
+}
+
+// dependencyNumbers returns the GitHub numbers of the issues the issue #number depends on
+func dependencyNumbers(t *testing.T, db *sql.DB, number int) []int {
+	t.Helper()
+	localID, err := internal.LocalIDByNumber(db, 1, number)
+	if err != nil || localID == 0 {
+		t.Fatalf("Expected issue #%d to be recorded, got %d (%v)", number, localID, err)
+	}
+	dependencies, err := internal.GetDependencies(db, localID)
+	if err != nil {
+		t.Fatalf("GetDependencies failed: %v", err)
+	}
+	var numbers []int
+	for _, dependency := range dependencies {
+		numbers = append(numbers, dependency.Number)
+	}
+	return numbers
+}
+
+func TestImportRecordsIssuesAndDependencies(t *testing.T) {
+	created := 0
+	oldCreate, oldEnsure := createGitHubIssue, ensureGitHubCredentials
+	createGitHubIssue = func(owner, repo, token string, req internal.CreateIssueRequest) (*internal.CreateIssueResponse, error) {
+		created++
+		return &internal.CreateIssueResponse{ID: 100 + created, Number: 10 + created, Title: req.Title, State: "open"}, nil
+	}
+	ensureGitHubCredentials = func(owner, repo, token string) error { return nil }
+	t.Cleanup(func() { createGitHubIssue, ensureGitHubCredentials = oldCreate, oldEnsure })
+
+	db := newRecordTestDB(t)
+	// Rows 1-3 become #11-#13; row 1 depends on rows 3 and 2 and on the synced #7
+	path := writeTestCSV(t, `id,title,dependencies
+1,Checkout,"3,2,7"
+2,Cart,
+3,Payments,99
+`)
+	result, err := ImportCSVToGitHub(path, "owner", "repo", "token", &ImportConfig{DB: db})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if result.Created != 3 {
+		t.Fatalf("Expected 3 issues created, got %d", result.Created)
+	}
+
+	if got, want := dependencyNumbers(t, db, 11), []int{7, 12, 13}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected #11 to depend on %v, got %v", want, got)
+	}
+	if got := dependencyNumbers(t, db, 12); got != nil {
+		t.Errorf("Expected #12 to have no dependencies, got %v", got)
+	}
+	if got := dependencyNumbers(t, db, 13); got != nil {
+		t.Errorf("Expected the unresolved reference of #13 to be dropped, got %v", got)
+	}
+	if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "'Payments' depends on 99") {
+		t.Errorf("Expected a warning for the unresolved reference, got %v", result.Warnings)
+	}
+
+	localID, _ := internal.LocalIDByNumber(db, 1, 11)
+	state, err := internal.GetSyncState(db, localID)
+	if err != nil || state == nil || state.SyncState != internal.SyncStateSynced {
+		t.Errorf("Expected the imported issue to be SYNCED, got %+v (%v)", state, err)
+	}
+
+	blocked, err := internal.ListIssues(db, internal.ListOptions{Blocked: true})
+	if err != nil {
+		t.Fatalf("ListIssues failed: %v", err)
+	}
+	if len(blocked) != 1 || blocked[0].Number != 11 || blocked[0].Dependencies != "7,12,13" {
+		t.Errorf("Expected #11 to be blocked by 7,12,13, got %+v", blocked)
+	}
+}
+
+func TestImportWithoutDatabaseRecordsNothing(t *testing.T) {
+	mockAssigneeImport(t, nil)
+
+	path := writeTestCSV(t, "id,title,dependencies\n1,Checkout,2\n2,Cart,\n")
+	result, err := ImportCSVToGitHub(path, "owner", "repo", "token", &ImportConfig{})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if result.Created != 2 || len(result.Warnings) != 0 {
+		t.Errorf("Expected 2 issues created without warnings, got %+v", result)
+	}
+}
+
+func TestImportRecordsBodyWithoutFooter(t *testing.T) {
+	var sent string
+	oldCreate, oldEnsure := createGitHubIssue, ensureGitHubCredentials
+	createGitHubIssue = func(owner, repo, token string, req internal.CreateIssueRequest) (*internal.CreateIssueResponse, error) {
+		sent = req.Body
+		return &internal.CreateIssueResponse{ID: 101, Number: 11, Title: req.Title, State: "open"}, nil
+	}
+	ensureGitHubCredentials = func(owner, repo, token string) error { return nil }
+	t.Cleanup(func() { createGitHubIssue, ensureGitHubCredentials = oldCreate, oldEnsure })
+
+	db := newRecordTestDB(t)
+	path := writeTestCSV(t, "title,body\nCheckout,Pay with a card\n")
+	if _, err := ImportCSVToGitHub(path, "owner", "repo", "token", &ImportConfig{DB: db, AddFooter: true, Version: "1.2.3"}); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if !strings.HasPrefix(sent, "Pay with a card") || sent == "Pay with a card" {
+		t.Fatalf("Expected the footer to be sent to GitHub, got %q", sent)
+	}
+
+	var stored string
+	if err := db.QueryRow("SELECT body FROM issues WHERE number = 11").Scan(&stored); err != nil {
+		t.Fatalf("Failed to read issue: %v", err)
+	}
+	if stored != "Pay with a card" {
+		t.Errorf("Expected the stored body to leave out the footer, got %q", stored)
+	}
+}
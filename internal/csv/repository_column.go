@@ -0,0 +1,167 @@
+package csv
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RepositoryImport is the result of importing the rows that target one repository
+type RepositoryImport struct {
+	Repository string // owner/repo as first written in the CSV
+	Result     *ImportResult
+}
+
+// rowRepository reads the owner/repo of a row from config.RepositoryColumn. A blank
+// value falls back to config.Repository.
+func rowRepository(record []string, headerIndex map[string]int, lineNum int, config *ImportConfig) (string, *CSVRowError) {
+	field := strings.ToLower(strings.TrimSpace(config.RepositoryColumn))
+	idx := headerIndex[field]
+
+	var value string
+	if idx < len(record) {
+		value = strings.TrimSpace(record[idx])
+	}
+	if value == "" {
+		value = config.Repository
+	}
+	if value == "" {
+		return "", &CSVRowError{Line: lineNum, Column: idx + 1, Field: field,
+			Cause: fmt.Errorf("repository is required (or set a fallback with --repository)")}
+	}
+	if _, _, err := splitRepository(value); err != nil {
+		return "", &CSVRowError{Line: lineNum, Column: idx + 1, Field: field, Cause: err}
+	}
+	return value, nil
+}
+
+// splitRepository splits an owner/repo value, rejecting anything GitHub would not accept as one
+func splitRepository(repository string) (string, string, error) {
+	owner, repo, ok := strings.Cut(repository, "/")
+	if !ok || owner == "" || repo == "" || strings.Contains(repo, "/") || strings.ContainsAny(repository, " \t") {
+		return "", "", fmt.Errorf("invalid repository '%s' (must be in format 'owner/repo')", repository)
+	}
+	return owner, repo, nil
+}
+
+// groupByRepository splits issues by their Repository, compared case-insensitively,
+// keeping the order in which the repositories and their rows first appear
+func groupByRepository(issues []*Issue) ([]string, map[string][]*Issue) {
+	var order []string
+	names := make(map[string]string)
+	groups := make(map[string][]*Issue)
+	for _, issue := range issues {
+		key := strings.ToLower(issue.Repository)
+		if _, ok := names[key]; !ok {
+			names[key] = issue.Repository
+			order = append(order, key)
+		}
+		groups[names[key]] = append(groups[names[key]], issue)
+	}
+
+	repositories := make([]string, len(order))
+	for i, key := range order {
+		repositories[i] = names[key]
+	}
+	return repositories, groups
+}
+
+// ImportCSVFilesByRepository parses and merges CSV files like ImportCSVFilesToGitHub,
+// but creates each row in the repository named by config.RepositoryColumn. Rows are
+// imported one repository at a time, in order of first appearance, and the results
+// are returned per repository. When an import aborts, the repositories not reached
+// yet are reported with all their rows skipped, together with the error. token
+// returns the GitHub token to use for each owner/repo.
+func ImportCSVFilesByRepository(filePaths []string, token func(owner, repo string) string, config *ImportConfig) ([]RepositoryImport, error) {
+	if config.RepositoryColumn == "" {
+		return nil, fmt.Errorf("no repository column configured")
+	}
+
+	issues, duplicates, err := ParseCSVFiles(filePaths, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV: %w", err)
+	}
+
+	onError, err := ParseOnErrorMode(config.OnError)
+	if err != nil {
+		return nil, err
+	}
+
+	repositories, groups := groupByRepository(append(append([]*Issue{}, issues...), duplicates...))
+
+	isDuplicate := make(map[*Issue]bool, len(duplicates))
+	for _, dup := range duplicates {
+		isDuplicate[dup] = true
+	}
+	split := func(repository string) (repoIssues, repoDuplicates []*Issue) {
+		for _, issue := range groups[repository] {
+			if isDuplicate[issue] {
+				repoDuplicates = append(repoDuplicates, issue)
+			} else {
+				repoIssues = append(repoIssues, issue)
+			}
+		}
+		return repoIssues, repoDuplicates
+	}
+
+	// Check the rows of every repository and the access to it before anything is
+	// created in any of them
+	violations, err := validateForPush(issues, config)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateRequests(issues); err != nil {
+		return nil, err
+	}
+	if !config.DryRun {
+		for _, repository := range repositories {
+			owner, repo, _ := splitRepository(repository)
+			if err := ensureGitHubCredentials(owner, repo, token(owner, repo)); err != nil {
+				return nil, fmt.Errorf("GitHub credential validation failed for %s: %w", repository, err)
+			}
+			if config.ValidateAssignees {
+				repoIssues, _ := split(repository)
+				if err := validateAssignees(repoIssues, violations, owner, repo, token(owner, repo)); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	var results []RepositoryImport
+	var importErr error
+	for _, repository := range repositories {
+		repoIssues, repoDuplicates := split(repository)
+
+		if importErr != nil {
+			results = append(results, RepositoryImport{Repository: repository, Result: notAttempted(repoIssues, repoDuplicates, onError)})
+			continue
+		}
+
+		owner, repo, _ := splitRepository(repository)
+		result, err := importIssues(repoIssues, repoDuplicates, owner, repo, token(owner, repo), config)
+		if err != nil {
+			importErr = fmt.Errorf("%s: %w", repository, err)
+			if result == nil {
+				result = notAttempted(repoIssues, repoDuplicates, onError)
+			}
+		}
+		results = append(results, RepositoryImport{Repository: repository, Result: result})
+	}
+
+	return results, importErr
+}
+
+// notAttempted is the result of a repository whose rows were never sent to GitHub
+// because the import stopped earlier
+func notAttempted(issues, duplicates []*Issue, onError string) *ImportResult {
+	result := &ImportResult{
+		Total:      len(issues) + len(duplicates),
+		OnError:    onError,
+		Aborted:    true,
+		Errors:     []string{},
+		Issues:     issues,
+		Duplicates: duplicates,
+	}
+	result.skipRemaining()
+	return result
+}
@@ -0,0 +1,184 @@
+package csv
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rhino11/pivot/internal"
+)
+
+// captureRepositories replaces the GitHub calls of the import and records the titles
+// created in each owner/repo and the repositories whose access was checked
+func captureRepositories(t *testing.T) (created map[string][]string, checked *[]string) {
+	t.Helper()
+	created = make(map[string][]string)
+	checked = &[]string{}
+
+	oldCreate, oldEnsure := createGitHubIssue, ensureGitHubCredentials
+	createGitHubIssue = func(owner, repo, token string, req internal.CreateIssueRequest) (*internal.CreateIssueResponse, error) {
+		if req.Title == "Rejected" {
+			return nil, fmt.Errorf("validation failed")
+		}
+		key := owner + "/" + repo
+		created[key] = append(created[key], req.Title)
+		return &internal.CreateIssueResponse{ID: len(created[key]), Number: len(created[key]), Title: req.Title}, nil
+	}
+	ensureGitHubCredentials = func(owner, repo, token string) error {
+		*checked = append(*checked, owner+"/"+repo)
+		if repo == "private" {
+			return fmt.Errorf("repository not found")
+		}
+		return nil
+	}
+	t.Cleanup(func() { createGitHubIssue, ensureGitHubCredentials = oldCreate, oldEnsure })
+
+	return created, checked
+}
+
+// staticToken returns the same token for every repository
+func staticToken(owner, repo string) string { return "token" }
+
+func TestImportCSVFilesByRepository_GroupsRowsByRepository(t *testing.T) {
+	created, checked := captureRepositories(t)
+	path := writeTestCSV(t, `title,repo
+API bug,acme/api
+Web bug,acme/web
+API feature,ACME/API
+Shared task,
+`)
+
+	config := &ImportConfig{RepositoryColumn: "Repo", Repository: "acme/web"}
+	results, err := ImportCSVFilesByRepository([]string{path}, staticToken, config)
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	if got := strings.Join(created["acme/api"], ", "); got != "API bug, API feature" {
+		t.Errorf("Expected the api rows in acme/api, got %q", got)
+	}
+	if got := strings.Join(created["acme/web"], ", "); got != "Web bug, Shared task" {
+		t.Errorf("Expected the web rows and the blank row (fallback) in acme/web, got %q", got)
+	}
+	if len(created) != 2 {
+		t.Errorf("Expected issues in 2 repositories, got %v", created)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 repository results, got %d", len(results))
+	}
+	if results[0].Repository != "acme/api" || results[0].Result.Created != 2 || results[0].Result.Total != 2 {
+		t.Errorf("Expected 2 created in acme/api first, got %s with %+v", results[0].Repository, results[0].Result)
+	}
+	if results[1].Repository != "acme/web" || results[1].Result.Created != 2 || results[1].Result.Total != 2 {
+		t.Errorf("Expected 2 created in acme/web second, got %s with %+v", results[1].Repository, results[1].Result)
+	}
+
+	// Each repository is checked once up front, and again by its own import
+	if got := strings.Join((*checked)[:2], ", "); got != "acme/api, acme/web" {
+		t.Errorf("Expected both repositories to be checked before importing, got %v", *checked)
+	}
+}
+
+func TestImportCSVFilesByRepository_TokenPerRepository(t *testing.T) {
+	used := make(map[string][]string)
+	oldCreate, oldEnsure := createGitHubIssue, ensureGitHubCredentials
+	createGitHubIssue = func(owner, repo, token string, req internal.CreateIssueRequest) (*internal.CreateIssueResponse, error) {
+		used[owner+"/"+repo] = append(used[owner+"/"+repo], token)
+		return &internal.CreateIssueResponse{ID: 1, Number: 1, Title: req.Title}, nil
+	}
+	ensureGitHubCredentials = func(owner, repo, token string) error {
+		used[owner+"/"+repo] = append(used[owner+"/"+repo], token)
+		return nil
+	}
+	t.Cleanup(func() { createGitHubIssue, ensureGitHubCredentials = oldCreate, oldEnsure })
+
+	path := writeTestCSV(t, "title,repo\nAPI bug,acme/api\nWeb bug,acme/web\n")
+	tokens := map[string]string{"acme/api": "api-token", "acme/web": "web-token"}
+	_, err := ImportCSVFilesByRepository([]string{path}, func(owner, repo string) string {
+		return tokens[owner+"/"+repo]
+	}, &ImportConfig{RepositoryColumn: "repo"})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	for repository, token := range tokens {
+		if len(used[repository]) == 0 {
+			t.Errorf("Expected GitHub calls for %s", repository)
+		}
+		for _, got := range used[repository] {
+			if got != token {
+				t.Errorf("Expected %s to use %q, got %q", repository, token, got)
+			}
+		}
+	}
+}
+
+func TestImportCSVFilesByRepository_InvalidRepository(t *testing.T) {
+	created, _ := captureRepositories(t)
+	dir := t.TempDir()
+
+	tests := map[string]string{
+		"title,repo\nOne,acme/api\nTwo,not-a-repo\n":   "invalid repository 'not-a-repo'",
+		"title,repo\nOne,acme/api/extra\n":             "invalid repository 'acme/api/extra'",
+		"title,repo\nOne,acme/ api\n":                  "invalid repository 'acme/ api'",
+		"title,repo\nOne,\n":                           "repository is required",
+		"title,repository\nOne,acme/api\n":             "repository column 'repo' not found",
+		"title,repo\nOne,acme/api\nTwo,acme/private\n": "GitHub credential validation failed for acme/private",
+	}
+	for content, want := range tests {
+		path := writeTestCSVIn(t, dir, "backlog.csv", content)
+		_, err := ImportCSVFilesByRepository([]string{path}, staticToken, &ImportConfig{RepositoryColumn: "repo"})
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error containing %q for %q, got: %v", want, content, err)
+		}
+	}
+	if len(created) != 0 {
+		t.Errorf("Expected nothing to be created when a repository is invalid, got %v", created)
+	}
+}
+
+func TestImportCSVFilesByRepository_AbortSkipsRemainingRepositories(t *testing.T) {
+	created, _ := captureRepositories(t)
+	path := writeTestCSV(t, `title,repo
+First,acme/api
+Rejected,acme/api
+Later,acme/web
+`)
+
+	results, err := ImportCSVFilesByRepository([]string{path}, staticToken, &ImportConfig{RepositoryColumn: "repo", OnError: OnErrorAbort})
+	if err == nil || !strings.Contains(err.Error(), "acme/api: import aborted") {
+		t.Fatalf("Expected the abort to name the repository, got: %v", err)
+	}
+	if len(created["acme/web"]) != 0 {
+		t.Errorf("Expected no issues in acme/web after the abort, got %v", created["acme/web"])
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("Expected results for both repositories, got %d", len(results))
+	}
+	api, web := results[0].Result, results[1].Result
+	if api.Created != 1 || len(api.Errors) != 1 || !api.Aborted {
+		t.Errorf("Expected 1 created and 1 error in acme/api, got %+v", api)
+	}
+	if web.Total != 1 || web.Skipped != 1 || !web.Aborted || web.Processed() != web.Total {
+		t.Errorf("Expected the acme/web row to be counted as skipped, got %+v", web)
+	}
+}
+
+func TestParseCSVFiles_RepositoryColumnScopesDuplicates(t *testing.T) {
+	dir := t.TempDir()
+	first := writeTestCSVIn(t, dir, "first.csv", "title,repo\nLogin fails,acme/api\n")
+	second := writeTestCSVIn(t, dir, "second.csv", "title,repo\nLogin fails,acme/web\nlogin fails,Acme/Api\n")
+
+	issues, duplicates, err := ParseCSVFiles([]string{first, second}, &ImportConfig{RepositoryColumn: "repo"})
+	if err != nil {
+		t.Fatalf("ParseCSVFiles failed: %v", err)
+	}
+	if len(issues) != 2 || len(duplicates) != 1 {
+		t.Fatalf("Expected 2 issues and 1 duplicate, got %d and %d", len(issues), len(duplicates))
+	}
+	if duplicates[0].Repository != "Acme/Api" {
+		t.Errorf("Expected the second acme/api row to be the duplicate, got %s", duplicates[0].Repository)
+	}
+}
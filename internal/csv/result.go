@@ -0,0 +1,44 @@
+package csv
+
+import "fmt"
+
+// importOutcome is the bucket of ImportResult an imported row is counted in
+type importOutcome int
+
+const (
+	outcomeCreated importOutcome = iota // A new issue was created on GitHub
+	outcomeUpdated                      // An existing GitHub issue was updated
+	outcomeSkipped                      // The row was not sent to GitHub (duplicate, violation, dry run, abort)
+	outcomeFailed                       // GitHub rejected the row; the reason is added to Errors
+)
+
+// record counts one row in exactly one bucket, so that Total always equals
+// Created+Updated+Skipped+len(Errors). reason is kept for skipped and failed rows.
+func (r *ImportResult) record(outcome importOutcome, reason string) {
+	switch outcome {
+	case outcomeCreated:
+		r.Created++
+	case outcomeUpdated:
+		r.Updated++
+	case outcomeSkipped:
+		r.Skipped++
+		if reason != "" {
+			r.SkipReasons = append(r.SkipReasons, reason)
+		}
+	case outcomeFailed:
+		r.Errors = append(r.Errors, reason)
+	}
+}
+
+// skipRemaining counts the rows an aborted import never reached as skipped
+func (r *ImportResult) skipRemaining() {
+	if remaining := r.Total - r.Processed(); remaining > 0 {
+		r.Skipped += remaining
+		r.SkipReasons = append(r.SkipReasons, fmt.Sprintf("%d issues not attempted after the import stopped", remaining))
+	}
+}
+
+// Processed returns the number of rows counted in a bucket so far
+func (r *ImportResult) Processed() int {
+	return r.Created + r.Updated + r.Skipped + len(r.Errors)
+}
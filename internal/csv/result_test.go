@@ -0,0 +1,100 @@
+package csv
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/rhino11/pivot/internal"
+)
+
+// assertBalanced checks that every row of an import was counted in exactly one bucket
+func assertBalanced(t *testing.T, result *ImportResult) {
+	t.Helper()
+	if result.Processed() != result.Total {
+		t.Errorf("Expected Total %d == Created %d + Updated %d + Skipped %d + Errors %d",
+			result.Total, result.Created, result.Updated, result.Skipped, len(result.Errors))
+	}
+}
+
+func TestImportResult_RecordMixedOutcomes(t *testing.T) {
+	result := &ImportResult{Total: 5}
+	result.record(outcomeCreated, "")
+	result.record(outcomeUpdated, "")
+	result.record(outcomeSkipped, "Skipped duplicate issue 'A'")
+	result.record(outcomeSkipped, "")
+	result.record(outcomeFailed, "Failed to create issue 'B': boom")
+
+	if result.Created != 1 || result.Updated != 1 || result.Skipped != 2 || len(result.Errors) != 1 {
+		t.Errorf("Expected 1 created, 1 updated, 2 skipped, 1 error, got %+v", result)
+	}
+	if len(result.SkipReasons) != 1 {
+		t.Errorf("Expected only the skip with a reason to be reported, got %v", result.SkipReasons)
+	}
+	assertBalanced(t, result)
+}
+
+func TestImportIssues_MixedImportIsBalanced(t *testing.T) {
+	stubGitHub(t, "Broken")
+	dir := t.TempDir()
+	first := writeTestCSVIn(t, dir, "first.csv", "title,labels,body\nCreated,triage,A body long enough to pass\nBroken,triage,A body long enough to pass\n")
+	second := writeTestCSVIn(t, dir, "second.csv", "title,labels,body\nCreated,triage,Again\nUnlabeled,,A body long enough to pass\n")
+
+	config := &ImportConfig{Validation: testValidationRules, OnViolation: internal.ViolationSkip}
+	result, err := ImportCSVFilesToGitHub([]string{first, second}, "owner", "repo", "token", config)
+	if err != nil {
+		t.Fatalf("ImportCSVFilesToGitHub failed: %v", err)
+	}
+
+	// Created once, a cross-file duplicate, a validation skip and a create failure
+	if result.Total != 4 || result.Created != 1 || result.Skipped != 2 || len(result.Errors) != 1 {
+		t.Errorf("Expected total 4, created 1, skipped 2, 1 error, got %+v", result)
+	}
+	if len(result.Duplicates) != 1 {
+		t.Errorf("Expected 1 duplicate, got %d", len(result.Duplicates))
+	}
+	assertBalanced(t, result)
+}
+
+func TestImportIssues_AbortCountsRemainingAsSkipped(t *testing.T) {
+	stubGitHub(t, "Broken issue")
+	csvFile := writeOnErrorCSV(t)
+
+	result, err := ImportCSVToGitHub(csvFile, "owner", "repo", "token", &ImportConfig{OnError: OnErrorAbort})
+	if err == nil {
+		t.Fatal("Expected abort error")
+	}
+	if result.Created != 1 || len(result.Errors) != 1 || result.Skipped != 1 {
+		t.Errorf("Expected 1 created, 1 error, 1 skipped, got %+v", result)
+	}
+	if len(result.SkipReasons) != 1 || !strings.Contains(result.SkipReasons[0], "1 issues not attempted") {
+		t.Errorf("Expected the unattempted issue to be reported, got %v", result.SkipReasons)
+	}
+	assertBalanced(t, result)
+}
+
+func TestImportIssues_CancelledImportIsBalanced(t *testing.T) {
+	stubGitHub(t, "")
+	csvFile := writeOnErrorCSV(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := ImportCSVToGitHub(csvFile, "owner", "repo", "token", &ImportConfig{Context: ctx, Delay: DefaultImportDelay})
+	if err == nil {
+		t.Fatal("Expected cancellation error")
+	}
+	assertBalanced(t, result)
+}
+
+func TestImportIssues_DryRunIsBalanced(t *testing.T) {
+	csvFile := writeOnErrorCSV(t)
+
+	result, err := ImportCSVToGitHub(csvFile, "owner", "repo", "token", &ImportConfig{DryRun: true})
+	if err != nil {
+		t.Fatalf("Dry run failed: %v", err)
+	}
+	if result.Skipped != 3 || len(result.SkipReasons) != 0 {
+		t.Errorf("Expected 3 silent dry-run skips, got %d (%v)", result.Skipped, result.SkipReasons)
+	}
+	assertBalanced(t, result)
+}
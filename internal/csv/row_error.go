@@ -0,0 +1,98 @@
+package csv
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MaxRowErrors caps how many bad rows a single parse reports
+const MaxRowErrors = 20
+
+// CSVRowError describes a problem with one row of a CSV file
+type CSVRowError struct {
+	Line   int    // 1-based line of the row in the file
+	Column int    // 1-based CSV column of the offending field (0 = the whole row or a default value)
+	Field  string // Issue field of the column, empty for problems with the whole row
+	Cause  error
+}
+
+// Error formats the row error as "line 4, column 2 (title): title is required"
+func (e *CSVRowError) Error() string {
+	switch {
+	case e.Field == "":
+		return fmt.Sprintf("line %d: %v", e.Line, e.Cause)
+	case e.Column == 0:
+		return fmt.Sprintf("line %d (%s): %v", e.Line, e.Field, e.Cause)
+	}
+	return fmt.Sprintf("line %d, column %d (%s): %v", e.Line, e.Column, e.Field, e.Cause)
+}
+
+// Unwrap returns the underlying cause
+func (e *CSVRowError) Unwrap() error {
+	return e.Cause
+}
+
+// CSVRowErrors collects the bad rows of a file, up to MaxRowErrors
+type CSVRowErrors struct {
+	Errors  []*CSVRowError
+	Omitted int // Further bad rows beyond the cap
+}
+
+// add records a row error, counting it as omitted once the cap is reached
+func (e *CSVRowErrors) add(rowErr *CSVRowError) {
+	if len(e.Errors) >= MaxRowErrors {
+		e.Omitted++
+		return
+	}
+	e.Errors = append(e.Errors, rowErr)
+}
+
+// err returns the collected errors, or nil if there are none
+func (e *CSVRowErrors) err() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Error lists every collected row error, one per line
+func (e *CSVRowErrors) Error() string {
+	if len(e.Errors) == 1 && e.Omitted == 0 {
+		return e.Errors[0].Error()
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d invalid rows:", len(e.Errors)+e.Omitted)
+	for _, rowErr := range e.Errors {
+		fmt.Fprintf(&b, "\n  %s", rowErr.Error())
+	}
+	if e.Omitted > 0 {
+		fmt.Fprintf(&b, "\n  ... and %d more", e.Omitted)
+	}
+	return b.String()
+}
+
+// Unwrap returns the collected row errors for errors.Is and errors.As
+func (e *CSVRowErrors) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, rowErr := range e.Errors {
+		errs[i] = rowErr
+	}
+	return errs
+}
+
+// readRowError converts an error from the CSV reader into a row error. record is
+// what the reader returned and expected the number of header columns.
+func readRowError(err error, line int, record []string, expected int) *CSVRowError {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		if errors.Is(parseErr.Err, csv.ErrFieldCount) {
+			return &CSVRowError{Line: parseErr.StartLine,
+				Cause: fmt.Errorf("%w (expected %d columns, got %d)", csv.ErrFieldCount, expected, len(record))}
+		}
+		return &CSVRowError{Line: parseErr.StartLine, Cause: fmt.Errorf("%w at byte %d of line %d", parseErr.Err, parseErr.Column, parseErr.Line)}
+	}
+	return &CSVRowError{Line: line, Cause: err}
+}
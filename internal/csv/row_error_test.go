@@ -0,0 +1,127 @@
+package csv
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParseCSV_ReportsEveryBadRow(t *testing.T) {
+	path := writeTestCSV(t, "state,title,state_reason\n"+
+		"open,Good,\n"+
+		"open,,\n"+ // line 3: missing title
+		"closed,\"Multi\nline\",abandoned\n"+ // lines 4-5: invalid state_reason
+		"open,Too,many,fields\n"+ // line 6: field count
+		"open,Also good,\n")
+
+	issues, err := ParseCSV(path, nil)
+	if err == nil {
+		t.Fatal("Expected an error for the bad rows")
+	}
+	if issues != nil {
+		t.Errorf("Expected no issues when rows are invalid, got %d", len(issues))
+	}
+
+	var rowErrs *CSVRowErrors
+	if !errors.As(err, &rowErrs) {
+		t.Fatalf("Expected *CSVRowErrors, got %T: %v", err, err)
+	}
+
+	expected := []CSVRowError{
+		{Line: 3, Column: 2, Field: "title"},
+		{Line: 4, Column: 3, Field: "state_reason"},
+		{Line: 6, Column: 0, Field: ""},
+	}
+	if len(rowErrs.Errors) != len(expected) {
+		t.Fatalf("Expected %d row errors, got %d: %v", len(expected), len(rowErrs.Errors), err)
+	}
+	for i, want := range expected {
+		got := rowErrs.Errors[i]
+		if got.Line != want.Line || got.Column != want.Column || got.Field != want.Field {
+			t.Errorf("Row error %d: expected line %d column %d field %q, got line %d column %d field %q",
+				i, want.Line, want.Column, want.Field, got.Line, got.Column, got.Field)
+		}
+		if got.Cause == nil {
+			t.Errorf("Row error %d: expected a cause", i)
+		}
+	}
+	if !errors.Is(rowErrs.Errors[2], csv.ErrFieldCount) {
+		t.Errorf("Expected the field count error to wrap csv.ErrFieldCount, got %v", rowErrs.Errors[2])
+	}
+
+	message := err.Error()
+	for _, want := range []string{
+		"3 invalid rows:",
+		"line 3, column 2 (title): title is required",
+		"line 4, column 3 (state_reason): invalid state_reason 'abandoned'",
+		"line 6: wrong number of fields (expected 3 columns, got 4)",
+	} {
+		if !strings.Contains(message, want) {
+			t.Errorf("Expected error to contain %q, got:\n%s", want, message)
+		}
+	}
+}
+
+func TestParseCSV_RowErrorCap(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("title,state\n")
+	for i := 0; i < MaxRowErrors+5; i++ {
+		b.WriteString(",open\n")
+	}
+
+	_, err := ParseCSV(writeTestCSV(t, b.String()), nil)
+	var rowErrs *CSVRowErrors
+	if !errors.As(err, &rowErrs) {
+		t.Fatalf("Expected *CSVRowErrors, got %T: %v", err, err)
+	}
+	if len(rowErrs.Errors) != MaxRowErrors || rowErrs.Omitted != 5 {
+		t.Errorf("Expected %d errors and 5 omitted, got %d and %d", MaxRowErrors, len(rowErrs.Errors), rowErrs.Omitted)
+	}
+	if !strings.Contains(err.Error(), fmt.Sprintf("%d invalid rows:", MaxRowErrors+5)) || !strings.Contains(err.Error(), "... and 5 more") {
+		t.Errorf("Expected the total and omitted count in the message, got:\n%s", err.Error())
+	}
+}
+
+func TestValidateCSV_ReportsEveryBadRow(t *testing.T) {
+	path := writeTestCSV(t, "title,state\nOne,open\nTwo\nThree,open,extra\nFour,\"bad\"quote\n")
+
+	err := ValidateCSV(path)
+	var rowErrs *CSVRowErrors
+	if !errors.As(err, &rowErrs) {
+		t.Fatalf("Expected *CSVRowErrors, got %T: %v", err, err)
+	}
+
+	var lines []int
+	for _, rowErr := range rowErrs.Errors {
+		lines = append(lines, rowErr.Line)
+	}
+	if fmt.Sprint(lines) != "[3 4 5]" {
+		t.Errorf("Expected bad rows on lines 3, 4 and 5, got %v (%v)", lines, err)
+	}
+	if !errors.Is(err, csv.ErrQuote) {
+		t.Errorf("Expected the quote error to be reachable with errors.Is, got %v", err)
+	}
+}
+
+func TestCSVRowError_Error(t *testing.T) {
+	tests := []struct {
+		err      *CSVRowError
+		expected string
+	}{
+		{&CSVRowError{Line: 4, Column: 2, Field: "title", Cause: errors.New("title is required")}, "line 4, column 2 (title): title is required"},
+		{&CSVRowError{Line: 4, Field: "state_reason", Cause: errors.New("bad")}, "line 4 (state_reason): bad"},
+		{&CSVRowError{Line: 7, Cause: errors.New("broken row")}, "line 7: broken row"},
+	}
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.expected {
+			t.Errorf("Expected %q, got %q", tt.expected, got)
+		}
+	}
+
+	single := &CSVRowErrors{Errors: []*CSVRowError{tests[0].err}}
+	if single.Error() != tests[0].expected {
+		t.Errorf("Expected a single row error to print on its own, got %q", single.Error())
+	}
+}
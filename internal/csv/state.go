@@ -0,0 +1,70 @@
+package csv
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Issue states GitHub knows about
+const (
+	StateOpen   = "open"
+	StateClosed = "closed"
+)
+
+// ParseDefaultState validates a --default-state value. An empty value selects open.
+func ParseDefaultState(state string) (string, error) {
+	switch normalized := strings.ToLower(strings.TrimSpace(state)); normalized {
+	case "":
+		return StateOpen, nil
+	case StateOpen, StateClosed:
+		return normalized, nil
+	default:
+		return "", fmt.Errorf("invalid default state '%s' (valid: %s, %s)", state, StateOpen, StateClosed)
+	}
+}
+
+// ParseStateMap parses --state-map values of the form "synonym=state", e.g.
+// "done=closed". Synonyms are matched case-insensitively and must map to open or closed.
+func ParseStateMap(values []string) (map[string]string, error) {
+	stateMap := make(map[string]string)
+	for _, value := range values {
+		synonym, state, found := strings.Cut(value, "=")
+		synonym = strings.ToLower(strings.TrimSpace(synonym))
+		state = strings.ToLower(strings.TrimSpace(state))
+		if !found || synonym == "" {
+			return nil, fmt.Errorf("invalid state mapping '%s' (expected value=state)", value)
+		}
+		if state != StateOpen && state != StateClosed {
+			return nil, fmt.Errorf("invalid state mapping '%s' (state must be %s or %s)", value, StateOpen, StateClosed)
+		}
+		stateMap[synonym] = state
+	}
+	return stateMap, nil
+}
+
+// normalizeState maps a raw CSV state to open or closed. Values are matched
+// case-insensitively against open, closed and the configured synonyms; blank and
+// unknown values fall back to the default state, unknown ones with a warning.
+func normalizeState(raw string, config *ImportConfig) (string, string) {
+	defaultState := StateOpen
+	var stateMap map[string]string
+	if config != nil {
+		if config.DefaultState != "" {
+			defaultState = config.DefaultState
+		}
+		stateMap = config.StateMap
+	}
+
+	value := strings.ToLower(strings.TrimSpace(raw))
+	switch {
+	case value == "":
+		return defaultState, ""
+	case value == StateOpen || value == StateClosed:
+		return value, ""
+	}
+	if state, ok := stateMap[value]; ok {
+		return state, ""
+	}
+	return defaultState, fmt.Sprintf("unknown state '%s', using '%s' (map it with --state-map %s=open|closed)",
+		strings.TrimSpace(raw), defaultState, value)
+}
@@ -0,0 +1,62 @@
+package csv
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseIssueFromRecord_StateReasonAndClosedAt(t *testing.T) {
+	headerIndex := map[string]int{"title": 0, "state": 1, "state_reason": 2, "closed_at": 3}
+
+	for _, reason := range StateReasons {
+		issue, err := parseIssueFromRecord([]string{"Done", "closed", reason, "2024-02-01T12:30:00Z"}, headerIndex, 2)
+		if err != nil {
+			t.Fatalf("Expected state_reason %s to parse, got: %v", reason, err)
+		}
+		if issue.StateReason != reason {
+			t.Errorf("Expected state_reason %s, got %s", reason, issue.StateReason)
+		}
+		if expected := time.Date(2024, 2, 1, 12, 30, 0, 0, time.UTC); !issue.ClosedAt.Equal(expected) {
+			t.Errorf("Expected closed_at %v, got %v", expected, issue.ClosedAt)
+		}
+	}
+
+	issue, err := parseIssueFromRecord([]string{"Won't fix", "closed", "NOT_PLANNED", ""}, headerIndex, 2)
+	if err != nil {
+		t.Fatalf("Expected case-insensitive state_reason, got: %v", err)
+	}
+	if issue.StateReason != "not_planned" || !issue.ClosedAt.IsZero() {
+		t.Errorf("Expected not_planned without closed_at, got %q and %v", issue.StateReason, issue.ClosedAt)
+	}
+}
+
+func TestParseIssueFromRecord_InvalidStateReason(t *testing.T) {
+	headerIndex := map[string]int{"title": 0, "state_reason": 1}
+
+	_, err := parseIssueFromRecord([]string{"Done", "abandoned"}, headerIndex, 2)
+	if err == nil || !strings.Contains(err.Error(), "invalid state_reason 'abandoned'") {
+		t.Errorf("Expected invalid state_reason error, got: %v", err)
+	}
+}
+
+func TestWriteCSV_ClosedIssueRoundTrip(t *testing.T) {
+	closedAt := time.Date(2024, 3, 5, 8, 0, 0, 0, time.UTC)
+	original := &Issue{ID: 7, Title: "Duplicate report", State: "closed", StateReason: "duplicate", ClosedAt: closedAt}
+	path := filepath.Join(t.TempDir(), "closed.csv")
+
+	if err := WriteCSV([]*Issue{original}, path, &ExportConfig{FilePath: path}); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+	issues, err := ParseCSV(path, &ImportConfig{FilePath: path})
+	if err != nil {
+		t.Fatalf("ParseCSV failed: %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d", len(issues))
+	}
+	if issues[0].StateReason != "duplicate" || !issues[0].ClosedAt.Equal(closedAt) {
+		t.Errorf("Expected duplicate closed at %v, got %q at %v", closedAt, issues[0].StateReason, issues[0].ClosedAt)
+	}
+}
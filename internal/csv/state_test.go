@@ -0,0 +1,85 @@
+package csv
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseStateMap(t *testing.T) {
+	stateMap, err := ParseStateMap([]string{"Done=closed", " todo = OPEN "})
+	if err != nil {
+		t.Fatalf("ParseStateMap failed: %v", err)
+	}
+	if stateMap["done"] != StateClosed || stateMap["todo"] != StateOpen {
+		t.Errorf("Expected done=closed and todo=open, got %v", stateMap)
+	}
+
+	for _, value := range []string{"done", "=closed", "done=finished"} {
+		if _, err := ParseStateMap([]string{value}); err == nil {
+			t.Errorf("Expected error for state mapping %q", value)
+		}
+	}
+}
+
+func TestParseDefaultState(t *testing.T) {
+	tests := map[string]string{"": StateOpen, "Closed": StateClosed, "open": StateOpen}
+	for input, expected := range tests {
+		state, err := ParseDefaultState(input)
+		if err != nil || state != expected {
+			t.Errorf("ParseDefaultState(%q): expected %s, got %s (%v)", input, expected, state, err)
+		}
+	}
+	if _, err := ParseDefaultState("todo"); err == nil {
+		t.Error("Expected error for invalid default state")
+	}
+}
+
+func TestParseCSV_NormalizesStates(t *testing.T) {
+	path := writeTestCSV(t, `title,state
+Mixed case,Open
+Upper case,OPEN
+Closed upper,CLOSED
+Mapped,Done
+Blank,
+Unknown,blocked
+`)
+	config := &ImportConfig{
+		FilePath:     path,
+		StateMap:     map[string]string{"done": StateClosed},
+		DefaultState: StateClosed,
+	}
+
+	issues, err := ParseCSV(path, config)
+	if err != nil {
+		t.Fatalf("ParseCSV failed: %v", err)
+	}
+
+	expected := []string{StateOpen, StateOpen, StateClosed, StateClosed, StateClosed, StateClosed}
+	for i, issue := range issues {
+		if issue.State != expected[i] {
+			t.Errorf("%s: expected state %s, got %s", issue.Title, expected[i], issue.State)
+		}
+	}
+
+	for _, issue := range issues[:5] {
+		if len(issue.Warnings) != 0 {
+			t.Errorf("%s: expected no warnings, got %v", issue.Title, issue.Warnings)
+		}
+	}
+	unknown := issues[5]
+	if len(unknown.Warnings) != 1 || !strings.Contains(unknown.Warnings[0], "line 7: unknown state 'blocked', using 'closed'") {
+		t.Errorf("Expected fallback warning for unknown state, got %v", unknown.Warnings)
+	}
+}
+
+func TestParseCSV_UnknownStateDefaultsToOpen(t *testing.T) {
+	path := writeTestCSV(t, "title,state\nTodo item,todo\n")
+
+	issues, err := ParseCSV(path, &ImportConfig{FilePath: path})
+	if err != nil {
+		t.Fatalf("ParseCSV failed: %v", err)
+	}
+	if issues[0].State != StateOpen || len(issues[0].Warnings) != 1 {
+		t.Errorf("Expected open with a warning, got %s and %v", issues[0].State, issues[0].Warnings)
+	}
+}
@@ -82,7 +82,7 @@ func TestWriteCSV_ErrorHandling(t *testing.T) {
 				State:          "open",
 				Priority:       "high",
 				Labels:         []string{"bug", "urgent"},
-				Assignee:       "testuser",
+				Assignees:      []string{"testuser"},
 				Milestone:      "v1.0",
 				EstimatedHours: 8,
 				StoryPoints:    5,
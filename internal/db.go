@@ -19,7 +19,7 @@ func InitDB() (*sql.DB, error) {
 		dbPath = cfg.Database
 	}
 
-	db, err := sql.Open("sqlite3", dbPath)
+	db, err := OpenSQLite(dbPath, DatabaseSettings{})
 	if err != nil {
 		return nil, err
 	}
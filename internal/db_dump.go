@@ -0,0 +1,342 @@
+package internal
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// DatabaseDumpFormat identifies pivot database dumps
+const DatabaseDumpFormat = "pivot-db-dump"
+
+// DatabaseDumpVersion is the version of the dump layout written by DumpDatabase
+const DatabaseDumpVersion = 1
+
+// sqliteTimeFormat is the layout go-sqlite3 uses when storing time values
+const sqliteTimeFormat = "2006-01-02 15:04:05.999999999-07:00"
+
+// dumpRowIDColumn is the dump column holding the rowid of tables without an INTEGER
+// PRIMARY KEY. Other tables point at those rows by rowid (issue_sync_state.issue_local_id
+// refers to issues), so a restore must keep it.
+const dumpRowIDColumn = "rowid"
+
+// redactedDumpColumns are left out of dumps so credentials never end up in a portable file
+var redactedDumpColumns = map[string]bool{"projects.token": true}
+
+// DatabaseDump is a portable, SQLite-version-independent copy of the database contents
+type DatabaseDump struct {
+	Format     string      `json:"format"`
+	Version    int         `json:"version"`
+	ExportedAt string      `json:"exported_at"`
+	Tables     []TableDump `json:"tables"`
+}
+
+// TableDump holds the rows of one table, each row listing values in Columns order
+type TableDump struct {
+	Name    string          `json:"name"`
+	Columns []string        `json:"columns"`
+	Rows    [][]interface{} `json:"rows"`
+}
+
+// RestoreResult reports what RestoreDatabase loaded
+type RestoreResult struct {
+	Tables        []TableRowCount
+	SkippedTables []string // Tables in the dump that this version's schema does not have
+}
+
+// DumpDatabase reads every table of the database. Projects come first so a restore
+// can satisfy foreign keys; the other tables follow in alphabetical order.
+func DumpDatabase(db *sql.DB) (*DatabaseDump, error) {
+	tables, err := listTables(db)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(tables, func(i, j int) bool {
+		return tables[i] == "projects" && tables[j] != "projects"
+	})
+
+	dump := &DatabaseDump{
+		Format:     DatabaseDumpFormat,
+		Version:    DatabaseDumpVersion,
+		ExportedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+	for _, table := range tables {
+		tableDump, err := dumpTable(db, table)
+		if err != nil {
+			return nil, err
+		}
+		dump.Tables = append(dump.Tables, *tableDump)
+	}
+
+	return dump, nil
+}
+
+// dumpTable reads all rows of a table in insertion order, with the rowid of tables whose
+// primary key does not already hold it
+func dumpTable(db *sql.DB, table string) (*TableDump, error) {
+	aliased, err := hasRowIDAlias(db, table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read columns of %s: %w", table, err)
+	}
+	selected := "*"
+	if !aliased {
+		selected = fmt.Sprintf("rowid AS %q, *", dumpRowIDColumn)
+	}
+
+	// #nosec G201 - table names come from sqlite_master
+	rows, err := db.Query(fmt.Sprintf("SELECT %s FROM %q ORDER BY rowid", selected, table))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read table %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read columns of %s: %w", table, err)
+	}
+
+	tableDump := &TableDump{Name: table, Columns: columns, Rows: [][]interface{}{}}
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, fmt.Errorf("failed to read row of %s: %w", table, err)
+		}
+
+		for i, value := range values {
+			switch v := value.(type) {
+			case []byte:
+				if utf8.Valid(v) {
+					values[i] = string(v)
+				} else {
+					// Binary values such as compressed raw JSON would not survive as a JSON string
+					values[i] = map[string]string{"base64": base64.StdEncoding.EncodeToString(v)}
+				}
+			case time.Time:
+				values[i] = v.Format(sqliteTimeFormat)
+			}
+			if redactedDumpColumns[table+"."+columns[i]] {
+				values[i] = nil
+			}
+		}
+		tableDump.Rows = append(tableDump.Rows, values)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read table %s: %w", table, err)
+	}
+
+	return tableDump, nil
+}
+
+// RestoreDatabase loads a dump into an initialized database in a single transaction.
+// The database must not contain any rows yet.
+func RestoreDatabase(db *sql.DB, dump *DatabaseDump) (*RestoreResult, error) {
+	if dump.Format != DatabaseDumpFormat {
+		return nil, fmt.Errorf("not a pivot database dump (format '%s')", dump.Format)
+	}
+	if dump.Version > DatabaseDumpVersion {
+		return nil, fmt.Errorf("database dump version %d is newer than supported version %d; upgrade pivot", dump.Version, DatabaseDumpVersion)
+	}
+
+	existing, err := listTables(db)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool)
+	for _, table := range existing {
+		var count int64
+		// #nosec G201 - table names come from sqlite_master
+		if err := db.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM %q", table)).Scan(&count); err != nil {
+			return nil, fmt.Errorf("failed to count rows in %s: %w", table, err)
+		}
+		if count > 0 {
+			return nil, fmt.Errorf("database is not empty (table %s has %d rows); import into a fresh database", table, count)
+		}
+		known[table] = true
+	}
+
+	result := &RestoreResult{}
+	var restore []TableDump
+	for _, table := range dump.Tables {
+		if !known[table.Name] {
+			result.SkippedTables = append(result.SkippedTables, table.Name)
+			continue
+		}
+		for _, column := range table.Columns {
+			if column == dumpRowIDColumn {
+				continue
+			}
+			exists, err := hasColumn(db, table.Name, column)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check columns of %s: %w", table.Name, err)
+			}
+			if !exists {
+				return nil, fmt.Errorf("column %s.%s does not exist in this database version; upgrade pivot", table.Name, column)
+			}
+		}
+		restore = append(restore, table)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // #nosec G104 - No-op after a successful commit
+
+	for _, table := range restore {
+		if err := restoreTable(tx, table); err != nil {
+			return nil, err
+		}
+		result.Tables = append(result.Tables, TableRowCount{Table: table.Name, Rows: int64(len(table.Rows))})
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit restore: %w", err)
+	}
+	return result, nil
+}
+
+// restoreTable inserts the rows of one table dump
+func restoreTable(tx *sql.Tx, table TableDump) error {
+	quoted := make([]string, len(table.Columns))
+	for i, column := range table.Columns {
+		quoted[i] = fmt.Sprintf("%q", column)
+	}
+
+	// #nosec G201 - table and column names were checked against the schema
+	query := fmt.Sprintf("INSERT INTO %q (%s) VALUES (%s)", table.Name, strings.Join(quoted, ", "),
+		strings.TrimSuffix(strings.Repeat("?, ", len(quoted)), ", "))
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return fmt.Errorf("failed to prepare insert into %s: %w", table.Name, err)
+	}
+	defer stmt.Close()
+
+	for i, row := range table.Rows {
+		if len(row) != len(table.Columns) {
+			return fmt.Errorf("table %s row %d has %d values, expected %d", table.Name, i+1, len(row), len(table.Columns))
+		}
+		values := make([]interface{}, len(row))
+		for j, value := range row {
+			values[j] = dumpValue(value)
+		}
+		if _, err := stmt.Exec(values...); err != nil {
+			return fmt.Errorf("failed to restore %s row %d: %w", table.Name, i+1, err)
+		}
+	}
+	return nil
+}
+
+// dumpValue converts a decoded JSON value back to the value stored in SQLite
+func dumpValue(value interface{}) interface{} {
+	if binary, ok := value.(map[string]interface{}); ok {
+		if encoded, ok := binary["base64"].(string); ok {
+			if data, err := base64.StdEncoding.DecodeString(encoded); err == nil {
+				return data
+			}
+		}
+		return value
+	}
+
+	number, ok := value.(json.Number)
+	if !ok {
+		return value
+	}
+	if i, err := number.Int64(); err == nil {
+		return i
+	}
+	if f, err := number.Float64(); err == nil {
+		return f
+	}
+	return number.String()
+}
+
+// hasRowIDAlias reports whether a table's INTEGER PRIMARY KEY column is its rowid, in
+// which case the rowid is dumped with the other columns
+func hasRowIDAlias(db *sql.DB, table string) (bool, error) {
+	// #nosec G201 - table names come from sqlite_master
+	rows, err := db.Query(fmt.Sprintf("PRAGMA table_info(%q)", table))
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	var keys int
+	var integerKey bool
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, dataType string
+		var defaultValue interface{}
+		if err := rows.Scan(&cid, &name, &dataType, &notNull, &defaultValue, &pk); err != nil {
+			return false, err
+		}
+		if pk > 0 {
+			keys++
+			integerKey = strings.EqualFold(dataType, "INTEGER")
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return false, err
+	}
+	return keys == 1 && integerKey, nil
+}
+
+// listTables returns the names of the user tables in the database
+func listTables(db *sql.DB) ([]string, error) {
+	rows, err := db.Query("SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan table name: %w", err)
+		}
+		tables = append(tables, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+	return tables, nil
+}
+
+// WriteDatabaseDump writes a dump as indented JSON with owner-only permissions
+func WriteDatabaseDump(path string, dump *DatabaseDump) error {
+	data, err := json.MarshalIndent(dump, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode database dump: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("failed to write database dump: %w", err)
+	}
+	return nil
+}
+
+// ReadDatabaseDump reads a dump written by WriteDatabaseDump, keeping numbers exact
+func ReadDatabaseDump(path string) (*DatabaseDump, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - User controls dump file path
+	if err != nil {
+		return nil, fmt.Errorf("failed to read database dump: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var dump DatabaseDump
+	if err := decoder.Decode(&dump); err != nil {
+		return nil, fmt.Errorf("failed to parse database dump: %w", err)
+	}
+	return &dump, nil
+}
@@ -0,0 +1,258 @@
+package internal
+
+import (
+	"database/sql"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newPopulatedDumpDB creates a database with rows in every table
+func newPopulatedDumpDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db := newTestMultiProjectDB(t)
+	if err := InitSyncStateSchema(db); err != nil {
+		t.Fatalf("Failed to create sync state schema: %v", err)
+	}
+
+	alpha, err := CreateProject(db, &ProjectConfig{Owner: "org", Repo: "alpha", Token: "ghp_secret"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	beta, err := CreateProject(db, &ProjectConfig{Owner: "org", Repo: "beta", Path: "/work/beta"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	issues := []struct {
+		projectID int64
+		issue     *DBIssue
+	}{
+		{alpha, &DBIssue{ID: 101, Number: 1, Title: "Login fails", Body: "Steps:\n1. \"quoted\" ünïcode", State: "open",
+			Labels: "bug,auth", Assignees: "octocat", CreatedAt: "2024-01-01T00:00:00Z", UpdatedAt: "2024-01-02T00:00:00Z",
+			StoryPoints: 5, EstimatedHours: 8, Epic: "Auth", AcceptanceCriteria: "Users can log in", Type: "Bug"}},
+		{alpha, &DBIssue{ID: 102, Number: 2, Title: "Old request", State: "closed", ClosedAt: "2024-02-01T00:00:00Z"}},
+		{beta, &DBIssue{ID: 201, Number: 1, Title: "Docs", State: "open", Milestone: "v1.0"}},
+	}
+	for _, entry := range issues {
+		if err := SaveIssue(db, entry.projectID, entry.issue); err != nil {
+			t.Fatalf("Failed to save issue: %v", err)
+		}
+	}
+
+	githubID := int64(101)
+	if err := CreateSyncState(db, 1, SyncStateSynced, &githubID); err != nil {
+		t.Fatalf("Failed to create sync state: %v", err)
+	}
+	if err := CreateSyncState(db, 2, SyncStateLocalOnly, nil); err != nil {
+		t.Fatalf("Failed to create sync state: %v", err)
+	}
+	if err := SaveReactions(db, alpha, 1, &Reactions{TotalCount: 3, PlusOne: 2, Heart: 1}); err != nil {
+		t.Fatalf("Failed to save reactions: %v", err)
+	}
+	if err := SaveRawIssue(db, alpha, 1, []byte(`{"number": 1, "title": "Login fails"}`), true); err != nil {
+		t.Fatalf("Failed to save raw JSON: %v", err)
+	}
+	if err := SaveRawIssue(db, beta, 1, []byte(`{"number": 1, "title": "Docs"}`), false); err != nil {
+		t.Fatalf("Failed to save raw JSON: %v", err)
+	}
+	if err := SaveSyncCheckpoint(db, beta, 4); err != nil {
+		t.Fatalf("Failed to save checkpoint: %v", err)
+	}
+	if err := SaveSyncWatermark(db, alpha, "2024-01-02T00:00:00Z"); err != nil {
+		t.Fatalf("Failed to save watermark: %v", err)
+	}
+
+	return db
+}
+
+// tableContents returns the JSON encoding of each table's columns and rows
+func tableContents(t *testing.T, dump *DatabaseDump) map[string]string {
+	t.Helper()
+	contents := make(map[string]string)
+	for _, table := range dump.Tables {
+		data, err := json.Marshal(struct {
+			Columns []string
+			Rows    [][]interface{}
+		}{table.Columns, table.Rows})
+		if err != nil {
+			t.Fatalf("Failed to encode table %s: %v", table.Name, err)
+		}
+		contents[table.Name] = string(data)
+	}
+	return contents
+}
+
+func TestDatabaseDump_RoundTrip(t *testing.T) {
+	source := newPopulatedDumpDB(t)
+
+	dump, err := DumpDatabase(source)
+	if err != nil {
+		t.Fatalf("DumpDatabase failed: %v", err)
+	}
+	if dump.Tables[0].Name != "projects" {
+		t.Errorf("Expected projects to be dumped first, got %s", dump.Tables[0].Name)
+	}
+
+	path := filepath.Join(t.TempDir(), "dump.json")
+	if err := WriteDatabaseDump(path, dump); err != nil {
+		t.Fatalf("WriteDatabaseDump failed: %v", err)
+	}
+	loaded, err := ReadDatabaseDump(path)
+	if err != nil {
+		t.Fatalf("ReadDatabaseDump failed: %v", err)
+	}
+
+	target := newTestMultiProjectDB(t)
+	if err := InitSyncStateSchema(target); err != nil {
+		t.Fatalf("Failed to create sync state schema: %v", err)
+	}
+	result, err := RestoreDatabase(target, loaded)
+	if err != nil {
+		t.Fatalf("RestoreDatabase failed: %v", err)
+	}
+	if len(result.SkippedTables) != 0 {
+		t.Errorf("Expected no skipped tables, got %v", result.SkippedTables)
+	}
+
+	restored, err := DumpDatabase(target)
+	if err != nil {
+		t.Fatalf("DumpDatabase of restored database failed: %v", err)
+	}
+
+	expected := tableContents(t, dump)
+	actual := tableContents(t, restored)
+	for _, table := range []string{"projects", "issues", "issue_sync_state", "issue_reactions", "issue_raw", "sync_checkpoints", "sync_watermarks"} {
+		if expected[table] == "" || strings.Contains(expected[table], `"Rows":[]`) {
+			t.Errorf("Expected source table %s to have rows", table)
+		}
+		if expected[table] != actual[table] {
+			t.Errorf("Table %s differs after round trip:\nexpected %s\ngot      %s", table, expected[table], actual[table])
+		}
+	}
+	if len(expected) != len(actual) {
+		t.Errorf("Expected %d tables after round trip, got %d", len(expected), len(actual))
+	}
+
+	// Restored rows are usable through the normal accessors
+	projectID, err := getProjectID(target, "org", "alpha")
+	if err != nil {
+		t.Fatalf("Failed to find restored project: %v", err)
+	}
+	issues, err := GetIssuesForProject(target, projectID)
+	if err != nil || len(issues) != 2 {
+		t.Fatalf("Expected 2 restored issues, got %d (%v)", len(issues), err)
+	}
+	if issues[0].Type != "Bug" && issues[1].Type != "Bug" {
+		t.Errorf("Expected issue type to be restored, got %+v", issues)
+	}
+	reactions, err := GetReactions(target, projectID, 1)
+	if err != nil || reactions == nil || reactions.PlusOne != 2 {
+		t.Errorf("Expected restored reactions, got %+v (%v)", reactions, err)
+	}
+	if raw, err := GetRawIssue(target, projectID, 1); err != nil || string(raw) != `{"number": 1, "title": "Login fails"}` {
+		t.Errorf("Expected restored compressed raw JSON, got %q (%v)", raw, err)
+	}
+	if since, err := GetSyncWatermark(target, projectID); err != nil || since != "2024-01-02T00:00:00Z" {
+		t.Errorf("Expected restored watermark, got '%s' (%v)", since, err)
+	}
+}
+
+func TestDatabaseDump_KeepsIssueRowIDs(t *testing.T) {
+	source := newTestMultiProjectDB(t)
+	projectID, err := CreateProject(source, &ProjectConfig{Owner: "org", Repo: "alpha"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	first, err := CreateLocalIssue(source, projectID, &DBIssue{Title: "Deleted draft"})
+	if err != nil {
+		t.Fatalf("CreateLocalIssue failed: %v", err)
+	}
+	second, err := CreateLocalIssue(source, projectID, &DBIssue{Title: "Kept draft"})
+	if err != nil {
+		t.Fatalf("CreateLocalIssue failed: %v", err)
+	}
+	// Leave a gap in the rowids, as deleting a local issue does
+	if _, err := source.Exec("DELETE FROM issues WHERE rowid = ?", first); err != nil {
+		t.Fatalf("Failed to delete issue: %v", err)
+	}
+	if _, err := source.Exec("DELETE FROM issue_sync_state WHERE issue_local_id = ?", first); err != nil {
+		t.Fatalf("Failed to delete sync state: %v", err)
+	}
+
+	dump, err := DumpDatabase(source)
+	if err != nil {
+		t.Fatalf("DumpDatabase failed: %v", err)
+	}
+	target := newTestMultiProjectDB(t)
+	if err := InitSyncStateSchema(target); err != nil {
+		t.Fatalf("Failed to create sync state schema: %v", err)
+	}
+	if _, err := RestoreDatabase(target, dump); err != nil {
+		t.Fatalf("RestoreDatabase failed: %v", err)
+	}
+
+	// The sync state still joins to its issue
+	pushable, err := ListPushableIssues(target)
+	if err != nil {
+		t.Fatalf("ListPushableIssues failed: %v", err)
+	}
+	if len(pushable) != 1 || pushable[0].LocalID != second || pushable[0].Issue.Title != "Kept draft" {
+		t.Errorf("Expected issue %d to stay pushable, got %+v", second, pushable)
+	}
+}
+
+func TestDatabaseDump_RedactsTokens(t *testing.T) {
+	dump, err := DumpDatabase(newPopulatedDumpDB(t))
+	if err != nil {
+		t.Fatalf("DumpDatabase failed: %v", err)
+	}
+	data, _ := json.Marshal(dump)
+	if strings.Contains(string(data), "ghp_secret") {
+		t.Error("Expected project tokens to be left out of the dump")
+	}
+}
+
+func TestRestoreDatabase_Errors(t *testing.T) {
+	dump, err := DumpDatabase(newPopulatedDumpDB(t))
+	if err != nil {
+		t.Fatalf("DumpDatabase failed: %v", err)
+	}
+
+	// Restoring over existing data is refused
+	if _, err := RestoreDatabase(newPopulatedDumpDB(t), dump); err == nil || !strings.Contains(err.Error(), "not empty") {
+		t.Errorf("Expected non-empty database error, got: %v", err)
+	}
+
+	if _, err := RestoreDatabase(newTestMultiProjectDB(t), &DatabaseDump{Format: "other"}); err == nil {
+		t.Error("Expected error for a foreign format")
+	}
+	if _, err := RestoreDatabase(newTestMultiProjectDB(t), &DatabaseDump{Format: DatabaseDumpFormat, Version: DatabaseDumpVersion + 1}); err == nil {
+		t.Error("Expected error for a newer dump version")
+	}
+
+	unknownColumn := &DatabaseDump{Format: DatabaseDumpFormat, Version: 1, Tables: []TableDump{
+		{Name: "projects", Columns: []string{"id", "owner", "repo", "color"}, Rows: [][]interface{}{{json.Number("1"), "org", "alpha", "red"}}},
+	}}
+	if _, err := RestoreDatabase(newTestMultiProjectDB(t), unknownColumn); err == nil || !strings.Contains(err.Error(), "projects.color") {
+		t.Errorf("Expected unknown column error, got: %v", err)
+	}
+
+	// Tables this version does not know about are skipped, the rest is restored
+	target := newTestMultiProjectDB(t)
+	withExtra := &DatabaseDump{Format: DatabaseDumpFormat, Version: 1, Tables: []TableDump{
+		{Name: "projects", Columns: []string{"id", "owner", "repo"}, Rows: [][]interface{}{{json.Number("7"), "org", "alpha"}}},
+		{Name: "issue_history", Columns: []string{"id"}, Rows: [][]interface{}{{json.Number("1")}}},
+	}}
+	result, err := RestoreDatabase(target, withExtra)
+	if err != nil {
+		t.Fatalf("RestoreDatabase failed: %v", err)
+	}
+	if strings.Join(result.SkippedTables, ",") != "issue_history" {
+		t.Errorf("Expected issue_history to be skipped, got %v", result.SkippedTables)
+	}
+	if id, err := getProjectID(target, "org", "alpha"); err != nil || id != 7 {
+		t.Errorf("Expected project restored with id 7, got %d (%v)", id, err)
+	}
+}
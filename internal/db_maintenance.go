@@ -0,0 +1,119 @@
+package internal
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+)
+
+// TableRowCount is the number of rows in one database table
+type TableRowCount struct {
+	Table string
+	Rows  int64
+}
+
+// DatabaseInfo summarizes the size and contents of a database file
+type DatabaseInfo struct {
+	Path      string
+	SizeBytes int64
+	Tables    []TableRowCount
+}
+
+// VacuumResult reports the database file size before and after a VACUUM
+type VacuumResult struct {
+	BeforeBytes int64
+	AfterBytes  int64
+}
+
+// Reclaimed returns the number of bytes freed by the VACUUM
+func (r *VacuumResult) Reclaimed() int64 {
+	if r.AfterBytes >= r.BeforeBytes {
+		return 0
+	}
+	return r.BeforeBytes - r.AfterBytes
+}
+
+// ConfiguredDBPath returns the resolved path of the database named in the configuration
+func ConfiguredDBPath() (string, error) {
+	config, err := LoadMultiProjectConfig()
+	if err != nil {
+		return "", fmt.Errorf("failed to load config: %w", err)
+	}
+	return ResolveDatabasePath(config.Global.Database)
+}
+
+// DatabaseFileSize returns the on-disk size of a database, including its write-ahead log
+func DatabaseFileSize(path string) (int64, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat database: %w", err)
+	}
+	size := info.Size()
+	if wal, err := os.Stat(path + "-wal"); err == nil {
+		size += wal.Size()
+	}
+	return size, nil
+}
+
+// VacuumDatabase rebuilds the database file to reclaim unused space
+func VacuumDatabase(db *sql.DB, path string) (*VacuumResult, error) {
+	// Flush the write-ahead log first so the before size is comparable
+	if _, err := db.Exec("PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		return nil, fmt.Errorf("failed to checkpoint database: %w", err)
+	}
+	before, err := DatabaseFileSize(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := db.Exec("VACUUM"); err != nil {
+		return nil, fmt.Errorf("failed to vacuum database: %w", err)
+	}
+	if _, err := db.Exec("PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		return nil, fmt.Errorf("failed to checkpoint database: %w", err)
+	}
+
+	after, err := DatabaseFileSize(path)
+	if err != nil {
+		return nil, err
+	}
+	return &VacuumResult{BeforeBytes: before, AfterBytes: after}, nil
+}
+
+// GetDatabaseInfo returns the file size and per-table row counts of a database
+func GetDatabaseInfo(db *sql.DB, path string) (*DatabaseInfo, error) {
+	size, err := DatabaseFileSize(path)
+	if err != nil {
+		return nil, err
+	}
+
+	tables, err := listTables(db)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &DatabaseInfo{Path: path, SizeBytes: size}
+	for _, table := range tables {
+		var count int64
+		// #nosec G201 - table names come from sqlite_master
+		if err := db.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM %q", table)).Scan(&count); err != nil {
+			return nil, fmt.Errorf("failed to count rows in %s: %w", table, err)
+		}
+		info.Tables = append(info.Tables, TableRowCount{Table: table, Rows: count})
+	}
+	return info, nil
+}
+
+// FormatByteSize formats a byte count using binary units, e.g. 1.5 MiB
+func FormatByteSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
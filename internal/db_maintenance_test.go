@@ -0,0 +1,117 @@
+package internal
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestVacuumDatabase_ShrinksAfterDeletes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pivot.db")
+	db, err := InitMultiProjectDBFromPath(path)
+	if err != nil {
+		t.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer db.Close()
+
+	projectID, err := CreateProject(db, &ProjectConfig{Owner: "org", Repo: "repo"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	body := strings.Repeat("x", 4096)
+	for i := 1; i <= 500; i++ {
+		issue := &DBIssue{ID: i, Number: i, Title: "Bulk issue", Body: body, State: "open"}
+		if err := SaveIssue(db, projectID, issue); err != nil {
+			t.Fatalf("Failed to save issue: %v", err)
+		}
+	}
+	if _, err := db.Exec("DELETE FROM issues"); err != nil {
+		t.Fatalf("Failed to delete issues: %v", err)
+	}
+
+	result, err := VacuumDatabase(db, path)
+	if err != nil {
+		t.Fatalf("Expected vacuum to succeed, got %v", err)
+	}
+	if result.AfterBytes >= result.BeforeBytes {
+		t.Errorf("Expected file to shrink, got %d -> %d bytes", result.BeforeBytes, result.AfterBytes)
+	}
+	if result.Reclaimed() != result.BeforeBytes-result.AfterBytes {
+		t.Errorf("Expected reclaimed %d, got %d", result.BeforeBytes-result.AfterBytes, result.Reclaimed())
+	}
+}
+
+func TestVacuumResult_ReclaimedNeverNegative(t *testing.T) {
+	result := &VacuumResult{BeforeBytes: 100, AfterBytes: 200}
+	if result.Reclaimed() != 0 {
+		t.Errorf("Expected 0 reclaimed, got %d", result.Reclaimed())
+	}
+}
+
+func TestGetDatabaseInfo_RowCounts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pivot.db")
+	db, err := InitMultiProjectDBFromPath(path)
+	if err != nil {
+		t.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer db.Close()
+
+	projectID, err := CreateProject(db, &ProjectConfig{Owner: "org", Repo: "repo"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	for i := 1; i <= 3; i++ {
+		if err := SaveIssue(db, projectID, &DBIssue{ID: i, Number: i, Title: "Issue", State: "open"}); err != nil {
+			t.Fatalf("Failed to save issue: %v", err)
+		}
+	}
+
+	info, err := GetDatabaseInfo(db, path)
+	if err != nil {
+		t.Fatalf("Expected info to succeed, got %v", err)
+	}
+	if info.SizeBytes <= 0 {
+		t.Errorf("Expected positive size, got %d", info.SizeBytes)
+	}
+
+	counts := map[string]int64{}
+	for _, table := range info.Tables {
+		counts[table.Table] = table.Rows
+	}
+	if counts["issues"] != 3 {
+		t.Errorf("Expected 3 issues, got %d", counts["issues"])
+	}
+	if counts["projects"] != 1 {
+		t.Errorf("Expected 1 project, got %d", counts["projects"])
+	}
+	for table := range counts {
+		if strings.HasPrefix(table, "sqlite_") {
+			t.Errorf("Expected internal table %s to be skipped", table)
+		}
+	}
+}
+
+func TestDatabaseFileSize_MissingFile(t *testing.T) {
+	if _, err := DatabaseFileSize(filepath.Join(t.TempDir(), "missing.db")); err == nil {
+		t.Error("Expected error for missing database file")
+	}
+}
+
+func TestFormatByteSize(t *testing.T) {
+	tests := []struct {
+		bytes    int64
+		expected string
+	}{
+		{0, "0 B"},
+		{512, "512 B"},
+		{1024, "1.0 KiB"},
+		{1536, "1.5 KiB"},
+		{10 * 1024 * 1024, "10.0 MiB"},
+	}
+	for _, tt := range tests {
+		if got := FormatByteSize(tt.bytes); got != tt.expected {
+			t.Errorf("FormatByteSize(%d): expected %s, got %s", tt.bytes, tt.expected, got)
+		}
+	}
+}
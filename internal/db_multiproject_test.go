@@ -2,7 +2,10 @@ package internal
 
 import (
 	"database/sql"
+	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	_ "github.com/mattn/go-sqlite3"
@@ -414,16 +417,12 @@ func TestConvertIssueToDBIssue(t *testing.T) {
 		CreatedAt: "2025-01-01T00:00:00Z",
 		UpdatedAt: "2025-01-02T00:00:00Z",
 		ClosedAt:  "2025-01-03T00:00:00Z",
-		Labels: []struct {
-			Name string `json:"name"`
-		}{
+		Labels: []IssueLabel{
 			{Name: "bug"},
 			{Name: "enhancement"},
 			{Name: "high-priority"},
 		},
-		Assignees: []struct {
-			Login string `json:"login"`
-		}{
+		Assignees: []IssueUser{
 			{Login: "user1"},
 			{Login: "user2"},
 		},
@@ -475,12 +474,8 @@ func TestConvertIssueToDBIssue_EmptyLabelsAndAssignees(t *testing.T) {
 		CreatedAt: "2025-01-01T00:00:00Z",
 		UpdatedAt: "2025-01-01T00:00:00Z",
 		ClosedAt:  "",
-		Labels: []struct {
-			Name string `json:"name"`
-		}{},
-		Assignees: []struct {
-			Login string `json:"login"`
-		}{},
+		Labels:    []IssueLabel{},
+		Assignees: []IssueUser{},
 	}
 
 	dbIssue := ConvertIssueToDBIssue(issue)
@@ -499,14 +494,10 @@ func TestConvertIssueToDBIssue_SingleLabelAndAssignee(t *testing.T) {
 		ID:     1,
 		Number: 1,
 		Title:  "Single",
-		Labels: []struct {
-			Name string `json:"name"`
-		}{
+		Labels: []IssueLabel{
 			{Name: "solo-label"},
 		},
-		Assignees: []struct {
-			Login string `json:"login"`
-		}{
+		Assignees: []IssueUser{
 			{Login: "solo-user"},
 		},
 	}
@@ -520,3 +511,246 @@ func TestConvertIssueToDBIssue_SingleLabelAndAssignee(t *testing.T) {
 		t.Errorf("Expected assignees 'solo-user', got '%s'", dbIssue.Assignees)
 	}
 }
+
+// TestToCreateRequest tests converting a fully populated DBIssue into a create request
+func TestToCreateRequest(t *testing.T) {
+	issue := &DBIssue{
+		ID:        123,
+		Number:    7,
+		Title:     "Full Issue",
+		Body:      "Full body",
+		State:     "open",
+		Labels:    "bug, enhancement,,high-priority",
+		Assignees: "user1,user2",
+		Milestone: "4",
+	}
+
+	request := ToCreateRequest(issue)
+
+	if request.Title != "Full Issue" {
+		t.Errorf("Expected title 'Full Issue', got '%s'", request.Title)
+	}
+	if request.Body != "Full body" {
+		t.Errorf("Expected body 'Full body', got '%s'", request.Body)
+	}
+	expectedLabels := []string{"bug", "enhancement", "high-priority"}
+	if len(request.Labels) != len(expectedLabels) {
+		t.Fatalf("Expected labels %v, got %v", expectedLabels, request.Labels)
+	}
+	for i, label := range expectedLabels {
+		if request.Labels[i] != label {
+			t.Errorf("Expected label %d to be '%s', got '%s'", i, label, request.Labels[i])
+		}
+	}
+	if len(request.Assignees) != 2 || request.Assignees[0] != "user1" || request.Assignees[1] != "user2" {
+		t.Errorf("Expected assignees [user1 user2], got %v", request.Assignees)
+	}
+	if request.Milestone != 4 {
+		t.Errorf("Expected milestone 4, got %d", request.Milestone)
+	}
+}
+
+// TestToCreateRequest_Minimal tests converting an issue with only a title
+func TestToCreateRequest_Minimal(t *testing.T) {
+	request := ToCreateRequest(&DBIssue{Title: "Minimal"})
+
+	if request.Title != "Minimal" {
+		t.Errorf("Expected title 'Minimal', got '%s'", request.Title)
+	}
+	if request.Body != "" {
+		t.Errorf("Expected empty body, got '%s'", request.Body)
+	}
+	if request.Labels != nil {
+		t.Errorf("Expected nil labels, got %v", request.Labels)
+	}
+	if request.Assignees != nil {
+		t.Errorf("Expected nil assignees, got %v", request.Assignees)
+	}
+	if request.Milestone != 0 {
+		t.Errorf("Expected no milestone, got %d", request.Milestone)
+	}
+}
+
+// TestToCreateRequest_MilestoneTitle tests that non-numeric milestones are not sent as numbers
+func TestToCreateRequest_MilestoneTitle(t *testing.T) {
+	request := ToCreateRequest(&DBIssue{Title: "With milestone title", Milestone: "v1.0"})
+
+	if request.Milestone != 0 {
+		t.Errorf("Expected milestone title to be left unset, got %d", request.Milestone)
+	}
+}
+
+// newTestMultiProjectDB creates an initialized multi-project database in a temporary directory
+func newTestMultiProjectDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := InitMultiProjectDBFromPath(t.TempDir() + "/pivot.db")
+	if err != nil {
+		t.Fatalf("Failed to initialize test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// TestEnsureDirectoryExists_PlatformSeparators tests directory creation from paths built with filepath
+func TestEnsureDirectoryExists_PlatformSeparators(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "a", "b", "pivot.db")
+
+	if err := ensureDirectoryExists(dbPath); err != nil {
+		t.Fatalf("ensureDirectoryExists failed: %v", err)
+	}
+	if info, err := os.Stat(filepath.Dir(dbPath)); err != nil || !info.IsDir() {
+		t.Errorf("Expected directory %s to be created", filepath.Dir(dbPath))
+	}
+}
+
+// issueRowIDs returns the local ID of each stored issue of a project, keyed by title
+func issueRowIDs(t *testing.T, db *sql.DB, projectID int64) map[string]int64 {
+	t.Helper()
+	rows, err := db.Query("SELECT rowid, title FROM issues WHERE project_id = ?", projectID)
+	if err != nil {
+		t.Fatalf("Failed to query issues: %v", err)
+	}
+	defer rows.Close()
+	ids := map[string]int64{}
+	for rows.Next() {
+		var id int64
+		var title string
+		if err := rows.Scan(&id, &title); err != nil {
+			t.Fatalf("Failed to scan issue: %v", err)
+		}
+		ids[title] = id
+	}
+	return ids
+}
+
+func TestSaveIssue_UpsertsRemoteIssueByNumber(t *testing.T) {
+	db := newTestMultiProjectDB(t)
+	projectID, err := CreateProject(db, &ProjectConfig{Owner: "org", Repo: "repo"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	if err := SaveIssue(db, projectID, &DBIssue{ID: 101, Number: 1, Title: "First title", State: "open"}); err != nil {
+		t.Fatalf("Failed to save issue: %v", err)
+	}
+	if err := SaveIssue(db, projectID, &DBIssue{ID: 102, Number: 2, Title: "Other", State: "open"}); err != nil {
+		t.Fatalf("Failed to save issue: %v", err)
+	}
+	before := issueRowIDs(t, db, projectID)
+
+	// Saving the same issue again updates the stored row in place
+	if err := SaveIssue(db, projectID, &DBIssue{ID: 101, Number: 1, Title: "Renamed", State: "closed"}); err != nil {
+		t.Fatalf("Failed to save issue: %v", err)
+	}
+	after := issueRowIDs(t, db, projectID)
+	if len(after) != 2 {
+		t.Fatalf("Expected 2 stored issues, got %v", after)
+	}
+	if after["Renamed"] != before["First title"] || after["Other"] != before["Other"] {
+		t.Errorf("Expected the local IDs to be kept, got %v before and %v after", before, after)
+	}
+
+	issues, err := GetIssuesForProject(db, projectID)
+	if err != nil {
+		t.Fatalf("Failed to get issues: %v", err)
+	}
+	if issues[0].Number != 1 || issues[0].State != "closed" {
+		t.Errorf("Expected issue #1 to be updated, got %+v", issues[0])
+	}
+
+	// The number identifies the issue even when the GitHub ID differs
+	if err := SaveIssue(db, projectID, &DBIssue{ID: 999, Number: 2, Title: "Other again", State: "open"}); err != nil {
+		t.Fatalf("Failed to save issue: %v", err)
+	}
+	if ids := issueRowIDs(t, db, projectID); len(ids) != 2 || ids["Other again"] != before["Other"] {
+		t.Errorf("Expected issue #2 to be replaced in place, got %v", ids)
+	}
+}
+
+func TestSaveIssue_LocalOnlyIssuesGetStableIDs(t *testing.T) {
+	db := newTestMultiProjectDB(t)
+	projectID, err := CreateProject(db, &ProjectConfig{Owner: "org", Repo: "repo"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	first, err := saveIssue(db, projectID, &DBIssue{Title: "Draft one", State: "open"})
+	if err != nil {
+		t.Fatalf("Failed to save local issue: %v", err)
+	}
+	if err := SaveIssue(db, projectID, &DBIssue{ID: 101, Number: 1, Title: "Remote", State: "open"}); err != nil {
+		t.Fatalf("Failed to save issue: %v", err)
+	}
+	second, err := saveIssue(db, projectID, &DBIssue{Title: "Draft two", State: "open"})
+	if err != nil {
+		t.Fatalf("Failed to save local issue: %v", err)
+	}
+	if first == 0 || second <= first {
+		t.Errorf("Expected increasing local IDs, got %d and %d", first, second)
+	}
+
+	// Local-only issues neither collide with each other nor move when others are saved
+	if err := SaveIssue(db, projectID, &DBIssue{ID: 101, Number: 1, Title: "Remote updated", State: "open"}); err != nil {
+		t.Fatalf("Failed to save issue: %v", err)
+	}
+	ids := issueRowIDs(t, db, projectID)
+	if len(ids) != 3 || ids["Draft one"] != first || ids["Draft two"] != second {
+		t.Errorf("Expected both drafts at their local IDs %d and %d, got %v", first, second, ids)
+	}
+
+	var githubID sql.NullInt64
+	if err := db.QueryRow("SELECT github_id FROM issues WHERE rowid = ?", first).Scan(&githubID); err != nil {
+		t.Fatalf("Failed to read issue: %v", err)
+	}
+	if githubID.Valid {
+		t.Errorf("Expected a local-only issue without a GitHub ID, got %d", githubID.Int64)
+	}
+}
+
+func TestParseIssueOrder(t *testing.T) {
+	order, err := ParseIssueOrder(" Updated ", true)
+	if err != nil || order != (IssueOrder{Field: "updated", Desc: true}) {
+		t.Errorf("Expected updated descending, got %+v, %v", order, err)
+	}
+	if clause := order.orderByClause(); clause != "ORDER BY updated_at DESC, number DESC, rowid DESC" {
+		t.Errorf("Unexpected clause: %s", clause)
+	}
+	if clause := DefaultIssueOrder.orderByClause(); clause != "ORDER BY number ASC, rowid ASC" {
+		t.Errorf("Unexpected default clause: %s", clause)
+	}
+	if _, err := ParseIssueOrder("priority", false); err == nil || !strings.Contains(err.Error(), "supported: created, number, reactions, title, updated") {
+		t.Errorf("Expected an error listing the fields, got %v", err)
+	}
+}
+
+func TestGetIssuesForProjectOrdered_BreaksTiesByNumber(t *testing.T) {
+	db := newTestMultiProjectDB(t)
+	projectID, err := CreateProject(db, &ProjectConfig{Owner: "org", Repo: "repo"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	for _, number := range []int{3, 1, 2} {
+		issue := &DBIssue{ID: 100 + number, Number: number, Title: "Same", State: "open", CreatedAt: "2024-01-01T00:00:00Z"}
+		if err := SaveIssue(db, projectID, issue); err != nil {
+			t.Fatalf("Failed to save issue: %v", err)
+		}
+	}
+
+	for _, order := range []IssueOrder{{Field: "title"}, {Field: "created", Desc: true}} {
+		issues, err := GetIssuesForProjectOrdered(db, projectID, order)
+		if err != nil {
+			t.Fatalf("GetIssuesForProjectOrdered failed: %v", err)
+		}
+		var numbers []int
+		for _, issue := range issues {
+			numbers = append(numbers, issue.Number)
+		}
+		want := []int{1, 2, 3}
+		if order.Desc {
+			want = []int{3, 2, 1}
+		}
+		if fmt.Sprint(numbers) != fmt.Sprint(want) {
+			t.Errorf("%+v: expected ties in number order %v, got %v", order, want, numbers)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDedupeList(t *testing.T) {
+	got := DedupeList([]string{"bug", " Bug", "", "urgent", "BUG", "ui ", "Urgent"})
+	expected := []string{"bug", "urgent", "ui"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+	if got := DedupeList(nil); len(got) != 0 {
+		t.Errorf("Expected empty result, got %v", got)
+	}
+}
+
+func TestConvertIssueToDBIssue_DedupesLabelsAndAssignees(t *testing.T) {
+	issue := &Issue{
+		ID:        1,
+		Number:    1,
+		Title:     "Duplicates",
+		State:     "open",
+		Labels:    []IssueLabel{{Name: "bug"}, {Name: "Bug"}, {Name: "api"}},
+		Assignees: []IssueUser{{Login: "octocat"}, {Login: "OctoCat"}},
+	}
+
+	dbIssue := ConvertIssueToDBIssue(issue)
+	if dbIssue.Labels != "api,bug" {
+		t.Errorf("Expected labels 'api,bug', got '%s'", dbIssue.Labels)
+	}
+	if dbIssue.Assignees != "octocat" {
+		t.Errorf("Expected assignees 'octocat', got '%s'", dbIssue.Assignees)
+	}
+}
+
+func TestToCreateRequest_DedupesLabelsAndAssignees(t *testing.T) {
+	request := ToCreateRequest(&DBIssue{Title: "Duplicates", Labels: "ui,bug,UI,bug", Assignees: "alice,Alice,bob"})
+
+	if !reflect.DeepEqual(request.Labels, []string{"ui", "bug"}) {
+		t.Errorf("Expected labels [ui bug], got %v", request.Labels)
+	}
+	if !reflect.DeepEqual(request.Assignees, []string{"alice", "bob"}) {
+		t.Errorf("Expected assignees [alice bob], got %v", request.Assignees)
+	}
+}
@@ -0,0 +1,173 @@
+package internal
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// dependencyReference matches an issue reference such as "#12" or "12"
+var dependencyReference = regexp.MustCompile(`#?(\d+)`)
+
+// ParseDependencies extracts the issue numbers from a dependency list such as
+// "#12, #15" or "12 15", sorted and without duplicates
+func ParseDependencies(value string) []int {
+	seen := make(map[int]bool)
+	var numbers []int
+	for _, match := range dependencyReference.FindAllStringSubmatch(value, -1) {
+		number, err := strconv.Atoi(match[1])
+		if err != nil || number <= 0 || seen[number] {
+			continue
+		}
+		seen[number] = true
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+	return numbers
+}
+
+// FormatDependencies joins issue numbers into the comma-separated form stored in the
+// dependencies column
+func FormatDependencies(numbers []int) string {
+	parts := make([]string, len(numbers))
+	for i, number := range numbers {
+		parts[i] = strconv.Itoa(number)
+	}
+	return strings.Join(parts, ",")
+}
+
+// dependsOn is an SQL condition that holds when issue a depends on issue b. The
+// dependencies column lists GitHub issue numbers, so it only refers to pushed issues;
+// links to issues that are not pushed yet are kept by local ID in issue_dependencies.
+const dependsOn = "a.project_id = b.project_id AND a.rowid != b.rowid AND (" +
+	"(COALESCE(b.number, 0) > 0 AND (',' || COALESCE(a.dependencies, '') || ',') LIKE ('%,' || b.number || ',%')) OR " +
+	"EXISTS (SELECT 1 FROM issue_dependencies d WHERE d.issue_local_id = a.rowid AND d.depends_on_local_id = b.rowid))"
+
+// blockedCondition restricts a query on issues to open issues with an open dependency
+const blockedCondition = ` AND issues.state = 'open' AND EXISTS (
+	SELECT 1 FROM issues a, issues b
+	WHERE a.rowid = issues.rowid AND b.state = 'open' AND ` + dependsOn + `)`
+
+// blockingCondition restricts a query on issues to open issues an open issue depends on
+const blockingCondition = ` AND issues.state = 'open' AND EXISTS (
+	SELECT 1 FROM issues a, issues b
+	WHERE b.rowid = issues.rowid AND a.state = 'open' AND ` + dependsOn + `)`
+
+// ResolveDependencies resolves the depends_on references of an issue of a project:
+// a reference is the number of an issue stored for the project, or else the local ID
+// of one of its issues that is not pushed yet. It returns the GitHub numbers for the
+// dependencies column and the local IDs of all referenced issues.
+func ResolveDependencies(db *sql.DB, projectID int64, references []int) ([]int, []int64, error) {
+	var numbers []int
+	var localIDs []int64
+	for _, reference := range references {
+		localID, err := LocalIDByNumber(db, projectID, reference)
+		if err != nil {
+			return nil, nil, err
+		}
+		if localID > 0 {
+			numbers = append(numbers, reference)
+			localIDs = append(localIDs, localID)
+			continue
+		}
+
+		err = db.QueryRow("SELECT rowid FROM issues WHERE rowid = ? AND project_id = ? AND COALESCE(number, 0) = 0",
+			reference, projectID).Scan(&localID)
+		if err == sql.ErrNoRows {
+			return nil, nil, fmt.Errorf("dependency %d is neither an issue number nor the local ID of an unpushed issue", reference)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to look up dependency %d: %w", reference, err)
+		}
+		localIDs = append(localIDs, localID)
+	}
+	return numbers, localIDs, nil
+}
+
+// IssueDependency is an issue that another issue depends on
+type IssueDependency struct {
+	LocalID int64
+	Number  int // 0 while the issue has not been pushed
+	Title   string
+	State   string
+}
+
+// InitDependencySchema creates the issue_dependencies table, which links issues by
+// local ID (the issues rowid)
+func InitDependencySchema(db *sql.DB) error {
+	schema := `
+	CREATE TABLE IF NOT EXISTS issue_dependencies (
+		issue_local_id INTEGER NOT NULL,
+		depends_on_local_id INTEGER NOT NULL,
+		PRIMARY KEY(issue_local_id, depends_on_local_id)
+	);
+	CREATE INDEX IF NOT EXISTS idx_issue_dependencies_depends_on ON issue_dependencies(depends_on_local_id);`
+
+	if _, err := db.Exec(schema); err != nil {
+		return fmt.Errorf("failed to create issue_dependencies table: %w", err)
+	}
+	return nil
+}
+
+// SetDependencies replaces the issues an issue depends on
+func SetDependencies(db *sql.DB, issueLocalID int64, dependsOn []int64) error {
+	if err := InitDependencySchema(db); err != nil {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // #nosec G104 - No-op after a successful commit
+
+	if _, err := tx.Exec("DELETE FROM issue_dependencies WHERE issue_local_id = ?", issueLocalID); err != nil {
+		return fmt.Errorf("failed to clear dependencies of issue %d: %w", issueLocalID, err)
+	}
+	for _, dependency := range dependsOn {
+		if dependency == issueLocalID {
+			continue
+		}
+		if _, err := tx.Exec("INSERT OR IGNORE INTO issue_dependencies (issue_local_id, depends_on_local_id) VALUES (?, ?)",
+			issueLocalID, dependency); err != nil {
+			return fmt.Errorf("failed to save dependency of issue %d: %w", issueLocalID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit dependencies: %w", err)
+	}
+	return nil
+}
+
+// GetDependencies returns the issues an issue depends on, ordered by local ID.
+// Dependencies on issues that no longer exist are left out.
+func GetDependencies(db *sql.DB, issueLocalID int64) ([]IssueDependency, error) {
+	if err := InitDependencySchema(db); err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Query(`
+		SELECT d.depends_on_local_id, COALESCE(i.number, 0), COALESCE(i.title, ''), COALESCE(i.state, '')
+		FROM issue_dependencies d
+		JOIN issues i ON i.rowid = d.depends_on_local_id
+		WHERE d.issue_local_id = ?
+		ORDER BY d.depends_on_local_id`, issueLocalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dependencies: %w", err)
+	}
+	defer rows.Close()
+
+	var dependencies []IssueDependency
+	for rows.Next() {
+		var dependency IssueDependency
+		if err := rows.Scan(&dependency.LocalID, &dependency.Number, &dependency.Title, &dependency.State); err != nil {
+			return nil, fmt.Errorf("failed to scan dependency: %w", err)
+		}
+		dependencies = append(dependencies, dependency)
+	}
+	return dependencies, rows.Err()
+}
@@ -0,0 +1,205 @@
+package internal
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestParseDependencies(t *testing.T) {
+	tests := map[string][]int{
+		"#12, #15":       {12, 15},
+		"15 12 #12":      {12, 15},
+		"3,#1;2":         {1, 2, 3},
+		"none":           nil,
+		"#0":             nil,
+		"":               nil,
+		"org/repo#7, #8": {7, 8},
+	}
+	for input, want := range tests {
+		if got := ParseDependencies(input); !reflect.DeepEqual(got, want) {
+			t.Errorf("ParseDependencies(%q): expected %v, got %v", input, want, got)
+		}
+	}
+	if got := FormatDependencies([]int{3, 12}); got != "3,12" {
+		t.Errorf("Expected 3,12, got %s", got)
+	}
+}
+
+func TestApplyAgileMetadata_Dependencies(t *testing.T) {
+	for _, line := range []string{"Depends on: #12, #15", "**Dependencies:** 15 12", "- Blocked by: #12 #15"} {
+		issue := &DBIssue{Body: "Details\n" + line + "\n"}
+		ApplyAgileMetadata(issue)
+		if issue.Dependencies != "12,15" {
+			t.Errorf("Expected dependencies 12,15 from %q, got %q", line, issue.Dependencies)
+		}
+	}
+
+	// Prose that merely starts with a field name is not metadata
+	for _, line := range []string{"Dependencies were upgraded in #12", "Depends on whether #12 lands", "Blocked by the outage in #12"} {
+		issue := &DBIssue{Body: "Details\n" + line + "\n", Dependencies: "7"}
+		ApplyAgileMetadata(issue)
+		if issue.Dependencies != "7" {
+			t.Errorf("Expected %q to leave the dependencies alone, got %q", line, issue.Dependencies)
+		}
+	}
+}
+
+// TestListIssues_BlockedAndBlocking seeds this graph in project alpha:
+//
+//	#101 open   depends on #102 (open) and #103 (closed) -> blocked by #102
+//	#102 open                                             -> blocking #101
+//	#103 closed
+//	#104 open   depends on #103 (closed)                  -> not blocked
+//	#105 closed depends on #102                           -> closed, ignored
+//	local open  depends on #104                           -> blocked by #104
+//	#106 open   depends on the local issue (by local ID)  -> blocked by the local issue
+//
+// and #1 in project beta depending on #104, which only exists in alpha.
+func TestListIssues_BlockedAndBlocking(t *testing.T) {
+	db := newTestMultiProjectDB(t)
+	alpha, err := CreateProject(db, &ProjectConfig{Owner: "org", Repo: "alpha"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	beta, err := CreateProject(db, &ProjectConfig{Owner: "org", Repo: "beta"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	save := func(projectID int64, number int, state, dependencies string) {
+		t.Helper()
+		issue := &DBIssue{ID: int(projectID)*1000 + number, Number: number, Title: "Issue " + strconv.Itoa(number),
+			State: state, Dependencies: dependencies}
+		if err := SaveIssue(db, projectID, issue); err != nil {
+			t.Fatalf("Failed to save issue: %v", err)
+		}
+	}
+	save(alpha, 101, "open", "102,103")
+	save(alpha, 102, "open", "")
+	save(alpha, 103, "closed", "")
+	save(alpha, 104, "open", "103")
+	save(alpha, 105, "closed", "102")
+	localID, err := CreateLocalIssue(db, alpha, &DBIssue{Title: "Local work", Dependencies: "104"})
+	if err != nil {
+		t.Fatalf("Failed to create local issue: %v", err)
+	}
+	save(alpha, 106, "open", "")
+	issue106, _ := LocalIDByNumber(db, alpha, 106)
+	if err := SetDependencies(db, issue106, []int64{localID}); err != nil {
+		t.Fatalf("SetDependencies failed: %v", err)
+	}
+	save(beta, 1, "open", "104")
+
+	titles := func(opts ListOptions) []string {
+		t.Helper()
+		issues, err := ListIssues(db, opts)
+		if err != nil {
+			t.Fatalf("ListIssues failed: %v", err)
+		}
+		var result []string
+		for _, issue := range issues {
+			result = append(result, issue.Title)
+		}
+		return result
+	}
+
+	if got, want := titles(ListOptions{Blocked: true}), []string{"Local work", "Issue 101", "Issue 106"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected blocked issues %v, got %v", want, got)
+	}
+	if got, want := titles(ListOptions{Blocking: true}), []string{"Local work", "Issue 102", "Issue 104"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected blocking issues %v, got %v", want, got)
+	}
+	if got, want := titles(ListOptions{Blocked: true, Blocking: true}), []string{"Local work"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected issues both blocked and blocking %v, got %v", want, got)
+	}
+	if got := titles(ListOptions{Blocked: true, ProjectID: beta}); got != nil {
+		t.Errorf("Expected no blocked issues in beta, got %v", got)
+	}
+
+	issues, err := ListIssues(db, ListOptions{Number: 101})
+	if err != nil || len(issues) != 1 || issues[0].Dependencies != "102,103" {
+		t.Errorf("Expected the dependencies to be listed, got %+v (%v)", issues, err)
+	}
+}
+
+func TestSetAndGetDependencies(t *testing.T) {
+	db := newTestMultiProjectDB(t)
+	projectID, err := CreateProject(db, &ProjectConfig{Owner: "org", Repo: "alpha"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	syncedID, err := SaveSyncedIssue(db, projectID, &DBIssue{ID: 7000, Number: 7, Title: "Existing", State: "open"})
+	if err != nil {
+		t.Fatalf("SaveSyncedIssue failed: %v", err)
+	}
+	if state, err := GetSyncState(db, syncedID); err != nil || state.SyncState != SyncStateSynced || *state.GitHubID != 7000 {
+		t.Errorf("Expected SYNCED with github_id 7000, got %+v (%v)", state, err)
+	}
+	localID, err := CreateLocalIssue(db, projectID, &DBIssue{Title: "Local"})
+	if err != nil {
+		t.Fatalf("Failed to create local issue: %v", err)
+	}
+
+	// Self references are dropped, references to deleted issues are not returned
+	if err := SetDependencies(db, localID, []int64{syncedID, 999, localID}); err != nil {
+		t.Fatalf("SetDependencies failed: %v", err)
+	}
+	dependencies, err := GetDependencies(db, localID)
+	if err != nil {
+		t.Fatalf("GetDependencies failed: %v", err)
+	}
+	want := []IssueDependency{{LocalID: syncedID, Number: 7, Title: "Existing", State: "open"}}
+	if !reflect.DeepEqual(dependencies, want) {
+		t.Errorf("Expected %+v, got %+v", want, dependencies)
+	}
+
+	if err := SetDependencies(db, localID, nil); err != nil {
+		t.Fatalf("SetDependencies failed: %v", err)
+	}
+	if dependencies, _ := GetDependencies(db, localID); dependencies != nil {
+		t.Errorf("Expected the dependencies to be replaced, got %+v", dependencies)
+	}
+	if missing, err := LocalIDByNumber(db, projectID, 8); err != nil || missing != 0 {
+		t.Errorf("Expected 0 for an unknown number, got %d (%v)", missing, err)
+	}
+}
+
+func TestListIssues_DependencyNamespaces(t *testing.T) {
+	db := newTestMultiProjectDB(t)
+	projectID, err := CreateProject(db, &ProjectConfig{Owner: "org", Repo: "alpha"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	localID, err := CreateLocalIssue(db, projectID, &DBIssue{Title: "Local work"})
+	if err != nil {
+		t.Fatalf("Failed to create local issue: %v", err)
+	}
+
+	// #20 depends on the GitHub issue numbered like the local issue's local ID, which
+	// is not stored; the unpushed local issue must not block it
+	number := int(localID)
+	if err := SaveIssue(db, projectID, &DBIssue{ID: 2000, Number: 20, Title: "Issue 20", State: "open", Dependencies: strconv.Itoa(number)}); err != nil {
+		t.Fatalf("Failed to save issue: %v", err)
+	}
+	blocked, err := ListIssues(db, ListOptions{Blocked: true})
+	if err != nil {
+		t.Fatalf("ListIssues failed: %v", err)
+	}
+	if len(blocked) != 0 {
+		t.Errorf("Expected a GitHub number not to match a local ID, got %+v", blocked)
+	}
+
+	// Issue file references resolve numbers first, then local IDs of unpushed issues
+	numbers, localIDs, err := ResolveDependencies(db, projectID, []int{20, number})
+	if err != nil {
+		t.Fatalf("ResolveDependencies failed: %v", err)
+	}
+	issue20, _ := LocalIDByNumber(db, projectID, 20)
+	if !reflect.DeepEqual(numbers, []int{20}) || !reflect.DeepEqual(localIDs, []int64{issue20, localID}) {
+		t.Errorf("Expected number 20 and local IDs %d and %d, got %v and %v", issue20, localID, numbers, localIDs)
+	}
+	if _, _, err := ResolveDependencies(db, projectID, []int{99}); err == nil {
+		t.Error("Expected an unknown reference to be rejected")
+	}
+}
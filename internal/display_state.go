@@ -0,0 +1,81 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StateMapping derives a local display state from a set of labels. An open issue
+// carrying every label in Labels is shown with State instead of "open".
+type StateMapping struct {
+	Labels []string `yaml:"labels"`
+	State  string   `yaml:"state"`
+}
+
+// ParseStateMappings parses --map-state values of the form "label=state" or "label+label=state"
+func ParseStateMappings(values []string) ([]StateMapping, error) {
+	var mappings []StateMapping
+	for _, value := range values {
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[1]) == "" {
+			return nil, fmt.Errorf("invalid state mapping '%s' (expected label=state)", value)
+		}
+		var labels []string
+		for _, label := range strings.Split(parts[0], "+") {
+			if label = strings.TrimSpace(label); label != "" {
+				labels = append(labels, label)
+			}
+		}
+		if len(labels) == 0 {
+			return nil, fmt.Errorf("invalid state mapping '%s' (expected label=state)", value)
+		}
+		mappings = append(mappings, StateMapping{Labels: labels, State: strings.TrimSpace(parts[1])})
+	}
+	return mappings, nil
+}
+
+// DisplayState returns the state to show for an issue: the first mapping whose labels
+// are all present on an open issue, otherwise GitHub's own state
+func DisplayState(issue DBIssue, mappings []StateMapping) string {
+	if issue.State != "open" || len(mappings) == 0 {
+		return issue.State
+	}
+
+	present := make(map[string]bool)
+	for _, label := range splitCommaList(issue.Labels) {
+		present[strings.ToLower(label)] = true
+	}
+
+	for _, mapping := range mappings {
+		matched := len(mapping.Labels) > 0
+		for _, label := range mapping.Labels {
+			if !present[strings.ToLower(label)] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return mapping.State
+		}
+	}
+
+	return issue.State
+}
+
+// ConfiguredStateMappings returns the state mappings from the configuration, if any
+func ConfiguredStateMappings() []StateMapping {
+	config, err := LoadMultiProjectConfig()
+	if err != nil {
+		return nil
+	}
+	return config.States
+}
+
+// CountDisplayStates counts issues by their display state
+func CountDisplayStates(issues []DBIssue, mappings []StateMapping) map[string]int {
+	counts := make(map[string]int)
+	for _, issue := range issues {
+		counts[DisplayState(issue, mappings)]++
+	}
+	return counts
+}
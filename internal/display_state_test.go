@@ -0,0 +1,68 @@
+package internal
+
+import (
+	"testing"
+)
+
+func TestDisplayState(t *testing.T) {
+	mappings := []StateMapping{
+		{Labels: []string{"in-review", "blocked"}, State: "blocked_review"},
+		{Labels: []string{"in-review"}, State: "in_review"},
+		{Labels: []string{"in-progress"}, State: "in_progress"},
+	}
+
+	tests := []struct {
+		issue    DBIssue
+		expected string
+	}{
+		{DBIssue{State: "open", Labels: "bug,in-progress"}, "in_progress"},
+		{DBIssue{State: "open", Labels: "In-Review"}, "in_review"},
+		{DBIssue{State: "open", Labels: "blocked,in-review"}, "blocked_review"},
+		{DBIssue{State: "open", Labels: "bug"}, "open"},
+		{DBIssue{State: "closed", Labels: "in-progress"}, "closed"},
+	}
+
+	for _, tt := range tests {
+		if got := DisplayState(tt.issue, mappings); got != tt.expected {
+			t.Errorf("DisplayState(%s %q): expected %s, got %s", tt.issue.State, tt.issue.Labels, tt.expected, got)
+		}
+	}
+
+	if got := DisplayState(DBIssue{State: "open", Labels: "in-progress"}, nil); got != "open" {
+		t.Errorf("Expected GitHub state without mappings, got %s", got)
+	}
+}
+
+func TestParseStateMappings(t *testing.T) {
+	mappings, err := ParseStateMappings([]string{"in-progress=in_progress", "in-review + blocked = blocked_review"})
+	if err != nil {
+		t.Fatalf("ParseStateMappings failed: %v", err)
+	}
+	if len(mappings) != 2 {
+		t.Fatalf("Expected 2 mappings, got %d", len(mappings))
+	}
+	if mappings[1].State != "blocked_review" || len(mappings[1].Labels) != 2 || mappings[1].Labels[1] != "blocked" {
+		t.Errorf("Unexpected label set mapping: %+v", mappings[1])
+	}
+
+	for _, invalid := range []string{"in-progress", "=state", "label="} {
+		if _, err := ParseStateMappings([]string{invalid}); err == nil {
+			t.Errorf("Expected error for %q", invalid)
+		}
+	}
+}
+
+func TestCountDisplayStates(t *testing.T) {
+	mappings := []StateMapping{{Labels: []string{"in-progress"}, State: "in_progress"}}
+	issues := []DBIssue{
+		{State: "open", Labels: "in-progress"},
+		{State: "open", Labels: "in-progress,bug"},
+		{State: "open"},
+		{State: "closed", Labels: "in-progress"},
+	}
+
+	counts := CountDisplayStates(issues, mappings)
+	if counts["in_progress"] != 2 || counts["open"] != 1 || counts["closed"] != 1 {
+		t.Errorf("Unexpected counts: %v", counts)
+	}
+}
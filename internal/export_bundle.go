@@ -0,0 +1,136 @@
+package internal
+
+import (
+	"archive/zip"
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// ExportFile is a single rendered file of a multi-file export
+type ExportFile struct {
+	Name    string `json:"name"`
+	Project string `json:"project"`
+	Issues  int    `json:"issues"`
+	Content []byte `json:"-"`
+}
+
+// ExportManifest describes the contents of an export archive
+type ExportManifest struct {
+	GeneratedAt string       `json:"generated_at"`
+	Files       []ExportFile `json:"files"`
+}
+
+// ProjectIssues groups the issues of a single project
+type ProjectIssues struct {
+	Project ProjectConfig
+	Issues  []DBIssue
+}
+
+// GetIssuesByProject retrieves all issues grouped by project
+func GetIssuesByProject(db *sql.DB) ([]ProjectIssues, error) {
+	return GetIssuesByProjectOrdered(db, DefaultIssueOrder)
+}
+
+// GetIssuesByProjectOrdered groups the stored issues by project like GetIssuesByProject,
+// with the issues of each project in the given order
+func GetIssuesByProjectOrdered(db *sql.DB, order IssueOrder) ([]ProjectIssues, error) {
+	projects, err := ListProjects(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []ProjectIssues
+	for _, project := range projects {
+		issues, err := GetIssuesForProjectOrdered(db, int64(project.ID), order)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get issues for %s/%s: %w", project.Owner, project.Repo, err)
+		}
+		groups = append(groups, ProjectIssues{Project: project, Issues: issues})
+	}
+
+	return groups, nil
+}
+
+// SplitExportFileName returns the file name used for one project of a split export,
+// e.g. report.md becomes report-owner-repo.md
+func SplitExportFileName(baseName string, project ProjectConfig) string {
+	ext := filepath.Ext(baseName)
+	stem := strings.TrimSuffix(filepath.Base(baseName), ext)
+	return fmt.Sprintf("%s-%s-%s%s", stem, project.Owner, project.Repo, ext)
+}
+
+// RenderSplitExport renders a template once per project, producing one export file per project
+func RenderSplitExport(tmpl *template.Template, groups []ProjectIssues, baseName string) ([]ExportFile, error) {
+	var files []ExportFile
+	for _, group := range groups {
+		var buf bytes.Buffer
+		if err := RenderIssueTemplate(&buf, tmpl, group.Issues); err != nil {
+			return nil, fmt.Errorf("failed to render %s/%s: %w", group.Project.Owner, group.Project.Repo, err)
+		}
+		files = append(files, ExportFile{
+			Name:    SplitExportFileName(baseName, group.Project),
+			Project: group.Project.Owner + "/" + group.Project.Repo,
+			Issues:  len(group.Issues),
+			Content: buf.Bytes(),
+		})
+	}
+	return files, nil
+}
+
+// WriteExportFiles writes export files into a directory
+func WriteExportFiles(dir string, files []ExportFile) error {
+	for _, file := range files {
+		path := filepath.Join(dir, file.Name)
+		if err := os.WriteFile(path, file.Content, 0644); err != nil { // #nosec G306 - Export files are meant to be shared
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// WriteExportZip bundles export files into a zip archive together with a manifest.json
+func WriteExportZip(archivePath string, files []ExportFile) error {
+	archive, err := os.Create(archivePath) // #nosec G304 - User controls archive path
+	if err != nil {
+		return fmt.Errorf("failed to create archive: %w", err)
+	}
+	defer archive.Close()
+
+	writer := zip.NewWriter(archive)
+	for _, file := range files {
+		entry, err := writer.Create(file.Name)
+		if err != nil {
+			return fmt.Errorf("failed to add %s to archive: %w", file.Name, err)
+		}
+		if _, err := entry.Write(file.Content); err != nil {
+			return fmt.Errorf("failed to write %s to archive: %w", file.Name, err)
+		}
+	}
+
+	manifest, err := json.MarshalIndent(ExportManifest{
+		GeneratedAt: time.Now().Format(time.RFC3339),
+		Files:       files,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	entry, err := writer.Create("manifest.json")
+	if err != nil {
+		return fmt.Errorf("failed to add manifest to archive: %w", err)
+	}
+	if _, err := entry.Write(manifest); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to finalize archive: %w", err)
+	}
+	return nil
+}
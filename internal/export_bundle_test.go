@@ -0,0 +1,112 @@
+package internal
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+// seedTwoProjects creates two projects with issues in a fresh database
+func seedTwoProjects(t *testing.T) []ProjectIssues {
+	t.Helper()
+	db := newTestMultiProjectDB(t)
+
+	seed := map[string][]DBIssue{
+		"alpha": {{ID: 1, Number: 1, Title: "Alpha one", State: "open"}, {ID: 2, Number: 2, Title: "Alpha two", State: "closed"}},
+		"beta":  {{ID: 3, Number: 1, Title: "Beta one", State: "open"}},
+	}
+	for _, repo := range []string{"alpha", "beta"} {
+		projectID, err := CreateProject(db, &ProjectConfig{Owner: "org", Repo: repo})
+		if err != nil {
+			t.Fatalf("Failed to create project: %v", err)
+		}
+		for i := range seed[repo] {
+			if err := SaveIssue(db, projectID, &seed[repo][i]); err != nil {
+				t.Fatalf("Failed to save issue: %v", err)
+			}
+		}
+	}
+
+	groups, err := GetIssuesByProject(db)
+	if err != nil {
+		t.Fatalf("GetIssuesByProject failed: %v", err)
+	}
+	return groups
+}
+
+func TestSplitExportFileName(t *testing.T) {
+	project := ProjectConfig{Owner: "org", Repo: "alpha"}
+	if got := SplitExportFileName("out/report.md", project); got != "report-org-alpha.md" {
+		t.Errorf("Expected report-org-alpha.md, got %s", got)
+	}
+	if got := SplitExportFileName("issues", project); got != "issues-org-alpha" {
+		t.Errorf("Expected issues-org-alpha, got %s", got)
+	}
+}
+
+func TestWriteExportZip_TwoProjects(t *testing.T) {
+	groups := seedTwoProjects(t)
+	if len(groups) != 2 {
+		t.Fatalf("Expected 2 project groups, got %d", len(groups))
+	}
+
+	tmpl := template.Must(template.New("csv").Funcs(templateFuncs).Parse(
+		"number,title\n{{range .Issues}}{{.Number}},{{.Title}}\n{{end}}"))
+
+	files, err := RenderSplitExport(tmpl, groups, "issues.csv")
+	if err != nil {
+		t.Fatalf("RenderSplitExport failed: %v", err)
+	}
+
+	archivePath := filepath.Join(t.TempDir(), "export.zip")
+	if err := WriteExportZip(archivePath, files); err != nil {
+		t.Fatalf("WriteExportZip failed: %v", err)
+	}
+
+	reader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		t.Fatalf("Failed to open archive: %v", err)
+	}
+	defer reader.Close()
+
+	entries := make(map[string]string)
+	for _, file := range reader.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatalf("Failed to open entry %s: %v", file.Name, err)
+		}
+		content, _ := io.ReadAll(rc)
+		rc.Close()
+		entries[file.Name] = string(content)
+	}
+
+	expected := []string{"issues-org-alpha.csv", "issues-org-beta.csv", "manifest.json"}
+	if len(entries) != len(expected) {
+		t.Errorf("Expected %d entries, got %d: %v", len(expected), len(entries), entries)
+	}
+	for _, name := range expected {
+		if _, ok := entries[name]; !ok {
+			t.Errorf("Expected archive entry %s", name)
+		}
+	}
+
+	if !strings.Contains(entries["issues-org-alpha.csv"], "2,Alpha two") {
+		t.Errorf("Expected alpha export to contain its issues, got:\n%s", entries["issues-org-alpha.csv"])
+	}
+	if strings.Contains(entries["issues-org-beta.csv"], "Alpha") {
+		t.Errorf("Expected beta export to contain only beta issues, got:\n%s", entries["issues-org-beta.csv"])
+	}
+
+	var manifest ExportManifest
+	if err := json.Unmarshal([]byte(entries["manifest.json"]), &manifest); err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+	if len(manifest.Files) != 2 || manifest.Files[0].Project != "org/alpha" || manifest.Files[0].Issues != 2 ||
+		manifest.Files[1].Project != "org/beta" || manifest.Files[1].Issues != 1 {
+		t.Errorf("Unexpected manifest contents: %+v", manifest.Files)
+	}
+}
@@ -6,30 +6,71 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	neturl "net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
 )
 
+// githubAPIURL is the base URL of the GitHub REST API. Tests point it at a mock server.
+var githubAPIURL = "https://api.github.com"
+
+// SetGitHubAPIURL points all GitHub requests at baseURL and returns a function that
+// restores the previous URL. Command tests use it to run against a mock server.
+func SetGitHubAPIURL(baseURL string) (restore func()) {
+	previous := githubAPIURL
+	githubAPIURL = strings.TrimSuffix(baseURL, "/")
+	return func() { githubAPIURL = previous }
+}
+
 type Issue struct {
-	ID        int    `json:"id"`
-	Number    int    `json:"number"`
-	Title     string `json:"title"`
-	Body      string `json:"body"`
-	State     string `json:"state"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
-	ClosedAt  string `json:"closed_at"`
-	Labels    []struct {
-		Name string `json:"name"`
-	} `json:"labels"`
-	Assignees []struct {
-		Login string `json:"login"`
-	} `json:"assignees"`
+	ID        int          `json:"id"`
+	Number    int          `json:"number"`
+	Title     string       `json:"title"`
+	Body      string       `json:"body"`
+	State     string       `json:"state"`
+	CreatedAt string       `json:"created_at"`
+	UpdatedAt string       `json:"updated_at"`
+	ClosedAt  string       `json:"closed_at"`
+	Labels    []IssueLabel `json:"labels"`
+	Assignees []IssueUser  `json:"assignees"`
+	Reactions *Reactions   `json:"reactions,omitempty"`
+	Type      *IssueType   `json:"type,omitempty"` // nil when the repository has no issue types
+
+	Raw json.RawMessage `json:"-"` // The issue JSON as received from GitHub
+}
+
+// IssueLabel is a label as GitHub returns it with an issue
+type IssueLabel struct {
+	ID          int64  `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Color       string `json:"color,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// IssueUser is a user as GitHub returns it in the assignees of an issue
+type IssueUser struct {
+	ID    int64  `json:"id,omitempty"`
+	Login string `json:"login"`
+}
+
+// Reactions is the reaction summary GitHub includes with each issue
+type Reactions struct {
+	TotalCount int `json:"total_count"`
+	PlusOne    int `json:"+1"`
+	MinusOne   int `json:"-1"`
+	Laugh      int `json:"laugh"`
+	Hooray     int `json:"hooray"`
+	Confused   int `json:"confused"`
+	Heart      int `json:"heart"`
+	Rocket     int `json:"rocket"`
+	Eyes       int `json:"eyes"`
 }
 
 func FetchIssues(owner, repo, token string) ([]Issue, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/issues?state=all&per_page=100", owner, repo)
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	if _, err := newIssuesPageRequest(owner, repo, token, 1, issuesQuery{}); err != nil {
+		return nil, err
 	}
 
 	// Validate credentials after successful request creation
@@ -37,10 +78,157 @@ func FetchIssues(owner, repo, token string) ([]Issue, error) {
 		return nil, err
 	}
 
+	var issues []Issue
+	err := forEachIssuesPage(owner, repo, token, 1, issuesQuery{}, func(page int, pageIssues []Issue, hasNext bool) error {
+		issues = append(issues, pageIssues...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return issues, nil
+}
+
+// issuePageWorkers bounds the number of issue pages fetched concurrently
+var issuePageWorkers = 4
+
+// issuesPage is one page of issues and the pagination GitHub advertised with it
+type issuesPage struct {
+	issues   []Issue
+	hasNext  bool
+	lastPage int // Last page from the Link header's rel="last", 0 when not advertised
+}
+
+// forEachIssuesPage fetches a repository's issues from startPage on and calls fn for
+// each page in page order. When the first response names the last page, the remaining
+// pages are fetched concurrently, up to issuePageWorkers at a time; otherwise pages are
+// fetched one after another following rel="next".
+func forEachIssuesPage(owner, repo, token string, startPage int, query issuesQuery, fn func(page int, issues []Issue, hasNext bool) error) error {
+	first, err := fetchIssuesPage(owner, repo, token, startPage, query)
+	if err != nil {
+		return err
+	}
+	if err := fn(startPage, first.issues, first.hasNext); err != nil {
+		return err
+	}
+	if !first.hasNext {
+		return nil
+	}
+
+	if first.lastPage > startPage {
+		for next := startPage + 1; next <= first.lastPage; next += issuePageWorkers {
+			end := min(next+issuePageWorkers-1, first.lastPage)
+			pages, fetchErr := fetchIssuesPages(owner, repo, token, next, end, query)
+			for i, issues := range pages {
+				page := next + i
+				if err := fn(page, issues, page < first.lastPage); err != nil {
+					return err
+				}
+			}
+			if fetchErr != nil {
+				return fetchErr
+			}
+		}
+		return nil
+	}
+
+	for page := startPage + 1; ; page++ {
+		result, err := fetchIssuesPage(owner, repo, token, page, query)
+		if err != nil {
+			return err
+		}
+		if err := fn(page, result.issues, result.hasNext); err != nil {
+			return err
+		}
+		if !result.hasNext {
+			return nil
+		}
+	}
+}
+
+// fetchIssuesPages fetches the pages first to last concurrently and returns their issues
+// in page order. When a page fails, only the pages before it are returned, with its error.
+func fetchIssuesPages(owner, repo, token string, first, last int, query issuesQuery) ([][]Issue, error) {
+	pages := make([][]Issue, last-first+1)
+	errs := make([]error, len(pages))
+
+	var wg sync.WaitGroup
+	for i := range pages {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			result, err := fetchIssuesPage(owner, repo, token, first+i, query)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			pages[i] = result.issues
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return pages[:i], err
+		}
+	}
+	return pages, nil
+}
+
+// parseLastPage returns the page number of the rel="last" link of a Link header, or 0
+func parseLastPage(link string) int {
+	for _, part := range strings.Split(link, ",") {
+		target, params, found := strings.Cut(part, ";")
+		if !found || !strings.Contains(params, `rel="last"`) {
+			continue
+		}
+		parsed, err := neturl.Parse(strings.Trim(strings.TrimSpace(target), "<>"))
+		if err != nil {
+			return 0
+		}
+		page, err := strconv.Atoi(parsed.Query().Get("page"))
+		if err != nil {
+			return 0
+		}
+		return page
+	}
+	return 0
+}
+
+// issuesQuery narrows the issues fetched from a repository
+type issuesQuery struct {
+	since    string // Only issues updated at or after this timestamp
+	assignee string // Only issues assigned to this login
+}
+
+// newIssuesPageRequest builds the request for one page of a repository's issues
+func newIssuesPageRequest(owner, repo, token string, page int, query issuesQuery) (*http.Request, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/issues?state=all&per_page=100&page=%d", githubAPIURL, owner, repo, page)
+	if query.since != "" {
+		url += "&since=" + neturl.QueryEscape(query.since)
+	}
+	if query.assignee != "" {
+		url += "&assignee=" + neturl.QueryEscape(query.assignee)
+	}
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
 	req.Header.Set("Authorization", "token "+token)
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	return req, nil
+}
+
+// fetchIssuesPage fetches a single page of issues along with the next and last pages
+// GitHub advertises in the Link header
+func fetchIssuesPage(owner, repo, token string, page int, query issuesQuery) (*issuesPage, error) {
+	req, err := newIssuesPageRequest(owner, repo, token, page, query)
+	if err != nil {
+		return nil, err
+	}
 
-	client := &http.Client{}
+	client := newHTTPClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make request: %w", err)
@@ -78,12 +266,50 @@ func FetchIssues(owner, repo, token string) ([]Issue, error) {
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	var issues []Issue
-	if err := json.Unmarshal(body, &issues); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	issues, skipped, err := decodeIssues(body)
+	if err != nil {
+		return nil, err
+	}
+	if skipped > 0 {
+		fmt.Printf("⚠ Skipped %d malformed issue(s) in response from %s/%s\n", skipped, owner, repo)
 	}
 
-	return issues, nil
+	link := resp.Header.Get("Link")
+	return &issuesPage{
+		issues:   issues,
+		hasNext:  strings.Contains(link, `rel="next"`),
+		lastPage: parseLastPage(link),
+	}, nil
+}
+
+// decodeIssues decodes a page of issues one element at a time so that a single
+// malformed issue does not abort the whole page. It returns the successfully
+// decoded issues along with the number of elements that were skipped.
+func decodeIssues(body []byte) ([]Issue, int, error) {
+	// An empty body, e.g. after following a redirect, means there are no issues
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, 0, nil
+	}
+
+	var rawIssues []json.RawMessage
+	if err := json.Unmarshal(body, &rawIssues); err != nil {
+		return nil, 0, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+
+	issues := make([]Issue, 0, len(rawIssues))
+	skipped := 0
+	for i, raw := range rawIssues {
+		var issue Issue
+		if err := json.Unmarshal(raw, &issue); err != nil {
+			fmt.Printf("⚠ Skipping malformed issue at index %d: %v\n", i, err)
+			skipped++
+			continue
+		}
+		issue.Raw = raw
+		issues = append(issues, issue)
+	}
+
+	return issues, skipped, nil
 }
 
 // CreateIssueRequest represents the request payload for creating a GitHub issue
@@ -93,6 +319,41 @@ type CreateIssueRequest struct {
 	Labels    []string `json:"labels,omitempty"`
 	Assignees []string `json:"assignees,omitempty"`
 	Milestone int      `json:"milestone,omitempty"`
+	Type      string   `json:"type,omitempty"` // Issue type name, e.g. Bug
+}
+
+// GitHub limits on the fields of a new issue
+const (
+	MaxIssueTitleLength = 256
+	MaxIssueBodyLength  = 65536
+	MaxLabelNameLength  = 50
+	MaxIssueAssignees   = 10
+)
+
+// Validate checks a request against GitHub's limits, so invalid issues fail locally
+// with a clear message instead of a 422 from the API
+func (r CreateIssueRequest) Validate() error {
+	if strings.TrimSpace(r.Title) == "" {
+		return fmt.Errorf("issue title is required")
+	}
+	if n := utf8.RuneCountInString(r.Title); n > MaxIssueTitleLength {
+		return fmt.Errorf("issue title is %d characters long (maximum %d)", n, MaxIssueTitleLength)
+	}
+	if n := utf8.RuneCountInString(r.Body); n > MaxIssueBodyLength {
+		return fmt.Errorf("issue body is %d characters long (maximum %d)", n, MaxIssueBodyLength)
+	}
+	for _, label := range r.Labels {
+		if strings.TrimSpace(label) == "" {
+			return fmt.Errorf("label names must not be empty")
+		}
+		if n := utf8.RuneCountInString(label); n > MaxLabelNameLength {
+			return fmt.Errorf("label %q is %d characters long (maximum %d)", label, n, MaxLabelNameLength)
+		}
+	}
+	if len(r.Assignees) > MaxIssueAssignees {
+		return fmt.Errorf("issue has %d assignees (maximum %d)", len(r.Assignees), MaxIssueAssignees)
+	}
+	return nil
 }
 
 // CreateIssueResponse represents the response from GitHub when creating an issue
@@ -106,7 +367,11 @@ type CreateIssueResponse struct {
 
 // CreateIssue creates a new GitHub issue
 func CreateIssue(owner, repo, token string, request CreateIssueRequest) (*CreateIssueResponse, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/issues", owner, repo)
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid issue: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/%s/issues", githubAPIURL, owner, repo)
 
 	payload, err := json.Marshal(request)
 	if err != nil {
@@ -127,7 +392,7 @@ func CreateIssue(owner, repo, token string, request CreateIssueRequest) (*Create
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
 
-	client := &http.Client{}
+	client := newHTTPClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make request: %w", err)
@@ -197,14 +462,14 @@ func ValidateGitHubCredentials(token string) error {
 	}
 
 	// Test the token by calling the user endpoint
-	req, err := http.NewRequest("GET", "https://api.github.com/user", nil)
+	req, err := http.NewRequest("GET", githubAPIURL+"/user", nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Authorization", "token "+token)
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
 
-	client := &http.Client{}
+	client := newHTTPClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to validate GitHub token: %w", err)
@@ -238,12 +503,16 @@ func ValidateGitHubCredentials(token string) error {
 
 // ValidateRepositoryAccess validates that the token has access to a specific repository
 func ValidateRepositoryAccess(owner, repo, token string) error {
-	if err := ValidateGitHubCredentials(token); err != nil {
-		return err
+	// Installation tokens of a GitHub App cannot read /user; the repository
+	// request below still proves they work
+	if token != GitHubAppToken {
+		if err := ValidateGitHubCredentials(token); err != nil {
+			return err
+		}
 	}
 
 	// Test repository access
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s", owner, repo)
+	url := fmt.Sprintf("%s/repos/%s/%s", githubAPIURL, owner, repo)
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
@@ -251,22 +520,23 @@ func ValidateRepositoryAccess(owner, repo, token string) error {
 	req.Header.Set("Authorization", "token "+token)
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
 
-	client := &http.Client{}
+	client := newHTTPClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to validate repository access: %w", err)
 	}
-	defer resp.Body.Close()
+	// Read and close the response before a 404 sends another request: it holds one
+	// of the request limiter's slots until closed
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
 
 	switch resp.StatusCode {
 	case http.StatusOK:
 		return nil // Has access to repository
 	case http.StatusNotFound:
-		return &GitHubCredentialError{
-			StatusCode: 404,
-			Message:    fmt.Sprintf("Repository %s/%s not found or not accessible", owner, repo),
-			Suggestion: "Check the repository name or ensure your token has access to this repository",
-		}
+		// The token passed /user, so a 404 means the repository is missing or
+		// hidden from this token (fine-grained PATs get 404 rather than 403)
+		return repositoryNotFoundError(owner, repo, token)
 	case http.StatusForbidden:
 		return &GitHubCredentialError{
 			StatusCode: 403,
@@ -274,7 +544,6 @@ func ValidateRepositoryAccess(owner, repo, token string) error {
 			Suggestion: "Your token doesn't have permission to access this repository. Ensure it has 'repo' scope",
 		}
 	default:
-		body, _ := io.ReadAll(resp.Body)
 		return &GitHubCredentialError{
 			StatusCode: resp.StatusCode,
 			Message:    fmt.Sprintf("Unexpected response when accessing repository: %s", string(body)),
@@ -283,11 +552,54 @@ func ValidateRepositoryAccess(owner, repo, token string) error {
 	}
 }
 
+// fineGrainedTokenSettingsURL is where fine-grained PAT repository access is configured
+const fineGrainedTokenSettingsURL = "https://github.com/settings/personal-access-tokens"
+
+// repositoryNotFoundError explains a 404 for a repository when the token itself is valid
+func repositoryNotFoundError(owner, repo, token string) error {
+	if repositoryIsPublic(owner, repo) {
+		return &GitHubCredentialError{
+			StatusCode: 404,
+			Message:    fmt.Sprintf("Repository %s/%s exists but your token cannot access it", owner, repo),
+			Suggestion: fmt.Sprintf("Your token is valid but restricted to other repositories. If it is a fine-grained personal access token, add %s/%s under 'Repository access' at %s", owner, repo, fineGrainedTokenSettingsURL),
+		}
+	}
+
+	suggestion := "Your token is valid, so check the repository name or ensure your token has access to this repository"
+	if strings.HasPrefix(token, "github_pat_") {
+		suggestion = fmt.Sprintf("Your token is valid, so either the repository name is wrong or this fine-grained personal access token is not granted access to it. Add %s/%s under 'Repository access' at %s", owner, repo, fineGrainedTokenSettingsURL)
+	}
+	return &GitHubCredentialError{
+		StatusCode: 404,
+		Message:    fmt.Sprintf("Repository %s/%s not found or not accessible", owner, repo),
+		Suggestion: suggestion,
+	}
+}
+
+// repositoryIsPublic reports whether a repository can be read without authentication
+func repositoryIsPublic(owner, repo string) bool {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/repos/%s/%s", githubAPIURL, owner, repo), nil)
+	if err != nil {
+		return false
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+	resp, err := newHTTPClient().Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode == http.StatusOK
+}
+
 // EnsureGitHubCredentials validates credentials and provides user-friendly error messages
 func EnsureGitHubCredentials(owner, repo, token string) error {
-	// First validate the basic token
-	if err := ValidateGitHubCredentials(token); err != nil {
-		return err
+	// First validate the basic token. GitHub App installations are only checked
+	// against the repository, as they cannot read /user.
+	if token != GitHubAppToken {
+		if err := ValidateGitHubCredentials(token); err != nil {
+			return err
+		}
 	}
 
 	// Then validate repository access if specified
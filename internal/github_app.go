@@ -0,0 +1,226 @@
+package internal
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// GitHubAppToken is the token a project resolves to when it authenticates as a
+// GitHub App. Requests carrying it are sent with a minted installation token instead.
+const GitHubAppToken = "github-app"
+
+// appTokenRefreshMargin is how long before it expires an installation token is replaced
+const appTokenRefreshMargin = time.Minute
+
+// GitHubAppConfig holds the credentials of a GitHub App installation, configured under
+// global.github_app in config.yml as an alternative to a personal access token
+type GitHubAppConfig struct {
+	AppID          int64  `yaml:"app_id"`
+	InstallationID int64  `yaml:"installation_id"`
+	PrivateKeyPath string `yaml:"private_key_path"` // PEM private key downloaded from the app settings
+}
+
+// Validate checks that every field of the app configuration is set
+func (c *GitHubAppConfig) Validate() error {
+	var missing []string
+	if c.AppID <= 0 {
+		missing = append(missing, "app_id")
+	}
+	if c.InstallationID <= 0 {
+		missing = append(missing, "installation_id")
+	}
+	if c.PrivateKeyPath == "" {
+		missing = append(missing, "private_key_path")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("github_app is missing %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// appTokenSource mints installation tokens for a GitHub App and caches each one until
+// shortly before it expires
+type appTokenSource struct {
+	appID          int64
+	installationID int64
+	key            *rsa.PrivateKey
+	now            func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// githubAppTokens mints the tokens of the GitHub App of the loaded configuration
+// (nil = no app configured)
+var githubAppTokens *appTokenSource
+
+// configureGitHubApp installs the GitHub App of a loaded configuration, reading its
+// private key. A configuration without an app removes a previously installed one.
+func configureGitHubApp(config *GitHubAppConfig) error {
+	if config == nil {
+		githubAppTokens = nil
+		return nil
+	}
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	source, err := newAppTokenSource(config)
+	if err != nil {
+		return err
+	}
+	githubAppTokens = source
+	return nil
+}
+
+// newAppTokenSource creates the token source of a GitHub App from its configuration
+func newAppTokenSource(config *GitHubAppConfig) (*appTokenSource, error) {
+	// The key path is expanded like the database path: ~ and environment variables
+	path, err := ResolveDatabasePath(config.PrivateKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path) // #nosec G304 - Path comes from the user's config
+	if err != nil {
+		return nil, fmt.Errorf("failed to read GitHub App private key: %w", err)
+	}
+	key, err := parseAppPrivateKey(data)
+	if err != nil {
+		return nil, err
+	}
+	return &appTokenSource{appID: config.AppID, installationID: config.InstallationID, key: key, now: time.Now}, nil
+}
+
+// parseAppPrivateKey parses a PEM-encoded RSA key in PKCS#1 (as GitHub issues them) or PKCS#8 form
+func parseAppPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("GitHub App private key is not PEM-encoded")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse GitHub App private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("GitHub App private key must be an RSA key")
+	}
+	return key, nil
+}
+
+// appJWT returns the signed JWT identifying the app, valid for 10 minutes. Its issue
+// time is backdated a minute to allow for clock drift, as GitHub recommends.
+func (s *appTokenSource) appJWT() (string, error) {
+	now := s.now()
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]interface{}{
+		"iat": now.Add(-time.Minute).Unix(),
+		"exp": now.Add(9 * time.Minute).Unix(),
+		"iss": fmt.Sprint(s.appID),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign GitHub App JWT: %w", err)
+	}
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// Token returns a valid installation token, minting a new one when none is cached
+// or the cached one is about to expire
+func (s *appTokenSource) Token() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && s.now().Add(appTokenRefreshMargin).Before(s.expiresAt) {
+		return s.token, nil
+	}
+
+	jwt, err := s.appJWT()
+	if err != nil {
+		return "", err
+	}
+	url := fmt.Sprintf("%s/app/installations/%d/access_tokens", githubAPIURL, s.installationID)
+	req, err := http.NewRequest("POST", url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+jwt)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+	resp, err := newHTTPClient().Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to request GitHub App installation token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("GitHub API error (%d) creating installation token for app %d: %s", resp.StatusCode, s.appID, strings.TrimSpace(string(body)))
+	}
+
+	var minted struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if err := json.Unmarshal(body, &minted); err != nil {
+		return "", fmt.Errorf("failed to parse installation token: %w", err)
+	}
+	if minted.Token == "" {
+		return "", fmt.Errorf("GitHub returned an empty installation token for app %d", s.appID)
+	}
+
+	RegisterSecret(minted.Token)
+	s.token, s.expiresAt = minted.Token, minted.ExpiresAt
+	return s.token, nil
+}
+
+// appAuthTransport sends requests authorized with GitHubAppToken with a minted
+// installation token instead
+type appAuthTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *appAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	source := githubAppTokens
+	if source == nil || req.Header.Get("Authorization") != "token "+GitHubAppToken {
+		return t.base.RoundTrip(req)
+	}
+
+	token, err := source.Token()
+	if err != nil {
+		return nil, err
+	}
+	authorized := req.Clone(req.Context())
+	authorized.Header.Set("Authorization", "token "+token)
+	return t.base.RoundTrip(authorized)
+}
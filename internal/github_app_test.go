@@ -0,0 +1,244 @@
+package internal
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeAppKey generates an RSA key and writes it PKCS#1 PEM-encoded to dir/app.pem
+func writeAppKey(t *testing.T, dir string) (*rsa.PrivateKey, string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	path := filepath.Join(dir, "app.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+	return key, path
+}
+
+// verifyAppJWT checks the signature of a JWT and returns its claims
+func verifyAppJWT(t *testing.T, key *rsa.PrivateKey, jwt string) map[string]interface{} {
+	t.Helper()
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		t.Fatalf("Expected a JWT with 3 parts, got %q", jwt)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatalf("Failed to decode signature: %v", err)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature); err != nil {
+		t.Fatalf("Expected a valid RS256 signature: %v", err)
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		t.Fatalf("Failed to decode claims: %v", err)
+	}
+	claims := map[string]interface{}{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		t.Fatalf("Failed to parse claims: %v", err)
+	}
+	return claims
+}
+
+func TestGitHubAppConfig_Validate(t *testing.T) {
+	if err := (&GitHubAppConfig{AppID: 1, InstallationID: 2, PrivateKeyPath: "app.pem"}).Validate(); err != nil {
+		t.Errorf("Expected a complete config to be valid, got %v", err)
+	}
+	err := (&GitHubAppConfig{InstallationID: 2}).Validate()
+	if err == nil || err.Error() != "github_app is missing app_id, private_key_path" {
+		t.Errorf("Expected the missing fields to be listed, got %v", err)
+	}
+}
+
+func TestParseAppPrivateKey(t *testing.T) {
+	key, path := writeAppKey(t, t.TempDir())
+	data, _ := os.ReadFile(path)
+	if parsed, err := parseAppPrivateKey(data); err != nil || !parsed.Equal(key) {
+		t.Errorf("Expected the PKCS#1 key to parse, got %v", err)
+	}
+
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+	if parsed, err := parseAppPrivateKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8})); err != nil || !parsed.Equal(key) {
+		t.Errorf("Expected the PKCS#8 key to parse, got %v", err)
+	}
+
+	if _, err := parseAppPrivateKey([]byte("not a key")); err == nil || !strings.Contains(err.Error(), "not PEM-encoded") {
+		t.Errorf("Expected an error for a non-PEM key, got %v", err)
+	}
+}
+
+func TestGitHubApp_MintsCachesAndRefreshesTokens(t *testing.T) {
+	key, path := writeAppKey(t, t.TempDir())
+	clock := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	var minted int
+	var repoAuth []string
+	newMockGitHubServer(t, "owner", "repo", "[]", map[string]http.HandlerFunc{
+		"/app/installations/99/access_tokens": func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != "POST" {
+				t.Errorf("Expected POST, got %s", r.Method)
+			}
+			jwt := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			claims := verifyAppJWT(t, key, jwt)
+			if claims["iss"] != "1234" || claims["iat"] != float64(clock.Add(-time.Minute).Unix()) {
+				t.Errorf("Unexpected JWT claims: %v", claims)
+			}
+			minted++
+			w.WriteHeader(http.StatusCreated)
+			_, _ = fmt.Fprintf(w, `{"token": "ghs_%d", "expires_at": %q}`, minted, clock.Add(time.Hour).Format(time.RFC3339))
+		},
+		"/repos/owner/repo": func(w http.ResponseWriter, r *http.Request) {
+			repoAuth = append(repoAuth, r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(`{"private": true}`))
+		},
+		"/user": func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.Header.Get("Authorization"), "token ghs_") {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"message": "Resource not accessible by integration"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"login": "octocat"}`))
+		},
+	})
+
+	if err := configureGitHubApp(&GitHubAppConfig{AppID: 1234, InstallationID: 99, PrivateKeyPath: path}); err != nil {
+		t.Fatalf("configureGitHubApp failed: %v", err)
+	}
+	t.Cleanup(func() { githubAppTokens = nil })
+	githubAppTokens.now = func() time.Time { return clock }
+
+	// The /user check is skipped for app installations, which cannot read it
+	if err := EnsureGitHubCredentials("owner", "repo", GitHubAppToken); err != nil {
+		t.Fatalf("EnsureGitHubCredentials failed: %v", err)
+	}
+	if err := ValidateRepositoryAccess("owner", "repo", GitHubAppToken); err != nil {
+		t.Fatalf("ValidateRepositoryAccess failed: %v", err)
+	}
+	if minted != 1 {
+		t.Errorf("Expected the cached token to be reused, got %d mints", minted)
+	}
+
+	// Within the refresh margin of its expiry the token is replaced
+	clock = clock.Add(59*time.Minute + time.Second)
+	if err := ValidateRepositoryAccess("owner", "repo", GitHubAppToken); err != nil {
+		t.Fatalf("ValidateRepositoryAccess failed: %v", err)
+	}
+	if minted != 2 {
+		t.Errorf("Expected a new token near expiry, got %d mints", minted)
+	}
+
+	want := []string{"token ghs_1", "token ghs_1", "token ghs_2"}
+	if strings.Join(repoAuth, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected requests authorized with %v, got %v", want, repoAuth)
+	}
+
+	// Personal tokens pass through untouched
+	repoAuth = nil
+	if err := ValidateRepositoryAccess("owner", "repo", "ghp_personal"); err != nil {
+		t.Fatalf("ValidateRepositoryAccess failed: %v", err)
+	}
+	if len(repoAuth) != 1 || repoAuth[0] != "token ghp_personal" {
+		t.Errorf("Expected the personal token to be sent, got %v", repoAuth)
+	}
+}
+
+func TestGitHubApp_MintError(t *testing.T) {
+	_, path := writeAppKey(t, t.TempDir())
+	newMockGitHubServer(t, "owner", "repo", "[]", map[string]http.HandlerFunc{
+		"/app/installations/7/access_tokens": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message": "A JSON web token could not be decoded"}`))
+		},
+	})
+	if err := configureGitHubApp(&GitHubAppConfig{AppID: 5, InstallationID: 7, PrivateKeyPath: path}); err != nil {
+		t.Fatalf("configureGitHubApp failed: %v", err)
+	}
+	t.Cleanup(func() { githubAppTokens = nil })
+
+	err := ValidateRepositoryAccess("owner", "repo", GitHubAppToken)
+	if err == nil || !strings.Contains(err.Error(), "GitHub API error (401) creating installation token for app 5") {
+		t.Errorf("Expected the mint error, got %v", err)
+	}
+}
+
+func TestLoadMultiProjectConfig_GitHubApp(t *testing.T) {
+	dir := chdirTemp(t)
+	_, path := writeAppKey(t, dir)
+	t.Cleanup(func() { githubAppTokens = nil })
+
+	config := fmt.Sprintf(`global:
+  database: ./pivot.db
+  github_app:
+    app_id: 1234
+    installation_id: 99
+    private_key_path: %s
+projects:
+  - owner: org
+    repo: app
+  - owner: org
+    repo: personal
+    token: ghp_project
+`, path)
+	if err := os.WriteFile("config.yml", []byte(config), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	loaded, err := LoadMultiProjectConfig()
+	if err != nil {
+		t.Fatalf("LoadMultiProjectConfig failed: %v", err)
+	}
+	if githubAppTokens == nil {
+		t.Fatal("Expected the GitHub App to be configured")
+	}
+	if token := loaded.Projects[0].GetEffectiveToken(&loaded.Global); token != GitHubAppToken {
+		t.Errorf("Expected the app token marker, got %q", token)
+	}
+	if token := loaded.Projects[1].GetEffectiveToken(&loaded.Global); token != "ghp_project" {
+		t.Errorf("Expected a project token to take precedence, got %q", token)
+	}
+
+	// A configured global token takes precedence over the app
+	global := GlobalConfig{Token: "ghp_global", GitHubApp: loaded.Global.GitHubApp}
+	if token := global.EffectiveToken(); token != "ghp_global" {
+		t.Errorf("Expected the global token, got %q", token)
+	}
+
+	if err := os.WriteFile("config.yml", []byte(strings.Replace(config, "installation_id: 99", "installation_id: 0", 1)), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if _, err := LoadMultiProjectConfig(); err == nil || !strings.Contains(err.Error(), "github_app is missing installation_id") {
+		t.Errorf("Expected an invalid app config error, got %v", err)
+	}
+
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("Failed to remove key: %v", err)
+	}
+	if err := os.WriteFile("config.yml", []byte(config), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if _, err := LoadMultiProjectConfig(); err == nil || !strings.Contains(err.Error(), "failed to read GitHub App private key") {
+		t.Errorf("Expected a missing key error, got %v", err)
+	}
+}
@@ -0,0 +1,178 @@
+package internal
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// pagedIssuesHandler serves pages of two issues each. With advertiseLast every response
+// names the last page; otherwise only rel="next" is sent.
+func pagedIssuesHandler(pages int, advertiseLast bool, inFlight, maxInFlight *int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(inFlight, 1)
+		defer atomic.AddInt32(inFlight, -1)
+		for {
+			seen := atomic.LoadInt32(maxInFlight)
+			if current <= seen || atomic.CompareAndSwapInt32(maxInFlight, seen, current) {
+				break
+			}
+		}
+		// Let concurrent requests overlap, and finish later pages first
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		time.Sleep(time.Duration(pages-page+1) * 5 * time.Millisecond)
+
+		var links []string
+		if page < pages {
+			links = append(links, fmt.Sprintf(`<http://%s/repos/octo/widgets/issues?page=%d>; rel="next"`, r.Host, page+1))
+		}
+		if advertiseLast && page < pages {
+			links = append(links, fmt.Sprintf(`<http://%s/repos/octo/widgets/issues?per_page=100&page=%d>; rel="last"`, r.Host, pages))
+		}
+		if len(links) > 0 {
+			w.Header().Set("Link", strings.Join(links, ", "))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `[{"id": %d, "number": %d, "title": "Issue %d", "state": "open"}, {"id": %d, "number": %d, "title": "Issue %d", "state": "open"}]`,
+			page*10, page*2-1, page*2-1, page*10+1, page*2, page*2)
+	}
+}
+
+func issueNumbers(issues []Issue) []int {
+	numbers := make([]int, len(issues))
+	for i, issue := range issues {
+		numbers[i] = issue.Number
+	}
+	return numbers
+}
+
+func TestFetchIssues_ConcurrentPagesInOrder(t *testing.T) {
+	var inFlight, maxInFlight int32
+	newMockGitHubServer(t, "octo", "widgets", "", map[string]http.HandlerFunc{
+		"/repos/octo/widgets/issues": pagedIssuesHandler(7, true, &inFlight, &maxInFlight),
+	})
+	oldWorkers := issuePageWorkers
+	issuePageWorkers = 3
+	t.Cleanup(func() { issuePageWorkers = oldWorkers })
+
+	issues, err := FetchIssues("octo", "widgets", "test-token")
+	if err != nil {
+		t.Fatalf("FetchIssues failed: %v", err)
+	}
+
+	numbers := issueNumbers(issues)
+	if len(numbers) != 14 {
+		t.Fatalf("Expected 14 issues from 7 pages, got %d", len(numbers))
+	}
+	for i, number := range numbers {
+		if number != i+1 {
+			t.Fatalf("Expected issues in page order, got %v", numbers)
+		}
+	}
+	if maxInFlight < 2 || maxInFlight > 3 {
+		t.Errorf("Expected between 2 and 3 concurrent page requests, got %d", maxInFlight)
+	}
+}
+
+func TestFetchIssues_SequentialWithoutLastLink(t *testing.T) {
+	var inFlight, maxInFlight int32
+	newMockGitHubServer(t, "octo", "widgets", "", map[string]http.HandlerFunc{
+		"/repos/octo/widgets/issues": pagedIssuesHandler(4, false, &inFlight, &maxInFlight),
+	})
+
+	issues, err := FetchIssues("octo", "widgets", "test-token")
+	if err != nil {
+		t.Fatalf("FetchIssues failed: %v", err)
+	}
+	if got := fmt.Sprint(issueNumbers(issues)); got != "[1 2 3 4 5 6 7 8]" {
+		t.Errorf("Expected all issues in order, got %s", got)
+	}
+	if maxInFlight != 1 {
+		t.Errorf("Expected sequential requests without rel=\"last\", got %d in flight", maxInFlight)
+	}
+}
+
+func TestForEachIssuesPage_StopsAtFailedPage(t *testing.T) {
+	newMockGitHubServer(t, "octo", "widgets", "", map[string]http.HandlerFunc{
+		"/repos/octo/widgets/issues": func(w http.ResponseWriter, r *http.Request) {
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			if page == 3 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			if page == 1 {
+				w.Header().Set("Link", fmt.Sprintf(`<http://%s/issues?page=2>; rel="next", <http://%s/issues?page=4>; rel="last"`, r.Host, r.Host))
+			}
+			_, _ = fmt.Fprintf(w, `[{"id": %d, "number": %d, "title": "Issue", "state": "open"}]`, page, page)
+		},
+	})
+
+	var mu sync.Mutex
+	var seen []int
+	err := forEachIssuesPage("octo", "widgets", "test-token", 1, issuesQuery{}, func(page int, issues []Issue, hasNext bool) error {
+		mu.Lock()
+		defer mu.Unlock()
+		seen = append(seen, page)
+		return nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "500") {
+		t.Errorf("Expected the page 3 error, got %v", err)
+	}
+	if fmt.Sprint(seen) != "[1 2]" {
+		t.Errorf("Expected the pages before the failure to be processed, got %v", seen)
+	}
+}
+
+func TestSyncAdHoc_ConcurrentPagesSavedWithCheckpoints(t *testing.T) {
+	var inFlight, maxInFlight int32
+	newMockGitHubServer(t, "octo", "widgets", "", map[string]http.HandlerFunc{
+		"/repos/octo/widgets/issues": pagedIssuesHandler(5, true, &inFlight, &maxInFlight),
+	})
+
+	config, err := NewAdHocConfig("octo/widgets", "test-token")
+	if err != nil {
+		t.Fatalf("NewAdHocConfig failed: %v", err)
+	}
+	config.Global.Database = filepath.Join(t.TempDir(), "pivot.db")
+
+	result, err := SyncAdHoc(config, SyncOptions{Checkpoint: true})
+	if err != nil {
+		t.Fatalf("SyncAdHoc failed: %v", err)
+	}
+	if totals := result.Totals(); totals.Created != 10 {
+		t.Errorf("Expected 10 created issues, got %d", totals.Created)
+	}
+
+	db, err := InitMultiProjectDBFromPath(config.Global.Database)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	project, err := FindProjectByOwnerRepo(db, "octo", "widgets")
+	if err != nil {
+		t.Fatalf("Expected project to be recorded: %v", err)
+	}
+	if page, _ := GetSyncCheckpoint(db, int64(project.ID)); page != 0 {
+		t.Errorf("Expected checkpoint to be cleared after completion, got %d", page)
+	}
+}
+
+func TestParseLastPage(t *testing.T) {
+	tests := map[string]int{
+		`<https://api.github.com/repositories/1/issues?page=2>; rel="next", <https://api.github.com/repositories/1/issues?state=all&page=34>; rel="last"`: 34,
+		`<https://api.github.com/repositories/1/issues?page=2>; rel="next"`:                                                                               0,
+		`<https://api.github.com/repositories/1/issues?page=x>; rel="last"`:                                                                               0,
+		"": 0,
+	}
+	for link, want := range tests {
+		if got := parseLastPage(link); got != want {
+			t.Errorf("parseLastPage(%q): expected %d, got %d", link, want, got)
+		}
+	}
+}
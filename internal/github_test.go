@@ -21,14 +21,10 @@ func TestFetchIssues_Success(t *testing.T) {
 			State:     "open",
 			CreatedAt: "2025-01-01T00:00:00Z",
 			UpdatedAt: "2025-01-01T00:00:00Z",
-			Labels: []struct {
-				Name string `json:"name"`
-			}{
+			Labels: []IssueLabel{
 				{Name: "bug"},
 			},
-			Assignees: []struct {
-				Login string `json:"login"`
-			}{
+			Assignees: []IssueUser{
 				{Login: "testuser"},
 			},
 		},
@@ -200,14 +196,10 @@ func TestFetchIssues_LargeResponse(t *testing.T) {
 			State:     "open",
 			CreatedAt: "2025-01-01T00:00:00Z",
 			UpdatedAt: "2025-01-01T00:00:00Z",
-			Labels: []struct {
-				Name string `json:"name"`
-			}{
+			Labels: []IssueLabel{
 				{Name: fmt.Sprintf("label-%d", i)},
 			},
-			Assignees: []struct {
-				Login string `json:"login"`
-			}{
+			Assignees: []IssueUser{
 				{Login: fmt.Sprintf("user-%d", i)},
 			},
 		})
@@ -659,3 +651,39 @@ func fetchIssuesFromURL(url, owner, repo, token string) ([]Issue, error) {
 
 	return issues, nil
 }
+
+func TestDecodeIssues_SkipsMalformedElement(t *testing.T) {
+	body := []byte(`[
+		{"id": 1, "number": 1, "title": "First", "state": "open"},
+		{"id": 2, "number": "two", "title": "Broken", "state": "open"},
+		{"id": 3, "number": 3, "title": "Third", "state": "closed", "labels": [{"name": "bug"}], "extra_field": {"nested": true}}
+	]`)
+
+	issues, skipped, err := decodeIssues(body)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if skipped != 1 {
+		t.Errorf("Expected 1 skipped issue, got %d", skipped)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d", len(issues))
+	}
+	if issues[0].Number != 1 || issues[1].Number != 3 {
+		t.Errorf("Expected issues #1 and #3, got #%d and #%d", issues[0].Number, issues[1].Number)
+	}
+	if len(issues[1].Labels) != 1 || issues[1].Labels[0].Name != "bug" {
+		t.Errorf("Expected label 'bug' on issue #3, got %v", issues[1].Labels)
+	}
+}
+
+func TestDecodeIssues_InvalidPage(t *testing.T) {
+	_, _, err := decodeIssues([]byte(`{"message": "not a list"}`))
+	if err == nil {
+		t.Fatal("Expected error for non-array response")
+	}
+	if !strings.Contains(err.Error(), "failed to unmarshal JSON") {
+		t.Errorf("Expected unmarshal error, got: %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+package internal
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// GitignoreEntryForDatabase returns the .gitignore entry and repository root for a
+// database path that lives inside the current git repository. It returns empty
+// strings when the path is absolute, home-relative, or outside the repository.
+func GitignoreEntryForDatabase(dbPath string) (string, string, error) {
+	if dbPath == "" || filepath.IsAbs(dbPath) || strings.HasPrefix(dbPath, "~") {
+		return "", "", nil
+	}
+
+	gitDir, err := findGitDirectory()
+	if err != nil {
+		return "", "", nil // Not in a git repository
+	}
+	repoRoot := filepath.Dir(gitDir)
+
+	absPath, err := filepath.Abs(dbPath)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to resolve database path: %w", err)
+	}
+
+	relPath, err := filepath.Rel(repoRoot, absPath)
+	if err != nil || strings.HasPrefix(relPath, "..") {
+		return "", "", nil // Database lives outside the repository
+	}
+
+	return "/" + filepath.ToSlash(relPath), repoRoot, nil
+}
+
+// EnsureGitignoreEntry appends an entry to the .gitignore in repoRoot unless it is
+// already present. It reports whether the file was changed.
+func EnsureGitignoreEntry(repoRoot, entry string) (bool, error) {
+	gitignorePath := filepath.Join(repoRoot, ".gitignore")
+
+	content, err := os.ReadFile(gitignorePath) // #nosec G304 - .gitignore in detected repository root
+	if err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("failed to read .gitignore: %w", err)
+	}
+
+	if gitignoreHasEntry(string(content), entry) {
+		return false, nil
+	}
+
+	var addition strings.Builder
+	if len(content) > 0 && !strings.HasSuffix(string(content), "\n") {
+		addition.WriteString("\n")
+	}
+	addition.WriteString("# Pivot local issues database\n")
+	addition.WriteString(entry + "\n")
+
+	file, err := os.OpenFile(gitignorePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644) // #nosec G302 G304 - .gitignore is a regular repository file
+	if err != nil {
+		return false, fmt.Errorf("failed to open .gitignore: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(addition.String()); err != nil {
+		return false, fmt.Errorf("failed to write .gitignore: %w", err)
+	}
+
+	return true, nil
+}
+
+// gitignoreHasEntry reports whether .gitignore content already lists an entry, with or without the leading slash
+func gitignoreHasEntry(content, entry string) bool {
+	bare := strings.TrimPrefix(entry, "/")
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == entry || line == bare {
+			return true
+		}
+	}
+	return false
+}
+
+// OfferGitignoreEntry asks whether to ignore a repository-local database file and
+// adds it to .gitignore when the user accepts
+func OfferGitignoreEntry(dbPath string) error {
+	entry, repoRoot, err := GitignoreEntryForDatabase(dbPath)
+	if err != nil || entry == "" {
+		return err
+	}
+
+	content, _ := os.ReadFile(filepath.Join(repoRoot, ".gitignore")) // #nosec G304 - .gitignore in detected repository root
+	if gitignoreHasEntry(string(content), entry) {
+		return nil
+	}
+
+	fmt.Printf("Add %s to .gitignore to avoid committing it? (Y/n): ", entry)
+	reader := bufio.NewReader(os.Stdin)
+	response, _ := reader.ReadString('\n')
+	response = strings.TrimSpace(strings.ToLower(response))
+	if response != "" && response != "y" && response != "yes" {
+		return nil
+	}
+
+	added, err := EnsureGitignoreEntry(repoRoot, entry)
+	if err != nil {
+		return err
+	}
+	if added {
+		fmt.Printf("✓ Added %s to .gitignore\n", entry)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// chdirTempGitRepo creates a temporary git repository and changes into it for the duration of the test
+func chdirTempGitRepo(t *testing.T) string {
+	t.Helper()
+	repoDir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(repoDir, ".git"), 0755); err != nil {
+		t.Fatalf("Failed to create .git directory: %v", err)
+	}
+
+	oldDir, _ := os.Getwd()
+	if err := os.Chdir(repoDir); err != nil {
+		t.Fatalf("Failed to change to repository directory: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := os.Chdir(oldDir); err != nil {
+			t.Logf("Warning: Failed to change back to original directory: %v", err)
+		}
+	})
+
+	// Resolve symlinks (e.g. /tmp on macOS) so paths compare cleanly
+	resolved, _ := os.Getwd()
+	return resolved
+}
+
+func TestGitignoreEntryForDatabase(t *testing.T) {
+	repoDir := chdirTempGitRepo(t)
+
+	entry, root, err := GitignoreEntryForDatabase("./data/pivot.db")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if entry != "/data/pivot.db" {
+		t.Errorf("Expected entry '/data/pivot.db', got '%s'", entry)
+	}
+	if root != repoDir {
+		t.Errorf("Expected repository root '%s', got '%s'", repoDir, root)
+	}
+
+	for _, path := range []string{"~/.pivot/pivot.db", filepath.Join(repoDir, "pivot.db"), "../outside.db", ""} {
+		entry, _, err := GitignoreEntryForDatabase(path)
+		if err != nil {
+			t.Errorf("Expected no error for %q, got: %v", path, err)
+		}
+		if entry != "" {
+			t.Errorf("Expected no entry for %q, got '%s'", path, entry)
+		}
+	}
+}
+
+func TestEnsureGitignoreEntry_AddsOnce(t *testing.T) {
+	repoDir := chdirTempGitRepo(t)
+	if err := os.WriteFile(filepath.Join(repoDir, ".gitignore"), []byte("node_modules/"), 0644); err != nil {
+		t.Fatalf("Failed to write .gitignore: %v", err)
+	}
+
+	// Simulate running init twice
+	for i := 0; i < 2; i++ {
+		if err := OfferGitignoreEntry("pivot.db"); err != nil {
+			t.Fatalf("OfferGitignoreEntry failed on run %d: %v", i+1, err)
+		}
+	}
+
+	content, err := os.ReadFile(filepath.Join(repoDir, ".gitignore"))
+	if err != nil {
+		t.Fatalf("Failed to read .gitignore: %v", err)
+	}
+	if count := strings.Count(string(content), "/pivot.db"); count != 1 {
+		t.Errorf("Expected entry exactly once, found %d times:\n%s", count, content)
+	}
+	if !strings.HasPrefix(string(content), "node_modules/\n") {
+		t.Errorf("Expected existing entries to be preserved, got:\n%s", content)
+	}
+
+	added, err := EnsureGitignoreEntry(repoDir, "/pivot.db")
+	if err != nil {
+		t.Fatalf("EnsureGitignoreEntry failed: %v", err)
+	}
+	if added {
+		t.Error("Expected no change when entry already exists")
+	}
+}
+
+func TestEnsureGitignoreEntry_RecognizesBareEntry(t *testing.T) {
+	repoDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(repoDir, ".gitignore"), []byte("pivot.db\n"), 0644); err != nil {
+		t.Fatalf("Failed to write .gitignore: %v", err)
+	}
+
+	added, err := EnsureGitignoreEntry(repoDir, "/pivot.db")
+	if err != nil {
+		t.Fatalf("EnsureGitignoreEntry failed: %v", err)
+	}
+	if added {
+		t.Error("Expected existing bare entry to be recognized")
+	}
+}
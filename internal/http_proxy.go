@@ -0,0 +1,124 @@
+package internal
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// SyncSettings holds network options used when talking to GitHub
+type SyncSettings struct {
+	Proxy  string         `yaml:"proxy,omitempty"`  // Proxy URL overriding HTTP_PROXY/HTTPS_PROXY/NO_PROXY
+	Notify NotifySettings `yaml:"notify,omitempty"` // Completion hooks fired by sync --notify
+
+	RawCompression string `yaml:"raw_compression,omitempty"` // gzip (default) or none, for sync --store-raw
+
+	RequestsPerSecond float64 `yaml:"requests_per_second,omitempty"` // Pace of all GitHub requests (0 = unpaced)
+	MaxConcurrency    int     `yaml:"max_concurrency,omitempty"`     // GitHub requests in flight at once (0 = unbounded)
+}
+
+var (
+	// httpTransportMu guards the proxy override and the transport built for it
+	httpTransportMu sync.Mutex
+	// httpProxyURL is the configured proxy override (nil = use the environment)
+	httpProxyURL *url.URL
+	// httpTransport is shared by every client so connections are kept alive across
+	// requests (nil = not built yet)
+	httpTransport *http.Transport
+)
+
+// ParseProxyURL validates a proxy URL such as http://proxy.example.com:3128
+func ParseProxyURL(raw string) (*url.URL, error) {
+	proxyURL, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL '%s': %w", raw, err)
+	}
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("invalid proxy URL '%s': scheme must be http, https or socks5", raw)
+	}
+	if proxyURL.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL '%s': missing host", raw)
+	}
+	return proxyURL, nil
+}
+
+// SetHTTPProxy routes all GitHub requests through the given proxy. An empty
+// value restores the HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment behaviour.
+func SetHTTPProxy(raw string) error {
+	var proxyURL *url.URL
+	if raw != "" {
+		parsed, err := ParseProxyURL(raw)
+		if err != nil {
+			return err
+		}
+		proxyURL = parsed
+	}
+
+	httpTransportMu.Lock()
+	defer httpTransportMu.Unlock()
+	if sameProxy(proxyURL, httpProxyURL) {
+		return nil
+	}
+	httpProxyURL = proxyURL
+	// The transport is rebuilt on next use; connections to the old proxy are dropped
+	if httpTransport != nil {
+		httpTransport.CloseIdleConnections()
+		httpTransport = nil
+	}
+	return nil
+}
+
+// sameProxy reports whether two proxy overrides route requests the same way
+func sameProxy(a, b *url.URL) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.String() == b.String()
+}
+
+// newHTTPTransport builds the transport used for GitHub requests, using the proxy
+// override when set and the standard proxy environment variables otherwise
+func newHTTPTransport(proxyURL *url.URL) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if proxyURL != nil {
+		transport.Proxy = http.ProxyURL(proxyURL)
+	} else {
+		transport.Proxy = http.ProxyFromEnvironment
+	}
+	return transport
+}
+
+// sharedHTTPTransport returns the transport for the configured proxy, building it on first use
+func sharedHTTPTransport() *http.Transport {
+	httpTransportMu.Lock()
+	defer httpTransportMu.Unlock()
+	if httpTransport == nil {
+		httpTransport = newHTTPTransport(httpProxyURL)
+	}
+	return httpTransport
+}
+
+// newHTTPClient returns an HTTP client honouring the configured proxy and request limits
+func newHTTPClient() *http.Client {
+	var transport http.RoundTripper = sharedHTTPTransport()
+	if limiter := currentRequestLimiter(); limiter != nil {
+		transport = &limitedTransport{base: transport, limiter: limiter}
+	}
+	if githubAppTokens != nil {
+		transport = &appAuthTransport{base: transport}
+	}
+	return &http.Client{Transport: transport}
+}
+
+// applySyncSettings installs the network options of a loaded configuration
+func applySyncSettings(config *MultiProjectConfig) {
+	if err := SetHTTPProxy(config.Sync.Proxy); err != nil {
+		fmt.Printf("⚠ %v\n", err)
+	}
+	if err := setConfiguredRequestLimits(config.Sync); err != nil {
+		fmt.Printf("⚠ %v\n", err)
+	}
+}
@@ -0,0 +1,158 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestParseProxyURL(t *testing.T) {
+	if _, err := ParseProxyURL("http://proxy.corp.example:3128"); err != nil {
+		t.Errorf("Expected valid proxy URL, got %v", err)
+	}
+	for _, raw := range []string{"proxy.corp.example:3128", "ftp://proxy.corp.example", "http://", "http://[::1"} {
+		if _, err := ParseProxyURL(raw); err == nil {
+			t.Errorf("Expected error for proxy URL %q", raw)
+		}
+	}
+}
+
+func TestNewHTTPTransport_UsesConfiguredProxy(t *testing.T) {
+	proxyURL, err := ParseProxyURL("http://proxy.corp.example:3128")
+	if err != nil {
+		t.Fatalf("ParseProxyURL failed: %v", err)
+	}
+
+	transport := newHTTPTransport(proxyURL)
+	req, _ := http.NewRequest("GET", "https://api.github.com/user", nil)
+	got, err := transport.Proxy(req)
+	if err != nil {
+		t.Fatalf("Proxy func failed: %v", err)
+	}
+	if got == nil || got.String() != "http://proxy.corp.example:3128" {
+		t.Errorf("Expected request routed through configured proxy, got %v", got)
+	}
+}
+
+func TestNewHTTPTransport_DefaultsToEnvironment(t *testing.T) {
+	transport := newHTTPTransport(nil)
+	if transport.Proxy == nil {
+		t.Fatal("Expected environment proxy function to be set explicitly")
+	}
+	// The default transport must not be shared so per-client changes stay local
+	if transport == http.DefaultTransport {
+		t.Error("Expected a cloned transport")
+	}
+}
+
+func TestNewHTTPClient_SharesTransport(t *testing.T) {
+	defer func() { _ = SetHTTPProxy("") }()
+
+	first := sharedHTTPTransport()
+	if second := sharedHTTPTransport(); second != first {
+		t.Error("Expected clients to share one transport so connections are reused")
+	}
+
+	// Reapplying the same settings keeps the transport; a new proxy replaces it
+	if err := SetHTTPProxy(""); err != nil {
+		t.Fatalf("SetHTTPProxy failed: %v", err)
+	}
+	if sharedHTTPTransport() != first {
+		t.Error("Expected unchanged proxy settings to keep the transport")
+	}
+	if err := SetHTTPProxy("http://proxy.corp.example:3128"); err != nil {
+		t.Fatalf("SetHTTPProxy failed: %v", err)
+	}
+	proxied := sharedHTTPTransport()
+	if proxied == first {
+		t.Fatal("Expected a new transport for a new proxy")
+	}
+	req, _ := http.NewRequest("GET", "https://api.github.com/user", nil)
+	if got, _ := proxied.Proxy(req); got == nil || got.Host != "proxy.corp.example:3128" {
+		t.Errorf("Expected the new transport to use the proxy, got %v", got)
+	}
+	if err := SetHTTPProxy("http://proxy.corp.example:3128"); err != nil {
+		t.Fatalf("SetHTTPProxy failed: %v", err)
+	}
+	if sharedHTTPTransport() != proxied {
+		t.Error("Expected the same proxy to keep the transport")
+	}
+}
+
+func TestSetHTTPProxy_RoutesRequestsThroughProxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Requests sent to a proxy carry the absolute target URL
+		proxied = append(proxied, r.URL.String())
+		switch r.URL.Path {
+		case "/user":
+			_, _ = w.Write([]byte(`{"login": "octocat"}`))
+		case "/repos/octo/widgets":
+			_, _ = w.Write([]byte(`{"private": false}`))
+		default:
+			_, _ = w.Write([]byte(`[{"id": 1, "number": 1, "title": "Via proxy", "state": "open"}]`))
+		}
+	}))
+	defer proxy.Close()
+
+	oldURL := githubAPIURL
+	githubAPIURL = "http://github.invalid"
+	defer func() { githubAPIURL = oldURL }()
+
+	if err := SetHTTPProxy(proxy.URL); err != nil {
+		t.Fatalf("SetHTTPProxy failed: %v", err)
+	}
+	defer func() { _ = SetHTTPProxy("") }()
+
+	issues, err := FetchIssues("octo", "widgets", "test-token")
+	if err != nil {
+		t.Fatalf("FetchIssues through proxy failed: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Title != "Via proxy" {
+		t.Errorf("Expected issue served by proxy, got %+v", issues)
+	}
+	if len(proxied) == 0 || proxied[0] != "http://github.invalid/user" {
+		t.Errorf("Expected proxy to receive absolute GitHub URLs, got %v", proxied)
+	}
+
+	if err := SetHTTPProxy("not a proxy"); err == nil {
+		t.Error("Expected error for invalid proxy URL")
+	}
+}
+
+func TestLoadMultiProjectConfig_SyncProxy(t *testing.T) {
+	tempDir := t.TempDir()
+	oldDir, _ := os.Getwd()
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.Chdir(oldDir); err != nil {
+			t.Logf("Warning: Failed to change back to original directory: %v", err)
+		}
+	}()
+	defer func() { _ = SetHTTPProxy("") }()
+
+	configContent := `global:
+  database: ./pivot.db
+sync:
+  proxy: http://proxy.corp.example:3128
+projects:
+  - owner: octo
+    repo: widgets`
+	if err := os.WriteFile("config.yml", []byte(configContent), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	config, err := LoadMultiProjectConfig()
+	if err != nil {
+		t.Fatalf("LoadMultiProjectConfig failed: %v", err)
+	}
+	if config.Sync.Proxy != "http://proxy.corp.example:3128" {
+		t.Errorf("Expected sync.proxy to be loaded, got %q", config.Sync.Proxy)
+	}
+	if httpProxyURL == nil || httpProxyURL.Host != "proxy.corp.example:3128" {
+		t.Errorf("Expected proxy override to be installed, got %v", httpProxyURL)
+	}
+}
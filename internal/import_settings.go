@@ -0,0 +1,6 @@
+package internal
+
+// ImportSettings contains configuration for importing issues from files such as CSV
+type ImportSettings struct {
+	AddFooter bool `yaml:"add_footer,omitempty"` // Append an "imported by" footer to the body sent to GitHub
+}
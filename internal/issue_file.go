@@ -0,0 +1,152 @@
+package internal
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// IssueFile is an issue authored as a markdown file: YAML front matter between ---
+// lines holds the fields, the markdown after it is the body
+type IssueFile struct {
+	Title     string   `yaml:"title"`
+	Labels    []string `yaml:"labels"`
+	Assignees []string `yaml:"assignees"`
+	Type      string   `yaml:"type"`
+	Milestone string   `yaml:"milestone"`  // Milestone title or number, resolved by push
+	DependsOn []int    `yaml:"depends_on"` // Issue numbers, or local IDs of unpushed issues (see ResolveDependencies)
+
+	StoryPoints        int    `yaml:"story_points"`
+	EstimatedHours     int    `yaml:"estimated_hours"`
+	Epic               string `yaml:"epic"`
+	AcceptanceCriteria string `yaml:"acceptance_criteria"`
+
+	Body string `yaml:"-"`
+}
+
+// ParseIssueFile reads a markdown issue file with YAML front matter, e.g.
+//
+//	---
+//	title: Login fails on Safari
+//	labels: [bug, frontend]
+//	---
+//	Steps to reproduce...
+//
+// Agile fields missing from the front matter are taken from "Field: value" metadata
+// lines in the body, as sync does for GitHub issues (see ApplyAgileMetadata).
+func ParseIssueFile(path string) (*IssueFile, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - User controls issue file path
+	if err != nil {
+		return nil, fmt.Errorf("failed to read issue file: %w", err)
+	}
+
+	content := strings.ReplaceAll(string(data), "\r\n", "\n")
+	content = strings.TrimPrefix(content, "\ufeff")
+	if !strings.HasPrefix(content, "---\n") {
+		return nil, fmt.Errorf("%s has no front matter (start the file with a --- line)", path)
+	}
+	rest := content[len("---\n"):]
+
+	var frontMatter, body string
+	if strings.HasPrefix(rest, "---\n") || rest == "---" {
+		body = strings.TrimPrefix(rest, "---")
+	} else {
+		end := strings.Index(rest, "\n---\n")
+		if end < 0 {
+			if !strings.HasSuffix(rest, "\n---") {
+				return nil, fmt.Errorf("%s has no closing --- line after the front matter", path)
+			}
+			end = len(rest) - len("\n---")
+		}
+		frontMatter = rest[:end]
+		body = strings.TrimPrefix(rest[end:], "\n---")
+	}
+
+	var issue IssueFile
+	if err := yaml.Unmarshal([]byte(frontMatter), &issue); err != nil {
+		return nil, fmt.Errorf("failed to parse front matter of %s: %w", path, err)
+	}
+
+	issue.Title = strings.TrimSpace(issue.Title)
+	if issue.Title == "" {
+		return nil, fmt.Errorf("front matter of %s is missing the required title", path)
+	}
+	issue.Labels = DedupeList(issue.Labels)
+	issue.Assignees = DedupeList(issue.Assignees)
+	issue.Type = strings.TrimSpace(issue.Type)
+	issue.Milestone = strings.TrimSpace(issue.Milestone)
+	issue.DependsOn = ParseDependencies(FormatDependencies(issue.DependsOn))
+	issue.Epic = strings.TrimSpace(issue.Epic)
+	issue.AcceptanceCriteria = strings.TrimSpace(issue.AcceptanceCriteria)
+	issue.Body = strings.TrimSpace(body)
+	issue.applyBodyMetadata()
+
+	return &issue, nil
+}
+
+// applyBodyMetadata fills the fields the front matter left empty from the body's
+// metadata lines; a field set in the front matter wins over the body
+func (f *IssueFile) applyBodyMetadata() {
+	meta := &DBIssue{Body: f.Body}
+	ApplyAgileMetadata(meta)
+
+	if f.Milestone == "" {
+		f.Milestone = meta.Milestone
+	}
+	if f.StoryPoints == 0 {
+		f.StoryPoints = meta.StoryPoints
+	}
+	if f.EstimatedHours == 0 {
+		f.EstimatedHours = meta.EstimatedHours
+	}
+	if f.Epic == "" {
+		f.Epic = meta.Epic
+	}
+	if f.AcceptanceCriteria == "" {
+		f.AcceptanceCriteria = meta.AcceptanceCriteria
+	}
+	if len(f.DependsOn) == 0 {
+		f.DependsOn = ParseDependencies(meta.Dependencies)
+	}
+}
+
+// CreateLocalIssue stores an issue that does not exist on GitHub yet and marks it
+// LOCAL_ONLY. It returns the local ID (the issues rowid) the sync state refers to.
+func CreateLocalIssue(db *sql.DB, projectID int64, issue *DBIssue) (int64, error) {
+	if err := InitSyncStateSchema(db); err != nil {
+		return 0, err
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	state := issue.State
+	if state == "" {
+		state = "open"
+	}
+
+	res, err := db.Exec(`
+		INSERT INTO issues (github_id, project_id, number, title, body, state, labels, assignees,
+			milestone, story_points, estimated_hours, epic, acceptance_criteria,
+			created_at, updated_at, local_modified_at, sync_hash, issue_type, dependencies)
+		VALUES (NULL, ?, 0, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		projectID, issue.Title, issue.Body, state, issue.Labels, issue.Assignees, issue.Milestone,
+		issue.StoryPoints, issue.EstimatedHours, issue.Epic, issue.AcceptanceCriteria,
+		now, now, now, ComputeSyncHash(issue), issue.Type, issue.Dependencies)
+	if err != nil {
+		return 0, fmt.Errorf("failed to save local issue: %w", err)
+	}
+	localID, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get local issue ID: %w", err)
+	}
+
+	if err := CreateSyncState(db, localID, SyncStateLocalOnly, nil); err != nil {
+		_, _ = db.Exec("DELETE FROM issues WHERE rowid = ?", localID)
+		return 0, err
+	}
+
+	return localID, nil
+}
@@ -0,0 +1,177 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeIssueFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "issue.md")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write issue file: %v", err)
+	}
+	return path
+}
+
+func TestParseIssueFile(t *testing.T) {
+	path := writeIssueFile(t, "---\r\ntitle: \" Login fails on Safari \"\r\nlabels: [bug, frontend, Bug]\r\nassignees:\r\n  - alice\r\ntype: Bug\r\nmilestone: v2.0\r\n---\r\n\r\n## Steps\r\n\r\n1. Open Safari\r\n")
+
+	issue, err := ParseIssueFile(path)
+	if err != nil {
+		t.Fatalf("Expected issue file to parse, got: %v", err)
+	}
+	if issue.Title != "Login fails on Safari" {
+		t.Errorf("Expected trimmed title, got '%s'", issue.Title)
+	}
+	if strings.Join(issue.Labels, ",") != "bug,frontend" {
+		t.Errorf("Expected deduplicated labels bug,frontend, got %v", issue.Labels)
+	}
+	if strings.Join(issue.Assignees, ",") != "alice" {
+		t.Errorf("Expected assignee alice, got %v", issue.Assignees)
+	}
+	if issue.Type != "Bug" {
+		t.Errorf("Expected type Bug, got '%s'", issue.Type)
+	}
+	if issue.Milestone != "v2.0" {
+		t.Errorf("Expected milestone v2.0, got '%s'", issue.Milestone)
+	}
+	if issue.Body != "## Steps\n\n1. Open Safari" {
+		t.Errorf("Expected markdown body, got %q", issue.Body)
+	}
+}
+
+func TestParseIssueFile_DependsOn(t *testing.T) {
+	path := writeIssueFile(t, "---\ntitle: Checkout\ndepends_on: [15, 12, 15]\n---\nBody\n")
+
+	issue, err := ParseIssueFile(path)
+	if err != nil {
+		t.Fatalf("Expected issue file to parse, got: %v", err)
+	}
+	if FormatDependencies(issue.DependsOn) != "12,15" {
+		t.Errorf("Expected sorted unique dependencies 12,15, got %v", issue.DependsOn)
+	}
+}
+
+func TestParseIssueFile_AgileFields(t *testing.T) {
+	path := writeIssueFile(t, "---\ntitle: Checkout\nstory_points: 8\nepic: Payments\n---\nStory Points: 3\nEstimated Hours: 12\nDepends on: #4\nAcceptance Criteria:\n- Card payments succeed\n- Refunds work\n")
+
+	issue, err := ParseIssueFile(path)
+	if err != nil {
+		t.Fatalf("Expected issue file to parse, got: %v", err)
+	}
+	if issue.StoryPoints != 8 || issue.Epic != "Payments" {
+		t.Errorf("Expected front matter to win over the body, got points %d epic %q", issue.StoryPoints, issue.Epic)
+	}
+	if issue.EstimatedHours != 12 {
+		t.Errorf("Expected estimated hours from the body, got %d", issue.EstimatedHours)
+	}
+	if issue.AcceptanceCriteria != "- Card payments succeed\n- Refunds work" {
+		t.Errorf("Expected acceptance criteria from the body, got %q", issue.AcceptanceCriteria)
+	}
+	if FormatDependencies(issue.DependsOn) != "4" {
+		t.Errorf("Expected dependencies from the body, got %v", issue.DependsOn)
+	}
+}
+
+func TestParseIssueFile_BodyMayContainRules(t *testing.T) {
+	path := writeIssueFile(t, "---\ntitle: Docs\n---\nIntro\n\n---\n\nMore")
+
+	issue, err := ParseIssueFile(path)
+	if err != nil {
+		t.Fatalf("Expected issue file to parse, got: %v", err)
+	}
+	if issue.Body != "Intro\n\n---\n\nMore" {
+		t.Errorf("Expected horizontal rule to stay in the body, got %q", issue.Body)
+	}
+}
+
+func TestParseIssueFile_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"no front matter", "# Just markdown\n", "no front matter"},
+		{"unclosed front matter", "---\ntitle: Open\nbody text\n", "no closing ---"},
+		{"missing title", "---\nlabels: [bug]\n---\nBody\n", "missing the required title"},
+		{"blank title", "---\ntitle: \"  \"\n---\nBody\n", "missing the required title"},
+		{"invalid yaml", "---\ntitle: [unclosed\n---\n", "failed to parse front matter"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseIssueFile(writeIssueFile(t, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing '%s', got: %v", tt.want, err)
+			}
+		})
+	}
+
+	if _, err := ParseIssueFile(filepath.Join(t.TempDir(), "missing.md")); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
+
+func TestCreateLocalIssue(t *testing.T) {
+	db := newTestMultiProjectDB(t)
+	projectID, err := CreateProject(db, &ProjectConfig{Owner: "octo", Repo: "widgets"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	localID, err := CreateLocalIssue(db, projectID, &DBIssue{
+		Title:     "Draft feature",
+		Body:      "Details",
+		Labels:    "enhancement,ui",
+		Assignees: "alice",
+		Type:      "Feature",
+
+		StoryPoints:        5,
+		EstimatedHours:     8,
+		Epic:               "Onboarding",
+		AcceptanceCriteria: "- Wizard shows",
+	})
+	if err != nil {
+		t.Fatalf("Expected local issue to be created, got: %v", err)
+	}
+
+	state, err := GetSyncState(db, localID)
+	if err != nil {
+		t.Fatalf("Failed to get sync state: %v", err)
+	}
+	if state.SyncState != SyncStateLocalOnly {
+		t.Errorf("Expected state %s, got %s", SyncStateLocalOnly, state.SyncState)
+	}
+	if state.GitHubID != nil {
+		t.Errorf("Expected no GitHub ID, got %d", *state.GitHubID)
+	}
+
+	issues, err := GetIssuesForProject(db, projectID)
+	if err != nil {
+		t.Fatalf("Expected local issue to be listable, got: %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d", len(issues))
+	}
+	issue := issues[0]
+	if issue.ID != 0 || issue.Number != 0 {
+		t.Errorf("Expected no GitHub ID or number, got id %d number %d", issue.ID, issue.Number)
+	}
+	if issue.Title != "Draft feature" || issue.Body != "Details" || issue.State != "open" {
+		t.Errorf("Unexpected issue fields: %+v", issue)
+	}
+	if issue.Labels != "enhancement,ui" || issue.Assignees != "alice" || issue.Type != "Feature" {
+		t.Errorf("Unexpected labels/assignees/type: %+v", issue)
+	}
+	if issue.StoryPoints != 5 || issue.EstimatedHours != 8 || issue.Epic != "Onboarding" || issue.AcceptanceCriteria != "- Wizard shows" {
+		t.Errorf("Expected agile fields to be stored, got: %+v", issue)
+	}
+
+	// A second local issue must not collide with the first on the (github_id, project_id) key
+	if _, err := CreateLocalIssue(db, projectID, &DBIssue{Title: "Another draft"}); err != nil {
+		t.Errorf("Expected second local issue to be created, got: %v", err)
+	}
+}
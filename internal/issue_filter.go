@@ -0,0 +1,147 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IssueFilterKeys lists the fields an issue filter expression can match on
+var IssueFilterKeys = []string{"state", "label", "assignee", "milestone", "epic", "title"}
+
+// IssueFilter matches issues against an expression such as `label:team-a state:open`.
+// Terms are combined with AND, comma-separated values within a term with OR, and a
+// leading "-" negates a term. Values are compared case-insensitively; title matches
+// a substring. Quote values containing spaces: `title:"login page"`.
+type IssueFilter struct {
+	expr  string
+	terms []filterTerm
+}
+
+type filterTerm struct {
+	key    string
+	values []string
+	negate bool
+}
+
+// ParseIssueFilter parses a filter expression. An empty expression matches every issue.
+func ParseIssueFilter(expr string) (*IssueFilter, error) {
+	tokens, err := splitFilterTokens(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &IssueFilter{expr: strings.TrimSpace(expr)}
+	for _, token := range tokens {
+		term := filterTerm{}
+		if strings.HasPrefix(token, "-") {
+			term.negate = true
+			token = token[1:]
+		}
+
+		key, value, found := strings.Cut(token, ":")
+		if !found || value == "" {
+			return nil, fmt.Errorf("invalid filter term '%s' (expected key:value)", token)
+		}
+		term.key = strings.ToLower(key)
+		if !isIssueFilterKey(term.key) {
+			return nil, fmt.Errorf("unknown filter key '%s' (valid: %s)", key, strings.Join(IssueFilterKeys, ", "))
+		}
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.ToLower(strings.TrimSpace(v)); v != "" {
+				term.values = append(term.values, v)
+			}
+		}
+		if len(term.values) == 0 {
+			return nil, fmt.Errorf("invalid filter term '%s' (expected key:value)", token)
+		}
+		filter.terms = append(filter.terms, term)
+	}
+	return filter, nil
+}
+
+// String returns the expression the filter was parsed from
+func (f *IssueFilter) String() string {
+	return f.expr
+}
+
+// Matches reports whether the issue satisfies every term of the filter
+func (f *IssueFilter) Matches(issue *DBIssue) bool {
+	for _, term := range f.terms {
+		if term.matches(issue) == term.negate {
+			return false
+		}
+	}
+	return true
+}
+
+// matches reports whether any of the term's values matches the issue
+func (t filterTerm) matches(issue *DBIssue) bool {
+	var fields []string
+	switch t.key {
+	case "state":
+		fields = []string{issue.State}
+	case "label":
+		fields = splitCommaList(issue.Labels)
+	case "assignee":
+		fields = splitCommaList(issue.Assignees)
+	case "milestone":
+		fields = []string{issue.Milestone}
+	case "epic":
+		fields = []string{issue.Epic}
+	case "title":
+		title := strings.ToLower(issue.Title)
+		for _, value := range t.values {
+			if strings.Contains(title, value) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, field := range fields {
+		for _, value := range t.values {
+			if strings.EqualFold(strings.TrimSpace(field), value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isIssueFilterKey reports whether key is one of IssueFilterKeys
+func isIssueFilterKey(key string) bool {
+	for _, valid := range IssueFilterKeys {
+		if key == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// splitFilterTokens splits an expression on whitespace outside double quotes,
+// removing the quotes
+func splitFilterTokens(expr string) ([]string, error) {
+	var tokens []string
+	var current strings.Builder
+	inQuotes := false
+	for _, r := range expr {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case (r == ' ' || r == '\t') && !inQuotes:
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if inQuotes {
+		return nil, fmt.Errorf("invalid filter '%s': unterminated quote", expr)
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+	return tokens, nil
+}
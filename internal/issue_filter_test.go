@@ -0,0 +1,166 @@
+package internal
+
+import (
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseIssueFilter_Matches(t *testing.T) {
+	issue := &DBIssue{Title: "Fix Login page", State: "open", Labels: "bug,Team-A", Assignees: "alice", Milestone: "v1"}
+
+	tests := map[string]bool{
+		"":                            true,
+		"label:team-a":                true,
+		"label:team-b":                false,
+		"label:team-b,team-a":         true,
+		"state:open label:bug":        true,
+		"state:closed label:bug":      false,
+		"-state:closed":               true,
+		"-label:bug":                  false,
+		"assignee:ALICE":              true,
+		"milestone:v2":                false,
+		`title:"login page"`:          true,
+		"title:signup":                false,
+		"label:team-a assignee:alice": true,
+	}
+	for expr, expected := range tests {
+		filter, err := ParseIssueFilter(expr)
+		if err != nil {
+			t.Fatalf("ParseIssueFilter(%q) failed: %v", expr, err)
+		}
+		if got := filter.Matches(issue); got != expected {
+			t.Errorf("Filter %q: expected %v, got %v", expr, expected, got)
+		}
+	}
+}
+
+func TestParseIssueFilter_Invalid(t *testing.T) {
+	for expr, message := range map[string]string{
+		"team-a":          "expected key:value",
+		"label:":          "expected key:value",
+		"author:alice":    "unknown filter key",
+		`title:"unclosed`: "unterminated quote",
+	} {
+		if _, err := ParseIssueFilter(expr); err == nil || !strings.Contains(err.Error(), message) {
+			t.Errorf("ParseIssueFilter(%q): expected error containing %q, got %v", expr, message, err)
+		}
+	}
+}
+
+func TestSyncAdHoc_SelectPersistsOnlyMatches(t *testing.T) {
+	pages := 0
+	newMockGitHubServer(t, "octo", "widgets", "", map[string]http.HandlerFunc{
+		"/repos/octo/widgets/issues": func(w http.ResponseWriter, r *http.Request) {
+			pages++
+			if r.URL.Query().Get("page") == "2" {
+				_, _ = w.Write([]byte(`[{"id": 903, "number": 3, "title": "Third", "state": "open", "labels": [{"name": "team-a"}], "updated_at": "2024-03-01T10:00:00Z"}]`))
+				return
+			}
+			w.Header().Set("Link", `<`+githubAPIURL+`/repos/octo/widgets/issues?page=2>; rel="next"`)
+			_, _ = w.Write([]byte(`[
+				{"id": 901, "number": 1, "title": "First", "state": "open", "labels": [{"name": "team-a"}], "updated_at": "2024-03-01T10:00:00Z"},
+				{"id": 902, "number": 2, "title": "Second", "state": "open", "labels": [{"name": "team-b"}], "updated_at": "2024-03-01T10:00:00Z"}
+			]`))
+		},
+	})
+
+	config, err := NewAdHocConfig("octo/widgets", "test-token")
+	if err != nil {
+		t.Fatalf("NewAdHocConfig failed: %v", err)
+	}
+	config.Global.Database = filepath.Join(t.TempDir(), "pivot.db")
+
+	filter, err := ParseIssueFilter("label:team-a")
+	if err != nil {
+		t.Fatalf("ParseIssueFilter failed: %v", err)
+	}
+	result, err := SyncAdHoc(config, SyncOptions{Select: filter})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	if pages != 2 {
+		t.Errorf("Expected both remote pages to be fetched, got %d", pages)
+	}
+	totals := result.Totals()
+	if totals.Created != 2 || totals.Skipped != 1 {
+		t.Errorf("Expected 2 created and 1 skipped, got %d and %d", totals.Created, totals.Skipped)
+	}
+
+	db, err := InitMultiProjectDBFromPath(config.Global.Database)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	rows, err := db.Query("SELECT number FROM issues ORDER BY number")
+	if err != nil {
+		t.Fatalf("Failed to query issues: %v", err)
+	}
+	defer rows.Close()
+	var numbers []int
+	for rows.Next() {
+		var number int
+		if err := rows.Scan(&number); err != nil {
+			t.Fatalf("Failed to scan issue: %v", err)
+		}
+		numbers = append(numbers, number)
+	}
+	if len(numbers) != 2 || numbers[0] != 1 || numbers[1] != 3 {
+		t.Errorf("Expected only issues #1 and #3 stored, got %v", numbers)
+	}
+}
+
+func TestSyncSelect_KeepsWatermarkForSkippedIssues(t *testing.T) {
+	// Like GitHub, the mock only returns issues updated at or after ?since
+	newMockGitHubServer(t, "octo", "widgets", "", map[string]http.HandlerFunc{
+		"/repos/octo/widgets/issues": func(w http.ResponseWriter, r *http.Request) {
+			var issues []string
+			for _, issue := range []struct{ updatedAt, json string }{
+				{"2024-05-01T10:00:00Z", `{"id": 901, "number": 1, "title": "First", "state": "open", "labels": [{"name": "team-a"}], "updated_at": "2024-05-01T10:00:00Z"}`},
+				{"2024-05-02T10:00:00Z", `{"id": 902, "number": 2, "title": "Second", "state": "open", "labels": [{"name": "team-b"}], "updated_at": "2024-05-02T10:00:00Z"}`},
+			} {
+				if issue.updatedAt >= r.URL.Query().Get("since") {
+					issues = append(issues, issue.json)
+				}
+			}
+			_, _ = w.Write([]byte("[" + strings.Join(issues, ",") + "]"))
+		},
+	})
+	db := newTestMultiProjectDB(t)
+
+	project := &ProjectConfig{Owner: "octo", Repo: "widgets"}
+	projectID, err := CreateProject(db, project)
+	if err != nil {
+		t.Fatalf("CreateProject failed: %v", err)
+	}
+	filter, err := ParseIssueFilter("label:team-a")
+	if err != nil {
+		t.Fatalf("ParseIssueFilter failed: %v", err)
+	}
+
+	global := &GlobalConfig{Token: "test-token"}
+	result, err := syncProjectWithOptions(db, global, project, SyncOptions{Select: filter})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if result.Skipped != 1 {
+		t.Errorf("Expected 1 skipped issue, got %d", result.Skipped)
+	}
+	if watermark, err := GetSyncWatermark(db, projectID); err != nil || watermark != "" {
+		t.Errorf("Expected --select not to advance the watermark, got %q (%v)", watermark, err)
+	}
+
+	// Dropping the selection stores the issue the selected sync skipped
+	if _, err := syncProjectWithOptions(db, global, project, SyncOptions{}); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	issues, err := GetIssuesForProject(db, projectID)
+	if err != nil {
+		t.Fatalf("Failed to get issues: %v", err)
+	}
+	if len(issues) != 2 || issues[1].Title != "Second" {
+		t.Errorf("Expected issue #2 to be stored once the selection is dropped, got %+v", issues)
+	}
+}
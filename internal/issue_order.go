@@ -0,0 +1,50 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IssueOrder is the order stored issues are read in
+type IssueOrder struct {
+	Field string // A list sort key: number, created, updated, title or reactions
+	Desc  bool
+}
+
+// DefaultIssueOrder orders issues by ascending number
+var DefaultIssueOrder = IssueOrder{Field: "number"}
+
+// IssueOrderFields returns the fields issues can be ordered by, sorted by name. They
+// are the sort keys of 'pivot list' (see ListSortKeys).
+func IssueOrderFields() []string {
+	return ListSortKeys()
+}
+
+// ParseIssueOrder validates an --order-by field and returns the order it selects
+func ParseIssueOrder(field string, desc bool) (IssueOrder, error) {
+	field = strings.ToLower(strings.TrimSpace(field))
+	if _, ok := listSortColumns[field]; !ok {
+		return IssueOrder{}, fmt.Errorf("invalid order field '%s' (supported: %s)", field, strings.Join(IssueOrderFields(), ", "))
+	}
+	return IssueOrder{Field: field, Desc: desc}, nil
+}
+
+// orderByClause returns the ORDER BY clause of the order. Ties are broken by number
+// and then local ID, so issues are always read in the same order.
+func (o IssueOrder) orderByClause() string {
+	column, ok := listSortColumns[o.Field]
+	if !ok {
+		column = listSortColumns[DefaultIssueOrder.Field]
+	}
+	direction := "ASC"
+	if o.Desc {
+		direction = "DESC"
+	}
+
+	terms := []string{column + " " + direction}
+	if column != "number" {
+		terms = append(terms, "number "+direction)
+	}
+	terms = append(terms, "rowid "+direction)
+	return "ORDER BY " + strings.Join(terms, ", ")
+}
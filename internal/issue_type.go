@@ -0,0 +1,27 @@
+package internal
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// IssueType is the GitHub issue type (e.g. Bug, Feature or Task) attached to an issue.
+// Repositories whose organization has no issue types report no type at all.
+type IssueType struct {
+	Name string `json:"name"`
+}
+
+// AddIssueTypeColumn adds the issue_type column to the issues table
+func AddIssueTypeColumn(db *sql.DB) error {
+	exists, err := hasColumn(db, "issues", "issue_type")
+	if err != nil {
+		return fmt.Errorf("failed to check for issue_type column: %w", err)
+	}
+	if exists {
+		return nil
+	}
+	if _, err := db.Exec("ALTER TABLE issues ADD COLUMN issue_type TEXT"); err != nil {
+		return fmt.Errorf("failed to add issue_type column: %w", err)
+	}
+	return nil
+}
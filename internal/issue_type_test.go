@@ -0,0 +1,87 @@
+package internal
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSyncAdHoc_PersistsIssueType(t *testing.T) {
+	newMockGitHubServer(t, "octo", "widgets", `[
+		{"id": 1201, "number": 1, "title": "Crash", "state": "open", "type": {"id": 7, "name": "Bug"}, "updated_at": "2024-03-01T10:00:00Z"},
+		{"id": 1202, "number": 2, "title": "Untyped", "state": "open", "type": null, "updated_at": "2024-03-01T10:00:00Z"}
+	]`, nil)
+
+	config, err := NewAdHocConfig("octo/widgets", "test-token")
+	if err != nil {
+		t.Fatalf("NewAdHocConfig failed: %v", err)
+	}
+	config.Global.Database = filepath.Join(t.TempDir(), "pivot.db")
+	if _, err := SyncAdHoc(config, SyncOptions{}); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	db, err := InitMultiProjectDBFromPath(config.Global.Database)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	projectID, err := getProjectID(db, "octo", "widgets")
+	if err != nil {
+		t.Fatalf("Failed to find project: %v", err)
+	}
+
+	issues, err := GetIssuesForProject(db, projectID)
+	if err != nil {
+		t.Fatalf("GetIssuesForProject failed: %v", err)
+	}
+	if len(issues) != 2 || issues[0].Type != "Bug" || issues[1].Type != "" {
+		t.Errorf("Expected types Bug and empty, got %+v", issues)
+	}
+
+	listed, err := ListIssues(db, ListOptions{ProjectID: projectID})
+	if err != nil {
+		t.Fatalf("ListIssues failed: %v", err)
+	}
+	if len(listed) != 2 || listed[0].Type != "Bug" {
+		t.Errorf("Expected ListIssues to include the type, got %+v", listed)
+	}
+	if cell := listColumnValues["type"](listed[0], nil); cell != "Bug" {
+		t.Errorf("Expected type column 'Bug', got '%s'", cell)
+	}
+}
+
+func TestAddIssueTypeColumn_Idempotent(t *testing.T) {
+	db := newTestMultiProjectDB(t)
+	if err := AddIssueTypeColumn(db); err != nil {
+		t.Errorf("Expected adding an existing column to be a no-op, got: %v", err)
+	}
+}
+
+func TestCreateIssue_SendsType(t *testing.T) {
+	var payload map[string]interface{}
+	newMockGitHubServer(t, "octo", "widgets", "", map[string]http.HandlerFunc{
+		"/repos/octo/widgets/issues": func(w http.ResponseWriter, r *http.Request) {
+			data, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(data, &payload)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id": 1, "number": 12, "title": "Crash"}`))
+		},
+	})
+
+	request := ToCreateRequest(&DBIssue{Title: "Crash", Type: "Bug"})
+	if _, err := CreateIssue("octo", "widgets", "test-token", request); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if payload["type"] != "Bug" {
+		t.Errorf("Expected type Bug in the request, got %v", payload)
+	}
+
+	untyped, _ := json.Marshal(CreateIssueRequest{Title: "Plain"})
+	if strings.Contains(string(untyped), "type") {
+		t.Errorf("Expected no type field for an untyped issue, got %s", untyped)
+	}
+}
@@ -0,0 +1,158 @@
+package internal
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// LabelUsage counts the stored issues of a project that carry a label
+type LabelUsage struct {
+	Open   int
+	Closed int
+}
+
+// Total returns the number of issues carrying the label
+func (u LabelUsage) Total() int {
+	return u.Open + u.Closed
+}
+
+// hasLabel reports whether a comma-separated label list contains name (case-insensitive)
+func hasLabel(labels, name string) bool {
+	for _, label := range splitCommaList(labels) {
+		if strings.EqualFold(label, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// CountLabelUsage counts the open and closed stored issues of a project that carry
+// the label, compared case-insensitively like GitHub does
+func CountLabelUsage(db *sql.DB, projectID int64, name string) (LabelUsage, error) {
+	var usage LabelUsage
+	rows, err := db.Query("SELECT state, COALESCE(labels, '') FROM issues WHERE project_id = ?", projectID)
+	if err != nil {
+		return usage, fmt.Errorf("failed to read labels: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var state, labels string
+		if err := rows.Scan(&state, &labels); err != nil {
+			return usage, fmt.Errorf("failed to scan labels: %w", err)
+		}
+		if !hasLabel(labels, name) {
+			continue
+		}
+		if strings.EqualFold(state, "closed") {
+			usage.Closed++
+		} else {
+			usage.Open++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return usage, fmt.Errorf("failed to read labels: %w", err)
+	}
+	return usage, nil
+}
+
+// RemoveLabelLocally removes a label from every stored issue of a project and returns
+// the number of issues changed. The sync hash of issues without local edits is
+// recomputed, so 'pivot sync --checksum-verify' does not report them as corrupted.
+func RemoveLabelLocally(db *sql.DB, projectID int64, name string) (int, error) {
+	rows, err := db.Query(`
+		SELECT rowid, title, COALESCE(body, ''), state, COALESCE(labels, ''), COALESCE(assignees, ''),
+			COALESCE(sync_hash, ''), COALESCE(local_modified_at, '')
+		FROM issues WHERE project_id = ?`, projectID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read labels: %w", err)
+	}
+
+	type labelUpdate struct {
+		rowid  int64
+		labels string
+		hash   string
+	}
+	var updates []labelUpdate
+	for rows.Next() {
+		var rowid int64
+		var issue DBIssue
+		var hash, localModifiedAt string
+		if err := rows.Scan(&rowid, &issue.Title, &issue.Body, &issue.State, &issue.Labels, &issue.Assignees,
+			&hash, &localModifiedAt); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan labels: %w", err)
+		}
+		if !hasLabel(issue.Labels, name) {
+			continue
+		}
+
+		var kept []string
+		for _, label := range splitCommaList(issue.Labels) {
+			if !strings.EqualFold(label, name) {
+				kept = append(kept, label)
+			}
+		}
+		issue.Labels = strings.Join(kept, ",")
+		if hash != "" && localModifiedAt == "" {
+			hash = ComputeSyncHash(&issue)
+		}
+		updates = append(updates, labelUpdate{rowid: rowid, labels: issue.Labels, hash: hash})
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return 0, fmt.Errorf("failed to read labels: %w", err)
+	}
+	rows.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	for _, update := range updates {
+		if _, err := tx.Exec("UPDATE issues SET labels = ?, sync_hash = NULLIF(?, '') WHERE rowid = ?",
+			update.labels, update.hash, update.rowid); err != nil {
+			_ = tx.Rollback()
+			return 0, fmt.Errorf("failed to remove label '%s': %w", name, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit label removal: %w", err)
+	}
+	return len(updates), nil
+}
+
+// DeleteRemoteLabel deletes a label from a GitHub repository, which also removes it
+// from every issue and pull request there
+func DeleteRemoteLabel(owner, repo, token, name string) error {
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/labels/%s", githubAPIURL, owner, repo, url.PathEscape(name))
+	req, err := http.NewRequest("DELETE", endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "token "+token)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+	resp, err := newHTTPClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to delete label '%s': %w", name, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+	switch resp.StatusCode {
+	case http.StatusNoContent:
+		return nil
+	case http.StatusNotFound:
+		return fmt.Errorf("label '%s' not found in %s/%s", name, owner, repo)
+	default:
+		return fmt.Errorf("GitHub API error (%d) deleting label '%s': %s", resp.StatusCode, name, strings.TrimSpace(string(body)))
+	}
+}
@@ -0,0 +1,121 @@
+package internal
+
+import (
+	"database/sql"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// newLabelTestDB stores a project with labeled open and closed issues
+func newLabelTestDB(t *testing.T) (*sql.DB, int64) {
+	t.Helper()
+	db := newTestMultiProjectDB(t)
+
+	projectID, err := CreateProject(db, &ProjectConfig{Owner: "owner", Repo: "repo"})
+	if err != nil {
+		t.Fatalf("CreateProject failed: %v", err)
+	}
+	for _, issue := range []*DBIssue{
+		{ID: 1, Number: 1, Title: "Open bug", State: "open", Labels: "bug,ui"},
+		{ID: 2, Number: 2, Title: "Closed wontfix", State: "closed", Labels: "WontFix"},
+		{ID: 3, Number: 3, Title: "Closed both", State: "closed", Labels: "ui, wontfix"},
+		{ID: 4, Number: 4, Title: "Unlabeled", State: "open"},
+	} {
+		if err := SaveIssue(db, projectID, issue); err != nil {
+			t.Fatalf("SaveIssue failed: %v", err)
+		}
+	}
+	return db, projectID
+}
+
+func TestCountLabelUsage(t *testing.T) {
+	db, projectID := newLabelTestDB(t)
+
+	tests := map[string]LabelUsage{
+		"ui":      {Open: 1, Closed: 1},
+		"wontfix": {Closed: 2},
+		"BUG":     {Open: 1},
+		"bu":      {},
+	}
+	for name, want := range tests {
+		usage, err := CountLabelUsage(db, projectID, name)
+		if err != nil {
+			t.Fatalf("CountLabelUsage(%q) failed: %v", name, err)
+		}
+		if usage != want {
+			t.Errorf("Expected usage %+v for %q, got %+v", want, name, usage)
+		}
+	}
+}
+
+func TestRemoveLabelLocally(t *testing.T) {
+	db, projectID := newLabelTestDB(t)
+	if _, err := db.Exec("UPDATE issues SET local_modified_at = '2024-01-01T00:00:00Z' WHERE number = 2"); err != nil {
+		t.Fatalf("Failed to edit issue locally: %v", err)
+	}
+
+	changed, err := RemoveLabelLocally(db, projectID, "wontfix")
+	if err != nil {
+		t.Fatalf("RemoveLabelLocally failed: %v", err)
+	}
+	if changed != 2 {
+		t.Errorf("Expected 2 issues changed, got %d", changed)
+	}
+
+	issues, err := GetIssuesForProject(db, projectID)
+	if err != nil {
+		t.Fatalf("Failed to get issues: %v", err)
+	}
+	labels := make(map[int]string)
+	for _, issue := range issues {
+		labels[issue.Number] = issue.Labels
+	}
+	want := map[int]string{1: "bug,ui", 2: "", 3: "ui", 4: ""}
+	for number, expected := range want {
+		if labels[number] != expected {
+			t.Errorf("Expected labels %q on #%d, got %q", expected, number, labels[number])
+		}
+	}
+
+	// The hash of the synced issue follows its new labels; the locally edited one is left alone
+	report, err := VerifySyncHashes(db, projectID)
+	if err != nil {
+		t.Fatalf("VerifySyncHashes failed: %v", err)
+	}
+	if len(report.Mismatches) != 0 {
+		t.Errorf("Expected no hash mismatches after removing a label, got %+v", report.Mismatches)
+	}
+
+	if changed, err := RemoveLabelLocally(db, projectID, "wontfix"); err != nil || changed != 0 {
+		t.Errorf("Expected a second removal to change nothing, got %d, %v", changed, err)
+	}
+}
+
+func TestDeleteRemoteLabel(t *testing.T) {
+	var method, path string
+	newMockGitHubServer(t, "owner", "repo", "[]", map[string]http.HandlerFunc{
+		"/repos/owner/repo/labels/needs triage": func(w http.ResponseWriter, r *http.Request) {
+			method, path = r.Method, r.URL.EscapedPath()
+			w.WriteHeader(http.StatusNoContent)
+		},
+		"/repos/owner/repo/labels/locked": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message": "Must have admin rights"}`))
+		},
+	})
+
+	if err := DeleteRemoteLabel("owner", "repo", "token", "needs triage"); err != nil {
+		t.Fatalf("DeleteRemoteLabel failed: %v", err)
+	}
+	if method != "DELETE" || path != "/repos/owner/repo/labels/needs%20triage" {
+		t.Errorf("Expected DELETE of the escaped label path, got %s %s", method, path)
+	}
+
+	if err := DeleteRemoteLabel("owner", "repo", "token", "missing"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+	if err := DeleteRemoteLabel("owner", "repo", "token", "locked"); err == nil || !strings.Contains(err.Error(), "GitHub API error (403)") {
+		t.Errorf("Expected API error, got %v", err)
+	}
+}
@@ -0,0 +1,160 @@
+package internal
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ListOptions controls which local issues ListIssues returns and in what order
+type ListOptions struct {
+	ProjectID int64  // Restrict to a single project (0 = all projects)
+	State     string // Restrict to a GitHub state such as open or closed
+	Number    int    // Restrict to a single issue number (0 = any)
+	Label     string // Restrict to issues carrying this label
+	Sort      string // One of the keys of listSortColumns (default: number)
+	Desc      bool
+	Limit     int // Maximum number of issues to return (0 = no limit)
+	Offset    int
+
+	UpdatedBefore time.Time // Restrict to issues last updated before this time (zero = any)
+	Blocked       bool      // Restrict to open issues with an open dependency
+	Blocking      bool      // Restrict to open issues that an open issue depends on
+}
+
+// listSortColumns maps user-facing sort keys to SQL ordering expressions
+var listSortColumns = map[string]string{
+	"number":  "number",
+	"updated": "updated_at",
+	"created": "created_at",
+	"title":   "title COLLATE NOCASE",
+	"reactions": `COALESCE((SELECT r.plus_one FROM issue_reactions r
+		WHERE r.project_id = issues.project_id AND r.number = issues.number), 0)`,
+}
+
+// ListSortKeys returns the supported sort keys in alphabetical order
+func ListSortKeys() []string {
+	keys := make([]string, 0, len(listSortColumns))
+	for key := range listSortColumns {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ListIssues retrieves local issues using SQL filtering, ordering and pagination
+func ListIssues(db *sql.DB, opts ListOptions) ([]DBIssue, error) {
+	sortKey := opts.Sort
+	if sortKey == "" {
+		sortKey = "number"
+	}
+	orderBy, ok := listSortColumns[sortKey]
+	if !ok {
+		return nil, fmt.Errorf("invalid sort field '%s' (valid: %s)", opts.Sort, strings.Join(ListSortKeys(), ", "))
+	}
+	if opts.Limit < 0 || opts.Offset < 0 {
+		return nil, fmt.Errorf("limit and offset must not be negative")
+	}
+
+	direction := "ASC"
+	if opts.Desc {
+		direction = "DESC"
+	}
+
+	query := `
+		SELECT COALESCE(github_id, 0), number, title, body, state, labels, assignees, created_at, updated_at, closed_at, issue_type,
+		       dependencies
+		FROM issues
+		WHERE 1 = 1`
+	var args []interface{}
+
+	if opts.ProjectID != 0 {
+		query += " AND project_id = ?"
+		args = append(args, opts.ProjectID)
+	}
+	if opts.State != "" {
+		query += " AND state = ?"
+		args = append(args, opts.State)
+	}
+	if opts.Number != 0 {
+		query += " AND number = ?"
+		args = append(args, opts.Number)
+	}
+	if opts.Label != "" {
+		// Match a whole label, case-insensitively like hasLabel; LIKE wildcards in the
+		// label are escaped so "good_first" does not match "goodXfirst"
+		query += ` AND (',' || REPLACE(COALESCE(labels, ''), ', ', ',') || ',') LIKE ? ESCAPE '\'`
+		args = append(args, "%,"+escapeLikePattern(strings.TrimSpace(opts.Label))+",%")
+	}
+	if !opts.UpdatedBefore.IsZero() {
+		// updated_at holds RFC3339 UTC timestamps, which sort as strings; issues
+		// without one are never considered old
+		query += " AND updated_at != '' AND updated_at < ?"
+		args = append(args, opts.UpdatedBefore.UTC().Format(time.RFC3339))
+	}
+
+	if opts.Blocked || opts.Blocking {
+		if err := InitDependencySchema(db); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Blocked {
+		query += blockedCondition
+	}
+	if opts.Blocking {
+		query += blockingCondition
+	}
+
+	// Break ties by project and number so pages never overlap
+	query += fmt.Sprintf(" ORDER BY %s %s, project_id, number", orderBy, direction)
+
+	if opts.Limit > 0 || opts.Offset > 0 {
+		limit := opts.Limit
+		if limit == 0 {
+			limit = -1 // SQLite: no limit
+		}
+		query += " LIMIT ? OFFSET ?"
+		args = append(args, limit, opts.Offset)
+	}
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query issues: %w", err)
+	}
+	defer rows.Close()
+
+	var issues []DBIssue
+	for rows.Next() {
+		var issue DBIssue
+		var labels, assignees, createdAt, updatedAt, closedAt, issueType, dependencies sql.NullString
+
+		err := rows.Scan(&issue.ID, &issue.Number, &issue.Title, &issue.Body,
+			&issue.State, &labels, &assignees, &createdAt, &updatedAt, &closedAt, &issueType, &dependencies)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan issue: %w", err)
+		}
+
+		issue.Labels = labels.String
+		issue.Assignees = assignees.String
+		issue.CreatedAt = createdAt.String
+		issue.UpdatedAt = updatedAt.String
+		issue.ClosedAt = closedAt.String
+		issue.Type = issueType.String
+		issue.Dependencies = dependencies.String
+
+		issues = append(issues, issue)
+	}
+
+	return issues, rows.Err()
+}
+
+// likeEscaper escapes the LIKE wildcards and the escape character itself, for
+// patterns used with ESCAPE '\'
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLikePattern makes a value match itself literally in a LIKE pattern
+func escapeLikePattern(value string) string {
+	return likeEscaper.Replace(value)
+}
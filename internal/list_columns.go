@@ -0,0 +1,111 @@
+package internal
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultColumnWidth is the maximum cell width used when none is configured
+const DefaultColumnWidth = 40
+
+// listColumnValues maps column names accepted by --columns to the value they display
+var listColumnValues = map[string]func(issue DBIssue, mappings []StateMapping) string{
+	"number":    func(issue DBIssue, _ []StateMapping) string { return "#" + strconv.Itoa(issue.Number) },
+	"title":     func(issue DBIssue, _ []StateMapping) string { return issue.Title },
+	"state":     func(issue DBIssue, mappings []StateMapping) string { return DisplayState(issue, mappings) },
+	"labels":    func(issue DBIssue, _ []StateMapping) string { return issue.Labels },
+	"assignees": func(issue DBIssue, _ []StateMapping) string { return issue.Assignees },
+	"created":   func(issue DBIssue, _ []StateMapping) string { return issue.CreatedAt },
+	"updated":   func(issue DBIssue, _ []StateMapping) string { return issue.UpdatedAt },
+	"closed":    func(issue DBIssue, _ []StateMapping) string { return issue.ClosedAt },
+	"type":      func(issue DBIssue, _ []StateMapping) string { return issue.Type },
+}
+
+// ListColumnNames returns the supported column names in alphabetical order
+func ListColumnNames() []string {
+	names := make([]string, 0, len(listColumnValues))
+	for name := range listColumnValues {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseListColumns validates a comma-separated column list such as "number,title,state"
+func ParseListColumns(spec string) ([]string, error) {
+	var columns []string
+	for _, part := range strings.Split(spec, ",") {
+		name := strings.ToLower(strings.TrimSpace(part))
+		if name == "" {
+			continue
+		}
+		if _, ok := listColumnValues[name]; !ok {
+			return nil, fmt.Errorf("invalid column '%s' (valid: %s)", name, strings.Join(ListColumnNames(), ", "))
+		}
+		columns = append(columns, name)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("no columns selected (valid: %s)", strings.Join(ListColumnNames(), ", "))
+	}
+	return columns, nil
+}
+
+// TruncateCell shortens a value to at most width characters, ending it with an ellipsis
+func TruncateCell(value string, width int) string {
+	if width <= 0 || utf8.RuneCountInString(value) <= width {
+		return value
+	}
+	runes := []rune(value)
+	if width == 1 {
+		return "…"
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+// RenderIssueTable renders issues as an aligned table of the selected columns.
+// Each column is sized to its widest cell, capped at maxWidth.
+func RenderIssueTable(issues []DBIssue, columns []string, mappings []StateMapping, maxWidth int) string {
+	rows := make([][]string, 0, len(issues)+1)
+
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = strings.ToUpper(column)
+	}
+	rows = append(rows, header)
+
+	for _, issue := range issues {
+		row := make([]string, len(columns))
+		for i, column := range columns {
+			// Newlines would break the table layout
+			value := strings.ReplaceAll(listColumnValues[column](issue, mappings), "\n", " ")
+			row[i] = TruncateCell(value, maxWidth)
+		}
+		rows = append(rows, row)
+	}
+
+	widths := make([]int, len(columns))
+	for _, row := range rows {
+		for i, cell := range row {
+			if n := utf8.RuneCountInString(cell); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+
+	var b strings.Builder
+	for _, row := range rows {
+		for i, cell := range row {
+			if i == len(row)-1 {
+				b.WriteString(cell)
+				break
+			}
+			b.WriteString(cell)
+			b.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)+2))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
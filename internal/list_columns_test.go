@@ -0,0 +1,73 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseListColumns(t *testing.T) {
+	columns, err := ParseListColumns("number, Title ,state")
+	if err != nil {
+		t.Fatalf("ParseListColumns failed: %v", err)
+	}
+	if strings.Join(columns, ",") != "number,title,state" {
+		t.Errorf("Expected number,title,state, got %v", columns)
+	}
+
+	if _, err := ParseListColumns("number,priority"); err == nil || !strings.Contains(err.Error(), "invalid column 'priority'") {
+		t.Errorf("Expected invalid column error, got %v", err)
+	}
+	if _, err := ParseListColumns(" , "); err == nil {
+		t.Error("Expected error for empty column list")
+	}
+}
+
+func TestTruncateCell(t *testing.T) {
+	tests := []struct {
+		value    string
+		width    int
+		expected string
+	}{
+		{"short", 10, "short"},
+		{"exactly10!", 10, "exactly10!"},
+		{"this is too long", 10, "this is t…"},
+		{"héllo wörld", 6, "héllo…"},
+		{"abc", 1, "…"},
+		{"unlimited", 0, "unlimited"},
+	}
+
+	for _, tt := range tests {
+		if got := TruncateCell(tt.value, tt.width); got != tt.expected {
+			t.Errorf("TruncateCell(%q, %d): expected %q, got %q", tt.value, tt.width, tt.expected, got)
+		}
+	}
+}
+
+func TestRenderIssueTable_ColumnsInOrderAndTruncated(t *testing.T) {
+	issues := []DBIssue{
+		{Number: 7, Title: "A very long title that should not fit the column", State: "open", Labels: "bug"},
+		{Number: 12, Title: "Short", State: "closed"},
+	}
+
+	output := RenderIssueTable(issues, []string{"state", "number", "title"}, nil, 20)
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected header and 2 rows, got %d lines: %q", len(lines), output)
+	}
+
+	if fields := strings.Fields(lines[0]); strings.Join(fields, " ") != "STATE NUMBER TITLE" {
+		t.Errorf("Expected header in selected order, got %q", lines[0])
+	}
+	if strings.Contains(output, "bug") {
+		t.Error("Expected unselected labels column to be omitted")
+	}
+	if !strings.Contains(lines[1], "A very long title t…") {
+		t.Errorf("Expected title truncated to 20 characters, got %q", lines[1])
+	}
+
+	// Cells of every row start at the same offset
+	titleOffset := strings.Index(lines[0], "TITLE")
+	if strings.Index(lines[1], "A very") != titleOffset || strings.Index(lines[2], "Short") != titleOffset {
+		t.Errorf("Expected aligned title column, got:\n%s", output)
+	}
+}
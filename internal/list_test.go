@@ -0,0 +1,119 @@
+package internal
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestListIssues_OrderingAndPagination(t *testing.T) {
+	db := newTestMultiProjectDB(t)
+
+	projectID, err := CreateProject(db, &ProjectConfig{Owner: "owner", Repo: "repo"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	// Titles run in reverse of numbers; updated_at is rotated
+	for n := 1; n <= 10; n++ {
+		issue := &DBIssue{
+			ID:        1000 + n,
+			Number:    n,
+			Title:     fmt.Sprintf("Issue %c", 'a'+10-n),
+			State:     map[bool]string{true: "open", false: "closed"}[n%2 == 1],
+			CreatedAt: fmt.Sprintf("2025-01-%02dT00:00:00Z", n),
+			UpdatedAt: fmt.Sprintf("2025-02-%02dT00:00:00Z", (n+3)%10+1),
+		}
+		if err := SaveIssue(db, projectID, issue); err != nil {
+			t.Fatalf("Failed to save issue: %v", err)
+		}
+	}
+
+	numbers := func(issues []DBIssue) []int {
+		var result []int
+		for _, issue := range issues {
+			result = append(result, issue.Number)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		opts     ListOptions
+		expected []int
+	}{
+		{"default number order", ListOptions{Limit: 3}, []int{1, 2, 3}},
+		{"number desc", ListOptions{Desc: true, Limit: 3}, []int{10, 9, 8}},
+		{"offset page", ListOptions{Limit: 3, Offset: 3}, []int{4, 5, 6}},
+		{"last partial page", ListOptions{Limit: 3, Offset: 9}, []int{10}},
+		{"past the end", ListOptions{Limit: 3, Offset: 10}, nil},
+		{"offset without limit", ListOptions{Offset: 8}, []int{9, 10}},
+		{"title order", ListOptions{Sort: "title", Limit: 2}, []int{10, 9}},
+		{"created desc", ListOptions{Sort: "created", Desc: true, Limit: 2}, []int{10, 9}},
+		{"updated order", ListOptions{Sort: "updated", Limit: 2}, []int{7, 8}},
+		{"state filter", ListOptions{State: "closed", Limit: 2, Offset: 1}, []int{4, 6}},
+		{"project filter", ListOptions{ProjectID: projectID, Limit: 1}, []int{1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, err := ListIssues(db, tt.opts)
+			if err != nil {
+				t.Fatalf("ListIssues failed: %v", err)
+			}
+			got := numbers(issues)
+			if fmt.Sprint(got) != fmt.Sprint(tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestListIssues_InvalidOptions(t *testing.T) {
+	db := newTestMultiProjectDB(t)
+
+	if _, err := ListIssues(db, ListOptions{Sort: "priority"}); err == nil {
+		t.Error("Expected error for invalid sort field")
+	}
+	if _, err := ListIssues(db, ListOptions{Limit: -1}); err == nil {
+		t.Error("Expected error for negative limit")
+	}
+}
+
+func TestListIssues_LabelFilter(t *testing.T) {
+	db := newTestMultiProjectDB(t)
+
+	projectID, err := CreateProject(db, &ProjectConfig{Owner: "owner", Repo: "repo"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	for n, labels := range map[int]string{1: "good_first,bug", 2: "goodXfirst", 3: "docs, Good_First", 4: "100%", 5: "1000", 6: `a\b`, 7: "ab"} {
+		if err := SaveIssue(db, projectID, &DBIssue{ID: 100 + n, Number: n, Title: fmt.Sprintf("Issue %d", n), State: "open", Labels: labels}); err != nil {
+			t.Fatalf("Failed to save issue: %v", err)
+		}
+	}
+
+	tests := []struct {
+		label    string
+		expected []int
+	}{
+		{"good_first", []int{1, 3}},
+		{"GOOD_FIRST", []int{1, 3}},
+		{"100%", []int{4}},
+		{`a\b`, []int{6}},
+		{"bug", []int{1}},
+		{"good", nil},
+	}
+	for _, tt := range tests {
+		issues, err := ListIssues(db, ListOptions{Label: tt.label})
+		if err != nil {
+			t.Fatalf("ListIssues failed: %v", err)
+		}
+		var got []int
+		for _, issue := range issues {
+			got = append(got, issue.Number)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tt.expected) {
+			t.Errorf("Label %q: expected %v, got %v", tt.label, tt.expected, got)
+		}
+	}
+}
@@ -255,6 +255,33 @@ database: legacy.db`
 		os.Remove("config.yml")
 	})
 
+	t.Run("Legacy config keeps push and import settings", func(t *testing.T) {
+		legacyContent := `owner: legacyowner
+repo: legacyrepo
+token: legacy_token
+push:
+  validation:
+    required_labels: [triage]
+import:
+  add_footer: true`
+
+		if err := os.WriteFile("config.yml", []byte(legacyContent), 0644); err != nil {
+			t.Fatalf("Failed to create legacy config: %v", err)
+		}
+		defer os.Remove("config.yml")
+
+		config, err := LoadMultiProjectConfig()
+		if err != nil {
+			t.Fatalf("LoadMultiProjectConfig failed: %v", err)
+		}
+		if len(config.Push.Validation.RequiredLabels) != 1 || config.Push.Validation.RequiredLabels[0] != "triage" {
+			t.Errorf("Expected the push validation rules to be kept, got %+v", config.Push.Validation)
+		}
+		if !config.Import.AddFooter {
+			t.Error("Expected the import footer setting to be kept")
+		}
+	})
+
 	t.Run("Legacy config with partial fields", func(t *testing.T) {
 		// Test legacy config with only required fields
 		legacyContent := `owner: minimalowner
@@ -0,0 +1,147 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Milestone is a GitHub milestone
+type Milestone struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	State  string `json:"state"`
+}
+
+// ListMilestones returns the open and closed milestones of a repository
+func ListMilestones(owner, repo, token string) ([]Milestone, error) {
+	var milestones []Milestone
+	for page := 1; ; page++ {
+		url := fmt.Sprintf("%s/repos/%s/%s/milestones?state=all&per_page=100&page=%d", githubAPIURL, owner, repo, page)
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Authorization", "token "+token)
+		req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+		resp, err := newHTTPClient().Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list milestones of %s/%s: %w", owner, repo, err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read milestones of %s/%s: %w", owner, repo, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("GitHub API error (%d) listing milestones: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+		}
+
+		var pageMilestones []Milestone
+		if err := json.Unmarshal(body, &pageMilestones); err != nil {
+			return nil, fmt.Errorf("failed to parse milestones of %s/%s: %w", owner, repo, err)
+		}
+		milestones = append(milestones, pageMilestones...)
+
+		if !strings.Contains(resp.Header.Get("Link"), `rel="next"`) {
+			return milestones, nil
+		}
+	}
+}
+
+// CreateMilestone creates an open milestone with the given title
+func CreateMilestone(owner, repo, token, title string) (*Milestone, error) {
+	payload, err := json.Marshal(map[string]string{"title": title})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal milestone: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/%s/milestones", githubAPIURL, owner, repo)
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "token "+token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+	resp, err := newHTTPClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create milestone '%s': %w", title, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("GitHub API error (%d) creating milestone '%s': %s", resp.StatusCode, title, strings.TrimSpace(string(body)))
+	}
+
+	var milestone Milestone
+	if err := json.Unmarshal(body, &milestone); err != nil {
+		return nil, fmt.Errorf("failed to parse created milestone: %w", err)
+	}
+	return &milestone, nil
+}
+
+// MilestoneResolver maps milestone titles to numbers for one repository, listing
+// the milestones once and optionally creating missing ones
+type MilestoneResolver struct {
+	Owner, Repo, Token string
+	CreateMissing      bool
+	Created            []string // Titles of milestones created by Resolve
+
+	byTitle map[string]int
+}
+
+// Resolve returns the number of the milestone with the given title (case-insensitive).
+// A missing milestone is created when CreateMissing is set and is an error otherwise.
+func (r *MilestoneResolver) Resolve(title string) (int, error) {
+	title = strings.TrimSpace(title)
+	if r.byTitle == nil {
+		milestones, err := ListMilestones(r.Owner, r.Repo, r.Token)
+		if err != nil {
+			return 0, err
+		}
+		r.byTitle = make(map[string]int, len(milestones))
+		for _, milestone := range milestones {
+			r.byTitle[strings.ToLower(milestone.Title)] = milestone.Number
+		}
+	}
+
+	if number, ok := r.byTitle[strings.ToLower(title)]; ok {
+		return number, nil
+	}
+	if !r.CreateMissing {
+		return 0, fmt.Errorf("milestone '%s' does not exist in %s/%s (use --create-milestone-if-missing to create it)", title, r.Owner, r.Repo)
+	}
+
+	milestone, err := CreateMilestone(r.Owner, r.Repo, r.Token, title)
+	if err != nil {
+		return 0, err
+	}
+	r.byTitle[strings.ToLower(title)] = milestone.Number
+	r.Created = append(r.Created, title)
+	return milestone.Number, nil
+}
+
+// ResolveMilestone sets the milestone number of a create request from an issue's
+// milestone, which holds either a number or a title
+func (r *MilestoneResolver) ResolveMilestone(request *CreateIssueRequest, milestone string) error {
+	milestone = strings.TrimSpace(milestone)
+	if milestone == "" || request.Milestone > 0 {
+		return nil
+	}
+	number, err := r.Resolve(milestone)
+	if err != nil {
+		return err
+	}
+	request.Milestone = number
+	return nil
+}
@@ -0,0 +1,48 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newMockGitHubServer starts a mock GitHub API that accepts any token for /user and
+// the owner/repo repository endpoint, serving issuesJSON from the issues endpoint.
+// Extra handlers override or extend the default routes. githubAPIURL points at the
+// server for the duration of the test.
+func newMockGitHubServer(t *testing.T, owner, repo, issuesJSON string, extra map[string]http.HandlerFunc) *httptest.Server {
+	t.Helper()
+
+	routes := map[string]http.HandlerFunc{
+		"/user": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"login": "octocat"}`))
+		},
+		"/repos/" + owner + "/" + repo: func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"private": false}`))
+		},
+		"/repos/" + owner + "/" + repo + "/issues": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(issuesJSON))
+		},
+	}
+	for path, handler := range extra {
+		routes[path] = handler
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if handler, ok := routes[r.URL.Path]; ok {
+			handler(w, r)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+
+	oldURL := githubAPIURL
+	githubAPIURL = server.URL
+	t.Cleanup(func() {
+		githubAPIURL = oldURL
+		server.Close()
+	})
+
+	return server
+}
@@ -3,8 +3,8 @@ package internal
 import (
 	"fmt"
 	"os"
-	"os/user"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"gopkg.in/yaml.v2"
@@ -12,14 +12,25 @@ import (
 
 // MultiProjectConfig represents the new multi-project configuration format
 type MultiProjectConfig struct {
-	Global   GlobalConfig    `yaml:"global"`
-	Projects []ProjectConfig `yaml:"projects"`
+	Global    GlobalConfig      `yaml:"global"`
+	Database  DatabaseSettings  `yaml:"database,omitempty"`
+	Export    ExportSettings    `yaml:"export,omitempty"`
+	Push      PushSettings      `yaml:"push,omitempty"`
+	Import    ImportSettings    `yaml:"import,omitempty"`
+	Discovery DiscoverySettings `yaml:"discovery,omitempty"`
+	States    []StateMapping    `yaml:"state_mapping,omitempty"` // Derived display states for open issues
+	Sync      SyncSettings      `yaml:"sync,omitempty"`
+	Audit     AuditSettings     `yaml:"audit,omitempty"`
+	Match     MatchSettings     `yaml:"match,omitempty"`
+	Projects  []ProjectConfig   `yaml:"projects"`
 }
 
 // GlobalConfig contains global settings for all projects
 type GlobalConfig struct {
-	Database string `yaml:"database,omitempty"`
-	Token    string `yaml:"token,omitempty"`
+	Database       string           `yaml:"database,omitempty"`
+	Token          string           `yaml:"token,omitempty"`
+	RedactPatterns []string         `yaml:"redact_patterns,omitempty"` // Extra secrets to hide in errors and logs
+	GitHubApp      *GitHubAppConfig `yaml:"github_app,omitempty"`      // Authenticate as a GitHub App installation when no token is set
 }
 
 // ProjectConfig represents configuration for a single project
@@ -30,14 +41,22 @@ type ProjectConfig struct {
 	Path     string `yaml:"path,omitempty"`     // Local filesystem path
 	Token    string `yaml:"token,omitempty"`    // Project-specific token (overrides global)
 	Database string `yaml:"database,omitempty"` // Project-specific database (rare)
+	Source   string `yaml:"-"`                  // Fragment under config.d/ defining it (empty = main config)
 }
 
 // LoadMultiProjectConfig loads configuration supporting both new multi-project and legacy formats
 func LoadMultiProjectConfig() (*MultiProjectConfig, error) {
-	data, err := os.ReadFile("config.yml")
+	return loadMultiProjectConfig(readConfigFile)
+}
+
+// loadMultiProjectConfig loads the configuration, reading the main config file with read
+func loadMultiProjectConfig(read func(path string) ([]byte, error)) (*MultiProjectConfig, error) {
+	configFile := "config.yml"
+	data, err := read(configFile)
 	if err != nil {
 		// Try config.yaml for backward compatibility
-		data, err = os.ReadFile("config.yaml")
+		configFile = "config.yaml"
+		data, err = read(configFile)
 		if err != nil {
 			return nil, err
 		}
@@ -50,7 +69,15 @@ func LoadMultiProjectConfig() (*MultiProjectConfig, error) {
 			(multiConfig.Global.Database != "" && multiConfig.Global.Token != "") ||
 			(strings.Contains(string(data), "global:") || strings.Contains(string(data), "projects:"))) {
 		// Successfully parsed as multi-project config
+		if err := loadConfigFragments(&multiConfig, configFile); err != nil {
+			return nil, err
+		}
 		setDefaults(&multiConfig)
+		registerConfigSecrets(&multiConfig)
+		applySyncSettings(&multiConfig)
+		if err := configureGitHubApp(multiConfig.Global.GitHubApp); err != nil {
+			return nil, fmt.Errorf("invalid GitHub App configuration: %w", err)
+		}
 		return &multiConfig, nil
 	}
 
@@ -81,9 +108,13 @@ func LoadMultiProjectConfig() (*MultiProjectConfig, error) {
 				// Note: Token and Database will be inherited from Global
 			},
 		},
+		Push:   legacyConfig.Push,
+		Import: legacyConfig.Import,
+		Match:  legacyConfig.Match,
 	}
 
 	setDefaults(converted)
+	registerConfigSecrets(converted)
 	return converted, nil
 }
 
@@ -213,18 +244,44 @@ func parseGitHubURL(url string) (string, string, error) {
 	return parts[0], parts[1], nil
 }
 
-// ResolveDatabasePath resolves a database path, expanding ~ to home directory
+// ResolveDatabasePath resolves a database path, expanding ~ to the home directory
+// and $VAR, ${VAR} and %VAR% environment variables. Paths may use either / or the
+// platform separator. Drive-relative Windows paths such as C:pivot.db are made absolute.
 func ResolveDatabasePath(dbPath string) (string, error) {
-	if !strings.HasPrefix(dbPath, "~") {
-		return dbPath, nil
+	path := expandWindowsEnv(os.ExpandEnv(dbPath))
+
+	if path == "~" || strings.HasPrefix(path, "~/") || strings.HasPrefix(path, "~"+string(filepath.Separator)) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory: %w", err)
+		}
+		return filepath.Join(home, filepath.FromSlash(path[1:])), nil
 	}
 
-	usr, err := user.Current()
-	if err != nil {
-		return "", fmt.Errorf("failed to get current user: %w", err)
+	path = filepath.FromSlash(path)
+	if filepath.VolumeName(path) != "" && !filepath.IsAbs(path) {
+		absolute, err := filepath.Abs(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve drive-relative path: %w", err)
+		}
+		return absolute, nil
 	}
 
-	return filepath.Join(usr.HomeDir, strings.TrimPrefix(dbPath, "~")), nil
+	return path, nil
+}
+
+// windowsEnvVar matches %VAR% references in Windows-style paths
+var windowsEnvVar = regexp.MustCompile(`%([A-Za-z_][A-Za-z0-9_]*)%`)
+
+// expandWindowsEnv replaces %VAR% references with the value of set environment
+// variables, leaving references to unset variables untouched
+func expandWindowsEnv(path string) string {
+	return windowsEnvVar.ReplaceAllStringFunc(path, func(ref string) string {
+		if value, ok := os.LookupEnv(strings.Trim(ref, "%")); ok {
+			return value
+		}
+		return ref
+	})
 }
 
 // ImportConfigFromFile imports configuration from a file
@@ -243,18 +300,56 @@ func ImportConfigFromFile(filePath string) (*MultiProjectConfig, error) {
 	return &config, nil
 }
 
-// SaveMultiProjectConfig saves a multi-project configuration to config.yml
+// SaveMultiProjectConfig saves a multi-project configuration to config.yml. Use
+// UpdateMultiProjectConfig to change a loaded configuration.
 func SaveMultiProjectConfig(config *MultiProjectConfig) error {
-	data, err := yaml.Marshal(config)
+	return saveMultiProjectConfig(config, writeConfigFile)
+}
+
+// saveMultiProjectConfig writes config.yml with write
+func saveMultiProjectConfig(config *MultiProjectConfig, write func(path string, data []byte) error) error {
+	// Projects from config.d/ fragments stay in, and are saved to, their own files
+	saved := *config
+	saved.Projects = mainConfigProjects(config.Projects)
+
+	data, err := yaml.Marshal(&saved)
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
 
-	if err := os.WriteFile("config.yml", data, 0600); err != nil {
+	if err := write("config.yml", data); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
 
-	return nil
+	return saveConfigFragments(config.Projects)
+}
+
+// UpdateMultiProjectConfig loads the configuration, applies update and saves it, holding
+// the config.yml lock throughout so concurrent pivot commands never lose each other's
+// changes. Nothing is saved when update returns an error, which is returned as is.
+func UpdateMultiProjectConfig(update func(config *MultiProjectConfig) error) error {
+	const configFile = "config.yml"
+	unlock, err := lockConfigFile(configFile)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// The lock is held, so config.yml is read directly; config.yaml has its own lock
+	config, err := loadMultiProjectConfig(func(path string) ([]byte, error) {
+		if path == configFile {
+			return os.ReadFile(path) // #nosec G304 - Fixed config file name
+		}
+		return readConfigFile(path)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if err := update(config); err != nil {
+		return err
+	}
+	return saveMultiProjectConfig(config, replaceConfigFile)
 }
 
 // GetEffectiveToken returns the effective token for a project (project-specific or global)
@@ -262,7 +357,16 @@ func (p *ProjectConfig) GetEffectiveToken(global *GlobalConfig) string {
 	if p.Token != "" {
 		return p.Token
 	}
-	return global.Token
+	return global.EffectiveToken()
+}
+
+// EffectiveToken returns the global token, or GitHubAppToken when no token is set
+// and a GitHub App is configured
+func (g *GlobalConfig) EffectiveToken() string {
+	if g.Token == "" && g.GitHubApp != nil {
+		return GitHubAppToken
+	}
+	return g.Token
 }
 
 // GetEffectiveDatabase returns the effective database path for a project
@@ -4,8 +4,12 @@ import (
 	"bufio"
 	"database/sql"
 	"fmt"
+	"io"
 	"os"
+	"sort"
 	"strings"
+
+	"gopkg.in/yaml.v2"
 )
 
 // InitMultiProjectConfig creates a new multi-project config with interactive prompts
@@ -184,110 +188,510 @@ func InitMultiProjectDatabase() error {
 	return nil
 }
 
+// SyncOptions controls optional behaviour of a multi-project sync
+type SyncOptions struct {
+	Checkpoint       bool         // Resume from the last synced page and record progress per page
+	FullSync         bool         // Ignore the watermark and fetch every issue
+	ResetWatermark   bool         // Forget the watermark before syncing
+	WithReactions    bool         // Persist reaction counts for each issue
+	Audit            *AuditLog    // Records every sync action (nil = use the audit settings of the config)
+	Assignee         string       // Only fetch issues assigned to this login
+	AssignedToMe     bool         // Only fetch issues assigned to the user the token belongs to
+	ChecksumVerify   bool         // Re-read stored issues after syncing and check their sync hashes
+	CompareOnly      bool         // Record local-vs-remote differences without changing the database
+	Select           *IssueFilter // Persist only fetched issues matching this filter (nil = all)
+	Explain          io.Writer    // Receives one JSON SyncDecision per issue (nil = off)
+	Token            string       // Used instead of the configured project and global tokens for this run; never saved
+	ForceOverwrite   bool         // Store fetched issues even when their updated_at is older than the stored copy
+	WaitForRateLimit bool         // Pause until the rate limit resets when the budget is too low for the sync
+	StoreRaw         bool         // Persist the GitHub JSON of each stored issue
+	RawCompression   string       // gzip (default) or none for StoreRaw (empty = sync.raw_compression of the config)
+	ResumeFrom       int          // Process fetched issues in number order starting at this number (0 = all)
+	OnlyNew          bool         // Store only issues not yet in the database; leave stored issues untouched
+	DryRun           bool         // Work out what the sync would change without changing the database
+	IncludeTimeline  bool         // Fetch and store the closed, reopened and labeled events of each stored issue
+	ForceConflictAs  string       // Resolve every conflict of this run toward ResolutionLocal or ResolutionRemote (empty = mark them CONFLICTED)
+
+	// ConfirmRepositoryMove is asked whether to record a renamed or transferred
+	// repository in config.yml and the database (nil = only warn)
+	ConfirmRepositoryMove func(project *ProjectConfig, newOwner, newRepo string) bool
+}
+
 // SyncMultiProject syncs all projects or a specific project
-func SyncMultiProject(projectFilter string) error {
+func SyncMultiProject(projectFilter string) (*SyncResult, error) {
+	return SyncMultiProjectWithOptions(projectFilter, SyncOptions{})
+}
+
+// SyncMultiProjectWithOptions syncs all projects or a specific project using the given
+// options. A project that fails to sync is recorded in the result and the others continue.
+func SyncMultiProjectWithOptions(projectFilter string, opts SyncOptions) (*SyncResult, error) {
 	// Load configuration
 	config, err := LoadMultiProjectConfig()
 	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
+		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
 
 	// Validate configuration has projects
 	if len(config.Projects) == 0 {
-		return fmt.Errorf("no projects configured in multi-project configuration")
+		return nil, fmt.Errorf("no projects configured in multi-project configuration")
 	}
 
-	// Open central database
-	dbPath, err := ResolveDatabasePath(config.Global.Database)
+	if opts.RawCompression == "" {
+		opts.RawCompression = config.Sync.RawCompression
+	}
+	if _, err := ParseRawCompression(opts.RawCompression); err != nil {
+		return nil, err
+	}
+
+	projectsToSync, err := selectSyncProjects(config, projectFilter)
 	if err != nil {
-		return fmt.Errorf("failed to resolve database path: %w", err)
+		return nil, err
+	}
+	// Without a token every request would fail with an opaque 401
+	if err := CheckProjectTokens(&config.Global, projectsToSync, opts.Token); err != nil {
+		return nil, err
 	}
 
-	db, err := sql.Open("sqlite3", dbPath)
+	// Open central database, applying any pending schema upgrades
+	db, err := InitMultiProjectDBWithSettings(config.Global.Database, config.Database)
 	if err != nil {
-		return fmt.Errorf("failed to open database: %w", err)
+		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 	defer db.Close()
 
-	// Determine which projects to sync
-	var projectsToSync []ProjectConfig
-	if projectFilter != "" {
-		// Parse project filter (owner/repo format)
-		parts := strings.Split(projectFilter, "/")
-		if len(parts) != 2 {
-			return fmt.Errorf("project filter must be in format 'owner/repo', got: %s", projectFilter)
-		}
+	RegisterSecret(opts.Token)
 
-		// Find the specific project
-		found := false
-		for _, project := range config.Projects {
-			if project.Owner == parts[0] && project.Repo == parts[1] {
-				projectsToSync = append(projectsToSync, project)
-				found = true
-				break
-			}
+	if opts.Audit == nil {
+		if opts.Audit, err = NewAuditLog(config.Audit); err != nil {
+			return nil, err
 		}
-
-		if !found {
-			return fmt.Errorf("project %s not found in configuration", projectFilter)
-		}
-	} else {
-		// Sync all projects
-		projectsToSync = config.Projects
 	}
 
+	checkRateLimitBudget(db, &config.Global, projectsToSync, opts)
+
 	// Sync each project
+	result := &SyncResult{}
 	for _, project := range projectsToSync {
 		fmt.Printf("🔄 Syncing %s/%s...\n", project.Owner, project.Repo)
 
-		if err := syncProject(db, &config.Global, &project); err != nil {
+		projectResult, err := syncProjectWithOptions(db, &config.Global, &project, opts)
+		if err != nil {
+			err = ScrubError(err)
+			projectResult.Errors = append(projectResult.Errors, err.Error())
+			result.Projects = append(result.Projects, *projectResult)
 			fmt.Printf("❌ Failed to sync %s/%s: %v\n", project.Owner, project.Repo, err)
 			continue
 		}
 
+		result.Projects = append(result.Projects, *projectResult)
 		fmt.Printf("✓ Synced %s/%s\n", project.Owner, project.Repo)
 	}
 
-	return nil
+	return result, nil
+}
+
+// selectSyncProjects returns the configured project named by projectFilter (owner/repo),
+// or all configured projects when the filter is empty
+func selectSyncProjects(config *MultiProjectConfig, projectFilter string) ([]ProjectConfig, error) {
+	if projectFilter == "" {
+		return config.Projects, nil
+	}
+
+	parts := strings.Split(projectFilter, "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("project filter must be in format 'owner/repo', got: %s", projectFilter)
+	}
+	for _, project := range config.Projects {
+		if project.Owner == parts[0] && project.Repo == parts[1] {
+			return []ProjectConfig{project}, nil
+		}
+	}
+	return nil, fmt.Errorf("project %s not found in configuration", projectFilter)
 }
 
 // syncProject syncs a single project
-func syncProject(db *sql.DB, global *GlobalConfig, project *ProjectConfig) error {
-	// Get effective token for this project
+func syncProject(db *sql.DB, global *GlobalConfig, project *ProjectConfig) (*ProjectSyncResult, error) {
+	return syncProjectWithOptions(db, global, project, SyncOptions{})
+}
+
+// syncProjectWithOptions syncs a single project using the given options. The result
+// holds the counts reached so far, also when an error is returned.
+func syncProjectWithOptions(db *sql.DB, global *GlobalConfig, project *ProjectConfig, opts SyncOptions) (*ProjectSyncResult, error) {
+	result := &ProjectSyncResult{Owner: project.Owner, Repo: project.Repo}
+
+	// Get effective token for this project; an override applies to this run only and
+	// is not copied into the project, which is saved to the database below
 	token := project.GetEffectiveToken(global)
+	if opts.Token != "" {
+		token = opts.Token
+	}
 	if token == "" {
-		return fmt.Errorf("no GitHub token configured for project %s/%s", project.Owner, project.Repo)
+		return result, fmt.Errorf("no GitHub token configured for project %s/%s", project.Owner, project.Repo)
 	}
 
 	// Validate GitHub credentials before attempting sync
 	if err := EnsureGitHubCredentials(project.Owner, project.Repo, token); err != nil {
-		return fmt.Errorf("GitHub credential validation failed for %s/%s: %w", project.Owner, project.Repo, err)
+		return result, fmt.Errorf("GitHub credential validation failed for %s/%s: %w", project.Owner, project.Repo, err)
+	}
+
+	if opts.CompareOnly {
+		diffs, err := compareProjectIssues(db, project.Owner, project.Repo, token)
+		if err != nil {
+			return result, err
+		}
+		result.Differences = diffs
+		fmt.Printf("  Found %d differing issues\n", len(diffs))
+		return result, nil
+	}
+
+	if opts.DryRun {
+		diff, err := planProjectSync(db, project.Owner, project.Repo, token, opts)
+		if err != nil {
+			return result, err
+		}
+		result.DryRun = diff
+		fmt.Printf("  Would create %d, update %d and conflict on %d issues\n", diff.Counts.New, diff.Counts.Updated, diff.Counts.Conflicted)
+		return result, nil
+	}
+
+	// Detect renamed or transferred repositories
+	fetchOwner, fetchRepo := project.Owner, project.Repo
+	if newOwner, newRepo, err := ResolveRepositoryRedirect(project.Owner, project.Repo, token); err == nil &&
+		(!strings.EqualFold(newOwner, project.Owner) || !strings.EqualFold(newRepo, project.Repo)) {
+		fmt.Printf("⚠ Repository %s/%s has moved to %s/%s\n", project.Owner, project.Repo, newOwner, newRepo)
+		if offerRepositoryMove(db, project, newOwner, newRepo, opts.ConfirmRepositoryMove) {
+			project.Owner, project.Repo = newOwner, newRepo
+		}
+		fetchOwner, fetchRepo = newOwner, newRepo
 	}
 
 	// Ensure project exists in database
 	projectID, err := CreateProject(db, project)
 	if err != nil {
-		return fmt.Errorf("failed to ensure project in database: %w", err)
+		return result, fmt.Errorf("failed to ensure project in database: %w", err)
+	}
+
+	// Every stored issue gets a sync state, so status reflects a fresh sync
+	if err := InitSyncStateSchema(db); err != nil {
+		return result, err
 	}
 
-	// Fetch issues from GitHub
-	issues, err := FetchIssues(project.Owner, project.Repo, token)
+	if opts.ResetWatermark {
+		if err := ResetSyncWatermark(db, projectID); err != nil {
+			return result, err
+		}
+	}
+
+	// Only fetch issues updated since the last successful sync
+	var query issuesQuery
+	if !opts.FullSync {
+		watermark, err := GetSyncWatermark(db, projectID)
+		if err != nil {
+			return result, err
+		}
+		query.since = watermark
+		if query.since != "" {
+			fmt.Printf("  Fetching issues updated since %s\n", query.since)
+		}
+	}
+	newWatermark := query.since
+
+	query.assignee = opts.Assignee
+	if opts.AssignedToMe {
+		login, err := AuthenticatedLogin(token)
+		if err != nil {
+			return result, fmt.Errorf("failed to resolve authenticated user: %w", err)
+		}
+		query.assignee = login
+	}
+	if query.assignee != "" {
+		fmt.Printf("  Fetching issues assigned to %s\n", query.assignee)
+	}
+
+	startPage := 1
+	if opts.Checkpoint {
+		lastPage, err := GetSyncCheckpoint(db, projectID)
+		if err != nil {
+			return result, err
+		}
+		if lastPage > 0 {
+			startPage = lastPage + 1
+			fmt.Printf("  Resuming from page %d\n", startPage)
+		}
+	}
+
+	// Fetch and save issues from GitHub one page at a time, in page order
+	var saveErr error
+	fetched := 0
+	err = forEachIssuesPage(fetchOwner, fetchRepo, token, startPage, query, func(page int, issues []Issue, hasNext bool) error {
+		fetched += len(issues)
+		newWatermark = latestUpdatedAt(newWatermark, issues)
+
+		saved, err := saveSyncedIssues(db, projectID, issues, opts, result)
+		if err != nil {
+			saveErr = err
+			return err
+		}
+
+		// Timelines are fetched per page, so a checkpointed sync resumes them too
+		if opts.IncludeTimeline {
+			if err := syncIssueTimelines(db, projectID, fetchOwner, fetchRepo, token, saved, result); err != nil {
+				saveErr = err
+				return err
+			}
+		}
+
+		if hasNext && opts.Checkpoint {
+			if err := SaveSyncCheckpoint(db, projectID, page); err != nil {
+				saveErr = err
+				return err
+			}
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("failed to fetch issues from GitHub: %w", err)
+		if saveErr != nil {
+			return result, saveErr
+		}
+		return result, fmt.Errorf("failed to fetch issues from GitHub: %w", err)
+	}
+
+	if opts.Checkpoint {
+		if err := ClearSyncCheckpoint(db, projectID); err != nil {
+			return result, err
+		}
+	}
+
+	// Advance the watermark only once every page has been saved. A sync limited
+	// to one assignee or a --select filter, resumed past some issues or leaving
+	// stored issues untouched skips issues, so it must not move the watermark. An
+	// empty repository has no watermark yet, but saving it still records when the
+	// project was last synced.
+	if query.assignee == "" && opts.Select == nil && opts.ResumeFrom == 0 && !opts.OnlyNew {
+		if err := SaveSyncWatermark(db, projectID, newWatermark); err != nil {
+			return result, err
+		}
 	}
 
-	// Save issues to database
+	switch {
+	case fetched > 0:
+		fmt.Printf("  Saved %d issues\n", result.Created+result.Updated)
+	case query.since != "":
+		result.NoIssues = true
+		fmt.Printf("  No issues found updated since %s\n", query.since)
+	default:
+		result.NoIssues = true
+		fmt.Printf("  No issues found in %s/%s\n", fetchOwner, fetchRepo)
+	}
+	if opts.ResumeFrom > 0 {
+		fmt.Printf("  Skipped %d issues numbered below #%d; the watermark was not advanced\n", result.Resumed, opts.ResumeFrom)
+	}
+	if opts.Select != nil {
+		fmt.Printf("  Skipped %d issues not matching --select %q; the watermark was not advanced\n", result.Skipped, opts.Select.String())
+	}
+	if opts.OnlyNew {
+		fmt.Printf("  Left %d already stored issues untouched (--only-new); the watermark was not advanced\n", result.Existing)
+	}
+	if opts.IncludeTimeline {
+		fmt.Printf("  Stored %d timeline events\n", result.TimelineEvents)
+	}
+
+	if opts.ChecksumVerify {
+		report, err := VerifySyncHashes(db, projectID)
+		if err != nil {
+			return result, err
+		}
+		for _, mismatch := range report.Mismatches {
+			fmt.Printf("  ⚠ Issue #%d failed checksum verification (stored %.12s, computed %.12s)\n",
+				mismatch.Number, mismatch.StoredHash, mismatch.ComputedHash)
+			result.ChecksumMismatches = append(result.ChecksumMismatches, mismatch.Number)
+		}
+		fmt.Printf("  Verified %d issues, %d mismatches\n", report.Checked, len(report.Mismatches))
+	}
+
+	return result, nil
+}
+
+// saveSyncedIssues stores a page of fetched issues, counting them in result, and returns
+// the numbers of the issues it stored
+func saveSyncedIssues(db *sql.DB, projectID int64, issues []Issue, opts SyncOptions, result *ProjectSyncResult) ([]int, error) {
+	if opts.ResumeFrom > 0 {
+		sorted := make([]Issue, len(issues))
+		copy(sorted, issues)
+		sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Number < sorted[j].Number })
+		issues = sorted
+	}
+
+	var saved []int
 	for _, issue := range issues {
 		dbIssue := ConvertIssueToDBIssue(&issue)
+
+		// --resume-from skips ahead past issues that failed before
+		if issue.Number < opts.ResumeFrom {
+			result.Resumed++
+			if err := explainSyncDecision(opts.Explain, db, projectID, result, dbIssue, issue.Number, SyncDecisionResume, opts); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		// --select is a local projection: every page is fetched, only matches are stored
+		if opts.Select != nil && !opts.Select.Matches(dbIssue) {
+			result.Skipped++
+			if err := explainSyncDecision(opts.Explain, db, projectID, result, dbIssue, issue.Number, SyncDecisionSkipped, opts); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		oldState, exists, err := storedIssueState(db, projectID, dbIssue.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		// --only-new treats the database as append-only: stored issues are never touched
+		if exists && opts.OnlyNew {
+			result.Existing++
+			if err := explainSyncDecision(opts.Explain, db, projectID, result, dbIssue, issue.Number, SyncDecisionExisting, opts); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		// An older updated_at than stored means stale data from GitHub, not a change
+		if exists && !opts.ForceOverwrite {
+			stored, err := storedUpdatedAt(db, projectID, dbIssue.ID)
+			if err != nil {
+				return nil, err
+			}
+			if isUpdatedAtRegression(stored, dbIssue.UpdatedAt) {
+				fmt.Printf("⚠ Issue #%d: GitHub returned updated_at %s, older than the stored %s; keeping the stored copy (use --force-overwrite to replace it)\n",
+					issue.Number, dbIssue.UpdatedAt, stored)
+				result.Regressions = append(result.Regressions, issue.Number)
+				if err := explainSyncDecision(opts.Explain, db, projectID, result, dbIssue, issue.Number, SyncDecisionRegression, opts); err != nil {
+					return nil, err
+				}
+				if err := recordSyncAction(opts.Audit, result, issue.Number, AuditActionRegression, oldState, dbIssue.State); err != nil {
+					return nil, err
+				}
+				continue
+			}
+		}
+
+		// Keep local edits when GitHub changed the same issue
+		conflict, err := CheckSyncConflict(db, projectID, dbIssue)
+		if err != nil {
+			return nil, err
+		}
+		if conflict && opts.ForceConflictAs != "" {
+			if err := forceConflictResolution(db, projectID, dbIssue, opts.ForceConflictAs); err != nil {
+				return nil, err
+			}
+			result.ForcedResolutions++
+			if opts.ForceConflictAs == ResolutionRemote {
+				fmt.Printf("⚠ Issue #%d changed both locally and on GitHub; taking the GitHub copy (--force-conflict-as remote)\n", issue.Number)
+				conflict = false
+			} else {
+				fmt.Printf("⚠ Issue #%d changed both locally and on GitHub; keeping local copy (--force-conflict-as local)\n", issue.Number)
+				if err := explainSyncDecision(opts.Explain, db, projectID, result, dbIssue, issue.Number, SyncDecisionConflict, opts); err != nil {
+					return nil, err
+				}
+				if err := recordSyncAction(opts.Audit, result, issue.Number, AuditActionConflict, oldState, dbIssue.State); err != nil {
+					return nil, err
+				}
+				continue
+			}
+		}
+		if conflict {
+			fmt.Printf("⚠ Issue #%d changed both locally and on GitHub; keeping local copy\n", issue.Number)
+			result.Conflicted++
+			if err := recordFetchedSyncState(db, projectID, dbIssue, true); err != nil {
+				return nil, err
+			}
+			if err := explainSyncDecision(opts.Explain, db, projectID, result, dbIssue, issue.Number, SyncDecisionConflict, opts); err != nil {
+				return nil, err
+			}
+			if err := recordSyncAction(opts.Audit, result, issue.Number, AuditActionConflict, oldState, dbIssue.State); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		decision := SyncDecisionCreated
+		if exists {
+			decision = SyncDecisionUpdated
+		}
+		if err := explainSyncDecision(opts.Explain, db, projectID, result, dbIssue, issue.Number, decision, opts); err != nil {
+			return nil, err
+		}
+
 		if err := SaveIssue(db, projectID, dbIssue); err != nil {
-			return fmt.Errorf("failed to save issue %d: %w", issue.ID, err)
+			return nil, fmt.Errorf("failed to save issue %d: %w", issue.ID, err)
+		}
+		if err := recordFetchedSyncState(db, projectID, dbIssue, false); err != nil {
+			return nil, err
+		}
+
+		if opts.WithReactions && issue.Reactions != nil {
+			if err := SaveReactions(db, projectID, issue.Number, issue.Reactions); err != nil {
+				return nil, fmt.Errorf("failed to save reactions for issue %d: %w", issue.Number, err)
+			}
+		}
+
+		if opts.StoreRaw && len(issue.Raw) > 0 {
+			compress, err := ParseRawCompression(opts.RawCompression)
+			if err != nil {
+				return nil, err
+			}
+			if err := SaveRawIssue(db, projectID, issue.Number, issue.Raw, compress); err != nil {
+				return nil, fmt.Errorf("failed to save raw JSON for issue %d: %w", issue.Number, err)
+			}
+		}
+
+		saved = append(saved, issue.Number)
+		action := AuditActionCreated
+		if exists {
+			action = AuditActionUpdated
+			result.Updated++
+		} else {
+			result.Created++
+		}
+		if err := recordSyncAction(opts.Audit, result, issue.Number, action, oldState, dbIssue.State); err != nil {
+			return nil, err
 		}
 	}
+	return saved, nil
+}
 
-	fmt.Printf("  Saved %d issues\n", len(issues))
+// forceConflictResolution resolves a conflict detected by a sync run with
+// --force-conflict-as the way 'pivot resolve' would: toward local the local copy stays
+// queued as a local modification, toward remote the local edit is discarded so the
+// fetched copy is stored
+func forceConflictResolution(db *sql.DB, projectID int64, issue *DBIssue, resolution string) error {
+	localID, err := storedIssueID(db, projectID, issue)
+	if err != nil {
+		return err
+	}
+	if err := recordFetchedSyncState(db, projectID, issue, true); err != nil {
+		return err
+	}
+	if err := ResolveConflict(db, localID, resolution); err != nil {
+		return fmt.Errorf("failed to resolve conflict of issue #%d: %w", issue.Number, err)
+	}
 	return nil
 }
 
+// recordSyncAction writes a sync action of the result's project to the audit log
+func recordSyncAction(audit *AuditLog, result *ProjectSyncResult, number int, action, oldState, newState string) error {
+	return audit.Record(AuditEntry{
+		Project:  result.Owner + "/" + result.Repo,
+		Issue:    number,
+		Action:   action,
+		OldState: oldState,
+		NewState: newState,
+	})
+}
+
 // ShowMultiProjectConfig displays the current multi-project configuration
 func ShowMultiProjectConfig() error {
 	config, err := LoadMultiProjectConfig()
@@ -387,29 +791,257 @@ func AddProject() error {
 		project.Token = strings.TrimSpace(projectToken)
 	}
 
-	// Check if project already exists
-	for _, existing := range config.Projects {
-		if existing.Owner == project.Owner && existing.Repo == project.Repo {
-			return fmt.Errorf("project %s/%s already exists in configuration", project.Owner, project.Repo)
+	// Add to the configuration as it is now; another command may have changed it
+	// while the prompts were answered
+	err = UpdateMultiProjectConfig(func(config *MultiProjectConfig) error {
+		for _, existing := range config.Projects {
+			if existing.Owner == project.Owner && existing.Repo == project.Repo {
+				return fmt.Errorf("project %s/%s already exists in configuration", project.Owner, project.Repo)
+			}
 		}
+		config.Projects = append(config.Projects, project)
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	// Add to configuration
-	config.Projects = append(config.Projects, project)
+	fmt.Printf("✓ Added project %s/%s to configuration\n", project.Owner, project.Repo)
+	fmt.Println("Run 'pivot sync' to sync this project's issues.")
 
-	// Save configuration
-	if err := SaveMultiProjectConfig(config); err != nil {
-		return fmt.Errorf("failed to save configuration: %w", err)
+	return nil
+}
+
+// ConfigImportOptions controls how an imported configuration is merged into the current one
+type ConfigImportOptions struct {
+	// PreserveLocal keeps the current global and project values; imported values only
+	// fill settings the current configuration leaves empty. Without it, imported values win.
+	PreserveLocal bool
+}
+
+// merge merges imported into current according to the options
+func (o ConfigImportOptions) merge(current, imported *MultiProjectConfig) *MultiProjectConfig {
+	if o.PreserveLocal {
+		return MergeConfigsPreservingLocal(current, imported)
 	}
+	return MergeConfigs(current, imported)
+}
 
-	fmt.Printf("✓ Added project %s/%s to configuration\n", project.Owner, project.Repo)
-	fmt.Println("Run 'pivot sync' to sync this project's issues.")
+// MergeConfigs merges an imported configuration into the current one. Imported global
+// settings take precedence and imported projects replace projects with the same owner/repo.
+func MergeConfigs(current, imported *MultiProjectConfig) *MultiProjectConfig {
+	merged := *current
+	merged.Projects = append([]ProjectConfig(nil), current.Projects...)
 
+	if imported.Global.Token != "" {
+		merged.Global.Token = imported.Global.Token
+	}
+	if imported.Global.Database != "" {
+		merged.Global.Database = imported.Global.Database
+	}
+
+	// Merge projects (avoid duplicates)
+	for _, importedProject := range imported.Projects {
+		found := false
+		for i, currentProject := range merged.Projects {
+			if currentProject.Owner == importedProject.Owner && currentProject.Repo == importedProject.Repo {
+				// Update existing project
+				merged.Projects[i] = importedProject
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged.Projects = append(merged.Projects, importedProject)
+		}
+	}
+
+	return &merged
+}
+
+// MergeConfigsPreservingLocal merges an imported configuration into the current one,
+// keeping every global and project value already set. Imported values only fill empty
+// settings, and imported projects not configured yet are appended.
+func MergeConfigsPreservingLocal(current, imported *MultiProjectConfig) *MultiProjectConfig {
+	merged := *current
+	merged.Projects = append([]ProjectConfig(nil), current.Projects...)
+
+	if merged.Global.Token == "" {
+		merged.Global.Token = imported.Global.Token
+	}
+	if merged.Global.Database == "" {
+		merged.Global.Database = imported.Global.Database
+	}
+
+	for _, importedProject := range imported.Projects {
+		found := false
+		for i := range merged.Projects {
+			project := &merged.Projects[i]
+			if project.Owner != importedProject.Owner || project.Repo != importedProject.Repo {
+				continue
+			}
+			if project.Path == "" {
+				project.Path = importedProject.Path
+			}
+			if project.Token == "" {
+				project.Token = importedProject.Token
+			}
+			if project.Database == "" {
+				project.Database = importedProject.Database
+			}
+			found = true
+			break
+		}
+		if !found {
+			merged.Projects = append(merged.Projects, importedProject)
+		}
+	}
+
+	return &merged
+}
+
+// PreviewConfigImport returns the configuration an import would produce, merged with
+// config.yml when it exists, together with a line diff against the current
+// configuration. Tokens and the notify webhook URL are masked in the diff.
+func PreviewConfigImport(filePath string, opts ConfigImportOptions) (*MultiProjectConfig, string, error) {
+	imported, err := ImportConfigFromFile(filePath)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to import config: %w", err)
+	}
+
+	var currentYAML []byte
+	var reference *MultiProjectConfig
+	result := imported
+	if _, err := os.Stat("config.yml"); err == nil {
+		current, err := LoadMultiProjectConfig()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to load current config: %w", err)
+		}
+		if currentYAML, err = yaml.Marshal(maskConfigSecrets(current, nil)); err != nil {
+			return nil, "", fmt.Errorf("failed to marshal current config: %w", err)
+		}
+		result = opts.merge(current, imported)
+		reference = current
+	}
+
+	resultYAML, err := yaml.Marshal(maskConfigSecrets(result, reference))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to marshal merged config: %w", err)
+	}
+
+	return result, diffLines(string(currentYAML), string(resultYAML)), nil
+}
+
+// maskConfigSecrets returns a copy of config with its tokens and notify webhook URL
+// masked, so previews never print them. A secret that differs from the one in
+// reference (nil = none) is marked as changed.
+func maskConfigSecrets(config, reference *MultiProjectConfig) *MultiProjectConfig {
+	if reference == nil {
+		reference = &MultiProjectConfig{}
+	}
+	mask := func(value, previous string) string {
+		switch {
+		case value == "":
+			return ""
+		case previous != "" && previous != value:
+			return scrubReplacement + " (changed)"
+		default:
+			return scrubReplacement
+		}
+	}
+
+	masked := *config
+	masked.Global.Token = mask(config.Global.Token, reference.Global.Token)
+	masked.Sync.Notify.Webhook = mask(config.Sync.Notify.Webhook, reference.Sync.Notify.Webhook)
+	masked.Projects = make([]ProjectConfig, len(config.Projects))
+	for i, project := range config.Projects {
+		previous := ""
+		for _, referenced := range reference.Projects {
+			if referenced.Owner == project.Owner && referenced.Repo == project.Repo {
+				previous = referenced.Token
+			}
+		}
+		project.Token = mask(project.Token, previous)
+		masked.Projects[i] = project
+	}
+	return &masked
+}
+
+// ImportConfigFileDryRun prints the configuration an import would produce without writing it
+func ImportConfigFileDryRun(filePath string, opts ConfigImportOptions) error {
+	fmt.Printf("📥 Previewing import of configuration from: %s (dry run)\n", filePath)
+
+	result, diff, err := PreviewConfigImport(filePath, opts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(diff)
+	fmt.Printf("Dry run: configuration would contain %d projects; config.yml was not modified\n", len(result.Projects))
 	return nil
 }
 
-// ImportConfigFile imports a configuration from a file
+// diffLines renders a simple line-based diff, prefixing removed lines with "-",
+// added lines with "+" and unchanged lines with a space
+func diffLines(before, after string) string {
+	a := splitLines(before)
+	b := splitLines(after)
+
+	// Longest common subsequence table
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var out strings.Builder
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			out.WriteString("  " + a[i] + "\n")
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] >= lcs[i+1][j]):
+			out.WriteString("+ " + b[j] + "\n")
+			j++
+		default:
+			out.WriteString("- " + a[i] + "\n")
+			i++
+		}
+	}
+
+	return strings.TrimSuffix(out.String(), "\n")
+}
+
+// splitLines splits text into lines, ignoring a trailing newline
+func splitLines(text string) []string {
+	text = strings.TrimSuffix(text, "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+// ImportConfigFile imports a configuration from a file, asking before merging it with
+// an existing config.yml; imported values win in the merge
 func ImportConfigFile(filePath string) error {
+	return ImportConfigFileWithOptions(filePath, ConfigImportOptions{})
+}
+
+// ImportConfigFileWithOptions imports a configuration from a file. With PreserveLocal,
+// an existing config.yml is always merged, without asking, and its values win.
+func ImportConfigFileWithOptions(filePath string, opts ConfigImportOptions) error {
 	fmt.Printf("📥 Importing configuration from: %s\n", filePath)
 
 	imported, err := ImportConfigFromFile(filePath)
@@ -419,7 +1051,10 @@ func ImportConfigFile(filePath string) error {
 
 	// Check if current config exists
 	var merge bool
-	if _, err := os.Stat("config.yml"); err == nil {
+	if _, err := os.Stat("config.yml"); err == nil && opts.PreserveLocal {
+		fmt.Println("Merging into the current config.yml; current values are kept (--preserve-local)")
+		merge = true
+	} else if err == nil {
 		fmt.Print("Current config.yml exists. Merge with imported config? (y/N): ")
 		reader := bufio.NewReader(os.Stdin)
 		response, _ := reader.ReadString('\n')
@@ -428,41 +1063,16 @@ func ImportConfigFile(filePath string) error {
 	}
 
 	if merge {
-		// Load current config and merge
-		current, err := LoadMultiProjectConfig()
+		// Merge into the current config under its lock
+		err = UpdateMultiProjectConfig(func(current *MultiProjectConfig) error {
+			*current = *opts.merge(current, imported)
+			imported = current
+			return nil
+		})
 		if err != nil {
-			return fmt.Errorf("failed to load current config: %w", err)
-		}
-
-		// Merge global settings (imported takes precedence)
-		if imported.Global.Token != "" {
-			current.Global.Token = imported.Global.Token
-		}
-		if imported.Global.Database != "" {
-			current.Global.Database = imported.Global.Database
+			return fmt.Errorf("failed to merge imported config: %w", err)
 		}
-
-		// Merge projects (avoid duplicates)
-		for _, importedProject := range imported.Projects {
-			found := false
-			for i, currentProject := range current.Projects {
-				if currentProject.Owner == importedProject.Owner && currentProject.Repo == importedProject.Repo {
-					// Update existing project
-					current.Projects[i] = importedProject
-					found = true
-					break
-				}
-			}
-			if !found {
-				current.Projects = append(current.Projects, importedProject)
-			}
-		}
-
-		imported = current
-	}
-
-	// Save the final configuration
-	if err := SaveMultiProjectConfig(imported); err != nil {
+	} else if err := SaveMultiProjectConfig(imported); err != nil {
 		return fmt.Errorf("failed to save imported config: %w", err)
 	}
 
@@ -2,10 +2,13 @@ package internal
 
 import (
 	"database/sql"
+	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 
 	_ "github.com/mattn/go-sqlite3"
@@ -319,7 +322,7 @@ projects: []`
 		t.Fatalf("Failed to create empty config: %v", err)
 	}
 
-	err = SyncMultiProject("")
+	_, err = SyncMultiProject("")
 	if err == nil {
 		t.Error("Expected error when no projects configured")
 	}
@@ -334,7 +337,7 @@ projects: []`
 	}
 
 	// Test with invalid project filter
-	err = SyncMultiProject("invalid")
+	_, err = SyncMultiProject("invalid")
 	if err == nil {
 		t.Error("Expected error for invalid project filter")
 	}
@@ -343,7 +346,7 @@ projects: []`
 	}
 
 	// Test with project filter that doesn't exist
-	err = SyncMultiProject("nonexistent/repo")
+	_, err = SyncMultiProject("nonexistent/repo")
 	if err == nil {
 		t.Error("Expected error for non-existent project")
 	}
@@ -751,3 +754,92 @@ func TestAddProjectEdgeCases(t *testing.T) {
 		t.Errorf("Expected config load error, got: %v", err)
 	}
 }
+
+// recordAuthorization serves issuesJSON and records the Authorization header of each request
+func recordAuthorization(headers *[]string, issuesJSON string) http.HandlerFunc {
+	var mu sync.Mutex
+	return func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		*headers = append(*headers, r.Header.Get("Authorization"))
+		mu.Unlock()
+		_, _ = w.Write([]byte(issuesJSON))
+	}
+}
+
+func TestSyncTokenOverride_UsedInsteadOfConfiguredToken(t *testing.T) {
+	var headers []string
+	newMockGitHubServer(t, "owner", "repo", "", map[string]http.HandlerFunc{
+		"/repos/owner/repo/issues": recordAuthorization(&headers, `[{"id": 1, "number": 1, "title": "Issue", "state": "open"}]`),
+	})
+	db := newTestMultiProjectDB(t)
+
+	global := &GlobalConfig{Token: "configured-global-token"}
+	project := &ProjectConfig{Owner: "owner", Repo: "repo", Token: "configured-project-token"}
+	result, err := syncProjectWithOptions(db, global, project, SyncOptions{Token: "override-token-for-run"})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if result.Created != 1 {
+		t.Errorf("Expected 1 issue created, got %d", result.Created)
+	}
+
+	if len(headers) == 0 {
+		t.Fatal("Expected the issues endpoint to be called")
+	}
+	for _, header := range headers {
+		if header != "token override-token-for-run" {
+			t.Errorf("Expected the override token in the API call, got '%s'", header)
+		}
+	}
+
+	// The override is not saved with the project
+	if project.Token != "configured-project-token" {
+		t.Errorf("Expected the configured project token to be unchanged, got '%s'", project.Token)
+	}
+	stored, err := FindProjectByOwnerRepo(db, "owner", "repo")
+	if err != nil {
+		t.Fatalf("Failed to find project: %v", err)
+	}
+	if stored.Token == "override-token-for-run" {
+		t.Error("Expected the override token not to be stored in the database")
+	}
+}
+
+func TestSyncTokenOverride_ScrubbedAndNotPersisted(t *testing.T) {
+	var headers []string
+	newMockGitHubServer(t, "owner", "repo", "", map[string]http.HandlerFunc{
+		"/repos/owner/repo/issues": recordAuthorization(&headers, `[]`),
+	})
+	chdirTemp(t)
+	configContent := `global:
+  database: ./pivot.db
+  token: configured-global-token
+projects:
+  - owner: owner
+    repo: repo
+`
+	if err := os.WriteFile("config.yml", []byte(configContent), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	// Not shaped like a GitHub token, so only registering it gets it scrubbed
+	override := "teammate-debug-token-1445"
+	if _, err := SyncMultiProjectWithOptions("owner/repo", SyncOptions{Token: override}); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if len(headers) == 0 || headers[0] != "token "+override {
+		t.Errorf("Expected the override token in the API call, got %v", headers)
+	}
+
+	if scrubbed := ScrubError(fmt.Errorf("request with %s failed", override)).Error(); strings.Contains(scrubbed, override) {
+		t.Errorf("Expected the override token to be scrubbed, got '%s'", scrubbed)
+	}
+
+	data, err := os.ReadFile("config.yml")
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	if strings.Contains(string(data), override) {
+		t.Error("Expected the override token not to be written to config.yml")
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	_ "github.com/mattn/go-sqlite3"
 )
@@ -21,6 +23,20 @@ type DBIssue struct {
 	CreatedAt string `json:"created_at"`
 	UpdatedAt string `json:"updated_at"`
 	ClosedAt  string `json:"closed_at"`
+	Milestone string `json:"milestone,omitempty"` // Milestone number or title
+	Type      string `json:"type,omitempty"`      // GitHub issue type, empty when the repository has none
+
+	// Agile planning fields, set locally or parsed from body metadata
+	StoryPoints        int    `json:"story_points,omitempty"`
+	EstimatedHours     int    `json:"estimated_hours,omitempty"`
+	Epic               string `json:"epic,omitempty"`
+	AcceptanceCriteria string `json:"acceptance_criteria,omitempty"`
+	Dependencies       string `json:"dependencies,omitempty"` // Comma-separated numbers of the issues this one depends on
+
+	// Label and assignee objects as received from GitHub, set only by
+	// ConvertIssueToDBIssueWithOptions with Structured; never stored in the issues table
+	LabelObjects    []IssueLabel `json:"-"`
+	AssigneeObjects []IssueUser  `json:"-"`
 }
 
 // InitMultiProjectDB initializes the multi-project database schema
@@ -87,6 +103,38 @@ func InitMultiProjectDB(db *sql.DB) error {
 		}
 	}
 
+	if err := AddSyncColumnsToIssues(db); err != nil {
+		return err
+	}
+
+	if err := AddAgileColumnsToIssues(db); err != nil {
+		return err
+	}
+
+	if err := AddIssueTypeColumn(db); err != nil {
+		return err
+	}
+
+	if err := createReactionsTable(db); err != nil {
+		return err
+	}
+
+	if err := createIssueRawTable(db); err != nil {
+		return err
+	}
+
+	if err := createIssueEventsTable(db); err != nil {
+		return err
+	}
+
+	if err := createSyncCheckpointTable(db); err != nil {
+		return err
+	}
+
+	if err := createSyncWatermarkTable(db); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -138,18 +186,13 @@ func CreateProject(db *sql.DB, project *ProjectConfig) (int64, error) {
 			updated_at = CURRENT_TIMESTAMP
 	`
 
-	result, err := db.Exec(query, project.Owner, project.Repo, project.Path, project.Token, project.Database)
-	if err != nil {
+	if _, err := db.Exec(query, project.Owner, project.Repo, project.Path, project.Token, project.Database); err != nil {
 		return 0, fmt.Errorf("failed to create/update project: %w", err)
 	}
 
-	projectID, err := result.LastInsertId()
-	if err != nil {
-		// If LastInsertId fails (conflict case), try to get the existing ID
-		return getProjectID(db, project.Owner, project.Repo)
-	}
-
-	return projectID, nil
+	// LastInsertId is not updated when the upsert takes the DO UPDATE branch,
+	// so always look the ID up to get the existing row on re-sync
+	return getProjectID(db, project.Owner, project.Repo)
 }
 
 // getProjectID gets the database ID for a project by owner/repo
@@ -243,33 +286,139 @@ func ListProjects(db *sql.DB) ([]ProjectConfig, error) {
 	return projects, nil
 }
 
-// SaveIssue saves an issue to the database for a specific project
+// SaveIssue saves an issue to the database for a specific project. An issue from
+// GitHub replaces the stored issue with the same number (or, failing that, the same
+// GitHub ID) in place; an issue without a GitHub ID or number is local-only and is
+// inserted as a new row.
 func SaveIssue(db *sql.DB, projectID int64, issue *DBIssue) error {
+	_, err := saveIssue(db, projectID, issue)
+	return err
+}
+
+// saveIssue saves an issue like SaveIssue and returns its local ID
+func saveIssue(db *sql.DB, projectID int64, issue *DBIssue) (int64, error) {
+	localID, err := storedIssueID(db, projectID, issue)
+	if err != nil {
+		return 0, err
+	}
+
+	// Replacing a stored issue keeps its rowid, the local ID that sync states and
+	// dependencies refer to. A NULL rowid lets SQLite assign the next free one.
+	var rowID, githubID interface{}
+	if localID > 0 {
+		rowID = localID
+	}
+	if !isLocalOnlyIssue(issue) {
+		githubID = issue.ID
+	}
+
 	query := `
-		INSERT OR REPLACE INTO issues (github_id, project_id, number, title, body, state, labels, assignees, created_at, updated_at, closed_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT OR REPLACE INTO issues (rowid, github_id, project_id, number, title, body, state, labels, assignees, created_at, updated_at, closed_at, sync_hash,
+			milestone, story_points, estimated_hours, epic, acceptance_criteria, issue_type, dependencies)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
-	_, err := db.Exec(query,
-		issue.ID, projectID, issue.Number, issue.Title, issue.Body,
+	res, err := db.Exec(query,
+		rowID, githubID, projectID, issue.Number, issue.Title, issue.Body,
 		issue.State, issue.Labels, issue.Assignees,
-		issue.CreatedAt, issue.UpdatedAt, issue.ClosedAt)
+		issue.CreatedAt, issue.UpdatedAt, issue.ClosedAt, ComputeSyncHash(issue),
+		issue.Milestone, issue.StoryPoints, issue.EstimatedHours, issue.Epic, issue.AcceptanceCriteria, issue.Type, issue.Dependencies)
 
 	if err != nil {
-		return fmt.Errorf("failed to save issue: %w", err)
+		return 0, fmt.Errorf("failed to save issue: %w", err)
+	}
+	if localID > 0 {
+		return localID, nil
 	}
 
-	return nil
+	localID, err = res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get local issue ID: %w", err)
+	}
+	return localID, nil
+}
+
+// isLocalOnlyIssue reports whether an issue has not been created on GitHub yet
+func isLocalOnlyIssue(issue *DBIssue) bool {
+	return issue.ID == 0 && issue.Number == 0
+}
+
+// storedIssueID returns the local ID of the stored copy of a GitHub issue, matched by
+// number and then by GitHub ID, or 0 when it is not stored or the issue is local-only
+func storedIssueID(db *sql.DB, projectID int64, issue *DBIssue) (int64, error) {
+	if isLocalOnlyIssue(issue) {
+		return 0, nil
+	}
+	if issue.Number > 0 {
+		localID, err := LocalIDByNumber(db, projectID, issue.Number)
+		if err != nil || localID > 0 {
+			return localID, err
+		}
+	}
+	if issue.ID == 0 {
+		return 0, nil
+	}
+
+	var localID int64
+	err := db.QueryRow("SELECT rowid FROM issues WHERE project_id = ? AND github_id = ?", projectID, issue.ID).Scan(&localID)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up issue %d: %w", issue.ID, err)
+	}
+	return localID, nil
 }
 
-// GetIssuesForProject retrieves all issues for a specific project
+// SaveSyncedIssue saves an issue that exists on GitHub, such as one just created by an
+// import, marks it SYNCED and returns its local ID
+func SaveSyncedIssue(db *sql.DB, projectID int64, issue *DBIssue) (int64, error) {
+	localID, err := saveIssue(db, projectID, issue)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := InitSyncStateSchema(db); err != nil {
+		return 0, err
+	}
+	githubID := int64(issue.ID)
+	if _, err := db.Exec("DELETE FROM issue_sync_state WHERE issue_local_id = ?", localID); err != nil {
+		return 0, fmt.Errorf("failed to reset sync state: %w", err)
+	}
+	if err := CreateSyncState(db, localID, SyncStateSynced, &githubID); err != nil {
+		return 0, err
+	}
+	return localID, nil
+}
+
+// LocalIDByNumber returns the local ID of a project's issue with the given GitHub number,
+// or 0 when it is not stored
+func LocalIDByNumber(db *sql.DB, projectID int64, number int) (int64, error) {
+	var localID int64
+	err := db.QueryRow("SELECT rowid FROM issues WHERE project_id = ? AND number = ?", projectID, number).Scan(&localID)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up issue #%d: %w", number, err)
+	}
+	return localID, nil
+}
+
+// GetIssuesForProject retrieves all issues for a specific project, ordered by number
 func GetIssuesForProject(db *sql.DB, projectID int64) ([]DBIssue, error) {
+	return GetIssuesForProjectOrdered(db, projectID, DefaultIssueOrder)
+}
+
+// GetIssuesForProjectOrdered retrieves all issues for a specific project in the given order
+func GetIssuesForProjectOrdered(db *sql.DB, projectID int64, order IssueOrder) ([]DBIssue, error) {
 	query := `
-		SELECT github_id, number, title, body, state, labels, assignees, created_at, updated_at, closed_at
+		SELECT COALESCE(github_id, 0), number, title, body, state, labels, assignees, created_at, updated_at, closed_at,
+		       COALESCE(milestone, ''), COALESCE(story_points, 0), COALESCE(estimated_hours, 0),
+		       COALESCE(epic, ''), COALESCE(acceptance_criteria, ''), COALESCE(issue_type, ''), COALESCE(dependencies, '')
 		FROM issues 
 		WHERE project_id = ?
-		ORDER BY number
-	`
+	` + order.orderByClause()
 
 	rows, err := db.Query(query, projectID)
 	if err != nil {
@@ -285,7 +434,9 @@ func GetIssuesForProject(db *sql.DB, projectID int64) ([]DBIssue, error) {
 
 		err := rows.Scan(&issue.ID, &issue.Number, &issue.Title, &issue.Body,
 			&issue.State, &labels, &assignees,
-			&issue.CreatedAt, &issue.UpdatedAt, &closedAt)
+			&issue.CreatedAt, &issue.UpdatedAt, &closedAt,
+			&issue.Milestone, &issue.StoryPoints, &issue.EstimatedHours,
+			&issue.Epic, &issue.AcceptanceCriteria, &issue.Type, &issue.Dependencies)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan issue: %w", err)
 		}
@@ -306,6 +457,31 @@ func GetIssuesForProject(db *sql.DB, projectID int64) ([]DBIssue, error) {
 	return issues, nil
 }
 
+// GetAllIssues retrieves the issues of every project, ordered by project and number
+func GetAllIssues(db *sql.DB) ([]DBIssue, error) {
+	return GetAllIssuesOrdered(db, DefaultIssueOrder)
+}
+
+// GetAllIssuesOrdered retrieves the issues of every project, ordered by project and
+// then in the given order
+func GetAllIssuesOrdered(db *sql.DB, order IssueOrder) ([]DBIssue, error) {
+	projects, err := ListProjects(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []DBIssue
+	for _, project := range projects {
+		projectIssues, err := GetIssuesForProjectOrdered(db, int64(project.ID), order)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get issues for %s/%s: %w", project.Owner, project.Repo, err)
+		}
+		issues = append(issues, projectIssues...)
+	}
+
+	return issues, nil
+}
+
 // MigrateToMultiProject migrates a legacy single-project database to multi-project format
 func MigrateToMultiProject(db *sql.DB, legacyOwner, legacyRepo, legacyPath string) error {
 	// First, initialize the multi-project schema
@@ -352,6 +528,12 @@ func MigrateToMultiProject(db *sql.DB, legacyOwner, legacyRepo, legacyPath strin
 
 // InitMultiProjectDBFromPath initializes a multi-project database at the specified path
 func InitMultiProjectDBFromPath(dbPath string) (*sql.DB, error) {
+	return InitMultiProjectDBWithSettings(dbPath, DatabaseSettings{})
+}
+
+// InitMultiProjectDBWithSettings initializes a multi-project database at the specified path
+// using the given connection settings
+func InitMultiProjectDBWithSettings(dbPath string, settings DatabaseSettings) (*sql.DB, error) {
 	// Resolve database path (expand ~ etc.)
 	resolvedPath, err := ResolveDatabasePath(dbPath)
 	if err != nil {
@@ -363,9 +545,9 @@ func InitMultiProjectDBFromPath(dbPath string) (*sql.DB, error) {
 		return nil, fmt.Errorf("failed to create database directory: %w", err)
 	}
 
-	db, err := sql.Open("sqlite3", resolvedPath)
+	db, err := OpenSQLite(resolvedPath, settings)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open database: %w", err)
+		return nil, err
 	}
 
 	if err := InitMultiProjectDB(db); err != nil {
@@ -376,38 +558,56 @@ func InitMultiProjectDBFromPath(dbPath string) (*sql.DB, error) {
 	return db, nil
 }
 
+// OpenConfiguredDB opens the central multi-project database named in the configuration
+func OpenConfiguredDB() (*sql.DB, error) {
+	config, err := LoadMultiProjectConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	return InitMultiProjectDBWithSettings(config.Global.Database, config.Database)
+}
+
 // ensureDirectoryExists creates the directory for the database file if it doesn't exist
 func ensureDirectoryExists(dbPath string) error {
 	dir := filepath.Dir(dbPath)
-	if dir == "." {
-		// No directory component
+	if dir == "." || dir == filepath.VolumeName(dbPath)+"." {
+		// No directory component (C:pivot.db has the directory "C:." on Windows)
 		return nil
 	}
 
 	return os.MkdirAll(dir, 0755) // #nosec G301 - Standard directory permissions for app data
 }
 
-// ConvertIssueToDBIssue converts a GitHub API issue to database format
+// ConvertOptions controls optional output of ConvertIssueToDBIssueWithOptions
+type ConvertOptions struct {
+	// Structured also keeps the label and assignee objects (IDs, colors and
+	// descriptions) next to the flat comma-separated Labels and Assignees
+	Structured bool
+}
+
+// ConvertIssueToDBIssue converts a GitHub API issue to database format, flattening
+// labels and assignees to sorted comma-separated names
 func ConvertIssueToDBIssue(issue *Issue) *DBIssue {
-	// Convert labels to comma-separated string
-	var labels string
-	for i, l := range issue.Labels {
-		if i > 0 {
-			labels += ","
-		}
-		labels += l.Name
-	}
+	return ConvertIssueToDBIssueWithOptions(issue, ConvertOptions{})
+}
 
-	// Convert assignees to comma-separated string
-	var assignees string
-	for i, a := range issue.Assignees {
-		if i > 0 {
-			assignees += ","
-		}
-		assignees += a.Login
+// ConvertIssueToDBIssueWithOptions converts a GitHub API issue to database format.
+// With opts.Structured the label and assignee objects are copied as well, in the
+// order GitHub returned them, so callers can fill normalized tables without a refetch.
+func ConvertIssueToDBIssueWithOptions(issue *Issue, opts ConvertOptions) *DBIssue {
+	// Convert labels and assignees to sorted comma-separated strings
+	var labelNames, logins []string
+	for _, l := range issue.Labels {
+		labelNames = append(labelNames, l.Name)
 	}
+	for _, a := range issue.Assignees {
+		logins = append(logins, a.Login)
+	}
+	labels := normalizeList(labelNames)
+	assignees := normalizeList(logins)
 
-	return &DBIssue{
+	dbIssue := &DBIssue{
 		ID:        issue.ID,
 		Number:    issue.Number,
 		Title:     issue.Title,
@@ -419,4 +619,61 @@ func ConvertIssueToDBIssue(issue *Issue) *DBIssue {
 		UpdatedAt: issue.UpdatedAt,
 		ClosedAt:  issue.ClosedAt,
 	}
+	if issue.Type != nil {
+		dbIssue.Type = issue.Type.Name
+	}
+	if opts.Structured {
+		dbIssue.LabelObjects = append([]IssueLabel{}, issue.Labels...)
+		dbIssue.AssigneeObjects = append([]IssueUser{}, issue.Assignees...)
+	}
+	ApplyAgileMetadata(dbIssue)
+	return dbIssue
+}
+
+// ToCreateRequest converts a stored issue into a GitHub create-issue request.
+// Comma-separated labels and assignees are split into deduplicated lists, and a numeric
+// milestone is passed through as the milestone number. Milestone titles are
+// left unset since GitHub only accepts milestone numbers on creation.
+func ToCreateRequest(issue *DBIssue) CreateIssueRequest {
+	request := CreateIssueRequest{
+		Title:     issue.Title,
+		Body:      issue.Body,
+		Labels:    DedupeList(splitCommaList(issue.Labels)),
+		Assignees: DedupeList(splitCommaList(issue.Assignees)),
+		Type:      issue.Type,
+	}
+
+	if milestone, err := strconv.Atoi(strings.TrimSpace(issue.Milestone)); err == nil && milestone > 0 {
+		request.Milestone = milestone
+	}
+
+	return request
+}
+
+// splitCommaList splits a comma-separated string, trimming whitespace and dropping empty entries
+func splitCommaList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// DedupeList trims values, drops empty ones and removes case-insensitive duplicates,
+// keeping the first occurrence of each value in its original order
+func DedupeList(values []string) []string {
+	var unique []string
+	seen := make(map[string]bool)
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		key := strings.ToLower(value)
+		if value == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, value)
+	}
+	return unique
 }
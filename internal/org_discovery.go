@@ -0,0 +1,194 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"strings"
+)
+
+// DiscoverySettings controls which repositories are picked up when adding all
+// repositories of an organization
+type DiscoverySettings struct {
+	SkipArchived bool `yaml:"skip_archived,omitempty"` // Leave out archived repositories
+	SkipForks    bool `yaml:"skip_forks,omitempty"`    // Leave out forks
+}
+
+// OrgRepo is a repository as listed by GET /orgs/{org}/repos
+type OrgRepo struct {
+	Name     string `json:"name"`
+	Owner    string `json:"-"`
+	Archived bool   `json:"archived"`
+	Fork     bool   `json:"fork"`
+	Private  bool   `json:"private"`
+}
+
+// OrgDiscovery is the outcome of matching an organization's repositories against the configuration
+type OrgDiscovery struct {
+	Added    []ProjectConfig // Repositories added as projects
+	Existing []ProjectConfig // Repositories that were already configured
+	Skipped  []SkippedRepo   // Repositories left out by the discovery settings
+}
+
+// SkippedRepo is a repository left out of discovery, with the reason
+type SkippedRepo struct {
+	Repo   OrgRepo
+	Reason string
+}
+
+// ListOrgRepos returns every repository of an organization that the token can see
+func ListOrgRepos(org, token string) ([]OrgRepo, error) {
+	var repos []OrgRepo
+	for page := 1; ; page++ {
+		url := fmt.Sprintf("%s/orgs/%s/repos?type=all&per_page=100&page=%d", githubAPIURL, neturl.PathEscape(org), page)
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Authorization", "token "+token)
+		req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+		resp, err := newHTTPClient().Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list repositories of %s: %w", org, err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read repositories of %s: %w", org, err)
+		}
+
+		switch resp.StatusCode {
+		case http.StatusOK:
+		case http.StatusNotFound:
+			return nil, fmt.Errorf("organization %s not found or not accessible with this token", org)
+		default:
+			return nil, fmt.Errorf("GitHub API error (%d): %s", resp.StatusCode, strings.TrimSpace(string(body)))
+		}
+
+		var pageRepos []OrgRepo
+		if err := json.Unmarshal(body, &pageRepos); err != nil {
+			return nil, fmt.Errorf("failed to parse repositories of %s: %w", org, err)
+		}
+		for i := range pageRepos {
+			pageRepos[i].Owner = org
+		}
+		repos = append(repos, pageRepos...)
+
+		if !strings.Contains(resp.Header.Get("Link"), `rel="next"`) {
+			return repos, nil
+		}
+	}
+}
+
+// FilterOrgRepos splits repositories into those to keep and those the settings leave out
+func FilterOrgRepos(repos []OrgRepo, settings DiscoverySettings) ([]OrgRepo, []SkippedRepo) {
+	var kept []OrgRepo
+	var skipped []SkippedRepo
+	for _, repo := range repos {
+		switch {
+		case settings.SkipArchived && repo.Archived:
+			skipped = append(skipped, SkippedRepo{Repo: repo, Reason: "archived"})
+		case settings.SkipForks && repo.Fork:
+			skipped = append(skipped, SkippedRepo{Repo: repo, Reason: "fork"})
+		default:
+			kept = append(kept, repo)
+		}
+	}
+	return kept, skipped
+}
+
+// DiscoverOrgProjects adds every repository of an organization that passes the
+// settings to config.Projects. The configuration is not saved.
+func DiscoverOrgProjects(config *MultiProjectConfig, org, token string, settings DiscoverySettings) (*OrgDiscovery, error) {
+	repos, err := ListOrgRepos(org, token)
+	if err != nil {
+		return nil, err
+	}
+
+	kept, skipped := FilterOrgRepos(repos, settings)
+	discovery := &OrgDiscovery{Skipped: skipped}
+	for _, repo := range kept {
+		project := ProjectConfig{Owner: repo.Owner, Repo: repo.Name}
+		if configuredProject(config, project.Owner, project.Repo) {
+			discovery.Existing = append(discovery.Existing, project)
+			continue
+		}
+		config.Projects = append(config.Projects, project)
+		discovery.Added = append(discovery.Added, project)
+	}
+
+	return discovery, nil
+}
+
+// configuredProject reports whether owner/repo is already a configured project
+func configuredProject(config *MultiProjectConfig, owner, repo string) bool {
+	for _, project := range config.Projects {
+		if strings.EqualFold(project.Owner, owner) && strings.EqualFold(project.Repo, repo) {
+			return true
+		}
+	}
+	return false
+}
+
+// AddOrgProjects adds the repositories of an organization to config.yml. A nil
+// skipArchived or skipForks falls back to the discovery section of the config.
+func AddOrgProjects(org string, skipArchived, skipForks *bool, dryRun bool) error {
+	config, err := LoadMultiProjectConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	token := config.Global.EffectiveToken()
+	if token == "" {
+		return fmt.Errorf("no global token configured; run 'pivot config setup' first")
+	}
+
+	settings := config.Discovery
+	if skipArchived != nil {
+		settings.SkipArchived = *skipArchived
+	}
+	if skipForks != nil {
+		settings.SkipForks = *skipForks
+	}
+
+	discovery, err := DiscoverOrgProjects(config, org, token, settings)
+	if err != nil {
+		return err
+	}
+
+	for _, project := range discovery.Added {
+		fmt.Printf("✓ Added %s/%s\n", project.Owner, project.Repo)
+	}
+	for _, project := range discovery.Existing {
+		fmt.Printf("  Already configured: %s/%s\n", project.Owner, project.Repo)
+	}
+	for _, skipped := range discovery.Skipped {
+		fmt.Printf("⏭  Skipped %s/%s (%s)\n", skipped.Repo.Owner, skipped.Repo.Name, skipped.Reason)
+	}
+
+	fmt.Printf("\n📊 %d added, %d already configured, %d skipped\n",
+		len(discovery.Added), len(discovery.Existing), len(discovery.Skipped))
+	if dryRun {
+		fmt.Println("Dry run: config.yml was not changed")
+		return nil
+	}
+	if len(discovery.Added) == 0 {
+		return nil
+	}
+	// Discovery talked to GitHub without the config lock; add to the config as it is now
+	err = UpdateMultiProjectConfig(func(current *MultiProjectConfig) error {
+		for _, project := range discovery.Added {
+			if !configuredProject(current, project.Owner, project.Repo) {
+				current.Projects = append(current.Projects, project)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println("✓ Configuration saved to config.yml")
+	return nil
+}
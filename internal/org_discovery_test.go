@@ -0,0 +1,200 @@
+package internal
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// orgReposJSON lists an active repository, an archived one, a fork and an archived fork
+const orgReposJSON = `[
+	{"name": "api", "archived": false, "fork": false, "private": true},
+	{"name": "legacy", "archived": true, "fork": false, "private": false},
+	{"name": "upstream-lib", "archived": false, "fork": true, "private": false},
+	{"name": "old-fork", "archived": true, "fork": true, "private": false}
+]`
+
+// orgReposHandler serves a fixed organization repository list
+func orgReposHandler(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}
+}
+
+func repoNames(repos []OrgRepo) []string {
+	names := make([]string, len(repos))
+	for i, repo := range repos {
+		names[i] = repo.Name
+	}
+	return names
+}
+
+func TestListOrgRepos(t *testing.T) {
+	newMockGitHubServer(t, "acme", "api", "[]", map[string]http.HandlerFunc{
+		"/orgs/acme/repos": orgReposHandler(orgReposJSON),
+	})
+
+	repos, err := ListOrgRepos("acme", "test-token")
+	if err != nil {
+		t.Fatalf("ListOrgRepos failed: %v", err)
+	}
+	if len(repos) != 4 {
+		t.Fatalf("Expected 4 repositories, got %d", len(repos))
+	}
+	if repos[0].Owner != "acme" || !repos[1].Archived || !repos[2].Fork {
+		t.Errorf("Expected owner, archived and fork fields to be read, got %+v", repos)
+	}
+}
+
+func TestListOrgRepos_Pagination(t *testing.T) {
+	newMockGitHubServer(t, "acme", "api", "[]", map[string]http.HandlerFunc{
+		"/orgs/acme/repos": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("page") == "1" {
+				w.Header().Set("Link", `<https://api.github.com/organizations/1/repos?page=2>; rel="next"`)
+				_, _ = w.Write([]byte(`[{"name": "one"}]`))
+				return
+			}
+			_, _ = w.Write([]byte(`[{"name": "two"}]`))
+		},
+	})
+
+	repos, err := ListOrgRepos("acme", "test-token")
+	if err != nil {
+		t.Fatalf("ListOrgRepos failed: %v", err)
+	}
+	if got := strings.Join(repoNames(repos), ","); got != "one,two" {
+		t.Errorf("Expected repositories from both pages, got %s", got)
+	}
+}
+
+func TestListOrgRepos_NotFound(t *testing.T) {
+	newMockGitHubServer(t, "acme", "api", "[]", nil)
+
+	if _, err := ListOrgRepos("missing", "test-token"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
+
+func TestFilterOrgRepos(t *testing.T) {
+	repos := []OrgRepo{
+		{Name: "api"},
+		{Name: "legacy", Archived: true},
+		{Name: "upstream-lib", Fork: true},
+		{Name: "old-fork", Archived: true, Fork: true},
+	}
+
+	tests := []struct {
+		name     string
+		settings DiscoverySettings
+		kept     string
+		skipped  string
+	}{
+		{"no filters", DiscoverySettings{}, "api,legacy,upstream-lib,old-fork", ""},
+		{"skip archived", DiscoverySettings{SkipArchived: true}, "api,upstream-lib", "legacy:archived,old-fork:archived"},
+		{"skip forks", DiscoverySettings{SkipForks: true}, "api,legacy", "upstream-lib:fork,old-fork:fork"},
+		{"skip both", DiscoverySettings{SkipArchived: true, SkipForks: true}, "api", "legacy:archived,upstream-lib:fork,old-fork:archived"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kept, skipped := FilterOrgRepos(repos, tt.settings)
+			if got := strings.Join(repoNames(kept), ","); got != tt.kept {
+				t.Errorf("Expected kept %q, got %q", tt.kept, got)
+			}
+			var reasons []string
+			for _, s := range skipped {
+				reasons = append(reasons, s.Repo.Name+":"+s.Reason)
+			}
+			if got := strings.Join(reasons, ","); got != tt.skipped {
+				t.Errorf("Expected skipped %q, got %q", tt.skipped, got)
+			}
+		})
+	}
+}
+
+func TestDiscoverOrgProjects(t *testing.T) {
+	newMockGitHubServer(t, "acme", "api", "[]", map[string]http.HandlerFunc{
+		"/orgs/acme/repos": orgReposHandler(orgReposJSON),
+	})
+
+	t.Run("excludes archived and forks when set", func(t *testing.T) {
+		config := &MultiProjectConfig{}
+		discovery, err := DiscoverOrgProjects(config, "acme", "test-token", DiscoverySettings{SkipArchived: true, SkipForks: true})
+		if err != nil {
+			t.Fatalf("DiscoverOrgProjects failed: %v", err)
+		}
+		if len(config.Projects) != 1 || config.Projects[0].Repo != "api" {
+			t.Errorf("Expected only acme/api to be added, got %+v", config.Projects)
+		}
+		if len(discovery.Skipped) != 3 {
+			t.Errorf("Expected 3 skipped repositories, got %d", len(discovery.Skipped))
+		}
+	})
+
+	t.Run("includes everything by default", func(t *testing.T) {
+		config := &MultiProjectConfig{}
+		if _, err := DiscoverOrgProjects(config, "acme", "test-token", DiscoverySettings{}); err != nil {
+			t.Fatalf("DiscoverOrgProjects failed: %v", err)
+		}
+		if len(config.Projects) != 4 {
+			t.Errorf("Expected 4 projects, got %d", len(config.Projects))
+		}
+	})
+
+	t.Run("keeps existing projects", func(t *testing.T) {
+		config := &MultiProjectConfig{Projects: []ProjectConfig{{Owner: "ACME", Repo: "api", Token: "project-token"}}}
+		discovery, err := DiscoverOrgProjects(config, "acme", "test-token", DiscoverySettings{SkipArchived: true, SkipForks: true})
+		if err != nil {
+			t.Fatalf("DiscoverOrgProjects failed: %v", err)
+		}
+		if len(config.Projects) != 1 || config.Projects[0].Token != "project-token" {
+			t.Errorf("Expected the existing project to be left alone, got %+v", config.Projects)
+		}
+		if len(discovery.Added) != 0 || len(discovery.Existing) != 1 {
+			t.Errorf("Expected 0 added and 1 existing, got %d and %d", len(discovery.Added), len(discovery.Existing))
+		}
+	})
+}
+
+func TestAddOrgProjects(t *testing.T) {
+	chdirTemp(t)
+	newMockGitHubServer(t, "acme", "api", "[]", map[string]http.HandlerFunc{
+		"/orgs/acme/repos": orgReposHandler(orgReposJSON),
+	})
+	config := &MultiProjectConfig{
+		Global:    GlobalConfig{Token: "test-token", Database: "./pivot.db"},
+		Discovery: DiscoverySettings{SkipArchived: true, SkipForks: true},
+	}
+	if err := SaveMultiProjectConfig(config); err != nil {
+		t.Fatalf("Failed to save config: %v", err)
+	}
+
+	if err := AddOrgProjects("acme", nil, nil, true); err != nil {
+		t.Fatalf("AddOrgProjects dry run failed: %v", err)
+	}
+	loaded, err := LoadMultiProjectConfig()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if len(loaded.Projects) != 0 {
+		t.Errorf("Expected dry run to leave config.yml unchanged, got %+v", loaded.Projects)
+	}
+
+	// The flag overrides the configured default
+	includeForks := false
+	if err := AddOrgProjects("acme", nil, &includeForks, false); err != nil {
+		t.Fatalf("AddOrgProjects failed: %v", err)
+	}
+	loaded, err = LoadMultiProjectConfig()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	var names []string
+	for _, project := range loaded.Projects {
+		names = append(names, project.Owner+"/"+project.Repo)
+	}
+	if got := strings.Join(names, ","); got != "acme/api,acme/upstream-lib" {
+		t.Errorf("Expected acme/api and acme/upstream-lib, got %s", got)
+	}
+}
@@ -0,0 +1,211 @@
+package internal
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// PushOptions controls how local-only issues are created on GitHub
+type PushOptions struct {
+	Limit                   int    // Push at most this many issues (0 = all)
+	CreateMissingMilestones bool   // Create milestones referenced by title that do not exist yet
+	OnViolation             string // ViolationSkip or ViolationAbort (default) for issues failing push validation
+}
+
+// LocalIssue is an issue waiting to be pushed, together with the project it belongs to
+type LocalIssue struct {
+	LocalID int64
+	Owner   string
+	Repo    string
+	Issue   DBIssue
+}
+
+// PushedIssue is a local issue that now exists on GitHub
+type PushedIssue struct {
+	LocalID int64
+	Owner   string
+	Repo    string
+	Number  int
+	Title   string
+	URL     string
+}
+
+// PushFailure is a local issue that could not be created on GitHub
+type PushFailure struct {
+	LocalID int64
+	Title   string
+	Error   string
+}
+
+// PushResult is the outcome of PushLocalIssues
+type PushResult struct {
+	Pushed            []PushedIssue
+	Failed            []PushFailure
+	Skipped           []PushFailure // Issues failing push validation with --on-violation skip; their state is unchanged
+	CreatedMilestones []string      // owner/repo: title of each milestone created
+}
+
+// ListPushableIssues returns the issues waiting to be pushed, oldest first: those in
+// the LOCAL_ONLY state and those whose previous push failed
+func ListPushableIssues(db *sql.DB) ([]LocalIssue, error) {
+	if err := InitSyncStateSchema(db); err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Query(`
+		SELECT s.issue_local_id, p.owner, p.repo, COALESCE(i.title, ''), COALESCE(i.body, ''), COALESCE(i.state, ''),
+		       COALESCE(i.labels, ''), COALESCE(i.assignees, ''), COALESCE(i.milestone, ''), COALESCE(i.issue_type, '')
+		FROM issue_sync_state s
+		JOIN issues i ON i.rowid = s.issue_local_id
+		JOIN projects p ON p.id = i.project_id
+		WHERE s.sync_state IN (?, ?)
+		ORDER BY s.issue_local_id`, string(SyncStateLocalOnly), string(SyncStatePushFailed))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query local-only issues: %w", err)
+	}
+	defer rows.Close()
+
+	var issues []LocalIssue
+	for rows.Next() {
+		var local LocalIssue
+		issue := &local.Issue
+		if err := rows.Scan(&local.LocalID, &local.Owner, &local.Repo, &issue.Title, &issue.Body, &issue.State,
+			&issue.Labels, &issue.Assignees, &issue.Milestone, &issue.Type); err != nil {
+			return nil, fmt.Errorf("failed to scan local-only issue: %w", err)
+		}
+		issues = append(issues, local)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query local-only issues: %w", err)
+	}
+	return issues, nil
+}
+
+// PushLocalIssues creates the given local-only issues on GitHub. Every issue is first
+// checked against the push validation rules: in abort mode any violation fails the
+// push before an issue is created, in skip mode violating issues are skipped and keep
+// their state. Milestone titles are resolved to milestone numbers per repository. An
+// issue that fails to be created is marked PUSH_FAILED with the error and the others
+// continue.
+func PushLocalIssues(db *sql.DB, config *MultiProjectConfig, issues []LocalIssue, opts PushOptions) (*PushResult, error) {
+	if opts.Limit > 0 && len(issues) > opts.Limit {
+		issues = issues[:opts.Limit]
+	}
+
+	result := &PushResult{}
+	issues, skipped, err := validateLocalIssues(issues, config.Push.Validation, opts.OnViolation)
+	if err != nil {
+		return result, err
+	}
+	result.Skipped = skipped
+
+	resolvers := make(map[string]*MilestoneResolver)
+	for _, local := range issues {
+		repository := local.Owner + "/" + local.Repo
+		token := RepositoryToken(config, local.Owner, local.Repo)
+		if token == "" {
+			return result, fmt.Errorf("no GitHub token configured for project %s", repository)
+		}
+
+		resolver, ok := resolvers[repository]
+		if !ok {
+			resolver = &MilestoneResolver{Owner: local.Owner, Repo: local.Repo, Token: token, CreateMissing: opts.CreateMissingMilestones}
+			resolvers[repository] = resolver
+		}
+
+		pushed, err := pushLocalIssue(db, local, token, resolver)
+		if err != nil {
+			err = ScrubError(err)
+			message := err.Error()
+			if updateErr := UpdateSyncState(db, local.LocalID, SyncStatePushFailed, nil, &message); updateErr != nil {
+				return result, updateErr
+			}
+			result.Failed = append(result.Failed, PushFailure{LocalID: local.LocalID, Title: local.Issue.Title, Error: message})
+			continue
+		}
+		result.Pushed = append(result.Pushed, *pushed)
+	}
+
+	for repository, resolver := range resolvers {
+		for _, title := range resolver.Created {
+			result.CreatedMilestones = append(result.CreatedMilestones, repository+": "+title)
+		}
+	}
+	return result, nil
+}
+
+// validateLocalIssues checks every issue against the push validation rules before
+// any is pushed. In abort mode any violation is returned as an error; in skip mode the
+// issues that pass are returned together with the violating ones.
+func validateLocalIssues(issues []LocalIssue, rules PushValidationRules, onViolation string) ([]LocalIssue, []PushFailure, error) {
+	mode, err := ParseViolationMode(onViolation)
+	if err != nil {
+		return nil, nil, err
+	}
+	if rules.IsEmpty() {
+		return issues, nil, nil
+	}
+
+	var valid []LocalIssue
+	var skipped []PushFailure
+	var report []string
+	for _, local := range issues {
+		violations, err := rules.Validate(ToCreateRequest(&local.Issue))
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(violations) == 0 {
+			valid = append(valid, local)
+			continue
+		}
+		message := strings.Join(violations, "; ")
+		skipped = append(skipped, PushFailure{LocalID: local.LocalID, Title: local.Issue.Title, Error: message})
+		report = append(report, fmt.Sprintf("'%s' (Local ID: %d): %s", local.Issue.Title, local.LocalID, message))
+	}
+
+	if mode == ViolationAbort && len(report) > 0 {
+		return nil, nil, fmt.Errorf("%d issues failed push validation, nothing was pushed:\n  %s",
+			len(report), strings.Join(report, "\n  "))
+	}
+	return valid, skipped, nil
+}
+
+// pushLocalIssue creates one local issue on GitHub and links the local row to it
+func pushLocalIssue(db *sql.DB, local LocalIssue, token string, resolver *MilestoneResolver) (*PushedIssue, error) {
+	request := ToCreateRequest(&local.Issue)
+	if err := resolver.ResolveMilestone(&request, local.Issue.Milestone); err != nil {
+		return nil, err
+	}
+
+	created, err := CreateIssue(local.Owner, local.Repo, token, request)
+	if err != nil {
+		return nil, err
+	}
+
+	// The row now mirrors the GitHub issue; clear the local edit marker so the next
+	// sync refreshes it instead of reporting a conflict
+	local.Issue.ID = created.ID
+	local.Issue.Number = created.Number
+	if _, err := db.Exec("UPDATE issues SET github_id = ?, number = ?, local_modified_at = NULL, sync_hash = ? WHERE rowid = ?",
+		created.ID, created.Number, ComputeSyncHash(&local.Issue), local.LocalID); err != nil {
+		return nil, fmt.Errorf("issue was created as #%d but the local copy could not be updated: %w", created.Number, err)
+	}
+	githubID := int64(created.ID)
+	if err := UpdateSyncState(db, local.LocalID, SyncStateSynced, &githubID, nil); err != nil {
+		return nil, err
+	}
+
+	return &PushedIssue{LocalID: local.LocalID, Owner: local.Owner, Repo: local.Repo,
+		Number: created.Number, Title: local.Issue.Title, URL: created.HTMLURL}, nil
+}
+
+// RepositoryToken returns the configured token for a repository, falling back to the global token
+func RepositoryToken(config *MultiProjectConfig, owner, repo string) string {
+	for _, project := range config.Projects {
+		if strings.EqualFold(project.Owner, owner) && strings.EqualFold(project.Repo, repo) {
+			return project.GetEffectiveToken(&config.Global)
+		}
+	}
+	return config.Global.EffectiveToken()
+}
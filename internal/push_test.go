@@ -0,0 +1,257 @@
+package internal
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// pushMock records the milestones and issues created through the mock GitHub API
+type pushMock struct {
+	createdMilestones []string
+	createdIssues     []CreateIssueRequest
+}
+
+// newPushMock serves a repository with a single milestone "v1.0" (number 1)
+func newPushMock(t *testing.T) *pushMock {
+	t.Helper()
+	mock := &pushMock{}
+	newMockGitHubServer(t, "org", "alpha", "[]", map[string]http.HandlerFunc{
+		"/repos/org/alpha/milestones": func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				var payload map[string]string
+				_ = json.NewDecoder(r.Body).Decode(&payload)
+				mock.createdMilestones = append(mock.createdMilestones, payload["title"])
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(`{"number": 7, "title": "` + payload["title"] + `", "state": "open"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`[{"number": 1, "title": "v1.0", "state": "open"}]`))
+		},
+		"/repos/org/alpha/issues": func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				_, _ = w.Write([]byte(`[]`))
+				return
+			}
+			var request CreateIssueRequest
+			_ = json.NewDecoder(r.Body).Decode(&request)
+			mock.createdIssues = append(mock.createdIssues, request)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id": 9001, "number": 42, "title": "` + request.Title + `", "state": "open",
+				"html_url": "https://github.com/org/alpha/issues/42"}`))
+		},
+	})
+	return mock
+}
+
+// newPushTestDB creates a database with one local-only issue using the given milestone
+func newPushTestDB(t *testing.T, milestone string) (*sql.DB, int64) {
+	t.Helper()
+	db := newTestMultiProjectDB(t)
+	projectID, err := CreateProject(db, &ProjectConfig{Owner: "org", Repo: "alpha"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	localID, err := CreateLocalIssue(db, projectID, &DBIssue{Title: "Ship it", Body: "Details", Milestone: milestone})
+	if err != nil {
+		t.Fatalf("Failed to create local issue: %v", err)
+	}
+	return db, localID
+}
+
+var pushTestConfig = &MultiProjectConfig{
+	Global:   GlobalConfig{Token: "test-token"},
+	Projects: []ProjectConfig{{Owner: "org", Repo: "alpha"}},
+}
+
+func pushAll(t *testing.T, db *sql.DB, opts PushOptions) *PushResult {
+	t.Helper()
+	issues, err := ListPushableIssues(db)
+	if err != nil {
+		t.Fatalf("ListPushableIssues failed: %v", err)
+	}
+	result, err := PushLocalIssues(db, pushTestConfig, issues, opts)
+	if err != nil {
+		t.Fatalf("PushLocalIssues failed: %v", err)
+	}
+	return result
+}
+
+func TestPushLocalIssues_CreatesMissingMilestone(t *testing.T) {
+	mock := newPushMock(t)
+	db, localID := newPushTestDB(t, "v2.0")
+
+	result := pushAll(t, db, PushOptions{CreateMissingMilestones: true})
+
+	if len(result.Pushed) != 1 || len(result.Failed) != 0 {
+		t.Fatalf("Expected 1 pushed issue, got %+v", result)
+	}
+	if len(mock.createdMilestones) != 1 || mock.createdMilestones[0] != "v2.0" {
+		t.Errorf("Expected milestone v2.0 to be created, got %v", mock.createdMilestones)
+	}
+	if len(mock.createdIssues) != 1 || mock.createdIssues[0].Milestone != 7 {
+		t.Errorf("Expected the issue to be created with milestone 7, got %+v", mock.createdIssues)
+	}
+	if len(result.CreatedMilestones) != 1 || result.CreatedMilestones[0] != "org/alpha: v2.0" {
+		t.Errorf("Expected the created milestone in the result, got %v", result.CreatedMilestones)
+	}
+
+	state, err := GetSyncState(db, localID)
+	if err != nil || state.SyncState != SyncStateSynced || state.GitHubID == nil || *state.GitHubID != 9001 {
+		t.Errorf("Expected SYNCED with GitHub ID 9001, got %+v (%v)", state, err)
+	}
+	var number int
+	if err := db.QueryRow("SELECT number FROM issues WHERE rowid = ?", localID).Scan(&number); err != nil || number != 42 {
+		t.Errorf("Expected the local row to take issue number 42, got %d (%v)", number, err)
+	}
+}
+
+func TestPushLocalIssues_MissingMilestoneWithoutFlag(t *testing.T) {
+	mock := newPushMock(t)
+	db, localID := newPushTestDB(t, "v2.0")
+
+	result := pushAll(t, db, PushOptions{})
+
+	if len(result.Pushed) != 0 || len(result.Failed) != 1 {
+		t.Fatalf("Expected 1 failed issue, got %+v", result)
+	}
+	if !strings.Contains(result.Failed[0].Error, "milestone 'v2.0' does not exist") ||
+		!strings.Contains(result.Failed[0].Error, "--create-milestone-if-missing") {
+		t.Errorf("Expected missing milestone error, got %s", result.Failed[0].Error)
+	}
+	if len(mock.createdMilestones) != 0 || len(mock.createdIssues) != 0 {
+		t.Errorf("Expected nothing to be created, got milestones %v and issues %v", mock.createdMilestones, mock.createdIssues)
+	}
+
+	state, _ := GetSyncState(db, localID)
+	if state.SyncState != SyncStatePushFailed || state.SyncError == nil {
+		t.Errorf("Expected PUSH_FAILED with the error, got %+v", state)
+	}
+
+	// Failed issues are retried by the next push
+	issues, err := ListPushableIssues(db)
+	if err != nil || len(issues) != 1 {
+		t.Errorf("Expected the failed issue to be pushable again, got %d (%v)", len(issues), err)
+	}
+}
+
+func TestPushLocalIssues_ExistingMilestone(t *testing.T) {
+	mock := newPushMock(t)
+	db, _ := newPushTestDB(t, "V1.0")
+
+	result := pushAll(t, db, PushOptions{CreateMissingMilestones: true})
+
+	if len(result.Pushed) != 1 {
+		t.Fatalf("Expected 1 pushed issue, got %+v", result)
+	}
+	if len(mock.createdMilestones) != 0 {
+		t.Errorf("Expected no milestone to be created, got %v", mock.createdMilestones)
+	}
+	if mock.createdIssues[0].Milestone != 1 {
+		t.Errorf("Expected the title to resolve to milestone 1, got %d", mock.createdIssues[0].Milestone)
+	}
+}
+
+func TestPushLocalIssues_ValidationRules(t *testing.T) {
+	mock := newPushMock(t)
+	db, validID := newPushTestDB(t, "")
+	projectID, _ := getProjectID(db, "org", "alpha")
+	invalidID, err := CreateLocalIssue(db, projectID, &DBIssue{Title: "Untriaged", Body: "Details"})
+	if err != nil {
+		t.Fatalf("Failed to create local issue: %v", err)
+	}
+	if _, err := db.Exec("UPDATE issues SET labels = 'triage' WHERE rowid = ?", validID); err != nil {
+		t.Fatalf("Failed to label issue: %v", err)
+	}
+
+	config := *pushTestConfig
+	config.Push.Validation = PushValidationRules{RequiredLabels: []string{"triage"}}
+	issues, _ := ListPushableIssues(db)
+
+	// Abort (the default) checks every issue before pushing any
+	_, err = PushLocalIssues(db, &config, issues, PushOptions{})
+	if err == nil || !strings.Contains(err.Error(), "1 issues failed push validation, nothing was pushed") ||
+		!strings.Contains(err.Error(), "'Untriaged'") || !strings.Contains(err.Error(), "missing required label 'triage'") {
+		t.Errorf("Expected the push to be aborted, got %v", err)
+	}
+	if len(mock.createdIssues) != 0 {
+		t.Errorf("Expected no issue to be created, got %v", mock.createdIssues)
+	}
+	for _, id := range []int64{validID, invalidID} {
+		if state, _ := GetSyncState(db, id); state.SyncState != SyncStateLocalOnly {
+			t.Errorf("Expected issue %d to stay LOCAL_ONLY after the abort, got %s", id, state.SyncState)
+		}
+	}
+
+	// Skip pushes the valid issue and leaves the other one untouched
+	result, err := PushLocalIssues(db, &config, issues, PushOptions{OnViolation: ViolationSkip})
+	if err != nil {
+		t.Fatalf("PushLocalIssues failed: %v", err)
+	}
+	if len(result.Pushed) != 1 || len(result.Failed) != 0 || len(result.Skipped) != 1 {
+		t.Fatalf("Expected 1 pushed and 1 skipped issue, got %+v", result)
+	}
+	if result.Skipped[0].LocalID != invalidID || !strings.Contains(result.Skipped[0].Error, "missing required label 'triage'") {
+		t.Errorf("Expected the untriaged issue to be skipped, got %+v", result.Skipped[0])
+	}
+	if len(mock.createdIssues) != 1 || mock.createdIssues[0].Title != "Ship it" {
+		t.Errorf("Expected only the valid issue to be created, got %v", mock.createdIssues)
+	}
+	if state, _ := GetSyncState(db, invalidID); state.SyncState != SyncStateLocalOnly || state.SyncError != nil {
+		t.Errorf("Expected the skipped issue to stay LOCAL_ONLY without an error, got %+v", state)
+	}
+
+	if _, err := PushLocalIssues(db, &config, issues, PushOptions{OnViolation: "ignore"}); err == nil {
+		t.Error("Expected an invalid violation mode to be rejected")
+	}
+}
+
+func TestMilestoneResolver_ListsOnce(t *testing.T) {
+	lists := 0
+	newMockGitHubServer(t, "org", "alpha", "[]", map[string]http.HandlerFunc{
+		"/repos/org/alpha/milestones": func(w http.ResponseWriter, r *http.Request) {
+			lists++
+			_, _ = w.Write([]byte(`[{"number": 3, "title": "Beta", "state": "closed"}]`))
+		},
+	})
+
+	resolver := &MilestoneResolver{Owner: "org", Repo: "alpha", Token: "test-token"}
+	for i := 0; i < 3; i++ {
+		if number, err := resolver.Resolve("beta"); err != nil || number != 3 {
+			t.Errorf("Expected closed milestone 3, got %d (%v)", number, err)
+		}
+	}
+	if lists != 1 {
+		t.Errorf("Expected milestones to be listed once, got %d", lists)
+	}
+}
+
+func TestRepositoryToken(t *testing.T) {
+	config := &MultiProjectConfig{
+		Global: GlobalConfig{GitHubApp: &GitHubAppConfig{AppID: 1, InstallationID: 2}},
+		Projects: []ProjectConfig{
+			{Owner: "org", Repo: "alpha", Token: "alpha-token"},
+			{Owner: "org", Repo: "beta"},
+		},
+	}
+
+	tests := map[string]string{
+		"org/alpha": "alpha-token",
+		"ORG/Alpha": "alpha-token",
+		"org/beta":  GitHubAppToken,
+		"org/other": GitHubAppToken,
+	}
+	for repository, want := range tests {
+		owner, repo, _ := strings.Cut(repository, "/")
+		if got := RepositoryToken(config, owner, repo); got != want {
+			t.Errorf("Expected token %q for %s, got %q", want, repository, got)
+		}
+	}
+
+	config.Global.Token = "global-token"
+	if got := RepositoryToken(config, "org", "other"); got != "global-token" {
+		t.Errorf("Expected the global token for an unconfigured repository, got %q", got)
+	}
+}
@@ -0,0 +1,76 @@
+package internal
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Violation handling modes for push validation
+const (
+	ViolationSkip  = "skip"  // Skip issues that break a rule and push the rest
+	ViolationAbort = "abort" // Push nothing if any issue breaks a rule
+)
+
+// PushSettings contains configuration applied before issues are created on GitHub
+type PushSettings struct {
+	Validation PushValidationRules `yaml:"validation,omitempty"`
+}
+
+// PushValidationRules are checks an issue must pass before it is created on GitHub
+type PushValidationRules struct {
+	RequiredLabels []string `yaml:"required_labels,omitempty"` // Labels every issue must carry
+	MinBodyLength  int      `yaml:"min_body_length,omitempty"` // Minimum body length after trimming whitespace
+	TitlePattern   string   `yaml:"title_pattern,omitempty"`   // Regular expression the title must match
+}
+
+// IsEmpty reports whether no validation rules are configured
+func (r PushValidationRules) IsEmpty() bool {
+	return len(r.RequiredLabels) == 0 && r.MinBodyLength == 0 && r.TitlePattern == ""
+}
+
+// Validate checks an issue request against the rules and returns a description of each violation
+func (r PushValidationRules) Validate(request CreateIssueRequest) ([]string, error) {
+	var violations []string
+
+	for _, required := range r.RequiredLabels {
+		found := false
+		for _, label := range request.Labels {
+			if strings.EqualFold(label, required) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			violations = append(violations, fmt.Sprintf("missing required label '%s'", required))
+		}
+	}
+
+	if length := len(strings.TrimSpace(request.Body)); length < r.MinBodyLength {
+		violations = append(violations, fmt.Sprintf("body is %d characters, minimum is %d", length, r.MinBodyLength))
+	}
+
+	if r.TitlePattern != "" {
+		re, err := regexp.Compile(r.TitlePattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid title pattern '%s': %w", r.TitlePattern, err)
+		}
+		if !re.MatchString(request.Title) {
+			violations = append(violations, fmt.Sprintf("title does not match pattern '%s'", r.TitlePattern))
+		}
+	}
+
+	return violations, nil
+}
+
+// ParseViolationMode validates an --on-violation value
+func ParseViolationMode(mode string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "", ViolationAbort:
+		return ViolationAbort, nil
+	case ViolationSkip:
+		return ViolationSkip, nil
+	default:
+		return "", fmt.Errorf("invalid violation mode '%s' (valid: %s, %s)", mode, ViolationSkip, ViolationAbort)
+	}
+}
@@ -0,0 +1,81 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPushValidationRules_Validate(t *testing.T) {
+	rules := PushValidationRules{
+		RequiredLabels: []string{"triage"},
+		MinBodyLength:  10,
+		TitlePattern:   `^\[[A-Z]+\] `,
+	}
+
+	passing := CreateIssueRequest{
+		Title:  "[API] Add pagination",
+		Body:   "Paginate the list endpoint",
+		Labels: []string{"Triage", "enhancement"},
+	}
+	violations, err := rules.Validate(passing)
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if len(violations) != 0 {
+		t.Errorf("Expected no violations, got %v", violations)
+	}
+
+	failing := CreateIssueRequest{
+		Title: "Add pagination",
+		Body:  "  short  ",
+	}
+	violations, err = rules.Validate(failing)
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if len(violations) != 3 {
+		t.Fatalf("Expected 3 violations, got %d: %v", len(violations), violations)
+	}
+	for i, expected := range []string{"missing required label 'triage'", "body is 5 characters", "title does not match"} {
+		if !strings.Contains(violations[i], expected) {
+			t.Errorf("Expected violation %d to contain %q, got %q", i, expected, violations[i])
+		}
+	}
+}
+
+func TestPushValidationRules_InvalidPattern(t *testing.T) {
+	rules := PushValidationRules{TitlePattern: "("}
+	if _, err := rules.Validate(CreateIssueRequest{Title: "x"}); err == nil {
+		t.Error("Expected error for invalid title pattern")
+	}
+}
+
+func TestPushValidationRules_IsEmpty(t *testing.T) {
+	if !(PushValidationRules{}).IsEmpty() {
+		t.Error("Expected empty rules to report IsEmpty")
+	}
+	if (PushValidationRules{MinBodyLength: 1}).IsEmpty() {
+		t.Error("Expected rules with a minimum body length not to be empty")
+	}
+}
+
+func TestParseViolationMode(t *testing.T) {
+	tests := map[string]string{
+		"":      ViolationAbort,
+		"abort": ViolationAbort,
+		"SKIP":  ViolationSkip,
+	}
+	for input, expected := range tests {
+		mode, err := ParseViolationMode(input)
+		if err != nil {
+			t.Errorf("ParseViolationMode(%q) failed: %v", input, err)
+		}
+		if mode != expected {
+			t.Errorf("ParseViolationMode(%q): expected %s, got %s", input, expected, mode)
+		}
+	}
+
+	if _, err := ParseViolationMode("ignore"); err == nil {
+		t.Error("Expected error for unknown violation mode")
+	}
+}
@@ -0,0 +1,210 @@
+package internal
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// syncRequestsPerProject is the number of requests a project sync makes besides
+// fetching issue pages: the credential checks (/user and the repository) and the
+// redirect check
+const syncRequestsPerProject = 3
+
+// issuesPerPage matches the per_page used when fetching issues
+const issuesPerPage = 100
+
+// Clock used while waiting for the rate limit to reset. Tests replace it.
+var (
+	rateLimitNow   = time.Now
+	rateLimitSleep = time.Sleep
+)
+
+// RateLimit is the core REST API budget of a token, as reported by GET /rate_limit
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Used      int
+	Reset     time.Time
+}
+
+// String describes the budget, e.g. "4990 of 5000 requests remaining, resets at 15:04:05 (in 42m0s)"
+func (r *RateLimit) String() string {
+	wait := r.Reset.Sub(rateLimitNow()).Round(time.Second)
+	if wait < 0 {
+		wait = 0
+	}
+	return fmt.Sprintf("%d of %d requests remaining, resets at %s (in %s)",
+		r.Remaining, r.Limit, r.Reset.Local().Format("15:04:05"), wait)
+}
+
+// FetchRateLimit returns the core REST API budget of a token. Requests to
+// /rate_limit do not count against the budget.
+func FetchRateLimit(token string) (*RateLimit, error) {
+	req, err := http.NewRequest("GET", githubAPIURL+"/rate_limit", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "token "+token)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+	resp, err := newHTTPClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch rate limit: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rate limit: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GitHub API returned status %d for /rate_limit: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	type budget struct {
+		Limit     int   `json:"limit"`
+		Remaining int   `json:"remaining"`
+		Used      int   `json:"used"`
+		Reset     int64 `json:"reset"`
+	}
+	var payload struct {
+		Resources struct {
+			Core *budget `json:"core"`
+		} `json:"resources"`
+		Rate *budget `json:"rate"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("failed to parse rate limit: %w", err)
+	}
+
+	core := payload.Resources.Core
+	if core == nil {
+		core = payload.Rate
+	}
+	if core == nil {
+		return nil, fmt.Errorf("rate limit response has no core budget")
+	}
+
+	return &RateLimit{
+		Limit:     core.Limit,
+		Remaining: core.Remaining,
+		Used:      core.Used,
+		Reset:     time.Unix(core.Reset, 0),
+	}, nil
+}
+
+// EstimateSyncCost estimates the requests a full sync of the projects needs, from
+// the number of issues stored for each of them
+func EstimateSyncCost(db *sql.DB, projects []ProjectConfig) int {
+	cost := 0
+	for _, project := range projects {
+		var stored int
+		_ = db.QueryRow(`
+			SELECT COUNT(*) FROM issues i JOIN projects p ON p.id = i.project_id
+			WHERE p.owner = ? AND p.repo = ?`, project.Owner, project.Repo).Scan(&stored)
+		cost += syncRequestsPerProject + stored/issuesPerPage + 1
+	}
+	return cost
+}
+
+// tokenGroup is a set of projects that share a token, and so a rate limit budget
+type tokenGroup struct {
+	token    string
+	projects []ProjectConfig
+}
+
+// groupProjectsByToken groups projects by their effective token, in configuration
+// order. A non-empty override is used for every project.
+func groupProjectsByToken(global *GlobalConfig, projects []ProjectConfig, override string) []tokenGroup {
+	var groups []tokenGroup
+	index := make(map[string]int)
+	for _, project := range projects {
+		token := project.GetEffectiveToken(global)
+		if override != "" {
+			token = override
+		}
+		if token == "" {
+			continue
+		}
+		if i, ok := index[token]; ok {
+			groups[i].projects = append(groups[i].projects, project)
+			continue
+		}
+		index[token] = len(groups)
+		groups = append(groups, tokenGroup{token: token, projects: []ProjectConfig{project}})
+	}
+	return groups
+}
+
+// projectNames lists projects as owner/repo
+func projectNames(projects []ProjectConfig) string {
+	names := make([]string, len(projects))
+	for i, project := range projects {
+		names[i] = project.Owner + "/" + project.Repo
+	}
+	return strings.Join(names, ", ")
+}
+
+// checkRateLimitBudget compares the estimated cost of syncing the projects with the
+// remaining budget of each token. A low budget is reported, and with
+// opts.WaitForRateLimit the sync pauses until the budget resets. Tokens whose budget
+// cannot be read (e.g. GitHub Enterprise with rate limiting disabled) are not checked.
+// It reports whether any budget was too low.
+func checkRateLimitBudget(db *sql.DB, global *GlobalConfig, projects []ProjectConfig, opts SyncOptions) bool {
+	low := false
+	for _, group := range groupProjectsByToken(global, projects, opts.Token) {
+		limit, err := FetchRateLimit(group.token)
+		if err != nil {
+			continue
+		}
+
+		cost := EstimateSyncCost(db, group.projects)
+		if cost <= limit.Remaining {
+			continue
+		}
+
+		low = true
+		fmt.Printf("⚠ Syncing %s needs up to %d API requests, but only %s\n", projectNames(group.projects), cost, limit)
+		if !opts.WaitForRateLimit {
+			fmt.Println("  The sync may stop at the rate limit; use --wait-for-rate-limit to pause until it resets")
+			continue
+		}
+
+		if wait := limit.Reset.Sub(rateLimitNow()); wait > 0 {
+			wait = wait.Round(time.Second) + time.Second
+			fmt.Printf("⏸ Waiting %s for the rate limit to reset...\n", wait)
+			rateLimitSleep(wait)
+		}
+	}
+	return low
+}
+
+// PrintRateLimits writes the current rate limit budget of every token used by the
+// selected projects, naming the projects instead of the tokens
+func PrintRateLimits(w io.Writer, config *MultiProjectConfig, projectFilter, tokenOverride string) error {
+	projects, err := selectSyncProjects(config, projectFilter)
+	if err != nil {
+		return err
+	}
+
+	groups := groupProjectsByToken(&config.Global, projects, tokenOverride)
+	if len(groups) == 0 {
+		return fmt.Errorf("no GitHub token configured for %s", projectNames(projects))
+	}
+
+	for _, group := range groups {
+		limit, err := FetchRateLimit(group.token)
+		if err != nil {
+			return fmt.Errorf("failed to get rate limit for %s: %w", projectNames(group.projects), err)
+		}
+		fmt.Fprintf(w, "📊 GitHub API rate limit for %s\n", projectNames(group.projects))
+		fmt.Fprintf(w, "   Remaining: %d of %d (%d used)\n", limit.Remaining, limit.Limit, limit.Used)
+		fmt.Fprintf(w, "   Resets at: %s\n", limit.Reset.Local().Format("2006-01-02 15:04:05"))
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rateLimitHandler serves a /rate_limit response with the given core budget
+func rateLimitHandler(remaining int, reset time.Time) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"resources": {"core": {"limit": 5000, "used": %d, "remaining": %d, "reset": %d},
+			"search": {"limit": 30, "used": 0, "remaining": 30, "reset": 0}}}`, 5000-remaining, remaining, reset.Unix())
+	}
+}
+
+// stubRateLimitClock fixes the clock used for rate limit waits and records any sleep
+func stubRateLimitClock(t *testing.T, now time.Time) *[]time.Duration {
+	t.Helper()
+	var slept []time.Duration
+	oldNow, oldSleep := rateLimitNow, rateLimitSleep
+	rateLimitNow = func() time.Time { return now }
+	rateLimitSleep = func(d time.Duration) { slept = append(slept, d) }
+	t.Cleanup(func() { rateLimitNow, rateLimitSleep = oldNow, oldSleep })
+	return &slept
+}
+
+func TestFetchRateLimit(t *testing.T) {
+	reset := time.Unix(1767225600, 0)
+	newMockGitHubServer(t, "org", "alpha", "[]", map[string]http.HandlerFunc{
+		"/rate_limit": rateLimitHandler(4990, reset),
+	})
+
+	limit, err := FetchRateLimit("test-token")
+	if err != nil {
+		t.Fatalf("FetchRateLimit failed: %v", err)
+	}
+	if limit.Limit != 5000 || limit.Remaining != 4990 || limit.Used != 10 {
+		t.Errorf("Expected core budget 4990 of 5000 (10 used), got %+v", limit)
+	}
+	if !limit.Reset.Equal(reset) {
+		t.Errorf("Expected reset %v, got %v", reset, limit.Reset)
+	}
+}
+
+func TestFetchRateLimit_LegacyRateField(t *testing.T) {
+	newMockGitHubServer(t, "org", "alpha", "[]", map[string]http.HandlerFunc{
+		"/rate_limit": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"rate": {"limit": 60, "used": 59, "remaining": 1, "reset": 0}}`))
+		},
+	})
+
+	limit, err := FetchRateLimit("test-token")
+	if err != nil || limit.Remaining != 1 || limit.Limit != 60 {
+		t.Errorf("Expected budget from the rate field, got %+v (%v)", limit, err)
+	}
+}
+
+func TestFetchRateLimit_Errors(t *testing.T) {
+	newMockGitHubServer(t, "org", "alpha", "[]", nil)
+	if _, err := FetchRateLimit("test-token"); err == nil || !strings.Contains(err.Error(), "status 404") {
+		t.Errorf("Expected status error when /rate_limit is missing, got: %v", err)
+	}
+
+	newMockGitHubServer(t, "org", "alpha", "[]", map[string]http.HandlerFunc{
+		"/rate_limit": func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write([]byte(`{}`)) },
+	})
+	if _, err := FetchRateLimit("test-token"); err == nil || !strings.Contains(err.Error(), "no core budget") {
+		t.Errorf("Expected missing budget error, got: %v", err)
+	}
+}
+
+func TestEstimateSyncCost(t *testing.T) {
+	db := newTestMultiProjectDB(t)
+	projectID, err := CreateProject(db, &ProjectConfig{Owner: "org", Repo: "alpha"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	for i := 1; i <= 250; i++ {
+		if err := SaveIssue(db, projectID, &DBIssue{ID: i, Number: i, Title: "Issue", State: "open"}); err != nil {
+			t.Fatalf("Failed to save issue: %v", err)
+		}
+	}
+
+	// 3 requests per project plus 3 pages for 250 issues, and 3 + 1 page for an unknown project
+	projects := []ProjectConfig{{Owner: "org", Repo: "alpha"}, {Owner: "org", Repo: "new"}}
+	if cost := EstimateSyncCost(db, projects); cost != 10 {
+		t.Errorf("Expected estimated cost 10, got %d", cost)
+	}
+}
+
+func TestGroupProjectsByToken(t *testing.T) {
+	global := &GlobalConfig{Token: "global-token"}
+	projects := []ProjectConfig{
+		{Owner: "org", Repo: "alpha"},
+		{Owner: "org", Repo: "beta", Token: "beta-token"},
+		{Owner: "org", Repo: "gamma"},
+	}
+
+	groups := groupProjectsByToken(global, projects, "")
+	if len(groups) != 2 || groups[0].token != "global-token" || projectNames(groups[0].projects) != "org/alpha, org/gamma" {
+		t.Errorf("Expected alpha and gamma to share the global token, got %+v", groups)
+	}
+	if groups[1].token != "beta-token" || projectNames(groups[1].projects) != "org/beta" {
+		t.Errorf("Expected beta to use its own token, got %+v", groups[1])
+	}
+
+	if groups := groupProjectsByToken(global, projects, "override"); len(groups) != 1 || len(groups[0].projects) != 3 {
+		t.Errorf("Expected the override to put all projects in one group, got %+v", groups)
+	}
+}
+
+func TestCheckRateLimitBudget(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	global := &GlobalConfig{Token: "test-token"}
+	projects := []ProjectConfig{{Owner: "org", Repo: "alpha"}, {Owner: "org", Repo: "beta"}}
+
+	tests := []struct {
+		name      string
+		remaining int
+		wait      bool
+		wantLow   bool
+		wantSleep []time.Duration
+	}{
+		{name: "enough budget", remaining: 4990},
+		{name: "low budget warns", remaining: 5, wantLow: true},
+		{name: "low budget pauses", remaining: 5, wait: true, wantLow: true, wantSleep: []time.Duration{10*time.Minute + time.Second}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slept := stubRateLimitClock(t, now)
+			newMockGitHubServer(t, "org", "alpha", "[]", map[string]http.HandlerFunc{
+				"/rate_limit": rateLimitHandler(tt.remaining, now.Add(10*time.Minute)),
+			})
+			db := newTestMultiProjectDB(t)
+
+			// Two projects need 2 * (3 + 1) = 8 requests
+			low := checkRateLimitBudget(db, global, projects, SyncOptions{WaitForRateLimit: tt.wait})
+			if low != tt.wantLow {
+				t.Errorf("Expected low budget %v, got %v", tt.wantLow, low)
+			}
+			if fmt.Sprint(*slept) != fmt.Sprint(tt.wantSleep) {
+				t.Errorf("Expected sleeps %v, got %v", tt.wantSleep, *slept)
+			}
+		})
+	}
+}
+
+func TestCheckRateLimitBudget_UnreadableBudgetIsSkipped(t *testing.T) {
+	slept := stubRateLimitClock(t, time.Now())
+	newMockGitHubServer(t, "org", "alpha", "[]", nil)
+	db := newTestMultiProjectDB(t)
+
+	low := checkRateLimitBudget(db, &GlobalConfig{Token: "test-token"}, []ProjectConfig{{Owner: "org", Repo: "alpha"}},
+		SyncOptions{WaitForRateLimit: true})
+	if low || len(*slept) != 0 {
+		t.Errorf("Expected no warning or pause without a readable budget, got low=%v sleeps=%v", low, *slept)
+	}
+}
+
+func TestSyncMultiProject_LowBudgetStillSyncs(t *testing.T) {
+	chdirTemp(t)
+	now := time.Now()
+	stubRateLimitClock(t, now)
+	newMockGitHubServer(t, "org", "alpha", "[]", map[string]http.HandlerFunc{
+		"/rate_limit": rateLimitHandler(1, now.Add(time.Minute)),
+	})
+	config := "global:\n  database: ./pivot.db\n  token: test-token\nprojects:\n  - owner: org\n    repo: alpha\n"
+	if err := writeConfigFile("config.yml", []byte(config)); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	result, err := SyncMultiProjectWithOptions("", SyncOptions{})
+	if err != nil || len(result.Projects) != 1 || len(result.Projects[0].Errors) != 0 {
+		t.Errorf("Expected a low budget to only warn, got %+v (%v)", result, err)
+	}
+}
+
+func TestPrintRateLimits(t *testing.T) {
+	stubRateLimitClock(t, time.Now())
+	newMockGitHubServer(t, "org", "alpha", "[]", map[string]http.HandlerFunc{
+		"/rate_limit": rateLimitHandler(4321, time.Now().Add(time.Hour)),
+	})
+	config := &MultiProjectConfig{
+		Global:   GlobalConfig{Token: "test-token"},
+		Projects: []ProjectConfig{{Owner: "org", Repo: "alpha"}, {Owner: "org", Repo: "beta"}},
+	}
+
+	var out bytes.Buffer
+	if err := PrintRateLimits(&out, config, "org/beta", ""); err != nil {
+		t.Fatalf("PrintRateLimits failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "rate limit for org/beta\n") || !strings.Contains(out.String(), "Remaining: 4321 of 5000 (679 used)") {
+		t.Errorf("Expected budget for org/beta, got:\n%s", out.String())
+	}
+	if strings.Contains(out.String(), "test-token") {
+		t.Errorf("Expected the token not to be printed, got:\n%s", out.String())
+	}
+
+	if err := PrintRateLimits(&out, config, "org/gamma", ""); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected unknown project error, got: %v", err)
+	}
+	noToken := &MultiProjectConfig{Projects: []ProjectConfig{{Owner: "org", Repo: "alpha"}}}
+	if err := PrintRateLimits(&out, noToken, "", ""); err == nil || !strings.Contains(err.Error(), "no GitHub token") {
+		t.Errorf("Expected missing token error, got: %v", err)
+	}
+}
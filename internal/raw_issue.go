@@ -0,0 +1,125 @@
+package internal
+
+import (
+	"bytes"
+	"compress/gzip"
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Encodings of the raw issue JSON stored by sync --store-raw
+const (
+	RawEncodingJSON = "json" // Stored as received from GitHub
+	RawEncodingGzip = "gzip" // Gzip-compressed JSON
+)
+
+// Values of sync.raw_compression in config.yml
+const (
+	RawCompressionGzip = "gzip" // Compress stored raw JSON (default)
+	RawCompressionNone = "none" // Store raw JSON uncompressed
+)
+
+// ParseRawCompression validates a sync.raw_compression value and reports whether
+// raw JSON should be compressed
+func ParseRawCompression(value string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", RawCompressionGzip:
+		return true, nil
+	case RawCompressionNone:
+		return false, nil
+	default:
+		return false, fmt.Errorf("invalid raw_compression '%s' (valid: %s, %s)", value, RawCompressionGzip, RawCompressionNone)
+	}
+}
+
+// createIssueRawTable creates the table holding the GitHub JSON of each issue
+func createIssueRawTable(db *sql.DB) error {
+	schema := `
+	CREATE TABLE IF NOT EXISTS issue_raw (
+		project_id INTEGER NOT NULL,
+		number INTEGER NOT NULL,
+		encoding TEXT NOT NULL DEFAULT 'json',
+		raw BLOB NOT NULL,
+		PRIMARY KEY(project_id, number),
+		FOREIGN KEY(project_id) REFERENCES projects(id) ON DELETE CASCADE
+	);`
+
+	if _, err := db.Exec(schema); err != nil {
+		return fmt.Errorf("failed to create issue_raw table: %w", err)
+	}
+	return nil
+}
+
+// SaveRawIssue stores the GitHub JSON of an issue, gzip-compressed when compress
+// is set, replacing any previous copy
+func SaveRawIssue(db *sql.DB, projectID int64, number int, raw []byte, compress bool) error {
+	encoding, data := RawEncodingJSON, raw
+	if compress {
+		compressed, err := compressRaw(raw)
+		if err != nil {
+			return err
+		}
+		encoding, data = RawEncodingGzip, compressed
+	}
+
+	_, err := db.Exec("INSERT OR REPLACE INTO issue_raw (project_id, number, encoding, raw) VALUES (?, ?, ?, ?)",
+		projectID, number, encoding, data)
+	if err != nil {
+		return fmt.Errorf("failed to save raw JSON: %w", err)
+	}
+	return nil
+}
+
+// GetRawIssue returns the stored GitHub JSON of an issue exactly as received,
+// decompressing it if needed, or nil if none is stored
+func GetRawIssue(db *sql.DB, projectID int64, number int) ([]byte, error) {
+	var encoding string
+	var data []byte
+	err := db.QueryRow("SELECT encoding, raw FROM issue_raw WHERE project_id = ? AND number = ?",
+		projectID, number).Scan(&encoding, &data)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get raw JSON: %w", err)
+	}
+
+	switch encoding {
+	case RawEncodingJSON:
+		return data, nil
+	case RawEncodingGzip:
+		return decompressRaw(data)
+	default:
+		return nil, fmt.Errorf("unknown raw JSON encoding '%s'", encoding)
+	}
+}
+
+// compressRaw gzip-compresses raw JSON
+func compressRaw(raw []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(raw); err != nil {
+		return nil, fmt.Errorf("failed to compress raw JSON: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress raw JSON: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// decompressRaw reverses compressRaw
+func decompressRaw(data []byte) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress raw JSON: %w", err)
+	}
+	defer reader.Close()
+
+	raw, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress raw JSON: %w", err)
+	}
+	return raw, nil
+}
@@ -0,0 +1,139 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// largeRawIssue builds the JSON of an issue with a long, repetitive body like real issue templates
+func largeRawIssue() []byte {
+	body := strings.Repeat("## Steps to reproduce\\n1. Open the dashboard\\n2. Click refresh\\n", 500)
+	return []byte(fmt.Sprintf(`{"id": 101, "number": 1, "title": "Large", "state": "open", "body": "%s",
+  "user": {"login": "octocat", "id": 1}, "labels": [{"name": "bug", "color": "d73a4a"}]}`, body))
+}
+
+func TestSaveRawIssue_Compressed(t *testing.T) {
+	db := newTestMultiProjectDB(t)
+	projectID, err := CreateProject(db, &ProjectConfig{Owner: "owner", Repo: "repo"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	raw := largeRawIssue()
+
+	if err := SaveRawIssue(db, projectID, 1, raw, true); err != nil {
+		t.Fatalf("SaveRawIssue failed: %v", err)
+	}
+	if err := SaveRawIssue(db, projectID, 2, raw, false); err != nil {
+		t.Fatalf("SaveRawIssue failed: %v", err)
+	}
+
+	var compressedSize, plainSize int
+	var encoding string
+	if err := db.QueryRow("SELECT length(raw), encoding FROM issue_raw WHERE number = 1").Scan(&compressedSize, &encoding); err != nil {
+		t.Fatalf("Failed to read stored size: %v", err)
+	}
+	if err := db.QueryRow("SELECT length(raw) FROM issue_raw WHERE number = 2").Scan(&plainSize); err != nil {
+		t.Fatalf("Failed to read stored size: %v", err)
+	}
+	if encoding != RawEncodingGzip {
+		t.Errorf("Expected encoding %s, got %s", RawEncodingGzip, encoding)
+	}
+	if plainSize != len(raw) {
+		t.Errorf("Expected uncompressed size %d, got %d", len(raw), plainSize)
+	}
+	if compressedSize >= plainSize/10 {
+		t.Errorf("Expected compressed storage to be much smaller than %d bytes, got %d", plainSize, compressedSize)
+	}
+
+	for _, number := range []int{1, 2} {
+		got, err := GetRawIssue(db, projectID, number)
+		if err != nil {
+			t.Fatalf("GetRawIssue failed: %v", err)
+		}
+		if !bytes.Equal(got, raw) {
+			t.Errorf("Expected issue #%d to read back the exact original bytes", number)
+		}
+	}
+
+	missing, err := GetRawIssue(db, projectID, 3)
+	if err != nil || missing != nil {
+		t.Errorf("Expected nil for an issue without raw JSON, got %q (%v)", missing, err)
+	}
+}
+
+func TestGetRawIssue_UnknownEncoding(t *testing.T) {
+	db := newTestMultiProjectDB(t)
+	projectID, _ := CreateProject(db, &ProjectConfig{Owner: "owner", Repo: "repo"})
+	if _, err := db.Exec("INSERT INTO issue_raw (project_id, number, encoding, raw) VALUES (?, 1, 'zstd', 'x')", projectID); err != nil {
+		t.Fatalf("Failed to insert raw row: %v", err)
+	}
+
+	if _, err := GetRawIssue(db, projectID, 1); err == nil || !strings.Contains(err.Error(), "zstd") {
+		t.Errorf("Expected unknown encoding error, got %v", err)
+	}
+}
+
+func TestParseRawCompression(t *testing.T) {
+	tests := []struct {
+		value    string
+		compress bool
+		wantErr  bool
+	}{
+		{"", true, false},
+		{"gzip", true, false},
+		{"GZIP", true, false},
+		{"none", false, false},
+		{"zstd", false, true},
+	}
+	for _, tt := range tests {
+		compress, err := ParseRawCompression(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseRawCompression(%q): expected error %v, got %v", tt.value, tt.wantErr, err)
+		}
+		if compress != tt.compress {
+			t.Errorf("ParseRawCompression(%q): expected %v, got %v", tt.value, tt.compress, compress)
+		}
+	}
+}
+
+func TestSyncStoreRaw(t *testing.T) {
+	newMockGitHubServer(t, "owner", "repo", reactionsIssuesJSON, nil)
+	db := newTestMultiProjectDB(t)
+
+	project := &ProjectConfig{Owner: "owner", Repo: "repo"}
+	if _, err := syncProjectWithOptions(db, &GlobalConfig{Token: "test-token"}, project, SyncOptions{StoreRaw: true}); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	projectID, _ := getProjectID(db, "owner", "repo")
+	raw, err := GetRawIssue(db, projectID, 2)
+	if err != nil {
+		t.Fatalf("GetRawIssue failed: %v", err)
+	}
+	issues, _, _ := decodeIssues([]byte(reactionsIssuesJSON))
+	if !bytes.Equal(raw, issues[1].Raw) {
+		t.Errorf("Expected the issue JSON as received, got %s", raw)
+	}
+	if !strings.Contains(string(raw), `"Popular"`) {
+		t.Errorf("Expected raw JSON of issue #2, got %s", raw)
+	}
+}
+
+func TestSyncWithoutStoreRaw_DoesNotPersist(t *testing.T) {
+	newMockGitHubServer(t, "owner", "repo", reactionsIssuesJSON, nil)
+	db := newTestMultiProjectDB(t)
+
+	if _, err := syncProject(db, &GlobalConfig{Token: "test-token"}, &ProjectConfig{Owner: "owner", Repo: "repo"}); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM issue_raw").Scan(&count); err != nil {
+		t.Fatalf("Failed to count raw rows: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("Expected no raw JSON without --store-raw, got %d rows", count)
+	}
+}
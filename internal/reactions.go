@@ -0,0 +1,142 @@
+package internal
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+)
+
+// createReactionsTable creates the table holding per-issue reaction counts
+func createReactionsTable(db *sql.DB) error {
+	schema := `
+	CREATE TABLE IF NOT EXISTS issue_reactions (
+		project_id INTEGER NOT NULL,
+		number INTEGER NOT NULL,
+		total_count INTEGER DEFAULT 0,
+		plus_one INTEGER DEFAULT 0,
+		minus_one INTEGER DEFAULT 0,
+		laugh INTEGER DEFAULT 0,
+		hooray INTEGER DEFAULT 0,
+		confused INTEGER DEFAULT 0,
+		heart INTEGER DEFAULT 0,
+		rocket INTEGER DEFAULT 0,
+		eyes INTEGER DEFAULT 0,
+		PRIMARY KEY(project_id, number),
+		FOREIGN KEY(project_id) REFERENCES projects(id) ON DELETE CASCADE
+	);`
+
+	if _, err := db.Exec(schema); err != nil {
+		return fmt.Errorf("failed to create issue_reactions table: %w", err)
+	}
+	return nil
+}
+
+// SaveReactions stores the reaction counts for an issue, replacing any previous counts
+func SaveReactions(db *sql.DB, projectID int64, number int, reactions *Reactions) error {
+	query := `
+		INSERT OR REPLACE INTO issue_reactions
+		(project_id, number, total_count, plus_one, minus_one, laugh, hooray, confused, heart, rocket, eyes)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := db.Exec(query, projectID, number, reactions.TotalCount,
+		reactions.PlusOne, reactions.MinusOne, reactions.Laugh, reactions.Hooray,
+		reactions.Confused, reactions.Heart, reactions.Rocket, reactions.Eyes)
+	if err != nil {
+		return fmt.Errorf("failed to save reactions: %w", err)
+	}
+
+	return nil
+}
+
+// GetReactions retrieves the stored reaction counts for an issue, or nil if none are stored
+func GetReactions(db *sql.DB, projectID int64, number int) (*Reactions, error) {
+	query := `
+		SELECT total_count, plus_one, minus_one, laugh, hooray, confused, heart, rocket, eyes
+		FROM issue_reactions
+		WHERE project_id = ? AND number = ?
+	`
+
+	var r Reactions
+	err := db.QueryRow(query, projectID, number).Scan(&r.TotalCount, &r.PlusOne, &r.MinusOne,
+		&r.Laugh, &r.Hooray, &r.Confused, &r.Heart, &r.Rocket, &r.Eyes)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reactions: %w", err)
+	}
+
+	return &r, nil
+}
+
+// ReactedIssue is an open issue together with its stored reaction counts
+type ReactedIssue struct {
+	Owner     string
+	Repo      string
+	Number    int
+	Title     string
+	Reactions Reactions
+}
+
+// TopReactedIssues returns up to limit open issues with the most reactions, most reacted
+// first. Ties are broken by 👍 count and then by repository and issue number. Issues
+// without stored reactions are left out. A projectID of 0 considers all projects.
+func TopReactedIssues(db *sql.DB, projectID int64, limit int) ([]ReactedIssue, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("top reactions limit must be positive, got %d", limit)
+	}
+
+	query := `
+		SELECT p.owner, p.repo, i.number, i.title,
+			r.total_count, r.plus_one, r.minus_one, r.laugh, r.hooray, r.confused, r.heart, r.rocket, r.eyes
+		FROM issue_reactions r
+		JOIN issues i ON i.project_id = r.project_id AND i.number = r.number
+		JOIN projects p ON p.id = r.project_id
+		WHERE i.state = 'open' AND r.total_count > 0`
+	var args []interface{}
+	if projectID != 0 {
+		query += " AND r.project_id = ?"
+		args = append(args, projectID)
+	}
+	query += `
+		ORDER BY r.total_count DESC, r.plus_one DESC, p.owner, p.repo, i.number
+		LIMIT ?`
+	args = append(args, limit)
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query top reacted issues: %w", err)
+	}
+	defer rows.Close()
+
+	var issues []ReactedIssue
+	for rows.Next() {
+		var issue ReactedIssue
+		r := &issue.Reactions
+		if err := rows.Scan(&issue.Owner, &issue.Repo, &issue.Number, &issue.Title,
+			&r.TotalCount, &r.PlusOne, &r.MinusOne, &r.Laugh, &r.Hooray, &r.Confused, &r.Heart, &r.Rocket, &r.Eyes); err != nil {
+			return nil, fmt.Errorf("failed to scan reacted issue: %w", err)
+		}
+		issues = append(issues, issue)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read top reacted issues: %w", err)
+	}
+
+	return issues, nil
+}
+
+// PrintTopReactedIssues writes the top reacted open issues as a prioritization hint
+func PrintTopReactedIssues(w io.Writer, issues []ReactedIssue) {
+	if len(issues) == 0 {
+		fmt.Fprintln(w, "\n🔥 No open issues with reactions (sync with --with-reactions to store them)")
+		return
+	}
+
+	fmt.Fprintf(w, "\n🔥 Top %d most-reacted open issues\n", len(issues))
+	for i, issue := range issues {
+		fmt.Fprintf(w, "  %d. %s/%s#%d %s (%d reactions, %d 👍)\n", i+1, issue.Owner, issue.Repo,
+			issue.Number, issue.Title, issue.Reactions.TotalCount, issue.Reactions.PlusOne)
+	}
+}
@@ -0,0 +1,190 @@
+package internal
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const reactionsIssuesJSON = `[
+	{"id": 101, "number": 1, "title": "Few reactions", "state": "open",
+	 "reactions": {"total_count": 2, "+1": 1, "-1": 0, "laugh": 0, "hooray": 0, "confused": 0, "heart": 1, "rocket": 0, "eyes": 0}},
+	{"id": 102, "number": 2, "title": "Popular", "state": "open",
+	 "reactions": {"total_count": 9, "+1": 7, "-1": 1, "laugh": 0, "hooray": 0, "confused": 0, "heart": 0, "rocket": 1, "eyes": 0}},
+	{"id": 103, "number": 3, "title": "No reactions", "state": "open"}
+]`
+
+func TestDecodeIssues_Reactions(t *testing.T) {
+	issues, skipped, err := decodeIssues([]byte(reactionsIssuesJSON))
+	if err != nil || skipped != 0 {
+		t.Fatalf("Expected clean decode, got err=%v skipped=%d", err, skipped)
+	}
+
+	r := issues[1].Reactions
+	if r == nil {
+		t.Fatal("Expected reactions to be parsed")
+	}
+	if r.TotalCount != 9 || r.PlusOne != 7 || r.MinusOne != 1 || r.Rocket != 1 {
+		t.Errorf("Unexpected reaction counts: %+v", *r)
+	}
+	if issues[2].Reactions != nil {
+		t.Error("Expected nil reactions for issue without reactions object")
+	}
+}
+
+func TestSyncWithReactions_PersistsAndSorts(t *testing.T) {
+	newMockGitHubServer(t, "owner", "repo", reactionsIssuesJSON, nil)
+	db := newTestMultiProjectDB(t)
+
+	project := &ProjectConfig{Owner: "owner", Repo: "repo"}
+	_, err := syncProjectWithOptions(db, &GlobalConfig{Token: "test-token"}, project, SyncOptions{WithReactions: true})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	projectID, err := getProjectID(db, "owner", "repo")
+	if err != nil {
+		t.Fatalf("Failed to get project ID: %v", err)
+	}
+
+	reactions, err := GetReactions(db, projectID, 2)
+	if err != nil {
+		t.Fatalf("Failed to get reactions: %v", err)
+	}
+	if reactions == nil || reactions.PlusOne != 7 || reactions.TotalCount != 9 {
+		t.Errorf("Expected persisted reactions for issue #2, got %+v", reactions)
+	}
+
+	missing, err := GetReactions(db, projectID, 3)
+	if err != nil {
+		t.Fatalf("Failed to get reactions: %v", err)
+	}
+	if missing != nil {
+		t.Errorf("Expected no reactions for issue #3, got %+v", missing)
+	}
+
+	issues, err := ListIssues(db, ListOptions{Sort: "reactions", Desc: true})
+	if err != nil {
+		t.Fatalf("Failed to list issues: %v", err)
+	}
+	var order []int
+	for _, issue := range issues {
+		order = append(order, issue.Number)
+	}
+	if fmt.Sprint(order) != "[2 1 3]" {
+		t.Errorf("Expected reaction order [2 1 3], got %v", order)
+	}
+}
+
+func TestSyncWithoutReactions_DoesNotPersist(t *testing.T) {
+	newMockGitHubServer(t, "owner", "repo", reactionsIssuesJSON, nil)
+	db := newTestMultiProjectDB(t)
+
+	if _, err := syncProject(db, &GlobalConfig{Token: "test-token"}, &ProjectConfig{Owner: "owner", Repo: "repo"}); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	projectID, _ := getProjectID(db, "owner", "repo")
+	reactions, err := GetReactions(db, projectID, 2)
+	if err != nil {
+		t.Fatalf("Failed to get reactions: %v", err)
+	}
+	if reactions != nil {
+		t.Errorf("Expected reactions not to be stored without the option, got %+v", reactions)
+	}
+}
+
+// seedReactedIssue stores an issue together with its reaction counts
+func seedReactedIssue(t *testing.T, db *sql.DB, projectID int64, number int, state string, total, plusOne int) {
+	t.Helper()
+	issue := &DBIssue{ID: int(projectID)*1000 + number, Number: number, Title: fmt.Sprintf("Issue %d", number), State: state}
+	if err := SaveIssue(db, projectID, issue); err != nil {
+		t.Fatalf("Failed to save issue: %v", err)
+	}
+	if total == 0 {
+		return
+	}
+	if err := SaveReactions(db, projectID, number, &Reactions{TotalCount: total, PlusOne: plusOne}); err != nil {
+		t.Fatalf("Failed to save reactions: %v", err)
+	}
+}
+
+func TestTopReactedIssues_Ordering(t *testing.T) {
+	db := newTestMultiProjectDB(t)
+
+	alpha, err := CreateProject(db, &ProjectConfig{Owner: "org", Repo: "alpha"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	beta, err := CreateProject(db, &ProjectConfig{Owner: "org", Repo: "beta"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	seedReactedIssue(t, db, alpha, 1, "open", 3, 3)
+	seedReactedIssue(t, db, alpha, 2, "open", 12, 2)
+	seedReactedIssue(t, db, alpha, 3, "closed", 50, 50) // Closed issues are not a priority
+	seedReactedIssue(t, db, alpha, 4, "open", 0, 0)     // No reactions stored
+	seedReactedIssue(t, db, alpha, 5, "open", 7, 1)
+	seedReactedIssue(t, db, beta, 1, "open", 7, 6) // Same total as alpha#5, more 👍
+	seedReactedIssue(t, db, beta, 2, "open", 1, 0)
+
+	describe := func(issues []ReactedIssue) string {
+		var parts []string
+		for _, issue := range issues {
+			parts = append(parts, fmt.Sprintf("%s#%d", issue.Repo, issue.Number))
+		}
+		return strings.Join(parts, " ")
+	}
+
+	top, err := TopReactedIssues(db, 0, 3)
+	if err != nil {
+		t.Fatalf("Failed to get top reacted issues: %v", err)
+	}
+	if got := describe(top); got != "alpha#2 beta#1 alpha#5" {
+		t.Errorf("Expected top 3 'alpha#2 beta#1 alpha#5', got '%s'", got)
+	}
+	if top[0].Owner != "org" || top[0].Title != "Issue 2" || top[0].Reactions.TotalCount != 12 {
+		t.Errorf("Unexpected top issue: %+v", top[0])
+	}
+
+	all, err := TopReactedIssues(db, 0, 10)
+	if err != nil {
+		t.Fatalf("Failed to get top reacted issues: %v", err)
+	}
+	if got := describe(all); got != "alpha#2 beta#1 alpha#5 alpha#1 beta#2" {
+		t.Errorf("Expected all open reacted issues in order, got '%s'", got)
+	}
+
+	onlyBeta, err := TopReactedIssues(db, beta, 10)
+	if err != nil {
+		t.Fatalf("Failed to get top reacted issues: %v", err)
+	}
+	if got := describe(onlyBeta); got != "beta#1 beta#2" {
+		t.Errorf("Expected only beta issues, got '%s'", got)
+	}
+
+	if _, err := TopReactedIssues(db, 0, 0); err == nil {
+		t.Error("Expected error for a non-positive limit")
+	}
+}
+
+func TestPrintTopReactedIssues(t *testing.T) {
+	var out bytes.Buffer
+	PrintTopReactedIssues(&out, []ReactedIssue{
+		{Owner: "org", Repo: "alpha", Number: 2, Title: "Dark mode", Reactions: Reactions{TotalCount: 12, PlusOne: 9}},
+	})
+	for _, want := range []string{"Top 1 most-reacted open issues", "1. org/alpha#2 Dark mode (12 reactions, 9 👍)"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	PrintTopReactedIssues(&out, nil)
+	if !strings.Contains(out.String(), "--with-reactions") {
+		t.Errorf("Expected hint to sync with --with-reactions, got:\n%s", out.String())
+	}
+}
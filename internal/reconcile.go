@@ -0,0 +1,195 @@
+package internal
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RemoteCandidate is a synced issue that a local-only issue may duplicate
+type RemoteCandidate struct {
+	LocalID int64
+	Number  int
+	Title   string
+}
+
+// ReconcileMatch pairs an issue waiting to be pushed with the synced issues of the same
+// project that have a matching title
+type ReconcileMatch struct {
+	Local   LocalIssue
+	Remotes []RemoteCandidate
+}
+
+// FindReconcileMatches returns the issues waiting to be pushed that have a synced
+// duplicate in the same project, matched by title after normalization. Pushing them
+// would create the issue on GitHub a second time.
+func FindReconcileMatches(db *sql.DB, normalization TitleNormalization) ([]ReconcileMatch, error) {
+	locals, err := ListPushableIssues(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []ReconcileMatch
+	for _, local := range locals {
+		remotes, err := findRemoteCandidates(db, local, normalization)
+		if err != nil {
+			return nil, err
+		}
+		if len(remotes) > 0 {
+			matches = append(matches, ReconcileMatch{Local: local, Remotes: remotes})
+		}
+	}
+	return matches, nil
+}
+
+// findRemoteCandidates returns the synced issues of the local issue's project whose
+// title matches, ordered by issue number
+func findRemoteCandidates(db *sql.DB, local LocalIssue, normalization TitleNormalization) ([]RemoteCandidate, error) {
+	rows, err := db.Query(`
+		SELECT i.rowid, COALESCE(i.number, 0), COALESCE(i.title, '')
+		FROM issues i
+		JOIN projects p ON p.id = i.project_id
+		LEFT JOIN issue_sync_state s ON s.issue_local_id = i.rowid
+		WHERE p.owner = ? AND p.repo = ? AND i.github_id IS NOT NULL
+		  AND COALESCE(s.sync_state, ?) = ?
+		ORDER BY i.number`, local.Owner, local.Repo, string(SyncStateSynced), string(SyncStateSynced))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query synced issues: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []RemoteCandidate
+	for rows.Next() {
+		var candidate RemoteCandidate
+		if err := rows.Scan(&candidate.LocalID, &candidate.Number, &candidate.Title); err != nil {
+			return nil, fmt.Errorf("failed to scan synced issue: %w", err)
+		}
+		if normalization.Match(local.Issue.Title, candidate.Title) {
+			candidates = append(candidates, candidate)
+		}
+	}
+	return candidates, rows.Err()
+}
+
+// LinkLocalIssue links an issue waiting to be pushed to the synced issue with local ID
+// remoteID, so push no longer creates a duplicate. The local issue takes over the GitHub
+// ID, number and fields of the synced copy, which is removed, and becomes SYNCED.
+func LinkLocalIssue(db *sql.DB, localID, remoteID int64) error {
+	if localID == remoteID {
+		return fmt.Errorf("cannot link issue %d to itself", localID)
+	}
+	if err := InitSyncStateSchema(db); err != nil {
+		return err
+	}
+
+	var localState sql.NullString
+	var localProject int64
+	err := db.QueryRow(`
+		SELECT i.project_id, s.sync_state
+		FROM issues i
+		LEFT JOIN issue_sync_state s ON s.issue_local_id = i.rowid
+		WHERE i.rowid = ?`, localID).Scan(&localProject, &localState)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("local issue %d not found", localID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to look up local issue %d: %w", localID, err)
+	}
+	if state := SyncState(localState.String); state != SyncStateLocalOnly && state != SyncStatePushFailed {
+		return fmt.Errorf("local issue %d is not waiting to be pushed (state: %s)", localID, localState.String)
+	}
+
+	var remoteProject int64
+	var githubID sql.NullInt64
+	err = db.QueryRow("SELECT project_id, github_id FROM issues WHERE rowid = ?", remoteID).Scan(&remoteProject, &githubID)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("synced issue %d not found", remoteID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to look up synced issue %d: %w", remoteID, err)
+	}
+	if !githubID.Valid {
+		return fmt.Errorf("issue %d is not on GitHub", remoteID)
+	}
+	if remoteProject != localProject {
+		return fmt.Errorf("issues %d and %d belong to different projects", localID, remoteID)
+	}
+
+	columns, err := tableColumns(db, "issues")
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // #nosec G104 - No-op after a successful commit
+
+	// Read the synced copy before deleting it; github_id is part of the primary key,
+	// so the local row can only take it over once the copy is gone
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = fmt.Sprintf("%q", column)
+	}
+	values := make([]interface{}, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	// #nosec G201 - column names come from the schema
+	if err := tx.QueryRow(fmt.Sprintf("SELECT %s FROM issues WHERE rowid = ?", strings.Join(quoted, ", ")), remoteID).Scan(pointers...); err != nil {
+		return fmt.Errorf("failed to read synced issue %d: %w", remoteID, err)
+	}
+
+	if _, err := tx.Exec("DELETE FROM issue_sync_state WHERE issue_local_id = ?", remoteID); err != nil {
+		return fmt.Errorf("failed to remove sync state of issue %d: %w", remoteID, err)
+	}
+	if _, err := tx.Exec("DELETE FROM issues WHERE rowid = ?", remoteID); err != nil {
+		return fmt.Errorf("failed to remove duplicate issue %d: %w", remoteID, err)
+	}
+
+	assignments := make([]string, len(quoted))
+	for i, column := range quoted {
+		assignments[i] = column + " = ?"
+	}
+	// #nosec G201 - column names come from the schema
+	if _, err := tx.Exec(fmt.Sprintf("UPDATE issues SET %s WHERE rowid = ?", strings.Join(assignments, ", ")),
+		append(values, localID)...); err != nil {
+		return fmt.Errorf("failed to link local issue %d: %w", localID, err)
+	}
+	if _, err := tx.Exec(`
+		UPDATE issue_sync_state
+		SET sync_state = ?, github_id = ?, sync_error = NULL, updated_at = ?
+		WHERE issue_local_id = ?`,
+		string(SyncStateSynced), githubID.Int64, time.Now().Format(time.RFC3339), localID); err != nil {
+		return fmt.Errorf("failed to update sync state: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit link: %w", err)
+	}
+	return nil
+}
+
+// tableColumns returns the column names of a table in schema order
+func tableColumns(db *sql.DB, tableName string) ([]string, error) {
+	rows, err := db.Query("PRAGMA table_info(" + tableName + ")")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read columns of %s: %w", tableName, err)
+	}
+	defer rows.Close()
+
+	var columns []string
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, dataType string
+		var defaultValue interface{}
+		if err := rows.Scan(&cid, &name, &dataType, &notNull, &defaultValue, &pk); err != nil {
+			return nil, fmt.Errorf("failed to read columns of %s: %w", tableName, err)
+		}
+		columns = append(columns, name)
+	}
+	return columns, rows.Err()
+}
@@ -0,0 +1,167 @@
+package internal
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+)
+
+// seedSyncedIssue saves an issue as if it came from GitHub and returns its local ID
+func seedSyncedIssue(t *testing.T, db *sql.DB, projectID int64, githubID, number int, title string) int64 {
+	t.Helper()
+	if err := SaveIssue(db, projectID, &DBIssue{ID: githubID, Number: number, Title: title, Body: "From GitHub", State: "open"}); err != nil {
+		t.Fatalf("Failed to save synced issue: %v", err)
+	}
+	var localID int64
+	if err := db.QueryRow("SELECT rowid FROM issues WHERE github_id = ? AND project_id = ?", githubID, projectID).Scan(&localID); err != nil {
+		t.Fatalf("Failed to look up synced issue: %v", err)
+	}
+	id := int64(githubID)
+	if err := CreateSyncState(db, localID, SyncStateSynced, &id); err != nil {
+		t.Fatalf("Failed to create sync state: %v", err)
+	}
+	return localID
+}
+
+func TestReconcileLinksLocalIssueToRemoteDuplicate(t *testing.T) {
+	db, localID := newPushTestDB(t, "")
+	projectID, err := getProjectID(db, "org", "alpha")
+	if err != nil {
+		t.Fatalf("Failed to get project: %v", err)
+	}
+	remoteID := seedSyncedIssue(t, db, projectID, 9100, 17, "  ship IT ")
+	seedSyncedIssue(t, db, projectID, 9101, 18, "Something else")
+
+	matches, err := FindReconcileMatches(db, TitleNormalization{})
+	if err != nil {
+		t.Fatalf("FindReconcileMatches failed: %v", err)
+	}
+	if len(matches) != 1 || matches[0].Local.LocalID != localID {
+		t.Fatalf("Expected one match for local issue %d, got %+v", localID, matches)
+	}
+	if len(matches[0].Remotes) != 1 || matches[0].Remotes[0].Number != 17 {
+		t.Fatalf("Expected candidate #17, got %+v", matches[0].Remotes)
+	}
+
+	if err := LinkLocalIssue(db, localID, remoteID); err != nil {
+		t.Fatalf("LinkLocalIssue failed: %v", err)
+	}
+
+	var githubID sql.NullInt64
+	var number int
+	var body string
+	var modified sql.NullString
+	if err := db.QueryRow("SELECT github_id, number, body, local_modified_at FROM issues WHERE rowid = ?", localID).
+		Scan(&githubID, &number, &body, &modified); err != nil {
+		t.Fatalf("Failed to read linked issue: %v", err)
+	}
+	if githubID.Int64 != 9100 || number != 17 {
+		t.Errorf("Expected github_id 9100 and number 17, got %v and %d", githubID, number)
+	}
+	if body != "From GitHub" {
+		t.Errorf("Expected the GitHub fields, got body %q", body)
+	}
+	if modified.Valid {
+		t.Errorf("Expected no pending local modification, got %s", modified.String)
+	}
+
+	state, err := GetSyncState(db, localID)
+	if err != nil {
+		t.Fatalf("GetSyncState failed: %v", err)
+	}
+	if state.SyncState != SyncStateSynced || state.GitHubID == nil || *state.GitHubID != 9100 {
+		t.Errorf("Expected SYNCED with github_id 9100, got %+v", state)
+	}
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM issues WHERE github_id = 9100").Scan(&count); err != nil {
+		t.Fatalf("Failed to count issues: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected the synced copy to be removed, got %d rows for github_id 9100", count)
+	}
+	if state, _ := GetSyncState(db, remoteID); state != nil {
+		t.Errorf("Expected the sync state of the synced copy to be removed, got %+v", state)
+	}
+
+	pushable, err := ListPushableIssues(db)
+	if err != nil {
+		t.Fatalf("ListPushableIssues failed: %v", err)
+	}
+	if len(pushable) != 0 {
+		t.Errorf("Expected nothing left to push, got %+v", pushable)
+	}
+}
+
+func TestReconcileIgnoresOtherProjectsAndLocalEdits(t *testing.T) {
+	db, _ := newPushTestDB(t, "")
+	otherID, err := CreateProject(db, &ProjectConfig{Owner: "org", Repo: "beta"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	seedSyncedIssue(t, db, otherID, 9200, 3, "Ship it")
+
+	projectID, _ := getProjectID(db, "org", "alpha")
+	editedID := seedSyncedIssue(t, db, projectID, 9201, 4, "Ship it")
+	if err := UpdateSyncState(db, editedID, SyncStateLocalModified, nil, nil); err != nil {
+		t.Fatalf("Failed to update sync state: %v", err)
+	}
+
+	matches, err := FindReconcileMatches(db, TitleNormalization{})
+	if err != nil {
+		t.Fatalf("FindReconcileMatches failed: %v", err)
+	}
+	if len(matches) != 0 {
+		t.Errorf("Expected no matches, got %+v", matches)
+	}
+}
+
+func TestLinkLocalIssueErrors(t *testing.T) {
+	db, localID := newPushTestDB(t, "")
+	projectID, _ := getProjectID(db, "org", "alpha")
+	remoteID := seedSyncedIssue(t, db, projectID, 9300, 5, "Ship it")
+	otherID, _ := CreateProject(db, &ProjectConfig{Owner: "org", Repo: "beta"})
+	foreignID := seedSyncedIssue(t, db, otherID, 9301, 6, "Ship it")
+
+	tests := []struct {
+		name     string
+		localID  int64
+		remoteID int64
+		want     string
+	}{
+		{name: "synced issue as local", localID: remoteID, remoteID: localID, want: "not waiting to be pushed"},
+		{name: "other project", localID: localID, remoteID: foreignID, want: "different projects"},
+		{name: "missing remote", localID: localID, remoteID: 999, want: "not found"},
+		{name: "itself", localID: localID, remoteID: localID, want: "itself"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := LinkLocalIssue(db, tt.localID, tt.remoteID)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestReconcileUsesTitleNormalization(t *testing.T) {
+	db, localID := newPushTestDB(t, "")
+	projectID, _ := getProjectID(db, "org", "alpha")
+	seedSyncedIssue(t, db, projectID, 9400, 8, "[BUG]  Ship   it!")
+
+	matches, err := FindReconcileMatches(db, TitleNormalization{})
+	if err != nil {
+		t.Fatalf("FindReconcileMatches failed: %v", err)
+	}
+	if len(matches) != 0 {
+		t.Errorf("Expected no match without prefix stripping, got %+v", matches)
+	}
+
+	matches, err = FindReconcileMatches(db, TitleNormalization{StripPrefixes: []string{"[bug]"}})
+	if err != nil {
+		t.Fatalf("FindReconcileMatches failed: %v", err)
+	}
+	if len(matches) != 1 || matches[0].Local.LocalID != localID || matches[0].Remotes[0].Number != 8 {
+		t.Errorf("Expected local issue %d to match #8, got %+v", localID, matches)
+	}
+}
@@ -0,0 +1,130 @@
+package internal
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// ResolveRepositoryRedirect returns the current owner and name of a repository,
+// following GitHub's 301 redirect for renamed or transferred repositories. When
+// the repository has not moved, the original coordinates are returned.
+func ResolveRepositoryRedirect(owner, repo, token string) (string, string, error) {
+	client := newHTTPClient()
+	// Inspect redirects ourselves instead of following them silently
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/%s", githubAPIURL, owner, repo)
+	resp, err := getWithToken(client, url, token)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to query repository: %w", err)
+	}
+	// Close the first response before following the redirect: it holds one of the
+	// request limiter's slots until then
+	resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return owner, repo, nil
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		// Handled below
+	default:
+		return "", "", fmt.Errorf("unexpected status code %d for repository %s/%s", resp.StatusCode, owner, repo)
+	}
+
+	location := resp.Header.Get("Location")
+	if location == "" {
+		return "", "", fmt.Errorf("repository %s/%s redirected without a Location header", owner, repo)
+	}
+
+	redirected, err := getWithToken(newHTTPClient(), location, token)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to follow repository redirect: %w", err)
+	}
+	defer redirected.Body.Close()
+
+	if redirected.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("unexpected status code %d following redirect for %s/%s", redirected.StatusCode, owner, repo)
+	}
+
+	var moved struct {
+		Name  string `json:"name"`
+		Owner struct {
+			Login string `json:"login"`
+		} `json:"owner"`
+	}
+	if err := json.NewDecoder(redirected.Body).Decode(&moved); err != nil {
+		return "", "", fmt.Errorf("failed to unmarshal redirected repository: %w", err)
+	}
+	if moved.Name == "" || moved.Owner.Login == "" {
+		return "", "", fmt.Errorf("redirected repository response is missing owner or name")
+	}
+
+	return moved.Owner.Login, moved.Name, nil
+}
+
+// getWithToken performs an authenticated GET request against the GitHub API
+func getWithToken(client *http.Client, url, token string) (*http.Response, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "token "+token)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	return client.Do(req)
+}
+
+// RenameProject moves a project's database record to new owner/repo coordinates
+func RenameProject(db *sql.DB, oldOwner, oldRepo, newOwner, newRepo string) error {
+	query := `UPDATE projects SET owner = ?, repo = ?, updated_at = CURRENT_TIMESTAMP WHERE owner = ? AND repo = ?`
+	if _, err := db.Exec(query, newOwner, newRepo, oldOwner, oldRepo); err != nil {
+		return fmt.Errorf("failed to rename project: %w", err)
+	}
+	return nil
+}
+
+// UpdateProjectCoordinates rewrites a project's owner/repo in config.yml
+func UpdateProjectCoordinates(oldOwner, oldRepo, newOwner, newRepo string) error {
+	return UpdateMultiProjectConfig(func(config *MultiProjectConfig) error {
+		found := false
+		for i := range config.Projects {
+			if config.Projects[i].Owner == oldOwner && config.Projects[i].Repo == oldRepo {
+				config.Projects[i].Owner = newOwner
+				config.Projects[i].Repo = newRepo
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("project %s/%s not found in configuration", oldOwner, oldRepo)
+		}
+		return nil
+	})
+}
+
+// offerRepositoryMove asks confirm whether to record a moved repository's new
+// coordinates in the configuration and database, reporting whether the project was
+// updated. Without confirm the move is only reported.
+func offerRepositoryMove(db *sql.DB, project *ProjectConfig, newOwner, newRepo string, confirm func(project *ProjectConfig, newOwner, newRepo string) bool) bool {
+	if confirm == nil {
+		fmt.Printf("  Update the project in config.yml to %s/%s, or run 'pivot sync' in a terminal to be asked\n", newOwner, newRepo)
+		return false
+	}
+	if !confirm(project, newOwner, newRepo) {
+		return false
+	}
+
+	if err := UpdateProjectCoordinates(project.Owner, project.Repo, newOwner, newRepo); err != nil {
+		fmt.Printf("⚠ Could not update configuration: %v\n", ScrubError(err))
+		return false
+	}
+	if err := RenameProject(db, project.Owner, project.Repo, newOwner, newRepo); err != nil {
+		fmt.Printf("⚠ Could not update database project: %v\n", err)
+		return false
+	}
+
+	fmt.Printf("✓ Project updated to %s/%s\n", newOwner, newRepo)
+	return true
+}
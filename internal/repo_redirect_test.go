@@ -0,0 +1,162 @@
+package internal
+
+import (
+	"net/http"
+	"os"
+	"testing"
+)
+
+func TestResolveRepositoryRedirect_Moved(t *testing.T) {
+	newMockGitHubServer(t, "new-owner", "new-repo", `[{"id": 1, "number": 1, "title": "Moved issue", "state": "open"}]`, map[string]http.HandlerFunc{
+		"/repos/old-owner/old-repo": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Location", githubAPIURL+"/repositories/42")
+			w.WriteHeader(http.StatusMovedPermanently)
+		},
+		"/repositories/42": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"id": 42, "name": "new-repo", "full_name": "new-owner/new-repo", "owner": {"login": "new-owner"}}`))
+		},
+	})
+
+	owner, repo, err := ResolveRepositoryRedirect("old-owner", "old-repo", "test-token")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if owner != "new-owner" || repo != "new-repo" {
+		t.Errorf("Expected new-owner/new-repo, got %s/%s", owner, repo)
+	}
+}
+
+func TestResolveRepositoryRedirect_NotMoved(t *testing.T) {
+	newMockGitHubServer(t, "owner", "repo", `[]`, nil)
+
+	owner, repo, err := ResolveRepositoryRedirect("owner", "repo", "test-token")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if owner != "owner" || repo != "repo" {
+		t.Errorf("Expected unchanged coordinates, got %s/%s", owner, repo)
+	}
+}
+
+func TestResolveRepositoryRedirect_MissingLocation(t *testing.T) {
+	newMockGitHubServer(t, "owner", "repo", `[]`, map[string]http.HandlerFunc{
+		"/repos/owner/repo": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusMovedPermanently)
+		},
+	})
+
+	if _, _, err := ResolveRepositoryRedirect("owner", "repo", "test-token"); err == nil {
+		t.Error("Expected error for redirect without Location header")
+	}
+}
+
+func TestRenameProject(t *testing.T) {
+	db := newTestMultiProjectDB(t)
+
+	if _, err := CreateProject(db, &ProjectConfig{Owner: "old-owner", Repo: "old-repo"}); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	if err := RenameProject(db, "old-owner", "old-repo", "new-owner", "new-repo"); err != nil {
+		t.Fatalf("RenameProject failed: %v", err)
+	}
+	if _, err := FindProjectByOwnerRepo(db, "new-owner", "new-repo"); err != nil {
+		t.Errorf("Expected project under new coordinates: %v", err)
+	}
+	if _, err := FindProjectByOwnerRepo(db, "old-owner", "old-repo"); err == nil {
+		t.Error("Expected old coordinates to be gone")
+	}
+}
+
+// movedRepositoryServer serves old-owner/old-repo as moved to new-owner/new-repo
+func movedRepositoryServer(t *testing.T) {
+	t.Helper()
+	newMockGitHubServer(t, "new-owner", "new-repo", `[{"id": 1, "number": 1, "title": "Moved issue", "state": "open"}]`, map[string]http.HandlerFunc{
+		"/repos/old-owner/old-repo": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Location", githubAPIURL+"/repositories/42")
+			w.WriteHeader(http.StatusMovedPermanently)
+		},
+		"/repositories/42": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"id": 42, "name": "new-repo", "full_name": "new-owner/new-repo", "owner": {"login": "new-owner"}}`))
+		},
+	})
+}
+
+const movedRepositoryConfig = `global:
+  database: ./pivot.db
+  token: test-token
+projects:
+  - owner: old-owner
+    repo: old-repo
+`
+
+func TestSyncMovedRepository_OnlyWarnsWithoutConfirmation(t *testing.T) {
+	chdirTemp(t)
+	movedRepositoryServer(t)
+	writeConfigFixture(t, "config.yml", movedRepositoryConfig)
+
+	// Sync must not wait for an answer on stdin
+	oldStdin := os.Stdin
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	os.Stdin = r
+	t.Cleanup(func() { os.Stdin = oldStdin; w.Close(); r.Close() })
+
+	result, err := SyncMultiProjectWithOptions("", SyncOptions{})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if result.Totals().Created != 1 {
+		t.Errorf("Expected the issue to be synced from the new location, got %+v", result.Totals())
+	}
+	if data, _ := os.ReadFile("config.yml"); string(data) != movedRepositoryConfig {
+		t.Errorf("Expected config.yml to be left alone, got:\n%s", data)
+	}
+}
+
+func TestSyncMovedRepository_Confirmed(t *testing.T) {
+	chdirTemp(t)
+	movedRepositoryServer(t)
+	writeConfigFixture(t, "config.yml", movedRepositoryConfig)
+
+	var asked []string
+	confirm := func(project *ProjectConfig, newOwner, newRepo string) bool {
+		asked = append(asked, project.Owner+"/"+project.Repo+" -> "+newOwner+"/"+newRepo)
+		return true
+	}
+	if _, err := SyncMultiProjectWithOptions("", SyncOptions{ConfirmRepositoryMove: confirm}); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if len(asked) != 1 || asked[0] != "old-owner/old-repo -> new-owner/new-repo" {
+		t.Errorf("Expected to be asked once about the move, got %v", asked)
+	}
+	config, err := LoadMultiProjectConfig()
+	if err != nil {
+		t.Fatalf("LoadMultiProjectConfig failed: %v", err)
+	}
+	if config.Projects[0].Owner != "new-owner" || config.Projects[0].Repo != "new-repo" {
+		t.Errorf("Expected the project to be updated in config.yml, got %+v", config.Projects[0])
+	}
+}
+
+func TestSyncAdHocMovedRepository_NeverWritesConfig(t *testing.T) {
+	chdirTemp(t)
+	movedRepositoryServer(t)
+
+	config, err := NewAdHocConfig("old-owner/old-repo", "test-token")
+	if err != nil {
+		t.Fatalf("NewAdHocConfig failed: %v", err)
+	}
+	config.Global.Database = "./adhoc.db"
+	confirm := func(project *ProjectConfig, newOwner, newRepo string) bool {
+		t.Error("Expected an ad-hoc sync not to offer updating config.yml")
+		return true
+	}
+	if _, err := SyncAdHoc(config, SyncOptions{ConfirmRepositoryMove: confirm}); err != nil {
+		t.Fatalf("SyncAdHoc failed: %v", err)
+	}
+	if _, err := os.Stat("config.yml"); !os.IsNotExist(err) {
+		t.Errorf("Expected no config.yml to be written, got %v", err)
+	}
+}
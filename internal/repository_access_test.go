@@ -0,0 +1,74 @@
+package internal
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestValidateRepositoryAccess_TokenRestrictedFromPublicRepo(t *testing.T) {
+	newMockGitHubServer(t, "org", "other", "[]", map[string]http.HandlerFunc{
+		"/repos/org/repo": func(w http.ResponseWriter, r *http.Request) {
+			// Fine-grained PATs without access get 404; anonymous reads succeed
+			if r.Header.Get("Authorization") != "" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte(`{"private": false}`))
+		},
+	})
+
+	err := ValidateRepositoryAccess("org", "repo", "github_pat_restricted")
+
+	var credErr *GitHubCredentialError
+	if !errors.As(err, &credErr) {
+		t.Fatalf("Expected GitHubCredentialError, got %v", err)
+	}
+	if credErr.StatusCode != 404 {
+		t.Errorf("Expected status code 404, got %d", credErr.StatusCode)
+	}
+	if !strings.Contains(credErr.Message, "exists but your token cannot access it") {
+		t.Errorf("Expected message about restricted token, got: %s", credErr.Message)
+	}
+	if !strings.Contains(credErr.Suggestion, "fine-grained personal access token") {
+		t.Errorf("Expected fine-grained PAT guidance, got: %s", credErr.Suggestion)
+	}
+	if !strings.Contains(credErr.Suggestion, fineGrainedTokenSettingsURL) {
+		t.Errorf("Expected settings URL in suggestion, got: %s", credErr.Suggestion)
+	}
+}
+
+func TestValidateRepositoryAccess_FineGrainedTokenPrivateOrMissingRepo(t *testing.T) {
+	newMockGitHubServer(t, "org", "other", "[]", nil)
+
+	err := ValidateRepositoryAccess("org", "repo", "github_pat_restricted")
+
+	var credErr *GitHubCredentialError
+	if !errors.As(err, &credErr) {
+		t.Fatalf("Expected GitHubCredentialError, got %v", err)
+	}
+	if !strings.Contains(credErr.Message, "not found or not accessible") {
+		t.Errorf("Expected not found message, got: %s", credErr.Message)
+	}
+	if !strings.Contains(credErr.Suggestion, "Repository access") {
+		t.Errorf("Expected repository access guidance, got: %s", credErr.Suggestion)
+	}
+}
+
+func TestValidateRepositoryAccess_ClassicTokenMissingRepo(t *testing.T) {
+	newMockGitHubServer(t, "org", "other", "[]", nil)
+
+	err := ValidateRepositoryAccess("org", "repo", "ghp_classic")
+
+	var credErr *GitHubCredentialError
+	if !errors.As(err, &credErr) {
+		t.Fatalf("Expected GitHubCredentialError, got %v", err)
+	}
+	if !strings.Contains(credErr.Suggestion, "check the repository name") {
+		t.Errorf("Expected repository name guidance, got: %s", credErr.Suggestion)
+	}
+	if strings.Contains(credErr.Suggestion, "fine-grained") {
+		t.Errorf("Expected no fine-grained guidance for classic token, got: %s", credErr.Suggestion)
+	}
+}
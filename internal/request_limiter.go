@@ -0,0 +1,202 @@
+package internal
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RequestLimiter paces GitHub requests with a token bucket and bounds how many are in
+// flight at once. One limiter is shared by every client, so sync, import and push draw
+// on the same budget.
+type RequestLimiter struct {
+	rate  float64       // Requests per second (0 = unpaced)
+	burst float64       // Requests that may start back to back after an idle period
+	slots chan struct{} // One per request in flight (nil = unbounded)
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+
+	// Clock; tests replace it
+	now   func() time.Time
+	sleep func(time.Duration)
+}
+
+// NewRequestLimiter creates a limiter allowing requestsPerSecond requests per second and
+// at most maxConcurrency requests in flight. Zero disables the respective limit. The
+// bucket holds one second's worth of requests, at least one.
+func NewRequestLimiter(requestsPerSecond float64, maxConcurrency int) *RequestLimiter {
+	limiter := &RequestLimiter{
+		rate:  requestsPerSecond,
+		burst: math.Max(1, math.Ceil(requestsPerSecond)),
+		now:   time.Now,
+		sleep: time.Sleep,
+	}
+	limiter.tokens = limiter.burst
+	if maxConcurrency > 0 {
+		limiter.slots = make(chan struct{}, maxConcurrency)
+	}
+	return limiter
+}
+
+// Acquire waits until a request may start and returns the function to call once it
+// has finished
+func (l *RequestLimiter) Acquire() (release func()) {
+	if l.slots != nil {
+		l.slots <- struct{}{}
+	}
+	if wait := l.reserve(); wait > 0 {
+		l.sleep(wait)
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			if l.slots != nil {
+				<-l.slots
+			}
+		})
+	}
+}
+
+// reserve takes a token from the bucket and returns how long to wait until it is
+// available. Reservations queue up, so concurrent callers are spaced 1/rate apart.
+func (l *RequestLimiter) reserve() time.Duration {
+	if l.rate <= 0 {
+		return 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if !l.last.IsZero() && now.After(l.last) {
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	if now.After(l.last) {
+		l.last = now
+	}
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// limitedTransport holds every request to the limiter until its response body is closed
+type limitedTransport struct {
+	base    http.RoundTripper
+	limiter *RequestLimiter
+}
+
+// RoundTrip waits for the limiter, then sends the request with the base transport
+func (t *limitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	release := t.limiter.Acquire()
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// releasingBody frees the request's concurrency slot when the body is closed
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+// Close closes the body and releases the slot
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
+
+// requestLimits are the pacing settings of GitHub requests (0 = unlimited)
+type requestLimits struct {
+	requestsPerSecond float64
+	maxConcurrency    int
+}
+
+var (
+	// requestLimitsMu guards the limits and the limiter: commands install them while
+	// clients are created on other goroutines
+	requestLimitsMu sync.Mutex
+
+	configuredRequestLimits requestLimits   // From the sync settings of the config
+	overriddenRequestLimits requestLimits   // From --rate and --concurrency, taking precedence
+	requestLimiter          *RequestLimiter // Shared by all clients (nil = unlimited)
+)
+
+// SetRequestLimits overrides the configured request rate and concurrency for this run,
+// e.g. from the --rate and --concurrency flags. Zero keeps the configured value.
+func SetRequestLimits(requestsPerSecond float64, maxConcurrency int) error {
+	if err := validateRequestLimits(requestsPerSecond, maxConcurrency); err != nil {
+		return err
+	}
+	requestLimitsMu.Lock()
+	defer requestLimitsMu.Unlock()
+	overriddenRequestLimits = requestLimits{requestsPerSecond: requestsPerSecond, maxConcurrency: maxConcurrency}
+	installRequestLimiter()
+	return nil
+}
+
+// setConfiguredRequestLimits installs the limits of the sync settings
+func setConfiguredRequestLimits(settings SyncSettings) error {
+	if err := validateRequestLimits(settings.RequestsPerSecond, settings.MaxConcurrency); err != nil {
+		return fmt.Errorf("invalid sync settings: %w", err)
+	}
+	requestLimitsMu.Lock()
+	defer requestLimitsMu.Unlock()
+	configuredRequestLimits = requestLimits{requestsPerSecond: settings.RequestsPerSecond, maxConcurrency: settings.MaxConcurrency}
+	installRequestLimiter()
+	return nil
+}
+
+// validateRequestLimits rejects negative limits
+func validateRequestLimits(requestsPerSecond float64, maxConcurrency int) error {
+	if requestsPerSecond < 0 {
+		return fmt.Errorf("requests per second must not be negative, got %g", requestsPerSecond)
+	}
+	if maxConcurrency < 0 {
+		return fmt.Errorf("concurrency must not be negative, got %d", maxConcurrency)
+	}
+	return nil
+}
+
+// currentRequestLimiter returns the shared limiter (nil = unlimited)
+func currentRequestLimiter() *RequestLimiter {
+	requestLimitsMu.Lock()
+	defer requestLimitsMu.Unlock()
+	return requestLimiter
+}
+
+// installRequestLimiter replaces the shared limiter with one for the effective limits.
+// The caller holds requestLimitsMu.
+func installRequestLimiter() {
+	limits := configuredRequestLimits
+	if overriddenRequestLimits.requestsPerSecond > 0 {
+		limits.requestsPerSecond = overriddenRequestLimits.requestsPerSecond
+	}
+	if overriddenRequestLimits.maxConcurrency > 0 {
+		limits.maxConcurrency = overriddenRequestLimits.maxConcurrency
+	}
+
+	if current := requestLimiter; current != nil &&
+		current.rate == limits.requestsPerSecond && cap(current.slots) == limits.maxConcurrency {
+		// Keep the bucket and the requests in flight of an unchanged limiter
+		return
+	}
+	if limits.requestsPerSecond == 0 && limits.maxConcurrency == 0 {
+		requestLimiter = nil
+		return
+	}
+	requestLimiter = NewRequestLimiter(limits.requestsPerSecond, limits.maxConcurrency)
+}
@@ -0,0 +1,289 @@
+package internal
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeLimiterClock lets a limiter sleep without waiting: sleeping advances the clock
+type fakeLimiterClock struct {
+	now   time.Time
+	slept time.Duration
+}
+
+// install makes limiter use the fake clock
+func (c *fakeLimiterClock) install(limiter *RequestLimiter) {
+	limiter.now = func() time.Time { return c.now }
+	limiter.sleep = func(d time.Duration) {
+		c.now = c.now.Add(d)
+		c.slept += d
+	}
+}
+
+// resetRequestLimits restores unlimited requests after a test
+func resetRequestLimits(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() {
+		configuredRequestLimits = requestLimits{}
+		overriddenRequestLimits = requestLimits{}
+		requestLimiter = nil
+	})
+}
+
+func TestRequestLimiter_CapsRate(t *testing.T) {
+	limiter := NewRequestLimiter(5, 0)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := &fakeLimiterClock{now: start}
+	clock.install(limiter)
+
+	var started []time.Time
+	for i := 0; i < 20; i++ {
+		release := limiter.Acquire()
+		started = append(started, clock.now)
+		release()
+	}
+
+	// A full bucket of 5 starts at once, the other 15 follow at 5 per second
+	if elapsed := clock.now.Sub(start); elapsed != 3*time.Second {
+		t.Errorf("Expected 20 requests at 5/s with a burst of 5 to take 3s, took %v", elapsed)
+	}
+	for i, at := range started {
+		inWindow := 0
+		for _, other := range started[i:] {
+			if other.Sub(at) < time.Second {
+				inWindow++
+			}
+		}
+		if inWindow > 10 {
+			t.Errorf("Expected at most 10 requests (rate plus burst) in the second after %v, got %d", at.Sub(start), inWindow)
+		}
+	}
+	for i := 6; i < len(started); i++ {
+		if gap := started[i].Sub(started[i-1]); gap != 200*time.Millisecond {
+			t.Errorf("Expected request %d to start 200ms after the previous one, got %v", i+1, gap)
+		}
+	}
+
+	// After an idle period the bucket refills, but never beyond the burst
+	clock.now = clock.now.Add(time.Minute)
+	clock.slept = 0
+	for i := 0; i < 6; i++ {
+		limiter.Acquire()()
+	}
+	if clock.slept != 200*time.Millisecond {
+		t.Errorf("Expected only the 6th request after a pause to wait 200ms, waited %v", clock.slept)
+	}
+}
+
+func TestRequestLimiter_Unpaced(t *testing.T) {
+	limiter := NewRequestLimiter(0, 0)
+	clock := &fakeLimiterClock{now: time.Now()}
+	clock.install(limiter)
+
+	for i := 0; i < 100; i++ {
+		limiter.Acquire()()
+	}
+	if clock.slept != 0 {
+		t.Errorf("Expected an unpaced limiter not to wait, waited %v", clock.slept)
+	}
+}
+
+func TestRequestLimiter_CapsConcurrency(t *testing.T) {
+	limiter := NewRequestLimiter(0, 2)
+	first := limiter.Acquire()
+	second := limiter.Acquire()
+
+	acquired := make(chan func())
+	go func() { acquired <- limiter.Acquire() }()
+
+	select {
+	case <-acquired:
+		t.Fatal("Expected a third request to wait while two are in flight")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	first()
+	first() // Releasing twice must not free a second slot
+	select {
+	case third := <-acquired:
+		third()
+	case <-time.After(time.Second):
+		t.Fatal("Expected the third request to start once one finished")
+	}
+	second()
+}
+
+func TestRequestLimits_SharedByClients(t *testing.T) {
+	resetRequestLimits(t)
+
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	if err := SetRequestLimits(0, 1); err != nil {
+		t.Fatalf("SetRequestLimits failed: %v", err)
+	}
+
+	// Separate clients, as separate operations create, still share the one slot
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := newHTTPClient().Get(server.URL)
+			if err != nil {
+				t.Errorf("Request failed: %v", err)
+				return
+			}
+			_, _ = io.ReadAll(resp.Body)
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight != 1 {
+		t.Errorf("Expected at most 1 request in flight, got %d", maxInFlight)
+	}
+}
+
+func TestRequestLimits_Precedence(t *testing.T) {
+	resetRequestLimits(t)
+
+	if _, ok := newHTTPClient().Transport.(*http.Transport); !ok {
+		t.Error("Expected a plain transport without limits")
+	}
+
+	if err := setConfiguredRequestLimits(SyncSettings{RequestsPerSecond: 10, MaxConcurrency: 3}); err != nil {
+		t.Fatalf("setConfiguredRequestLimits failed: %v", err)
+	}
+	if requestLimiter == nil || requestLimiter.rate != 10 || cap(requestLimiter.slots) != 3 {
+		t.Fatalf("Expected the configured limits, got %+v", requestLimiter)
+	}
+	if _, ok := newHTTPClient().Transport.(*limitedTransport); !ok {
+		t.Error("Expected clients to use the limiter")
+	}
+	configured := requestLimiter
+
+	// Reloading the same settings keeps the limiter and its bucket
+	if err := setConfiguredRequestLimits(SyncSettings{RequestsPerSecond: 10, MaxConcurrency: 3}); err != nil {
+		t.Fatalf("setConfiguredRequestLimits failed: %v", err)
+	}
+	if requestLimiter != configured {
+		t.Error("Expected an unchanged configuration to keep the limiter")
+	}
+
+	// Flags override the config, field by field, also when the config is loaded later
+	if err := SetRequestLimits(2, 0); err != nil {
+		t.Fatalf("SetRequestLimits failed: %v", err)
+	}
+	if err := setConfiguredRequestLimits(SyncSettings{RequestsPerSecond: 10, MaxConcurrency: 3}); err != nil {
+		t.Fatalf("setConfiguredRequestLimits failed: %v", err)
+	}
+	if requestLimiter.rate != 2 || cap(requestLimiter.slots) != 3 {
+		t.Errorf("Expected rate 2 from the flag and concurrency 3 from the config, got rate %g and concurrency %d",
+			requestLimiter.rate, cap(requestLimiter.slots))
+	}
+
+	if err := SetRequestLimits(-1, 0); err == nil {
+		t.Error("Expected a negative rate to be rejected")
+	}
+	if err := setConfiguredRequestLimits(SyncSettings{MaxConcurrency: -2}); err == nil {
+		t.Error("Expected a negative concurrency to be rejected")
+	}
+
+	if err := SetRequestLimits(0, 0); err != nil {
+		t.Fatalf("SetRequestLimits failed: %v", err)
+	}
+	if err := setConfiguredRequestLimits(SyncSettings{}); err != nil {
+		t.Fatalf("setConfiguredRequestLimits failed: %v", err)
+	}
+	if requestLimiter != nil {
+		t.Errorf("Expected no limiter without limits, got %+v", requestLimiter)
+	}
+}
+
+// TestRequestLimits_ConcurrentInstall runs commands' limit updates alongside client
+// creation; run with -race to check the shared state is guarded
+func TestRequestLimits_ConcurrentInstall(t *testing.T) {
+	resetRequestLimits(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			if err := SetRequestLimits(float64(i%2), i%3); err != nil {
+				t.Errorf("SetRequestLimits failed: %v", err)
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			if newHTTPClient().Transport == nil {
+				t.Error("Expected a transport")
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestRequestLimits_SingleSlotFollowUpRequests(t *testing.T) {
+	resetRequestLimits(t)
+	newMockGitHubServer(t, "new-owner", "new-repo", `[]`, map[string]http.HandlerFunc{
+		"/repos/old-owner/old-repo": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Location", githubAPIURL+"/repositories/42")
+			w.WriteHeader(http.StatusMovedPermanently)
+		},
+		"/repositories/42": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"id": 42, "name": "new-repo", "owner": {"login": "new-owner"}}`))
+		},
+		"/repos/org/hidden": func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte(`{"private": false}`))
+		},
+	})
+	if err := SetRequestLimits(0, 1); err != nil {
+		t.Fatalf("SetRequestLimits failed: %v", err)
+	}
+
+	// Both send a second request after the first; with one slot they hang unless
+	// the first response is closed before
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if owner, repo, err := ResolveRepositoryRedirect("old-owner", "old-repo", "test-token"); err != nil || owner != "new-owner" || repo != "new-repo" {
+			t.Errorf("Expected new-owner/new-repo, got %s/%s (%v)", owner, repo, err)
+		}
+		if err := ValidateRepositoryAccess("org", "hidden", "test-token"); err == nil || !strings.Contains(err.Error(), "exists but your token cannot access it") {
+			t.Errorf("Expected restricted token error, got %v", err)
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Follow-up requests deadlocked on the single request slot")
+	}
+}
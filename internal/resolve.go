@@ -0,0 +1,150 @@
+package internal
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// Conflict resolutions accepted by ResolveConflict
+const (
+	ResolutionLocal  = "local"  // Keep the local edits; they stay queued as local modifications
+	ResolutionRemote = "remote" // Drop the local edits; the next sync fetches and takes GitHub's version
+)
+
+// ConflictedIssue pairs a conflicted sync state with the issue it belongs to
+type ConflictedIssue struct {
+	IssueLocalID int64
+	Number       int
+	Title        string
+}
+
+// GetConflictedIssues lists conflicted issues ordered by issue number, limited to
+// one project unless projectID is 0
+func GetConflictedIssues(db *sql.DB, projectID int64) ([]ConflictedIssue, error) {
+	query := `
+		SELECT s.issue_local_id, COALESCE(i.number, 0), COALESCE(i.title, '')
+		FROM issue_sync_state s
+		LEFT JOIN issues i ON i.rowid = s.issue_local_id
+		WHERE s.sync_state = ?`
+	args := []interface{}{string(SyncStateConflicted)}
+	if projectID > 0 {
+		query += " AND i.project_id = ?"
+		args = append(args, projectID)
+	}
+	rows, err := db.Query(query+" ORDER BY i.number", args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query conflicted issues: %w", err)
+	}
+	defer rows.Close()
+
+	var conflicts []ConflictedIssue
+	for rows.Next() {
+		var conflict ConflictedIssue
+		if err := rows.Scan(&conflict.IssueLocalID, &conflict.Number, &conflict.Title); err != nil {
+			return nil, fmt.Errorf("failed to scan conflicted issue: %w", err)
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	return conflicts, rows.Err()
+}
+
+// FindConflictedIssue returns the conflicted issue with the given number, limited to
+// one project unless projectID is 0. It fails when no such issue exists, when it is
+// not CONFLICTED, or when the number is conflicted in several projects.
+func FindConflictedIssue(db *sql.DB, projectID int64, number int) (*ConflictedIssue, error) {
+	query := `
+		SELECT i.rowid, i.number, COALESCE(i.title, ''), COALESCE(s.sync_state, '')
+		FROM issues i
+		LEFT JOIN issue_sync_state s ON s.issue_local_id = i.rowid
+		WHERE i.number = ?`
+	args := []interface{}{number}
+	if projectID > 0 {
+		query += " AND i.project_id = ?"
+		args = append(args, projectID)
+	}
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up issue #%d: %w", number, err)
+	}
+	defer rows.Close()
+
+	var conflicts []ConflictedIssue
+	found, otherState := false, ""
+	for rows.Next() {
+		var conflict ConflictedIssue
+		var state string
+		if err := rows.Scan(&conflict.IssueLocalID, &conflict.Number, &conflict.Title, &state); err != nil {
+			return nil, fmt.Errorf("failed to scan issue #%d: %w", number, err)
+		}
+		found = true
+		if SyncState(state) == SyncStateConflicted {
+			conflicts = append(conflicts, conflict)
+		} else if otherState == "" {
+			otherState = state
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to look up issue #%d: %w", number, err)
+	}
+
+	switch {
+	case !found:
+		return nil, fmt.Errorf("issue #%d not found", number)
+	case len(conflicts) > 1:
+		return nil, fmt.Errorf("issue #%d is conflicted in %d projects; use --project owner/repo", number, len(conflicts))
+	case len(conflicts) == 0:
+		if otherState == "" {
+			otherState = "untracked"
+		}
+		return nil, fmt.Errorf("issue #%d is not conflicted (state: %s)", number, otherState)
+	}
+	return &conflicts[0], nil
+}
+
+// ResolveConflict resolves a conflicted issue by keeping either the local or the remote version
+func ResolveConflict(db *sql.DB, issueLocalID int64, resolution string) error {
+	switch resolution {
+	case ResolutionLocal:
+		return UpdateSyncState(db, issueLocalID, SyncStateLocalModified, syncStateGitHubID(db, issueLocalID), nil)
+	case ResolutionRemote:
+		// Forget the local edit so the next sync overwrites the issue
+		if _, err := db.Exec("UPDATE issues SET local_modified_at = NULL WHERE rowid = ?", issueLocalID); err != nil {
+			return fmt.Errorf("failed to discard local changes: %w", err)
+		}
+		if err := rewindConflictWatermark(db, issueLocalID); err != nil {
+			return err
+		}
+		return UpdateSyncState(db, issueLocalID, SyncStateSynced, syncStateGitHubID(db, issueLocalID), nil)
+	default:
+		return fmt.Errorf("invalid resolution '%s' (valid: %s, %s)", resolution, ResolutionLocal, ResolutionRemote)
+	}
+}
+
+// rewindConflictWatermark rewinds the watermark of a conflicted issue's project to
+// the issue's stored updated_at. The sync that found the conflict already moved the
+// watermark past GitHub's copy, so an incremental sync would not fetch it again.
+func rewindConflictWatermark(db *sql.DB, issueLocalID int64) error {
+	// Single-project databases have no projects and no watermarks
+	multiProject, err := hasColumn(db, "issues", "project_id")
+	if err != nil || !multiProject {
+		return err
+	}
+
+	var projectID int64
+	var updatedAt string
+	err = db.QueryRow("SELECT project_id, COALESCE(updated_at, '') FROM issues WHERE rowid = ?", issueLocalID).Scan(&projectID, &updatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to look up conflicted issue: %w", err)
+	}
+	return rewindSyncWatermark(db, projectID, updatedAt)
+}
+
+// syncStateGitHubID returns the GitHub ID recorded for an issue's sync state, so
+// state updates keep it
+func syncStateGitHubID(db *sql.DB, issueLocalID int64) *int64 {
+	state, err := GetSyncState(db, issueLocalID)
+	if err != nil || state == nil {
+		return nil
+	}
+	return state.GitHubID
+}
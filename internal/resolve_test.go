@@ -0,0 +1,237 @@
+package internal
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// seedConflicts adds issues #1-#3 in CONFLICTED state and #4 SYNCED, returning their local IDs by number
+func seedConflicts(t *testing.T, fixture *syncStateTestFixture) map[int]int64 {
+	t.Helper()
+	ids := make(map[int]int64)
+	for number := 1; number <= 4; number++ {
+		result, err := fixture.db.Exec(`
+			INSERT INTO issues (project_id, github_id, number, title, state, local_modified_at)
+			VALUES (?, ?, ?, ?, 'open', '2024-01-01T00:00:00Z')`,
+			fixture.testProjectID, 1000+number, 10+number, "Issue "+string(rune('A'+number-1)))
+		if err != nil {
+			t.Fatalf("Failed to insert issue: %v", err)
+		}
+		id, _ := result.LastInsertId()
+		ids[10+number] = id
+
+		state := SyncStateConflicted
+		if number == 4 {
+			state = SyncStateSynced
+		}
+		githubID := int64(1000 + number)
+		if err := CreateSyncState(fixture.db, id, state, &githubID); err != nil {
+			t.Fatalf("Failed to create sync state: %v", err)
+		}
+	}
+	return ids
+}
+
+func TestFindConflictedIssue(t *testing.T) {
+	fixture := setupSyncStateTest(t)
+	defer teardownSyncStateTest(fixture)
+	ids := seedConflicts(t, fixture)
+
+	conflict, err := FindConflictedIssue(fixture.db, 0, 12)
+	if err != nil {
+		t.Fatalf("FindConflictedIssue failed: %v", err)
+	}
+	if conflict.IssueLocalID != ids[12] || conflict.Title != "Issue B" {
+		t.Errorf("Expected issue #12, got %+v", conflict)
+	}
+
+	if _, err := FindConflictedIssue(fixture.db, 0, 14); err == nil || !strings.Contains(err.Error(), "not conflicted (state: SYNCED)") {
+		t.Errorf("Expected not conflicted error, got %v", err)
+	}
+	if _, err := FindConflictedIssue(fixture.db, 0, 99); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
+
+func TestFindConflictedIssue_SeveralProjects(t *testing.T) {
+	fixture := setupSyncStateTest(t)
+	defer teardownSyncStateTest(fixture)
+	ids := seedConflicts(t, fixture)
+
+	// #12 is also conflicted in a second project, #14 only there
+	if _, err := fixture.db.Exec(`
+		INSERT INTO projects (id, owner, repo, path, created_at, updated_at)
+		VALUES (2, 'test', 'other-repo', '.', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	otherIDs := make(map[int]int64)
+	for _, number := range []int{12, 14} {
+		result, err := fixture.db.Exec(`
+			INSERT INTO issues (project_id, github_id, number, title, state, local_modified_at)
+			VALUES (2, ?, ?, 'Other', 'open', '2024-01-01T00:00:00Z')`, 2000+number, number)
+		if err != nil {
+			t.Fatalf("Failed to insert issue: %v", err)
+		}
+		id, _ := result.LastInsertId()
+		otherIDs[number] = id
+		githubID := int64(2000 + number)
+		if err := CreateSyncState(fixture.db, id, SyncStateConflicted, &githubID); err != nil {
+			t.Fatalf("Failed to create sync state: %v", err)
+		}
+	}
+
+	if _, err := FindConflictedIssue(fixture.db, 0, 12); err == nil || !strings.Contains(err.Error(), "conflicted in 2 projects; use --project") {
+		t.Errorf("Expected an ambiguity error, got %v", err)
+	}
+	conflict, err := FindConflictedIssue(fixture.db, 2, 12)
+	if err != nil || conflict.IssueLocalID != otherIDs[12] {
+		t.Errorf("Expected #12 of the second project, got %+v (%v)", conflict, err)
+	}
+	conflict, err = FindConflictedIssue(fixture.db, fixture.testProjectID, 12)
+	if err != nil || conflict.IssueLocalID != ids[12] {
+		t.Errorf("Expected #12 of the first project, got %+v (%v)", conflict, err)
+	}
+
+	// #14 is SYNCED in the first project but conflicted in the second
+	conflict, err = FindConflictedIssue(fixture.db, 0, 14)
+	if err != nil || conflict.IssueLocalID != otherIDs[14] {
+		t.Errorf("Expected the conflicted #14, got %+v (%v)", conflict, err)
+	}
+	if _, err := FindConflictedIssue(fixture.db, fixture.testProjectID, 14); err == nil || !strings.Contains(err.Error(), "not conflicted (state: SYNCED)") {
+		t.Errorf("Expected not conflicted error within the first project, got %v", err)
+	}
+
+	conflicts, err := GetConflictedIssues(fixture.db, 2)
+	if err != nil {
+		t.Fatalf("GetConflictedIssues failed: %v", err)
+	}
+	if len(conflicts) != 2 || conflicts[0].IssueLocalID != otherIDs[12] || conflicts[1].IssueLocalID != otherIDs[14] {
+		t.Errorf("Expected only the second project's conflicts, got %+v", conflicts)
+	}
+}
+
+func TestResolveConflict_SingleIssueLeavesOthersConflicted(t *testing.T) {
+	fixture := setupSyncStateTest(t)
+	defer teardownSyncStateTest(fixture)
+	ids := seedConflicts(t, fixture)
+
+	if err := ResolveConflict(fixture.db, ids[12], ResolutionRemote); err != nil {
+		t.Fatalf("ResolveConflict failed: %v", err)
+	}
+
+	state, err := GetSyncState(fixture.db, ids[12])
+	if err != nil {
+		t.Fatalf("GetSyncState failed: %v", err)
+	}
+	if state.SyncState != SyncStateSynced {
+		t.Errorf("Expected resolved issue to be SYNCED, got %s", state.SyncState)
+	}
+	if state.GitHubID == nil || *state.GitHubID != 1002 {
+		t.Errorf("Expected GitHub ID to be kept, got %v", state.GitHubID)
+	}
+
+	var localModified *string
+	if err := fixture.db.QueryRow("SELECT local_modified_at FROM issues WHERE rowid = ?", ids[12]).Scan(&localModified); err != nil {
+		t.Fatalf("Failed to read issue: %v", err)
+	}
+	if localModified != nil {
+		t.Errorf("Expected local modification to be discarded, got %s", *localModified)
+	}
+
+	conflicts, err := GetConflictedIssues(fixture.db, 0)
+	if err != nil {
+		t.Fatalf("GetConflictedIssues failed: %v", err)
+	}
+	if len(conflicts) != 2 || conflicts[0].Number != 11 || conflicts[1].Number != 13 {
+		t.Errorf("Expected #11 and #13 to stay conflicted, got %+v", conflicts)
+	}
+}
+
+func TestResolveConflict_TakeLocal(t *testing.T) {
+	fixture := setupSyncStateTest(t)
+	defer teardownSyncStateTest(fixture)
+	ids := seedConflicts(t, fixture)
+
+	if err := ResolveConflict(fixture.db, ids[11], ResolutionLocal); err != nil {
+		t.Fatalf("ResolveConflict failed: %v", err)
+	}
+	state, _ := GetSyncState(fixture.db, ids[11])
+	if state.SyncState != SyncStateLocalModified {
+		t.Errorf("Expected LOCAL_MODIFIED after keeping local, got %s", state.SyncState)
+	}
+
+	if err := ResolveConflict(fixture.db, ids[13], "merge"); err == nil {
+		t.Error("Expected error for invalid resolution")
+	}
+}
+
+func TestResolveConflict_RemoteIsFetchedByNextIncrementalSync(t *testing.T) {
+	body, updatedAt := "Original body", "2024-03-01T10:00:00Z"
+	otherUpdatedAt := "2024-02-01T00:00:00Z"
+	newMockGitHubServer(t, "octo", "widgets", "", map[string]http.HandlerFunc{
+		"/repos/octo/widgets/issues": func(w http.ResponseWriter, r *http.Request) {
+			// Like GitHub, only return issues updated at or after since
+			since := r.URL.Query().Get("since")
+			var issues []string
+			if since == "" || updatedAt >= since {
+				issues = append(issues, fmt.Sprintf(`{"id": 501, "number": 1, "title": "First", "body": %q, "state": "open", "updated_at": %q}`, body, updatedAt))
+			}
+			if since == "" || otherUpdatedAt >= since {
+				issues = append(issues, fmt.Sprintf(`{"id": 502, "number": 2, "title": "Second", "state": "open", "updated_at": %q}`, otherUpdatedAt))
+			}
+			_, _ = w.Write([]byte("[" + strings.Join(issues, ",") + "]"))
+		},
+	})
+
+	config, err := NewAdHocConfig("octo/widgets", "test-token")
+	if err != nil {
+		t.Fatalf("NewAdHocConfig failed: %v", err)
+	}
+	config.Global.Database = filepath.Join(t.TempDir(), "pivot.db")
+	if _, err := SyncAdHoc(config, SyncOptions{}); err != nil {
+		t.Fatalf("First sync failed: %v", err)
+	}
+
+	db, err := InitMultiProjectDBFromPath(config.Global.Database)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	// Edit #1 locally while it also changes on GitHub, and a newer change to #2
+	// moves the watermark past it
+	if _, err := db.Exec("UPDATE issues SET body = 'Local body', local_modified_at = '2024-03-02T00:00:00Z' WHERE number = 1"); err != nil {
+		t.Fatalf("Failed to edit issue: %v", err)
+	}
+	body, updatedAt = "Remote body", "2024-03-05T08:30:00Z"
+	otherUpdatedAt = "2024-03-06T00:00:00Z"
+	result, err := SyncAdHoc(config, SyncOptions{})
+	if err != nil {
+		t.Fatalf("Conflicting sync failed: %v", err)
+	}
+	if result.Totals().Conflicted != 1 {
+		t.Fatalf("Expected 1 conflict, got %+v", result.Totals())
+	}
+
+	conflict, err := FindConflictedIssue(db, 0, 1)
+	if err != nil {
+		t.Fatalf("FindConflictedIssue failed: %v", err)
+	}
+	if err := ResolveConflict(db, conflict.IssueLocalID, ResolutionRemote); err != nil {
+		t.Fatalf("ResolveConflict failed: %v", err)
+	}
+
+	if _, err := SyncAdHoc(config, SyncOptions{}); err != nil {
+		t.Fatalf("Sync after resolving failed: %v", err)
+	}
+	var stored string
+	if err := db.QueryRow("SELECT body FROM issues WHERE number = 1").Scan(&stored); err != nil {
+		t.Fatalf("Failed to read issue: %v", err)
+	}
+	if stored != "Remote body" {
+		t.Errorf("Expected the GitHub body after resolving toward remote, got %q", stored)
+	}
+}
@@ -0,0 +1,109 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// scrubReplacement replaces secrets in error messages and log lines
+const scrubReplacement = "***"
+
+// minSecretLength avoids scrubbing short values that would mangle ordinary text
+const minSecretLength = 8
+
+var (
+	secretsMu      sync.RWMutex
+	knownSecrets   = make(map[string]bool)
+	secretPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{20,}\b`),   // Classic GitHub tokens
+		regexp.MustCompile(`\bgithub_pat_[A-Za-z0-9_]{20,}\b`), // Fine-grained GitHub tokens
+	}
+)
+
+// RegisterSecret records a value (such as a GitHub token) that must never appear in errors or logs
+func RegisterSecret(secret string) {
+	secret = strings.TrimSpace(secret)
+	if len(secret) < minSecretLength {
+		return
+	}
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+	knownSecrets[secret] = true
+}
+
+// RegisterSecretPattern adds a regular expression whose matches are scrubbed from errors and logs
+func RegisterSecretPattern(pattern string) error {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid redact pattern '%s': %w", pattern, err)
+	}
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+	secretPatterns = append(secretPatterns, re)
+	return nil
+}
+
+// ScrubSecrets replaces every known secret and secret pattern match in text with ***
+func ScrubSecrets(text string) string {
+	secretsMu.RLock()
+	defer secretsMu.RUnlock()
+
+	// Replace longer secrets first so overlapping secrets are fully hidden
+	secrets := make([]string, 0, len(knownSecrets))
+	for secret := range knownSecrets {
+		secrets = append(secrets, secret)
+	}
+	sort.Slice(secrets, func(i, j int) bool { return len(secrets[i]) > len(secrets[j]) })
+
+	for _, secret := range secrets {
+		text = strings.ReplaceAll(text, secret, scrubReplacement)
+	}
+	for _, re := range secretPatterns {
+		text = re.ReplaceAllString(text, scrubReplacement)
+	}
+	return text
+}
+
+// scrubbedError is an error whose message has had secrets removed
+type scrubbedError struct {
+	msg string
+	err error
+}
+
+func (e *scrubbedError) Error() string { return e.msg }
+func (e *scrubbedError) Unwrap() error { return e.err }
+
+// ScrubError returns err with secrets removed from its message. The original error
+// remains available to errors.Is and errors.As.
+func ScrubError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var already *scrubbedError
+	if errors.As(err, &already) && already == err {
+		return err
+	}
+	msg := ScrubSecrets(err.Error())
+	if msg == err.Error() {
+		return err
+	}
+	return &scrubbedError{msg: msg, err: err}
+}
+
+// registerConfigSecrets registers the tokens, webhook and redact patterns from a configuration
+func registerConfigSecrets(config *MultiProjectConfig) {
+	RegisterSecret(config.Global.Token)
+	for _, project := range config.Projects {
+		RegisterSecret(project.Token)
+	}
+	RegisterSecret(config.Sync.Notify.Webhook) // Webhook URLs usually embed a secret
+	for _, pattern := range config.Global.RedactPatterns {
+		if err := RegisterSecretPattern(pattern); err != nil {
+			fmt.Printf("⚠ %v\n", err)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestScrubError_HidesRegisteredToken(t *testing.T) {
+	token := "s3cr3t-token-for-scrub-test"
+	RegisterSecret(token)
+
+	cause := errors.New("request failed with header Authorization: token " + token)
+	err := fmt.Errorf("GitHub credential validation failed: %w", cause)
+
+	scrubbed := ScrubError(err)
+	if strings.Contains(scrubbed.Error(), token) {
+		t.Errorf("Expected token to be hidden, got: %s", scrubbed.Error())
+	}
+	if !strings.Contains(scrubbed.Error(), "Authorization: token ***") {
+		t.Errorf("Expected token to be replaced with ***, got: %s", scrubbed.Error())
+	}
+	if !errors.Is(scrubbed, cause) {
+		t.Error("Expected scrubbed error to still wrap the original cause")
+	}
+}
+
+func TestScrubSecrets_GitHubTokenPatterns(t *testing.T) {
+	classic := "ghp_" + strings.Repeat("a1B2", 9)
+	fineGrained := "github_pat_" + strings.Repeat("x9_Y", 10)
+
+	line := fmt.Sprintf("tokens %s and %s leaked", classic, fineGrained)
+	scrubbed := ScrubSecrets(line)
+	if strings.Contains(scrubbed, classic) || strings.Contains(scrubbed, fineGrained) {
+		t.Errorf("Expected unregistered GitHub tokens to be hidden, got: %s", scrubbed)
+	}
+	if scrubbed != "tokens *** and *** leaked" {
+		t.Errorf("Unexpected scrubbed line: %s", scrubbed)
+	}
+}
+
+func TestScrubSecrets_LeavesOrdinaryText(t *testing.T) {
+	RegisterSecret("short") // Too short to register safely
+
+	text := "a short message without secrets"
+	if got := ScrubSecrets(text); got != text {
+		t.Errorf("Expected text to be unchanged, got: %s", got)
+	}
+
+	err := errors.New(text)
+	if ScrubError(err) != err {
+		t.Error("Expected errors without secrets to be returned unchanged")
+	}
+	if ScrubError(nil) != nil {
+		t.Error("Expected nil error to stay nil")
+	}
+}
+
+func TestRegisterSecretPattern(t *testing.T) {
+	if err := RegisterSecretPattern(`internal-key-[0-9]+`); err != nil {
+		t.Fatalf("RegisterSecretPattern failed: %v", err)
+	}
+	if got := ScrubSecrets("using internal-key-12345"); got != "using ***" {
+		t.Errorf("Expected configured pattern to be scrubbed, got: %s", got)
+	}
+	if err := RegisterSecretPattern("("); err == nil {
+		t.Error("Expected error for invalid pattern")
+	}
+}
+
+func TestLoadMultiProjectConfig_RegistersTokens(t *testing.T) {
+	chdirTempGitRepo(t)
+
+	content := `global:
+  token: global-token-abcdef
+projects:
+- owner: org
+  repo: alpha
+  token: project-token-123456
+`
+	if err := os.WriteFile("config.yml", []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write config.yml: %v", err)
+	}
+	if _, err := LoadMultiProjectConfig(); err != nil {
+		t.Fatalf("LoadMultiProjectConfig failed: %v", err)
+	}
+
+	scrubbed := ScrubSecrets("global-token-abcdef / project-token-123456")
+	if scrubbed != "*** / ***" {
+		t.Errorf("Expected configured tokens to be scrubbed, got: %s", scrubbed)
+	}
+}
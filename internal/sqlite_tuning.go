@@ -0,0 +1,44 @@
+package internal
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// DefaultBusyTimeoutMS is how long SQLite waits for a competing lock before
+// returning "database is locked" when no timeout is configured
+const DefaultBusyTimeoutMS = 5000
+
+// DatabaseSettings tunes how the local SQLite database is opened
+type DatabaseSettings struct {
+	BusyTimeoutMS int `yaml:"busy_timeout_ms,omitempty"` // Wait for locks up to this long (0 = default)
+}
+
+// BusyTimeout returns the configured busy timeout in milliseconds, falling back to the default
+func (s DatabaseSettings) BusyTimeout() int {
+	if s.BusyTimeoutMS > 0 {
+		return s.BusyTimeoutMS
+	}
+	return DefaultBusyTimeoutMS
+}
+
+// sqliteDSN builds a data source name that applies the busy timeout, WAL journaling and
+// synchronous=NORMAL to every connection the pool opens
+func sqliteDSN(path string, settings DatabaseSettings) string {
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	return fmt.Sprintf("%s%s_busy_timeout=%d&_journal_mode=WAL&_synchronous=NORMAL",
+		path, separator, settings.BusyTimeout())
+}
+
+// OpenSQLite opens a SQLite database with the connection tuning from settings
+func OpenSQLite(path string, settings DatabaseSettings) (*sql.DB, error) {
+	db, err := sql.Open("sqlite3", sqliteDSN(path, settings))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	return db, nil
+}
@@ -0,0 +1,166 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDatabaseSettings_BusyTimeout(t *testing.T) {
+	if got := (DatabaseSettings{}).BusyTimeout(); got != DefaultBusyTimeoutMS {
+		t.Errorf("Expected default busy timeout %d, got %d", DefaultBusyTimeoutMS, got)
+	}
+	if got := (DatabaseSettings{BusyTimeoutMS: 250}).BusyTimeout(); got != 250 {
+		t.Errorf("Expected configured busy timeout 250, got %d", got)
+	}
+}
+
+func TestOpenSQLite_AppliesPragmas(t *testing.T) {
+	db, err := OpenSQLite(filepath.Join(t.TempDir(), "pivot.db"), DatabaseSettings{BusyTimeoutMS: 1234})
+	if err != nil {
+		t.Fatalf("OpenSQLite failed: %v", err)
+	}
+	defer db.Close()
+
+	var journalMode string
+	if err := db.QueryRow("PRAGMA journal_mode").Scan(&journalMode); err != nil {
+		t.Fatalf("Failed to read journal_mode: %v", err)
+	}
+	if strings.ToLower(journalMode) != "wal" {
+		t.Errorf("Expected journal_mode wal, got %s", journalMode)
+	}
+
+	var busyTimeout int
+	if err := db.QueryRow("PRAGMA busy_timeout").Scan(&busyTimeout); err != nil {
+		t.Fatalf("Failed to read busy_timeout: %v", err)
+	}
+	if busyTimeout != 1234 {
+		t.Errorf("Expected busy_timeout 1234, got %d", busyTimeout)
+	}
+
+	var synchronous int
+	if err := db.QueryRow("PRAGMA synchronous").Scan(&synchronous); err != nil {
+		t.Fatalf("Failed to read synchronous: %v", err)
+	}
+	if synchronous != 1 { // NORMAL
+		t.Errorf("Expected synchronous NORMAL (1), got %d", synchronous)
+	}
+}
+
+func TestOpenSQLite_WaitsForWriteLock(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "pivot.db")
+	settings := DatabaseSettings{BusyTimeoutMS: 2000}
+
+	holder, err := OpenSQLite(dbPath, settings)
+	if err != nil {
+		t.Fatalf("OpenSQLite failed: %v", err)
+	}
+	defer holder.Close()
+	if _, err := holder.Exec("CREATE TABLE items (id INTEGER PRIMARY KEY)"); err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+
+	waiter, err := OpenSQLite(dbPath, settings)
+	if err != nil {
+		t.Fatalf("OpenSQLite failed: %v", err)
+	}
+	defer waiter.Close()
+
+	tx, err := holder.Begin()
+	if err != nil {
+		t.Fatalf("Failed to begin transaction: %v", err)
+	}
+	if _, err := tx.Exec("INSERT INTO items (id) VALUES (1)"); err != nil {
+		t.Fatalf("Failed to write in transaction: %v", err)
+	}
+
+	// Release the write lock after a short delay; the second connection must wait for it
+	holdFor := 300 * time.Millisecond
+	go func() {
+		time.Sleep(holdFor)
+		_ = tx.Commit() // #nosec G104 - test helper, commit result checked via row count
+	}()
+
+	start := time.Now()
+	if _, err := waiter.Exec("INSERT INTO items (id) VALUES (2)"); err != nil {
+		t.Fatalf("Expected second connection to wait for the lock, got error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < holdFor/2 {
+		t.Errorf("Expected second connection to wait for the lock, returned after %v", elapsed)
+	}
+
+	var count int
+	if err := waiter.QueryRow("SELECT COUNT(*) FROM items").Scan(&count); err != nil {
+		t.Fatalf("Failed to count rows: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 rows, got %d", count)
+	}
+}
+
+func TestOpenSQLite_TimesOutWhenLockHeld(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "pivot.db")
+
+	holder, err := OpenSQLite(dbPath, DatabaseSettings{})
+	if err != nil {
+		t.Fatalf("OpenSQLite failed: %v", err)
+	}
+	defer holder.Close()
+	if _, err := holder.Exec("CREATE TABLE items (id INTEGER PRIMARY KEY)"); err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+
+	tx, err := holder.Begin()
+	if err != nil {
+		t.Fatalf("Failed to begin transaction: %v", err)
+	}
+	defer func() { _ = tx.Rollback() }() // #nosec G104 - test cleanup
+	if _, err := tx.Exec("INSERT INTO items (id) VALUES (1)"); err != nil {
+		t.Fatalf("Failed to write in transaction: %v", err)
+	}
+
+	waiter, err := OpenSQLite(dbPath, DatabaseSettings{BusyTimeoutMS: 200})
+	if err != nil {
+		t.Fatalf("OpenSQLite failed: %v", err)
+	}
+	defer waiter.Close()
+
+	start := time.Now()
+	_, err = waiter.Exec("INSERT INTO items (id) VALUES (2)")
+	elapsed := time.Since(start)
+	if err == nil {
+		t.Fatal("Expected write to fail while the lock is held")
+	}
+	if !strings.Contains(err.Error(), "locked") {
+		t.Errorf("Expected database is locked error, got: %v", err)
+	}
+	if elapsed < 150*time.Millisecond {
+		t.Errorf("Expected to wait for the busy timeout before failing, returned after %v", elapsed)
+	}
+}
+
+func TestLoadMultiProjectConfig_DatabaseSettings(t *testing.T) {
+	chdirTempGitRepo(t)
+
+	content := `global:
+  database: ./pivot.db
+database:
+  busy_timeout_ms: 750
+projects:
+- owner: org
+  repo: alpha
+`
+	if err := os.WriteFile("config.yml", []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write config.yml: %v", err)
+	}
+
+	config, err := LoadMultiProjectConfig()
+	if err != nil {
+		t.Fatalf("LoadMultiProjectConfig failed: %v", err)
+	}
+	if config.Database.BusyTimeout() != 750 {
+		t.Errorf("Expected busy timeout 750, got %d", config.Database.BusyTimeout())
+	}
+}
@@ -0,0 +1,69 @@
+package internal
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseAge parses an age such as 30d, 2w or a Go duration such as 36h. Days are
+// 24 hours and weeks 7 days. The age must be positive.
+func ParseAge(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+
+	var age time.Duration
+	unit := map[byte]time.Duration{'d': 24 * time.Hour, 'w': 7 * 24 * time.Hour}
+	if len(value) > 1 && unit[value[len(value)-1]] != 0 {
+		count, err := strconv.Atoi(value[:len(value)-1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid age '%s' (use e.g. 30d, 2w or 36h)", value)
+		}
+		age = time.Duration(count) * unit[value[len(value)-1]]
+	} else {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("invalid age '%s' (use e.g. 30d, 2w or 36h)", value)
+		}
+		age = parsed
+	}
+
+	if age <= 0 {
+		return 0, fmt.Errorf("age must be positive, got '%s'", value)
+	}
+	return age, nil
+}
+
+// StaleIssues returns the open issues whose updated_at is older than age, least
+// recently updated first (projectID 0 = all projects)
+func StaleIssues(db *sql.DB, projectID int64, age time.Duration, now time.Time) ([]DBIssue, error) {
+	return ListIssues(db, ListOptions{
+		ProjectID:     projectID,
+		State:         "open",
+		UpdatedBefore: now.Add(-age),
+		Sort:          "updated",
+	})
+}
+
+// PrintStaleIssues prints how many open issues have not been updated within label
+// (the age as the user wrote it) and, when list is set, the issues themselves
+func PrintStaleIssues(w io.Writer, issues []DBIssue, label string, list bool, now time.Time) {
+	if len(issues) == 0 {
+		fmt.Fprintf(w, "✓ No open issues older than %s without an update\n", label)
+		return
+	}
+
+	fmt.Fprintf(w, "🕸  %d open issues not updated in %s\n", len(issues), label)
+	if !list {
+		return
+	}
+	for _, issue := range issues {
+		fmt.Fprintf(w, "  #%-6d %s", issue.Number, issue.Title)
+		if updated, err := time.Parse(time.RFC3339, issue.UpdatedAt); err == nil {
+			fmt.Fprintf(w, " (last updated %s, %d days ago)", updated.Format("2006-01-02"), int(now.Sub(updated).Hours()/24))
+		}
+		fmt.Fprintln(w)
+	}
+}
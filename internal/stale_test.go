@@ -0,0 +1,127 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseAge(t *testing.T) {
+	tests := map[string]time.Duration{
+		"30d":   30 * 24 * time.Hour,
+		"2w":    14 * 24 * time.Hour,
+		"36h":   36 * time.Hour,
+		"90m":   90 * time.Minute,
+		" 1d ":  24 * time.Hour,
+		"1h30m": 90 * time.Minute,
+	}
+	for input, expected := range tests {
+		age, err := ParseAge(input)
+		if err != nil || age != expected {
+			t.Errorf("ParseAge(%q): expected %s, got %s (%v)", input, expected, age, err)
+		}
+	}
+
+	for _, input := range []string{"", "d", "30", "xd", "3y", "0d", "-1w", "-5h"} {
+		if _, err := ParseAge(input); err == nil {
+			t.Errorf("ParseAge(%q): expected error", input)
+		}
+	}
+}
+
+func TestStaleIssues(t *testing.T) {
+	db := newTestMultiProjectDB(t)
+	now := time.Date(2026, 6, 30, 12, 0, 0, 0, time.UTC)
+
+	alphaID, err := CreateProject(db, &ProjectConfig{Owner: "org", Repo: "alpha"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	betaID, err := CreateProject(db, &ProjectConfig{Owner: "org", Repo: "beta"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	issues := []struct {
+		projectID int64
+		number    int
+		state     string
+		daysAgo   int
+	}{
+		{alphaID, 1, "open", 2},    // Recently updated
+		{alphaID, 2, "open", 45},   // Stale
+		{alphaID, 3, "closed", 90}, // Old but closed
+		{alphaID, 4, "open", 31},   // Just past 30 days
+		{alphaID, 5, "open", 29},   // Just within 30 days
+		{betaID, 1, "open", 120},   // Stale in another project
+	}
+	for i, seed := range issues {
+		issue := &DBIssue{
+			ID:        100 + i,
+			Number:    seed.number,
+			Title:     "Issue",
+			State:     seed.state,
+			UpdatedAt: now.AddDate(0, 0, -seed.daysAgo).Format(time.RFC3339),
+		}
+		if err := SaveIssue(db, seed.projectID, issue); err != nil {
+			t.Fatalf("Failed to save issue: %v", err)
+		}
+	}
+
+	stale, err := StaleIssues(db, 0, 30*24*time.Hour, now)
+	if err != nil {
+		t.Fatalf("StaleIssues failed: %v", err)
+	}
+	// Least recently updated first: beta#1 (120d), alpha#2 (45d), alpha#4 (31d)
+	if got := issueIDs(stale); got != "105,101,103" {
+		t.Errorf("Expected stale issues 105,101,103, got %s", got)
+	}
+
+	scoped, err := StaleIssues(db, alphaID, 40*24*time.Hour, now)
+	if err != nil {
+		t.Fatalf("StaleIssues failed: %v", err)
+	}
+	if got := issueIDs(scoped); got != "101" {
+		t.Errorf("Expected only alpha#2 older than 40 days, got %s", got)
+	}
+}
+
+// issueIDs lists the GitHub IDs of issues in order
+func issueIDs(issues []DBIssue) string {
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = fmt.Sprint(issue.ID)
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestPrintStaleIssues(t *testing.T) {
+	now := time.Date(2026, 6, 30, 12, 0, 0, 0, time.UTC)
+	issues := []DBIssue{
+		{Number: 7, Title: "Forgotten bug", UpdatedAt: "2026-05-01T08:00:00Z"},
+		{Number: 9, Title: "No timestamp"},
+	}
+
+	var counted bytes.Buffer
+	PrintStaleIssues(&counted, issues, "30d", false, now)
+	if counted.String() != "🕸  2 open issues not updated in 30d\n" {
+		t.Errorf("Expected only the count, got:\n%s", counted.String())
+	}
+
+	var listed bytes.Buffer
+	PrintStaleIssues(&listed, issues, "30d", true, now)
+	if !strings.Contains(listed.String(), "#7      Forgotten bug (last updated 2026-05-01, 60 days ago)") {
+		t.Errorf("Expected the stale issue with its age, got:\n%s", listed.String())
+	}
+	if !strings.Contains(listed.String(), "#9      No timestamp\n") {
+		t.Errorf("Expected an issue without a timestamp to be listed plainly, got:\n%s", listed.String())
+	}
+
+	var none bytes.Buffer
+	PrintStaleIssues(&none, nil, "2w", true, now)
+	if !strings.Contains(none.String(), "No open issues older than 2w") {
+		t.Errorf("Expected the all-clear message, got:\n%s", none.String())
+	}
+}
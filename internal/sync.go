@@ -2,17 +2,19 @@ package internal
 
 import (
 	"fmt"
-	"os"
 
 	"gopkg.in/yaml.v2"
 )
 
 type Config struct {
-	Owner    string     `yaml:"owner"`
-	Repo     string     `yaml:"repo"`
-	Token    string     `yaml:"token"`
-	Database string     `yaml:"database,omitempty"`
-	Sync     SyncConfig `yaml:"sync,omitempty"`
+	Owner    string         `yaml:"owner"`
+	Repo     string         `yaml:"repo"`
+	Token    string         `yaml:"token"`
+	Database string         `yaml:"database,omitempty"`
+	Sync     SyncConfig     `yaml:"sync,omitempty"`
+	Push     PushSettings   `yaml:"push,omitempty"`
+	Import   ImportSettings `yaml:"import,omitempty"`
+	Match    MatchSettings  `yaml:"match,omitempty"`
 }
 
 type SyncConfig struct {
@@ -28,10 +30,10 @@ type GitHubConfig struct {
 }
 
 func loadConfig() (*Config, error) {
-	data, err := os.ReadFile("config.yml")
+	data, err := readConfigFile("config.yml")
 	if err != nil {
 		// Try config.yaml for backward compatibility
-		data, err = os.ReadFile("config.yaml")
+		data, err = readConfigFile("config.yaml")
 		if err != nil {
 			return nil, err
 		}
@@ -41,6 +43,8 @@ func loadConfig() (*Config, error) {
 		return nil, err
 	}
 
+	RegisterSecret(cfg.Token)
+
 	// Set defaults
 	if cfg.Database == "" {
 		cfg.Database = "./pivot.db"
@@ -58,48 +62,51 @@ func LoadConfig() (*Config, error) {
 	return loadConfig()
 }
 
-func Sync() error {
+// Sync syncs the single project of a legacy config.yml into the local database
+func Sync() (*SyncResult, error) {
 	cfg, err := loadConfig()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	db, err := InitDB()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer db.Close()
 
 	// Validate GitHub credentials before attempting sync
 	if err := EnsureGitHubCredentials(cfg.Owner, cfg.Repo, cfg.Token); err != nil {
-		return fmt.Errorf("GitHub credential validation failed: %w", err)
+		return nil, fmt.Errorf("GitHub credential validation failed: %w", err)
 	}
 	issues, err := FetchIssues(cfg.Owner, cfg.Repo, cfg.Token)
 	if err != nil {
-		return err
+		return nil, err
 	}
+
+	result := ProjectSyncResult{Owner: cfg.Owner, Repo: cfg.Repo}
 	for _, iss := range issues {
-		// Convert labels and assignees to comma-separated
-		var labels, assignees string
-		for i, l := range iss.Labels {
-			if i > 0 {
-				labels += ","
-			}
-			labels += l.Name
-		}
-		for i, a := range iss.Assignees {
-			if i > 0 {
-				assignees += ","
-			}
-			assignees += a.Login
-		}
+		var found int
+		exists := db.QueryRow("SELECT 1 FROM issues WHERE github_id = ?", iss.ID).Scan(&found) == nil
+
+		// Convert labels and assignees to sorted comma-separated strings
+		dbIssue := ConvertIssueToDBIssue(&iss)
+		labels, assignees := dbIssue.Labels, dbIssue.Assignees
 		_, err := db.Exec(`
 			INSERT OR REPLACE INTO issues (github_id, number, title, body, state, labels, assignees, created_at, updated_at, closed_at)
 			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 			iss.ID, iss.Number, iss.Title, iss.Body, iss.State, labels, assignees, iss.CreatedAt, iss.UpdatedAt, iss.ClosedAt)
 		if err != nil {
 			fmt.Println("Failed to insert issue:", iss.Number, err)
+			result.Errors = append(result.Errors, fmt.Sprintf("failed to insert issue %d: %v", iss.Number, err))
+			continue
+		}
+
+		if exists {
+			result.Updated++
+		} else {
+			result.Created++
 		}
 	}
-	return nil
+	return &SyncResult{Projects: []ProjectSyncResult{result}}, nil
 }
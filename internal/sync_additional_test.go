@@ -32,7 +32,7 @@ func TestSyncProject(t *testing.T) {
 			Repo:  "testrepo",
 		}
 
-		err := syncProject(db, globalNoToken, projectNoToken)
+		_, err := syncProject(db, globalNoToken, projectNoToken)
 		if err == nil {
 			t.Error("Expected error for missing token")
 		}
@@ -50,7 +50,7 @@ func TestSyncProject(t *testing.T) {
 		}
 
 		// This will fail on the HTTP call, but should pass the token check
-		err := syncProject(db, globalNoToken, projectWithToken)
+		_, err := syncProject(db, globalNoToken, projectWithToken)
 		if err != nil && strings.Contains(err.Error(), "no GitHub token configured") {
 			t.Errorf("Should not be a token error when project has token, got: %v", err)
 		}
@@ -65,7 +65,7 @@ func TestSyncProject(t *testing.T) {
 		}
 
 		// This will fail on the HTTP call, but should pass the token check
-		err := syncProject(db, globalWithToken, project)
+		_, err := syncProject(db, globalWithToken, project)
 		if err != nil && strings.Contains(err.Error(), "no GitHub token configured") {
 			t.Errorf("Should not be a token error when global has token, got: %v", err)
 		}
@@ -97,7 +97,7 @@ database: test.db`
 			t.Fatalf("Failed to create config file: %v", err)
 		}
 
-		err = Sync()
+		_, err = Sync()
 		if err == nil {
 			t.Error("Expected error for empty owner")
 		}
@@ -114,7 +114,7 @@ database: test.db`
 			t.Fatalf("Failed to create config file: %v", err)
 		}
 
-		err = Sync()
+		_, err = Sync()
 		if err == nil {
 			t.Error("Expected error for empty repo")
 		}
@@ -132,7 +132,7 @@ database: test.db`
 		}
 
 		// This should pass validation but fail on network call
-		err = Sync()
+		_, err = Sync()
 		if err == nil {
 			t.Error("Expected error due to network call failure")
 		}
@@ -208,7 +208,7 @@ database: test_sync.db`
 
 	// Test that Sync attempts to load config and init DB properly
 	// This will fail on the FetchIssues call, but that's expected
-	err = Sync()
+	_, err = Sync()
 	if err == nil {
 		t.Error("Expected error due to GitHub API call")
 	}
@@ -249,7 +249,7 @@ database: /invalid/readonly/path/test.db`
 	}
 
 	// Test that Sync fails appropriately when DB can't be created
-	err = Sync()
+	_, err = Sync()
 	if err == nil {
 		t.Error("Expected error when database can't be created")
 	}
@@ -0,0 +1,106 @@
+package internal
+
+import (
+	"net/http"
+	"path/filepath"
+	"testing"
+)
+
+// newAssigneeSyncConfig returns an ad-hoc config for octo/widgets with a temporary database
+func newAssigneeSyncConfig(t *testing.T) *MultiProjectConfig {
+	t.Helper()
+	config, err := NewAdHocConfig("octo/widgets", "test-token")
+	if err != nil {
+		t.Fatalf("NewAdHocConfig failed: %v", err)
+	}
+	config.Global.Database = filepath.Join(t.TempDir(), "pivot.db")
+	return config
+}
+
+func TestSyncAdHoc_AssignedToMeUsesAuthenticatedLogin(t *testing.T) {
+	var assignees []string
+	newMockGitHubServer(t, "octo", "widgets", "", map[string]http.HandlerFunc{
+		"/user": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"login": "mona"}`))
+		},
+		"/repos/octo/widgets/issues": func(w http.ResponseWriter, r *http.Request) {
+			assignees = append(assignees, r.URL.Query().Get("assignee"))
+			_, _ = w.Write([]byte(`[{"id": 601, "number": 1, "title": "Mine", "state": "open", "updated_at": "2024-03-01T10:00:00Z"}]`))
+		},
+	})
+
+	config := newAssigneeSyncConfig(t)
+	result, err := SyncAdHoc(config, SyncOptions{AssignedToMe: true})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	if len(assignees) != 1 || assignees[0] != "mona" {
+		t.Errorf("Expected assignee=mona on the issues request, got %v", assignees)
+	}
+	if created := result.Totals().Created; created != 1 {
+		t.Errorf("Expected 1 created issue, got %d", created)
+	}
+}
+
+func TestSyncAdHoc_ExplicitAssignee(t *testing.T) {
+	var assignees []string
+	newMockGitHubServer(t, "octo", "widgets", "", map[string]http.HandlerFunc{
+		"/repos/octo/widgets/issues": func(w http.ResponseWriter, r *http.Request) {
+			assignees = append(assignees, r.URL.Query().Get("assignee"))
+			_, _ = w.Write([]byte(`[]`))
+		},
+	})
+
+	if _, err := SyncAdHoc(newAssigneeSyncConfig(t), SyncOptions{Assignee: "hubot"}); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	if len(assignees) != 1 || assignees[0] != "hubot" {
+		t.Errorf("Expected assignee=hubot on the issues request, got %v", assignees)
+	}
+}
+
+func TestSyncAdHoc_AssigneeFilterKeepsWatermark(t *testing.T) {
+	newMockGitHubServer(t, "octo", "widgets", `[{"id": 602, "number": 2, "title": "Mine", "state": "open", "updated_at": "2024-03-01T10:00:00Z"}]`, nil)
+
+	config := newAssigneeSyncConfig(t)
+	if _, err := SyncAdHoc(config, SyncOptions{Assignee: "hubot"}); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	db, err := InitMultiProjectDBFromPath(config.Global.Database)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	projectID, err := getProjectID(db, "octo", "widgets")
+	if err != nil {
+		t.Fatalf("Expected project to be recorded: %v", err)
+	}
+	if since, _ := GetSyncWatermark(db, projectID); since != "" {
+		t.Errorf("Expected filtered sync to leave the watermark unset, got %q", since)
+	}
+}
+
+func TestNewIssuesPageRequest_NoAssigneeByDefault(t *testing.T) {
+	req, err := newIssuesPageRequest("octo", "widgets", "token", 1, issuesQuery{})
+	if err != nil {
+		t.Fatalf("newIssuesPageRequest failed: %v", err)
+	}
+	if _, ok := req.URL.Query()["assignee"]; ok {
+		t.Errorf("Expected no assignee parameter, got %s", req.URL.RawQuery)
+	}
+}
+
+func TestAuthenticatedLogin(t *testing.T) {
+	newMockGitHubServer(t, "octo", "widgets", "[]", nil)
+
+	login, err := AuthenticatedLogin("test-token")
+	if err != nil {
+		t.Fatalf("AuthenticatedLogin failed: %v", err)
+	}
+	if login != "octocat" {
+		t.Errorf("Expected octocat, got %s", login)
+	}
+}
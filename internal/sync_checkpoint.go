@@ -0,0 +1,54 @@
+package internal
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// createSyncCheckpointTable creates the table recording the last synced page per project
+func createSyncCheckpointTable(db *sql.DB) error {
+	schema := `
+	CREATE TABLE IF NOT EXISTS sync_checkpoints (
+		project_id INTEGER PRIMARY KEY,
+		page INTEGER NOT NULL,
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY(project_id) REFERENCES projects(id) ON DELETE CASCADE
+	);`
+
+	if _, err := db.Exec(schema); err != nil {
+		return fmt.Errorf("failed to create sync_checkpoints table: %w", err)
+	}
+	return nil
+}
+
+// GetSyncCheckpoint returns the last successfully synced page for a project (0 = none)
+func GetSyncCheckpoint(db *sql.DB, projectID int64) (int, error) {
+	var page int
+	err := db.QueryRow("SELECT page FROM sync_checkpoints WHERE project_id = ?", projectID).Scan(&page)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read sync checkpoint: %w", err)
+	}
+	return page, nil
+}
+
+// SaveSyncCheckpoint records the last successfully synced page for a project
+func SaveSyncCheckpoint(db *sql.DB, projectID int64, page int) error {
+	_, err := db.Exec(`
+		INSERT OR REPLACE INTO sync_checkpoints (project_id, page, updated_at)
+		VALUES (?, ?, CURRENT_TIMESTAMP)`, projectID, page)
+	if err != nil {
+		return fmt.Errorf("failed to save sync checkpoint: %w", err)
+	}
+	return nil
+}
+
+// ClearSyncCheckpoint removes the checkpoint of a project after a complete sync
+func ClearSyncCheckpoint(db *sql.DB, projectID int64) error {
+	if _, err := db.Exec("DELETE FROM sync_checkpoints WHERE project_id = ?", projectID); err != nil {
+		return fmt.Errorf("failed to clear sync checkpoint: %w", err)
+	}
+	return nil
+}
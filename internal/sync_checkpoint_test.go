@@ -0,0 +1,184 @@
+package internal
+
+import (
+	"net/http"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestSyncCheckpoint_SaveGetClear(t *testing.T) {
+	db := newTestMultiProjectDB(t)
+
+	page, err := GetSyncCheckpoint(db, 1)
+	if err != nil {
+		t.Fatalf("GetSyncCheckpoint failed: %v", err)
+	}
+	if page != 0 {
+		t.Errorf("Expected no checkpoint, got page %d", page)
+	}
+
+	if err := SaveSyncCheckpoint(db, 1, 3); err != nil {
+		t.Fatalf("SaveSyncCheckpoint failed: %v", err)
+	}
+	if err := SaveSyncCheckpoint(db, 1, 4); err != nil {
+		t.Fatalf("SaveSyncCheckpoint failed: %v", err)
+	}
+	if page, _ := GetSyncCheckpoint(db, 1); page != 4 {
+		t.Errorf("Expected checkpoint page 4, got %d", page)
+	}
+
+	if err := ClearSyncCheckpoint(db, 1); err != nil {
+		t.Fatalf("ClearSyncCheckpoint failed: %v", err)
+	}
+	if page, _ := GetSyncCheckpoint(db, 1); page != 0 {
+		t.Errorf("Expected checkpoint to be cleared, got page %d", page)
+	}
+}
+
+func TestSyncAdHoc_CheckpointResumesAfterInterruption(t *testing.T) {
+	var mu sync.Mutex
+	var requestedPages []string
+	failPageTwo := true
+
+	newMockGitHubServer(t, "octo", "widgets", "", map[string]http.HandlerFunc{
+		"/repos/octo/widgets/issues": func(w http.ResponseWriter, r *http.Request) {
+			page := r.URL.Query().Get("page")
+			mu.Lock()
+			requestedPages = append(requestedPages, page)
+			fail := failPageTwo
+			mu.Unlock()
+
+			w.Header().Set("Content-Type", "application/json")
+			switch page {
+			case "1":
+				w.Header().Set("Link", `<http://`+r.Host+`/repos/octo/widgets/issues?page=2>; rel="next"`)
+				_, _ = w.Write([]byte(`[{"id": 301, "number": 1, "title": "Page one", "state": "open"}]`))
+			case "2":
+				if fail {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				_, _ = w.Write([]byte(`[{"id": 302, "number": 2, "title": "Page two", "state": "open"}]`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		},
+	})
+
+	config, err := NewAdHocConfig("octo/widgets", "test-token")
+	if err != nil {
+		t.Fatalf("NewAdHocConfig failed: %v", err)
+	}
+	config.Global.Database = filepath.Join(t.TempDir(), "pivot.db")
+	opts := SyncOptions{Checkpoint: true}
+
+	// First run is interrupted after page one
+	if _, err := SyncAdHoc(config, opts); err == nil {
+		t.Fatal("Expected first sync to fail on page two")
+	}
+
+	db, err := InitMultiProjectDBFromPath(config.Global.Database)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	project, err := FindProjectByOwnerRepo(db, "octo", "widgets")
+	if err != nil {
+		t.Fatalf("Expected project to be recorded: %v", err)
+	}
+	projectID := int64(project.ID)
+
+	if page, _ := GetSyncCheckpoint(db, projectID); page != 1 {
+		t.Fatalf("Expected checkpoint at page 1, got %d", page)
+	}
+
+	// Second run resumes from page two
+	mu.Lock()
+	failPageTwo = false
+	requestedPages = nil
+	mu.Unlock()
+
+	if _, err := SyncAdHoc(config, opts); err != nil {
+		t.Fatalf("Resumed sync failed: %v", err)
+	}
+
+	mu.Lock()
+	pages := append([]string(nil), requestedPages...)
+	mu.Unlock()
+	if len(pages) != 1 || pages[0] != "2" {
+		t.Errorf("Expected resume to request only page 2, got %v", pages)
+	}
+
+	if page, _ := GetSyncCheckpoint(db, projectID); page != 0 {
+		t.Errorf("Expected checkpoint to be cleared after completion, got %d", page)
+	}
+
+	issues, err := GetIssuesForProject(db, projectID)
+	if err != nil {
+		t.Fatalf("Failed to load issues: %v", err)
+	}
+	if len(issues) != 2 {
+		t.Errorf("Expected issues from both pages, got %d", len(issues))
+	}
+}
+
+func TestSyncAdHoc_WithoutCheckpointRestartsFromPageOne(t *testing.T) {
+	var requestedPages []string
+	newMockGitHubServer(t, "octo", "widgets", "", map[string]http.HandlerFunc{
+		"/repos/octo/widgets/issues": func(w http.ResponseWriter, r *http.Request) {
+			requestedPages = append(requestedPages, r.URL.Query().Get("page"))
+			_, _ = w.Write([]byte(`[{"id": 401, "number": 1, "title": "Only", "state": "open"}]`))
+		},
+	})
+
+	config, err := NewAdHocConfig("octo/widgets", "test-token")
+	if err != nil {
+		t.Fatalf("NewAdHocConfig failed: %v", err)
+	}
+	config.Global.Database = filepath.Join(t.TempDir(), "pivot.db")
+
+	db, err := InitMultiProjectDBFromPath(config.Global.Database)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	projectID, err := CreateProject(db, &config.Projects[0])
+	if err != nil {
+		t.Fatalf("CreateProject failed: %v", err)
+	}
+	if err := SaveSyncCheckpoint(db, projectID, 5); err != nil {
+		t.Fatalf("SaveSyncCheckpoint failed: %v", err)
+	}
+
+	if _, err := SyncAdHoc(config, SyncOptions{}); err != nil {
+		t.Fatalf("SyncAdHoc failed: %v", err)
+	}
+	if len(requestedPages) != 1 || requestedPages[0] != "1" {
+		t.Errorf("Expected sync without --checkpoint to start at page 1, got %v", requestedPages)
+	}
+}
+
+func TestCreateProject_ReturnsExistingIDOnUpdate(t *testing.T) {
+	db := newTestMultiProjectDB(t)
+
+	first := &ProjectConfig{Owner: "octo", Repo: "widgets"}
+	other := &ProjectConfig{Owner: "octo", Repo: "gadgets"}
+
+	firstID, err := CreateProject(db, first)
+	if err != nil {
+		t.Fatalf("CreateProject failed: %v", err)
+	}
+	if _, err := CreateProject(db, other); err != nil {
+		t.Fatalf("CreateProject failed: %v", err)
+	}
+
+	againID, err := CreateProject(db, first)
+	if err != nil {
+		t.Fatalf("CreateProject failed: %v", err)
+	}
+	if againID != firstID {
+		t.Errorf("Expected existing project ID %d, got %d", firstID, againID)
+	}
+}
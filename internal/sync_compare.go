@@ -0,0 +1,162 @@
+package internal
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Kinds of difference between the local database and GitHub
+const (
+	DiffChanged    = "changed"     // Stored on both sides with different content
+	DiffRemoteOnly = "remote_only" // On GitHub but not in the local database
+	DiffLocalOnly  = "local_only"  // In the local database but not on GitHub
+)
+
+// FieldDiff is one field whose local and remote values differ
+type FieldDiff struct {
+	Field  string `json:"field"`
+	Local  string `json:"local"`
+	Remote string `json:"remote"`
+}
+
+// IssueDiff describes how one issue differs between the local database and GitHub
+type IssueDiff struct {
+	Number int         `json:"number"`
+	Title  string      `json:"title"`
+	Kind   string      `json:"kind"`
+	Fields []FieldDiff `json:"fields,omitempty"`
+}
+
+// DiffIssue returns the synced fields whose values differ between two copies of an
+// issue. Labels and assignees are compared without regard to order.
+func DiffIssue(local, remote *DBIssue) []FieldDiff {
+	fields := []struct {
+		name          string
+		local, remote string
+	}{
+		{"title", local.Title, remote.Title},
+		{"body", local.Body, remote.Body},
+		{"state", local.State, remote.State},
+		{"labels", normalizeList(splitCommaList(local.Labels)), normalizeList(splitCommaList(remote.Labels))},
+		{"assignees", normalizeList(splitCommaList(local.Assignees)), normalizeList(splitCommaList(remote.Assignees))},
+	}
+
+	var diffs []FieldDiff
+	for _, field := range fields {
+		if field.local != field.remote {
+			diffs = append(diffs, FieldDiff{Field: field.name, Local: field.local, Remote: field.remote})
+		}
+	}
+	return diffs
+}
+
+// compareProjectIssues fetches every issue of a project and compares it with the
+// local copy, without writing to the database
+func compareProjectIssues(db *sql.DB, owner, repo, token string) ([]IssueDiff, error) {
+	remote, err := FetchIssues(owner, repo, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch issues from GitHub: %w", err)
+	}
+
+	local := map[int]DBIssue{}
+	projectID, err := getProjectID(db, owner, repo)
+	if err == nil {
+		issues, err := GetIssuesForProject(db, projectID)
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range issues {
+			local[issue.ID] = issue
+		}
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to look up project: %w", err)
+	}
+
+	var diffs []IssueDiff
+	for _, issue := range remote {
+		remoteIssue := ConvertIssueToDBIssue(&issue)
+		localIssue, ok := local[remoteIssue.ID]
+		if !ok {
+			diffs = append(diffs, IssueDiff{Number: remoteIssue.Number, Title: remoteIssue.Title, Kind: DiffRemoteOnly})
+			continue
+		}
+		delete(local, remoteIssue.ID)
+
+		if fields := DiffIssue(&localIssue, remoteIssue); len(fields) > 0 {
+			diffs = append(diffs, IssueDiff{Number: remoteIssue.Number, Title: remoteIssue.Title, Kind: DiffChanged, Fields: fields})
+		}
+	}
+	for _, issue := range local {
+		diffs = append(diffs, IssueDiff{Number: issue.Number, Title: issue.Title, Kind: DiffLocalOnly})
+	}
+
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Number < diffs[j].Number })
+	return diffs, nil
+}
+
+// compareReportProject is one project of a compare report
+type compareReportProject struct {
+	Project string      `json:"project"`
+	Issues  []IssueDiff `json:"issues"`
+}
+
+// WriteCompareReport writes the differences found by a --compare-only sync to path.
+// Files ending in .md are written as markdown, anything else as JSON.
+func WriteCompareReport(path string, result *SyncResult) error {
+	projects := make([]compareReportProject, 0, len(result.Projects))
+	for _, project := range result.Projects {
+		issues := project.Differences
+		if issues == nil {
+			issues = []IssueDiff{}
+		}
+		projects = append(projects, compareReportProject{Project: project.Owner + "/" + project.Repo, Issues: issues})
+	}
+
+	var data []byte
+	if strings.EqualFold(filepath.Ext(path), ".md") {
+		data = []byte(renderCompareMarkdown(projects))
+	} else {
+		report := struct {
+			GeneratedAt string                 `json:"generated_at"`
+			Projects    []compareReportProject `json:"projects"`
+		}{time.Now().UTC().Format(time.RFC3339), projects}
+
+		var err error
+		if data, err = json.MarshalIndent(report, "", "  "); err != nil {
+			return fmt.Errorf("failed to encode compare report: %w", err)
+		}
+		data = append(data, '\n')
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write compare report: %w", err)
+	}
+	return nil
+}
+
+// renderCompareMarkdown renders a compare report as markdown
+func renderCompareMarkdown(projects []compareReportProject) string {
+	var b strings.Builder
+	b.WriteString("# Sync Compare Report\n")
+	for _, project := range projects {
+		fmt.Fprintf(&b, "\n## %s\n\n", project.Project)
+		if len(project.Issues) == 0 {
+			b.WriteString("No differences.\n")
+			continue
+		}
+		for _, issue := range project.Issues {
+			fmt.Fprintf(&b, "- #%d %s (%s)\n", issue.Number, issue.Title, issue.Kind)
+			for _, field := range issue.Fields {
+				fmt.Fprintf(&b, "  - %s: local %q, remote %q\n", field.Field, field.Local, field.Remote)
+			}
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,167 @@
+package internal
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDiffIssue(t *testing.T) {
+	local := &DBIssue{Title: "Same", Body: "old body", State: "open", Labels: "bug,ui", Assignees: "alice"}
+	remote := &DBIssue{Title: "Same", Body: "new body", State: "open", Labels: "ui, bug", Assignees: "bob"}
+
+	diffs := DiffIssue(local, remote)
+
+	if len(diffs) != 2 {
+		t.Fatalf("Expected 2 differing fields, got %+v", diffs)
+	}
+	if diffs[0].Field != "body" || diffs[0].Local != "old body" || diffs[0].Remote != "new body" {
+		t.Errorf("Expected body diff, got %+v", diffs[0])
+	}
+	if diffs[1].Field != "assignees" || diffs[1].Local != "alice" || diffs[1].Remote != "bob" {
+		t.Errorf("Expected assignees diff, got %+v", diffs[1])
+	}
+}
+
+func TestSyncAdHoc_CompareOnlyReportsDifferencesWithoutWriting(t *testing.T) {
+	issuesJSON := `[
+		{"id": 801, "number": 1, "title": "One", "state": "open", "updated_at": "2024-03-01T10:00:00Z"},
+		{"id": 802, "number": 2, "title": "Two", "state": "open", "updated_at": "2024-03-01T10:00:00Z"},
+		{"id": 803, "number": 3, "title": "Three", "state": "open", "updated_at": "2024-03-01T10:00:00Z"}
+	]`
+	newMockGitHubServer(t, "octo", "widgets", "", map[string]http.HandlerFunc{
+		"/repos/octo/widgets/issues": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(issuesJSON))
+		},
+	})
+
+	config, err := NewAdHocConfig("octo/widgets", "test-token")
+	if err != nil {
+		t.Fatalf("NewAdHocConfig failed: %v", err)
+	}
+	config.Global.Database = filepath.Join(t.TempDir(), "pivot.db")
+	if _, err := SyncAdHoc(config, SyncOptions{}); err != nil {
+		t.Fatalf("Initial sync failed: %v", err)
+	}
+
+	db, err := InitMultiProjectDBFromPath(config.Global.Database)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	if _, err := db.Exec("UPDATE issues SET title = 'One (edited)' WHERE number = 1"); err != nil {
+		t.Fatalf("Failed to edit issue: %v", err)
+	}
+
+	// On GitHub #2 was closed, #3 is gone and #4 is new
+	issuesJSON = `[
+		{"id": 801, "number": 1, "title": "One", "state": "open", "updated_at": "2024-03-01T10:00:00Z"},
+		{"id": 802, "number": 2, "title": "Two", "state": "closed", "updated_at": "2024-03-02T10:00:00Z"},
+		{"id": 804, "number": 4, "title": "Four", "state": "open", "updated_at": "2024-03-02T10:00:00Z"}
+	]`
+	result, err := SyncAdHoc(config, SyncOptions{CompareOnly: true})
+	if err != nil {
+		t.Fatalf("Compare failed: %v", err)
+	}
+
+	diffs := result.Totals().Differences
+	if len(diffs) != 4 {
+		t.Fatalf("Expected 4 differing issues, got %+v", diffs)
+	}
+	expected := []struct {
+		number int
+		kind   string
+		field  string
+	}{
+		{1, DiffChanged, "title"},
+		{2, DiffChanged, "state"},
+		{3, DiffLocalOnly, ""},
+		{4, DiffRemoteOnly, ""},
+	}
+	for i, want := range expected {
+		got := diffs[i]
+		if got.Number != want.number || got.Kind != want.kind {
+			t.Errorf("Expected #%d %s, got #%d %s", want.number, want.kind, got.Number, got.Kind)
+		}
+		if want.field == "" {
+			if len(got.Fields) != 0 {
+				t.Errorf("Expected no field diffs for #%d, got %+v", got.Number, got.Fields)
+			}
+			continue
+		}
+		if len(got.Fields) != 1 || got.Fields[0].Field != want.field {
+			t.Errorf("Expected only %s to differ for #%d, got %+v", want.field, got.Number, got.Fields)
+		}
+	}
+
+	// The database is left untouched
+	var title, state string
+	if err := db.QueryRow("SELECT title FROM issues WHERE number = 1").Scan(&title); err != nil || title != "One (edited)" {
+		t.Errorf("Expected local edit to be kept, got %q (%v)", title, err)
+	}
+	if err := db.QueryRow("SELECT state FROM issues WHERE number = 2").Scan(&state); err != nil || state != "open" {
+		t.Errorf("Expected #2 to stay open locally, got %q (%v)", state, err)
+	}
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM issues WHERE number = 4").Scan(&count); err != nil || count != 0 {
+		t.Errorf("Expected #4 not to be stored, got %d (%v)", count, err)
+	}
+	projectID, _ := getProjectID(db, "octo", "widgets")
+	if since, _ := GetSyncWatermark(db, projectID); since != "2024-03-01T10:00:00Z" {
+		t.Errorf("Expected watermark to be unchanged, got %q", since)
+	}
+}
+
+func TestWriteCompareReport(t *testing.T) {
+	result := &SyncResult{Projects: []ProjectSyncResult{
+		{Owner: "octo", Repo: "widgets", Differences: []IssueDiff{
+			{Number: 2, Title: "Two", Kind: DiffChanged, Fields: []FieldDiff{{Field: "state", Local: "open", Remote: "closed"}}},
+		}},
+		{Owner: "octo", Repo: "gadgets"},
+	}}
+	dir := t.TempDir()
+
+	jsonPath := filepath.Join(dir, "report.json")
+	if err := WriteCompareReport(jsonPath, result); err != nil {
+		t.Fatalf("WriteCompareReport failed: %v", err)
+	}
+	data, err := os.ReadFile(jsonPath)
+	if err != nil {
+		t.Fatalf("Failed to read report: %v", err)
+	}
+	var report struct {
+		Projects []struct {
+			Project string      `json:"project"`
+			Issues  []IssueDiff `json:"issues"`
+		} `json:"projects"`
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("Expected valid JSON report, got %v", err)
+	}
+	if len(report.Projects) != 2 || report.Projects[0].Project != "octo/widgets" {
+		t.Fatalf("Expected both projects in report, got %+v", report.Projects)
+	}
+	if len(report.Projects[0].Issues) != 1 || report.Projects[0].Issues[0].Fields[0].Remote != "closed" {
+		t.Errorf("Expected state diff in report, got %+v", report.Projects[0].Issues)
+	}
+	if report.Projects[1].Issues == nil || len(report.Projects[1].Issues) != 0 {
+		t.Errorf("Expected empty issue list for project without differences, got %+v", report.Projects[1].Issues)
+	}
+
+	mdPath := filepath.Join(dir, "report.md")
+	if err := WriteCompareReport(mdPath, result); err != nil {
+		t.Fatalf("WriteCompareReport failed: %v", err)
+	}
+	markdown, err := os.ReadFile(mdPath)
+	if err != nil {
+		t.Fatalf("Failed to read report: %v", err)
+	}
+	for _, want := range []string{"## octo/widgets", "- #2 Two (changed)", `state: local "open", remote "closed"`, "No differences."} {
+		if !strings.Contains(string(markdown), want) {
+			t.Errorf("Expected markdown report to contain %q, got:\n%s", want, markdown)
+		}
+	}
+}
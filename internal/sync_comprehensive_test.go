@@ -113,7 +113,7 @@ func TestSync_ComprehensiveCoverage(t *testing.T) {
 			}
 
 			// Test the Sync function (Sync() takes no parameters)
-			err := Sync()
+			_, err := Sync()
 
 			if tt.expectError && err == nil {
 				t.Errorf("Expected error for %s, but got none", tt.name)
@@ -248,7 +248,7 @@ func TestSyncProject_ComprehensiveCoverage(t *testing.T) {
 			tt.config.Database = tmpDB.Name()
 
 			// Test the syncProject function (takes db, global config, project config)
-			err = syncProject(db, &GlobalConfig{Token: "test"}, &ProjectConfig{Owner: "test", Repo: "test"})
+			_, err = syncProject(db, &GlobalConfig{Token: "test"}, &ProjectConfig{Owner: "test", Repo: "test"})
 
 			if tt.expectError && err == nil {
 				t.Errorf("Expected error for %s, but got none", tt.name)
@@ -368,7 +368,7 @@ database: ` + tmpDB.Name()
 	defer os.Remove("config.yml")
 
 	// Test sync (should fail due to missing token, but exercises database code)
-	err = Sync() // Sync() takes no parameters
+	_, err = Sync() // Sync() takes no parameters
 	if err == nil {
 		t.Error("Expected sync to fail with empty token")
 	}
@@ -0,0 +1,197 @@
+package internal
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// SyncDiffSchemaVersion is the version of the sync --dry-run JSON document. It changes
+// only when existing fields change meaning or are removed.
+const SyncDiffSchemaVersion = 1
+
+// SyncDiffIssue is one issue a dry-run sync would create, update or conflict on
+type SyncDiffIssue struct {
+	Number int         `json:"number"`
+	Title  string      `json:"title"`
+	Fields []FieldDiff `json:"fields,omitempty"` // Local and remote values; empty for new issues
+}
+
+// SyncDiffCounts counts the fetched issues of a dry-run sync by what the sync would do
+type SyncDiffCounts struct {
+	New        int `json:"new"`
+	Updated    int `json:"updated"`
+	Conflicted int `json:"conflicted"`
+	Unchanged  int `json:"unchanged"`
+}
+
+// add sums other into c
+func (c *SyncDiffCounts) add(other SyncDiffCounts) {
+	c.New += other.New
+	c.Updated += other.Updated
+	c.Conflicted += other.Conflicted
+	c.Unchanged += other.Unchanged
+}
+
+// ProjectSyncDiff is what a sync would change in one project, ordered by issue number
+type ProjectSyncDiff struct {
+	Project    string          `json:"project"`
+	Counts     SyncDiffCounts  `json:"counts"`
+	New        []SyncDiffIssue `json:"new"`
+	Updated    []SyncDiffIssue `json:"updated"`
+	Conflicted []SyncDiffIssue `json:"conflicted"`
+	Errors     []string        `json:"errors,omitempty"`
+}
+
+// SyncDiffDocument is the JSON document of 'pivot sync --dry-run --output json'. It holds
+// no timestamps, so the same fetch always renders the same document.
+type SyncDiffDocument struct {
+	SchemaVersion int               `json:"schema_version"`
+	Counts        SyncDiffCounts    `json:"counts"`
+	Projects      []ProjectSyncDiff `json:"projects"`
+}
+
+// NewSyncDiffDocument collects the dry-run diffs of a sync result. Projects that
+// failed before their diff was computed are listed with their errors only.
+func NewSyncDiffDocument(result *SyncResult) *SyncDiffDocument {
+	doc := &SyncDiffDocument{SchemaVersion: SyncDiffSchemaVersion, Projects: []ProjectSyncDiff{}}
+	for _, project := range result.Projects {
+		diff := ProjectSyncDiff{Project: project.Owner + "/" + project.Repo}
+		if project.DryRun != nil {
+			diff = *project.DryRun
+		}
+		if diff.New == nil {
+			diff.New = []SyncDiffIssue{}
+		}
+		if diff.Updated == nil {
+			diff.Updated = []SyncDiffIssue{}
+		}
+		if diff.Conflicted == nil {
+			diff.Conflicted = []SyncDiffIssue{}
+		}
+		diff.Errors = project.Errors
+		doc.Counts.add(diff.Counts)
+		doc.Projects = append(doc.Projects, diff)
+	}
+	return doc
+}
+
+// planProjectSync fetches the issues a sync of the project would fetch and works out
+// what it would do with each, without writing to the database. Like a sync, it only
+// fetches issues updated since the watermark unless opts.FullSync is set.
+func planProjectSync(db *sql.DB, owner, repo, token string, opts SyncOptions) (*ProjectSyncDiff, error) {
+	diff := &ProjectSyncDiff{Project: owner + "/" + repo}
+
+	projectID, err := getProjectID(db, owner, repo)
+	stored := err == nil
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return diff, fmt.Errorf("failed to look up project: %w", err)
+	}
+
+	var query issuesQuery
+	local := map[int]DBIssue{}
+	if stored {
+		if !opts.FullSync {
+			if query.since, err = GetSyncWatermark(db, projectID); err != nil {
+				return diff, err
+			}
+		}
+		issues, err := GetIssuesForProject(db, projectID)
+		if err != nil {
+			return diff, err
+		}
+		for _, issue := range issues {
+			local[issue.ID] = issue
+		}
+	}
+
+	query.assignee = opts.Assignee
+	if opts.AssignedToMe {
+		if query.assignee, err = AuthenticatedLogin(token); err != nil {
+			return diff, fmt.Errorf("failed to resolve authenticated user: %w", err)
+		}
+	}
+
+	err = forEachIssuesPage(owner, repo, token, 1, query, func(page int, issues []Issue, hasNext bool) error {
+		for _, issue := range issues {
+			remote := ConvertIssueToDBIssue(&issue)
+			if issue.Number < opts.ResumeFrom || (opts.Select != nil && !opts.Select.Matches(remote)) {
+				continue
+			}
+
+			localIssue, exists := local[remote.ID]
+			if !exists {
+				diff.Counts.New++
+				diff.New = append(diff.New, SyncDiffIssue{Number: remote.Number, Title: remote.Title})
+				continue
+			}
+			if opts.OnlyNew {
+				diff.Counts.Unchanged++
+				continue
+			}
+
+			fields := DiffIssue(&localIssue, remote)
+			conflict, err := CheckSyncConflict(db, projectID, remote)
+			if err != nil {
+				return err
+			}
+			switch {
+			case conflict:
+				diff.Counts.Conflicted++
+				diff.Conflicted = append(diff.Conflicted, SyncDiffIssue{Number: remote.Number, Title: remote.Title, Fields: fields})
+			case len(fields) > 0:
+				diff.Counts.Updated++
+				diff.Updated = append(diff.Updated, SyncDiffIssue{Number: remote.Number, Title: remote.Title, Fields: fields})
+			default:
+				diff.Counts.Unchanged++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return diff, fmt.Errorf("failed to fetch issues from GitHub: %w", err)
+	}
+
+	for _, issues := range [][]SyncDiffIssue{diff.New, diff.Updated, diff.Conflicted} {
+		sort.Slice(issues, func(i, j int) bool { return issues[i].Number < issues[j].Number })
+	}
+	return diff, nil
+}
+
+// PrintSyncDiff writes a dry-run sync document for people: one line per project with
+// its counts, followed by the issues that would change
+func PrintSyncDiff(w io.Writer, doc *SyncDiffDocument) {
+	fmt.Fprintln(w, "\n🧪 Dry Run - no changes were made")
+	for _, project := range doc.Projects {
+		if len(project.Errors) > 0 {
+			fmt.Fprintf(w, "  ❌ %s: %s\n", project.Project, strings.Join(project.Errors, "; "))
+			continue
+		}
+		fmt.Fprintf(w, "  %s: %d new, %d updated, %d conflicted, %d unchanged\n", project.Project,
+			project.Counts.New, project.Counts.Updated, project.Counts.Conflicted, project.Counts.Unchanged)
+		for _, issue := range project.New {
+			fmt.Fprintf(w, "    + #%d %s\n", issue.Number, issue.Title)
+		}
+		for _, issue := range project.Updated {
+			fmt.Fprintf(w, "    ~ #%d %s (%s)\n", issue.Number, issue.Title, diffFieldNames(issue.Fields))
+		}
+		for _, issue := range project.Conflicted {
+			fmt.Fprintf(w, "    ⚠ #%d %s (conflict: %s)\n", issue.Number, issue.Title, diffFieldNames(issue.Fields))
+		}
+	}
+}
+
+// diffFieldNames lists the names of changed fields, or "no field changes"
+func diffFieldNames(fields []FieldDiff) string {
+	if len(fields) == 0 {
+		return "no field changes"
+	}
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = field.Field
+	}
+	return strings.Join(names, ", ")
+}
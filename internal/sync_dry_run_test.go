@@ -0,0 +1,199 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// dryRunIssuesJSON is a mixed fetch: #1 unchanged, #2 changed on GitHub, #3 changed on
+// both sides and #4 not stored yet
+const dryRunIssuesJSON = `[
+	{"id": 404, "number": 4, "title": "New on GitHub", "state": "open", "updated_at": "2024-06-04T00:00:00Z"},
+	{"id": 403, "number": 3, "title": "Remote edit", "state": "open", "updated_at": "2024-06-03T00:00:00Z"},
+	{"id": 402, "number": 2, "title": "Renamed", "state": "closed", "labels": [{"name": "bug"}], "updated_at": "2024-06-02T00:00:00Z"},
+	{"id": 401, "number": 1, "title": "Unchanged", "state": "open", "updated_at": "2024-06-01T00:00:00Z"}
+]`
+
+// dryRunGolden is the document the dry run of dryRunIssuesJSON must render
+const dryRunGolden = `{
+  "schema_version": 1,
+  "counts": {
+    "new": 1,
+    "updated": 1,
+    "conflicted": 1,
+    "unchanged": 1
+  },
+  "projects": [
+    {
+      "project": "owner/repo",
+      "counts": {
+        "new": 1,
+        "updated": 1,
+        "conflicted": 1,
+        "unchanged": 1
+      },
+      "new": [
+        {
+          "number": 4,
+          "title": "New on GitHub"
+        }
+      ],
+      "updated": [
+        {
+          "number": 2,
+          "title": "Renamed",
+          "fields": [
+            {
+              "field": "title",
+              "local": "Original",
+              "remote": "Renamed"
+            },
+            {
+              "field": "state",
+              "local": "open",
+              "remote": "closed"
+            },
+            {
+              "field": "labels",
+              "local": "",
+              "remote": "bug"
+            }
+          ]
+        }
+      ],
+      "conflicted": [
+        {
+          "number": 3,
+          "title": "Remote edit",
+          "fields": [
+            {
+              "field": "title",
+              "local": "Local edit",
+              "remote": "Remote edit"
+            }
+          ]
+        }
+      ]
+    }
+  ]
+}`
+
+func TestSyncDryRun_GoldenDiff(t *testing.T) {
+	newMockGitHubServer(t, "owner", "repo", dryRunIssuesJSON, nil)
+	db := newTestMultiProjectDB(t)
+
+	project := &ProjectConfig{Owner: "owner", Repo: "repo"}
+	projectID, err := CreateProject(db, project)
+	if err != nil {
+		t.Fatalf("CreateProject failed: %v", err)
+	}
+	for _, issue := range []*DBIssue{
+		{ID: 401, Number: 1, Title: "Unchanged", State: "open", UpdatedAt: "2024-06-01T00:00:00Z"},
+		{ID: 402, Number: 2, Title: "Original", State: "open", UpdatedAt: "2024-05-01T00:00:00Z"},
+		{ID: 403, Number: 3, Title: "Original", State: "open", UpdatedAt: "2024-05-01T00:00:00Z"},
+	} {
+		if err := SaveIssue(db, projectID, issue); err != nil {
+			t.Fatalf("SaveIssue failed: %v", err)
+		}
+	}
+	// Edit #3 locally after its last sync
+	if _, err := db.Exec("UPDATE issues SET title = 'Local edit', local_modified_at = '2024-05-15T00:00:00Z' WHERE number = 3"); err != nil {
+		t.Fatalf("Failed to edit issue locally: %v", err)
+	}
+
+	result, err := syncProjectWithOptions(db, &GlobalConfig{Token: "test-token"}, project, SyncOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("Dry run failed: %v", err)
+	}
+	if result.Created != 0 || result.Updated != 0 || result.Conflicted != 0 {
+		t.Errorf("Expected a dry run to report no sync counts, got %+v", result)
+	}
+
+	data, err := json.MarshalIndent(NewSyncDiffDocument(&SyncResult{Projects: []ProjectSyncResult{*result}}), "", "  ")
+	if err != nil {
+		t.Fatalf("Failed to encode document: %v", err)
+	}
+	if string(data) != dryRunGolden {
+		t.Errorf("Dry-run document does not match the golden document.\nExpected:\n%s\nGot:\n%s", dryRunGolden, data)
+	}
+
+	// Nothing was written
+	issues, err := GetIssuesForProject(db, projectID)
+	if err != nil {
+		t.Fatalf("Failed to get issues: %v", err)
+	}
+	if len(issues) != 3 {
+		t.Errorf("Expected the new issue not to be stored, got %d issues", len(issues))
+	}
+	for _, issue := range issues {
+		if issue.Number == 2 && issue.Title != "Original" {
+			t.Errorf("Expected #2 to be left unchanged, got %q", issue.Title)
+		}
+	}
+	if since, _ := GetSyncWatermark(db, projectID); since != "" {
+		t.Errorf("Expected a dry run not to advance the watermark, got %q", since)
+	}
+}
+
+func TestSyncDryRun_UnknownProjectIsAllNew(t *testing.T) {
+	newMockGitHubServer(t, "owner", "repo", dryRunIssuesJSON, nil)
+	db := newTestMultiProjectDB(t)
+
+	result, err := syncProjectWithOptions(db, &GlobalConfig{Token: "test-token"}, &ProjectConfig{Owner: "owner", Repo: "repo"}, SyncOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("Dry run failed: %v", err)
+	}
+	if result.DryRun.Counts != (SyncDiffCounts{New: 4}) {
+		t.Errorf("Expected all 4 issues to be new, got %+v", result.DryRun.Counts)
+	}
+	if numbers := []int{result.DryRun.New[0].Number, result.DryRun.New[3].Number}; numbers[0] != 1 || numbers[1] != 4 {
+		t.Errorf("Expected new issues in number order, got %+v", result.DryRun.New)
+	}
+	if _, err := getProjectID(db, "owner", "repo"); err == nil {
+		t.Error("Expected a dry run not to record the project")
+	}
+}
+
+func TestNewSyncDiffDocument_FailedProject(t *testing.T) {
+	doc := NewSyncDiffDocument(&SyncResult{Projects: []ProjectSyncResult{
+		{Owner: "o", Repo: "ok", DryRun: &ProjectSyncDiff{Project: "o/ok", Counts: SyncDiffCounts{New: 2, Unchanged: 1}}},
+		{Owner: "o", Repo: "broken", Errors: []string{"GitHub credential validation failed"}},
+	}})
+
+	if doc.Counts != (SyncDiffCounts{New: 2, Unchanged: 1}) {
+		t.Errorf("Expected totals from the successful project, got %+v", doc.Counts)
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("Failed to encode document: %v", err)
+	}
+	want := `{"project":"o/broken","counts":{"new":0,"updated":0,"conflicted":0,"unchanged":0},"new":[],"updated":[],"conflicted":[],"errors":["GitHub credential validation failed"]}`
+	if !strings.Contains(string(data), want) {
+		t.Errorf("Expected the failed project with empty lists and its error, got %s", data)
+	}
+}
+
+func TestPrintSyncDiff(t *testing.T) {
+	var out bytes.Buffer
+	PrintSyncDiff(&out, &SyncDiffDocument{Projects: []ProjectSyncDiff{{
+		Project:    "o/r",
+		Counts:     SyncDiffCounts{New: 1, Updated: 1, Conflicted: 1, Unchanged: 4},
+		New:        []SyncDiffIssue{{Number: 4, Title: "Fresh"}},
+		Updated:    []SyncDiffIssue{{Number: 2, Title: "Renamed", Fields: []FieldDiff{{Field: "title"}, {Field: "state"}}}},
+		Conflicted: []SyncDiffIssue{{Number: 3, Title: "Both", Fields: []FieldDiff{{Field: "body"}}}},
+	}}})
+
+	for _, want := range []string{
+		"Dry Run - no changes were made",
+		"o/r: 1 new, 1 updated, 1 conflicted, 4 unchanged",
+		"+ #4 Fresh",
+		"~ #2 Renamed (title, state)",
+		"⚠ #3 Both (conflict: body)",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+}
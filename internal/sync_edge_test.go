@@ -73,17 +73,13 @@ database: %s`, dbName)
 	t.Run("SingleLabelOnly", func(t *testing.T) {
 		mockIssues := []Issue{
 			{
-				ID:     2001,
-				Number: 1,
-				Title:  "Single Label Test",
-				Body:   "Test with exactly one label",
-				State:  "open",
-				Labels: []struct {
-					Name string `json:"name"`
-				}{{Name: "bug"}},
-				Assignees: []struct {
-					Login string `json:"login"`
-				}{},
+				ID:        2001,
+				Number:    1,
+				Title:     "Single Label Test",
+				Body:      "Test with exactly one label",
+				State:     "open",
+				Labels:    []IssueLabel{{Name: "bug"}},
+				Assignees: []IssueUser{},
 				CreatedAt: "2023-01-01T00:00:00Z",
 				UpdatedAt: "2023-01-02T00:00:00Z",
 				ClosedAt:  "",
@@ -99,17 +95,13 @@ database: %s`, dbName)
 	t.Run("TwoLabelsOnly", func(t *testing.T) {
 		mockIssues := []Issue{
 			{
-				ID:     2003,
-				Number: 3,
-				Title:  "Two Labels Test",
-				Body:   "Test with exactly two labels",
-				State:  "open",
-				Labels: []struct {
-					Name string `json:"name"`
-				}{{Name: "bug"}, {Name: "urgent"}},
-				Assignees: []struct {
-					Login string `json:"login"`
-				}{},
+				ID:        2003,
+				Number:    3,
+				Title:     "Two Labels Test",
+				Body:      "Test with exactly two labels",
+				State:     "open",
+				Labels:    []IssueLabel{{Name: "bug"}, {Name: "urgent"}},
+				Assignees: []IssueUser{},
 				CreatedAt: "2023-01-01T00:00:00Z",
 				UpdatedAt: "2023-01-02T00:00:00Z",
 				ClosedAt:  "",
@@ -125,17 +117,13 @@ database: %s`, dbName)
 	t.Run("TwoAssigneesOnly", func(t *testing.T) {
 		mockIssues := []Issue{
 			{
-				ID:     2004,
-				Number: 4,
-				Title:  "Two Assignees Test",
-				Body:   "Test with exactly two assignees",
-				State:  "closed",
-				Labels: []struct {
-					Name string `json:"name"`
-				}{},
-				Assignees: []struct {
-					Login string `json:"login"`
-				}{{Login: "dev1"}, {Login: "dev2"}},
+				ID:        2004,
+				Number:    4,
+				Title:     "Two Assignees Test",
+				Body:      "Test with exactly two assignees",
+				State:     "closed",
+				Labels:    []IssueLabel{},
+				Assignees: []IssueUser{{Login: "dev1"}, {Login: "dev2"}},
 				CreatedAt: "2023-01-01T00:00:00Z",
 				UpdatedAt: "2023-01-02T00:00:00Z",
 				ClosedAt:  "2023-01-02T00:00:00Z",
@@ -157,15 +145,11 @@ database: %s`, dbName)
 				Title:  "Maximum Labels",
 				Body:   "Test with many labels",
 				State:  "open",
-				Labels: []struct {
-					Name string `json:"name"`
-				}{
+				Labels: []IssueLabel{
 					{Name: "label1"}, {Name: "label2"}, {Name: "label3"}, {Name: "label4"},
 					{Name: "label5"}, {Name: "label6"}, {Name: "label7"}, {Name: "label8"},
 				},
-				Assignees: []struct {
-					Login string `json:"login"`
-				}{{Login: "dev"}},
+				Assignees: []IssueUser{{Login: "dev"}},
 				CreatedAt: "2023-01-01T00:00:00Z",
 				UpdatedAt: "2023-01-02T00:00:00Z",
 				ClosedAt:  "",
@@ -187,12 +171,8 @@ database: %s`, dbName)
 				Title:  "Maximum Assignees",
 				Body:   "Test with many assignees",
 				State:  "closed",
-				Labels: []struct {
-					Name string `json:"name"`
-				}{{Name: "team-effort"}},
-				Assignees: []struct {
-					Login string `json:"login"`
-				}{
+				Labels: []IssueLabel{{Name: "team-effort"}},
+				Assignees: []IssueUser{
 					{Login: "dev1"}, {Login: "dev2"}, {Login: "dev3"}, {Login: "dev4"},
 					{Login: "dev5"}, {Login: "dev6"}, {Login: "dev7"}, {Login: "dev8"},
 				},
@@ -246,17 +226,13 @@ database: error_recovery.db`
 
 		// First, insert a valid issue to set up the database
 		validIssue := Issue{
-			ID:     3001,
-			Number: 1,
-			Title:  "Valid Issue",
-			Body:   "This should work",
-			State:  "open",
-			Labels: []struct {
-				Name string `json:"name"`
-			}{{Name: "valid"}},
-			Assignees: []struct {
-				Login string `json:"login"`
-			}{{Login: "validuser"}},
+			ID:        3001,
+			Number:    1,
+			Title:     "Valid Issue",
+			Body:      "This should work",
+			State:     "open",
+			Labels:    []IssueLabel{{Name: "valid"}},
+			Assignees: []IssueUser{{Login: "validuser"}},
 			CreatedAt: "2023-01-01T00:00:00Z",
 			UpdatedAt: "2023-01-02T00:00:00Z",
 			ClosedAt:  "",
@@ -294,17 +270,13 @@ database: error_recovery.db`
 
 		// Try to insert again - this will trigger the error handling path
 		problemIssue := Issue{
-			ID:     3002,
-			Number: 2,
-			Title:  "Problem Issue",
-			Body:   "This should fail",
-			State:  "open",
-			Labels: []struct {
-				Name string `json:"name"`
-			}{{Name: "error"}},
-			Assignees: []struct {
-				Login string `json:"login"`
-			}{{Login: "erroruser"}},
+			ID:        3002,
+			Number:    2,
+			Title:     "Problem Issue",
+			Body:      "This should fail",
+			State:     "open",
+			Labels:    []IssueLabel{{Name: "error"}},
+			Assignees: []IssueUser{{Login: "erroruser"}},
 			CreatedAt: "2023-01-01T00:00:00Z",
 			UpdatedAt: "2023-01-02T00:00:00Z",
 			ClosedAt:  "",
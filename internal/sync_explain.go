@@ -0,0 +1,114 @@
+package internal
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Sync decisions reported by --explain
+const (
+	SyncDecisionCreated    = "created"
+	SyncDecisionUpdated    = "updated"
+	SyncDecisionConflict   = "conflict"
+	SyncDecisionSkipped    = "skipped"
+	SyncDecisionRegression = "regression"
+	SyncDecisionResume     = "before_resume"
+	SyncDecisionExisting   = "existing"
+)
+
+// SyncDecision records why sync handled an issue the way it did, together with the
+// inputs of the decision. Written as one JSON line per issue by sync --explain.
+type SyncDecision struct {
+	Project         string `json:"project"`
+	Issue           int    `json:"issue"`
+	Decision        string `json:"decision"`
+	Reason          string `json:"reason"`
+	RemoteUpdatedAt string `json:"remote_updated_at,omitempty"`
+	LocalModifiedAt string `json:"local_modified_at,omitempty"`
+	StoredHash      string `json:"stored_hash,omitempty"`
+	RemoteHash      string `json:"remote_hash"`
+	Filter          string `json:"filter,omitempty"`
+	FilterMatched   *bool  `json:"filter_matched,omitempty"`
+}
+
+// readSyncHashState returns the stored sync hash and local modification time of an issue
+func readSyncHashState(db *sql.DB, projectID int64, githubID int) (storedHash, localModifiedAt string, err error) {
+	var hash, modified sql.NullString
+	err = db.QueryRow(`
+		SELECT sync_hash, local_modified_at
+		FROM issues
+		WHERE github_id = ? AND project_id = ?`, githubID, projectID).Scan(&hash, &modified)
+	if err == sql.ErrNoRows {
+		return "", "", nil
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read sync hash: %w", err)
+	}
+	return hash.String, modified.String, nil
+}
+
+// explainSyncDecision writes the decision for an issue to w. It does nothing when w is nil.
+func explainSyncDecision(w io.Writer, db *sql.DB, projectID int64, result *ProjectSyncResult,
+	issue *DBIssue, number int, decision string, opts SyncOptions) error {
+	if w == nil {
+		return nil
+	}
+
+	storedHash, localModifiedAt, err := readSyncHashState(db, projectID, issue.ID)
+	if err != nil {
+		return err
+	}
+	remoteHash := ComputeSyncHash(issue)
+
+	entry := SyncDecision{
+		Project:         result.Owner + "/" + result.Repo,
+		Issue:           number,
+		Decision:        decision,
+		RemoteUpdatedAt: issue.UpdatedAt,
+		LocalModifiedAt: localModifiedAt,
+		StoredHash:      storedHash,
+		RemoteHash:      remoteHash,
+	}
+	if opts.Select != nil && decision != SyncDecisionResume {
+		matched := decision != SyncDecisionSkipped
+		entry.Filter = opts.Select.String()
+		entry.FilterMatched = &matched
+	}
+
+	switch decision {
+	case SyncDecisionSkipped:
+		entry.Reason = "does not match --select"
+	case SyncDecisionConflict:
+		entry.Reason = "modified locally and changed on GitHub since the last sync; local copy kept"
+	case SyncDecisionRegression:
+		entry.Reason = "remote updated_at is older than the stored copy; stored copy kept"
+	case SyncDecisionResume:
+		entry.Reason = fmt.Sprintf("numbered below --resume-from %d; not processed", opts.ResumeFrom)
+	case SyncDecisionExisting:
+		entry.Reason = "already stored locally; left untouched by --only-new"
+	case SyncDecisionCreated:
+		entry.Reason = "not stored locally yet"
+	case SyncDecisionUpdated:
+		switch {
+		case storedHash == "":
+			entry.Reason = "no sync hash stored; refreshed from GitHub"
+		case localModifiedAt != "":
+			entry.Reason = "modified locally but unchanged on GitHub since the last sync; refreshed from GitHub"
+		case storedHash == remoteHash:
+			entry.Reason = "unchanged on GitHub since the last sync"
+		default:
+			entry.Reason = "changed on GitHub since the last sync"
+		}
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode sync decision: %w", err)
+	}
+	if _, err := fmt.Fprintln(w, string(data)); err != nil {
+		return fmt.Errorf("failed to write sync decision: %w", err)
+	}
+	return nil
+}
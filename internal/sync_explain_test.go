@@ -0,0 +1,110 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSyncAdHoc_ExplainConflictAndSkip(t *testing.T) {
+	issuesJSON := `[
+		{"id": 1001, "number": 1, "title": "First", "body": "one", "state": "open", "labels": [{"name": "team-a"}], "updated_at": "2024-03-01T10:00:00Z"},
+		{"id": 1002, "number": 2, "title": "Second", "body": "two", "state": "open", "labels": [{"name": "team-b"}], "updated_at": "2024-03-01T10:00:00Z"}
+	]`
+	newMockGitHubServer(t, "octo", "widgets", "", map[string]http.HandlerFunc{
+		"/repos/octo/widgets/issues": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(issuesJSON))
+		},
+	})
+
+	config, err := NewAdHocConfig("octo/widgets", "test-token")
+	if err != nil {
+		t.Fatalf("NewAdHocConfig failed: %v", err)
+	}
+	config.Global.Database = filepath.Join(t.TempDir(), "pivot.db")
+
+	if _, err := SyncAdHoc(config, SyncOptions{}); err != nil {
+		t.Fatalf("First sync failed: %v", err)
+	}
+
+	db, err := InitMultiProjectDBFromPath(config.Global.Database)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if _, err := db.Exec("UPDATE issues SET title = 'Edited locally', local_modified_at = '2024-03-02T09:00:00Z' WHERE number = 1"); err != nil {
+		t.Fatalf("Failed to edit issue: %v", err)
+	}
+	db.Close()
+
+	// #1 changes on GitHub too, #2 no longer matches the selection
+	issuesJSON = `[
+		{"id": 1001, "number": 1, "title": "First", "body": "one, edited remotely", "state": "open", "labels": [{"name": "team-a"}], "updated_at": "2024-03-02T10:00:00Z"},
+		{"id": 1002, "number": 2, "title": "Second", "body": "two", "state": "open", "labels": [{"name": "team-b"}], "updated_at": "2024-03-02T10:00:00Z"}
+	]`
+	filter, err := ParseIssueFilter("-label:team-b")
+	if err != nil {
+		t.Fatalf("ParseIssueFilter failed: %v", err)
+	}
+	var explain bytes.Buffer
+	if _, err := SyncAdHoc(config, SyncOptions{FullSync: true, Select: filter, Explain: &explain}); err != nil {
+		t.Fatalf("Second sync failed: %v", err)
+	}
+
+	decisions := make(map[int]SyncDecision)
+	for _, line := range strings.Split(strings.TrimSpace(explain.String()), "\n") {
+		var decision SyncDecision
+		if err := json.Unmarshal([]byte(line), &decision); err != nil {
+			t.Fatalf("Explain line is not JSON: %v (%s)", err, line)
+		}
+		decisions[decision.Issue] = decision
+	}
+	if len(decisions) != 2 {
+		t.Fatalf("Expected a decision for each issue, got: %s", explain.String())
+	}
+
+	conflict := decisions[1]
+	if conflict.Decision != SyncDecisionConflict || !strings.Contains(conflict.Reason, "modified locally and changed on GitHub") {
+		t.Errorf("Expected conflict with reason for #1, got %+v", conflict)
+	}
+	if conflict.StoredHash == "" || conflict.StoredHash == conflict.RemoteHash {
+		t.Errorf("Expected differing stored and remote hashes for #1, got %+v", conflict)
+	}
+	if conflict.LocalModifiedAt != "2024-03-02T09:00:00Z" || conflict.RemoteUpdatedAt != "2024-03-02T10:00:00Z" {
+		t.Errorf("Expected timestamps for #1, got %+v", conflict)
+	}
+
+	skipped := decisions[2]
+	if skipped.Decision != SyncDecisionSkipped || skipped.Reason != "does not match --select" {
+		t.Errorf("Expected #2 skipped by --select, got %+v", skipped)
+	}
+	if skipped.Filter != "-label:team-b" || skipped.FilterMatched == nil || *skipped.FilterMatched {
+		t.Errorf("Expected unmatched filter for #2, got %+v", skipped)
+	}
+}
+
+func TestSyncAdHoc_ExplainCreated(t *testing.T) {
+	newMockGitHubServer(t, "octo", "widgets",
+		`[{"id": 1101, "number": 1, "title": "New", "state": "open", "updated_at": "2024-03-01T10:00:00Z"}]`, nil)
+
+	config, err := NewAdHocConfig("octo/widgets", "test-token")
+	if err != nil {
+		t.Fatalf("NewAdHocConfig failed: %v", err)
+	}
+	config.Global.Database = filepath.Join(t.TempDir(), "pivot.db")
+
+	var explain bytes.Buffer
+	if _, err := SyncAdHoc(config, SyncOptions{Explain: &explain}); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	var decision SyncDecision
+	if err := json.Unmarshal(explain.Bytes(), &decision); err != nil {
+		t.Fatalf("Explain output is not JSON: %v (%s)", err, explain.String())
+	}
+	if decision.Decision != SyncDecisionCreated || decision.StoredHash != "" || decision.RemoteHash == "" || decision.Filter != "" {
+		t.Errorf("Expected created decision without stored hash or filter, got %+v", decision)
+	}
+}
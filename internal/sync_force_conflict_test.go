@@ -0,0 +1,142 @@
+package internal
+
+import (
+	"bytes"
+	"database/sql"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+)
+
+// setupForcedConflictSync syncs two issues from a mock GitHub, edits #1 locally and
+// then renames it on GitHub, so the next sync conflicts on it. It returns the path
+// of the database.
+func setupForcedConflictSync(t *testing.T) string {
+	t.Helper()
+	chdirTemp(t)
+
+	configContent := `global:
+  database: ./pivot.db
+  token: test-token
+projects:
+  - owner: octo
+    repo: widgets
+`
+	if err := os.WriteFile("config.yml", []byte(configContent), 0600); err != nil {
+		t.Fatalf("Failed to create test config: %v", err)
+	}
+
+	issuesJSON := `[
+		{"id": 701, "number": 1, "title": "First", "state": "open", "updated_at": "2024-01-01T00:00:00Z"},
+		{"id": 702, "number": 2, "title": "Second", "state": "open", "updated_at": "2024-01-01T00:00:00Z"}
+	]`
+	newMockGitHubServer(t, "octo", "widgets", "", map[string]http.HandlerFunc{
+		"/repos/octo/widgets/issues": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(issuesJSON))
+		},
+	})
+	if _, err := SyncMultiProjectWithOptions("", SyncOptions{FullSync: true}); err != nil {
+		t.Fatalf("Initial sync failed: %v", err)
+	}
+
+	db, err := InitMultiProjectDBFromPath("./pivot.db")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	if _, err := db.Exec("UPDATE issues SET title = 'First, edited locally', local_modified_at = '2024-01-02T00:00:00Z' WHERE github_id = 701"); err != nil {
+		t.Fatalf("Failed to edit issue locally: %v", err)
+	}
+	issuesJSON = `[
+		{"id": 701, "number": 1, "title": "First, renamed upstream", "state": "open", "updated_at": "2024-01-03T00:00:00Z"},
+		{"id": 702, "number": 2, "title": "Second", "state": "open", "updated_at": "2024-01-01T00:00:00Z"}
+	]`
+	return "./pivot.db"
+}
+
+// forcedConflictIssue returns the stored title, local modification time and sync state of issue #1
+func forcedConflictIssue(t *testing.T, path string) (string, string, SyncState) {
+	t.Helper()
+	db, err := InitMultiProjectDBFromPath(path)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	var title string
+	var localModifiedAt sql.NullString
+	var localID int64
+	if err := db.QueryRow("SELECT rowid, title, local_modified_at FROM issues WHERE github_id = 701").Scan(&localID, &title, &localModifiedAt); err != nil {
+		t.Fatalf("Failed to read issue: %v", err)
+	}
+	state, err := GetSyncState(db, localID)
+	if err != nil || state == nil {
+		t.Fatalf("Failed to read sync state: %v", err)
+	}
+	return title, localModifiedAt.String, state.SyncState
+}
+
+func TestSyncForceConflictAsRemote(t *testing.T) {
+	path := setupForcedConflictSync(t)
+	configBefore, _ := os.ReadFile("config.yml")
+
+	result, err := SyncMultiProjectWithOptions("", SyncOptions{FullSync: true, ForceConflictAs: ResolutionRemote})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	totals := result.Totals()
+	if totals.Conflicted != 0 || totals.ForcedResolutions != 1 || totals.Updated != 2 {
+		t.Errorf("Expected the conflict to be resolved and stored, got %+v", totals)
+	}
+
+	title, localModifiedAt, state := forcedConflictIssue(t, path)
+	if title != "First, renamed upstream" || localModifiedAt != "" || state != SyncStateSynced {
+		t.Errorf("Expected the GitHub copy to win, got title %q, local_modified_at %q, state %s", title, localModifiedAt, state)
+	}
+
+	var out bytes.Buffer
+	PrintSyncResult(&out, result)
+	if !strings.Contains(out.String(), "1 conflicts resolved by --force-conflict-as") {
+		t.Errorf("Expected the forced resolutions in the summary, got:\n%s", out.String())
+	}
+
+	// The override is not persisted
+	if configAfter, _ := os.ReadFile("config.yml"); !bytes.Equal(configBefore, configAfter) {
+		t.Errorf("Expected config.yml to be unchanged, got:\n%s", configAfter)
+	}
+}
+
+func TestSyncForceConflictAsLocal(t *testing.T) {
+	path := setupForcedConflictSync(t)
+	configBefore, _ := os.ReadFile("config.yml")
+
+	result, err := SyncMultiProjectWithOptions("", SyncOptions{FullSync: true, ForceConflictAs: ResolutionLocal})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if totals := result.Totals(); totals.Conflicted != 0 || totals.ForcedResolutions != 1 {
+		t.Errorf("Expected the conflict to be resolved toward local, got %+v", totals)
+	}
+
+	title, localModifiedAt, state := forcedConflictIssue(t, path)
+	if title != "First, edited locally" || localModifiedAt == "" || state != SyncStateLocalModified {
+		t.Errorf("Expected the local copy to win, got title %q, local_modified_at %q, state %s", title, localModifiedAt, state)
+	}
+
+	if configAfter, _ := os.ReadFile("config.yml"); !bytes.Equal(configBefore, configAfter) {
+		t.Errorf("Expected config.yml to be unchanged, got:\n%s", configAfter)
+	}
+
+	// Only that run was affected: the next sync marks the conflict as usual
+	result, err = SyncMultiProjectWithOptions("", SyncOptions{FullSync: true})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if totals := result.Totals(); totals.Conflicted != 1 || totals.ForcedResolutions != 0 {
+		t.Errorf("Expected a regular conflict without the override, got %+v", totals)
+	}
+	if _, _, state := forcedConflictIssue(t, path); state != SyncStateConflicted {
+		t.Errorf("Expected the issue to be CONFLICTED, got %s", state)
+	}
+}
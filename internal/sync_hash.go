@@ -0,0 +1,47 @@
+package internal
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"sort"
+	"strings"
+)
+
+// normalizeList dedupes and sorts values and joins them with commas so that
+// ordering differences reported by GitHub never look like changes
+func normalizeList(values []string) string {
+	sorted := DedupeList(values)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
+
+// ComputeSyncHash returns a content hash of the synced fields of an issue.
+// Labels and assignees are normalized first, so their order does not affect the hash.
+func ComputeSyncHash(issue *DBIssue) string {
+	content := strings.Join([]string{
+		issue.Title,
+		issue.Body,
+		issue.State,
+		normalizeList(splitCommaList(issue.Labels)),
+		normalizeList(splitCommaList(issue.Assignees)),
+	}, "\x00")
+
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// CheckSyncConflict reports whether an incoming issue conflicts with the stored copy:
+// the issue was modified locally and its content changed on GitHub since the last sync
+func CheckSyncConflict(db *sql.DB, projectID int64, issue *DBIssue) (bool, error) {
+	storedHash, localModifiedAt, err := readSyncHashState(db, projectID, issue.ID)
+	if err != nil {
+		return false, err
+	}
+
+	if localModifiedAt == "" || storedHash == "" {
+		return false, nil
+	}
+
+	return storedHash != ComputeSyncHash(issue), nil
+}
@@ -0,0 +1,96 @@
+package internal
+
+import (
+	"testing"
+)
+
+func TestComputeSyncHash_IgnoresOrdering(t *testing.T) {
+	first := &DBIssue{Title: "Bug", Body: "Details", State: "open", Labels: "bug,urgent,ui", Assignees: "bob,alice"}
+	second := &DBIssue{Title: "Bug", Body: "Details", State: "open", Labels: "ui, bug,urgent", Assignees: "alice,bob"}
+
+	if ComputeSyncHash(first) != ComputeSyncHash(second) {
+		t.Error("Expected identical hashes for reordered labels and assignees")
+	}
+
+	second.Title = "Bug (edited)"
+	if ComputeSyncHash(first) == ComputeSyncHash(second) {
+		t.Error("Expected different hashes when the title changes")
+	}
+}
+
+func TestConvertIssueToDBIssue_SortsLabelsAndAssignees(t *testing.T) {
+	issues, _, err := decodeIssues([]byte(`[{"id": 1, "labels": [{"name": "urgent"}, {"name": "bug"}],
+		"assignees": [{"login": "carol"}, {"login": "alice"}]}]`))
+	if err != nil {
+		t.Fatalf("Failed to decode issue: %v", err)
+	}
+
+	dbIssue := ConvertIssueToDBIssue(&issues[0])
+	if dbIssue.Labels != "bug,urgent" {
+		t.Errorf("Expected sorted labels 'bug,urgent', got %s", dbIssue.Labels)
+	}
+	if dbIssue.Assignees != "alice,carol" {
+		t.Errorf("Expected sorted assignees 'alice,carol', got %s", dbIssue.Assignees)
+	}
+}
+
+func TestCheckSyncConflict_ReorderedLabels(t *testing.T) {
+	db := newTestMultiProjectDB(t)
+	projectID, err := CreateProject(db, &ProjectConfig{Owner: "owner", Repo: "repo"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	fromGitHub := func(labels string) *DBIssue {
+		issues, _, err := decodeIssues([]byte(`[{"id": 500, "number": 5, "title": "Same issue", "body": "Body",
+			"state": "open", "labels": [` + labels + `]}]`))
+		if err != nil {
+			t.Fatalf("Failed to decode issue: %v", err)
+		}
+		return ConvertIssueToDBIssue(&issues[0])
+	}
+
+	first := fromGitHub(`{"name": "bug"}, {"name": "ui"}`)
+	if err := SaveIssue(db, projectID, first); err != nil {
+		t.Fatalf("SaveIssue failed: %v", err)
+	}
+
+	// Mark the issue as modified locally so a remote change would be a conflict
+	if _, err := db.Exec("UPDATE issues SET local_modified_at = '2024-01-01T00:00:00Z' WHERE github_id = 500"); err != nil {
+		t.Fatalf("Failed to mark issue as locally modified: %v", err)
+	}
+
+	second := fromGitHub(`{"name": "ui"}, {"name": "bug"}`)
+	if ComputeSyncHash(first) != ComputeSyncHash(second) {
+		t.Fatal("Expected identical hashes for the same issue with reordered labels")
+	}
+
+	conflict, err := CheckSyncConflict(db, projectID, second)
+	if err != nil {
+		t.Fatalf("CheckSyncConflict failed: %v", err)
+	}
+	if conflict {
+		t.Error("Expected no conflict for reordered labels")
+	}
+
+	changed := fromGitHub(`{"name": "bug"}, {"name": "ui"}, {"name": "regression"}`)
+	conflict, err = CheckSyncConflict(db, projectID, changed)
+	if err != nil {
+		t.Fatalf("CheckSyncConflict failed: %v", err)
+	}
+	if !conflict {
+		t.Error("Expected conflict when labels really changed remotely")
+	}
+}
+
+func TestCheckSyncConflict_UnknownIssue(t *testing.T) {
+	db := newTestMultiProjectDB(t)
+
+	conflict, err := CheckSyncConflict(db, 1, &DBIssue{ID: 999})
+	if err != nil {
+		t.Fatalf("CheckSyncConflict failed: %v", err)
+	}
+	if conflict {
+		t.Error("Expected no conflict for an issue that was never synced")
+	}
+}
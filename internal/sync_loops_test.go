@@ -73,17 +73,13 @@ database: %s`, dbName)
 	t.Run("SingleLabelSingleAssignee", func(t *testing.T) {
 		mockIssues := []Issue{
 			{
-				ID:     2001,
-				Number: 1,
-				Title:  "Single Label Single Assignee",
-				Body:   "Test with exactly one label and one assignee",
-				State:  "open",
-				Labels: []struct {
-					Name string `json:"name"`
-				}{{Name: "bug"}},
-				Assignees: []struct {
-					Login string `json:"login"`
-				}{{Login: "developer"}},
+				ID:        2001,
+				Number:    1,
+				Title:     "Single Label Single Assignee",
+				Body:      "Test with exactly one label and one assignee",
+				State:     "open",
+				Labels:    []IssueLabel{{Name: "bug"}},
+				Assignees: []IssueUser{{Login: "developer"}},
 				CreatedAt: "2023-01-01T00:00:00Z",
 				UpdatedAt: "2023-01-02T00:00:00Z",
 				ClosedAt:  "",
@@ -100,17 +96,13 @@ database: %s`, dbName)
 		// This tests the i > 0 conditions in both loops
 		mockIssues := []Issue{
 			{
-				ID:     2002,
-				Number: 2,
-				Title:  "Two Labels Three Assignees",
-				Body:   "Test with two labels and three assignees",
-				State:  "closed",
-				Labels: []struct {
-					Name string `json:"name"`
-				}{{Name: "bug"}, {Name: "urgent"}},
-				Assignees: []struct {
-					Login string `json:"login"`
-				}{{Login: "dev1"}, {Login: "dev2"}, {Login: "dev3"}},
+				ID:        2002,
+				Number:    2,
+				Title:     "Two Labels Three Assignees",
+				Body:      "Test with two labels and three assignees",
+				State:     "closed",
+				Labels:    []IssueLabel{{Name: "bug"}, {Name: "urgent"}},
+				Assignees: []IssueUser{{Login: "dev1"}, {Login: "dev2"}, {Login: "dev3"}},
 				CreatedAt: "2023-01-01T00:00:00Z",
 				UpdatedAt: "2023-01-02T00:00:00Z",
 				ClosedAt:  "2023-01-02T00:00:00Z",
@@ -127,17 +119,13 @@ database: %s`, dbName)
 		// Test more iterations of the label loop
 		mockIssues := []Issue{
 			{
-				ID:     2003,
-				Number: 3,
-				Title:  "Four Labels One Assignee",
-				Body:   "Test with four labels and one assignee",
-				State:  "open",
-				Labels: []struct {
-					Name string `json:"name"`
-				}{{Name: "bug"}, {Name: "urgent"}, {Name: "frontend"}, {Name: "critical"}},
-				Assignees: []struct {
-					Login string `json:"login"`
-				}{{Login: "frontend-dev"}},
+				ID:        2003,
+				Number:    3,
+				Title:     "Four Labels One Assignee",
+				Body:      "Test with four labels and one assignee",
+				State:     "open",
+				Labels:    []IssueLabel{{Name: "bug"}, {Name: "urgent"}, {Name: "frontend"}, {Name: "critical"}},
+				Assignees: []IssueUser{{Login: "frontend-dev"}},
 				CreatedAt: "2023-01-01T00:00:00Z",
 				UpdatedAt: "2023-01-02T00:00:00Z",
 				ClosedAt:  "",
@@ -154,17 +142,13 @@ database: %s`, dbName)
 		// Test more iterations of the assignee loop
 		mockIssues := []Issue{
 			{
-				ID:     2004,
-				Number: 4,
-				Title:  "One Label Five Assignees",
-				Body:   "Test with one label and five assignees",
-				State:  "closed",
-				Labels: []struct {
-					Name string `json:"name"`
-				}{{Name: "team-effort"}},
-				Assignees: []struct {
-					Login string `json:"login"`
-				}{{Login: "dev1"}, {Login: "dev2"}, {Login: "dev3"}, {Login: "dev4"}, {Login: "dev5"}},
+				ID:        2004,
+				Number:    4,
+				Title:     "One Label Five Assignees",
+				Body:      "Test with one label and five assignees",
+				State:     "closed",
+				Labels:    []IssueLabel{{Name: "team-effort"}},
+				Assignees: []IssueUser{{Login: "dev1"}, {Login: "dev2"}, {Login: "dev3"}, {Login: "dev4"}, {Login: "dev5"}},
 				CreatedAt: "2023-01-01T00:00:00Z",
 				UpdatedAt: "2023-01-02T00:00:00Z",
 				ClosedAt:  "2023-01-02T00:00:00Z",
@@ -183,36 +167,24 @@ database: %s`, dbName)
 			// Issue 1: No labels, no assignees
 			{
 				ID: 2005, Number: 5, Title: "Empty", State: "open",
-				Labels: []struct {
-					Name string `json:"name"`
-				}{},
-				Assignees: []struct {
-					Login string `json:"login"`
-				}{},
+				Labels:    []IssueLabel{},
+				Assignees: []IssueUser{},
 				CreatedAt: "2023-01-01T00:00:00Z", UpdatedAt: "2023-01-02T00:00:00Z", ClosedAt: "",
 			},
 			// Issue 2: Three labels, two assignees
 			{
 				ID: 2006, Number: 6, Title: "Three Two", State: "closed",
-				Labels: []struct {
-					Name string `json:"name"`
-				}{{Name: "bug"}, {Name: "backend"}, {Name: "database"}},
-				Assignees: []struct {
-					Login string `json:"login"`
-				}{{Login: "backend-dev1"}, {Login: "backend-dev2"}},
+				Labels:    []IssueLabel{{Name: "bug"}, {Name: "backend"}, {Name: "database"}},
+				Assignees: []IssueUser{{Login: "backend-dev1"}, {Login: "backend-dev2"}},
 				CreatedAt: "2023-01-01T00:00:00Z", UpdatedAt: "2023-01-02T00:00:00Z", ClosedAt: "2023-01-02T00:00:00Z",
 			},
 			// Issue 3: Many labels, many assignees (stress test the loops)
 			{
 				ID: 2007, Number: 7, Title: "Many Many", State: "open",
-				Labels: []struct {
-					Name string `json:"name"`
-				}{
+				Labels: []IssueLabel{
 					{Name: "l1"}, {Name: "l2"}, {Name: "l3"}, {Name: "l4"}, {Name: "l5"}, {Name: "l6"},
 				},
-				Assignees: []struct {
-					Login string `json:"login"`
-				}{
+				Assignees: []IssueUser{
 					{Login: "a1"}, {Login: "a2"}, {Login: "a3"}, {Login: "a4"},
 				},
 				CreatedAt: "2023-01-01T00:00:00Z", UpdatedAt: "2023-01-02T00:00:00Z", ClosedAt: "",
@@ -264,12 +236,8 @@ database: error_handling.db`
 		// First insert a valid issue
 		validIssue := Issue{
 			ID: 3001, Number: 1, Title: "Valid", Body: "Valid", State: "open",
-			Labels: []struct {
-				Name string `json:"name"`
-			}{{Name: "valid"}},
-			Assignees: []struct {
-				Login string `json:"login"`
-			}{{Login: "validuser"}},
+			Labels:    []IssueLabel{{Name: "valid"}},
+			Assignees: []IssueUser{{Login: "validuser"}},
 			CreatedAt: "2023-01-01T00:00:00Z", UpdatedAt: "2023-01-02T00:00:00Z", ClosedAt: "",
 		}
 
@@ -305,12 +273,8 @@ database: error_handling.db`
 		// Try to insert with corrupted database - this triggers the error handling
 		problemIssue := Issue{
 			ID: 3002, Number: 2, Title: "Problem", Body: "Problem", State: "open",
-			Labels: []struct {
-				Name string `json:"name"`
-			}{{Name: "error"}},
-			Assignees: []struct {
-				Login string `json:"login"`
-			}{{Login: "erroruser"}},
+			Labels:    []IssueLabel{{Name: "error"}},
+			Assignees: []IssueUser{{Login: "erroruser"}},
 			CreatedAt: "2023-01-01T00:00:00Z", UpdatedAt: "2023-01-02T00:00:00Z", ClosedAt: "",
 		}
 
@@ -390,26 +354,18 @@ database: special_chars.db`
 		specialIssues := []Issue{
 			{
 				ID: 4001, Number: 1, Title: "Commas in labels", State: "open",
-				Labels: []struct {
-					Name string `json:"name"`
-				}{
+				Labels: []IssueLabel{
 					{Name: "label-with-dashes"}, {Name: "label_with_underscores"}, {Name: "label123"},
 				},
-				Assignees: []struct {
-					Login string `json:"login"`
-				}{
+				Assignees: []IssueUser{
 					{Login: "user-with-dashes"}, {Login: "user_with_underscores"},
 				},
 				CreatedAt: "2023-01-01T00:00:00Z", UpdatedAt: "2023-01-02T00:00:00Z", ClosedAt: "",
 			},
 			{
 				ID: 4002, Number: 2, Title: "Empty then filled", State: "closed",
-				Labels: []struct {
-					Name string `json:"name"`
-				}{}, // Empty first
-				Assignees: []struct {
-					Login string `json:"login"`
-				}{{Login: "solo"}}, // Then one
+				Labels:    []IssueLabel{},               // Empty first
+				Assignees: []IssueUser{{Login: "solo"}}, // Then one
 				CreatedAt: "2023-01-01T00:00:00Z", UpdatedAt: "2023-01-02T00:00:00Z", ClosedAt: "2023-01-02T00:00:00Z",
 			},
 		}
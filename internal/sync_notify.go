@@ -0,0 +1,98 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime"
+	"time"
+)
+
+// NotifySettings configures the hooks fired after a sync run with --notify
+type NotifySettings struct {
+	Command         string `yaml:"command,omitempty"`           // Shell command receiving the sync result JSON on stdin
+	Webhook         string `yaml:"webhook,omitempty"`           // URL the sync result JSON is POSTed to
+	OnlyOnConflicts bool   `yaml:"only_on_conflicts,omitempty"` // Fire only when the sync kept conflicting issues
+}
+
+// notifyTimeout bounds how long a hook may delay the end of a sync; replaced in tests
+var notifyTimeout = 30 * time.Second
+
+// IsEmpty reports whether no hook is configured
+func (s NotifySettings) IsEmpty() bool {
+	return s.Command == "" && s.Webhook == ""
+}
+
+// NotifySyncResult sends the sync result to the configured hooks. Hook failures never
+// fail the sync; they are written to w as warnings.
+func NotifySyncResult(w io.Writer, settings NotifySettings, result *SyncResult) {
+	if settings.IsEmpty() || result == nil {
+		return
+	}
+	if settings.OnlyOnConflicts && result.Totals().Conflicted == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(result)
+	if err != nil {
+		fmt.Fprintf(w, "⚠ Failed to encode sync result for notification: %v\n", err)
+		return
+	}
+
+	if settings.Command != "" {
+		if err := runNotifyCommand(settings.Command, payload); err != nil {
+			fmt.Fprintf(w, "⚠ Sync notify command failed: %v\n", err)
+		}
+	}
+	if settings.Webhook != "" {
+		if err := postNotifyWebhook(settings.Webhook, payload); err != nil {
+			fmt.Fprintf(w, "⚠ Sync notify webhook failed: %v\n", err)
+		}
+	}
+}
+
+// runNotifyCommand runs command through the platform shell with payload on stdin,
+// killing it once notifyTimeout has passed
+func runNotifyCommand(command string, payload []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	cmd.Stdin = bytes.NewReader(payload)
+	// Children of the shell may keep the output open after it is killed
+	cmd.WaitDelay = time.Second
+
+	output, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("command timed out after %s", notifyTimeout)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, bytes.TrimSpace(output))
+	}
+	return nil
+}
+
+// postNotifyWebhook POSTs payload as JSON and expects a 2xx response
+func postNotifyWebhook(url string, payload []byte) error {
+	client := newHTTPClient()
+	client.Timeout = notifyTimeout
+
+	resp, err := client.Post(url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return ScrubError(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// syncForNotify runs a mocked ad-hoc sync of two issues, registering extra routes on the mock server
+func syncForNotify(t *testing.T, extra map[string]http.HandlerFunc) (*SyncResult, string) {
+	t.Helper()
+	issuesJSON := `[
+		{"id": 801, "number": 1, "title": "First", "state": "open", "updated_at": "2024-03-01T10:00:00Z"},
+		{"id": 802, "number": 2, "title": "Second", "state": "open", "updated_at": "2024-03-01T10:00:00Z"}
+	]`
+	server := newMockGitHubServer(t, "octo", "widgets", issuesJSON, extra)
+
+	config, err := NewAdHocConfig("octo/widgets", "test-token")
+	if err != nil {
+		t.Fatalf("NewAdHocConfig failed: %v", err)
+	}
+	config.Global.Database = filepath.Join(t.TempDir(), "pivot.db")
+
+	result, err := SyncAdHoc(config, SyncOptions{})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	return result, server.URL
+}
+
+func TestNotifySyncResult_Webhook(t *testing.T) {
+	var received []byte
+	var contentType string
+	result, serverURL := syncForNotify(t, map[string]http.HandlerFunc{
+		"/hooks/sync": func(w http.ResponseWriter, r *http.Request) {
+			contentType = r.Header.Get("Content-Type")
+			received, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+
+	var out bytes.Buffer
+	NotifySyncResult(&out, NotifySettings{Webhook: serverURL + "/hooks/sync"}, result)
+
+	if out.Len() != 0 {
+		t.Errorf("Expected no warnings, got: %s", out.String())
+	}
+	if contentType != "application/json" {
+		t.Errorf("Expected application/json, got %q", contentType)
+	}
+	var payload SyncResult
+	if err := json.Unmarshal(received, &payload); err != nil {
+		t.Fatalf("Webhook payload is not a sync result: %v (%s)", err, received)
+	}
+	if len(payload.Projects) != 1 || payload.Projects[0].Repo != "widgets" || payload.Projects[0].Created != 2 {
+		t.Errorf("Expected widgets with 2 created issues, got %+v", payload.Projects)
+	}
+}
+
+func TestNotifySyncResult_Command(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell command")
+	}
+	result, _ := syncForNotify(t, nil)
+	payloadFile := filepath.Join(t.TempDir(), "payload.json")
+
+	var out bytes.Buffer
+	NotifySyncResult(&out, NotifySettings{Command: "cat > " + payloadFile}, result)
+
+	if out.Len() != 0 {
+		t.Errorf("Expected no warnings, got: %s", out.String())
+	}
+	data, err := os.ReadFile(payloadFile)
+	if err != nil {
+		t.Fatalf("Hook command did not run: %v", err)
+	}
+	if !strings.Contains(string(data), `"owner":"octo"`) || !strings.Contains(string(data), `"created":2`) {
+		t.Errorf("Expected sync result JSON on stdin, got: %s", data)
+	}
+}
+
+func TestNotifySyncResult_FailuresAreWarnings(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell command")
+	}
+	result, serverURL := syncForNotify(t, map[string]http.HandlerFunc{
+		"/hooks/sync": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		},
+	})
+
+	var out bytes.Buffer
+	NotifySyncResult(&out, NotifySettings{Command: "echo broken >&2; exit 3", Webhook: serverURL + "/hooks/sync"}, result)
+
+	if !strings.Contains(out.String(), "⚠ Sync notify command failed") || !strings.Contains(out.String(), "broken") {
+		t.Errorf("Expected command warning with its output, got: %s", out.String())
+	}
+	if !strings.Contains(out.String(), "webhook returned status 500") {
+		t.Errorf("Expected webhook warning, got: %s", out.String())
+	}
+}
+
+func TestNotifySyncResult_CommandTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell command")
+	}
+	result, _ := syncForNotify(t, nil)
+	old := notifyTimeout
+	notifyTimeout = 100 * time.Millisecond
+	t.Cleanup(func() { notifyTimeout = old })
+
+	var out bytes.Buffer
+	start := time.Now()
+	NotifySyncResult(&out, NotifySettings{Command: "sleep 30"}, result)
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected a hanging command to be killed, waited %s", elapsed)
+	}
+	if !strings.Contains(out.String(), "⚠ Sync notify command failed: command timed out after 100ms") {
+		t.Errorf("Expected a timeout warning, got: %s", out.String())
+	}
+}
+
+func TestNotifySyncResult_OnlyOnConflicts(t *testing.T) {
+	calls := 0
+	result, serverURL := syncForNotify(t, map[string]http.HandlerFunc{
+		"/hooks/sync": func(w http.ResponseWriter, r *http.Request) { calls++ },
+	})
+	settings := NotifySettings{Webhook: serverURL + "/hooks/sync", OnlyOnConflicts: true}
+
+	NotifySyncResult(io.Discard, settings, result)
+	if calls != 0 {
+		t.Errorf("Expected no notification without conflicts, got %d", calls)
+	}
+
+	result.Projects[0].Conflicted = 1
+	NotifySyncResult(io.Discard, settings, result)
+	if calls != 1 {
+		t.Errorf("Expected one notification for a conflicted sync, got %d", calls)
+	}
+}
+
+func TestLoadMultiProjectConfig_NotifySettings(t *testing.T) {
+	dir := t.TempDir()
+	oldDir, _ := os.Getwd()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("Failed to chdir: %v", err)
+	}
+	defer func() { _ = os.Chdir(oldDir) }()
+
+	configYAML := `global:
+  database: ./pivot.db
+sync:
+  notify:
+    command: ./notify.sh
+    webhook: https://hooks.example.com/secret-path
+    only_on_conflicts: true
+projects:
+  - owner: octo
+    repo: widgets
+`
+	if err := os.WriteFile("config.yml", []byte(configYAML), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	config, err := LoadMultiProjectConfig()
+	if err != nil {
+		t.Fatalf("LoadMultiProjectConfig failed: %v", err)
+	}
+	notify := config.Sync.Notify
+	if notify.Command != "./notify.sh" || notify.Webhook != "https://hooks.example.com/secret-path" || !notify.OnlyOnConflicts {
+		t.Errorf("Unexpected notify settings: %+v", notify)
+	}
+	if scrubbed := ScrubSecrets("POST https://hooks.example.com/secret-path failed"); strings.Contains(scrubbed, "secret-path") {
+		t.Errorf("Expected webhook URL to be scrubbed, got: %s", scrubbed)
+	}
+}
@@ -0,0 +1,142 @@
+package internal
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const onlyNewIssuesJSON = `[
+	{"id": 202, "number": 2, "title": "Brand new", "state": "open", "updated_at": "2024-05-02T00:00:00Z"},
+	{"id": 201, "number": 1, "title": "Renamed on GitHub", "state": "closed", "updated_at": "2024-05-01T00:00:00Z"}
+]`
+
+func TestSyncOnlyNew_LeavesExistingIssuesUntouched(t *testing.T) {
+	newMockGitHubServer(t, "owner", "repo", onlyNewIssuesJSON, nil)
+	db := newTestMultiProjectDB(t)
+
+	project := &ProjectConfig{Owner: "owner", Repo: "repo"}
+	projectID, err := CreateProject(db, project)
+	if err != nil {
+		t.Fatalf("CreateProject failed: %v", err)
+	}
+	stored := &DBIssue{ID: 201, Number: 1, Title: "Captured title", Body: "Captured body", State: "open",
+		CreatedAt: "2024-01-01T00:00:00Z", UpdatedAt: "2024-01-01T00:00:00Z"}
+	if err := SaveIssue(db, projectID, stored); err != nil {
+		t.Fatalf("SaveIssue failed: %v", err)
+	}
+
+	var explain bytes.Buffer
+	result, err := syncProjectWithOptions(db, &GlobalConfig{Token: "test-token"}, project,
+		SyncOptions{FullSync: true, OnlyNew: true, Explain: &explain})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if result.Created != 1 || result.Updated != 0 || result.Existing != 1 {
+		t.Errorf("Expected 1 created, 0 updated and 1 existing, got %d, %d and %d", result.Created, result.Updated, result.Existing)
+	}
+
+	issues, err := GetIssuesForProject(db, projectID)
+	if err != nil {
+		t.Fatalf("Failed to get issues: %v", err)
+	}
+	byNumber := make(map[int]DBIssue)
+	for _, issue := range issues {
+		byNumber[issue.Number] = issue
+	}
+	if len(byNumber) != 2 {
+		t.Fatalf("Expected 2 stored issues, got %d", len(byNumber))
+	}
+
+	existing := byNumber[1]
+	if existing.Title != "Captured title" || existing.Body != "Captured body" || existing.State != "open" ||
+		existing.UpdatedAt != "2024-01-01T00:00:00Z" {
+		t.Errorf("Expected the existing issue to be left untouched, got %+v", existing)
+	}
+	if created := byNumber[2]; created.Title != "Brand new" {
+		t.Errorf("Expected the new issue to be inserted, got %+v", created)
+	}
+
+	if !strings.Contains(explain.String(), `"issue":1,"decision":"existing"`) {
+		t.Errorf("Expected an existing decision for issue 1, got %s", explain.String())
+	}
+	if !strings.Contains(explain.String(), `"issue":2,"decision":"created"`) {
+		t.Errorf("Expected a created decision for issue 2, got %s", explain.String())
+	}
+}
+
+func TestSyncOnlyNew_DisabledUpdatesExistingIssues(t *testing.T) {
+	newMockGitHubServer(t, "owner", "repo", onlyNewIssuesJSON, nil)
+	db := newTestMultiProjectDB(t)
+
+	project := &ProjectConfig{Owner: "owner", Repo: "repo"}
+	projectID, err := CreateProject(db, project)
+	if err != nil {
+		t.Fatalf("CreateProject failed: %v", err)
+	}
+	if err := SaveIssue(db, projectID, &DBIssue{ID: 201, Number: 1, Title: "Captured title", State: "open",
+		UpdatedAt: "2024-01-01T00:00:00Z"}); err != nil {
+		t.Fatalf("SaveIssue failed: %v", err)
+	}
+
+	result, err := syncProjectWithOptions(db, &GlobalConfig{Token: "test-token"}, project, SyncOptions{FullSync: true})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if result.Updated != 1 || result.Existing != 0 {
+		t.Errorf("Expected the existing issue to be updated, got %d updated and %d existing", result.Updated, result.Existing)
+	}
+}
+
+func TestSyncOnlyNew_KeepsWatermarkForSkippedChanges(t *testing.T) {
+	newMockGitHubServer(t, "owner", "repo", onlyNewIssuesJSON, nil)
+	db := newTestMultiProjectDB(t)
+
+	project := &ProjectConfig{Owner: "owner", Repo: "repo"}
+	projectID, err := CreateProject(db, project)
+	if err != nil {
+		t.Fatalf("CreateProject failed: %v", err)
+	}
+	if err := SaveIssue(db, projectID, &DBIssue{ID: 201, Number: 1, Title: "Captured title", State: "open",
+		UpdatedAt: "2024-01-01T00:00:00Z"}); err != nil {
+		t.Fatalf("SaveIssue failed: %v", err)
+	}
+	if err := SaveSyncWatermark(db, projectID, "2024-01-01T00:00:00Z"); err != nil {
+		t.Fatalf("SaveSyncWatermark failed: %v", err)
+	}
+
+	global := &GlobalConfig{Token: "test-token"}
+	if _, err := syncProjectWithOptions(db, global, project, SyncOptions{OnlyNew: true}); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	watermark, err := GetSyncWatermark(db, projectID)
+	if err != nil {
+		t.Fatalf("GetSyncWatermark failed: %v", err)
+	}
+	if watermark != "2024-01-01T00:00:00Z" {
+		t.Errorf("Expected --only-new to leave the watermark at 2024-01-01T00:00:00Z, got %s", watermark)
+	}
+
+	// The next regular sync still picks up the rename the --only-new sync skipped
+	if _, err := syncProjectWithOptions(db, global, project, SyncOptions{}); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	issues, err := GetIssuesForProject(db, projectID)
+	if err != nil {
+		t.Fatalf("Failed to get issues: %v", err)
+	}
+	if len(issues) != 2 || issues[0].Title != "Renamed on GitHub" {
+		t.Errorf("Expected issue #1 to be renamed, got %+v", issues)
+	}
+	if watermark, _ := GetSyncWatermark(db, projectID); watermark != "2024-05-02T00:00:00Z" {
+		t.Errorf("Expected the regular sync to advance the watermark, got %s", watermark)
+	}
+}
+
+func TestPrintSyncResult_OnlyNew(t *testing.T) {
+	var out bytes.Buffer
+	PrintSyncResult(&out, &SyncResult{Projects: []ProjectSyncResult{{Owner: "o", Repo: "r", Created: 1, Existing: 4}}})
+	if !strings.Contains(out.String(), "4 already stored issues left untouched by --only-new") {
+		t.Errorf("Expected the untouched issues in the summary, got:\n%s", out.String())
+	}
+}
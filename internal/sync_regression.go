@@ -0,0 +1,39 @@
+package internal
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// storedUpdatedAt returns the updated_at of the locally stored copy of an issue, or ""
+// when the issue is not stored
+func storedUpdatedAt(db *sql.DB, projectID int64, githubID int) (string, error) {
+	var updatedAt sql.NullString
+	err := db.QueryRow("SELECT updated_at FROM issues WHERE github_id = ? AND project_id = ?", githubID, projectID).Scan(&updatedAt)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read updated_at of issue %d: %w", githubID, err)
+	}
+	return updatedAt.String, nil
+}
+
+// isUpdatedAtRegression reports whether a fetched updated_at is older than the stored
+// one, which points at a stale cache or clock skew rather than a real change. Values
+// that cannot be parsed are never treated as a regression.
+func isUpdatedAtRegression(stored, remote string) bool {
+	if stored == "" || remote == "" {
+		return false
+	}
+	storedTime, err := time.Parse(time.RFC3339, stored)
+	if err != nil {
+		return false
+	}
+	remoteTime, err := time.Parse(time.RFC3339, remote)
+	if err != nil {
+		return false
+	}
+	return remoteTime.Before(storedTime)
+}
@@ -0,0 +1,130 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIsUpdatedAtRegression(t *testing.T) {
+	tests := []struct {
+		stored, remote string
+		expected       bool
+	}{
+		{"2024-03-02T10:00:00Z", "2024-03-01T10:00:00Z", true},
+		{"2024-03-02T10:00:00Z", "2024-03-02T10:00:00Z", false},
+		{"2024-03-02T10:00:00Z", "2024-03-03T10:00:00Z", false},
+		{"2024-03-02T10:00:00+02:00", "2024-03-02T09:00:00Z", false}, // Same instant in another zone
+		{"", "2024-03-01T10:00:00Z", false},
+		{"2024-03-02T10:00:00Z", "", false},
+		{"not a time", "2024-03-01T10:00:00Z", false},
+	}
+	for _, tt := range tests {
+		if got := isUpdatedAtRegression(tt.stored, tt.remote); got != tt.expected {
+			t.Errorf("isUpdatedAtRegression(%q, %q): expected %v, got %v", tt.stored, tt.remote, tt.expected, got)
+		}
+	}
+}
+
+func TestSyncAdHoc_SkipsUpdatedAtRegression(t *testing.T) {
+	issuesJSON := `[
+		{"id": 1201, "number": 1, "title": "Current", "state": "open", "updated_at": "2024-03-02T10:00:00Z"},
+		{"id": 1202, "number": 2, "title": "Other", "state": "open", "updated_at": "2024-03-02T10:00:00Z"}
+	]`
+	newMockGitHubServer(t, "octo", "widgets", "", map[string]http.HandlerFunc{
+		"/repos/octo/widgets/issues": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(issuesJSON))
+		},
+	})
+
+	tempDir := t.TempDir()
+	config, err := NewAdHocConfig("octo/widgets", "test-token")
+	if err != nil {
+		t.Fatalf("NewAdHocConfig failed: %v", err)
+	}
+	config.Global.Database = filepath.Join(tempDir, "pivot.db")
+	config.Audit.File = filepath.Join(tempDir, "audit.jsonl")
+
+	if _, err := SyncAdHoc(config, SyncOptions{}); err != nil {
+		t.Fatalf("First sync failed: %v", err)
+	}
+
+	// A stale cache serves #1 with older data; #2 moves forward normally
+	issuesJSON = `[
+		{"id": 1201, "number": 1, "title": "Stale", "state": "open", "updated_at": "2024-03-01T10:00:00Z"},
+		{"id": 1202, "number": 2, "title": "Other, renamed", "state": "open", "updated_at": "2024-03-03T10:00:00Z"}
+	]`
+	var explain bytes.Buffer
+	result, err := SyncAdHoc(config, SyncOptions{FullSync: true, Explain: &explain})
+	if err != nil {
+		t.Fatalf("Second sync failed: %v", err)
+	}
+
+	project := result.Projects[0]
+	if len(project.Regressions) != 1 || project.Regressions[0] != 1 {
+		t.Errorf("Expected issue #1 reported as a regression, got %v", project.Regressions)
+	}
+	if project.Updated != 1 {
+		t.Errorf("Expected only #2 to be updated, got %d updates", project.Updated)
+	}
+
+	db, err := InitMultiProjectDBFromPath(config.Global.Database)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	projectID, _ := getProjectID(db, "octo", "widgets")
+	title := func(number int) string {
+		t.Helper()
+		var title string
+		if err := db.QueryRow("SELECT title FROM issues WHERE project_id = ? AND number = ?", projectID, number).Scan(&title); err != nil {
+			t.Fatalf("Failed to read issue #%d: %v", number, err)
+		}
+		return title
+	}
+	if got := title(1); got != "Current" {
+		t.Errorf("Expected #1 to keep the stored title, got '%s'", got)
+	}
+	if got := title(2); got != "Other, renamed" {
+		t.Errorf("Expected #2 to be updated, got '%s'", got)
+	}
+
+	// The regression is logged to --explain and the audit log
+	var decision SyncDecision
+	if err := json.Unmarshal([]byte(strings.Split(explain.String(), "\n")[0]), &decision); err != nil {
+		t.Fatalf("Explain line is not JSON: %v", err)
+	}
+	if decision.Issue != 1 || decision.Decision != SyncDecisionRegression || !strings.Contains(decision.Reason, "older") {
+		t.Errorf("Expected a regression decision for #1, got %+v", decision)
+	}
+	var regressions int
+	for _, entry := range readAuditEntries(t, config.Audit.File) {
+		if entry.Action == AuditActionRegression && entry.Issue == 1 {
+			regressions++
+		}
+	}
+	if regressions != 1 {
+		t.Errorf("Expected one regression audit entry for #1, got %d", regressions)
+	}
+
+	var summary bytes.Buffer
+	PrintSyncResult(&summary, result)
+	if !strings.Contains(summary.String(), "1 issues kept: GitHub returned an older updated_at") {
+		t.Errorf("Expected regressions in the summary, got:\n%s", summary.String())
+	}
+
+	// --force-overwrite stores the older copy anyway
+	result, err = SyncAdHoc(config, SyncOptions{FullSync: true, ForceOverwrite: true})
+	if err != nil {
+		t.Fatalf("Forced sync failed: %v", err)
+	}
+	if len(result.Projects[0].Regressions) != 0 {
+		t.Errorf("Expected no regressions with --force-overwrite, got %v", result.Projects[0].Regressions)
+	}
+	if got := title(1); got != "Stale" {
+		t.Errorf("Expected --force-overwrite to store the fetched copy, got '%s'", got)
+	}
+}
@@ -0,0 +1,121 @@
+package internal
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+)
+
+// ProjectSyncResult describes what a sync did to one project
+type ProjectSyncResult struct {
+	Owner          string   `json:"owner"`
+	Repo           string   `json:"repo"`
+	Created        int      `json:"created"`                   // Issues that were not yet in the local database
+	Updated        int      `json:"updated"`                   // Issues already stored locally and refreshed from GitHub
+	Conflicted     int      `json:"conflicted"`                // Issues kept locally because they changed on both sides
+	Skipped        int      `json:"skipped"`                   // Fetched issues not stored because they did not match --select
+	Resumed        int      `json:"resumed,omitempty"`         // Fetched issues not processed because they are numbered below --resume-from
+	Existing       int      `json:"existing,omitempty"`        // Fetched issues already stored and left untouched by --only-new
+	TimelineEvents int      `json:"timeline_events,omitempty"` // Timeline events stored by --include-timeline
+	Errors         []string `json:"errors,omitempty"`          // Failures that stopped this project's sync
+	NoIssues       bool     `json:"no_issues,omitempty"`       // The sync succeeded but GitHub returned no issues
+
+	Regressions       []int `json:"regressions,omitempty"`        // Issues kept because GitHub returned an older updated_at than stored
+	ForcedResolutions int   `json:"forced_resolutions,omitempty"` // Conflicts resolved by --force-conflict-as instead of being marked CONFLICTED
+
+	ChecksumMismatches []int       `json:"checksum_mismatches,omitempty"` // Issues whose stored content fails checksum verification
+	Differences        []IssueDiff `json:"differences,omitempty"`         // Local-vs-remote differences found by a compare-only sync
+
+	DryRun *ProjectSyncDiff `json:"dry_run,omitempty"` // What a dry-run sync would change
+}
+
+// SyncResult is the outcome of a sync, one entry per synced project
+type SyncResult struct {
+	Projects []ProjectSyncResult `json:"projects"`
+}
+
+// Totals sums the counts of all projects
+func (r *SyncResult) Totals() ProjectSyncResult {
+	var totals ProjectSyncResult
+	for _, project := range r.Projects {
+		totals.Created += project.Created
+		totals.Updated += project.Updated
+		totals.Conflicted += project.Conflicted
+		totals.Skipped += project.Skipped
+		totals.Resumed += project.Resumed
+		totals.Existing += project.Existing
+		totals.TimelineEvents += project.TimelineEvents
+		totals.ForcedResolutions += project.ForcedResolutions
+		totals.Errors = append(totals.Errors, project.Errors...)
+		totals.Regressions = append(totals.Regressions, project.Regressions...)
+		totals.ChecksumMismatches = append(totals.ChecksumMismatches, project.ChecksumMismatches...)
+		totals.Differences = append(totals.Differences, project.Differences...)
+	}
+	return totals
+}
+
+// HasErrors reports whether any project failed to sync completely
+func (r *SyncResult) HasErrors() bool {
+	for _, project := range r.Projects {
+		if len(project.Errors) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// PrintSyncResult writes a per-project summary of a sync result
+func PrintSyncResult(w io.Writer, result *SyncResult) {
+	if result == nil || len(result.Projects) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "\n📊 Sync Summary")
+	for _, project := range result.Projects {
+		status := "✓"
+		if len(project.Errors) > 0 {
+			status = "❌"
+		}
+		if project.NoIssues && len(project.Errors) == 0 {
+			fmt.Fprintf(w, "  %s %s/%s: no issues found\n", status, project.Owner, project.Repo)
+			continue
+		}
+		fmt.Fprintf(w, "  %s %s/%s: %d created, %d updated, %d conflicted\n",
+			status, project.Owner, project.Repo, project.Created, project.Updated, project.Conflicted)
+		if project.Skipped > 0 {
+			fmt.Fprintf(w, "    %d issues skipped by --select\n", project.Skipped)
+		}
+		if project.Resumed > 0 {
+			fmt.Fprintf(w, "    %d issues below --resume-from not processed\n", project.Resumed)
+		}
+		if project.Existing > 0 {
+			fmt.Fprintf(w, "    %d already stored issues left untouched by --only-new\n", project.Existing)
+		}
+		if project.TimelineEvents > 0 {
+			fmt.Fprintf(w, "    %d timeline events stored\n", project.TimelineEvents)
+		}
+		if project.ForcedResolutions > 0 {
+			fmt.Fprintf(w, "    %d conflicts resolved by --force-conflict-as\n", project.ForcedResolutions)
+		}
+		if len(project.ChecksumMismatches) > 0 {
+			fmt.Fprintf(w, "    ⚠ %d issues failed checksum verification\n", len(project.ChecksumMismatches))
+		}
+		if len(project.Regressions) > 0 {
+			fmt.Fprintf(w, "    ⚠ %d issues kept: GitHub returned an older updated_at than stored\n", len(project.Regressions))
+		}
+	}
+}
+
+// storedIssueState returns the locally stored state of an issue and whether the
+// project stores the issue at all
+func storedIssueState(db *sql.DB, projectID int64, githubID int) (string, bool, error) {
+	var state sql.NullString
+	err := db.QueryRow("SELECT state FROM issues WHERE github_id = ? AND project_id = ?", githubID, projectID).Scan(&state)
+	if err == sql.ErrNoRows {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to look up issue %d: %w", githubID, err)
+	}
+	return state.String, true, nil
+}
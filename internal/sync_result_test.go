@@ -0,0 +1,126 @@
+package internal
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestSyncMultiProject_ReturnsResult(t *testing.T) {
+	tempDir := t.TempDir()
+	oldDir, _ := os.Getwd()
+	defer func() {
+		if err := os.Chdir(oldDir); err != nil {
+			t.Logf("Warning: Failed to change back to original directory: %v", err)
+		}
+	}()
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+
+	configContent := `global:
+  database: ./pivot.db
+  token: test-token
+projects:
+  - owner: octo
+    repo: widgets
+  - owner: octo
+    repo: missing`
+	if err := os.WriteFile("config.yml", []byte(configContent), 0600); err != nil {
+		t.Fatalf("Failed to create test config: %v", err)
+	}
+
+	issuesJSON := `[
+		{"id": 601, "number": 1, "title": "First", "state": "open"},
+		{"id": 602, "number": 2, "title": "Second", "state": "open"}
+	]`
+	newMockGitHubServer(t, "octo", "widgets", "", map[string]http.HandlerFunc{
+		"/repos/octo/widgets/issues": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(issuesJSON))
+		},
+	})
+
+	// First sync creates both issues and records the failing project
+	result, err := SyncMultiProjectWithOptions("", SyncOptions{FullSync: true})
+	if err != nil {
+		t.Fatalf("SyncMultiProjectWithOptions failed: %v", err)
+	}
+	if len(result.Projects) != 2 {
+		t.Fatalf("Expected a result per project, got %+v", result.Projects)
+	}
+
+	widgets, missing := result.Projects[0], result.Projects[1]
+	if widgets.Owner != "octo" || widgets.Repo != "widgets" || widgets.Created != 2 || widgets.Updated != 0 {
+		t.Errorf("Expected 2 created issues for octo/widgets, got %+v", widgets)
+	}
+	if len(widgets.Errors) != 0 {
+		t.Errorf("Expected no errors for octo/widgets, got %v", widgets.Errors)
+	}
+	if missing.Repo != "missing" || len(missing.Errors) != 1 {
+		t.Errorf("Expected one error for octo/missing, got %+v", missing)
+	}
+	if !result.HasErrors() {
+		t.Error("Expected HasErrors to report the failed project")
+	}
+
+	// Mark issue 1 as edited locally, then change it on GitHub
+	db, err := InitMultiProjectDBFromPath("./pivot.db")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if _, err := db.Exec("UPDATE issues SET local_modified_at = '2024-01-01T00:00:00Z' WHERE github_id = 601"); err != nil {
+		t.Fatalf("Failed to mark local modification: %v", err)
+	}
+	db.Close()
+	issuesJSON = `[
+		{"id": 601, "number": 1, "title": "First, renamed upstream", "state": "open"},
+		{"id": 602, "number": 2, "title": "Second", "state": "closed"},
+		{"id": 603, "number": 3, "title": "Third", "state": "open"}
+	]`
+
+	result, err = SyncMultiProjectWithOptions("octo/widgets", SyncOptions{FullSync: true})
+	if err != nil {
+		t.Fatalf("Second sync failed: %v", err)
+	}
+	if len(result.Projects) != 1 {
+		t.Fatalf("Expected only the filtered project, got %+v", result.Projects)
+	}
+	widgets = result.Projects[0]
+	if widgets.Created != 1 || widgets.Updated != 1 || widgets.Conflicted != 1 {
+		t.Errorf("Expected 1 created, 1 updated, 1 conflicted, got %+v", widgets)
+	}
+	if result.HasErrors() {
+		t.Errorf("Expected no errors, got %v", result.Totals().Errors)
+	}
+}
+
+func TestSyncResult_TotalsAndPrint(t *testing.T) {
+	result := &SyncResult{Projects: []ProjectSyncResult{
+		{Owner: "octo", Repo: "widgets", Created: 2, Updated: 3, Conflicted: 1},
+		{Owner: "octo", Repo: "gadgets", Created: 1, Errors: []string{"GitHub API error (status 404)"}},
+	}}
+
+	totals := result.Totals()
+	if totals.Created != 3 || totals.Updated != 3 || totals.Conflicted != 1 || len(totals.Errors) != 1 {
+		t.Errorf("Expected summed totals, got %+v", totals)
+	}
+
+	var output bytes.Buffer
+	PrintSyncResult(&output, result)
+	for _, want := range []string{
+		"✓ octo/widgets: 2 created, 3 updated, 1 conflicted",
+		"❌ octo/gadgets: 1 created, 0 updated, 0 conflicted",
+	} {
+		if !strings.Contains(output.String(), want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output.String())
+		}
+	}
+
+	output.Reset()
+	PrintSyncResult(&output, nil)
+	if output.Len() != 0 {
+		t.Errorf("Expected no output for nil result, got %q", output.String())
+	}
+}
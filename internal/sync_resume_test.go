@@ -0,0 +1,82 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// resumeIssuesJSON lists issues newest first, as GitHub returns them
+const resumeIssuesJSON = `[
+	{"id": 105, "number": 5, "title": "Five", "state": "open", "updated_at": "2024-03-05T00:00:00Z"},
+	{"id": 104, "number": 4, "title": "Four", "state": "open", "updated_at": "2024-03-04T00:00:00Z"},
+	{"id": 103, "number": 3, "title": "Three", "state": "open", "updated_at": "2024-03-03T00:00:00Z"},
+	{"id": 102, "number": 2, "title": "Two", "state": "open", "updated_at": "2024-03-02T00:00:00Z"},
+	{"id": 101, "number": 1, "title": "One", "state": "open", "updated_at": "2024-03-01T00:00:00Z"}
+]`
+
+func TestSyncResumeFrom_SkipsLowerNumbers(t *testing.T) {
+	newMockGitHubServer(t, "owner", "repo", resumeIssuesJSON, nil)
+	db := newTestMultiProjectDB(t)
+
+	var explain bytes.Buffer
+	project := &ProjectConfig{Owner: "owner", Repo: "repo"}
+	result, err := syncProjectWithOptions(db, &GlobalConfig{Token: "test-token"}, project,
+		SyncOptions{FullSync: true, ResumeFrom: 3, Explain: &explain})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	if result.Created != 3 || result.Resumed != 2 {
+		t.Errorf("Expected 3 created and 2 resumed past, got %d and %d", result.Created, result.Resumed)
+	}
+
+	projectID, _ := getProjectID(db, "owner", "repo")
+	issues, err := GetIssuesForProject(db, projectID)
+	if err != nil {
+		t.Fatalf("Failed to get issues: %v", err)
+	}
+	var numbers []int
+	for _, issue := range issues {
+		numbers = append(numbers, issue.Number)
+	}
+	if fmt.Sprint(numbers) != "[3 4 5]" && fmt.Sprint(numbers) != "[5 4 3]" {
+		t.Errorf("Expected only issues 3-5 to be stored, got %v", numbers)
+	}
+
+	// Issues are processed in number order starting at the resume number
+	lines := strings.Split(strings.TrimSpace(explain.String()), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("Expected 5 decisions, got %d: %s", len(lines), explain.String())
+	}
+	for i, want := range []string{`"issue":1,"decision":"before_resume"`, `"issue":2,"decision":"before_resume"`,
+		`"issue":3,"decision":"created"`, `"issue":4,"decision":"created"`, `"issue":5,"decision":"created"`} {
+		if !strings.Contains(lines[i], want) {
+			t.Errorf("Expected decision %d to contain %s, got %s", i+1, want, lines[i])
+		}
+	}
+
+	// Skipped issues must be fetched again by the next incremental sync
+	if since, _ := GetSyncWatermark(db, projectID); since != "" {
+		t.Errorf("Expected the watermark not to advance with --resume-from, got %q", since)
+	}
+}
+
+func TestSyncResumeFrom_ZeroProcessesAll(t *testing.T) {
+	newMockGitHubServer(t, "owner", "repo", resumeIssuesJSON, nil)
+	db := newTestMultiProjectDB(t)
+
+	result, err := syncProjectWithOptions(db, &GlobalConfig{Token: "test-token"}, &ProjectConfig{Owner: "owner", Repo: "repo"}, SyncOptions{})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if result.Created != 5 || result.Resumed != 0 {
+		t.Errorf("Expected all 5 issues created, got %d created and %d resumed past", result.Created, result.Resumed)
+	}
+
+	projectID, _ := getProjectID(db, "owner", "repo")
+	if since, _ := GetSyncWatermark(db, projectID); since != "2024-03-05T00:00:00Z" {
+		t.Errorf("Expected the watermark to advance, got %q", since)
+	}
+}
@@ -3,6 +3,8 @@ package internal
 import (
 	"database/sql"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -38,6 +40,54 @@ const (
 	SyncStateError SyncState = "ERROR"
 )
 
+// SyncStates lists the sync states in canonical order, following an issue's life cycle
+var SyncStates = []SyncState{
+	SyncStateLocalOnly,
+	SyncStatePendingPush,
+	SyncStatePushFailed,
+	SyncStateSynced,
+	SyncStateLocalModified,
+	SyncStatePendingSync,
+	SyncStateSyncFailed,
+	SyncStateConflicted,
+	SyncStateError,
+}
+
+// String returns the stored name of the sync state, e.g. LOCAL_ONLY
+func (s SyncState) String() string {
+	return string(s)
+}
+
+// Valid reports whether s is one of the known sync states
+func (s SyncState) Valid() bool {
+	for _, state := range SyncStates {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseSyncState parses a user-supplied sync state name. Names are case-insensitive
+// and may use dashes instead of underscores, e.g. "local-only" for LOCAL_ONLY.
+func ParseSyncState(name string) (SyncState, error) {
+	state := SyncState(strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(name), "-", "_")))
+	if !state.Valid() {
+		names := make([]string, len(SyncStates))
+		for i, known := range SyncStates {
+			names[i] = known.String()
+		}
+		return "", fmt.Errorf("unknown sync state '%s' (valid: %s)", name, strings.Join(names, ", "))
+	}
+	return state, nil
+}
+
+// SyncStateCount is the number of issues in one sync state
+type SyncStateCount struct {
+	State SyncState `json:"state"`
+	Count int       `json:"count"`
+}
+
 // IssueSyncState represents the sync state record for an issue
 type IssueSyncState struct {
 	ID                 int64      `json:"id"`
@@ -141,6 +191,9 @@ func InitSyncStateSchema(db *sql.DB) error {
 
 // CreateSyncState creates a new sync state record for an issue
 func CreateSyncState(db *sql.DB, issueLocalID int64, state SyncState, githubID *int64) error {
+	if !state.Valid() {
+		return fmt.Errorf("failed to create sync state: unknown sync state '%s'", state)
+	}
 	now := time.Now().Format(time.RFC3339)
 
 	query := `
@@ -159,6 +212,9 @@ func CreateSyncState(db *sql.DB, issueLocalID int64, state SyncState, githubID *
 
 // UpdateSyncState updates the sync state of an issue
 func UpdateSyncState(db *sql.DB, issueLocalID int64, state SyncState, githubID *int64, syncError *string) error {
+	if !state.Valid() {
+		return fmt.Errorf("failed to update sync state: unknown sync state '%s'", state)
+	}
 	now := time.Now().Format(time.RFC3339)
 
 	query := `
@@ -350,3 +406,81 @@ func GetSyncStateSummary(db *sql.DB) (map[SyncState]int, error) {
 
 	return summary, nil
 }
+
+// GetOrderedSyncStateSummary returns the number of issues in each sync state, in the
+// canonical order of SyncStates. States without issues are left out.
+func GetOrderedSyncStateSummary(db *sql.DB) ([]SyncStateCount, error) {
+	summary, err := GetSyncStateSummary(db)
+	if err != nil {
+		return nil, err
+	}
+	return OrderSyncStateSummary(summary), nil
+}
+
+// OrderSyncStateSummary orders a summary from GetSyncStateSummary canonically. States
+// this version does not know follow the known ones in name order.
+func OrderSyncStateSummary(summary map[SyncState]int) []SyncStateCount {
+	ordered := make([]SyncStateCount, 0, len(summary))
+	known := make(map[SyncState]bool, len(SyncStates))
+	for _, state := range SyncStates {
+		known[state] = true
+		if count, ok := summary[state]; ok {
+			ordered = append(ordered, SyncStateCount{State: state, Count: count})
+		}
+	}
+
+	var unknown []SyncStateCount
+	for state, count := range summary {
+		if !known[state] {
+			unknown = append(unknown, SyncStateCount{State: state, Count: count})
+		}
+	}
+	sort.Slice(unknown, func(i, j int) bool { return unknown[i].State < unknown[j].State })
+	return append(ordered, unknown...)
+}
+
+// recordFetchedSyncState updates the sync state of an issue fetched by a sync. A newly
+// stored issue becomes SYNCED and a conflicting one CONFLICTED. An issue stored with the
+// GitHub copy is SYNCED again when it was waiting on GitHub (PENDING_SYNC, SYNC_FAILED
+// or CONFLICTED); other states record local intent and are kept. Running it again for
+// the same issue changes nothing.
+func recordFetchedSyncState(db *sql.DB, projectID int64, issue *DBIssue, conflicted bool) error {
+	var localID int64
+	err := db.QueryRow("SELECT rowid FROM issues WHERE github_id = ? AND project_id = ?", issue.ID, projectID).Scan(&localID)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to look up issue #%d: %w", issue.Number, err)
+	}
+
+	githubID := int64(issue.ID)
+	target := SyncStateSynced
+	if conflicted {
+		target = SyncStateConflicted
+	}
+
+	current, err := GetSyncState(db, localID)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		return CreateSyncState(db, localID, target, &githubID)
+	}
+
+	switch {
+	case conflicted:
+		if current.SyncState == SyncStateConflicted && current.GitHubID != nil {
+			return nil
+		}
+	case current.SyncState == SyncStatePendingSync || current.SyncState == SyncStateSyncFailed || current.SyncState == SyncStateConflicted:
+		// The GitHub copy was stored, so the issue is in sync again
+	default:
+		if current.GitHubID != nil {
+			return nil
+		}
+		// Keep the state, only record the GitHub ID
+		target = current.SyncState
+	}
+	return UpdateSyncState(db, localID, target, &githubID, nil)
+}
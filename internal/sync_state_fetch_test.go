@@ -0,0 +1,170 @@
+package internal
+
+import (
+	"net/http"
+	"path/filepath"
+	"testing"
+)
+
+// syncStateRows returns the sync state and GitHub ID recorded for each issue number
+func syncStateRows(t *testing.T, dbPath string) map[int]IssueSyncState {
+	t.Helper()
+	db, err := InitMultiProjectDBFromPath(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query(`
+		SELECT i.number, s.issue_local_id, s.sync_state, s.github_id
+		FROM issue_sync_state s JOIN issues i ON i.rowid = s.issue_local_id`)
+	if err != nil {
+		t.Fatalf("Failed to query sync states: %v", err)
+	}
+	defer rows.Close()
+
+	states := make(map[int]IssueSyncState)
+	for rows.Next() {
+		var number int
+		var state IssueSyncState
+		var githubID int64
+		if err := rows.Scan(&number, &state.IssueLocalID, &state.SyncState, &githubID); err != nil {
+			t.Fatalf("Failed to scan sync state: %v", err)
+		}
+		state.GitHubID = &githubID
+		states[number] = state
+	}
+	return states
+}
+
+func TestSyncCreatesSyncStates(t *testing.T) {
+	issuesJSON := `[
+		{"id": 701, "number": 1, "title": "First", "state": "open", "updated_at": "2024-03-01T10:00:00Z"},
+		{"id": 702, "number": 2, "title": "Second", "state": "closed", "updated_at": "2024-03-02T10:00:00Z"}
+	]`
+	newMockGitHubServer(t, "octo", "states", "", map[string]http.HandlerFunc{
+		"/repos/octo/states/issues": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(issuesJSON))
+		},
+	})
+
+	config, err := NewAdHocConfig("octo/states", "test-token")
+	if err != nil {
+		t.Fatalf("NewAdHocConfig failed: %v", err)
+	}
+	config.Global.Database = filepath.Join(t.TempDir(), "pivot.db")
+
+	if _, err := SyncAdHoc(config, SyncOptions{FullSync: true}); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	states := syncStateRows(t, config.Global.Database)
+	if len(states) != 2 {
+		t.Fatalf("Expected a sync state per issue, got %+v", states)
+	}
+	for number, githubID := range map[int]int64{1: 701, 2: 702} {
+		state := states[number]
+		if state.SyncState != SyncStateSynced || *state.GitHubID != githubID {
+			t.Errorf("Expected issue #%d SYNCED with github_id %d, got %s with %d", number, githubID, state.SyncState, *state.GitHubID)
+		}
+	}
+
+	db, err := InitMultiProjectDBFromPath(config.Global.Database)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	summary, err := GetSyncStateSummary(db)
+	if err != nil {
+		t.Fatalf("GetSyncStateSummary failed: %v", err)
+	}
+	if summary[SyncStateSynced] != 2 || len(summary) != 1 {
+		t.Errorf("Expected 2 SYNCED issues in the summary, got %v", summary)
+	}
+
+	// Syncing again keeps one state per issue attached to the same local IDs
+	issuesJSON = `[
+		{"id": 701, "number": 1, "title": "First, edited upstream", "state": "open", "updated_at": "2024-03-05T10:00:00Z"},
+		{"id": 702, "number": 2, "title": "Second", "state": "closed", "updated_at": "2024-03-02T10:00:00Z"}
+	]`
+	if _, err := SyncAdHoc(config, SyncOptions{FullSync: true}); err != nil {
+		t.Fatalf("Second sync failed: %v", err)
+	}
+	again := syncStateRows(t, config.Global.Database)
+	if len(again) != 2 {
+		t.Fatalf("Expected still one sync state per issue, got %+v", again)
+	}
+	for number, state := range states {
+		if again[number].IssueLocalID != state.IssueLocalID || again[number].SyncState != SyncStateSynced {
+			t.Errorf("Expected issue #%d to keep local ID %d and stay SYNCED, got %+v", number, state.IssueLocalID, again[number])
+		}
+	}
+	if summary, _ := GetSyncStateSummary(db); summary[SyncStateSynced] != 2 {
+		t.Errorf("Expected 2 SYNCED issues after a second sync, got %v", summary)
+	}
+}
+
+func TestSyncKeepsLocalStatesAndMarksConflicts(t *testing.T) {
+	issuesJSON := `[
+		{"id": 801, "number": 1, "title": "Edited on both sides", "state": "open"},
+		{"id": 802, "number": 2, "title": "Queued push", "state": "open"},
+		{"id": 803, "number": 3, "title": "Failed sync", "state": "open"}
+	]`
+	newMockGitHubServer(t, "octo", "conflicts", "", map[string]http.HandlerFunc{
+		"/repos/octo/conflicts/issues": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(issuesJSON))
+		},
+	})
+
+	config, err := NewAdHocConfig("octo/conflicts", "test-token")
+	if err != nil {
+		t.Fatalf("NewAdHocConfig failed: %v", err)
+	}
+	config.Global.Database = filepath.Join(t.TempDir(), "pivot.db")
+	if _, err := SyncAdHoc(config, SyncOptions{FullSync: true}); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	states := syncStateRows(t, config.Global.Database)
+	db, err := InitMultiProjectDBFromPath(config.Global.Database)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	// #1 is edited locally, #2 has local changes queued, #3 failed to sync
+	if _, err := db.Exec("UPDATE issues SET local_modified_at = '2024-01-01T00:00:00Z' WHERE github_id = 801"); err != nil {
+		t.Fatalf("Failed to mark local modification: %v", err)
+	}
+	if err := UpdateSyncState(db, states[2].IssueLocalID, SyncStateLocalModified, states[2].GitHubID, nil); err != nil {
+		t.Fatalf("UpdateSyncState failed: %v", err)
+	}
+	if err := UpdateSyncState(db, states[3].IssueLocalID, SyncStateSyncFailed, states[3].GitHubID, nil); err != nil {
+		t.Fatalf("UpdateSyncState failed: %v", err)
+	}
+
+	issuesJSON = `[
+		{"id": 801, "number": 1, "title": "Edited on both sides, renamed upstream", "state": "open"},
+		{"id": 802, "number": 2, "title": "Queued push", "state": "open"},
+		{"id": 803, "number": 3, "title": "Failed sync", "state": "open"}
+	]`
+	for i := 0; i < 2; i++ {
+		if _, err := SyncAdHoc(config, SyncOptions{FullSync: true}); err != nil {
+			t.Fatalf("Sync %d failed: %v", i+2, err)
+		}
+		after := syncStateRows(t, config.Global.Database)
+		expected := map[int]SyncState{1: SyncStateConflicted, 2: SyncStateLocalModified, 3: SyncStateSynced}
+		for number, state := range expected {
+			if after[number].SyncState != state {
+				t.Errorf("Sync %d: expected issue #%d to be %s, got %s", i+2, number, state, after[number].SyncState)
+			}
+		}
+	}
+
+	conflicts, err := GetConflictedIssues(db, 0)
+	if err != nil {
+		t.Fatalf("GetConflictedIssues failed: %v", err)
+	}
+	if len(conflicts) != 1 || conflicts[0].Number != 1 {
+		t.Errorf("Expected issue #1 to be listed for resolve, got %+v", conflicts)
+	}
+}
@@ -3,6 +3,8 @@ package internal
 import (
 	"database/sql"
 	"os"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -863,6 +865,68 @@ func TestSyncStateCRUD(t *testing.T) {
 	})
 }
 
+func TestGetOrderedSyncStateSummary(t *testing.T) {
+	fixture := setupSyncStateTest(t)
+	defer teardownSyncStateTest(fixture)
+
+	// Insert states in an order that differs from the canonical one
+	states := []SyncState{SyncStateConflicted, SyncStateSynced, SyncStateLocalOnly, SyncStateSynced, SyncStatePushFailed}
+	for i, state := range states {
+		result, err := fixture.db.Exec(`
+			INSERT INTO issues (project_id, number, title, body, state, created_at, updated_at)
+			VALUES (?, ?, 'Ordered', '', 'open', ?, ?)
+		`, fixture.testProjectID, 100+i, time.Now().Format(time.RFC3339), time.Now().Format(time.RFC3339))
+		if err != nil {
+			t.Fatalf("Failed to create issue: %v", err)
+		}
+		issueID, err := result.LastInsertId()
+		if err != nil {
+			t.Fatalf("Failed to get issue ID: %v", err)
+		}
+		if err := CreateSyncState(fixture.db, issueID, state, nil); err != nil {
+			t.Fatalf("Failed to create sync state: %v", err)
+		}
+	}
+
+	expected := []SyncStateCount{
+		{State: SyncStateLocalOnly, Count: 1},
+		{State: SyncStatePushFailed, Count: 1},
+		{State: SyncStateSynced, Count: 2},
+		{State: SyncStateConflicted, Count: 1},
+	}
+	for run := 0; run < 5; run++ {
+		summary, err := GetOrderedSyncStateSummary(fixture.db)
+		if err != nil {
+			t.Fatalf("GetOrderedSyncStateSummary failed: %v", err)
+		}
+		if !reflect.DeepEqual(summary, expected) {
+			t.Fatalf("Expected %v, got %v", expected, summary)
+		}
+	}
+}
+
+func TestOrderSyncStateSummary(t *testing.T) {
+	summary := make(map[SyncState]int)
+	for i, state := range SyncStates {
+		summary[state] = i + 1
+	}
+	summary["ZOMBIE"] = 7
+	summary["ARCHIVED"] = 3
+
+	ordered := OrderSyncStateSummary(summary)
+	if len(ordered) != len(SyncStates)+2 {
+		t.Fatalf("Expected %d states, got %d", len(SyncStates)+2, len(ordered))
+	}
+	for i, state := range SyncStates {
+		if ordered[i].State != state || ordered[i].Count != i+1 {
+			t.Errorf("Expected %s=%d at position %d, got %s=%d", state, i+1, i, ordered[i].State, ordered[i].Count)
+		}
+	}
+	if tail := ordered[len(SyncStates):]; tail[0].State != "ARCHIVED" || tail[1].State != "ZOMBIE" {
+		t.Errorf("Expected unknown states last in name order, got %v", tail)
+	}
+}
+
 // Test retry count increment
 func TestRetryCountIncrement(t *testing.T) {
 	fixture := setupSyncStateTest(t)
@@ -977,3 +1041,65 @@ func TestSyncAttemptTracking(t *testing.T) {
 		t.Errorf("Expected sync attempt timestamp to be updated: first=%v, second=%v", firstAttempt, *state.LastSyncAttempt)
 	}
 }
+
+func TestParseSyncState(t *testing.T) {
+	for _, state := range SyncStates {
+		name := state.String()
+		variants := []string{
+			name,
+			strings.ToLower(name),
+			strings.ToLower(strings.ReplaceAll(name, "_", "-")),
+			"  " + name[:1] + strings.ToLower(name[1:]) + " ",
+		}
+		for _, variant := range variants {
+			parsed, err := ParseSyncState(variant)
+			if err != nil {
+				t.Errorf("Expected %q to parse, got: %v", variant, err)
+				continue
+			}
+			if parsed != state {
+				t.Errorf("Expected %q to parse as %s, got %s", variant, state, parsed)
+			}
+		}
+		if !state.Valid() {
+			t.Errorf("Expected %s to be valid", state)
+		}
+	}
+
+	for _, name := range []string{"", "SYNCING", "local only", "LOCAL__ONLY"} {
+		parsed, err := ParseSyncState(name)
+		if err == nil {
+			t.Errorf("Expected %q to be rejected, got %s", name, parsed)
+			continue
+		}
+		if !strings.Contains(err.Error(), "unknown sync state") || !strings.Contains(err.Error(), "LOCAL_ONLY") {
+			t.Errorf("Expected the error to list the valid states, got: %v", err)
+		}
+	}
+	if SyncState("synced").Valid() {
+		t.Error("Expected Valid to require the stored upper-case name")
+	}
+}
+
+func TestSyncStateWritesRejectUnknownStates(t *testing.T) {
+	fixture := setupSyncStateTest(t)
+	defer teardownSyncStateTest(fixture)
+
+	if err := CreateSyncState(fixture.db, fixture.testIssueID, SyncState("BOGUS"), nil); err == nil {
+		t.Error("Expected creating an unknown sync state to fail")
+	}
+	if err := CreateSyncState(fixture.db, fixture.testIssueID, SyncStateLocalOnly, nil); err != nil {
+		t.Fatalf("Failed to create sync state: %v", err)
+	}
+	if err := UpdateSyncState(fixture.db, fixture.testIssueID, SyncState("BOGUS"), nil, nil); err == nil {
+		t.Error("Expected updating to an unknown sync state to fail")
+	}
+
+	state, err := GetSyncState(fixture.db, fixture.testIssueID)
+	if err != nil {
+		t.Fatalf("Failed to get sync state: %v", err)
+	}
+	if state.SyncState != SyncStateLocalOnly {
+		t.Errorf("Expected the state to stay LOCAL_ONLY, got %s", state.SyncState)
+	}
+}
@@ -0,0 +1,58 @@
+package internal
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// HashMismatch is a stored issue whose content no longer matches its sync hash
+type HashMismatch struct {
+	Number       int
+	StoredHash   string
+	ComputedHash string
+}
+
+// ChecksumReport is the outcome of verifying the sync hashes of a project
+type ChecksumReport struct {
+	Checked    int
+	Mismatches []HashMismatch
+}
+
+// VerifySyncHashes re-reads every synced issue of a project and compares its stored
+// sync_hash with a hash recomputed from the stored content. Issues without a hash
+// or with local edits are skipped, since their content is expected to differ.
+func VerifySyncHashes(db *sql.DB, projectID int64) (*ChecksumReport, error) {
+	rows, err := db.Query(`
+		SELECT number, title, COALESCE(body, ''), state, COALESCE(labels, ''), COALESCE(assignees, ''), sync_hash
+		FROM issues
+		WHERE project_id = ? AND sync_hash IS NOT NULL AND sync_hash != '' AND local_modified_at IS NULL
+		ORDER BY number`, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read issues for verification: %w", err)
+	}
+	defer rows.Close()
+
+	report := &ChecksumReport{}
+	for rows.Next() {
+		var issue DBIssue
+		var storedHash string
+		if err := rows.Scan(&issue.Number, &issue.Title, &issue.Body, &issue.State,
+			&issue.Labels, &issue.Assignees, &storedHash); err != nil {
+			return nil, fmt.Errorf("failed to scan issue for verification: %w", err)
+		}
+
+		report.Checked++
+		if computed := ComputeSyncHash(&issue); computed != storedHash {
+			report.Mismatches = append(report.Mismatches, HashMismatch{
+				Number:       issue.Number,
+				StoredHash:   storedHash,
+				ComputedHash: computed,
+			})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read issues for verification: %w", err)
+	}
+
+	return report, nil
+}
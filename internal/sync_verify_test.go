@@ -0,0 +1,122 @@
+package internal
+
+import (
+	"bytes"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestVerifySyncHashes_FlagsCorruptedRow(t *testing.T) {
+	db := newTestMultiProjectDB(t)
+	projectID, err := CreateProject(db, &ProjectConfig{Owner: "org", Repo: "repo"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	for i := 1; i <= 3; i++ {
+		issue := &DBIssue{ID: i, Number: i, Title: "Issue", Body: "Body with accents: café", State: "open", Labels: "bug"}
+		if err := SaveIssue(db, projectID, issue); err != nil {
+			t.Fatalf("Failed to save issue: %v", err)
+		}
+	}
+
+	// Simulate truncation of one stored body
+	if _, err := db.Exec("UPDATE issues SET body = 'Body with acc' WHERE number = 2 AND project_id = ?", projectID); err != nil {
+		t.Fatalf("Failed to corrupt issue: %v", err)
+	}
+
+	report, err := VerifySyncHashes(db, projectID)
+	if err != nil {
+		t.Fatalf("VerifySyncHashes failed: %v", err)
+	}
+	if report.Checked != 3 {
+		t.Errorf("Expected 3 checked issues, got %d", report.Checked)
+	}
+	if len(report.Mismatches) != 1 || report.Mismatches[0].Number != 2 {
+		t.Fatalf("Expected issue #2 to be flagged, got %+v", report.Mismatches)
+	}
+	if report.Mismatches[0].StoredHash == report.Mismatches[0].ComputedHash {
+		t.Error("Expected stored and computed hashes to differ")
+	}
+}
+
+func TestVerifySyncHashes_SkipsLocallyModifiedAndUnhashed(t *testing.T) {
+	db := newTestMultiProjectDB(t)
+	projectID, err := CreateProject(db, &ProjectConfig{Owner: "org", Repo: "repo"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	for i := 1; i <= 2; i++ {
+		if err := SaveIssue(db, projectID, &DBIssue{ID: i, Number: i, Title: "Issue", State: "open"}); err != nil {
+			t.Fatalf("Failed to save issue: %v", err)
+		}
+	}
+
+	if _, err := db.Exec("UPDATE issues SET title = 'Edited locally', local_modified_at = CURRENT_TIMESTAMP WHERE number = 1"); err != nil {
+		t.Fatalf("Failed to edit issue: %v", err)
+	}
+	if _, err := db.Exec("UPDATE issues SET title = 'Legacy', sync_hash = NULL WHERE number = 2"); err != nil {
+		t.Fatalf("Failed to clear hash: %v", err)
+	}
+
+	report, err := VerifySyncHashes(db, projectID)
+	if err != nil {
+		t.Fatalf("VerifySyncHashes failed: %v", err)
+	}
+	if report.Checked != 0 || len(report.Mismatches) != 0 {
+		t.Errorf("Expected both issues to be skipped, got %+v", report)
+	}
+}
+
+func TestSyncAdHoc_ChecksumVerify(t *testing.T) {
+	issuesJSON := `[
+		{"id": 701, "number": 1, "title": "First", "body": "one", "state": "open", "updated_at": "2024-03-01T10:00:00Z"},
+		{"id": 702, "number": 2, "title": "Second", "body": "two", "state": "open", "updated_at": "2024-03-01T10:00:00Z"}
+	]`
+	newMockGitHubServer(t, "octo", "widgets", "", map[string]http.HandlerFunc{
+		"/repos/octo/widgets/issues": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(issuesJSON))
+		},
+	})
+
+	config, err := NewAdHocConfig("octo/widgets", "test-token")
+	if err != nil {
+		t.Fatalf("NewAdHocConfig failed: %v", err)
+	}
+	config.Global.Database = filepath.Join(t.TempDir(), "pivot.db")
+
+	result, err := SyncAdHoc(config, SyncOptions{ChecksumVerify: true})
+	if err != nil {
+		t.Fatalf("First sync failed: %v", err)
+	}
+	if mismatches := result.Totals().ChecksumMismatches; len(mismatches) != 0 {
+		t.Fatalf("Expected a clean sync to verify, got mismatches %v", mismatches)
+	}
+
+	db, err := InitMultiProjectDBFromPath(config.Global.Database)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if _, err := db.Exec("UPDATE issues SET body = 'mangled' WHERE number = 1"); err != nil {
+		t.Fatalf("Failed to corrupt issue: %v", err)
+	}
+	db.Close()
+
+	// The second sync only refreshes issue #2, so the corrupted #1 is still stored
+	issuesJSON = `[{"id": 702, "number": 2, "title": "Second", "body": "two", "state": "open", "updated_at": "2024-03-02T10:00:00Z"}]`
+	result, err = SyncAdHoc(config, SyncOptions{ChecksumVerify: true})
+	if err != nil {
+		t.Fatalf("Second sync failed: %v", err)
+	}
+	mismatches := result.Totals().ChecksumMismatches
+	if len(mismatches) != 1 || mismatches[0] != 1 {
+		t.Errorf("Expected issue #1 to fail verification, got %v", mismatches)
+	}
+
+	var out bytes.Buffer
+	PrintSyncResult(&out, result)
+	if !strings.Contains(out.String(), "1 issues failed checksum verification") {
+		t.Errorf("Expected mismatch in summary, got: %s", out.String())
+	}
+}
@@ -0,0 +1,111 @@
+package internal
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// createSyncWatermarkTable creates the table recording the incremental sync
+// watermark (the newest updated_at seen by the last successful sync) per project
+func createSyncWatermarkTable(db *sql.DB) error {
+	schema := `
+	CREATE TABLE IF NOT EXISTS sync_watermarks (
+		project_id INTEGER PRIMARY KEY,
+		since TEXT NOT NULL,
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY(project_id) REFERENCES projects(id) ON DELETE CASCADE
+	);`
+
+	if _, err := db.Exec(schema); err != nil {
+		return fmt.Errorf("failed to create sync_watermarks table: %w", err)
+	}
+	return nil
+}
+
+// GetSyncWatermark returns the incremental sync watermark of a project ("" = none)
+func GetSyncWatermark(db *sql.DB, projectID int64) (string, error) {
+	var since string
+	err := db.QueryRow("SELECT since FROM sync_watermarks WHERE project_id = ?", projectID).Scan(&since)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read sync watermark: %w", err)
+	}
+	return since, nil
+}
+
+// SaveSyncWatermark records the incremental sync watermark of a project
+func SaveSyncWatermark(db *sql.DB, projectID int64, since string) error {
+	_, err := db.Exec(`
+		INSERT OR REPLACE INTO sync_watermarks (project_id, since, updated_at)
+		VALUES (?, ?, CURRENT_TIMESTAMP)`, projectID, since)
+	if err != nil {
+		return fmt.Errorf("failed to save sync watermark: %w", err)
+	}
+	return nil
+}
+
+// GetLastSyncedAt returns when the last successful sync of a project that was not
+// limited to an assignee finished (zero = never)
+func GetLastSyncedAt(db *sql.DB, projectID int64) (time.Time, error) {
+	var syncedAt time.Time
+	err := db.QueryRow("SELECT updated_at FROM sync_watermarks WHERE project_id = ?", projectID).Scan(&syncedAt)
+	if err == sql.ErrNoRows {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read last sync time: %w", err)
+	}
+	return syncedAt, nil
+}
+
+// ResetSyncWatermark removes the watermark so the next sync fetches every issue
+func ResetSyncWatermark(db *sql.DB, projectID int64) error {
+	if _, err := db.Exec("DELETE FROM sync_watermarks WHERE project_id = ?", projectID); err != nil {
+		return fmt.Errorf("failed to reset sync watermark: %w", err)
+	}
+	return nil
+}
+
+// rewindSyncWatermark moves the watermark of a project back to since when it is
+// newer, so the next incremental sync fetches the issues updated after since again.
+// An unparseable since resets the watermark.
+func rewindSyncWatermark(db *sql.DB, projectID int64, since string) error {
+	// Databases of issues created before incremental syncs have no watermark table
+	if err := createSyncWatermarkTable(db); err != nil {
+		return err
+	}
+	current, err := GetSyncWatermark(db, projectID)
+	if err != nil || current == "" {
+		return err
+	}
+	sinceTime, err := time.Parse(time.RFC3339, since)
+	if err != nil {
+		return ResetSyncWatermark(db, projectID)
+	}
+	if currentTime, err := time.Parse(time.RFC3339, current); err == nil && !currentTime.After(sinceTime) {
+		return nil
+	}
+	return SaveSyncWatermark(db, projectID, since)
+}
+
+// latestUpdatedAt returns the newest RFC 3339 timestamp of current and the
+// updated_at of the given issues. Unparseable timestamps are ignored.
+func latestUpdatedAt(current string, issues []Issue) string {
+	latest, latestTime := current, time.Time{}
+	if t, err := time.Parse(time.RFC3339, current); err == nil {
+		latestTime = t
+	}
+	for _, issue := range issues {
+		t, err := time.Parse(time.RFC3339, issue.UpdatedAt)
+		if err != nil {
+			continue
+		}
+		if t.After(latestTime) {
+			latest, latestTime = issue.UpdatedAt, t
+		}
+	}
+	return latest
+}
@@ -0,0 +1,320 @@
+package internal
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLatestUpdatedAt(t *testing.T) {
+	issues, _, err := decodeIssues([]byte(`[
+		{"id": 1, "number": 1, "updated_at": "2024-03-01T10:00:00Z"},
+		{"id": 2, "number": 2, "updated_at": "2024-03-05T08:30:00Z"},
+		{"id": 3, "number": 3, "updated_at": "not a time"}
+	]`))
+	if err != nil {
+		t.Fatalf("decodeIssues failed: %v", err)
+	}
+
+	if got := latestUpdatedAt("", issues); got != "2024-03-05T08:30:00Z" {
+		t.Errorf("Expected newest updated_at, got %q", got)
+	}
+	if got := latestUpdatedAt("2024-04-01T00:00:00Z", issues); got != "2024-04-01T00:00:00Z" {
+		t.Errorf("Expected newer current watermark to be kept, got %q", got)
+	}
+	if got := latestUpdatedAt("2024-02-01T00:00:00Z", nil); got != "2024-02-01T00:00:00Z" {
+		t.Errorf("Expected watermark unchanged without issues, got %q", got)
+	}
+}
+
+func TestSyncWatermark_SaveGetReset(t *testing.T) {
+	db := newTestMultiProjectDB(t)
+
+	if since, err := GetSyncWatermark(db, 1); err != nil || since != "" {
+		t.Fatalf("Expected no watermark, got %q (%v)", since, err)
+	}
+	if err := SaveSyncWatermark(db, 1, "2024-03-05T08:30:00Z"); err != nil {
+		t.Fatalf("SaveSyncWatermark failed: %v", err)
+	}
+	if since, _ := GetSyncWatermark(db, 1); since != "2024-03-05T08:30:00Z" {
+		t.Errorf("Expected saved watermark, got %q", since)
+	}
+	if err := ResetSyncWatermark(db, 1); err != nil {
+		t.Fatalf("ResetSyncWatermark failed: %v", err)
+	}
+	if since, _ := GetSyncWatermark(db, 1); since != "" {
+		t.Errorf("Expected watermark to be reset, got %q", since)
+	}
+}
+
+func TestSyncAdHoc_WatermarkAdvancesOnlyOnSuccess(t *testing.T) {
+	var sinceParams []string
+	var pageTwoStatus = http.StatusOK
+	var issuesJSON = `[{"id": 501, "number": 1, "title": "First", "state": "open", "updated_at": "2024-03-01T10:00:00Z"}]`
+
+	newMockGitHubServer(t, "octo", "widgets", "", map[string]http.HandlerFunc{
+		"/repos/octo/widgets/issues": func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query()
+			if query.Get("page") == "1" {
+				sinceParams = append(sinceParams, query.Get("since"))
+				w.Header().Set("Link", `<http://`+r.Host+`/repos/octo/widgets/issues?page=2>; rel="next"`)
+				_, _ = w.Write([]byte(issuesJSON))
+				return
+			}
+			w.WriteHeader(pageTwoStatus)
+			_, _ = w.Write([]byte(`[]`))
+		},
+	})
+
+	config, err := NewAdHocConfig("octo/widgets", "test-token")
+	if err != nil {
+		t.Fatalf("NewAdHocConfig failed: %v", err)
+	}
+	config.Global.Database = filepath.Join(t.TempDir(), "pivot.db")
+
+	// First successful sync fetches everything and records the watermark
+	if _, err := SyncAdHoc(config, SyncOptions{}); err != nil {
+		t.Fatalf("First sync failed: %v", err)
+	}
+
+	db, err := InitMultiProjectDBFromPath(config.Global.Database)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	projectID, err := getProjectID(db, "octo", "widgets")
+	if err != nil {
+		t.Fatalf("Expected project to be recorded: %v", err)
+	}
+
+	if since, _ := GetSyncWatermark(db, projectID); since != "2024-03-01T10:00:00Z" {
+		t.Fatalf("Expected watermark 2024-03-01T10:00:00Z after success, got %q", since)
+	}
+
+	// A failed sync sees a newer issue but must not advance the watermark
+	issuesJSON = `[{"id": 501, "number": 1, "title": "First", "state": "open", "updated_at": "2024-03-09T12:00:00Z"}]`
+	pageTwoStatus = http.StatusInternalServerError
+	if _, err := SyncAdHoc(config, SyncOptions{}); err == nil {
+		t.Fatal("Expected second sync to fail")
+	}
+	if since, _ := GetSyncWatermark(db, projectID); since != "2024-03-01T10:00:00Z" {
+		t.Errorf("Expected watermark unchanged after failure, got %q", since)
+	}
+
+	// The next successful sync still asks from the old watermark, then advances it
+	pageTwoStatus = http.StatusOK
+	if _, err := SyncAdHoc(config, SyncOptions{}); err != nil {
+		t.Fatalf("Third sync failed: %v", err)
+	}
+	if since, _ := GetSyncWatermark(db, projectID); since != "2024-03-09T12:00:00Z" {
+		t.Errorf("Expected watermark to advance after success, got %q", since)
+	}
+
+	expected := []string{"", "2024-03-01T10:00:00Z", "2024-03-01T10:00:00Z"}
+	if len(sinceParams) != len(expected) {
+		t.Fatalf("Expected %d syncs, got since params %v", len(expected), sinceParams)
+	}
+	for i, since := range expected {
+		if sinceParams[i] != since {
+			t.Errorf("Sync %d: expected since=%q, got %q", i+1, since, sinceParams[i])
+		}
+	}
+
+	// Resetting the watermark fetches everything again
+	if _, err := SyncAdHoc(config, SyncOptions{ResetWatermark: true}); err != nil {
+		t.Fatalf("Reset sync failed: %v", err)
+	}
+	if last := sinceParams[len(sinceParams)-1]; last != "" {
+		t.Errorf("Expected no since parameter after reset, got %q", last)
+	}
+
+	// A full sync ignores the watermark without forgetting it
+	if _, err := SyncAdHoc(config, SyncOptions{FullSync: true}); err != nil {
+		t.Fatalf("Full sync failed: %v", err)
+	}
+	if last := sinceParams[len(sinceParams)-1]; last != "" {
+		t.Errorf("Expected no since parameter for full sync, got %q", last)
+	}
+	if since, _ := GetSyncWatermark(db, projectID); since != "2024-03-09T12:00:00Z" {
+		t.Errorf("Expected watermark kept after full sync, got %q", since)
+	}
+}
+
+// captureStdout returns what fn prints to standard output
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	oldStdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = oldStdout }()
+
+	done := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		done <- string(data)
+	}()
+	fn()
+	_ = w.Close()
+	return <-done
+}
+
+func TestSyncAdHoc_EmptyRepository(t *testing.T) {
+	issuesJSON := `[]`
+	newMockGitHubServer(t, "octo", "empty", "", map[string]http.HandlerFunc{
+		"/repos/octo/empty/issues": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(issuesJSON))
+		},
+	})
+
+	config, err := NewAdHocConfig("octo/empty", "test-token")
+	if err != nil {
+		t.Fatalf("NewAdHocConfig failed: %v", err)
+	}
+	config.Global.Database = filepath.Join(t.TempDir(), "pivot.db")
+
+	var result *SyncResult
+	output := captureStdout(t, func() {
+		result, err = SyncAdHoc(config, SyncOptions{})
+	})
+	if err != nil {
+		t.Fatalf("Sync of an empty repository failed: %v", err)
+	}
+	if !strings.Contains(output, "No issues found in octo/empty") {
+		t.Errorf("Expected 'No issues found' message, got:\n%s", output)
+	}
+	if strings.Contains(output, "Saved 0 issues") {
+		t.Errorf("Expected no 'Saved 0 issues' message for an empty repository, got:\n%s", output)
+	}
+	if len(result.Projects) != 1 || !result.Projects[0].NoIssues || result.HasErrors() {
+		t.Fatalf("Expected one successful project without issues, got %+v", result.Projects)
+	}
+
+	var summary bytes.Buffer
+	PrintSyncResult(&summary, result)
+	if !strings.Contains(summary.String(), "✓ octo/empty: no issues found") {
+		t.Errorf("Expected summary to report no issues found, got:\n%s", summary.String())
+	}
+
+	db, err := InitMultiProjectDBFromPath(config.Global.Database)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	projectID, err := getProjectID(db, "octo", "empty")
+	if err != nil {
+		t.Fatalf("Expected project to be recorded: %v", err)
+	}
+	if since, _ := GetSyncWatermark(db, projectID); since != "" {
+		t.Errorf("Expected no watermark without issues, got %q", since)
+	}
+	syncedAt, err := GetLastSyncedAt(db, projectID)
+	if err != nil {
+		t.Fatalf("GetLastSyncedAt failed: %v", err)
+	}
+	if syncedAt.IsZero() {
+		t.Error("Expected the last sync time to be recorded for an empty repository")
+	}
+
+	// Once the first issue appears, the next sync saves it and sets the watermark
+	issuesJSON = `[{"id": 601, "number": 1, "title": "First", "state": "open", "updated_at": "2024-05-01T09:00:00Z"}]`
+	output = captureStdout(t, func() {
+		result, err = SyncAdHoc(config, SyncOptions{})
+	})
+	if err != nil {
+		t.Fatalf("Second sync failed: %v", err)
+	}
+	if !strings.Contains(output, "Saved 1 issues") || result.Projects[0].NoIssues {
+		t.Errorf("Expected the new issue to be saved, got:\n%s", output)
+	}
+	if since, _ := GetSyncWatermark(db, projectID); since != "2024-05-01T09:00:00Z" {
+		t.Errorf("Expected watermark 2024-05-01T09:00:00Z, got %q", since)
+	}
+
+	// An incremental sync with nothing new says so instead of reporting an empty repository
+	issuesJSON = `[]`
+	output = captureStdout(t, func() {
+		result, err = SyncAdHoc(config, SyncOptions{})
+	})
+	if err != nil {
+		t.Fatalf("Third sync failed: %v", err)
+	}
+	if !strings.Contains(output, "No issues found updated since 2024-05-01T09:00:00Z") {
+		t.Errorf("Expected incremental 'No issues found' message, got:\n%s", output)
+	}
+	if since, _ := GetSyncWatermark(db, projectID); since != "2024-05-01T09:00:00Z" {
+		t.Errorf("Expected watermark kept, got %q", since)
+	}
+}
+
+func TestSyncAdHoc_EmptyRepositoryErrorIsNotNoIssues(t *testing.T) {
+	newMockGitHubServer(t, "octo", "broken", "", map[string]http.HandlerFunc{
+		"/repos/octo/broken/issues": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`[]`))
+		},
+	})
+
+	config, err := NewAdHocConfig("octo/broken", "test-token")
+	if err != nil {
+		t.Fatalf("NewAdHocConfig failed: %v", err)
+	}
+	config.Global.Database = filepath.Join(t.TempDir(), "pivot.db")
+
+	output := captureStdout(t, func() {
+		_, err = SyncAdHoc(config, SyncOptions{})
+	})
+	if err == nil {
+		t.Fatal("Expected the sync to fail")
+	}
+	if strings.Contains(output, "No issues found") {
+		t.Errorf("Expected a failed sync not to report an empty repository, got:\n%s", output)
+	}
+
+	db, err := InitMultiProjectDBFromPath(config.Global.Database)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	projectID, err := getProjectID(db, "octo", "broken")
+	if err != nil {
+		t.Fatalf("Expected project to be recorded: %v", err)
+	}
+	if syncedAt, _ := GetLastSyncedAt(db, projectID); !syncedAt.IsZero() {
+		t.Errorf("Expected no last sync time after a failure, got %v", syncedAt)
+	}
+}
+
+func TestSyncAdHoc_RedirectToEmptyBody(t *testing.T) {
+	newMockGitHubServer(t, "octo", "moved", "", map[string]http.HandlerFunc{
+		"/repos/octo/moved/issues": func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "/repositories/42/issues?"+r.URL.RawQuery, http.StatusMovedPermanently)
+		},
+		"/repositories/42/issues": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		},
+	})
+
+	config, err := NewAdHocConfig("octo/moved", "test-token")
+	if err != nil {
+		t.Fatalf("NewAdHocConfig failed: %v", err)
+	}
+	config.Global.Database = filepath.Join(t.TempDir(), "pivot.db")
+
+	var result *SyncResult
+	output := captureStdout(t, func() {
+		result, err = SyncAdHoc(config, SyncOptions{})
+	})
+	if err != nil {
+		t.Fatalf("Expected an empty body after a redirect to mean no issues, got: %v", err)
+	}
+	if !result.Projects[0].NoIssues || !strings.Contains(output, "No issues found in octo/moved") {
+		t.Errorf("Expected 'No issues found' for an empty redirected response, got:\n%s", output)
+	}
+}
@@ -0,0 +1,85 @@
+package internal
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// TemplateData is the value passed to user-provided export templates
+type TemplateData struct {
+	Issues      []DBIssue
+	Count       int
+	ByState     map[string][]DBIssue
+	ByLabel     map[string][]DBIssue
+	ByAssignee  map[string][]DBIssue
+	GeneratedAt string
+}
+
+// templateFuncs are the helper functions available inside export templates
+var templateFuncs = template.FuncMap{
+	"split": splitCommaList,
+	"join":  strings.Join,
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"count": func(issues []DBIssue) int { return len(issues) },
+	"sortedKeys": func(groups map[string][]DBIssue) []string {
+		keys := make([]string, 0, len(groups))
+		for key := range groups {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return keys
+	},
+}
+
+// ParseExportTemplate reads and parses an export template file
+func ParseExportTemplate(path string) (*template.Template, error) {
+	content, err := os.ReadFile(path) // #nosec G304 - User controls template file path
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template file: %w", err)
+	}
+
+	tmpl, err := template.New(path).Funcs(templateFuncs).Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// NewTemplateData builds the template data for a set of issues, grouping them by state, label and assignee
+func NewTemplateData(issues []DBIssue) *TemplateData {
+	data := &TemplateData{
+		Issues:      issues,
+		Count:       len(issues),
+		ByState:     make(map[string][]DBIssue),
+		ByLabel:     make(map[string][]DBIssue),
+		ByAssignee:  make(map[string][]DBIssue),
+		GeneratedAt: time.Now().Format(time.RFC3339),
+	}
+
+	for _, issue := range issues {
+		data.ByState[issue.State] = append(data.ByState[issue.State], issue)
+		for _, label := range splitCommaList(issue.Labels) {
+			data.ByLabel[label] = append(data.ByLabel[label], issue)
+		}
+		for _, assignee := range splitCommaList(issue.Assignees) {
+			data.ByAssignee[assignee] = append(data.ByAssignee[assignee], issue)
+		}
+	}
+
+	return data
+}
+
+// RenderIssueTemplate executes a parsed export template against a set of issues
+func RenderIssueTemplate(w io.Writer, tmpl *template.Template, issues []DBIssue) error {
+	if err := tmpl.Execute(w, NewTemplateData(issues)); err != nil {
+		return fmt.Errorf("failed to render template: %w", err)
+	}
+	return nil
+}
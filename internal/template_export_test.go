@@ -0,0 +1,82 @@
+package internal
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTestTemplate(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "report.tmpl")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+	return path
+}
+
+func TestRenderIssueTemplate(t *testing.T) {
+	db := newTestMultiProjectDB(t)
+
+	projectID, err := CreateProject(db, &ProjectConfig{Owner: "owner", Repo: "repo"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	seed := []DBIssue{
+		{ID: 1, Number: 1, Title: "Login bug", State: "open", Labels: "bug,urgent", Assignees: "alice"},
+		{ID: 2, Number: 2, Title: "Dashboard", State: "closed", Labels: "feature", Assignees: "bob"},
+		{ID: 3, Number: 3, Title: "Crash", State: "open", Labels: "bug"},
+	}
+	for i := range seed {
+		if err := SaveIssue(db, projectID, &seed[i]); err != nil {
+			t.Fatalf("Failed to save issue: %v", err)
+		}
+	}
+
+	issues, err := GetAllIssues(db)
+	if err != nil {
+		t.Fatalf("Failed to load issues: %v", err)
+	}
+
+	tmpl, err := ParseExportTemplate(writeTestTemplate(t, `Total: {{.Count}}
+{{range .Issues}}#{{.Number}} {{.Title}} [{{join (split .Labels) "|"}}]
+{{end}}{{range $state := sortedKeys .ByState}}{{$state}}={{count (index $.ByState $state)}}
+{{end}}bugs={{count (index .ByLabel "bug")}}`))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := RenderIssueTemplate(&out, tmpl, issues); err != nil {
+		t.Fatalf("Failed to render template: %v", err)
+	}
+
+	expected := `Total: 3
+#1 Login bug [bug|urgent]
+#2 Dashboard [feature]
+#3 Crash [bug]
+closed=1
+open=2
+bugs=2`
+	if out.String() != expected {
+		t.Errorf("Unexpected rendered output:\n%s\nexpected:\n%s", out.String(), expected)
+	}
+}
+
+func TestParseExportTemplate_Invalid(t *testing.T) {
+	_, err := ParseExportTemplate(writeTestTemplate(t, "{{range .Issues}}unterminated"))
+	if err == nil {
+		t.Fatal("Expected error for invalid template")
+	}
+	if !strings.Contains(err.Error(), "failed to parse template") {
+		t.Errorf("Expected parse error, got: %v", err)
+	}
+}
+
+func TestParseExportTemplate_MissingFile(t *testing.T) {
+	if _, err := ParseExportTemplate(filepath.Join(t.TempDir(), "missing.tmpl")); err == nil {
+		t.Error("Expected error for missing template file")
+	}
+}
@@ -0,0 +1,216 @@
+package internal
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Timeline events kept by sync --include-timeline; other event types are dropped
+const (
+	TimelineEventClosed   = "closed"
+	TimelineEventReopened = "reopened"
+	TimelineEventLabeled  = "labeled"
+)
+
+// IssueEvent is a state or label change from the timeline of an issue
+type IssueEvent struct {
+	Number    int    `json:"number"`
+	EventID   int64  `json:"id"`
+	Event     string `json:"event"`           // closed, reopened or labeled
+	Actor     string `json:"actor,omitempty"` // Login of the user who caused the event
+	Label     string `json:"label,omitempty"` // Label name of labeled events
+	CreatedAt string `json:"created_at"`
+}
+
+// timelineEvent is one entry of the GitHub issue timeline API
+type timelineEvent struct {
+	ID    int64  `json:"id"`
+	Event string `json:"event"`
+	Actor *struct {
+		Login string `json:"login"`
+	} `json:"actor"`
+	Label *struct {
+		Name string `json:"name"`
+	} `json:"label"`
+	CreatedAt string `json:"created_at"`
+}
+
+// isTrackedTimelineEvent reports whether sync --include-timeline keeps an event type
+func isTrackedTimelineEvent(event string) bool {
+	switch event {
+	case TimelineEventClosed, TimelineEventReopened, TimelineEventLabeled:
+		return true
+	}
+	return false
+}
+
+// parseTimelineEvents decodes a page of the timeline API, keeping the tracked events
+// in the order GitHub returned them
+func parseTimelineEvents(number int, data []byte) ([]IssueEvent, error) {
+	var entries []timelineEvent
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse timeline of issue #%d: %w", number, err)
+	}
+
+	var events []IssueEvent
+	for _, entry := range entries {
+		if !isTrackedTimelineEvent(entry.Event) {
+			continue
+		}
+		event := IssueEvent{Number: number, EventID: entry.ID, Event: entry.Event, CreatedAt: entry.CreatedAt}
+		if entry.Actor != nil {
+			event.Actor = entry.Actor.Login
+		}
+		if entry.Label != nil {
+			event.Label = entry.Label.Name
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// FetchIssueTimeline returns the closed, reopened and labeled events of an issue,
+// oldest first, following the pages of the timeline API
+func FetchIssueTimeline(owner, repo, token string, number int) ([]IssueEvent, error) {
+	var events []IssueEvent
+	for page := 1; ; page++ {
+		url := fmt.Sprintf("%s/repos/%s/%s/issues/%d/timeline?per_page=100&page=%d", githubAPIURL, owner, repo, number, page)
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Authorization", "token "+token)
+		req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+		resp, err := newHTTPClient().Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch timeline of issue #%d: %w", number, err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read timeline of issue #%d: %w", number, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("GitHub API error (%d) fetching timeline of issue #%d: %s", resp.StatusCode, number, strings.TrimSpace(string(body)))
+		}
+
+		pageEvents, err := parseTimelineEvents(number, body)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, pageEvents...)
+
+		if !strings.Contains(resp.Header.Get("Link"), `rel="next"`) {
+			return events, nil
+		}
+	}
+}
+
+// createIssueEventsTable creates the table holding the timeline events of each issue
+func createIssueEventsTable(db *sql.DB) error {
+	schema := `
+	CREATE TABLE IF NOT EXISTS issue_events (
+		project_id INTEGER NOT NULL,
+		number INTEGER NOT NULL,
+		event_id INTEGER NOT NULL,
+		position INTEGER NOT NULL,
+		event TEXT NOT NULL,
+		actor TEXT,
+		label TEXT,
+		created_at TEXT,
+		PRIMARY KEY(project_id, number, event_id),
+		FOREIGN KEY(project_id) REFERENCES projects(id) ON DELETE CASCADE
+	);`
+
+	if _, err := db.Exec(schema); err != nil {
+		return fmt.Errorf("failed to create issue_events table: %w", err)
+	}
+	return nil
+}
+
+// SaveIssueEvents replaces the stored timeline events of an issue, keeping their order
+func SaveIssueEvents(db *sql.DB, projectID int64, number int, events []IssueEvent) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec("DELETE FROM issue_events WHERE project_id = ? AND number = ?", projectID, number); err != nil {
+		return fmt.Errorf("failed to clear events of issue #%d: %w", number, err)
+	}
+	for position, event := range events {
+		if _, err := tx.Exec(`
+			INSERT OR REPLACE INTO issue_events (project_id, number, event_id, position, event, actor, label, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			projectID, number, event.EventID, position, event.Event, event.Actor, event.Label, event.CreatedAt); err != nil {
+			return fmt.Errorf("failed to save events of issue #%d: %w", number, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit events of issue #%d: %w", number, err)
+	}
+	return nil
+}
+
+// GetIssueEvents returns the stored timeline events of an issue in timeline order
+func GetIssueEvents(db *sql.DB, projectID int64, number int) ([]IssueEvent, error) {
+	rows, err := db.Query(`
+		SELECT event_id, event, COALESCE(actor, ''), COALESCE(label, ''), COALESCE(created_at, '')
+		FROM issue_events
+		WHERE project_id = ? AND number = ?
+		ORDER BY position`, projectID, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query events of issue #%d: %w", number, err)
+	}
+	defer rows.Close()
+
+	var events []IssueEvent
+	for rows.Next() {
+		event := IssueEvent{Number: number}
+		if err := rows.Scan(&event.EventID, &event.Event, &event.Actor, &event.Label, &event.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read events of issue #%d: %w", number, err)
+	}
+	return events, nil
+}
+
+// syncIssueTimelines fetches and stores the timeline events of the given issues,
+// counting the stored events in result
+func syncIssueTimelines(db *sql.DB, projectID int64, owner, repo, token string, numbers []int, result *ProjectSyncResult) error {
+	for _, number := range numbers {
+		events, err := FetchIssueTimeline(owner, repo, token, number)
+		if err != nil {
+			return err
+		}
+		if err := SaveIssueEvents(db, projectID, number, events); err != nil {
+			return err
+		}
+		result.TimelineEvents += len(events)
+	}
+	return nil
+}
+
+// PrintIssueEvents writes the timeline events of an issue, one per line
+func PrintIssueEvents(w io.Writer, events []IssueEvent) {
+	for _, event := range events {
+		line := fmt.Sprintf("  %-20s  %-8s", event.CreatedAt, event.Event)
+		if event.Label != "" {
+			line += "  " + event.Label
+		}
+		if event.Actor != "" {
+			line += "  by " + event.Actor
+		}
+		fmt.Fprintln(w, line)
+	}
+}
@@ -0,0 +1,186 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// sampleTimelineJSON is a trimmed timeline API response mixing tracked and other events
+const sampleTimelineJSON = `[
+	{"id": 11, "event": "labeled", "actor": {"login": "alice"}, "created_at": "2024-03-01T10:00:00Z", "label": {"name": "bug", "color": "d73a4a"}},
+	{"id": 12, "event": "commented", "actor": {"login": "bob"}, "created_at": "2024-03-01T11:00:00Z", "body": "Confirmed"},
+	{"event": "cross-referenced", "created_at": "2024-03-01T12:00:00Z", "source": {"type": "issue"}},
+	{"id": 13, "event": "closed", "actor": {"login": "bob"}, "created_at": "2024-03-02T09:00:00Z", "commit_id": null},
+	{"id": 14, "event": "reopened", "actor": {"login": "alice"}, "created_at": "2024-03-03T09:00:00Z"},
+	{"id": 15, "event": "unlabeled", "actor": {"login": "alice"}, "created_at": "2024-03-03T09:30:00Z", "label": {"name": "bug"}},
+	{"id": 16, "event": "closed", "actor": null, "created_at": "2024-03-04T09:00:00Z"}
+]`
+
+func TestParseTimelineEvents(t *testing.T) {
+	events, err := parseTimelineEvents(7, []byte(sampleTimelineJSON))
+	if err != nil {
+		t.Fatalf("parseTimelineEvents failed: %v", err)
+	}
+
+	want := []IssueEvent{
+		{Number: 7, EventID: 11, Event: "labeled", Actor: "alice", Label: "bug", CreatedAt: "2024-03-01T10:00:00Z"},
+		{Number: 7, EventID: 13, Event: "closed", Actor: "bob", CreatedAt: "2024-03-02T09:00:00Z"},
+		{Number: 7, EventID: 14, Event: "reopened", Actor: "alice", CreatedAt: "2024-03-03T09:00:00Z"},
+		{Number: 7, EventID: 16, Event: "closed", CreatedAt: "2024-03-04T09:00:00Z"},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("Expected %d events, got %d: %+v", len(want), len(events), events)
+	}
+	for i := range want {
+		if events[i] != want[i] {
+			t.Errorf("Expected event %d to be %+v, got %+v", i, want[i], events[i])
+		}
+	}
+
+	if _, err := parseTimelineEvents(7, []byte(`{"message": "oops"}`)); err == nil {
+		t.Error("Expected error for a malformed timeline")
+	}
+}
+
+// timelinePages serves a timeline split over two pages
+func timelinePages(t *testing.T, number int) http.HandlerFunc {
+	t.Helper()
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			_, _ = w.Write([]byte(`[{"id": 22, "event": "reopened", "actor": {"login": "carol"}, "created_at": "2024-04-02T00:00:00Z"}]`))
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<%s/issues/%d/timeline?page=2>; rel="next"`, r.Host, number))
+		_, _ = w.Write([]byte(`[{"id": 21, "event": "closed", "actor": {"login": "carol"}, "created_at": "2024-04-01T00:00:00Z"}]`))
+	}
+}
+
+func TestFetchIssueTimeline_Pagination(t *testing.T) {
+	newMockGitHubServer(t, "owner", "repo", "[]", map[string]http.HandlerFunc{
+		"/repos/owner/repo/issues/5/timeline": timelinePages(t, 5),
+	})
+
+	events, err := FetchIssueTimeline("owner", "repo", "token", 5)
+	if err != nil {
+		t.Fatalf("FetchIssueTimeline failed: %v", err)
+	}
+	if len(events) != 2 || events[0].EventID != 21 || events[1].EventID != 22 {
+		t.Errorf("Expected the events of both pages in order, got %+v", events)
+	}
+
+	if _, err := FetchIssueTimeline("owner", "repo", "token", 6); err == nil || !strings.Contains(err.Error(), "GitHub API error (404)") {
+		t.Errorf("Expected API error for a missing issue, got %v", err)
+	}
+}
+
+func TestSaveIssueEvents_ReplacesInOrder(t *testing.T) {
+	db := newTestMultiProjectDB(t)
+	projectID, err := CreateProject(db, &ProjectConfig{Owner: "owner", Repo: "repo"})
+	if err != nil {
+		t.Fatalf("CreateProject failed: %v", err)
+	}
+
+	// Event IDs deliberately out of order: the timeline order is kept, not the ID order
+	first := []IssueEvent{
+		{EventID: 30, Event: "closed", CreatedAt: "2024-01-01T00:00:00Z"},
+		{EventID: 10, Event: "reopened", CreatedAt: "2024-01-02T00:00:00Z"},
+	}
+	if err := SaveIssueEvents(db, projectID, 1, first); err != nil {
+		t.Fatalf("SaveIssueEvents failed: %v", err)
+	}
+	second := append(first, IssueEvent{EventID: 20, Event: "labeled", Label: "done", CreatedAt: "2024-01-03T00:00:00Z"})
+	if err := SaveIssueEvents(db, projectID, 1, second); err != nil {
+		t.Fatalf("SaveIssueEvents failed: %v", err)
+	}
+
+	events, err := GetIssueEvents(db, projectID, 1)
+	if err != nil {
+		t.Fatalf("GetIssueEvents failed: %v", err)
+	}
+	var ids []string
+	for _, event := range events {
+		ids = append(ids, fmt.Sprint(event.EventID))
+	}
+	if got := strings.Join(ids, ","); got != "30,10,20" {
+		t.Errorf("Expected events 30,10,20 in timeline order, got %s", got)
+	}
+	if events[2].Label != "done" || events[2].Number != 1 {
+		t.Errorf("Expected the labeled event with its label, got %+v", events[2])
+	}
+
+	if other, err := GetIssueEvents(db, projectID, 2); err != nil || len(other) != 0 {
+		t.Errorf("Expected no events for another issue, got %+v, %v", other, err)
+	}
+}
+
+func TestSyncIncludeTimeline_PersistsEvents(t *testing.T) {
+	issuesJSON := `[
+		{"id": 501, "number": 1, "title": "Closed once", "state": "closed", "updated_at": "2024-03-04T09:00:00Z"},
+		{"id": 502, "number": 2, "title": "Paged", "state": "open", "updated_at": "2024-04-02T00:00:00Z"}
+	]`
+	var requests int
+	newMockGitHubServer(t, "owner", "repo", issuesJSON, map[string]http.HandlerFunc{
+		"/repos/owner/repo/issues/1/timeline": func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			_, _ = w.Write([]byte(sampleTimelineJSON))
+		},
+		"/repos/owner/repo/issues/2/timeline": timelinePages(t, 2),
+	})
+	db := newTestMultiProjectDB(t)
+
+	project := &ProjectConfig{Owner: "owner", Repo: "repo"}
+	result, err := syncProjectWithOptions(db, &GlobalConfig{Token: "test-token"}, project, SyncOptions{IncludeTimeline: true})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if result.TimelineEvents != 6 {
+		t.Errorf("Expected 6 timeline events, got %d", result.TimelineEvents)
+	}
+
+	projectID, err := getProjectID(db, "owner", "repo")
+	if err != nil {
+		t.Fatalf("Failed to get project ID: %v", err)
+	}
+	events, err := GetIssueEvents(db, projectID, 1)
+	if err != nil {
+		t.Fatalf("GetIssueEvents failed: %v", err)
+	}
+	var kinds []string
+	for _, event := range events {
+		kinds = append(kinds, event.Event)
+	}
+	if got := strings.Join(kinds, ","); got != "labeled,closed,reopened,closed" {
+		t.Errorf("Expected the tracked events of #1 in timeline order, got %s", got)
+	}
+	if paged, _ := GetIssueEvents(db, projectID, 2); len(paged) != 2 || paged[1].Event != "reopened" {
+		t.Errorf("Expected both pages of #2 to be stored, got %+v", paged)
+	}
+
+	// A sync without the option leaves the timeline alone
+	if _, err := syncProjectWithOptions(db, &GlobalConfig{Token: "test-token"}, project, SyncOptions{FullSync: true}); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("Expected the timeline to be fetched only with --include-timeline, got %d requests", requests)
+	}
+}
+
+func TestPrintIssueEventsAndSyncSummary(t *testing.T) {
+	var out bytes.Buffer
+	PrintIssueEvents(&out, []IssueEvent{
+		{Event: "labeled", Label: "bug", Actor: "alice", CreatedAt: "2024-03-01T10:00:00Z"},
+		{Event: "closed", CreatedAt: "2024-03-02T09:00:00Z"},
+	})
+	if !strings.Contains(out.String(), "labeled   bug  by alice") || !strings.Contains(out.String(), "2024-03-02T09:00:00Z  closed") {
+		t.Errorf("Unexpected events output:\n%s", out.String())
+	}
+
+	out.Reset()
+	PrintSyncResult(&out, &SyncResult{Projects: []ProjectSyncResult{{Owner: "o", Repo: "r", Updated: 2, TimelineEvents: 5}}})
+	if !strings.Contains(out.String(), "5 timeline events stored") {
+		t.Errorf("Expected the stored timeline events in the summary, got:\n%s", out.String())
+	}
+}
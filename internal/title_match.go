@@ -0,0 +1,65 @@
+package internal
+
+import (
+	"strings"
+	"unicode"
+)
+
+// MatchSettings controls how issues are matched against each other, e.g. by
+// 'pivot reconcile' and when merging CSV files
+type MatchSettings struct {
+	Normalize TitleNormalization `yaml:"normalize,omitempty"`
+}
+
+// TitleNormalization configures how titles are normalized before they are compared.
+// By default titles are lowercased, whitespace is collapsed and trailing punctuation
+// is stripped.
+type TitleNormalization struct {
+	KeepCase                bool     `yaml:"keep_case,omitempty"`                 // Compare titles case-sensitively
+	KeepWhitespace          bool     `yaml:"keep_whitespace,omitempty"`           // Only trim, do not collapse inner whitespace
+	KeepTrailingPunctuation bool     `yaml:"keep_trailing_punctuation,omitempty"` // Do not strip trailing punctuation such as "." or "!"
+	StripPrefixes           []string `yaml:"strip_prefixes,omitempty"`            // Leading prefixes to ignore, e.g. "[BUG]", matched ignoring case
+	StripBracketPrefixes    bool     `yaml:"strip_bracket_prefixes,omitempty"`    // Ignore any leading "[...]" tags
+}
+
+// trailingPunctuation is stripped from the end of titles; closing brackets and quotes are kept
+const trailingPunctuation = ".,;:!?…"
+
+// Normalize returns the form of title that is compared
+func (n TitleNormalization) Normalize(title string) string {
+	title = strings.TrimSpace(title)
+
+	// Prefixes can be stacked, e.g. "[BUG] [UI] Broken button"
+	for stripped := true; stripped; {
+		stripped = false
+		for _, prefix := range n.StripPrefixes {
+			prefix = strings.TrimSpace(prefix)
+			if prefix != "" && len(title) >= len(prefix) && strings.EqualFold(title[:len(prefix)], prefix) {
+				title = strings.TrimSpace(title[len(prefix):])
+				stripped = true
+			}
+		}
+		if n.StripBracketPrefixes && strings.HasPrefix(title, "[") {
+			if end := strings.Index(title, "]"); end > 0 {
+				title = strings.TrimSpace(title[end+1:])
+				stripped = true
+			}
+		}
+	}
+
+	if !n.KeepWhitespace {
+		title = strings.Join(strings.Fields(title), " ")
+	}
+	if !n.KeepTrailingPunctuation {
+		title = strings.TrimRightFunc(title, func(r rune) bool { return strings.ContainsRune(trailingPunctuation, r) || unicode.IsSpace(r) })
+	}
+	if !n.KeepCase {
+		title = strings.ToLower(title)
+	}
+	return title
+}
+
+// Match reports whether two titles are equal after normalization
+func (n TitleNormalization) Match(a, b string) bool {
+	return n.Normalize(a) == n.Normalize(b)
+}
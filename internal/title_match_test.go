@@ -0,0 +1,65 @@
+package internal
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestTitleNormalization_Normalize(t *testing.T) {
+	tests := []struct {
+		name          string
+		normalization TitleNormalization
+		title         string
+		want          string
+	}{
+		{name: "defaults", title: "  Fix   the Login\tPage!! ", want: "fix the login page"},
+		{name: "closing brackets kept", title: "Retry failed pushes (again).", want: "retry failed pushes (again)"},
+		{name: "keep case", normalization: TitleNormalization{KeepCase: true}, title: "Fix Login.", want: "Fix Login"},
+		{name: "keep whitespace", normalization: TitleNormalization{KeepWhitespace: true}, title: " Fix  login ", want: "fix  login"},
+		{name: "keep punctuation", normalization: TitleNormalization{KeepTrailingPunctuation: true}, title: "Fix login?", want: "fix login?"},
+		{name: "prefix", normalization: TitleNormalization{StripPrefixes: []string{"[BUG]"}}, title: "[bug] Fix login", want: "fix login"},
+		{name: "stacked prefixes", normalization: TitleNormalization{StripPrefixes: []string{"[BUG]", "WIP:"}}, title: "WIP: [BUG] Fix login", want: "fix login"},
+		{name: "unlisted prefix kept", normalization: TitleNormalization{StripPrefixes: []string{"[BUG]"}}, title: "[UI] Fix login", want: "[ui] fix login"},
+		{name: "bracket prefixes", normalization: TitleNormalization{StripBracketPrefixes: true}, title: "[UI][Auth]  Fix login", want: "fix login"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.normalization.Normalize(tt.title); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTitleNormalization_Match(t *testing.T) {
+	normalization := TitleNormalization{StripPrefixes: []string{"[BUG]"}}
+	pairs := [][2]string{
+		{"Fix login page", "fix   LOGIN page."},
+		{"[BUG] Fix login page", "Fix login page"},
+		{"  Fix login page  ", "Fix\tlogin page!"},
+	}
+	for _, pair := range pairs {
+		if !normalization.Match(pair[0], pair[1]) {
+			t.Errorf("Expected %q and %q to match", pair[0], pair[1])
+		}
+	}
+	if normalization.Match("Fix login page", "Fix logout page") {
+		t.Error("Expected different titles not to match")
+	}
+	if (TitleNormalization{KeepCase: true}).Match("Fix login", "fix login") {
+		t.Error("Expected titles differing in case not to match with keep_case")
+	}
+}
+
+func TestMatchSettingsFromYAML(t *testing.T) {
+	var config MultiProjectConfig
+	data := "match:\n  normalize:\n    strip_prefixes: [\"[BUG]\"]\n    keep_case: true\n"
+	if err := yaml.Unmarshal([]byte(data), &config); err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+	normalize := config.Match.Normalize
+	if !normalize.KeepCase || len(normalize.StripPrefixes) != 1 || normalize.StripPrefixes[0] != "[BUG]" {
+		t.Errorf("Expected keep_case and the [BUG] prefix, got %+v", normalize)
+	}
+}
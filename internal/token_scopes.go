@@ -0,0 +1,221 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// TokenScopeReport describes the identity and OAuth scopes of a GitHub token
+type TokenScopeReport struct {
+	Login  string
+	Scopes []string
+	// ScopesReported is false when GitHub omits the X-OAuth-Scopes header,
+	// which is the case for fine-grained personal access tokens
+	ScopesReported bool
+}
+
+// HasScope reports whether the token grants the given scope. The 'repo' scope
+// implies 'public_repo'.
+func (r *TokenScopeReport) HasScope(scope string) bool {
+	for _, s := range r.Scopes {
+		if s == scope || (scope == "public_repo" && s == "repo") {
+			return true
+		}
+	}
+	return false
+}
+
+// FetchTokenScopes calls the /user endpoint and reads the scopes granted to the token
+func FetchTokenScopes(token string) (*TokenScopeReport, error) {
+	if token == "" {
+		return nil, &GitHubCredentialError{
+			StatusCode: 401,
+			Message:    "No GitHub token provided",
+			Suggestion: "Run 'pivot init' to configure your GitHub token, or set it in config.yml",
+		}
+	}
+
+	req, err := http.NewRequest("GET", githubAPIURL+"/user", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "token "+token)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+	client := newHTTPClient()
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query token scopes: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &GitHubCredentialError{
+			StatusCode: resp.StatusCode,
+			Message:    "Invalid GitHub token",
+			Suggestion: "Your GitHub token is invalid or expired. Run 'pivot init' to update it, or check your config.yml file",
+		}
+	}
+
+	var user struct {
+		Login string `json:"login"`
+	}
+	if err := json.Unmarshal(body, &user); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal user response: %w", err)
+	}
+
+	report := &TokenScopeReport{Login: user.Login}
+	if header, ok := resp.Header[http.CanonicalHeaderKey("X-OAuth-Scopes")]; ok {
+		report.ScopesReported = true
+		for _, scope := range strings.Split(strings.Join(header, ","), ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				report.Scopes = append(report.Scopes, scope)
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// AuthenticatedLogin returns the login of the user the token belongs to
+func AuthenticatedLogin(token string) (string, error) {
+	report, err := FetchTokenScopes(token)
+	if err != nil {
+		return "", err
+	}
+	if report.Login == "" {
+		return "", fmt.Errorf("GitHub did not return a login for the token")
+	}
+	return report.Login, nil
+}
+
+// GetRepositoryVisibility reports whether a repository is private
+func GetRepositoryVisibility(owner, repo, token string) (bool, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s", githubAPIURL, owner, repo)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "token "+token)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+	client := newHTTPClient()
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to query repository: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("unexpected status code %d for repository %s/%s", resp.StatusCode, owner, repo)
+	}
+
+	var repository struct {
+		Private bool `json:"private"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&repository); err != nil {
+		return false, fmt.Errorf("failed to unmarshal repository response: %w", err)
+	}
+
+	return repository.Private, nil
+}
+
+// RequiredScopeForVisibility returns the classic token scope needed to work with issues in a repository
+func RequiredScopeForVisibility(private bool) string {
+	if private {
+		return "repo"
+	}
+	return "public_repo"
+}
+
+// ScopeGuidance returns an actionable message when the token lacks the scope
+// needed for a repository, or an empty string when the token is sufficient
+func ScopeGuidance(report *TokenScopeReport, owner, repo string, private bool) string {
+	if !report.ScopesReported {
+		return ""
+	}
+
+	required := RequiredScopeForVisibility(private)
+	if report.HasScope(required) {
+		return ""
+	}
+
+	visibility := "public"
+	if private {
+		visibility = "private"
+	}
+	return fmt.Sprintf("Token is missing the '%s' scope required for %s repository %s/%s. Create a new token with '%s' at https://github.com/settings/tokens",
+		required, visibility, owner, repo, required)
+}
+
+// DoctorToken inspects the scopes of each configured token and explains
+// whether they are sufficient for the configured projects
+func DoctorToken() error {
+	config, err := LoadMultiProjectConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	fmt.Println("🩺 GitHub Token Doctor")
+	fmt.Println("======================")
+
+	reports := make(map[string]*TokenScopeReport)
+	problems := 0
+
+	for _, project := range config.Projects {
+		fmt.Printf("\n📦 %s/%s\n", project.Owner, project.Repo)
+
+		token := project.GetEffectiveToken(&config.Global)
+		if token == "" {
+			fmt.Println("  ❌ No token configured for this project")
+			problems++
+			continue
+		}
+
+		report, ok := reports[token]
+		if !ok {
+			report, err = FetchTokenScopes(token)
+			if err != nil {
+				fmt.Printf("  ❌ Token check failed: %v\n", ScrubError(err))
+				problems++
+				continue
+			}
+			reports[token] = report
+		}
+
+		fmt.Printf("  Login: %s\n", report.Login)
+		if !report.ScopesReported {
+			fmt.Println("  Scopes: not reported (fine-grained token); check its repository and permission settings")
+			continue
+		}
+		fmt.Printf("  Scopes: %s\n", strings.Join(report.Scopes, ", "))
+
+		private, err := GetRepositoryVisibility(project.Owner, project.Repo, token)
+		if err != nil {
+			fmt.Printf("  ⚠ Could not determine repository visibility: %v\n", ScrubError(err))
+			private = true // Assume the stricter requirement
+		}
+
+		if guidance := ScopeGuidance(report, project.Owner, project.Repo, private); guidance != "" {
+			fmt.Printf("  ❌ %s\n", guidance)
+			problems++
+		} else {
+			fmt.Printf("  ✅ Token has the '%s' scope needed for this repository\n", RequiredScopeForVisibility(private))
+		}
+	}
+
+	fmt.Println()
+	if problems > 0 {
+		return fmt.Errorf("found %d token problem(s)", problems)
+	}
+	fmt.Println("🎉 All configured tokens have the required scopes")
+	return nil
+}
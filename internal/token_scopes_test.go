@@ -0,0 +1,172 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newScopeTestServer serves /user with the given scopes header and a repository with the given visibility
+func newScopeTestServer(t *testing.T, scopes *string, private bool) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/user":
+			if scopes != nil {
+				w.Header().Set("X-OAuth-Scopes", *scopes)
+			}
+			_, _ = w.Write([]byte(`{"login": "octocat"}`))
+		case "/repos/owner/repo":
+			if private {
+				_, _ = w.Write([]byte(`{"private": true}`))
+			} else {
+				_, _ = w.Write([]byte(`{"private": false}`))
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	oldURL := githubAPIURL
+	githubAPIURL = server.URL
+	t.Cleanup(func() {
+		githubAPIURL = oldURL
+		server.Close()
+	})
+	return server
+}
+
+func TestFetchTokenScopes_WithRepoScope(t *testing.T) {
+	scopes := "repo, read:org"
+	newScopeTestServer(t, &scopes, true)
+
+	report, err := FetchTokenScopes("test-token")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if report.Login != "octocat" {
+		t.Errorf("Expected login 'octocat', got '%s'", report.Login)
+	}
+	if !report.ScopesReported {
+		t.Error("Expected scopes to be reported")
+	}
+	if !report.HasScope("repo") || !report.HasScope("public_repo") || !report.HasScope("read:org") {
+		t.Errorf("Expected repo, public_repo and read:org scopes, got %v", report.Scopes)
+	}
+
+	private, err := GetRepositoryVisibility("owner", "repo", "test-token")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if guidance := ScopeGuidance(report, "owner", "repo", private); guidance != "" {
+		t.Errorf("Expected no guidance for sufficient scopes, got: %s", guidance)
+	}
+}
+
+func TestFetchTokenScopes_MissingRepoScope(t *testing.T) {
+	scopes := "public_repo"
+	newScopeTestServer(t, &scopes, true)
+
+	report, err := FetchTokenScopes("test-token")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	private, err := GetRepositoryVisibility("owner", "repo", "test-token")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !private {
+		t.Fatal("Expected repository to be private")
+	}
+
+	guidance := ScopeGuidance(report, "owner", "repo", private)
+	if !strings.Contains(guidance, "missing the 'repo' scope") {
+		t.Errorf("Expected guidance about missing 'repo' scope, got: %s", guidance)
+	}
+	if !strings.Contains(guidance, "private repository owner/repo") {
+		t.Errorf("Expected guidance to mention the private repository, got: %s", guidance)
+	}
+
+	// The same token is sufficient for public repositories
+	if guidance := ScopeGuidance(report, "owner", "repo", false); guidance != "" {
+		t.Errorf("Expected no guidance for public repository, got: %s", guidance)
+	}
+}
+
+func TestFetchTokenScopes_NoScopesHeader(t *testing.T) {
+	newScopeTestServer(t, nil, false)
+
+	report, err := FetchTokenScopes("fine-grained-token")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if report.ScopesReported {
+		t.Error("Expected scopes to be unreported for fine-grained token")
+	}
+	if guidance := ScopeGuidance(report, "owner", "repo", true); guidance != "" {
+		t.Errorf("Expected no scope guidance for fine-grained token, got: %s", guidance)
+	}
+}
+
+func TestFetchTokenScopes_EmptyToken(t *testing.T) {
+	if _, err := FetchTokenScopes(""); err == nil {
+		t.Error("Expected error for empty token")
+	}
+}
+
+func TestVerifyAuth_ValidTokenAndRepositories(t *testing.T) {
+	scopes := "repo, read:org"
+	newScopeTestServer(t, &scopes, false)
+
+	verification := VerifyAuth("test-token", []string{"owner/repo", "owner/missing"})
+	if !verification.TokenValid || verification.Login != "octocat" {
+		t.Errorf("Expected a valid token for octocat, got %+v", verification)
+	}
+	if len(verification.Scopes) != 2 || verification.Scopes[0] != "repo" || verification.Scopes[1] != "read:org" {
+		t.Errorf("Expected scopes [repo read:org], got %v", verification.Scopes)
+	}
+	if !verification.RepoAccess["owner/repo"] {
+		t.Error("Expected owner/repo to be accessible")
+	}
+	if accessible, ok := verification.RepoAccess["owner/missing"]; !ok || accessible {
+		t.Errorf("Expected owner/missing to be reported as not accessible, got %v (present: %v)", accessible, ok)
+	}
+	if verification.Passed() {
+		t.Error("Expected verification to fail when a repository is not accessible")
+	}
+	if len(verification.Errors) != 1 || !strings.HasPrefix(verification.Errors[0], "owner/missing: ") {
+		t.Errorf("Expected one error for owner/missing, got %v", verification.Errors)
+	}
+
+	if verification := VerifyAuth("test-token", []string{"owner/repo"}); !verification.Passed() {
+		t.Errorf("Expected verification to pass, got %+v", verification)
+	}
+}
+
+func TestVerifyAuth_InvalidToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	oldURL := githubAPIURL
+	githubAPIURL = server.URL
+	t.Cleanup(func() {
+		githubAPIURL = oldURL
+		server.Close()
+	})
+
+	verification := VerifyAuth("bad-token", []string{"owner/repo"})
+	if verification.TokenValid || verification.Passed() {
+		t.Errorf("Expected an invalid token, got %+v", verification)
+	}
+	if verification.Scopes == nil || len(verification.Scopes) != 0 {
+		t.Errorf("Expected an empty, non-nil scope list, got %#v", verification.Scopes)
+	}
+	if accessible, ok := verification.RepoAccess["owner/repo"]; !ok || accessible {
+		t.Errorf("Expected owner/repo to be reported as not accessible, got %v (present: %v)", accessible, ok)
+	}
+	if len(verification.Errors) == 0 {
+		t.Error("Expected the token error to be reported")
+	}
+}
@@ -0,0 +1,85 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// NormalizeToken trims a token read from a prompt, stdin or a file and rejects values
+// that cannot be a GitHub token
+func NormalizeToken(input string) (string, error) {
+	token := strings.TrimSpace(input)
+	if token == "" {
+		return "", fmt.Errorf("token is empty")
+	}
+	if strings.IndexFunc(token, unicode.IsSpace) >= 0 {
+		return "", fmt.Errorf("token must be a single line without spaces")
+	}
+	return token, nil
+}
+
+// SetToken stores a GitHub token in config.yml as the global token or, when project
+// (owner/repo) is set, as the token of that project. The token is registered as a
+// secret so it never shows up in error output.
+func SetToken(token, project string) error {
+	token, err := NormalizeToken(token)
+	if err != nil {
+		return err
+	}
+	RegisterSecret(token)
+
+	var owner, repo string
+	if project != "" {
+		var found bool
+		owner, repo, found = strings.Cut(project, "/")
+		if !found || owner == "" || repo == "" || strings.Contains(repo, "/") {
+			return fmt.Errorf("project must be in format 'owner/repo', got: %s", project)
+		}
+	}
+
+	err = UpdateMultiProjectConfig(func(config *MultiProjectConfig) error {
+		if project == "" {
+			config.Global.Token = token
+			return nil
+		}
+		found := false
+		for i := range config.Projects {
+			if strings.EqualFold(config.Projects[i].Owner, owner) && strings.EqualFold(config.Projects[i].Repo, repo) {
+				config.Projects[i].Token = token
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("project %s is not configured", project)
+		}
+		return nil
+	})
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w (run 'pivot init' first)", err)
+	}
+	return err
+}
+
+// CheckProjectTokens returns an error naming the projects that have no GitHub token:
+// neither their own nor a global one, no GitHub App and no override for this run
+func CheckProjectTokens(global *GlobalConfig, projects []ProjectConfig, override string) error {
+	if override != "" {
+		return nil
+	}
+
+	var missing []string
+	for i := range projects {
+		if projects[i].GetEffectiveToken(global) == "" {
+			missing = append(missing, projects[i].Owner+"/"+projects[i].Repo)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return fmt.Errorf("no GitHub token configured for %s; run 'pivot config set-token' to store a global token "+
+		"(or 'pivot config set-token --project owner/repo' for one project), configure global.github_app in config.yml, "+
+		"or pass --token for a single run", strings.Join(missing, ", "))
+}
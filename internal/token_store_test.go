@@ -0,0 +1,175 @@
+package internal
+
+import (
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+)
+
+// writeTokenTestConfig writes a config.yml with one project to the current directory
+func writeTokenTestConfig(t *testing.T) {
+	t.Helper()
+	content := `global:
+  database: ./pivot.db
+  token: old_token
+projects:
+  - owner: org
+    repo: alpha`
+	if err := os.WriteFile("config.yml", []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+}
+
+func TestNormalizeToken(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "ghp_abc123\n", want: "ghp_abc123"},
+		{input: "  ghp_abc123\r\n", want: "ghp_abc123"},
+		{input: "", wantErr: true},
+		{input: " \n", wantErr: true},
+		{input: "ghp_abc\nghp_def", wantErr: true},
+		{input: "ghp abc", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeToken(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Expected error for %q, got %q", tt.input, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("Expected %q for %q, got %q (err %v)", tt.want, tt.input, got, err)
+		}
+	}
+}
+
+func TestSetToken_Global(t *testing.T) {
+	chdirTemp(t)
+	writeTokenTestConfig(t)
+
+	if err := SetToken("ghp_newglobal\n", ""); err != nil {
+		t.Fatalf("SetToken failed: %v", err)
+	}
+
+	config, err := LoadMultiProjectConfig()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if config.Global.Token != "ghp_newglobal" {
+		t.Errorf("Expected global token ghp_newglobal, got %q", config.Global.Token)
+	}
+	if config.Projects[0].Token != "" {
+		t.Errorf("Expected project token to stay empty, got %q", config.Projects[0].Token)
+	}
+
+	info, err := os.Stat("config.yml")
+	if err != nil {
+		t.Fatalf("Failed to stat config: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Expected config.yml mode 0600, got %o", info.Mode().Perm())
+	}
+}
+
+func TestSetToken_Project(t *testing.T) {
+	chdirTemp(t)
+	writeTokenTestConfig(t)
+
+	if err := SetToken("ghp_project", "Org/Alpha"); err != nil {
+		t.Fatalf("SetToken failed: %v", err)
+	}
+
+	config, err := LoadMultiProjectConfig()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if config.Projects[0].Token != "ghp_project" {
+		t.Errorf("Expected project token ghp_project, got %q", config.Projects[0].Token)
+	}
+	if config.Global.Token != "old_token" {
+		t.Errorf("Expected global token to stay old_token, got %q", config.Global.Token)
+	}
+}
+
+func TestSetToken_Errors(t *testing.T) {
+	chdirTemp(t)
+	writeTokenTestConfig(t)
+
+	if err := SetToken("ghp_x", "org/missing"); err == nil || !strings.Contains(err.Error(), "not configured") {
+		t.Errorf("Expected not configured error, got %v", err)
+	}
+	if err := SetToken("ghp_x", "alpha"); err == nil || !strings.Contains(err.Error(), "owner/repo") {
+		t.Errorf("Expected format error, got %v", err)
+	}
+	if err := SetToken("  ", ""); err == nil {
+		t.Error("Expected error for an empty token")
+	}
+
+	data, err := os.ReadFile("config.yml")
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	if strings.Contains(string(data), "ghp_x") {
+		t.Errorf("Expected config.yml unchanged after errors, got:\n%s", data)
+	}
+}
+
+func TestCheckProjectTokens(t *testing.T) {
+	projects := []ProjectConfig{{Owner: "org", Repo: "a"}, {Owner: "org", Repo: "b", Token: "ghp_b"}}
+
+	err := CheckProjectTokens(&GlobalConfig{}, projects, "")
+	if err == nil || !strings.Contains(err.Error(), "no GitHub token configured for org/a;") || strings.Contains(err.Error(), "org/b") {
+		t.Errorf("Expected only org/a to be reported, got %v", err)
+	}
+	if err == nil || !strings.Contains(err.Error(), "pivot config set-token") || !strings.Contains(err.Error(), "--token") {
+		t.Errorf("Expected the error to explain how to configure a token, got %v", err)
+	}
+
+	for name, global := range map[string]*GlobalConfig{
+		"global token": {Token: "ghp_global"},
+		"github app":   {GitHubApp: &GitHubAppConfig{AppID: 1, InstallationID: 2, PrivateKeyPath: "app.pem"}},
+	} {
+		if err := CheckProjectTokens(global, projects, ""); err != nil {
+			t.Errorf("%s: expected every project to have a token, got %v", name, err)
+		}
+	}
+	if err := CheckProjectTokens(&GlobalConfig{}, projects, "ghp_override"); err != nil {
+		t.Errorf("Expected --token to cover every project, got %v", err)
+	}
+}
+
+func TestSyncWithoutToken_FailsBeforeNetwork(t *testing.T) {
+	chdirTemp(t)
+	content := `global:
+  database: ./pivot.db
+projects:
+  - owner: octo
+    repo: widgets
+`
+	if err := os.WriteFile("config.yml", []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	var requests int
+	server := newMockGitHubServer(t, "octo", "widgets", "[]", nil)
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+
+	result, err := SyncMultiProjectWithOptions("", SyncOptions{})
+	if err == nil || !strings.Contains(err.Error(), "no GitHub token configured for octo/widgets") {
+		t.Fatalf("Expected the missing token error, got %v (result %+v)", err, result)
+	}
+	if requests != 0 {
+		t.Errorf("Expected no request to GitHub, got %d", requests)
+	}
+	if _, err := os.Stat("pivot.db"); !os.IsNotExist(err) {
+		t.Errorf("Expected the database not to be created, got %v", err)
+	}
+}
@@ -0,0 +1,124 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Repository whose releases are checked by `pivot version --check`
+const (
+	releaseOwner = "rhino11"
+	releaseRepo  = "pivot"
+)
+
+// updateCheckTimeout keeps the check short when GitHub is unreachable
+const updateCheckTimeout = 5 * time.Second
+
+// UpdateInfo describes the result of comparing the running version to the latest release
+type UpdateInfo struct {
+	Current   string
+	Latest    string
+	Available bool // A newer release exists
+	DevBuild  bool // The running version is not a release and cannot be compared
+}
+
+// LatestReleaseTag returns the tag name of the latest published release of owner/repo
+func LatestReleaseTag(owner, repo string) (string, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/releases/latest", githubAPIURL, owner, repo)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+	client := newHTTPClient()
+	client.Timeout = updateCheckTimeout
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to reach GitHub: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("GitHub API error (status %d)", resp.StatusCode)
+	}
+
+	var release struct {
+		TagName string `json:"tag_name"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return "", fmt.Errorf("failed to decode release: %w", err)
+	}
+	if release.TagName == "" {
+		return "", fmt.Errorf("latest release has no tag")
+	}
+	return release.TagName, nil
+}
+
+// CheckForUpdate compares the running version with the latest pivot release
+func CheckForUpdate(current string) (*UpdateInfo, error) {
+	latest, err := LatestReleaseTag(releaseOwner, releaseRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &UpdateInfo{Current: current, Latest: latest}
+	if _, ok := parseVersion(current); !ok {
+		info.DevBuild = true
+		return info, nil
+	}
+	info.Available = CompareVersions(latest, current) > 0
+	return info, nil
+}
+
+// CompareVersions compares two versions such as v1.2.3 and returns -1, 0 or 1.
+// Unparseable versions sort before parseable ones.
+func CompareVersions(a, b string) int {
+	va, okA := parseVersion(a)
+	vb, okB := parseVersion(b)
+	switch {
+	case !okA && !okB:
+		return 0
+	case !okA:
+		return -1
+	case !okB:
+		return 1
+	}
+
+	for i := 0; i < 3; i++ {
+		if va[i] != vb[i] {
+			if va[i] < vb[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// parseVersion parses major.minor.patch, ignoring a leading v and any
+// pre-release or build suffix
+func parseVersion(version string) ([3]int, bool) {
+	var parts [3]int
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+
+	fields := strings.Split(version, ".")
+	if len(fields) == 0 || len(fields) > 3 {
+		return parts, false
+	}
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return parts, false
+		}
+		parts[i] = n
+	}
+	return parts, true
+}
@@ -0,0 +1,94 @@
+package internal
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"v1.2.3", "1.2.3", 0},
+		{"v1.10.0", "v1.9.9", 1},
+		{"v1.2", "v1.2.1", -1},
+		{"v2.0.0-rc1", "v1.9.0", 1},
+		{"dev", "v0.0.1", -1},
+		{"v1.0.0", "dev", 1},
+	}
+
+	for _, tt := range tests {
+		if got := CompareVersions(tt.a, tt.b); got != tt.expected {
+			t.Errorf("CompareVersions(%q, %q): expected %d, got %d", tt.a, tt.b, tt.expected, got)
+		}
+	}
+}
+
+func mockLatestRelease(t *testing.T, tag string) {
+	t.Helper()
+	newMockGitHubServer(t, "rhino11", "pivot", "[]", map[string]http.HandlerFunc{
+		"/repos/rhino11/pivot/releases/latest": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"tag_name": "` + tag + `", "name": "Pivot ` + tag + `"}`))
+		},
+	})
+}
+
+func TestCheckForUpdate_UpToDate(t *testing.T) {
+	mockLatestRelease(t, "v1.4.0")
+
+	info, err := CheckForUpdate("v1.4.0")
+	if err != nil {
+		t.Fatalf("CheckForUpdate failed: %v", err)
+	}
+	if info.Available {
+		t.Error("Expected no update for the latest version")
+	}
+	if info.Latest != "v1.4.0" {
+		t.Errorf("Expected latest v1.4.0, got %s", info.Latest)
+	}
+}
+
+func TestCheckForUpdate_Outdated(t *testing.T) {
+	mockLatestRelease(t, "v1.5.0")
+
+	info, err := CheckForUpdate("1.4.2")
+	if err != nil {
+		t.Fatalf("CheckForUpdate failed: %v", err)
+	}
+	if !info.Available {
+		t.Error("Expected an update to be available")
+	}
+	if info.DevBuild {
+		t.Error("Expected release version not to be treated as a dev build")
+	}
+}
+
+func TestCheckForUpdate_DevBuild(t *testing.T) {
+	mockLatestRelease(t, "v1.5.0")
+
+	info, err := CheckForUpdate("dev")
+	if err != nil {
+		t.Fatalf("CheckForUpdate failed: %v", err)
+	}
+	if !info.DevBuild || info.Available {
+		t.Errorf("Expected dev build without update notice, got %+v", info)
+	}
+}
+
+func TestCheckForUpdate_Offline(t *testing.T) {
+	server := newMockGitHubServer(t, "rhino11", "pivot", "[]", nil)
+	server.Close()
+
+	if _, err := CheckForUpdate("v1.0.0"); err == nil {
+		t.Error("Expected error when GitHub is unreachable")
+	}
+}
+
+func TestLatestReleaseTag_NoRelease(t *testing.T) {
+	newMockGitHubServer(t, "rhino11", "pivot", "[]", nil)
+
+	if _, err := LatestReleaseTag("rhino11", "pivot"); err == nil {
+		t.Error("Expected error when the repository has no releases")
+	}
+}